@@ -11,6 +11,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/base"
@@ -18,6 +19,7 @@ import (
 	"github.com/juju/juju/charmstore"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/storage"
 )
 
@@ -176,10 +178,18 @@ type SetCharmConfig struct {
 	// update during the upgrade. This field is only understood by Application
 	// facade version 2 and greater.
 	StorageConstraints map[string]storage.Constraints `json:"storage-constraints,omitempty"`
+
+	// RollingUpgrade, if not nil, causes the charm to be upgraded as
+	// a rolling upgrade. This field is only understood by Application
+	// facade version 4 and greater.
+	RollingUpgrade *params.RollingUpgradeParams
 }
 
 // SetCharm sets the charm for a given service.
 func (c *Client) SetCharm(cfg SetCharmConfig) error {
+	if cfg.RollingUpgrade != nil && c.BestAPIVersion() < 4 {
+		return errors.NotSupportedf("rolling upgrades")
+	}
 	var storageConstraints map[string]params.StorageConstraints
 	if len(cfg.StorageConstraints) > 0 {
 		storageConstraints = make(map[string]params.StorageConstraints)
@@ -209,6 +219,7 @@ func (c *Client) SetCharm(cfg SetCharmConfig) error {
 		ForceUnits:         cfg.ForceUnits,
 		ResourceIDs:        cfg.ResourceIDs,
 		StorageConstraints: storageConstraints,
+		RollingUpgrade:     cfg.RollingUpgrade,
 	}
 	return c.facade.FacadeCall("SetCharm", args, nil)
 }
@@ -246,6 +257,20 @@ func (c *Client) Destroy(application string) error {
 	return c.facade.FacadeCall("Destroy", params, nil)
 }
 
+// DestroyReleasingStorage destroys a given application, releasing
+// its cloud volumes rather than destroying them, so that they may
+// later be imported into another model.
+func (c *Client) DestroyReleasingStorage(application string) error {
+	if c.BestAPIVersion() < 5 {
+		return errors.NotSupportedf("releasing storage on this version of Juju")
+	}
+	params := params.ApplicationDestroy{
+		ApplicationName: application,
+		ReleaseStorage:  true,
+	}
+	return c.facade.FacadeCall("Destroy", params, nil)
+}
+
 // GetConstraints returns the constraints for the given application.
 func (c *Client) GetConstraints(service string) (constraints.Value, error) {
 	results := new(params.GetConstraintsResults)
@@ -269,6 +294,21 @@ func (c *Client) Expose(application string) error {
 	return c.facade.FacadeCall("Expose", params, nil)
 }
 
+// ExposeTo changes the juju-managed firewall to expose any ports that
+// were also explicitly marked by units as open, allowing access only
+// from the specified CIDRs and the subnets of the specified spaces.
+func (c *Client) ExposeTo(application string, cidrs, spaces []string) error {
+	if c.BestAPIVersion() < 6 {
+		return errors.NotSupportedf("restricting exposed applications on this version of Juju")
+	}
+	params := params.ApplicationExpose{
+		ApplicationName: application,
+		ToCIDRs:         cidrs,
+		ToSpaces:        spaces,
+	}
+	return c.facade.FacadeCall("Expose", params, nil)
+}
+
 // Unexpose changes the juju-managed firewall to unexpose any ports that
 // were also explicitly marked by units as open.
 func (c *Client) Unexpose(application string) error {
@@ -345,3 +385,171 @@ func (c *Client) Consume(remoteApplication, alias string) (string, error) {
 	}
 	return consumeRes.Results[0].LocalName, nil
 }
+
+// HookTimeouts returns the maximum durations that the hooks of the
+// specified application may run for.
+func (c *Client) HookTimeouts(application string) (params.HookTimeouts, error) {
+	if c.BestAPIVersion() < 4 {
+		return params.HookTimeouts{}, errors.NotSupportedf("hook timeouts")
+	}
+	var results params.HookTimeoutsResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewApplicationTag(application).String()}},
+	}
+	if err := c.facade.FacadeCall("HookTimeouts", args, &results); err != nil {
+		return params.HookTimeouts{}, errors.Trace(err)
+	}
+	if resultLen := len(results.Results); resultLen != 1 {
+		return params.HookTimeouts{}, errors.Errorf("expected 1 result, got %d", resultLen)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return params.HookTimeouts{}, errors.Trace(err)
+	}
+	return results.Results[0].Result, nil
+}
+
+// SetHookTimeouts replaces the maximum durations that the hooks of the
+// specified application may run for.
+func (c *Client) SetHookTimeouts(application string, timeouts params.HookTimeouts) error {
+	if c.BestAPIVersion() < 4 {
+		return errors.NotSupportedf("hook timeouts")
+	}
+	args := params.ApplicationHookTimeoutsArgs{
+		Args: []params.ApplicationHookTimeouts{{
+			ApplicationName: application,
+			Timeouts:        timeouts,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("SetHookTimeouts", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(results.OneError())
+}
+
+// EgressRules returns the destinations to which the units of the
+// specified application are allowed to connect.
+func (c *Client) EgressRules(application string) ([]network.EgressRule, error) {
+	if c.BestAPIVersion() < 7 {
+		return nil, errors.NotSupportedf("egress rules on this version of Juju")
+	}
+	var results params.EgressRulesResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewApplicationTag(application).String()}},
+	}
+	if err := c.facade.FacadeCall("EgressRules", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if resultLen := len(results.Results); resultLen != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", resultLen)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, errors.Trace(err)
+	}
+	rules := make([]network.EgressRule, len(results.Results[0].Rules))
+	for i, in := range results.Results[0].Rules {
+		rule, err := network.ParseEgressRule(in)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		rules[i] = rule
+	}
+	return rules, nil
+}
+
+// SetEgressRules replaces the destinations to which the units of the
+// specified application are allowed to connect. If no rules are
+// given, egress is no longer restricted.
+func (c *Client) SetEgressRules(application string, rules []network.EgressRule) error {
+	if c.BestAPIVersion() < 7 {
+		return errors.NotSupportedf("egress rules on this version of Juju")
+	}
+	arg := params.ApplicationEgressRules{ApplicationName: application}
+	for _, rule := range rules {
+		arg.Rules = append(arg.Rules, rule.String())
+	}
+	args := params.ApplicationEgressRulesArgs{
+		Args: []params.ApplicationEgressRules{arg},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("SetEgressRules", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(results.OneError())
+}
+
+// RollingUpgrade returns the rolling charm upgrade in progress for the
+// specified application, or nil if there is none.
+func (c *Client) RollingUpgrade(application string) (*params.RollingUpgrade, error) {
+	if c.BestAPIVersion() < 4 {
+		return nil, errors.NotSupportedf("rolling upgrades")
+	}
+	var results params.RollingUpgradeResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewApplicationTag(application).String()}},
+	}
+	if err := c.facade.FacadeCall("RollingUpgrades", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if resultLen := len(results.Results); resultLen != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", resultLen)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results.Results[0].Result, nil
+}
+
+// ReleaseRollingUpgradeUnits releases the specified units of the
+// application to its rolling charm upgrade.
+func (c *Client) ReleaseRollingUpgradeUnits(application string, units ...string) error {
+	if c.BestAPIVersion() < 4 {
+		return errors.NotSupportedf("rolling upgrades")
+	}
+	args := params.ReleaseRollingUpgradeUnitsArgs{
+		Args: []params.ReleaseRollingUpgradeUnits{{
+			ApplicationName: application,
+			Units:           units,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("ReleaseRollingUpgradeUnits", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(results.OneError())
+}
+
+// PauseRollingUpgrade records that the rolling charm upgrade of the
+// specified application was paused for the given reason.
+func (c *Client) PauseRollingUpgrade(application, reason string) error {
+	if c.BestAPIVersion() < 4 {
+		return errors.NotSupportedf("rolling upgrades")
+	}
+	args := params.PauseRollingUpgradeArgs{
+		Args: []params.PauseRollingUpgrade{{
+			ApplicationName: application,
+			Reason:          reason,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("PauseRollingUpgrade", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(results.OneError())
+}
+
+// FinishRollingUpgrade ends the rolling charm upgrade of the specified
+// application.
+func (c *Client) FinishRollingUpgrade(application string) error {
+	if c.BestAPIVersion() < 4 {
+		return errors.NotSupportedf("rolling upgrades")
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewApplicationTag(application).String()}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("FinishRollingUpgrade", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(results.OneError())
+}
@@ -4,6 +4,8 @@
 package application_test
 
 import (
+	"time"
+
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable"
@@ -15,6 +17,8 @@ import (
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/instance"
 	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/storage"
 )
 
@@ -76,6 +80,152 @@ func (s *applicationSuite) TestSetServiceMetricCredentialsNoMocks(c *gc.C) {
 	c.Assert(application.MetricCredentials(), gc.DeepEquals, []byte("creds"))
 }
 
+func (s *applicationSuite) TestDestroyReleasingStorage(c *gc.C) {
+	var called bool
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "Destroy")
+		c.Assert(a, jc.DeepEquals, params.ApplicationDestroy{
+			ApplicationName: "mysql",
+			ReleaseStorage:  true,
+		})
+		return nil
+	})
+	err := s.client.DestroyReleasingStorage("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestExposeTo(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	err := s.client.ExposeTo(application.Name(), []string{"10.0.0.0/8"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = application.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(application.IsExposed(), jc.IsTrue)
+	c.Assert(application.ExposedCIDRs(), jc.DeepEquals, []string{"10.0.0.0/8"})
+}
+
+func (s *applicationSuite) TestHookTimeouts(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	timeouts := params.HookTimeouts{
+		Default: time.Minute,
+		Hooks:   map[string]time.Duration{"install": time.Hour},
+	}
+	err := s.client.SetHookTimeouts(application.Name(), timeouts)
+	c.Assert(err, jc.ErrorIsNil)
+	err = application.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(application.HookTimeouts(), jc.DeepEquals, state.HookTimeouts{
+		Default: time.Minute,
+		Hooks:   map[string]time.Duration{"install": time.Hour},
+	})
+
+	result, err := s.client.HookTimeouts(application.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, timeouts)
+}
+
+func (s *applicationSuite) TestSetHookTimeoutsFails(c *gc.C) {
+	application.PatchFacadeCall(s, s.client, func(request string, args, response interface{}) error {
+		c.Assert(request, gc.Equals, "SetHookTimeouts")
+		result := response.(*params.ErrorResults)
+		result.Results = make([]params.ErrorResult, 1)
+		result.Results[0].Error = common.ServerError(common.ErrPerm)
+		return nil
+	})
+	err := s.client.SetHookTimeouts("application", params.HookTimeouts{Default: time.Minute})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *applicationSuite) TestEgressRules(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	rules := []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+		network.MustNewEgressRule("udp", 53, 53, "10.0.0.0/8"),
+	}
+	err := s.client.SetEgressRules(application.Name(), rules)
+	c.Assert(err, jc.ErrorIsNil)
+	err = application.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	stored, err := application.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stored, jc.DeepEquals, rules)
+
+	result, err := s.client.EgressRules(application.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, rules)
+}
+
+func (s *applicationSuite) TestSetEgressRulesFails(c *gc.C) {
+	application.PatchFacadeCall(s, s.client, func(request string, args, response interface{}) error {
+		c.Assert(request, gc.Equals, "SetEgressRules")
+		result := response.(*params.ErrorResults)
+		result.Results = make([]params.ErrorResult, 1)
+		result.Results[0].Error = common.ServerError(common.ErrPerm)
+		return nil
+	})
+	err := s.client.SetEgressRules("application", nil)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *applicationSuite) TestRollingUpgrade(c *gc.C) {
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		c.Assert(request, gc.Equals, "RollingUpgrades")
+		c.Assert(a, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "application-mysql"}},
+		})
+		result := response.(*params.RollingUpgradeResults)
+		result.Results = []params.RollingUpgradeResult{{
+			Result: &params.RollingUpgrade{
+				CharmURL:         "cs:mysql-2",
+				PreviousCharmURL: "cs:mysql-1",
+				BatchSize:        1,
+			},
+		}}
+		return nil
+	})
+	upgrade, err := s.client.RollingUpgrade("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(upgrade, jc.DeepEquals, &params.RollingUpgrade{
+		CharmURL:         "cs:mysql-2",
+		PreviousCharmURL: "cs:mysql-1",
+		BatchSize:        1,
+	})
+}
+
+func (s *applicationSuite) TestReleaseRollingUpgradeUnits(c *gc.C) {
+	var called bool
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "ReleaseRollingUpgradeUnits")
+		c.Assert(a, jc.DeepEquals, params.ReleaseRollingUpgradeUnitsArgs{
+			Args: []params.ReleaseRollingUpgradeUnits{{
+				ApplicationName: "mysql",
+				Units:           []string{"mysql/0", "mysql/1"},
+			}},
+		})
+		result := response.(*params.ErrorResults)
+		result.Results = make([]params.ErrorResult, 1)
+		return nil
+	})
+	err := s.client.ReleaseRollingUpgradeUnits("mysql", "mysql/0", "mysql/1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestFinishRollingUpgradeFails(c *gc.C) {
+	application.PatchFacadeCall(s, s.client, func(request string, args, response interface{}) error {
+		c.Assert(request, gc.Equals, "FinishRollingUpgrade")
+		result := response.(*params.ErrorResults)
+		result.Results = make([]params.ErrorResult, 1)
+		result.Results[0].Error = common.ServerError(common.ErrPerm)
+		return nil
+	})
+	err := s.client.FinishRollingUpgrade("mysql")
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
 func (s *applicationSuite) TestSetServiceDeploy(c *gc.C) {
 	var called bool
 	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
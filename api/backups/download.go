@@ -20,16 +20,26 @@ type downloadParams struct {
 
 // Download returns an io.ReadCloser for the given backup id.
 func (c *Client) Download(id string) (io.ReadCloser, error) {
+	return c.download(params.BackupsDownloadArgs{ID: id})
+}
+
+// DownloadModel returns an io.ReadCloser for an archive of the given
+// model, as it was when the backup with the given id was made. The
+// archive is in the format read by migration.ReadModelArchive. The
+// model is identified by its UUID, its name, or its owner and name as
+// "owner/name". The controller extracts the model before it responds,
+// which can take several minutes for a large backup.
+func (c *Client) DownloadModel(id, model string) (io.ReadCloser, error) {
+	if c.BestAPIVersion() < 3 {
+		return nil, errors.NotSupportedf("downloading a model from a backup")
+	}
+	return c.download(params.BackupsDownloadArgs{ID: id, Model: model})
+}
+
+func (c *Client) download(args params.BackupsDownloadArgs) (io.ReadCloser, error) {
 	// Send the request.
 	var resp *http.Response
-	err := c.client.Call(
-		&downloadParams{
-			Body: params.BackupsDownloadArgs{
-				ID: id,
-			},
-		},
-		&resp,
-	)
+	err := c.client.Call(&downloadParams{Body: args}, &resp)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -50,3 +50,10 @@ func (s *downloadSuite) TestFailedRequest(c *gc.C) {
 	c.Assert(err, jc.Satisfies, params.IsCodeNotFound)
 	c.Assert(resultArchive, gc.Equals, nil)
 }
+
+func (s *downloadSuite) TestDownloadModelFailedRequest(c *gc.C) {
+	resultArchive, err := s.client.DownloadModel("unknown", "admin/controller")
+	c.Assert(err, gc.ErrorMatches, `.*backup metadata "unknown" not found$`)
+	c.Assert(err, jc.Satisfies, params.IsCodeNotFound)
+	c.Assert(resultArchive, gc.Equals, nil)
+}
@@ -81,7 +81,7 @@ func (c *Client) RestoreReader(r io.ReadSeeker, meta *params.BackupsMetadataResu
 		logger.Errorf("could not clean up after failed backup upload: %v", finishErr)
 		return errors.Annotatef(err, "cannot upload backup file")
 	}
-	return c.restore(backupId, newClient)
+	return c.restore(params.RestoreArgs{BackupId: backupId}, newClient)
 }
 
 // Restore performs restore using a backup id corresponding to a backup stored in the server.
@@ -90,7 +90,21 @@ func (c *Client) Restore(backupId string, newClient ClientConnection) error {
 		return errors.Trace(err)
 	}
 	logger.Debugf("Server in 'about to restore' mode")
-	return c.restore(backupId, newClient)
+	return c.restore(params.RestoreArgs{BackupId: backupId}, newClient)
+}
+
+// RestoreToTime performs restore using a backup id corresponding to a
+// backup stored in the server, then replays the operations the server
+// archived from its oplog up to toTime.
+func (c *Client) RestoreToTime(backupId string, toTime time.Time, newClient ClientConnection) error {
+	if c.BestAPIVersion() < 2 {
+		return errors.NotSupportedf("restoring to a point in time")
+	}
+	if err := prepareRestore(newClient); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Debugf("Server in 'about to restore' mode")
+	return c.restore(params.RestoreArgs{BackupId: backupId, ToTime: &toTime}, newClient)
 }
 
 func restoreAttempt(client *Client, restoreArgs params.RestoreArgs) (error, error) {
@@ -103,20 +117,15 @@ func restoreAttempt(client *Client, restoreArgs params.RestoreArgs) (error, erro
 // restore is responsible for triggering the whole restore process in a remote
 // machine. The backup information for the process should already be in the
 // server and loaded in the backup storage under the backupId id.
-// It takes backupId as the identifier for the remote backup file and a
+// It takes restoreArgs identifying the remote backup file and a
 // client connection factory newClient (newClient should no longer be
 // necessary when lp:1399722 is sorted out).
-func (c *Client) restore(backupId string, newClient ClientConnection) error {
+func (c *Client) restore(restoreArgs params.RestoreArgs, newClient ClientConnection) error {
 	var err, remoteError error
 
-	// Restore
-	restoreArgs := params.RestoreArgs{
-		BackupId: backupId,
-	}
-
 	cleanExit := false
 	for a := restoreStrategy.Start(); a.Next(); {
-		logger.Debugf("Attempting Restore of %q", backupId)
+		logger.Debugf("Attempting Restore of %q", restoreArgs.BackupId)
 		var restoreClient *Client
 		restoreClient, err = newClient()
 		if err != nil {
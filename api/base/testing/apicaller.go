@@ -53,6 +53,18 @@ func (APICallerFunc) ConnectControllerStream(path string, attrs url.Values, head
 	return nil, errors.NotImplementedf("controller stream connection")
 }
 
+// BestVersionCaller is an APICallerFunc that reports the specified
+// facade version as the best supported.
+type BestVersionCaller struct {
+	APICallerFunc
+	BestVersion int
+}
+
+// BestFacadeVersion implements base.APICaller.
+func (c BestVersionCaller) BestFacadeVersion(facade string) int {
+	return c.BestVersion
+}
+
 // CheckArgs holds the possible arguments to CheckingAPICaller(). Any
 // fields non empty fields will be checked to match the arguments
 // recieved by the APICall() method of the returned APICallerFunc. If
@@ -421,6 +421,11 @@ func (c *Client) OpenCharm(curl *charm.URL) (io.ReadCloser, error) {
 	return c.OpenURI("/charms", query)
 }
 
+// OpenResource streams out the content of an application's resource.
+func (c *Client) OpenResource(application, name string) (io.ReadCloser, error) {
+	return c.OpenURI(fmt.Sprintf("/applications/%s/resources/%s", application, name), nil)
+}
+
 // OpenURI performs a GET on a Juju HTTP endpoint returning the
 func (c *Client) OpenURI(uri string, query url.Values) (io.ReadCloser, error) {
 	// The returned httpClient sets the base url to /model/<uuid> if it can.
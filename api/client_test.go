@@ -281,6 +281,14 @@ func (s *clientSuite) TestOpenCharmMissing(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, `.*cannot get charm from state: charm "cs:quantal/spam-3" not found`)
 }
 
+func (s *clientSuite) TestOpenResourceMissing(c *gc.C) {
+	app := s.Factory.MakeApplication(c, nil)
+	client := s.APIState.Client()
+
+	_, err := client.OpenResource(app.Name(), "spam")
+	c.Check(err, gc.ErrorMatches, `.*not found.*`)
+}
+
 func addLocalCharm(c *gc.C, client *api.Client, name string) (*charm.URL, *charm.CharmArchive) {
 	charmArchive := testcharms.Repo.CharmArchive(c.MkDir(), name)
 	curl := charm.MustParseURL(fmt.Sprintf("local:quantal/%s-%d", charmArchive.Meta().Name, charmArchive.Revision()))
@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/api/common"
 	"github.com/juju/juju/api/common/cloudspec"
 	"github.com/juju/juju/apiserver/params"
+	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/permission"
 )
@@ -214,6 +215,19 @@ func (c *Client) GetControllerAccess(user string) (permission.Access, error) {
 	return permission.Access(results.Results[0].Result.Access), nil
 }
 
+// ControllerHealth returns a report on the health of the controller,
+// gathered from all of its machines.
+func (c *Client) ControllerHealth() (params.ControllerHealth, error) {
+	var result params.ControllerHealth
+	if c.BestAPIVersion() < 7 {
+		return result, errors.NotSupportedf("ControllerHealth")
+	}
+	if err := c.facade.FacadeCall("ControllerHealth", nil, &result); err != nil {
+		return result, errors.Trace(err)
+	}
+	return result, nil
+}
+
 // MigrationSpec holds the details required to start the migration of
 // a single model.
 type MigrationSpec struct {
@@ -226,6 +240,20 @@ type MigrationSpec struct {
 	TargetMacaroons      []macaroon.Slice
 	ExternalControl      bool
 	SkipInitialPrechecks bool
+
+	// TargetCloud, TargetCloudRegion and TargetCredential remap the
+	// model's cloud details on the target controller. They are
+	// empty if the model keeps the cloud details it has on the
+	// source controller. TargetCredential holds a cloud credential
+	// ID of the form "cloud/owner/name".
+	TargetCloud       string
+	TargetCloudRegion string
+	TargetCredential  string
+
+	// Queue indicates that the migration should wait until the
+	// controller's limit on concurrent migrations allows it to
+	// start.
+	Queue bool
 }
 
 // Validate performs sanity checks on the migration configuration it
@@ -249,40 +277,44 @@ func (s *MigrationSpec) Validate() error {
 	if s.TargetPassword == "" && len(s.TargetMacaroons) == 0 {
 		return errors.NotValidf("missing authentication secrets")
 	}
-	return nil
+	if s.Queue && s.ExternalControl {
+		return errors.NotValidf("queued migration with external control")
+	}
+	remap, err := s.cloudRemap()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(remap.Validate())
+}
+
+func (s *MigrationSpec) hasCloudRemap() bool {
+	return s.TargetCloud != "" || s.TargetCloudRegion != "" || s.TargetCredential != ""
+}
+
+func (s *MigrationSpec) cloudRemap() (coremigration.CloudRemap, error) {
+	remap := coremigration.CloudRemap{
+		Cloud:       s.TargetCloud,
+		CloudRegion: s.TargetCloudRegion,
+	}
+	if s.TargetCredential != "" {
+		if !names.IsValidCloudCredential(s.TargetCredential) {
+			return remap, errors.NotValidf("target credential %q", s.TargetCredential)
+		}
+		remap.Credential = names.NewCloudCredentialTag(s.TargetCredential)
+	}
+	return remap, nil
 }
 
 // InitiateMigration attempts to start a migration for the specified
 // model, returning the migration's ID.
-//
-// The API server supports starting multiple migrations in one request
-// but we don't need that at the client side yet (and may never) so
-// this call just supports starting one migration at a time.
 func (c *Client) InitiateMigration(spec MigrationSpec) (string, error) {
-	if err := spec.Validate(); err != nil {
+	if err := c.checkSpecSupported(spec); err != nil {
 		return "", errors.Trace(err)
 	}
-
-	macsJSON, err := macaroonsToJSON(spec.TargetMacaroons)
+	args, err := initiateMigrationArgs(spec)
 	if err != nil {
 		return "", errors.Trace(err)
 	}
-
-	args := params.InitiateMigrationArgs{
-		Specs: []params.MigrationSpec{{
-			ModelTag: names.NewModelTag(spec.ModelUUID).String(),
-			TargetInfo: params.MigrationTargetInfo{
-				ControllerTag: names.NewControllerTag(spec.TargetControllerUUID).String(),
-				Addrs:         spec.TargetAddrs,
-				CACert:        spec.TargetCACert,
-				AuthTag:       names.NewUserTag(spec.TargetUser).String(),
-				Password:      spec.TargetPassword,
-				Macaroons:     string(macsJSON),
-			},
-			ExternalControl:      spec.ExternalControl,
-			SkipInitialPrechecks: spec.SkipInitialPrechecks,
-		}},
-	}
 	response := params.InitiateMigrationResults{}
 	if err := c.facade.FacadeCall("InitiateMigration", args, &response); err != nil {
 		return "", errors.Trace(err)
@@ -297,6 +329,159 @@ func (c *Client) InitiateMigration(spec MigrationSpec) (string, error) {
 	return result.MigrationId, nil
 }
 
+// InitiateMigrationResult holds the outcome of an attempt to start
+// the migration of one model.
+type InitiateMigrationResult struct {
+	ModelUUID   string
+	MigrationId string
+	Error       error
+}
+
+// InitiateMigrations attempts to start migrations for the specified
+// models in a single request. A migration which can't be started
+// doesn't prevent the others from starting; its error is reported in
+// the corresponding result instead.
+func (c *Client) InitiateMigrations(specs []MigrationSpec) ([]InitiateMigrationResult, error) {
+	results := make([]InitiateMigrationResult, len(specs))
+	var args params.InitiateMigrationArgs
+	var sent []int
+	for i, spec := range specs {
+		results[i].ModelUUID = spec.ModelUUID
+		if err := c.checkSpecSupported(spec); err != nil {
+			return nil, errors.Trace(err)
+		}
+		arg, err := migrationSpecArg(spec)
+		if err != nil {
+			results[i].Error = errors.Trace(err)
+			continue
+		}
+		args.Specs = append(args.Specs, arg)
+		sent = append(sent, i)
+	}
+	if len(args.Specs) == 0 {
+		return results, nil
+	}
+
+	response := params.InitiateMigrationResults{}
+	if err := c.facade.FacadeCall("InitiateMigration", args, &response); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(response.Results) != len(args.Specs) {
+		return nil, errors.New("unexpected number of results returned")
+	}
+	for j, result := range response.Results {
+		out := &results[sent[j]]
+		if result.Error != nil {
+			out.Error = result.Error
+		} else {
+			out.MigrationId = result.MigrationId
+		}
+	}
+	return results, nil
+}
+
+// checkSpecSupported returns a NotSupported error if the controller
+// is too old to honour everything asked for in spec.
+func (c *Client) checkSpecSupported(spec MigrationSpec) error {
+	if spec.hasCloudRemap() && c.BestAPIVersion() < 5 {
+		return errors.NotSupportedf("cloud remapping")
+	}
+	if spec.Queue && c.BestAPIVersion() < 6 {
+		return errors.NotSupportedf("queued migrations")
+	}
+	return nil
+}
+
+// MigrationPrechecks runs the source and target prechecks for the
+// migration described by spec without starting it. Every problem
+// found is returned, rather than just the first.
+func (c *Client) MigrationPrechecks(spec MigrationSpec) (coremigration.PrecheckReport, error) {
+	var report coremigration.PrecheckReport
+	if c.BestAPIVersion() < 4 {
+		return report, errors.NotSupportedf("MigrationPrechecks")
+	}
+	if spec.hasCloudRemap() && c.BestAPIVersion() < 5 {
+		return report, errors.NotSupportedf("cloud remapping")
+	}
+	args, err := initiateMigrationArgs(spec)
+	if err != nil {
+		return report, errors.Trace(err)
+	}
+	response := params.MigrationPrecheckResults{}
+	if err := c.facade.FacadeCall("MigrationPrechecks", args, &response); err != nil {
+		return report, errors.Trace(err)
+	}
+	if len(response.Results) != 1 {
+		return report, errors.New("unexpected number of results returned")
+	}
+	result := response.Results[0]
+	if result.Error != nil {
+		return report, errors.Trace(result.Error)
+	}
+	report.Source = precheckProblemsFromParams(result.Source)
+	report.Target = precheckProblemsFromParams(result.Target)
+	return report, nil
+}
+
+func initiateMigrationArgs(spec MigrationSpec) (params.InitiateMigrationArgs, error) {
+	arg, err := migrationSpecArg(spec)
+	if err != nil {
+		return params.InitiateMigrationArgs{}, errors.Trace(err)
+	}
+	return params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{arg},
+	}, nil
+}
+
+func migrationSpecArg(spec MigrationSpec) (params.MigrationSpec, error) {
+	if err := spec.Validate(); err != nil {
+		return params.MigrationSpec{}, errors.Trace(err)
+	}
+	macsJSON, err := macaroonsToJSON(spec.TargetMacaroons)
+	if err != nil {
+		return params.MigrationSpec{}, errors.Trace(err)
+	}
+	var cloudRemap *params.MigrationCloudRemap
+	if spec.hasCloudRemap() {
+		cloudRemap = &params.MigrationCloudRemap{
+			Cloud:       spec.TargetCloud,
+			CloudRegion: spec.TargetCloudRegion,
+		}
+		if spec.TargetCredential != "" {
+			cloudRemap.CredentialTag = names.NewCloudCredentialTag(spec.TargetCredential).String()
+		}
+	}
+	return params.MigrationSpec{
+		ModelTag: names.NewModelTag(spec.ModelUUID).String(),
+		TargetInfo: params.MigrationTargetInfo{
+			ControllerTag: names.NewControllerTag(spec.TargetControllerUUID).String(),
+			Addrs:         spec.TargetAddrs,
+			CACert:        spec.TargetCACert,
+			AuthTag:       names.NewUserTag(spec.TargetUser).String(),
+			Password:      spec.TargetPassword,
+			Macaroons:     macsJSON,
+		},
+		ExternalControl:      spec.ExternalControl,
+		SkipInitialPrechecks: spec.SkipInitialPrechecks,
+		CloudRemap:           cloudRemap,
+		Queue:                spec.Queue,
+	}, nil
+}
+
+func precheckProblemsFromParams(in []params.PrecheckProblem) []coremigration.PrecheckProblem {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make([]coremigration.PrecheckProblem, len(in))
+	for i, problem := range in {
+		out[i] = coremigration.PrecheckProblem{
+			Entity:  problem.Entity,
+			Message: problem.Message,
+		}
+	}
+	return out
+}
+
 func macaroonsToJSON(macs []macaroon.Slice) (string, error) {
 	if len(macs) == 0 {
 		return "", nil
@@ -16,6 +16,7 @@ import (
 	"github.com/juju/juju/api/controller"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
+	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/environs"
 	jujutesting "github.com/juju/testing"
 	"github.com/juju/utils"
@@ -83,6 +84,62 @@ func specToArgs(spec controller.MigrationSpec) params.InitiateMigrationArgs {
 	}
 }
 
+func (s *Suite) TestInitiateMigrationCloudRemap(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(
+		func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			*(result.(*params.InitiateMigrationResults)) = params.InitiateMigrationResults{
+				Results: []params.InitiateMigrationResult{{MigrationId: "id"}},
+			}
+			return nil
+		},
+	)
+	client := controller.NewClient(apitesting.BestVersionCaller{APICallerFunc: apiCaller, BestVersion: 5})
+
+	spec := makeSpec()
+	spec.TargetCloud = "cumulus"
+	spec.TargetCloudRegion = "west"
+	spec.TargetCredential = "cumulus/bob/default"
+	id, err := client.InitiateMigration(spec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(id, gc.Equals, "id")
+
+	expectArgs := specToArgs(spec)
+	expectArgs.Specs[0].CloudRemap = &params.MigrationCloudRemap{
+		Cloud:         "cumulus",
+		CloudRegion:   "west",
+		CredentialTag: "cloudcred-cumulus_bob_default",
+	}
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.InitiateMigration", []interface{}{expectArgs}},
+	})
+}
+
+func (s *Suite) TestInitiateMigrationCloudRemapNotSupported(c *gc.C) {
+	client, stub := makeClient(params.InitiateMigrationResults{})
+	spec := makeSpec()
+	spec.TargetCloud = "cumulus"
+	_, err := client.InitiateMigration(spec)
+	c.Check(err, gc.ErrorMatches, "cloud remapping not supported")
+	c.Check(stub.Calls(), gc.HasLen, 0)
+}
+
+func (s *Suite) TestMigrationSpecValidateCloudRemap(c *gc.C) {
+	spec := makeSpec()
+	spec.TargetCredential = "bad"
+	c.Check(spec.Validate(), gc.ErrorMatches, `target credential "bad" not valid`)
+
+	spec = makeSpec()
+	spec.TargetCloudRegion = "west"
+	c.Check(spec.Validate(), gc.ErrorMatches, "cloud region without cloud not valid")
+
+	spec = makeSpec()
+	spec.TargetCloud = "cumulus"
+	spec.TargetCredential = "stratus/bob/default"
+	c.Check(spec.Validate(), gc.ErrorMatches, `credential "stratus/bob/default" for cloud "cumulus" not valid`)
+}
+
 func (s *Suite) TestInitiateMigrationError(c *gc.C) {
 	client, _ := makeClient(params.InitiateMigrationResults{
 		Results: []params.InitiateMigrationResult{{
@@ -126,6 +183,136 @@ func (s *Suite) TestInitiateMigrationValidationError(c *gc.C) {
 	c.Check(stub.Calls(), gc.HasLen, 0) // API call shouldn't have happened
 }
 
+func (s *Suite) TestInitiateMigrations(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(
+		func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			*(result.(*params.InitiateMigrationResults)) = params.InitiateMigrationResults{
+				Results: []params.InitiateMigrationResult{
+					{MigrationId: "id-0"},
+					{Error: common.ServerError(errors.New("boom"))},
+				},
+			}
+			return nil
+		},
+	)
+	client := controller.NewClient(apitesting.BestVersionCaller{APICallerFunc: apiCaller, BestVersion: 6})
+
+	specs := []controller.MigrationSpec{makeSpec(), makeSpec(), makeSpec()}
+	specs[1].ModelUUID = "not-a-uuid"
+	for i := range specs {
+		specs[i].Queue = true
+	}
+	results, err := client.InitiateMigrations(specs)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 3)
+
+	c.Check(results[0].ModelUUID, gc.Equals, specs[0].ModelUUID)
+	c.Check(results[0].MigrationId, gc.Equals, "id-0")
+	c.Check(results[0].Error, jc.ErrorIsNil)
+	c.Check(results[1].ModelUUID, gc.Equals, "not-a-uuid")
+	c.Check(results[1].Error, gc.ErrorMatches, "model UUID not valid")
+	c.Check(results[2].ModelUUID, gc.Equals, specs[2].ModelUUID)
+	c.Check(results[2].Error, gc.ErrorMatches, "boom")
+
+	// Only the valid specs are sent to the controller.
+	expectArgs := params.InitiateMigrationArgs{Specs: []params.MigrationSpec{
+		specToArgs(specs[0]).Specs[0],
+		specToArgs(specs[2]).Specs[0],
+	}}
+	for i := range expectArgs.Specs {
+		expectArgs.Specs[i].Queue = true
+	}
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.InitiateMigration", []interface{}{expectArgs}},
+	})
+}
+
+func (s *Suite) TestInitiateMigrationsQueueNotSupported(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(
+		func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			return nil
+		},
+	)
+	client := controller.NewClient(apitesting.BestVersionCaller{APICallerFunc: apiCaller, BestVersion: 5})
+	spec := makeSpec()
+	spec.Queue = true
+	_, err := client.InitiateMigrations([]controller.MigrationSpec{spec})
+	c.Check(err, gc.ErrorMatches, "queued migrations not supported")
+	c.Check(stub.Calls(), gc.HasLen, 0)
+}
+
+func (s *Suite) TestMigrationSpecValidateQueue(c *gc.C) {
+	spec := makeSpec()
+	spec.Queue = true
+	spec.ExternalControl = true
+	c.Check(spec.Validate(), gc.ErrorMatches, "queued migration with external control not valid")
+}
+
+func makePrecheckClient(results params.MigrationPrecheckResults) (
+	*controller.Client, *jujutesting.Stub,
+) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(
+		func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			out := result.(*params.MigrationPrecheckResults)
+			*out = results
+			return nil
+		},
+	)
+	client := controller.NewClient(apitesting.BestVersionCaller{APICallerFunc: apiCaller, BestVersion: 4})
+	return client, &stub
+}
+
+func (s *Suite) TestMigrationPrechecks(c *gc.C) {
+	client, stub := makePrecheckClient(params.MigrationPrecheckResults{
+		Results: []params.MigrationPrecheckResult{{
+			Source: []params.PrecheckProblem{{Entity: "machine 0", Message: "machine 0 is dying"}},
+			Target: []params.PrecheckProblem{{Entity: "controller", Message: "upgrade in progress"}},
+		}},
+	})
+	spec := makeSpec()
+	report, err := client.MigrationPrechecks(spec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(report, jc.DeepEquals, coremigration.PrecheckReport{
+		Source: []coremigration.PrecheckProblem{{Entity: "machine 0", Message: "machine 0 is dying"}},
+		Target: []coremigration.PrecheckProblem{{Entity: "controller", Message: "upgrade in progress"}},
+	})
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.MigrationPrechecks", []interface{}{specToArgs(spec)}},
+	})
+}
+
+func (s *Suite) TestMigrationPrechecksError(c *gc.C) {
+	client, _ := makePrecheckClient(params.MigrationPrecheckResults{
+		Results: []params.MigrationPrecheckResult{{
+			Error: common.ServerError(errors.New("boom")),
+		}},
+	})
+	_, err := client.MigrationPrechecks(makeSpec())
+	c.Check(err, gc.ErrorMatches, "boom")
+}
+
+func (s *Suite) TestMigrationPrechecksValidationError(c *gc.C) {
+	client, stub := makePrecheckClient(params.MigrationPrecheckResults{})
+	spec := makeSpec()
+	spec.TargetCACert = ""
+	_, err := client.MigrationPrechecks(spec)
+	c.Check(err, gc.ErrorMatches, "empty target CA cert not valid")
+	c.Check(stub.Calls(), gc.HasLen, 0)
+}
+
+func (s *Suite) TestMigrationPrechecksNotSupported(c *gc.C) {
+	client, stub := makeClient(params.InitiateMigrationResults{})
+	_, err := client.MigrationPrechecks(makeSpec())
+	c.Check(err, gc.ErrorMatches, "MigrationPrechecks not supported")
+	c.Check(stub.Calls(), gc.HasLen, 0)
+}
+
 func (s *Suite) TestHostedModelConfigs_CallError(c *gc.C) {
 	apiCaller := apitesting.APICallerFunc(func(string, int, string, string, interface{}, interface{}) error {
 		return errors.New("boom")
@@ -229,3 +416,33 @@ func makeSpec() controller.MigrationSpec {
 func randomUUID() string {
 	return utils.MustNewUUID().String()
 }
+
+func (s *Suite) TestControllerHealth(c *gc.C) {
+	expected := params.ControllerHealth{
+		Machines:            []params.ControllerMachineHealth{{Id: "0", AgentAlive: true}},
+		PendingTransactions: 3,
+	}
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, arg)
+		*(result.(*params.ControllerHealth)) = expected
+		return nil
+	})
+	client := controller.NewClient(apitesting.BestVersionCaller{APICallerFunc: apiCaller, BestVersion: 7})
+	health, err := client.ControllerHealth()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(health, jc.DeepEquals, expected)
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.ControllerHealth", []interface{}{nil}},
+	})
+}
+
+func (s *Suite) TestControllerHealthNotSupported(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected call to %s.%s", objType, request)
+		return nil
+	})
+	client := controller.NewClient(apitesting.BestVersionCaller{APICallerFunc: apiCaller, BestVersion: 6})
+	_, err := client.ControllerHealth()
+	c.Check(err, gc.ErrorMatches, "ControllerHealth not supported")
+}
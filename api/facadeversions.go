@@ -17,10 +17,10 @@ var facadeVersions = map[string]int{
 	"AllModelWatcher":              2,
 	"AllWatcher":                   1,
 	"Annotations":                  2,
-	"Application":                  3,
+	"Application":                  7,
 	"ApplicationScaler":            1,
 	"ApplicationOffers":            1,
-	"Backups":                      1,
+	"Backups":                      3,
 	"Block":                        2,
 	"Bundle":                       1,
 	"CharmRevisionUpdater":         2,
@@ -28,15 +28,16 @@ var facadeVersions = map[string]int{
 	"Cleaner":                      2,
 	"Client":                       1,
 	"Cloud":                        1,
-	"Controller":                   3,
+	"Controller":                   7,
 	"CrossModelRelations":          1,
 	"Deployer":                     1,
 	"DiscoverSpaces":               2,
 	"DiskManager":                  2,
 	"EntityWatcher":                2,
 	"FilesystemAttachmentsWatcher": 2,
-	"Firewaller":                   3,
-	"HighAvailability":             2,
+	"Firewaller":                   5,
+	"HighAvailability":             4,
+	"HostFirewaller":               1,
 	"HostKeyReporter":              1,
 	"ImageManager":                 2,
 	"ImageMetadata":                2,
@@ -56,12 +57,12 @@ var facadeVersions = map[string]int{
 	"MetricsDebug":                 2,
 	"MetricsManager":               1,
 	"MigrationFlag":                1,
-	"MigrationMaster":              1,
+	"MigrationMaster":              3,
 	"MigrationMinion":              1,
 	"MigrationStatusWatcher":       1,
-	"MigrationTarget":              1,
+	"MigrationTarget":              3,
 	"ModelConfig":                  1,
-	"ModelManager":                 2,
+	"ModelManager":                 4,
 	"NotifyWatcher":                1,
 	"OfferedApplications":          1,
 	"Payloads":                     1,
@@ -81,8 +82,8 @@ var facadeVersions = map[string]int{
 	"Spaces":                       2,
 	"SSHClient":                    2,
 	"StatusHistory":                2,
-	"Storage":                      3,
-	"StorageProvisioner":           3,
+	"Storage":                      7,
+	"StorageProvisioner":           5,
 	"StringsWatcher":               1,
 	"Subnets":                      2,
 	"Undertaker":                   1,
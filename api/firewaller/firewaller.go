@@ -117,3 +117,32 @@ func (st *State) WatchOpenedPorts() (watcher.StringsWatcher, error) {
 	w := apiwatcher.NewStringsWatcher(st.facade.RawAPICaller(), result)
 	return w, nil
 }
+
+// WatchSubnets returns a StringsWatcher that notifies of changes to
+// the subnets in the current model. Controllers that predate
+// Firewaller version 5 do not support watching subnets.
+func (st *State) WatchSubnets() (watcher.StringsWatcher, error) {
+	if st.BestAPIVersion() < 5 {
+		return nil, errors.NotSupportedf("watching subnets")
+	}
+	modelTag, ok := st.ModelTag()
+	if !ok {
+		return nil, errors.New("API connection is controller-only (should never happen)")
+	}
+	var results params.StringsWatchResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: modelTag.String()}},
+	}
+	if err := st.facade.FacadeCall("WatchSubnets", args, &results); err != nil {
+		return nil, err
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if err := result.Error; err != nil {
+		return nil, result.Error
+	}
+	w := apiwatcher.NewStringsWatcher(st.facade.RawAPICaller(), result)
+	return w, nil
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/juju/juju/api/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/watcher"
 )
 
@@ -75,3 +76,34 @@ func (s *Application) IsExposed() (bool, error) {
 	}
 	return result.Result, nil
 }
+
+// IngressCIDRs returns whether the application is exposed and, if it
+// is, the source CIDRs from which its open ports may be accessed.
+//
+// Controllers that predate ingress restrictions allow exposed
+// applications to be accessed from anywhere.
+func (s *Application) IngressCIDRs() (bool, []string, error) {
+	if s.st.BestAPIVersion() < 4 {
+		exposed, err := s.IsExposed()
+		if err != nil || !exposed {
+			return false, nil, err
+		}
+		return true, network.DefaultIngressCIDRs(), nil
+	}
+	var results params.ExposeInfoResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: s.tag.String()}},
+	}
+	err := s.st.facade.FacadeCall("GetExposeInfo", args, &results)
+	if err != nil {
+		return false, nil, err
+	}
+	if len(results.Results) != 1 {
+		return false, nil, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return false, nil, result.Error
+	}
+	return result.Result.Exposed, result.Result.IngressCIDRs, nil
+}
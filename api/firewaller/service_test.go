@@ -91,3 +91,21 @@ func (s *serviceSuite) TestIsExposed(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(isExposed, jc.IsFalse)
 }
+
+func (s *serviceSuite) TestIngressCIDRs(c *gc.C) {
+	err := s.application.SetExposedTo([]string{"10.0.0.0/8"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	exposed, cidrs, err := s.apiApplication.IngressCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(exposed, jc.IsTrue)
+	c.Assert(cidrs, jc.DeepEquals, []string{"10.0.0.0/8"})
+
+	err = s.application.ClearExposed()
+	c.Assert(err, jc.ErrorIsNil)
+
+	exposed, cidrs, err = s.apiApplication.IngressCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(exposed, jc.IsFalse)
+	c.Assert(cidrs, gc.HasLen, 0)
+}
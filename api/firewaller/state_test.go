@@ -4,9 +4,12 @@
 package firewaller_test
 
 import (
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/firewaller"
 	apitesting "github.com/juju/juju/api/testing"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/state"
@@ -100,3 +103,30 @@ func (s *stateSuite) TestWatchOpenedPorts(c *gc.C) {
 	wc.AssertChange("1:")
 	wc.AssertNoChange()
 }
+
+func (s *stateSuite) TestWatchSubnets(c *gc.C) {
+	_, err := s.State.AddSubnet(state.SubnetInfo{CIDR: "10.0.0.0/24", SpaceName: "mgmt"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	w, err := s.firewaller.WatchSubnets()
+	c.Assert(err, jc.ErrorIsNil)
+	wc := watchertest.NewStringsWatcherC(c, w, s.BackingState.StartSync)
+	defer wc.AssertStops()
+
+	wc.AssertChangeInSingleEvent("10.0.0.0/24")
+	wc.AssertNoChange()
+
+	_, err = s.State.AddSubnet(state.SubnetInfo{CIDR: "10.0.1.0/24", SpaceName: "mgmt"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange("10.0.1.0/24")
+	wc.AssertNoChange()
+}
+
+func (s *stateSuite) TestWatchSubnetsNotSupported(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected call to %s.%s", objType, request)
+		return nil
+	})
+	_, err := firewaller.NewState(apiCaller).WatchSubnets()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
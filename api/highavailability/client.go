@@ -7,6 +7,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/replicaset"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
@@ -55,6 +56,106 @@ func (c *Client) EnableHA(
 	return result.Result, nil
 }
 
+// DrainController marks the controller machine with the given id as
+// draining, so that agents move their connections to the other
+// controllers.
+func (c *Client) DrainController(machineId string) error {
+	return c.changeDrain("DrainControllers", machineId)
+}
+
+// UndrainController returns the controller machine with the given id to
+// normal service.
+func (c *Client) UndrainController(machineId string) error {
+	return c.changeDrain("UndrainControllers", machineId)
+}
+
+func (c *Client) changeDrain(method, machineId string) error {
+	if c.BestAPIVersion() < 3 {
+		return errors.NotSupportedf("draining controllers")
+	}
+	if !names.IsValidMachine(machineId) {
+		return errors.NotValidf("machine id %q", machineId)
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewMachineTag(machineId).String()}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall(method, args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// ControllerDrainStatus reports how far the draining of the controller
+// machine with the given id has got.
+func (c *Client) ControllerDrainStatus(machineId string) (params.ControllerDrainStatus, error) {
+	if c.BestAPIVersion() < 3 {
+		return params.ControllerDrainStatus{}, errors.NotSupportedf("draining controllers")
+	}
+	if !names.IsValidMachine(machineId) {
+		return params.ControllerDrainStatus{}, errors.NotValidf("machine id %q", machineId)
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewMachineTag(machineId).String()}},
+	}
+	var results params.ControllerDrainStatusResults
+	if err := c.facade.FacadeCall("ControllerDrainStatus", args, &results); err != nil {
+		return params.ControllerDrainStatus{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.ControllerDrainStatus{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.ControllerDrainStatus{}, result.Error
+	}
+	return *result.Result, nil
+}
+
+// SetControllerMemberVoting records whether the controller machine with
+// the given id may have a vote in the controller's replica set.
+func (c *Client) SetControllerMemberVoting(machineId string, voting bool) error {
+	return c.setMemberSettings(machineId, params.ControllerMemberSettings{Voting: &voting})
+}
+
+// SetControllerMemberPriority records the replica set election
+// priority of the controller machine with the given id.
+func (c *Client) SetControllerMemberPriority(machineId string, priority float64) error {
+	return c.setMemberSettings(machineId, params.ControllerMemberSettings{Priority: &priority})
+}
+
+func (c *Client) setMemberSettings(machineId string, settings params.ControllerMemberSettings) error {
+	if c.BestAPIVersion() < 4 {
+		return errors.NotSupportedf("controller member settings")
+	}
+	if !names.IsValidMachine(machineId) {
+		return errors.NotValidf("machine id %q", machineId)
+	}
+	settings.MachineTag = names.NewMachineTag(machineId).String()
+	args := params.ControllerMemberSettingsArgs{
+		Settings: []params.ControllerMemberSettings{settings},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("SetControllerMemberSettings", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// ControllerReplicaSet returns the replica set settings of each
+// controller machine, and the configuration of the controller's
+// replica set.
+func (c *Client) ControllerReplicaSet() (params.ControllerReplicaSet, error) {
+	if c.BestAPIVersion() < 4 {
+		return params.ControllerReplicaSet{}, errors.NotSupportedf("controller member settings")
+	}
+	var result params.ControllerReplicaSet
+	if err := c.facade.FacadeCall("ControllerReplicaSet", nil, &result); err != nil {
+		return params.ControllerReplicaSet{}, errors.Trace(err)
+	}
+	return result, nil
+}
+
 // MongoUpgradeMode will make all Slave members of the HA
 // to shut down their mongo server.
 func (c *Client) MongoUpgradeMode(v mongo.Version) (params.MongoUpgradeResults, error) {
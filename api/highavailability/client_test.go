@@ -6,10 +6,13 @@ package highavailability_test
 import (
 	stdtesting "testing"
 
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	apitesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/api/highavailability"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/constraints"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
@@ -77,5 +80,118 @@ func (s *clientSuite) TestClientEnableHA(c *gc.C) {
 
 func (s *clientSuite) TestClientEnableHAVersion(c *gc.C) {
 	client := highavailability.NewClient(s.APIState)
-	c.Assert(client.BestAPIVersion(), gc.Equals, 2)
+	c.Assert(client.BestAPIVersion(), gc.Equals, 4)
+}
+
+func (s *clientSuite) TestClientDrainController(c *gc.C) {
+	for i := 0; i < 2; i++ {
+		_, err := s.State.AddMachine("quantal", state.JobManageModel)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	client := highavailability.NewClient(s.APIState)
+
+	err := client.DrainController("1")
+	c.Assert(err, jc.ErrorIsNil)
+	status, err := client.ControllerDrainStatus("1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(status.Draining, jc.IsTrue)
+
+	err = client.UndrainController("1")
+	c.Assert(err, jc.ErrorIsNil)
+	status, err = client.ControllerDrainStatus("1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(status, jc.DeepEquals, params.ControllerDrainStatus{})
+}
+
+func (s *clientSuite) TestClientDrainControllerError(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "HighAvailability")
+		c.Check(request, gc.Equals, "DrainControllers")
+		c.Check(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "machine-2"}},
+		})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{Error: &params.Error{Message: "boom"}}},
+		}
+		return nil
+	})
+	client := highavailability.NewClient(apitesting.BestVersionCaller{APICallerFunc: apiCaller, BestVersion: 3})
+	err := client.DrainController("2")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *clientSuite) TestClientDrainControllerNotSupported(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected call to %s.%s", objType, request)
+		return nil
+	})
+	client := highavailability.NewClient(apitesting.BestVersionCaller{APICallerFunc: apiCaller, BestVersion: 2})
+	err := client.DrainController("1")
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+	err = client.UndrainController("1")
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+	_, err = client.ControllerDrainStatus("1")
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *clientSuite) TestClientSetControllerMemberSettings(c *gc.C) {
+	for i := 0; i < 3; i++ {
+		_, err := s.State.AddMachine("quantal", state.JobManageModel)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	client := highavailability.NewClient(s.APIState)
+
+	err := client.SetControllerMemberVoting("2", false)
+	c.Assert(err, jc.ErrorIsNil)
+	err = client.SetControllerMemberPriority("1", 5)
+	c.Assert(err, jc.ErrorIsNil)
+
+	info, err := s.State.ControllerInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(info.NonVotingMachineIds, jc.DeepEquals, []string{"2"})
+	c.Check(info.MemberPriorities, jc.DeepEquals, map[string]float64{"1": 5})
+}
+
+func (s *clientSuite) TestClientControllerReplicaSet(c *gc.C) {
+	expected := params.ControllerReplicaSet{
+		Machines: []params.ControllerMember{{
+			MachineId: "0",
+			Voting:    true,
+			Priority:  1,
+			WantsVote: true,
+			HasVote:   true,
+		}},
+		Members: []params.ReplicaSetMemberConfig{{
+			Id:        1,
+			Address:   "10.0.0.1:37017",
+			MachineId: "0",
+			Voting:    true,
+			Priority:  1,
+		}},
+	}
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "HighAvailability")
+		c.Check(request, gc.Equals, "ControllerReplicaSet")
+		c.Check(arg, gc.IsNil)
+		*(result.(*params.ControllerReplicaSet)) = expected
+		return nil
+	})
+	client := highavailability.NewClient(apitesting.BestVersionCaller{APICallerFunc: apiCaller, BestVersion: 4})
+	result, err := client.ControllerReplicaSet()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, expected)
+}
+
+func (s *clientSuite) TestClientControllerMemberSettingsNotSupported(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected call to %s.%s", objType, request)
+		return nil
+	})
+	client := highavailability.NewClient(apitesting.BestVersionCaller{APICallerFunc: apiCaller, BestVersion: 3})
+	err := client.SetControllerMemberVoting("1", false)
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+	err = client.SetControllerMemberPriority("1", 2)
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+	_, err = client.ControllerReplicaSet()
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
 }
@@ -0,0 +1,146 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package hostfirewaller implements the client-side API facade used
+// by the hostfirewaller worker.
+package hostfirewaller
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/common"
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/watcher"
+)
+
+const hostFirewallerFacade = "HostFirewaller"
+
+// Facade provides access to the HostFirewaller API facade.
+type Facade struct {
+	*common.ModelWatcher
+	facade base.FacadeCaller
+}
+
+// NewFacade creates a new client-side HostFirewaller facade.
+func NewFacade(caller base.APICaller) *Facade {
+	facadeCaller := base.NewFacadeCaller(caller, hostFirewallerFacade)
+	return &Facade{
+		ModelWatcher: common.NewModelWatcher(facadeCaller),
+		facade:       facadeCaller,
+	}
+}
+
+// OpenedPorts returns the port ranges opened by units on the
+// specified machine, in all subnets.
+func (f *Facade) OpenedPorts(tag names.MachineTag) ([]network.PortRange, error) {
+	var results params.MachinePortsResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: tag.String()}},
+	}
+	if err := f.facade.FacadeCall("OpenedPorts", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	portRanges := make([]network.PortRange, len(result.Ports))
+	for i, port := range result.Ports {
+		portRanges[i] = port.PortRange.NetworkPortRange()
+	}
+	network.SortPortRanges(portRanges)
+	return portRanges, nil
+}
+
+// WatchOpenedPorts returns a NotifyWatcher that notifies of changes
+// to the ports opened on the specified machine.
+func (f *Facade) WatchOpenedPorts(tag names.MachineTag) (watcher.NotifyWatcher, error) {
+	return f.watch("WatchOpenedPorts", tag)
+}
+
+// EgressRules returns the destinations to which the units on the
+// specified machine may connect. No rules are returned if the
+// machine's egress is not restricted.
+func (f *Facade) EgressRules(tag names.MachineTag) ([]network.EgressRule, error) {
+	var results params.EgressRulesResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: tag.String()}},
+	}
+	if err := f.facade.FacadeCall("EgressRules", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	var rules []network.EgressRule
+	for _, in := range result.Rules {
+		rule, err := network.ParseEgressRule(in)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		rules = append(rules, rule)
+	}
+	network.SortEgressRules(rules)
+	return rules, nil
+}
+
+// WatchEgressRules returns a NotifyWatcher that notifies of changes
+// that may affect the egress rules of the specified machine.
+func (f *Facade) WatchEgressRules(tag names.MachineTag) (watcher.NotifyWatcher, error) {
+	return f.watch("WatchEgressRules", tag)
+}
+
+// ModelMachineAddresses returns the IP addresses of the machines in
+// the model, from which all traffic is accepted.
+func (f *Facade) ModelMachineAddresses() ([]string, error) {
+	var result params.StringsResult
+	if err := f.facade.FacadeCall("ModelMachineAddresses", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Result, nil
+}
+
+// WatchModelMachineAddresses returns a NotifyWatcher that notifies of
+// changes that may affect the addresses of the machines in the model.
+func (f *Facade) WatchModelMachineAddresses() (watcher.NotifyWatcher, error) {
+	var result params.NotifyWatchResult
+	if err := f.facade.FacadeCall("WatchModelMachineAddresses", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return apiwatcher.NewNotifyWatcher(f.facade.RawAPICaller(), result), nil
+}
+
+func (f *Facade) watch(method string, tag names.MachineTag) (watcher.NotifyWatcher, error) {
+	var results params.NotifyWatchResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: tag.String()}},
+	}
+	if err := f.facade.FacadeCall(method, args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return apiwatcher.NewNotifyWatcher(f.facade.RawAPICaller(), result), nil
+}
@@ -0,0 +1,219 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hostfirewaller_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/hostfirewaller"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
+)
+
+type facadeSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&facadeSuite{})
+
+func (s *facadeSuite) TestOpenedPorts(c *gc.C) {
+	stub := new(testing.Stub)
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		c.Check(objType, gc.Equals, "HostFirewaller")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		stub.AddCall(request, args)
+		*response.(*params.MachinePortsResults) = params.MachinePortsResults{
+			Results: []params.MachinePortsResult{{
+				Ports: []params.MachinePortRange{{
+					UnitTag:   "unit-wordpress-0",
+					PortRange: params.PortRange{FromPort: 443, ToPort: 443, Protocol: "tcp"},
+				}, {
+					UnitTag:   "unit-wordpress-0",
+					PortRange: params.PortRange{FromPort: 80, ToPort: 80, Protocol: "tcp"},
+				}},
+			}},
+		}
+		return nil
+	})
+	facade := hostfirewaller.NewFacade(apiCaller)
+
+	portRanges, err := facade.OpenedPorts(names.NewMachineTag("42"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(portRanges, jc.DeepEquals, []network.PortRange{
+		{FromPort: 80, ToPort: 80, Protocol: "tcp"},
+		{FromPort: 443, ToPort: 443, Protocol: "tcp"},
+	})
+	stub.CheckCalls(c, []testing.StubCall{{
+		"OpenedPorts", []interface{}{params.Entities{
+			Entities: []params.Entity{{Tag: "machine-42"}},
+		}},
+	}})
+}
+
+func (s *facadeSuite) TestOpenedPortsError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		*response.(*params.MachinePortsResults) = params.MachinePortsResults{
+			Results: []params.MachinePortsResult{{
+				Error: &params.Error{Message: "blam"},
+			}},
+		}
+		return nil
+	})
+	facade := hostfirewaller.NewFacade(apiCaller)
+
+	_, err := facade.OpenedPorts(names.NewMachineTag("42"))
+	c.Assert(err, gc.ErrorMatches, "blam")
+}
+
+func (s *facadeSuite) TestWatchOpenedPortsError(c *gc.C) {
+	stub := new(testing.Stub)
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		stub.AddCall(request, args)
+		*response.(*params.NotifyWatchResults) = params.NotifyWatchResults{
+			Results: []params.NotifyWatchResult{{
+				Error: &params.Error{Message: "blam"},
+			}},
+		}
+		return nil
+	})
+	facade := hostfirewaller.NewFacade(apiCaller)
+
+	_, err := facade.WatchOpenedPorts(names.NewMachineTag("42"))
+	c.Assert(err, gc.ErrorMatches, "blam")
+	stub.CheckCalls(c, []testing.StubCall{{
+		"WatchOpenedPorts", []interface{}{params.Entities{
+			Entities: []params.Entity{{Tag: "machine-42"}},
+		}},
+	}})
+}
+
+func (s *facadeSuite) TestEgressRules(c *gc.C) {
+	stub := new(testing.Stub)
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		stub.AddCall(request, args)
+		*response.(*params.EgressRulesResults) = params.EgressRulesResults{
+			Results: []params.EgressRulesResult{{
+				Rules: []string{"10.0.0.0/8:53/udp", "10.0.0.0/8:443/tcp"},
+			}},
+		}
+		return nil
+	})
+	facade := hostfirewaller.NewFacade(apiCaller)
+
+	rules, err := facade.EgressRules(names.NewMachineTag("42"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+		network.MustNewEgressRule("udp", 53, 53, "10.0.0.0/8"),
+	})
+	stub.CheckCalls(c, []testing.StubCall{{
+		"EgressRules", []interface{}{params.Entities{
+			Entities: []params.Entity{{Tag: "machine-42"}},
+		}},
+	}})
+}
+
+func (s *facadeSuite) TestWatchEgressRulesError(c *gc.C) {
+	stub := new(testing.Stub)
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		stub.AddCall(request, args)
+		*response.(*params.NotifyWatchResults) = params.NotifyWatchResults{
+			Results: []params.NotifyWatchResult{{
+				Error: &params.Error{Message: "blam"},
+			}},
+		}
+		return nil
+	})
+	facade := hostfirewaller.NewFacade(apiCaller)
+
+	_, err := facade.WatchEgressRules(names.NewMachineTag("42"))
+	c.Assert(err, gc.ErrorMatches, "blam")
+	stub.CheckCalls(c, []testing.StubCall{{
+		"WatchEgressRules", []interface{}{params.Entities{
+			Entities: []params.Entity{{Tag: "machine-42"}},
+		}},
+	}})
+}
+
+func (s *facadeSuite) TestModelMachineAddresses(c *gc.C) {
+	stub := new(testing.Stub)
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		c.Check(objType, gc.Equals, "HostFirewaller")
+		stub.AddCall(request, args)
+		*response.(*params.StringsResult) = params.StringsResult{
+			Result: []string{"10.0.0.1", "2001:db8::1"},
+		}
+		return nil
+	})
+	facade := hostfirewaller.NewFacade(apiCaller)
+
+	addresses, err := facade.ModelMachineAddresses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addresses, jc.DeepEquals, []string{"10.0.0.1", "2001:db8::1"})
+	stub.CheckCalls(c, []testing.StubCall{{"ModelMachineAddresses", []interface{}{nil}}})
+}
+
+func (s *facadeSuite) TestModelMachineAddressesError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		*response.(*params.StringsResult) = params.StringsResult{
+			Error: &params.Error{Message: "blam"},
+		}
+		return nil
+	})
+	facade := hostfirewaller.NewFacade(apiCaller)
+
+	_, err := facade.ModelMachineAddresses()
+	c.Assert(err, gc.ErrorMatches, "blam")
+}
+
+func (s *facadeSuite) TestWatchModelMachineAddressesError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		c.Check(request, gc.Equals, "WatchModelMachineAddresses")
+		*response.(*params.NotifyWatchResult) = params.NotifyWatchResult{
+			Error: &params.Error{Message: "blam"},
+		}
+		return nil
+	})
+	facade := hostfirewaller.NewFacade(apiCaller)
+
+	_, err := facade.WatchModelMachineAddresses()
+	c.Assert(err, gc.ErrorMatches, "blam")
+}
@@ -97,6 +97,18 @@ func (c *Client) MigrationStatus() (migration.MigrationStatus, error) {
 		}
 	}
 
+	var cloudRemap migration.CloudRemap
+	if remap := status.Spec.CloudRemap; remap != nil {
+		cloudRemap.Cloud = remap.Cloud
+		cloudRemap.CloudRegion = remap.CloudRegion
+		if remap.CredentialTag != "" {
+			cloudRemap.Credential, err = names.ParseCloudCredentialTag(remap.CredentialTag)
+			if err != nil {
+				return empty, errors.Annotatef(err, "parsing credential tag")
+			}
+		}
+	}
+
 	return migration.MigrationStatus{
 		MigrationId:      status.MigrationId,
 		ModelUUID:        modelTag.Id(),
@@ -111,6 +123,7 @@ func (c *Client) MigrationStatus() (migration.MigrationStatus, error) {
 			Password:      target.Password,
 			Macaroons:     macs,
 		},
+		CloudRemap: cloudRemap,
 	}, nil
 }
 
@@ -131,6 +144,39 @@ func (c *Client) SetStatusMessage(message string) error {
 	return c.caller.FacadeCall("SetStatusMessage", args, nil)
 }
 
+// SetProgress records structured details about how far the migration
+// has got through its current phase.
+func (c *Client) SetProgress(progress migration.Progress) error {
+	if c.caller.BestAPIVersion() < 2 {
+		return errors.NotSupportedf("SetProgress")
+	}
+	args := params.SetMigrationProgressArgs{
+		Progress: params.MigrationProgress{
+			BinariesUploaded:      progress.BinariesUploaded,
+			BinariesTotal:         progress.BinariesTotal,
+			MinionReportsReceived: progress.MinionReportsReceived,
+			MinionReportsExpected: progress.MinionReportsExpected,
+		},
+	}
+	return c.caller.FacadeCall("SetProgress", args, nil)
+}
+
+// QueuePosition reports where the migration is in the controller's
+// queue of migrations.
+func (c *Client) QueuePosition() (migration.QueuePosition, error) {
+	if c.caller.BestAPIVersion() < 3 {
+		return migration.QueuePosition{}, errors.NotSupportedf("QueuePosition")
+	}
+	var result params.MigrationQueuePosition
+	if err := c.caller.FacadeCall("QueuePosition", nil, &result); err != nil {
+		return migration.QueuePosition{}, errors.Trace(err)
+	}
+	return migration.QueuePosition{
+		Ahead: result.Ahead,
+		Limit: result.Limit,
+	}, nil
+}
+
 // ModelInfo return basic information about the model to migrated.
 func (c *Client) ModelInfo() (migration.ModelInfo, error) {
 	var info params.MigrationModelInfo
@@ -168,14 +214,18 @@ func (c *Client) Export() (migration.SerializedModel, error) {
 		return empty, errors.Trace(err)
 	}
 
-	// Convert tools info to output map.
+	// Convert tools info to output maps.
 	tools := make(map[version.Binary]string)
+	toolsSizes := make(map[version.Binary]int64)
 	for _, toolsInfo := range serialized.Tools {
 		v, err := version.ParseBinary(toolsInfo.Version)
 		if err != nil {
 			return migration.SerializedModel{}, errors.Annotate(err, "error parsing tools version")
 		}
 		tools[v] = toolsInfo.URI
+		if toolsInfo.Size > 0 {
+			toolsSizes[v] = toolsInfo.Size
+		}
 	}
 
 	resources, err := convertResources(serialized.Resources)
@@ -184,10 +234,11 @@ func (c *Client) Export() (migration.SerializedModel, error) {
 	}
 
 	return migration.SerializedModel{
-		Bytes:     serialized.Bytes,
-		Charms:    serialized.Charms,
-		Tools:     tools,
-		Resources: resources,
+		Bytes:      serialized.Bytes,
+		Charms:     serialized.Charms,
+		Tools:      tools,
+		ToolsSizes: toolsSizes,
+		Resources:  resources,
 	}, nil
 }
 
@@ -121,6 +121,34 @@ func (s *ClientSuite) TestMigrationStatus(c *gc.C) {
 	})
 }
 
+func (s *ClientSuite) TestMigrationStatusCloudRemap(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(_ string, _ int, _, _ string, _, result interface{}) error {
+		out := result.(*params.MasterMigrationStatus)
+		*out = params.MasterMigrationStatus{
+			Spec: params.MigrationSpec{
+				ModelTag: names.NewModelTag(utils.MustNewUUID().String()).String(),
+				TargetInfo: params.MigrationTargetInfo{
+					ControllerTag: names.NewControllerTag(utils.MustNewUUID().String()).String(),
+					AuthTag:       names.NewUserTag("admin").String(),
+				},
+				CloudRemap: &params.MigrationCloudRemap{
+					Cloud:         "openstack",
+					CredentialTag: "cloudcred-openstack_bob_default",
+				},
+			},
+			Phase: "IMPORT",
+		}
+		return nil
+	})
+	client := migrationmaster.NewClient(apiCaller, nil)
+	status, err := client.MigrationStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(status.CloudRemap, jc.DeepEquals, migration.CloudRemap{
+		Cloud:      "openstack",
+		Credential: names.NewCloudCredentialTag("openstack/bob/default"),
+	})
+}
+
 func (s *ClientSuite) TestSetPhase(c *gc.C) {
 	var stub jujutesting.Stub
 	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
@@ -169,6 +197,76 @@ func (s *ClientSuite) TestSetStatusMessageError(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "boom")
 }
 
+func (s *ClientSuite) TestSetProgress(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		return nil
+	})
+	client := migrationmaster.NewClient(apitesting.BestVersionCaller{APICallerFunc: apiCaller, BestVersion: 2}, nil)
+	err := client.SetProgress(migration.Progress{
+		BinariesUploaded:      10,
+		BinariesTotal:         100,
+		MinionReportsReceived: 1,
+		MinionReportsExpected: 3,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	expectedArg := params.SetMigrationProgressArgs{
+		Progress: params.MigrationProgress{
+			BinariesUploaded:      10,
+			BinariesTotal:         100,
+			MinionReportsReceived: 1,
+			MinionReportsExpected: 3,
+		},
+	}
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationMaster.SetProgress", []interface{}{"", expectedArg}},
+	})
+}
+
+func (s *ClientSuite) TestSetProgressNotSupported(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		return nil
+	})
+	client := migrationmaster.NewClient(apiCaller, nil)
+	err := client.SetProgress(migration.Progress{})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	stub.CheckNoCalls(c)
+}
+
+func (s *ClientSuite) TestQueuePosition(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		*(result.(*params.MigrationQueuePosition)) = params.MigrationQueuePosition{
+			Ahead: 2,
+			Limit: 4,
+		}
+		return nil
+	})
+	client := migrationmaster.NewClient(apitesting.BestVersionCaller{APICallerFunc: apiCaller, BestVersion: 3}, nil)
+	position, err := client.QueuePosition()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(position, gc.Equals, migration.QueuePosition{Ahead: 2, Limit: 4})
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationMaster.QueuePosition", []interface{}{"", nil}},
+	})
+}
+
+func (s *ClientSuite) TestQueuePositionNotSupported(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		return nil
+	})
+	client := migrationmaster.NewClient(apitesting.BestVersionCaller{APICallerFunc: apiCaller, BestVersion: 2}, nil)
+	_, err := client.QueuePosition()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	stub.CheckNoCalls(c)
+}
+
 func (s *ClientSuite) TestModelInfo(c *gc.C) {
 	var stub jujutesting.Stub
 	owner := names.NewUserTag("owner")
@@ -233,6 +331,7 @@ func (s *ClientSuite) TestExport(c *gc.C) {
 			Tools: []params.SerializedModelTools{{
 				Version: "2.0.0-trusty-amd64",
 				URI:     "/tools/0",
+				Size:    1234,
 			}},
 			Resources: []params.SerializedModelResource{{
 				Application: "fooapp",
@@ -288,6 +387,9 @@ func (s *ClientSuite) TestExport(c *gc.C) {
 		Tools: map[version.Binary]string{
 			version.MustParseBinary("2.0.0-trusty-amd64"): "/tools/0",
 		},
+		ToolsSizes: map[version.Binary]int64{
+			version.MustParseBinary("2.0.0-trusty-amd64"): 1234,
+		},
 		Resources: []migration.SerializedModelResource{{
 			ApplicationRevision: resource.Resource{
 				Resource: charmresource.Resource{
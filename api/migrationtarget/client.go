@@ -43,14 +43,43 @@ type Client struct {
 }
 
 func (c *Client) Prechecks(model coremigration.ModelInfo) error {
+	return c.caller.FacadeCall("Prechecks", migrationModelInfo(model), nil)
+}
+
+// PrecheckReport runs the target controller's migration prechecks and
+// returns every problem found, rather than just the first.
+func (c *Client) PrecheckReport(model coremigration.ModelInfo) ([]coremigration.PrecheckProblem, error) {
+	if c.caller.BestAPIVersion() < 2 {
+		return nil, errors.NotSupportedf("PrecheckReport")
+	}
+	var result params.PrecheckProblems
+	if err := c.caller.FacadeCall("PrecheckReport", migrationModelInfo(model), &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	problems := make([]coremigration.PrecheckProblem, len(result.Problems))
+	for i, problem := range result.Problems {
+		problems[i] = coremigration.PrecheckProblem{
+			Entity:  problem.Entity,
+			Message: problem.Message,
+		}
+	}
+	return problems, nil
+}
+
+func migrationModelInfo(model coremigration.ModelInfo) params.MigrationModelInfo {
 	args := params.MigrationModelInfo{
 		UUID:                   model.UUID,
 		Name:                   model.Name,
 		OwnerTag:               model.Owner.String(),
 		AgentVersion:           model.AgentVersion,
 		ControllerAgentVersion: model.ControllerAgentVersion,
+		Cloud:                  model.Cloud,
+		CloudRegion:            model.CloudRegion,
+	}
+	if model.CloudCredential.Id() != "" {
+		args.CloudCredentialTag = model.CloudCredential.String()
 	}
-	return c.caller.FacadeCall("Prechecks", args, nil)
+	return args
 }
 
 // Import takes a serialized model and imports it into the target
@@ -220,3 +249,26 @@ func (c *Client) AdoptResources(modelUUID string) error {
 	}
 	return errors.Trace(c.caller.FacadeCall("AdoptResources", args, nil))
 }
+
+// CheckMachines compares the machines in the serialized model with the
+// instances reported by the cloud the model description says hosts the
+// model, returning an error for each machine whose instance can't be
+// found. The model doesn't need to have been imported.
+func (c *Client) CheckMachines(modelBytes []byte) ([]error, error) {
+	if c.caller.BestAPIVersion() < 3 {
+		return nil, errors.NotSupportedf("CheckMachines")
+	}
+	var results params.ErrorResults
+	args := params.SerializedModel{Bytes: modelBytes}
+	err := c.caller.FacadeCall("CheckMachines", args, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var problems []error
+	for _, result := range results.Results {
+		if result.Error != nil {
+			problems = append(problems, result.Error)
+		}
+	}
+	return problems, nil
+}
@@ -77,6 +77,80 @@ func (s *ClientSuite) TestPrechecks(c *gc.C) {
 	})
 }
 
+func (s *ClientSuite) TestPrecheckReport(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		*(result.(*params.PrecheckProblems)) = params.PrecheckProblems{
+			Problems: []params.PrecheckProblem{{Entity: "model", Message: "upgrade in progress"}},
+		}
+		return nil
+	})
+	client := migrationtarget.NewClient(apitesting.BestVersionCaller{APICallerFunc: apiCaller, BestVersion: 2})
+
+	modelInfo := coremigration.ModelInfo{
+		UUID:                   "uuid",
+		Owner:                  names.NewUserTag("owner"),
+		Name:                   "name",
+		AgentVersion:           version.MustParse("1.2.3"),
+		ControllerAgentVersion: version.MustParse("1.2.5"),
+		Cloud:                  "aws",
+		CloudRegion:            "us-east-1",
+	}
+	problems, err := client.PrecheckReport(modelInfo)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(problems, jc.DeepEquals, []coremigration.PrecheckProblem{
+		{Entity: "model", Message: "upgrade in progress"},
+	})
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationTarget.PrecheckReport", []interface{}{"", params.MigrationModelInfo{
+			UUID:                   "uuid",
+			Name:                   "name",
+			OwnerTag:               "user-owner",
+			AgentVersion:           version.MustParse("1.2.3"),
+			ControllerAgentVersion: version.MustParse("1.2.5"),
+			Cloud:                  "aws",
+			CloudRegion:            "us-east-1",
+		}}},
+	})
+}
+
+func (s *ClientSuite) TestPrecheckReportNotSupported(c *gc.C) {
+	client, stub := s.getClientAndStub(c)
+	_, err := client.PrecheckReport(coremigration.ModelInfo{})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	stub.CheckNoCalls(c)
+}
+
+func (s *ClientSuite) TestCheckMachines(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{
+				{Error: &params.Error{Message: `machine 0 instance "inst-0" not found`}},
+			},
+		}
+		return nil
+	})
+	client := migrationtarget.NewClient(apitesting.BestVersionCaller{APICallerFunc: apiCaller, BestVersion: 3})
+
+	problems, err := client.CheckMachines([]byte("model"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(problems, gc.HasLen, 1)
+	c.Assert(problems[0], gc.ErrorMatches, `machine 0 instance "inst-0" not found`)
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationTarget.CheckMachines", []interface{}{"", params.SerializedModel{Bytes: []byte("model")}}},
+	})
+}
+
+func (s *ClientSuite) TestCheckMachinesNotSupported(c *gc.C) {
+	client, stub := s.getClientAndStub(c)
+	_, err := client.CheckMachines([]byte("model"))
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	stub.CheckNoCalls(c)
+}
+
 func (s *ClientSuite) TestImport(c *gc.C) {
 	client, stub := s.getClientAndStub(c)
 
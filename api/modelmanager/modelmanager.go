@@ -221,6 +221,30 @@ func (c *Client) DumpModel(model names.ModelTag) (map[string]interface{}, error)
 	return result.Result, nil
 }
 
+// ExportModel returns the serialized description of the model, in the
+// format used for model migration.
+func (c *Client) ExportModel(model names.ModelTag) ([]byte, error) {
+	if c.BestAPIVersion() < 4 {
+		return nil, errors.NotSupportedf("exporting models on this version of Juju")
+	}
+	var results params.SerializedModelResults
+	entities := params.Entities{
+		Entities: []params.Entity{{Tag: model.String()}},
+	}
+	err := c.facade.FacadeCall("ExportModels", entities, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if count := len(results.Results); count != 1 {
+		return nil, errors.Errorf("unexpected result count: %d", count)
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Bytes, nil
+}
+
 // DumpModelDB returns all relevant mongo documents for the model.
 func (c *Client) DumpModelDB(model names.ModelTag) (map[string]interface{}, error) {
 	var results params.MapResults
@@ -246,11 +270,26 @@ func (c *Client) DumpModelDB(model names.ModelTag) (map[string]interface{}, erro
 // cause the model's resources to be cleaned up, after which the model will
 // be removed.
 func (c *Client) DestroyModel(tag names.ModelTag) error {
+	return c.destroyModel(tag, false)
+}
+
+// DestroyModelReleasingStorage behaves like DestroyModel, except that
+// the model's cloud volumes are released rather than destroyed, so that
+// they may later be imported into another model.
+func (c *Client) DestroyModelReleasingStorage(tag names.ModelTag) error {
+	if c.BestAPIVersion() < 3 {
+		return errors.NotSupportedf("releasing storage on this version of Juju")
+	}
+	return c.destroyModel(tag, true)
+}
+
+func (c *Client) destroyModel(tag names.ModelTag, releaseStorage bool) error {
 	var results params.ErrorResults
-	entities := params.Entities{
-		Entities: []params.Entity{{Tag: tag.String()}},
+	args := params.DestroyModelsParams{
+		Entities:       []params.Entity{{Tag: tag.String()}},
+		ReleaseStorage: releaseStorage,
 	}
-	if err := c.facade.FacadeCall("DestroyModels", entities, &results); err != nil {
+	if err := c.facade.FacadeCall("DestroyModels", args, &results); err != nil {
 		return errors.Trace(err)
 	}
 	if n := len(results.Results); n != 1 {
@@ -4,6 +4,7 @@
 package modelmanager_test
 
 import (
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
@@ -102,7 +103,7 @@ func (s *modelmanagerSuite) TestDestroyModel(c *gc.C) {
 	modelmanager.PatchFacadeCall(&s.CleanupSuite, modelManager,
 		func(req string, args interface{}, resp interface{}) error {
 			c.Assert(req, gc.Equals, "DestroyModels")
-			c.Assert(args, jc.DeepEquals, params.Entities{
+			c.Assert(args, jc.DeepEquals, params.DestroyModelsParams{
 				Entities: []params.Entity{{testing.ModelTag.String()}},
 			})
 			results := resp.(*params.ErrorResults)
@@ -118,6 +119,79 @@ func (s *modelmanagerSuite) TestDestroyModel(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *modelmanagerSuite) TestDestroyModelReleasingStorage(c *gc.C) {
+	modelManager := s.OpenAPI(c)
+	defer modelManager.Close()
+	var called bool
+	modelmanager.PatchFacadeCall(&s.CleanupSuite, modelManager,
+		func(req string, args interface{}, resp interface{}) error {
+			c.Assert(req, gc.Equals, "DestroyModels")
+			c.Assert(args, jc.DeepEquals, params.DestroyModelsParams{
+				Entities:       []params.Entity{{testing.ModelTag.String()}},
+				ReleaseStorage: true,
+			})
+			results := resp.(*params.ErrorResults)
+			*results = params.ErrorResults{
+				Results: []params.ErrorResult{{}},
+			}
+			called = true
+			return nil
+		})
+
+	err := modelManager.DestroyModelReleasingStorage(testing.ModelTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *modelmanagerSuite) TestExportModel(c *gc.C) {
+	modelManager := s.OpenAPI(c)
+	defer modelManager.Close()
+	modelmanager.PatchFacadeCall(&s.CleanupSuite, modelManager,
+		func(req string, args interface{}, resp interface{}) error {
+			c.Assert(req, gc.Equals, "ExportModels")
+			c.Assert(args, jc.DeepEquals, params.Entities{[]params.Entity{{testing.ModelTag.String()}}})
+			results := resp.(*params.SerializedModelResults)
+			*results = params.SerializedModelResults{
+				Results: []params.SerializedModelResult{{Bytes: []byte("model")}},
+			}
+			return nil
+		})
+
+	out, err := modelManager.ExportModel(testing.ModelTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(out), gc.Equals, "model")
+}
+
+func (s *modelmanagerSuite) TestExportModelError(c *gc.C) {
+	modelManager := s.OpenAPI(c)
+	defer modelManager.Close()
+	modelmanager.PatchFacadeCall(&s.CleanupSuite, modelManager,
+		func(req string, args interface{}, resp interface{}) error {
+			results := resp.(*params.SerializedModelResults)
+			*results = params.SerializedModelResults{
+				Results: []params.SerializedModelResult{{
+					Error: &params.Error{Message: "fake error"},
+				}},
+			}
+			return nil
+		})
+
+	out, err := modelManager.ExportModel(testing.ModelTag)
+	c.Assert(err, gc.ErrorMatches, "fake error")
+	c.Assert(out, gc.IsNil)
+}
+
+func (s *modelmanagerSuite) TestExportModelNotSupported(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, args, result interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		})
+	client := modelmanager.NewClient(apiCaller)
+	_, err := client.ExportModel(testing.ModelTag)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *modelmanagerSuite) TestModelDefaults(c *gc.C) {
 	apiCaller := basetesting.APICallerFunc(
 		func(objType string,
@@ -9,6 +9,7 @@ import (
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
+	jujustorage "github.com/juju/juju/storage"
 )
 
 // Client allows access to the storage API end point.
@@ -139,6 +140,13 @@ func (c *Client) ListFilesystems(machines []string) ([]params.FilesystemDetailsL
 
 // AddToUnit adds specified storage to desired units.
 func (c *Client) AddToUnit(storages []params.StorageAddParams) ([]params.ErrorResult, error) {
+	if c.BestAPIVersion() < 6 {
+		for _, s := range storages {
+			if s.SnapshotId != "" {
+				return nil, errors.NotSupportedf("adding storage from a snapshot on this version of Juju")
+			}
+		}
+	}
 	out := params.ErrorResults{}
 	in := params.StoragesAddParams{Storages: storages}
 	err := c.facade.FacadeCall("AddToUnit", in, &out)
@@ -147,3 +155,196 @@ func (c *Client) AddToUnit(storages []params.StorageAddParams) ([]params.ErrorRe
 	}
 	return out.Results, nil
 }
+
+// Detach detaches the specified storage from the units it is attached
+// to, without destroying it. The storage can then be attached to
+// another unit.
+func (c *Client) Detach(storageIds []string) ([]params.ErrorResult, error) {
+	if c.BestAPIVersion() < 4 {
+		return nil, errors.NotSupportedf("detaching storage on this version of Juju")
+	}
+	ids := make([]params.StorageAttachmentId, len(storageIds))
+	for i, id := range storageIds {
+		if !names.IsValidStorage(id) {
+			return nil, errors.NotValidf("storage ID %q", id)
+		}
+		ids[i] = params.StorageAttachmentId{
+			StorageTag: names.NewStorageTag(id).String(),
+		}
+	}
+	var out params.ErrorResults
+	args := params.StorageAttachmentIds{ids}
+	if err := c.facade.FacadeCall("Detach", args, &out); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(out.Results) != len(storageIds) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(storageIds), len(out.Results))
+	}
+	return out.Results, nil
+}
+
+// Attach attaches the specified detached storage to the unit.
+func (c *Client) Attach(unitId string, storageIds []string) ([]params.ErrorResult, error) {
+	if c.BestAPIVersion() < 4 {
+		return nil, errors.NotSupportedf("attaching storage on this version of Juju")
+	}
+	if !names.IsValidUnit(unitId) {
+		return nil, errors.NotValidf("unit ID %q", unitId)
+	}
+	ids := make([]params.StorageAttachmentId, len(storageIds))
+	for i, id := range storageIds {
+		if !names.IsValidStorage(id) {
+			return nil, errors.NotValidf("storage ID %q", id)
+		}
+		ids[i] = params.StorageAttachmentId{
+			StorageTag: names.NewStorageTag(id).String(),
+			UnitTag:    names.NewUnitTag(unitId).String(),
+		}
+	}
+	var out params.ErrorResults
+	args := params.StorageAttachmentIds{ids}
+	if err := c.facade.FacadeCall("Attach", args, &out); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(out.Results) != len(storageIds) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(storageIds), len(out.Results))
+	}
+	return out.Results, nil
+}
+
+// Import imports the existing volume with the given provider ID, from the
+// specified storage pool, into the model as a storage instance with the
+// given name. A volume imported as filesystem storage must contain a
+// filesystem. The imported storage is not attached to any unit.
+func (c *Client) Import(
+	kind jujustorage.StorageKind,
+	storagePool, providerId, storageName string,
+) (names.StorageTag, error) {
+	if c.BestAPIVersion() < 5 {
+		return names.StorageTag{}, errors.NotSupportedf("importing storage on this version of Juju")
+	}
+	var paramsKind params.StorageKind
+	switch kind {
+	case jujustorage.StorageKindBlock:
+		paramsKind = params.StorageKindBlock
+	case jujustorage.StorageKindFilesystem:
+		paramsKind = params.StorageKindFilesystem
+	default:
+		return names.StorageTag{}, errors.NotValidf("storage kind %q", kind)
+	}
+	args := params.BulkImportStorageParams{[]params.ImportStorageParams{{
+		Kind:        paramsKind,
+		Pool:        storagePool,
+		ProviderId:  providerId,
+		StorageName: storageName,
+	}}}
+	var out params.ImportStorageResults
+	if err := c.facade.FacadeCall("Import", args, &out); err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	if len(out.Results) != 1 {
+		return names.StorageTag{}, errors.Errorf("expected 1 result, got %d", len(out.Results))
+	}
+	if err := out.Results[0].Error; err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	return names.ParseStorageTag(out.Results[0].Result.StorageTag)
+}
+
+// CreateSnapshots creates a snapshot of the volume assigned to, or
+// backing the filesystem of, each of the specified storage instances.
+func (c *Client) CreateSnapshots(storageIds []string) ([]params.CreateSnapshotResult, error) {
+	if c.BestAPIVersion() < 6 {
+		return nil, errors.NotSupportedf("snapshotting storage on this version of Juju")
+	}
+	entities := make([]params.Entity, len(storageIds))
+	for i, id := range storageIds {
+		if !names.IsValidStorage(id) {
+			return nil, errors.NotValidf("storage ID %q", id)
+		}
+		entities[i].Tag = names.NewStorageTag(id).String()
+	}
+	var out params.CreateSnapshotResults
+	args := params.Entities{entities}
+	if err := c.facade.FacadeCall("CreateSnapshots", args, &out); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(out.Results) != len(storageIds) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(storageIds), len(out.Results))
+	}
+	return out.Results, nil
+}
+
+// ListSnapshots lists the snapshots of the volume assigned to, or
+// backing the filesystem of, each of the specified storage instances.
+func (c *Client) ListSnapshots(storageIds []string) ([]params.ListSnapshotsResult, error) {
+	if c.BestAPIVersion() < 6 {
+		return nil, errors.NotSupportedf("listing storage snapshots on this version of Juju")
+	}
+	entities := make([]params.Entity, len(storageIds))
+	for i, id := range storageIds {
+		if !names.IsValidStorage(id) {
+			return nil, errors.NotValidf("storage ID %q", id)
+		}
+		entities[i].Tag = names.NewStorageTag(id).String()
+	}
+	var out params.ListSnapshotsResults
+	args := params.Entities{entities}
+	if err := c.facade.FacadeCall("ListSnapshots", args, &out); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(out.Results) != len(storageIds) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(storageIds), len(out.Results))
+	}
+	return out.Results, nil
+}
+
+// DeleteSnapshots deletes the specified snapshots of the volume assigned
+// to, or backing the filesystem of, the specified storage instance.
+func (c *Client) DeleteSnapshots(storageId string, snapshotIds []string) ([]params.ErrorResult, error) {
+	if c.BestAPIVersion() < 6 {
+		return nil, errors.NotSupportedf("removing storage snapshots on this version of Juju")
+	}
+	if !names.IsValidStorage(storageId) {
+		return nil, errors.NotValidf("storage ID %q", storageId)
+	}
+	storageTag := names.NewStorageTag(storageId).String()
+	args := params.DeleteSnapshotsParams{
+		Snapshots: make([]params.DeleteSnapshotParams, len(snapshotIds)),
+	}
+	for i, id := range snapshotIds {
+		args.Snapshots[i] = params.DeleteSnapshotParams{
+			StorageTag: storageTag,
+			SnapshotId: id,
+		}
+	}
+	var out params.ErrorResults
+	if err := c.facade.FacadeCall("DeleteSnapshots", args, &out); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(out.Results) != len(snapshotIds) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(snapshotIds), len(out.Results))
+	}
+	return out.Results, nil
+}
+
+// Resize requests that the specified storage instance be grown to the
+// specified size, in MiB. The resize happens asynchronously; the
+// storage's units are notified once it has completed.
+func (c *Client) Resize(storageId string, size uint64) error {
+	if c.BestAPIVersion() < 7 {
+		return errors.NotSupportedf("resizing storage on this version of Juju")
+	}
+	if !names.IsValidStorage(storageId) {
+		return errors.NotValidf("storage ID %q", storageId)
+	}
+	args := params.BulkResizeStorageParams{[]params.ResizeStorageParams{{
+		StorageTag: names.NewStorageTag(storageId).String(),
+		Size:       size,
+	}}}
+	var out params.ErrorResults
+	if err := c.facade.FacadeCall("Resize", args, &out); err != nil {
+		return errors.Trace(err)
+	}
+	return out.OneError()
+}
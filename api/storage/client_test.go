@@ -16,6 +16,7 @@ import (
 	"github.com/juju/juju/api/storage"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
+	jujustorage "github.com/juju/juju/storage"
 	"github.com/juju/juju/testing"
 )
 
@@ -581,3 +582,351 @@ func (s *storageMockSuite) TestAddToUnitFacadeCallError(c *gc.C) {
 	c.Assert(errors.Cause(err), gc.ErrorMatches, msg)
 	c.Assert(found, gc.HasLen, 0)
 }
+
+func (s *storageMockSuite) TestDetach(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.BestVersionCaller{APICallerFunc: basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(version, gc.Equals, 4)
+			c.Check(request, gc.Equals, "Detach")
+			c.Check(a, jc.DeepEquals, params.StorageAttachmentIds{[]params.StorageAttachmentId{
+				{StorageTag: "storage-foo-0"},
+				{StorageTag: "storage-data-1"},
+			}})
+			results := result.(*params.ErrorResults)
+			results.Results = []params.ErrorResult{{}, {Error: &params.Error{Message: "boom"}}}
+			called = true
+			return nil
+		},
+	), BestVersion: 4}
+	storageClient := storage.NewClient(apiCaller)
+	results, err := storageClient.Detach([]string{"foo/0", "data/1"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(results, jc.DeepEquals, []params.ErrorResult{{}, {Error: &params.Error{Message: "boom"}}})
+}
+
+func (s *storageMockSuite) TestDetachInvalidStorageId(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{APICallerFunc: basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+	), BestVersion: 4}
+	storageClient := storage.NewClient(apiCaller)
+	_, err := storageClient.Detach([]string{"foo"})
+	c.Assert(err, gc.ErrorMatches, `storage ID "foo" not valid`)
+}
+
+func (s *storageMockSuite) TestDetachNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{APICallerFunc: basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+	), BestVersion: 3}
+	storageClient := storage.NewClient(apiCaller)
+	_, err := storageClient.Detach([]string{"foo/0"})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *storageMockSuite) TestAttach(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.BestVersionCaller{APICallerFunc: basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(version, gc.Equals, 4)
+			c.Check(request, gc.Equals, "Attach")
+			c.Check(a, jc.DeepEquals, params.StorageAttachmentIds{[]params.StorageAttachmentId{
+				{StorageTag: "storage-data-0", UnitTag: "unit-mysql-1"},
+			}})
+			results := result.(*params.ErrorResults)
+			results.Results = []params.ErrorResult{{}}
+			called = true
+			return nil
+		},
+	), BestVersion: 4}
+	storageClient := storage.NewClient(apiCaller)
+	results, err := storageClient.Attach("mysql/1", []string{"data/0"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(results, jc.DeepEquals, []params.ErrorResult{{}})
+}
+
+func (s *storageMockSuite) TestAttachInvalidUnitId(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{APICallerFunc: basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+	), BestVersion: 4}
+	storageClient := storage.NewClient(apiCaller)
+	_, err := storageClient.Attach("mysql", []string{"data/0"})
+	c.Assert(err, gc.ErrorMatches, `unit ID "mysql" not valid`)
+}
+
+func (s *storageMockSuite) TestImport(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.BestVersionCaller{APICallerFunc: basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(version, gc.Equals, 5)
+			c.Check(request, gc.Equals, "Import")
+			c.Check(a, jc.DeepEquals, params.BulkImportStorageParams{[]params.ImportStorageParams{{
+				Kind:        params.StorageKindFilesystem,
+				Pool:        "ebs",
+				ProviderId:  "vol-123",
+				StorageName: "pgdata",
+			}}})
+			results := result.(*params.ImportStorageResults)
+			results.Results = []params.ImportStorageResult{{
+				Result: &params.ImportStorageDetails{StorageTag: "storage-pgdata-0"},
+			}}
+			called = true
+			return nil
+		},
+	), BestVersion: 5}
+	storageClient := storage.NewClient(apiCaller)
+	storageTag, err := storageClient.Import(jujustorage.StorageKindFilesystem, "ebs", "vol-123", "pgdata")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(storageTag, gc.Equals, names.NewStorageTag("pgdata/0"))
+}
+
+func (s *storageMockSuite) TestImportError(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{APICallerFunc: basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			results := result.(*params.ImportStorageResults)
+			results.Results = []params.ImportStorageResult{{
+				Error: &params.Error{Message: "volume is in use"},
+			}}
+			return nil
+		},
+	), BestVersion: 5}
+	storageClient := storage.NewClient(apiCaller)
+	_, err := storageClient.Import(jujustorage.StorageKindBlock, "ebs", "vol-123", "data")
+	c.Assert(err, gc.ErrorMatches, "volume is in use")
+}
+
+func (s *storageMockSuite) TestImportNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{APICallerFunc: basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+	), BestVersion: 4}
+	storageClient := storage.NewClient(apiCaller)
+	_, err := storageClient.Import(jujustorage.StorageKindBlock, "ebs", "vol-123", "data")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *storageMockSuite) TestAddToUnitFromSnapshotNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{APICallerFunc: basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+	), BestVersion: 5}
+	storageClient := storage.NewClient(apiCaller)
+	_, err := storageClient.AddToUnit([]params.StorageAddParams{{
+		UnitTag:     "unit-mysql-0",
+		StorageName: "data",
+		SnapshotId:  "snap-123",
+	}})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *storageMockSuite) TestCreateSnapshots(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.BestVersionCaller{APICallerFunc: basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(version, gc.Equals, 6)
+			c.Check(request, gc.Equals, "CreateSnapshots")
+			c.Check(a, jc.DeepEquals, params.Entities{[]params.Entity{
+				{Tag: "storage-data-0"},
+				{Tag: "storage-data-1"},
+			}})
+			results := result.(*params.CreateSnapshotResults)
+			results.Results = []params.CreateSnapshotResult{{
+				Result: &params.SnapshotDetails{SnapshotId: "snap-123"},
+			}, {
+				Error: &params.Error{Message: "boom"},
+			}}
+			called = true
+			return nil
+		},
+	), BestVersion: 6}
+	storageClient := storage.NewClient(apiCaller)
+	results, err := storageClient.CreateSnapshots([]string{"data/0", "data/1"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(results, jc.DeepEquals, []params.CreateSnapshotResult{{
+		Result: &params.SnapshotDetails{SnapshotId: "snap-123"},
+	}, {
+		Error: &params.Error{Message: "boom"},
+	}})
+}
+
+func (s *storageMockSuite) TestCreateSnapshotsInvalidStorageId(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{APICallerFunc: basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+	), BestVersion: 6}
+	storageClient := storage.NewClient(apiCaller)
+	_, err := storageClient.CreateSnapshots([]string{"foo"})
+	c.Assert(err, gc.ErrorMatches, `storage ID "foo" not valid`)
+}
+
+func (s *storageMockSuite) TestCreateSnapshotsNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{APICallerFunc: basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+	), BestVersion: 5}
+	storageClient := storage.NewClient(apiCaller)
+	_, err := storageClient.CreateSnapshots([]string{"data/0"})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *storageMockSuite) TestListSnapshots(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.BestVersionCaller{APICallerFunc: basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(version, gc.Equals, 6)
+			c.Check(request, gc.Equals, "ListSnapshots")
+			c.Check(a, jc.DeepEquals, params.Entities{[]params.Entity{
+				{Tag: "storage-data-0"},
+			}})
+			results := result.(*params.ListSnapshotsResults)
+			results.Results = []params.ListSnapshotsResult{{
+				Result: []params.SnapshotDetails{{SnapshotId: "snap-123"}, {Pending: true}},
+			}}
+			called = true
+			return nil
+		},
+	), BestVersion: 6}
+	storageClient := storage.NewClient(apiCaller)
+	results, err := storageClient.ListSnapshots([]string{"data/0"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(results, jc.DeepEquals, []params.ListSnapshotsResult{{
+		Result: []params.SnapshotDetails{{SnapshotId: "snap-123"}, {Pending: true}},
+	}})
+}
+
+func (s *storageMockSuite) TestListSnapshotsNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{APICallerFunc: basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+	), BestVersion: 5}
+	storageClient := storage.NewClient(apiCaller)
+	_, err := storageClient.ListSnapshots([]string{"data/0"})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *storageMockSuite) TestDeleteSnapshots(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.BestVersionCaller{APICallerFunc: basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(version, gc.Equals, 6)
+			c.Check(request, gc.Equals, "DeleteSnapshots")
+			c.Check(a, jc.DeepEquals, params.DeleteSnapshotsParams{[]params.DeleteSnapshotParams{
+				{StorageTag: "storage-data-0", SnapshotId: "snap-123"},
+				{StorageTag: "storage-data-0", SnapshotId: "snap-456"},
+			}})
+			results := result.(*params.ErrorResults)
+			results.Results = []params.ErrorResult{{}, {Error: &params.Error{Message: "boom"}}}
+			called = true
+			return nil
+		},
+	), BestVersion: 6}
+	storageClient := storage.NewClient(apiCaller)
+	results, err := storageClient.DeleteSnapshots("data/0", []string{"snap-123", "snap-456"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(results, jc.DeepEquals, []params.ErrorResult{{}, {Error: &params.Error{Message: "boom"}}})
+}
+
+func (s *storageMockSuite) TestDeleteSnapshotsNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{APICallerFunc: basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+	), BestVersion: 5}
+	storageClient := storage.NewClient(apiCaller)
+	_, err := storageClient.DeleteSnapshots("data/0", []string{"snap-123"})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *storageMockSuite) TestResize(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.BestVersionCaller{APICallerFunc: basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(version, gc.Equals, 7)
+			c.Check(request, gc.Equals, "Resize")
+			c.Check(a, jc.DeepEquals, params.BulkResizeStorageParams{[]params.ResizeStorageParams{{
+				StorageTag: "storage-data-0",
+				Size:       2048,
+			}}})
+			results := result.(*params.ErrorResults)
+			results.Results = []params.ErrorResult{{Error: &params.Error{Message: "boom"}}}
+			called = true
+			return nil
+		},
+	), BestVersion: 7}
+	storageClient := storage.NewClient(apiCaller)
+	err := storageClient.Resize("data/0", 2048)
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *storageMockSuite) TestResizeNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{APICallerFunc: basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+	), BestVersion: 6}
+	storageClient := storage.NewClient(apiCaller)
+	err := storageClient.Resize("data/0", 2048)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
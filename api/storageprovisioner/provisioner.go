@@ -87,6 +87,36 @@ func (st *State) WatchFilesystems() (watcher.StringsWatcher, error) {
 	return st.watchStorageEntities("WatchFilesystems")
 }
 
+// WatchVolumeResizes watches for changes to volumes scoped to the
+// entity with the tag passed to NewState, so that requests to resize
+// them may be observed.
+func (st *State) WatchVolumeResizes() (watcher.StringsWatcher, error) {
+	if st.facade.BestAPIVersion() < 4 {
+		return nil, errors.NotSupportedf("resizing volumes on this version of Juju")
+	}
+	return st.watchStorageEntities("WatchVolumeResizes")
+}
+
+// WatchFilesystemResizes watches for changes to filesystems scoped to
+// the entity with the tag passed to NewState, so that requests to
+// resize them may be observed.
+func (st *State) WatchFilesystemResizes() (watcher.StringsWatcher, error) {
+	if st.facade.BestAPIVersion() < 4 {
+		return nil, errors.NotSupportedf("resizing filesystems on this version of Juju")
+	}
+	return st.watchStorageEntities("WatchFilesystemResizes")
+}
+
+// WatchVolumeSnapshots watches for changes to volumes scoped to the
+// entity with the tag passed to NewState, so that requests to take and
+// delete snapshots of them may be observed.
+func (st *State) WatchVolumeSnapshots() (watcher.StringsWatcher, error) {
+	if st.facade.BestAPIVersion() < 5 {
+		return nil, errors.NotSupportedf("snapshotting machine-scoped volumes on this version of Juju")
+	}
+	return st.watchStorageEntities("WatchVolumeSnapshots")
+}
+
 func (st *State) watchStorageEntities(method string) (watcher.StringsWatcher, error) {
 	var results params.StringsWatchResults
 	args := params.Entities{
@@ -243,6 +273,46 @@ func (st *State) VolumeParams(tags []names.VolumeTag) ([]params.VolumeParamsResu
 	return results.Results, nil
 }
 
+// VolumeResizeParams returns the parameters for resizing the volumes
+// with the specified tags.
+func (st *State) VolumeResizeParams(tags []names.VolumeTag) ([]params.VolumeResizeParamsResult, error) {
+	args := params.Entities{
+		Entities: make([]params.Entity, len(tags)),
+	}
+	for i, tag := range tags {
+		args.Entities[i].Tag = tag.String()
+	}
+	var results params.VolumeResizeParamsResults
+	err := st.facade.FacadeCall("VolumeResizeParams", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(tags) {
+		panic(errors.Errorf("expected %d result(s), got %d", len(tags), len(results.Results)))
+	}
+	return results.Results, nil
+}
+
+// FilesystemResizeParams returns the parameters for resizing the
+// filesystems with the specified tags.
+func (st *State) FilesystemResizeParams(tags []names.FilesystemTag) ([]params.FilesystemResizeParamsResult, error) {
+	args := params.Entities{
+		Entities: make([]params.Entity, len(tags)),
+	}
+	for i, tag := range tags {
+		args.Entities[i].Tag = tag.String()
+	}
+	var results params.FilesystemResizeParamsResults
+	err := st.facade.FacadeCall("FilesystemResizeParams", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(tags) {
+		panic(errors.Errorf("expected %d result(s), got %d", len(tags), len(results.Results)))
+	}
+	return results.Results, nil
+}
+
 // FilesystemParams returns the parameters for creating the filesystems
 // with the specified tags.
 func (st *State) FilesystemParams(tags []names.FilesystemTag) ([]params.FilesystemParamsResult, error) {
@@ -307,6 +377,41 @@ func (st *State) SetVolumeInfo(volumes []params.Volume) ([]params.ErrorResult, e
 	return results.Results, nil
 }
 
+// VolumeSnapshotParams returns the parameters for the pending snapshot
+// operations on the volumes with the specified tags.
+func (st *State) VolumeSnapshotParams(tags []names.VolumeTag) ([]params.VolumeSnapshotParamsResult, error) {
+	args := params.Entities{
+		Entities: make([]params.Entity, len(tags)),
+	}
+	for i, tag := range tags {
+		args.Entities[i].Tag = tag.String()
+	}
+	var results params.VolumeSnapshotParamsResults
+	err := st.facade.FacadeCall("VolumeSnapshotParams", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(tags) {
+		panic(errors.Errorf("expected %d result(s), got %d", len(tags), len(results.Results)))
+	}
+	return results.Results, nil
+}
+
+// SetVolumeSnapshotOutcomes records the outcomes of the pending snapshot
+// operations on volumes.
+func (st *State) SetVolumeSnapshotOutcomes(outcomes []params.VolumeSnapshotOutcome) ([]params.ErrorResult, error) {
+	args := params.VolumeSnapshotOutcomes{Outcomes: outcomes}
+	var results params.ErrorResults
+	err := st.facade.FacadeCall("SetVolumeSnapshotOutcomes", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(outcomes) {
+		panic(errors.Errorf("expected %d result(s), got %d", len(outcomes), len(results.Results)))
+	}
+	return results.Results, nil
+}
+
 // SetFilesystemInfo records the details of newly provisioned filesystems.
 func (st *State) SetFilesystemInfo(filesystems []params.Filesystem) ([]params.ErrorResult, error) {
 	args := params.Filesystems{Filesystems: filesystems}
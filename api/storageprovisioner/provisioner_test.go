@@ -6,6 +6,7 @@ package storageprovisioner_test
 import (
 	"errors"
 
+	jujuerrors "github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
@@ -398,6 +399,117 @@ func (s *provisionerSuite) TestVolumeParams(c *gc.C) {
 	}})
 }
 
+func (s *provisionerSuite) TestVolumeResizeParams(c *gc.C) {
+	var callCount int
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "StorageProvisioner")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "VolumeResizeParams")
+		c.Check(arg, gc.DeepEquals, params.Entities{Entities: []params.Entity{{"volume-100"}}})
+		c.Assert(result, gc.FitsTypeOf, &params.VolumeResizeParamsResults{})
+		*(result.(*params.VolumeResizeParamsResults)) = params.VolumeResizeParamsResults{
+			Results: []params.VolumeResizeParamsResult{{
+				Result: params.VolumeResizeParams{
+					VolumeTag: "volume-100",
+					VolumeId:  "vol-100",
+					Provider:  "loop",
+					Size:      2048,
+				},
+			}},
+		}
+		callCount++
+		return nil
+	})
+
+	st, err := storageprovisioner.NewState(apiCaller, names.NewMachineTag("123"))
+	c.Assert(err, jc.ErrorIsNil)
+	resizeParams, err := st.VolumeResizeParams([]names.VolumeTag{names.NewVolumeTag("100")})
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(callCount, gc.Equals, 1)
+	c.Assert(resizeParams, jc.DeepEquals, []params.VolumeResizeParamsResult{{
+		Result: params.VolumeResizeParams{
+			VolumeTag: "volume-100", VolumeId: "vol-100", Provider: "loop", Size: 2048,
+		},
+	}})
+}
+
+func (s *provisionerSuite) TestWatchVolumeResizesNotSupported(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected call to %s", request)
+		return nil
+	})
+	st, err := storageprovisioner.NewState(apiCaller, names.NewMachineTag("123"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = st.WatchVolumeResizes()
+	c.Assert(err, jc.Satisfies, jujuerrors.IsNotSupported)
+	_, err = st.WatchFilesystemResizes()
+	c.Assert(err, jc.Satisfies, jujuerrors.IsNotSupported)
+	_, err = st.WatchVolumeSnapshots()
+	c.Assert(err, jc.Satisfies, jujuerrors.IsNotSupported)
+}
+
+func (s *provisionerSuite) TestVolumeSnapshotParams(c *gc.C) {
+	var callCount int
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "StorageProvisioner")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "VolumeSnapshotParams")
+		c.Check(arg, gc.DeepEquals, params.Entities{Entities: []params.Entity{{"volume-123-0"}}})
+		c.Assert(result, gc.FitsTypeOf, &params.VolumeSnapshotParamsResults{})
+		*(result.(*params.VolumeSnapshotParamsResults)) = params.VolumeSnapshotParamsResults{
+			Results: []params.VolumeSnapshotParamsResult{{
+				Result: params.VolumeSnapshotParams{
+					VolumeTag: "volume-123-0",
+					VolumeId:  "volume-123-0",
+					Provider:  "loop",
+					Create:    true,
+				},
+			}},
+		}
+		callCount++
+		return nil
+	})
+
+	st, err := storageprovisioner.NewState(apiCaller, names.NewMachineTag("123"))
+	c.Assert(err, jc.ErrorIsNil)
+	snapshotParams, err := st.VolumeSnapshotParams([]names.VolumeTag{names.NewVolumeTag("123/0")})
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(callCount, gc.Equals, 1)
+	c.Assert(snapshotParams, jc.DeepEquals, []params.VolumeSnapshotParamsResult{{
+		Result: params.VolumeSnapshotParams{
+			VolumeTag: "volume-123-0", VolumeId: "volume-123-0", Provider: "loop", Create: true,
+		},
+	}})
+}
+
+func (s *provisionerSuite) TestSetVolumeSnapshotOutcomes(c *gc.C) {
+	outcomes := []params.VolumeSnapshotOutcome{{
+		VolumeTag: "volume-123-0",
+		Deleted:   []string{"volume-123-0@1"},
+	}}
+	var callCount int
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "StorageProvisioner")
+		c.Check(request, gc.Equals, "SetVolumeSnapshotOutcomes")
+		c.Check(arg, jc.DeepEquals, params.VolumeSnapshotOutcomes{Outcomes: outcomes})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{}},
+		}
+		callCount++
+		return nil
+	})
+
+	st, err := storageprovisioner.NewState(apiCaller, names.NewMachineTag("123"))
+	c.Assert(err, jc.ErrorIsNil)
+	results, err := st.SetVolumeSnapshotOutcomes(outcomes)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(callCount, gc.Equals, 1)
+	c.Assert(results, jc.DeepEquals, []params.ErrorResult{{}})
+}
+
 func (s *provisionerSuite) TestFilesystemParams(c *gc.C) {
 	var callCount int
 	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
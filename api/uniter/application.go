@@ -108,6 +108,27 @@ func (s *Application) CharmModifiedVersion() (int, error) {
 	return result.Result, nil
 }
 
+// HookTimeouts returns the maximum durations that the application's
+// hooks may run for.
+func (s *Application) HookTimeouts() (params.HookTimeouts, error) {
+	var results params.HookTimeoutsResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: s.tag.String()}},
+	}
+	err := s.st.facade.FacadeCall("HookTimeouts", args, &results)
+	if err != nil {
+		return params.HookTimeouts{}, err
+	}
+	if len(results.Results) != 1 {
+		return params.HookTimeouts{}, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.HookTimeouts{}, result.Error
+	}
+	return result.Result, nil
+}
+
 // CharmURL returns the service's charm URL, and whether units should
 // upgrade to the charm with that URL even if they are in an error
 // state (force flag).
@@ -122,6 +122,24 @@ func (s *serviceSuite) TestCharmModifiedVersion(c *gc.C) {
 	c.Assert(ver, gc.Equals, s.wordpressService.CharmModifiedVersion())
 }
 
+func (s *serviceSuite) TestHookTimeouts(c *gc.C) {
+	timeouts, err := s.apiService.HookTimeouts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(timeouts, jc.DeepEquals, params.HookTimeouts{})
+
+	err = s.wordpressService.SetHookTimeouts(state.HookTimeouts{
+		Default: time.Minute,
+		Hooks:   map[string]time.Duration{"install": time.Hour},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	timeouts, err = s.apiService.HookTimeouts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(timeouts, jc.DeepEquals, params.HookTimeouts{
+		Default: time.Minute,
+		Hooks:   map[string]time.Duration{"install": time.Hour},
+	})
+}
+
 func (s *serviceSuite) TestSetServiceStatus(c *gc.C) {
 	message := "a test message"
 	stat, err := s.wordpressService.Status()
@@ -80,6 +80,11 @@ func (a *admin) login(req params.LoginRequest, loginVersion int) (params.LoginRe
 		kind, err = names.TagKind(req.AuthTag)
 		if err != nil || kind != names.UserTagKind {
 			isUser = false
+			// Remote agents must connect to another controller
+			// while this one is draining.
+			if a.srv.isDraining() && req.AuthTag != a.srv.tag.String() {
+				return fail, errControllerDraining
+			}
 			// Users are not rate limited, all other entities are.
 			if !a.srv.limiter.Acquire() {
 				logger.Debugf("rate limiting for agent %s", req.AuthTag)
@@ -147,6 +152,9 @@ func (a *admin) login(req params.LoginRequest, loginVersion int) (params.LoginRe
 			return fail, errors.Trace(err)
 		}
 	}
+	if a.srv.isRemoteAgent(entity.Tag()) {
+		a.srv.trackAgentConn(a.root.rpcConn)
+	}
 
 	var maybeUserInfo *params.AuthUserInfo
 	// Send back user info if user
@@ -31,6 +31,7 @@ import (
 	_ "github.com/juju/juju/apiserver/diskmanager"
 	_ "github.com/juju/juju/apiserver/firewaller"
 	_ "github.com/juju/juju/apiserver/highavailability" // ModelUser Write
+	_ "github.com/juju/juju/apiserver/hostfirewaller"
 	_ "github.com/juju/juju/apiserver/hostkeyreporter"
 	_ "github.com/juju/juju/apiserver/imagemanager" // ModelUser Write
 	_ "github.com/juju/juju/apiserver/imagemetadata"
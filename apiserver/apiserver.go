@@ -38,6 +38,7 @@ import (
 	"github.com/juju/juju/rpc"
 	"github.com/juju/juju/rpc/jsoncodec"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/worker/introspection"
 )
 
 var logger = loggo.GetLogger("juju.apiserver")
@@ -67,6 +68,7 @@ type Server struct {
 	centralHub        *pubsub.StructuredHub
 	newObserver       observer.ObserverFactory
 	connCount         int64
+	draining          int32
 	certChanged       <-chan params.StateServingInfo
 	tlsConfig         *tls.Config
 	allowModelAccess  bool
@@ -81,11 +83,19 @@ type Server struct {
 	// certDNSNames holds the DNS names associated with cert.
 	certDNSNames []string
 
+	// agentConns holds the connections of logged in remote agents,
+	// which are closed if the controller is drained.
+	agentConns map[*rpc.Conn]struct{}
+
 	// registerIntrospectionHandlers is a function that will
 	// call a function with (path, http.Handler) tuples. This
 	// is to support registering the handlers underneath the
 	// "/introspection" prefix.
 	registerIntrospectionHandlers func(func(string, http.Handler))
+
+	// dependencyReporter reports on the workers run by the machine
+	// agent, for inclusion in the controller's health reports.
+	dependencyReporter introspection.DepEngineReporter
 }
 
 // LoginValidator functions are used to decide whether login requests
@@ -134,6 +144,11 @@ type ServerConfig struct {
 	// is to support registering the handlers underneath the
 	// "/introspection" prefix.
 	RegisterIntrospectionHandlers func(func(string, http.Handler))
+
+	// DependencyReporter, if non-nil, reports on the workers run
+	// by the machine agent. A summary of its report is recorded
+	// periodically as part of the controller's health.
+	DependencyReporter introspection.DepEngineReporter
 }
 
 func (c *ServerConfig) Validate() error {
@@ -209,6 +224,7 @@ func newServer(s *state.State, lis net.Listener, cfg ServerConfig) (_ *Server, e
 		certChanged:                   cfg.CertChanged,
 		allowModelAccess:              cfg.AllowModelAccess,
 		registerIntrospectionHandlers: cfg.RegisterIntrospectionHandlers,
+		dependencyReporter:            cfg.DependencyReporter,
 	}
 
 	srv.tlsConfig = srv.newTLSConfig(cfg)
@@ -355,6 +371,18 @@ func (srv *Server) run() {
 		srv.tomb.Kill(srv.processModelRemovals())
 	}()
 
+	srv.wg.Add(1)
+	go func() {
+		defer srv.wg.Done()
+		srv.tomb.Kill(srv.processControllerDrain())
+	}()
+
+	srv.wg.Add(1)
+	go func() {
+		defer srv.wg.Done()
+		srv.tomb.Kill(srv.processHealthReports())
+	}()
+
 	// for pat based handlers, they are matched in-order of being
 	// registered, first match wins. So more specific ones have to be
 	// registered first.
@@ -661,6 +689,7 @@ func (srv *Server) serveConn(wsConn *websocket.Conn, modelUUID string, apiObserv
 	codec := jsoncodec.NewWebsocket(wsConn)
 
 	conn := rpc.NewConn(codec, apiObserver)
+	defer srv.untrackAgentConn(conn)
 
 	// Note that we don't overwrite modelUUID here because
 	// newAPIHandler treats an empty modelUUID as signifying
@@ -27,6 +27,7 @@ import (
 	"github.com/juju/juju/feature"
 	"github.com/juju/juju/instance"
 	jjj "github.com/juju/juju/juju"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
 )
@@ -42,6 +43,21 @@ func init() {
 
 	// Version 3 adds support for cross model relations.
 	common.RegisterStandardFacade("Application", 3, newAPI)
+
+	// Version 4 adds support for hook timeouts and rolling charm
+	// upgrades.
+	common.RegisterStandardFacade("Application", 4, newAPI)
+
+	// Version 5 adds support for releasing storage when
+	// destroying an application.
+	common.RegisterStandardFacade("Application", 5, newAPI)
+
+	// Version 6 adds support for restricting the sources from
+	// which an exposed application may be reached.
+	common.RegisterStandardFacade("Application", 6, newAPI)
+
+	// Version 7 adds support for egress rules.
+	common.RegisterStandardFacade("Application", 7, newAPI)
 }
 
 // API implements the application interface and is the concrete
@@ -147,6 +163,250 @@ func (api *API) SetMetricCredentials(args params.ApplicationMetricCredentials) (
 	return result, nil
 }
 
+// HookTimeouts returns the hook timeouts of the specified applications.
+func (api *API) HookTimeouts(args params.Entities) (params.HookTimeoutsResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.HookTimeoutsResults{}, errors.Trace(err)
+	}
+	result := params.HookTimeoutsResults{
+		Results: make([]params.HookTimeoutsResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseApplicationTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		application, err := api.backend.Application(tag.Id())
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		timeouts := application.HookTimeouts()
+		result.Results[i].Result = params.HookTimeouts{
+			Default: timeouts.Default,
+			Hooks:   timeouts.Hooks,
+		}
+	}
+	return result, nil
+}
+
+// SetHookTimeouts sets the hook timeouts of the specified applications.
+func (api *API) SetHookTimeouts(args params.ApplicationHookTimeoutsArgs) (params.ErrorResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		application, err := api.backend.Application(arg.ApplicationName)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		err = application.SetHookTimeouts(state.HookTimeouts{
+			Default: arg.Timeouts.Default,
+			Hooks:   arg.Timeouts.Hooks,
+		})
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+		}
+	}
+	return result, nil
+}
+
+// EgressRules returns the egress rules of the specified applications.
+func (api *API) EgressRules(args params.Entities) (params.EgressRulesResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.EgressRulesResults{}, errors.Trace(err)
+	}
+	result := params.EgressRulesResults{
+		Results: make([]params.EgressRulesResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseApplicationTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		application, err := api.backend.Application(tag.Id())
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		rules, err := application.EgressRules()
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Rules = make([]string, len(rules))
+		for j, rule := range rules {
+			result.Results[i].Rules[j] = rule.String()
+		}
+	}
+	return result, nil
+}
+
+// SetEgressRules replaces the egress rules of the specified
+// applications.
+func (api *API) SetEgressRules(args params.ApplicationEgressRulesArgs) (params.ErrorResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		err := api.setEgressRules(arg)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+		}
+	}
+	return result, nil
+}
+
+func (api *API) setEgressRules(arg params.ApplicationEgressRules) error {
+	rules := make([]network.EgressRule, len(arg.Rules))
+	for i, in := range arg.Rules {
+		rule, err := network.ParseEgressRule(in)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		rules[i] = rule
+	}
+	application, err := api.backend.Application(arg.ApplicationName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return application.SetEgressRules(rules)
+}
+
+// RollingUpgrades returns the rolling charm upgrades in progress for the
+// specified applications. The result for an application with no rolling
+// upgrade in progress is empty.
+func (api *API) RollingUpgrades(args params.Entities) (params.RollingUpgradeResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.RollingUpgradeResults{}, errors.Trace(err)
+	}
+	result := params.RollingUpgradeResults{
+		Results: make([]params.RollingUpgradeResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseApplicationTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		application, err := api.backend.Application(tag.Id())
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		upgrade, ok := application.RollingUpgrade()
+		if !ok {
+			continue
+		}
+		curl, _ := application.CharmURL()
+		result.Results[i].Result = &params.RollingUpgrade{
+			CharmURL:         curl.String(),
+			PreviousCharmURL: upgrade.PreviousCharmURL.String(),
+			BatchSize:        upgrade.BatchSize,
+			LeaderFirst:      upgrade.LeaderFirst,
+			Released:         upgrade.Released,
+			Paused:           upgrade.Paused,
+			PauseReason:      upgrade.PauseReason,
+		}
+	}
+	return result, nil
+}
+
+// ReleaseRollingUpgradeUnits releases units of the specified
+// applications to their rolling charm upgrades.
+func (api *API) ReleaseRollingUpgradeUnits(args params.ReleaseRollingUpgradeUnitsArgs) (params.ErrorResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		application, err := api.backend.Application(arg.ApplicationName)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		if err := application.ReleaseRollingUpgradeUnits(arg.Units...); err != nil {
+			result.Results[i].Error = common.ServerError(err)
+		}
+	}
+	return result, nil
+}
+
+// PauseRollingUpgrade records that the rolling charm upgrades of the
+// specified applications were paused.
+func (api *API) PauseRollingUpgrade(args params.PauseRollingUpgradeArgs) (params.ErrorResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		application, err := api.backend.Application(arg.ApplicationName)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		if err := application.PauseRollingUpgrade(arg.Reason); err != nil {
+			result.Results[i].Error = common.ServerError(err)
+		}
+	}
+	return result, nil
+}
+
+// FinishRollingUpgrade ends the rolling charm upgrades of the specified
+// applications, releasing any remaining units to them.
+func (api *API) FinishRollingUpgrade(args params.Entities) (params.ErrorResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseApplicationTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		application, err := api.backend.Application(tag.Id())
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		if err := application.FinishRollingUpgrade(); err != nil {
+			result.Results[i].Error = common.ServerError(err)
+		}
+	}
+	return result, nil
+}
+
 // Deploy fetches the charms from the charm store and deploys them
 // using the specified placement directives.
 func (api *API) Deploy(args params.ApplicationsDeploy) (params.ErrorResults, error) {
@@ -313,6 +573,7 @@ func (api *API) Update(args params.ApplicationUpdate) error {
 			args.ForceCharmURL,
 			nil, // resource IDs
 			nil, // storage constraints
+			nil, // rolling upgrade
 		); err != nil {
 			return errors.Trace(err)
 		}
@@ -367,6 +628,7 @@ func (api *API) SetCharm(args params.ApplicationSetCharm) error {
 		args.ForceUnits,
 		args.ResourceIDs,
 		args.StorageConstraints,
+		args.RollingUpgrade,
 	)
 }
 
@@ -382,6 +644,7 @@ func (api *API) applicationSetCharm(
 	forceUnits bool,
 	resourceIDs map[string]string,
 	storageConstraints map[string]params.StorageConstraints,
+	rollingUpgrade *params.RollingUpgradeParams,
 ) error {
 	curl, err := charm.ParseURL(url)
 	if err != nil {
@@ -423,6 +686,12 @@ func (api *API) applicationSetCharm(
 		ResourceIDs:        resourceIDs,
 		StorageConstraints: stateStorageConstraints,
 	}
+	if rollingUpgrade != nil {
+		cfg.RollingUpgrade = &state.RollingUpgradeParams{
+			BatchSize:   rollingUpgrade.BatchSize,
+			LeaderFirst: rollingUpgrade.LeaderFirst,
+		}
+	}
 	return application.SetCharm(cfg)
 }
 
@@ -567,7 +836,8 @@ func (api *API) CharmRelations(p params.ApplicationCharmRelations) (params.Appli
 }
 
 // Expose changes the juju-managed firewall to expose any ports that
-// were also explicitly marked by units as open.
+// were also explicitly marked by units as open. If ToCIDRs or ToSpaces
+// are specified, the ports are only opened to those sources.
 func (api *API) Expose(args params.ApplicationExpose) error {
 	if err := api.checkCanWrite(); err != nil {
 		return err
@@ -579,6 +849,9 @@ func (api *API) Expose(args params.ApplicationExpose) error {
 	if err != nil {
 		return err
 	}
+	if len(args.ToCIDRs) > 0 || len(args.ToSpaces) > 0 {
+		return app.SetExposedTo(args.ToCIDRs, args.ToSpaces)
+	}
 	return app.SetExposed()
 }
 
@@ -662,7 +935,9 @@ type appDestroy interface {
 	Destroy() (err error)
 }
 
-// Destroy destroys a given application, local or remote.
+// Destroy destroys a given application, local or remote. If
+// ReleaseStorage is set, the application's cloud volumes are
+// released rather than destroyed.
 func (api *API) Destroy(args params.ApplicationDestroy) error {
 	if err := api.checkCanWrite(); err != nil {
 		return err
@@ -674,6 +949,14 @@ func (api *API) Destroy(args params.ApplicationDestroy) error {
 		app appDestroy
 		err error
 	)
+	if args.ReleaseStorage {
+		// Only local applications have storage to release.
+		application, err := api.backend.Application(args.ApplicationName)
+		if err != nil {
+			return err
+		}
+		return application.DestroyReleasingStorage()
+	}
 	app, err = api.backend.RemoteApplication(args.ApplicationName)
 	if errors.IsNotFound(err) {
 		app, err = api.backend.Application(args.ApplicationName)
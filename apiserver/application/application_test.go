@@ -1832,7 +1832,7 @@ func (s *serviceSuite) TestServiceExpose(c *gc.C) {
 	c.Assert(svcs[1].IsExposed(), jc.IsTrue)
 	for i, t := range serviceExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err = s.applicationAPI.Expose(params.ApplicationExpose{t.service})
+		err = s.applicationAPI.Expose(params.ApplicationExpose{ApplicationName: t.service})
 		if t.err != "" {
 			c.Assert(err, gc.ErrorMatches, t.err)
 		} else {
@@ -1844,6 +1844,40 @@ func (s *serviceSuite) TestServiceExpose(c *gc.C) {
 	}
 }
 
+func (s *serviceSuite) TestServiceExposeTo(c *gc.C) {
+	charm := s.AddTestingCharm(c, "dummy")
+	app := s.AddTestingService(c, "dummy-service", charm)
+
+	err := s.applicationAPI.Expose(params.ApplicationExpose{
+		ApplicationName: "dummy-service",
+		ToCIDRs:         []string{"10.0.0.0/8"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.IsExposed(), jc.IsTrue)
+	c.Assert(app.ExposedCIDRs(), jc.DeepEquals, []string{"10.0.0.0/8"})
+
+	// Exposing without restrictions clears the CIDRs.
+	err = s.applicationAPI.Expose(params.ApplicationExpose{ApplicationName: "dummy-service"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.IsExposed(), jc.IsTrue)
+	c.Assert(app.ExposedCIDRs(), gc.HasLen, 0)
+}
+
+func (s *serviceSuite) TestServiceExposeToInvalidCIDR(c *gc.C) {
+	charm := s.AddTestingCharm(c, "dummy")
+	s.AddTestingService(c, "dummy-service", charm)
+
+	err := s.applicationAPI.Expose(params.ApplicationExpose{
+		ApplicationName: "dummy-service",
+		ToCIDRs:         []string{"10.0.0.0"},
+	})
+	c.Assert(err, gc.ErrorMatches, `.*CIDR "10.0.0.0" not valid`)
+}
+
 func (s *serviceSuite) setupServiceExpose(c *gc.C) {
 	charm := s.AddTestingCharm(c, "dummy")
 	serviceNames := []string{"dummy-service", "exposed-service"}
@@ -1884,7 +1918,7 @@ var serviceExposeTests = []struct {
 func (s *serviceSuite) assertServiceExpose(c *gc.C) {
 	for i, t := range serviceExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err := s.applicationAPI.Expose(params.ApplicationExpose{t.service})
+		err := s.applicationAPI.Expose(params.ApplicationExpose{ApplicationName: t.service})
 		if t.err != "" {
 			c.Assert(err, gc.ErrorMatches, t.err)
 		} else {
@@ -1899,7 +1933,7 @@ func (s *serviceSuite) assertServiceExpose(c *gc.C) {
 func (s *serviceSuite) assertServiceExposeBlocked(c *gc.C, msg string) {
 	for i, t := range serviceExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err := s.applicationAPI.Expose(params.ApplicationExpose{t.service})
+		err := s.applicationAPI.Expose(params.ApplicationExpose{ApplicationName: t.service})
 		s.AssertBlocked(c, err, msg)
 	}
 }
@@ -2054,7 +2088,7 @@ func (s *serviceSuite) TestApplicationDestroy(c *gc.C) {
 
 	for i, t := range applicationDestroyTests {
 		c.Logf("test %d. %s", i, t.about)
-		err := s.applicationAPI.Destroy(params.ApplicationDestroy{t.application})
+		err := s.applicationAPI.Destroy(params.ApplicationDestroy{ApplicationName: t.application})
 		if t.err != "" {
 			c.Assert(err, gc.ErrorMatches, t.err)
 		} else {
@@ -2069,12 +2103,57 @@ func (s *serviceSuite) TestApplicationDestroy(c *gc.C) {
 	serviceName := "wordpress"
 	application, err := s.State.Application(serviceName)
 	c.Assert(err, jc.ErrorIsNil)
-	err = s.applicationAPI.Destroy(params.ApplicationDestroy{serviceName})
+	err = s.applicationAPI.Destroy(params.ApplicationDestroy{ApplicationName: serviceName})
 	c.Assert(err, jc.ErrorIsNil)
 	err = application.Refresh()
 	c.Assert(err, jc.Satisfies, errors.IsNotFound)
 }
 
+func (s *serviceSuite) TestApplicationDestroyReleasingStorage(c *gc.C) {
+	s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	err := s.applicationAPI.Destroy(params.ApplicationDestroy{
+		ApplicationName: "wordpress",
+		ReleaseStorage:  true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.Application("wordpress")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *serviceSuite) TestApplicationDestroyReleasingStorageNotSupported(c *gc.C) {
+	app := s.AddTestingServiceWithStorage(c, "storage-block", s.AddTestingCharm(c, "storage-block"),
+		map[string]state.StorageConstraints{
+			"data": {Pool: "environscoped", Size: 1024, Count: 1},
+		},
+	)
+	unit, err := app.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AssignUnit(unit, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.applicationAPI.Destroy(params.ApplicationDestroy{
+		ApplicationName: "storage-block",
+		ReleaseStorage:  true,
+	})
+	c.Assert(err, gc.ErrorMatches, `.*releasing volumes with storage provider "environscoped" not supported`)
+	assertLife(c, app, state.Alive)
+}
+
+func (s *serviceSuite) TestRemoteApplicationDestroyReleasingStorage(c *gc.C) {
+	_, err := s.State.AddRemoteApplication(state.AddRemoteApplicationParams{
+		Name:        "remote-application",
+		URL:         "local:/u/me/remote",
+		SourceModel: s.State.ModelTag(),
+		Token:       "t0",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.applicationAPI.Destroy(params.ApplicationDestroy{
+		ApplicationName: "remote-application",
+		ReleaseStorage:  true,
+	})
+	c.Assert(err, gc.ErrorMatches, `application "remote-application" not found`)
+}
+
 func assertLife(c *gc.C, entity state.Living, life state.Life) {
 	err := entity.Refresh()
 	c.Assert(err, jc.ErrorIsNil)
@@ -2086,7 +2165,7 @@ func (s *serviceSuite) TestBlockServiceDestroy(c *gc.C) {
 
 	// block remove-objects
 	s.BlockRemoveObject(c, "TestBlockServiceDestroy")
-	err := s.applicationAPI.Destroy(params.ApplicationDestroy{"dummy-service"})
+	err := s.applicationAPI.Destroy(params.ApplicationDestroy{ApplicationName: "dummy-service"})
 	s.AssertBlocked(c, err, "TestBlockServiceDestroy")
 	// Tests may have invalid application names.
 	application, err := s.State.Application("dummy-service")
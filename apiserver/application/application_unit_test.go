@@ -4,6 +4,8 @@
 package application_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -15,6 +17,7 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	coretesting "github.com/juju/juju/testing"
 )
@@ -131,6 +134,262 @@ postgresql:
 	})
 }
 
+func (s *ApplicationSuite) TestHookTimeouts(c *gc.C) {
+	s.application.hookTimeouts = state.HookTimeouts{
+		Default: time.Minute,
+		Hooks:   map[string]time.Duration{"install": time.Hour},
+	}
+	results, err := s.api.HookTimeouts(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-postgresql"},
+			{Tag: "unit-postgresql-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.HookTimeoutsResults{
+		Results: []params.HookTimeoutsResult{{
+			Result: params.HookTimeouts{
+				Default: time.Minute,
+				Hooks:   map[string]time.Duration{"install": time.Hour},
+			},
+		}, {
+			Error: &params.Error{Message: `"unit-postgresql-0" is not a valid application tag`},
+		}},
+	})
+	s.backend.CheckCallNames(c, "ModelTag", "Application")
+	s.backend.CheckCall(c, 1, "Application", "postgresql")
+}
+
+func (s *ApplicationSuite) TestSetHookTimeouts(c *gc.C) {
+	s.application.SetErrors(nil, errors.New("boom"))
+	timeouts := params.HookTimeouts{
+		Default: time.Minute,
+		Hooks:   map[string]time.Duration{"install": time.Hour},
+	}
+	results, err := s.api.SetHookTimeouts(params.ApplicationHookTimeoutsArgs{
+		Args: []params.ApplicationHookTimeouts{
+			{ApplicationName: "postgresql", Timeouts: timeouts},
+			{ApplicationName: "mysql"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: "boom"}},
+		},
+	})
+	s.blockChecker.CheckCallNames(c, "ChangeAllowed")
+	s.application.CheckCalls(c, []testing.StubCall{
+		{"SetHookTimeouts", []interface{}{state.HookTimeouts{
+			Default: time.Minute,
+			Hooks:   map[string]time.Duration{"install": time.Hour},
+		}}},
+		{"SetHookTimeouts", []interface{}{state.HookTimeouts{}}},
+	})
+}
+
+func (s *ApplicationSuite) TestSetHookTimeoutsBlocked(c *gc.C) {
+	s.blockChecker.SetErrors(errors.New("blocked"))
+	_, err := s.api.SetHookTimeouts(params.ApplicationHookTimeoutsArgs{
+		Args: []params.ApplicationHookTimeouts{{ApplicationName: "postgresql"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "blocked")
+	s.application.CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestEgressRules(c *gc.C) {
+	s.application.egressRules = []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+	}
+	results, err := s.api.EgressRules(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-postgresql"},
+			{Tag: "unit-postgresql-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.EgressRulesResults{
+		Results: []params.EgressRulesResult{{
+			Rules: []string{"10.0.0.0/8:443/tcp"},
+		}, {
+			Error: &params.Error{Message: `"unit-postgresql-0" is not a valid application tag`},
+		}},
+	})
+	s.backend.CheckCallNames(c, "ModelTag", "Application")
+	s.backend.CheckCall(c, 1, "Application", "postgresql")
+}
+
+func (s *ApplicationSuite) TestSetEgressRules(c *gc.C) {
+	s.application.SetErrors(nil, errors.New("boom"))
+	results, err := s.api.SetEgressRules(params.ApplicationEgressRulesArgs{
+		Args: []params.ApplicationEgressRules{
+			{ApplicationName: "postgresql", Rules: []string{"10.0.0.0/8:443/tcp"}},
+			{ApplicationName: "mysql"},
+			{ApplicationName: "mysql", Rules: []string{"10.0.0.0:443/tcp"}},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: "boom"}},
+			{Error: &params.Error{Message: "invalid CIDR address: 10.0.0.0"}},
+		},
+	})
+	s.blockChecker.CheckCallNames(c, "ChangeAllowed")
+	s.application.CheckCalls(c, []testing.StubCall{
+		{"SetEgressRules", []interface{}{[]network.EgressRule{
+			network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+		}}},
+		{"SetEgressRules", []interface{}{[]network.EgressRule{}}},
+	})
+}
+
+func (s *ApplicationSuite) TestSetEgressRulesBlocked(c *gc.C) {
+	s.blockChecker.SetErrors(errors.New("blocked"))
+	_, err := s.api.SetEgressRules(params.ApplicationEgressRulesArgs{
+		Args: []params.ApplicationEgressRules{{ApplicationName: "postgresql"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "blocked")
+	s.application.CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestSetCharmRollingUpgrade(c *gc.C) {
+	err := s.api.SetCharm(params.ApplicationSetCharm{
+		ApplicationName: "postgresql",
+		CharmURL:        "cs:postgresql",
+		RollingUpgrade: &params.RollingUpgradeParams{
+			BatchSize:   2,
+			LeaderFirst: true,
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.application.CheckCall(c, 0, "SetCharm", state.SetCharmConfig{
+		Charm: &state.Charm{},
+		RollingUpgrade: &state.RollingUpgradeParams{
+			BatchSize:   2,
+			LeaderFirst: true,
+		},
+	})
+}
+
+func (s *ApplicationSuite) TestRollingUpgrades(c *gc.C) {
+	s.application.charmURL = charm.MustParseURL("cs:postgresql-2")
+	s.application.rollingUpgrade = &state.RollingUpgrade{
+		RollingUpgradeParams: state.RollingUpgradeParams{BatchSize: 2},
+		PreviousCharmURL:     charm.MustParseURL("cs:postgresql-1"),
+		Released:             []string{"postgresql/0"},
+		Paused:               true,
+		PauseReason:          "unit postgresql/0 is in error",
+	}
+	results, err := s.api.RollingUpgrades(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-postgresql"},
+			{Tag: "unit-postgresql-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.RollingUpgradeResults{
+		Results: []params.RollingUpgradeResult{{
+			Result: &params.RollingUpgrade{
+				CharmURL:         "cs:postgresql-2",
+				PreviousCharmURL: "cs:postgresql-1",
+				BatchSize:        2,
+				Released:         []string{"postgresql/0"},
+				Paused:           true,
+				PauseReason:      "unit postgresql/0 is in error",
+			},
+		}, {
+			Error: &params.Error{Message: `"unit-postgresql-0" is not a valid application tag`},
+		}},
+	})
+}
+
+func (s *ApplicationSuite) TestRollingUpgradesNone(c *gc.C) {
+	results, err := s.api.RollingUpgrades(params.Entities{
+		Entities: []params.Entity{{Tag: "application-postgresql"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.RollingUpgradeResults{
+		Results: []params.RollingUpgradeResult{{}},
+	})
+}
+
+func (s *ApplicationSuite) TestReleaseRollingUpgradeUnits(c *gc.C) {
+	s.application.SetErrors(nil, errors.New("boom"))
+	results, err := s.api.ReleaseRollingUpgradeUnits(params.ReleaseRollingUpgradeUnitsArgs{
+		Args: []params.ReleaseRollingUpgradeUnits{
+			{ApplicationName: "postgresql", Units: []string{"postgresql/0", "postgresql/1"}},
+			{ApplicationName: "mysql", Units: []string{"mysql/0"}},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: "boom"}},
+		},
+	})
+	s.blockChecker.CheckCallNames(c, "ChangeAllowed")
+	s.application.CheckCalls(c, []testing.StubCall{
+		{"ReleaseRollingUpgradeUnits", []interface{}{[]string{"postgresql/0", "postgresql/1"}}},
+		{"ReleaseRollingUpgradeUnits", []interface{}{[]string{"mysql/0"}}},
+	})
+}
+
+func (s *ApplicationSuite) TestPauseRollingUpgrade(c *gc.C) {
+	results, err := s.api.PauseRollingUpgrade(params.PauseRollingUpgradeArgs{
+		Args: []params.PauseRollingUpgrade{
+			{ApplicationName: "postgresql", Reason: "unit postgresql/0 is in error"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{}},
+	})
+	s.blockChecker.CheckCallNames(c, "ChangeAllowed")
+	s.application.CheckCall(c, 0, "PauseRollingUpgrade", "unit postgresql/0 is in error")
+}
+
+func (s *ApplicationSuite) TestPauseRollingUpgradeBlocked(c *gc.C) {
+	s.blockChecker.SetErrors(errors.New("blocked"))
+	_, err := s.api.PauseRollingUpgrade(params.PauseRollingUpgradeArgs{
+		Args: []params.PauseRollingUpgrade{
+			{ApplicationName: "postgresql", Reason: "unit postgresql/0 is in error"},
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, "blocked")
+	s.application.CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestFinishRollingUpgrade(c *gc.C) {
+	results, err := s.api.FinishRollingUpgrade(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-postgresql"},
+			{Tag: "unit-postgresql-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: `"unit-postgresql-0" is not a valid application tag`}},
+		},
+	})
+	s.blockChecker.CheckCallNames(c, "ChangeAllowed")
+	s.application.CheckCallNames(c, "FinishRollingUpgrade")
+}
+
+func (s *ApplicationSuite) TestFinishRollingUpgradeBlocked(c *gc.C) {
+	s.blockChecker.SetErrors(errors.New("blocked"))
+	_, err := s.api.FinishRollingUpgrade(params.Entities{
+		Entities: []params.Entity{{Tag: "application-postgresql"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "blocked")
+	s.application.CheckNoCalls(c)
+}
+
 type mockBackend struct {
 	application.Backend
 	testing.Stub
@@ -169,6 +428,61 @@ func (b *mockBackend) Charm(curl *charm.URL) (application.Charm, error) {
 type mockApplication struct {
 	application.Application
 	testing.Stub
+	hookTimeouts   state.HookTimeouts
+	egressRules    []network.EgressRule
+	charmURL       *charm.URL
+	rollingUpgrade *state.RollingUpgrade
+}
+
+func (a *mockApplication) CharmURL() (*charm.URL, bool) {
+	a.MethodCall(a, "CharmURL")
+	a.PopNoErr()
+	return a.charmURL, false
+}
+
+func (a *mockApplication) RollingUpgrade() (state.RollingUpgrade, bool) {
+	a.MethodCall(a, "RollingUpgrade")
+	a.PopNoErr()
+	if a.rollingUpgrade == nil {
+		return state.RollingUpgrade{}, false
+	}
+	return *a.rollingUpgrade, true
+}
+
+func (a *mockApplication) ReleaseRollingUpgradeUnits(unitNames ...string) error {
+	a.MethodCall(a, "ReleaseRollingUpgradeUnits", unitNames)
+	return a.NextErr()
+}
+
+func (a *mockApplication) PauseRollingUpgrade(reason string) error {
+	a.MethodCall(a, "PauseRollingUpgrade", reason)
+	return a.NextErr()
+}
+
+func (a *mockApplication) FinishRollingUpgrade() error {
+	a.MethodCall(a, "FinishRollingUpgrade")
+	return a.NextErr()
+}
+
+func (a *mockApplication) HookTimeouts() state.HookTimeouts {
+	a.MethodCall(a, "HookTimeouts")
+	a.PopNoErr()
+	return a.hookTimeouts
+}
+
+func (a *mockApplication) SetHookTimeouts(timeouts state.HookTimeouts) error {
+	a.MethodCall(a, "SetHookTimeouts", timeouts)
+	return a.NextErr()
+}
+
+func (a *mockApplication) EgressRules() ([]network.EgressRule, error) {
+	a.MethodCall(a, "EgressRules")
+	return a.egressRules, a.NextErr()
+}
+
+func (a *mockApplication) SetEgressRules(rules []network.EgressRule) error {
+	a.MethodCall(a, "SetEgressRules", rules)
+	return a.NextErr()
 }
 
 func (a *mockApplication) SetCharm(cfg state.SetCharmConfig) error {
@@ -10,6 +10,7 @@ import (
 
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/storage"
 )
@@ -57,12 +58,22 @@ type Application interface {
 	ConfigSettings() (charm.Settings, error)
 	Constraints() (constraints.Value, error)
 	Destroy() error
+	DestroyReleasingStorage() error
+	EgressRules() ([]network.EgressRule, error)
 	Endpoints() ([]state.Endpoint, error)
+	FinishRollingUpgrade() error
+	HookTimeouts() state.HookTimeouts
 	IsPrincipal() bool
+	PauseRollingUpgrade(string) error
+	ReleaseRollingUpgradeUnits(...string) error
+	RollingUpgrade() (state.RollingUpgrade, bool)
 	Series() string
 	SetCharm(state.SetCharmConfig) error
 	SetConstraints(constraints.Value) error
+	SetEgressRules([]network.EgressRule) error
 	SetExposed() error
+	SetExposedTo(cidrs, spaces []string) error
+	SetHookTimeouts(state.HookTimeouts) error
 	SetMetricCredentials([]byte) error
 	SetMinUnits(int) error
 	UpdateConfigSettings(charm.Settings) error
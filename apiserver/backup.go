@@ -4,12 +4,15 @@
 package apiserver
 
 import (
+	"crypto/sha1"
 	"encoding/json"
 	"io"
 	"io/ioutil"
 	"net/http"
+	"os"
 
 	"github.com/juju/errors"
+	"github.com/juju/utils/hash"
 
 	apiserverbackups "github.com/juju/juju/apiserver/backups"
 	"github.com/juju/juju/apiserver/common"
@@ -45,7 +48,7 @@ func (h *backupHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
 	switch req.Method {
 	case "GET":
 		logger.Infof("handling backups download request")
-		id, err := h.download(backups, resp, req)
+		id, err := h.download(backups, st, resp, req)
 		if err != nil {
 			h.sendError(resp, err)
 			return
@@ -64,11 +67,15 @@ func (h *backupHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
 	}
 }
 
-func (h *backupHandler) download(backups backups.Backups, resp http.ResponseWriter, req *http.Request) (string, error) {
+func (h *backupHandler) download(backups backups.Backups, st *state.State, resp http.ResponseWriter, req *http.Request) (string, error) {
 	args, err := h.parseGETArgs(req)
 	if err != nil {
 		return "", err
 	}
+	if args.Model != "" {
+		logger.Infof("backups download request for model %q in %q", args.Model, args.ID)
+		return args.ID, h.downloadModel(backups, st, args, resp)
+	}
 	logger.Infof("backups download request for %q", args.ID)
 
 	meta, archive, err := backups.Get(args.ID)
@@ -81,6 +88,50 @@ func (h *backupHandler) download(backups backups.Backups, resp http.ResponseWrit
 	return args.ID, err
 }
 
+// downloadModel sends an archive of a single model extracted from the
+// backup. The archive is written to a temporary file first, so that a
+// failure to extract the model can still be reported to the client.
+//
+// The extraction is done synchronously, while the request waits: a
+// scratch mongod is started, the whole backed up database is restored
+// into it and the model is exported, which for a large controller can
+// take several minutes before the first byte of the response is sent.
+// Clients must allow for this, and each concurrent request runs its
+// own scratch mongod, using memory and disk space on the controller
+// until it finishes.
+func (h *backupHandler) downloadModel(b backups.Backups, st *state.State, args *params.BackupsDownloadArgs, resp http.ResponseWriter) error {
+	servingInfo, err := st.StateServingInfo()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	controllerConfig, err := st.ControllerConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	caCert, _ := controllerConfig.CACert()
+
+	file, err := ioutil.TempFile("", "juju-model-archive")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	hasher := hash.NewHashingWriter(file, sha1.New())
+	err = b.ExtractModel(args.ID, backups.ExtractModelArgs{
+		Model:       args.Model,
+		ServingInfo: servingInfo,
+		CACert:      caCert,
+	}, hasher)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Seek(0, 0); err != nil {
+		return errors.Trace(err)
+	}
+	return h.sendFile(file, hasher.Base64Sum(), resp)
+}
+
 func (h *backupHandler) upload(backups backups.Backups, resp http.ResponseWriter, req *http.Request) (string, error) {
 	// Since we want to stream the archive in we cannot simply use
 	// mime/multipart directly.
@@ -26,6 +26,7 @@ import (
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/backups"
 	backupstesting "github.com/juju/juju/state/backups/testing"
+	coretesting "github.com/juju/juju/testing"
 )
 
 type backupsCommonSuite struct {
@@ -258,6 +259,46 @@ func (s *backupsDownloadSuite) TestErrorWhenGetFails(c *gc.C) {
 	s.assertErrorResponse(c, resp, http.StatusInternalServerError, "failed!")
 }
 
+func (s *backupsDownloadSuite) sendModelGet(c *gc.C) *http.Response {
+	return s.authRequest(c, httpRequestParams{
+		method:      "GET",
+		url:         s.backupURL(c),
+		contentType: params.ContentTypeJSON,
+		jsonBody: params.BackupsDownloadArgs{
+			ID:    "backup-id",
+			Model: "admin/mymodel",
+		},
+	})
+}
+
+func (s *backupsDownloadSuite) TestModel(c *gc.C) {
+	s.fake.ModelArchive = []byte("<model archive>")
+	resp := s.sendModelGet(c)
+	defer resp.Body.Close()
+
+	c.Check(resp.StatusCode, gc.Equals, http.StatusOK)
+	c.Check(resp.Header.Get("Content-Type"), gc.Equals, params.ContentTypeRaw)
+	body, err := ioutil.ReadAll(resp.Body)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(body), gc.Equals, "<model archive>")
+
+	c.Check(s.fake.Calls, gc.DeepEquals, []string{"ExtractModel"})
+	c.Check(s.fake.IDArg, gc.Equals, "backup-id")
+	c.Check(s.fake.ExtractArg.Model, gc.Equals, "admin/mymodel")
+	info, err := s.State.StateServingInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.fake.ExtractArg.ServingInfo, jc.DeepEquals, info)
+	c.Check(s.fake.ExtractArg.CACert, gc.Equals, coretesting.CACert)
+}
+
+func (s *backupsDownloadSuite) TestModelError(c *gc.C) {
+	s.fake.Error = errors.NotFoundf(`model "admin/mymodel" in backup`)
+	resp := s.sendModelGet(c)
+	defer resp.Body.Close()
+
+	s.assertErrorResponse(c, resp, http.StatusNotFound, `model "admin/mymodel" in backup not found`)
+}
+
 type backupsUploadSuite struct {
 	backupsCommonSuite
 	meta *backups.Metadata
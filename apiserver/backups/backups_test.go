@@ -64,6 +64,10 @@ func (s *backupsSuite) setBackups(c *gc.C, meta *backups.Metadata, err string) *
 func (s *backupsSuite) TestRegistered(c *gc.C) {
 	_, err := common.Facades.GetType("Backups", 1)
 	c.Check(err, jc.ErrorIsNil)
+	_, err = common.Facades.GetType("Backups", 2)
+	c.Check(err, jc.ErrorIsNil)
+	_, err = common.Facades.GetType("Backups", 3)
+	c.Check(err, jc.ErrorIsNil)
 }
 
 func (s *backupsSuite) TestNewAPIOkay(c *gc.C) {
@@ -6,6 +6,7 @@ package backups
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
@@ -70,6 +71,17 @@ func (a *API) Restore(p params.RestoreArgs) error {
 		NewInstTag:     machine.Tag(),
 		NewInstSeries:  machine.Series(),
 	}
+	if p.ToTime != nil {
+		// The oplog archiver runs a little behind the oplog, so
+		// archive the latest entries before they're needed.
+		if err := backups.ArchiveOplog(a.backend, time.Now()); err != nil {
+			return errors.Annotate(err, "cannot archive oplog")
+		}
+		oplog := backups.NewOplogArchive(a.backend)
+		defer oplog.Close()
+		restoreArgs.ToTime = *p.ToTime
+		restoreArgs.Oplog = oplog
+	}
 
 	session := a.backend.MongoSession().Copy()
 	defer session.Close()
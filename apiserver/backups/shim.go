@@ -17,6 +17,10 @@ import (
 
 func init() {
 	common.RegisterStandardFacade("Backups", 1, newAPI)
+	// Version 2 adds restoring to a point in time.
+	common.RegisterStandardFacade("Backups", 2, newAPI)
+	// Version 3 adds downloading a single model from a backup.
+	common.RegisterStandardFacade("Backups", 3, newAPI)
 }
 
 type stateShim struct {
@@ -27,7 +27,7 @@ var sendMetrics = func(st metricsender.ModelBackend) error {
 // have been done. If the model is a controller hosting other
 // models, they will also be destroyed.
 func DestroyModelIncludingHosted(st ModelManagerBackend, systemTag names.ModelTag) error {
-	return destroyModel(st, systemTag, true)
+	return destroyModel(st, systemTag, true, false)
 }
 
 // DestroyModel sets the environment to dying. Cleanup jobs then destroy
@@ -36,10 +36,17 @@ func DestroyModelIncludingHosted(st ModelManagerBackend, systemTag names.ModelTa
 // have been done. An error will be returned if this model is a
 // controller hosting other model.
 func DestroyModel(st ModelManagerBackend, modelTag names.ModelTag) error {
-	return destroyModel(st, modelTag, false)
+	return destroyModel(st, modelTag, false, false)
 }
 
-func destroyModel(st ModelManagerBackend, modelTag names.ModelTag, destroyHostedModels bool) error {
+// DestroyModelReleasingStorage behaves like DestroyModel, except that
+// the model's cloud volumes are released rather than destroyed, so
+// that they may later be imported into another model.
+func DestroyModelReleasingStorage(st ModelManagerBackend, modelTag names.ModelTag) error {
+	return destroyModel(st, modelTag, false, true)
+}
+
+func destroyModel(st ModelManagerBackend, modelTag names.ModelTag, destroyHostedModels, releaseStorage bool) error {
 	var err error
 	if modelTag != st.ModelTag() {
 		if st, err = st.ForModel(modelTag); err != nil {
@@ -93,6 +100,10 @@ func destroyModel(st ModelManagerBackend, modelTag names.ModelTag, destroyHosted
 		if err := model.DestroyIncludingHosted(); err != nil {
 			return err
 		}
+	} else if releaseStorage {
+		if err = model.DestroyReleasingStorage(); err != nil {
+			return errors.Trace(err)
+		}
 	} else {
 		if err = model.Destroy(); err != nil {
 			return errors.Trace(err)
@@ -77,6 +77,7 @@ type Model interface {
 	Users() ([]permission.UserAccess, error)
 	Destroy() error
 	DestroyIncludingHosted() error
+	DestroyReleasingStorage() error
 }
 
 var _ ModelManagerBackend = (*modelManagerStateShim)(nil)
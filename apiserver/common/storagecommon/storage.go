@@ -54,6 +54,14 @@ type StorageInterface interface {
 	// with the specified machine.
 	WatchBlockDevices(names.MachineTag) state.NotifyWatcher
 
+	// WatchVolume watches for changes to the specified volume,
+	// such as its size changing after a resize.
+	WatchVolume(names.VolumeTag) state.NotifyWatcher
+
+	// WatchFilesystem watches for changes to the specified filesystem,
+	// such as its size changing after a resize.
+	WatchFilesystem(names.FilesystemTag) state.NotifyWatcher
+
 	// BlockDevices returns information about block devices published
 	// for the specified machine.
 	BlockDevices(names.MachineTag) ([]state.BlockDeviceInfo, error)
@@ -133,6 +141,7 @@ func volumeStorageAttachmentInfo(
 	return &storage.StorageAttachmentInfo{
 		storage.StorageKindBlock,
 		devicePath,
+		volumeInfo.Size,
 	}, nil
 }
 
@@ -154,9 +163,18 @@ func filesystemStorageAttachmentInfo(
 	if err != nil {
 		return nil, errors.Annotate(err, "getting filesystem attachment info")
 	}
+	// The size of the filesystem is reported if it is known;
+	// it is not required for the attachment to be usable.
+	var size uint64
+	if filesystemInfo, err := filesystem.Info(); err == nil {
+		size = filesystemInfo.Size
+	} else if !errors.IsNotProvisioned(err) {
+		return nil, errors.Annotate(err, "getting filesystem info")
+	}
 	return &storage.StorageAttachmentInfo{
 		storage.StorageKindFilesystem,
 		filesystemAttachmentInfo.MountPoint,
+		size,
 	}, nil
 }
 
@@ -193,6 +211,9 @@ func WatchStorageAttachment(
 			// or have the filter ignore changes until the volume
 			// attachment is provisioned.
 			st.WatchBlockDevices(machineTag),
+			// The volume is watched so that changes to its
+			// size are observed.
+			st.WatchVolume(volume.VolumeTag()),
 		}
 	case state.StorageKindFilesystem:
 		filesystem, err := st.StorageInstanceFilesystem(storageTag)
@@ -201,6 +222,7 @@ func WatchStorageAttachment(
 		}
 		watchers = []state.NotifyWatcher{
 			st.WatchFilesystemAttachment(machineTag, filesystem.FilesystemTag()),
+			st.WatchFilesystem(filesystem.FilesystemTag()),
 		}
 	default:
 		return nil, errors.Errorf("invalid storage kind %v", storageInstance.Kind())
@@ -263,7 +285,9 @@ func storageTags(
 	)
 	if storageInstance != nil {
 		storageTags[tags.JujuStorageInstance] = storageInstance.Tag().Id()
-		storageTags[tags.JujuStorageOwner] = storageInstance.Owner().Id()
+		if owner, ok := storageInstance.Owner(); ok {
+			storageTags[tags.JujuStorageOwner] = owner.Id()
+		}
 	}
 	return storageTags, nil
 }
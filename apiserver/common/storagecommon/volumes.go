@@ -36,11 +36,12 @@ func VolumeParams(
 	registry storage.ProviderRegistry,
 ) (params.VolumeParams, error) {
 
-	var pool string
+	var pool, snapshotId string
 	var size uint64
 	if stateVolumeParams, ok := v.Params(); ok {
 		pool = stateVolumeParams.Pool
 		size = stateVolumeParams.Size
+		snapshotId = stateVolumeParams.SnapshotId
 	} else {
 		volumeInfo, err := v.Info()
 		if err != nil {
@@ -65,6 +66,7 @@ func VolumeParams(
 		string(providerType),
 		cfg.Attrs(),
 		volumeTags,
+		snapshotId,
 		nil, // attachment params set by the caller
 	}, nil
 }
@@ -131,6 +133,7 @@ func VolumeFromState(v state.Volume) (params.Volume, error) {
 	return params.Volume{
 		v.VolumeTag().String(),
 		VolumeInfoFromState(info),
+		v.Releasing(),
 	}, nil
 }
 
@@ -89,3 +89,19 @@ func (*volumesSuite) TestVolumeParamsStorageTags(c *gc.C) {
 		},
 	})
 }
+
+func (*volumesSuite) TestVolumeParamsSnapshot(c *gc.C) {
+	p, err := storagecommon.VolumeParams(
+		&fakeVolume{tag: names.NewVolumeTag("100"), params: &state.VolumeParams{
+			Pool: "loop", Size: 1024, SnapshotId: "volume-0@1",
+		}},
+		nil, // StorageInstance
+		testing.ModelTag.Id(),
+		testing.ControllerTag.Id(),
+		testing.CustomModelConfig(c, nil),
+		&fakePoolManager{},
+		provider.CommonStorageProviders(),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(p.SnapshotId, gc.Equals, "volume-0@1")
+}
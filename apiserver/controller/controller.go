@@ -33,6 +33,18 @@ var logger = loggo.GetLogger("juju.apiserver.controller")
 
 func init() {
 	common.RegisterStandardFacade("Controller", 3, NewControllerAPI)
+
+	// Version 4 adds MigrationPrechecks.
+	common.RegisterStandardFacade("Controller", 4, NewControllerAPI)
+
+	// Version 5 adds cloud remapping to migration specs.
+	common.RegisterStandardFacade("Controller", 5, NewControllerAPI)
+
+	// Version 6 adds queued migrations.
+	common.RegisterStandardFacade("Controller", 6, NewControllerAPI)
+
+	// Version 7 adds ControllerHealth.
+	common.RegisterStandardFacade("Controller", 7, NewControllerAPI)
 }
 
 // Controller defines the methods on the controller API end point.
@@ -48,7 +60,9 @@ type Controller interface {
 	WatchAllModels() (params.AllWatcherId, error)
 	ModelStatus(params.Entities) (params.ModelStatusResults, error)
 	InitiateMigration(params.InitiateMigrationArgs) (params.InitiateMigrationResults, error)
+	MigrationPrechecks(params.InitiateMigrationArgs) (params.MigrationPrecheckResults, error)
 	ModifyControllerAccess(params.ModifyControllerAccessRequest) (params.ErrorResults, error)
+	ControllerHealth() (params.ControllerHealth, error)
 }
 
 // ControllerAPI implements the environment manager interface and is
@@ -406,48 +420,158 @@ func (c *ControllerAPI) initiateOneMigration(spec params.MigrationSpec) (string,
 	}
 	defer hostedState.Close()
 
-	// Construct target info.
-	specTarget := spec.TargetInfo
+	targetInfo, err := makeTargetInfo(spec.TargetInfo)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	cloudRemap, err := makeCloudRemap(hostedState, spec.CloudRemap)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+
+	// Check if the migration is likely to succeed.
+	if !(spec.ExternalControl && spec.SkipInitialPrechecks) {
+		if err := runMigrationPrechecks(hostedState, targetInfo, cloudRemap); err != nil {
+			return "", errors.Trace(err)
+		}
+	}
+
+	// Trigger the migration.
+	mig, err := hostedState.CreateMigration(state.MigrationSpec{
+		InitiatedBy:     c.apiUser,
+		TargetInfo:      targetInfo,
+		ExternalControl: spec.ExternalControl,
+		CloudRemap:      cloudRemap,
+		Queue:           spec.Queue,
+	})
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return mig.Id(), nil
+}
+
+// MigrationPrechecks runs the source and target prechecks for each of
+// the given migration specs without starting any migrations. Every
+// problem found is reported, rather than just the first.
+func (c *ControllerAPI) MigrationPrechecks(reqArgs params.InitiateMigrationArgs) (
+	params.MigrationPrecheckResults, error,
+) {
+	out := params.MigrationPrecheckResults{
+		Results: make([]params.MigrationPrecheckResult, len(reqArgs.Specs)),
+	}
+	if err := c.checkHasAdmin(); err != nil {
+		return out, errors.Trace(err)
+	}
+
+	for i, spec := range reqArgs.Specs {
+		result := &out.Results[i]
+		result.ModelTag = spec.ModelTag
+		report, err := c.oneMigrationPrechecks(spec)
+		if err != nil {
+			result.Error = common.ServerError(err)
+			continue
+		}
+		result.Source = precheckProblemsToParams(report.Source)
+		result.Target = precheckProblemsToParams(report.Target)
+	}
+	return out, nil
+}
+
+func (c *ControllerAPI) oneMigrationPrechecks(spec params.MigrationSpec) (coremigration.PrecheckReport, error) {
+	var empty coremigration.PrecheckReport
+	modelTag, err := names.ParseModelTag(spec.ModelTag)
+	if err != nil {
+		return empty, errors.Annotate(err, "model tag")
+	}
+	if _, err := c.state.GetModel(modelTag); err != nil {
+		return empty, errors.Annotate(err, "unable to read model")
+	}
+	hostedState, err := c.state.ForModel(modelTag)
+	if err != nil {
+		return empty, errors.Trace(err)
+	}
+	defer hostedState.Close()
+
+	targetInfo, err := makeTargetInfo(spec.TargetInfo)
+	if err != nil {
+		return empty, errors.Trace(err)
+	}
+	cloudRemap, err := makeCloudRemap(hostedState, spec.CloudRemap)
+	if err != nil {
+		return empty, errors.Trace(err)
+	}
+	report, err := runMigrationPrecheckReport(hostedState, targetInfo, cloudRemap)
+	return report, errors.Trace(err)
+}
+
+func precheckProblemsToParams(problems []coremigration.PrecheckProblem) []params.PrecheckProblem {
+	if len(problems) == 0 {
+		return nil
+	}
+	out := make([]params.PrecheckProblem, len(problems))
+	for i, problem := range problems {
+		out[i] = params.PrecheckProblem{
+			Entity:  problem.Entity,
+			Message: problem.Message,
+		}
+	}
+	return out
+}
+
+func makeTargetInfo(specTarget params.MigrationTargetInfo) (coremigration.TargetInfo, error) {
+	var empty coremigration.TargetInfo
 	controllerTag, err := names.ParseControllerTag(specTarget.ControllerTag)
 	if err != nil {
-		return "", errors.Annotate(err, "controller tag")
+		return empty, errors.Annotate(err, "controller tag")
 	}
 	authTag, err := names.ParseUserTag(specTarget.AuthTag)
 	if err != nil {
-		return "", errors.Annotate(err, "auth tag")
+		return empty, errors.Annotate(err, "auth tag")
 	}
 	var macs []macaroon.Slice
 	if specTarget.Macaroons != "" {
 		if err := json.Unmarshal([]byte(specTarget.Macaroons), &macs); err != nil {
-			return "", errors.Annotate(err, "invalid macaroons")
+			return empty, errors.Annotate(err, "invalid macaroons")
 		}
 	}
-	targetInfo := coremigration.TargetInfo{
+	return coremigration.TargetInfo{
 		ControllerTag: controllerTag,
 		Addrs:         specTarget.Addrs,
 		CACert:        specTarget.CACert,
 		AuthTag:       authTag,
 		Password:      specTarget.Password,
 		Macaroons:     macs,
-	}
+	}, nil
+}
 
-	// Check if the migration is likely to succeed.
-	if !(spec.ExternalControl && spec.SkipInitialPrechecks) {
-		if err := runMigrationPrechecks(hostedState, targetInfo); err != nil {
-			return "", errors.Trace(err)
+// makeCloudRemap returns the validated cloud remap for migrating the
+// model with the given state.
+func makeCloudRemap(st *state.State, args *params.MigrationCloudRemap) (coremigration.CloudRemap, error) {
+	var remap coremigration.CloudRemap
+	if args == nil {
+		return remap, nil
+	}
+	remap.Cloud = args.Cloud
+	remap.CloudRegion = args.CloudRegion
+	if args.CredentialTag != "" {
+		credTag, err := names.ParseCloudCredentialTag(args.CredentialTag)
+		if err != nil {
+			return remap, errors.Annotate(err, "credential tag")
 		}
+		remap.Credential = credTag
 	}
-
-	// Trigger the migration.
-	mig, err := hostedState.CreateMigration(state.MigrationSpec{
-		InitiatedBy:     c.apiUser,
-		TargetInfo:      targetInfo,
-		ExternalControl: spec.ExternalControl,
-	})
+	if err := remap.Validate(); err != nil {
+		return remap, errors.Annotate(err, "cloud remap")
+	}
+	model, err := st.Model()
 	if err != nil {
-		return "", errors.Trace(err)
+		return remap, errors.Trace(err)
 	}
-	return mig.Id(), nil
+	modelCredential, _ := model.CloudCredential()
+	if err := remap.CheckCredential(modelCredential); err != nil {
+		return remap, errors.Annotate(err, "cloud remap")
+	}
+	return remap, nil
 }
 
 // ModifyControllerAccess changes the model access granted to users.
@@ -488,7 +612,7 @@ func (c *ControllerAPI) ModifyControllerAccess(args params.ModifyControllerAcces
 	return result, nil
 }
 
-var runMigrationPrechecks = func(st *state.State, targetInfo coremigration.TargetInfo) error {
+var runMigrationPrechecks = func(st *state.State, targetInfo coremigration.TargetInfo, cloudRemap coremigration.CloudRemap) error {
 	// Check model and source controller.
 	backend, err := migration.PrecheckShim(st)
 	if err != nil {
@@ -508,10 +632,52 @@ var runMigrationPrechecks = func(st *state.State, targetInfo coremigration.Targe
 	if err != nil {
 		return errors.Trace(err)
 	}
-	err = migrationtarget.NewClient(conn).Prechecks(modelInfo)
+	err = migrationtarget.NewClient(conn).Prechecks(cloudRemap.Apply(modelInfo))
 	return errors.Annotate(err, "target prechecks failed")
 }
 
+var runMigrationPrecheckReport = func(
+	st *state.State, targetInfo coremigration.TargetInfo, cloudRemap coremigration.CloudRemap,
+) (coremigration.PrecheckReport, error) {
+	var report coremigration.PrecheckReport
+
+	// Check model and source controller.
+	backend, err := migration.PrecheckShim(st)
+	if err != nil {
+		return report, errors.Annotate(err, "creating backend")
+	}
+	report.Source, err = migration.SourcePrecheckReport(backend)
+	if err != nil {
+		return report, errors.Annotate(err, "source prechecks failed")
+	}
+
+	// Check target controller.
+	conn, err := api.Open(targetToAPIInfo(targetInfo), migration.ControllerDialOpts())
+	if err != nil {
+		return report, errors.Annotate(err, "connect to target controller")
+	}
+	defer conn.Close()
+	modelInfo, err := makeModelInfo(st)
+	if err != nil {
+		return report, errors.Trace(err)
+	}
+	modelInfo = cloudRemap.Apply(modelInfo)
+	client := migrationtarget.NewClient(conn)
+	report.Target, err = client.PrecheckReport(modelInfo)
+	if errors.IsNotSupported(err) {
+		// Older target controllers can only report the first
+		// problem they find.
+		if err := client.Prechecks(modelInfo); err != nil {
+			report.Target = []coremigration.PrecheckProblem{{
+				Entity:  "controller",
+				Message: err.Error(),
+			}}
+		}
+		return report, nil
+	}
+	return report, errors.Annotate(err, "target prechecks failed")
+}
+
 func makeModelInfo(st *state.State) (coremigration.ModelInfo, error) {
 	var empty coremigration.ModelInfo
 
@@ -544,6 +710,8 @@ func makeModelInfo(st *state.State) (coremigration.ModelInfo, error) {
 		Owner:                  model.Owner(),
 		AgentVersion:           agentVersion,
 		ControllerAgentVersion: controllerVersion,
+		Cloud:                  model.Cloud(),
+		CloudRegion:            model.CloudRegion(),
 	}, nil
 }
 
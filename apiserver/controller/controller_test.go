@@ -23,6 +23,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/cloud"
+	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/permission"
@@ -437,6 +438,148 @@ func (s *controllerSuite) TestInitiateMigration(c *gc.C) {
 	}
 }
 
+func (s *controllerSuite) TestInitiateMigrationCloudRemap(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	var remapSeen coremigration.CloudRemap
+	s.PatchValue(controller.RunMigrationPrechecks, func(
+		_ *state.State, _ coremigration.TargetInfo, remap coremigration.CloudRemap,
+	) error {
+		remapSeen = remap
+		return nil
+	})
+
+	args := params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{{
+			ModelTag: st.ModelTag().String(),
+			TargetInfo: params.MigrationTargetInfo{
+				ControllerTag: randomControllerTag(),
+				Addrs:         []string{"1.1.1.1:1111"},
+				CACert:        "cert",
+				AuthTag:       names.NewUserTag("admin").String(),
+				Password:      "secret",
+			},
+			CloudRemap: &params.MigrationCloudRemap{
+				Cloud:         "openstack",
+				CloudRegion:   "RegionOne",
+				CredentialTag: names.NewCloudCredentialTag("openstack/bob/default").String(),
+			},
+		}},
+	}
+	out, err := s.controller.InitiateMigration(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 1)
+	c.Assert(out.Results[0].Error, gc.IsNil)
+
+	expected := coremigration.CloudRemap{
+		Cloud:       "openstack",
+		CloudRegion: "RegionOne",
+		Credential:  names.NewCloudCredentialTag("openstack/bob/default"),
+	}
+	c.Check(remapSeen, jc.DeepEquals, expected)
+	mig, err := st.LatestMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mig.CloudRemap(), jc.DeepEquals, expected)
+}
+
+func (s *controllerSuite) TestInitiateMigrationQueued(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	s.PatchValue(controller.RunMigrationPrechecks, func(
+		*state.State, coremigration.TargetInfo, coremigration.CloudRemap,
+	) error {
+		return nil
+	})
+
+	args := params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{{
+			ModelTag: st.ModelTag().String(),
+			TargetInfo: params.MigrationTargetInfo{
+				ControllerTag: randomControllerTag(),
+				Addrs:         []string{"1.1.1.1:1111"},
+				CACert:        "cert",
+				AuthTag:       names.NewUserTag("admin").String(),
+				Password:      "secret",
+			},
+			Queue: true,
+		}},
+	}
+	out, err := s.controller.InitiateMigration(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 1)
+	c.Assert(out.Results[0].Error, gc.IsNil)
+
+	mig, err := st.LatestMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	phase, err := mig.Phase()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(phase, gc.Equals, coremigration.QUEUED)
+}
+
+func (s *controllerSuite) TestInitiateMigrationInvalidCloudRemap(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	controller.SetPrecheckResult(s, nil)
+
+	args := params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{{
+			ModelTag: st.ModelTag().String(),
+			TargetInfo: params.MigrationTargetInfo{
+				ControllerTag: randomControllerTag(),
+				Addrs:         []string{"1.1.1.1:1111"},
+				CACert:        "cert",
+				AuthTag:       names.NewUserTag("admin").String(),
+				Password:      "secret",
+			},
+			CloudRemap: &params.MigrationCloudRemap{
+				Cloud:         "openstack",
+				CredentialTag: names.NewCloudCredentialTag("aws/bob/default").String(),
+			},
+		}},
+	}
+	out, err := s.controller.InitiateMigration(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 1)
+	c.Check(out.Results[0].Error, gc.ErrorMatches,
+		`cloud remap: credential "aws/bob/default" for cloud "openstack" not valid`)
+}
+
+func (s *controllerSuite) TestInitiateMigrationCloudRemapRequiresCredential(c *gc.C) {
+	owner := s.Factory.MakeUser(c, nil).UserTag()
+	credTag := names.NewCloudCredentialTag("dummy/" + owner.Id() + "/empty-credential")
+	err := s.State.UpdateCloudCredential(credTag, cloud.NewEmptyCredential())
+	c.Assert(err, jc.ErrorIsNil)
+	st := s.Factory.MakeModel(c, &factory.ModelParams{
+		CloudName:       "dummy",
+		CloudCredential: credTag,
+		Owner:           owner,
+	})
+	defer st.Close()
+	controller.SetPrecheckResult(s, nil)
+
+	args := params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{{
+			ModelTag: st.ModelTag().String(),
+			TargetInfo: params.MigrationTargetInfo{
+				ControllerTag: randomControllerTag(),
+				Addrs:         []string{"1.1.1.1:1111"},
+				CACert:        "cert",
+				AuthTag:       names.NewUserTag("admin").String(),
+				Password:      "secret",
+			},
+			CloudRemap: &params.MigrationCloudRemap{
+				Cloud: "openstack",
+			},
+		}},
+	}
+	out, err := s.controller.InitiateMigration(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 1)
+	c.Check(out.Results[0].Error, gc.ErrorMatches,
+		`cloud remap: model uses credential "dummy/.*/empty-credential", so a target credential is required to remap its cloud to "openstack"`)
+}
+
 func (s *controllerSuite) TestInitiateMigrationSpecError(c *gc.C) {
 	// Create a hosted model to migrate.
 	st := s.Factory.MakeModel(c, nil)
@@ -543,6 +686,91 @@ func (s *controllerSuite) TestInitiateMigrationPrecheckFail(c *gc.C) {
 	c.Check(active, jc.IsFalse)
 }
 
+func (s *controllerSuite) TestMigrationPrechecks(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	controller.SetPrecheckReport(s, coremigration.PrecheckReport{
+		Source: []coremigration.PrecheckProblem{
+			{Entity: "machine 0", Message: "machine 0 is dying"},
+		},
+		Target: []coremigration.PrecheckProblem{
+			{Entity: "controller", Message: "upgrade in progress"},
+		},
+	}, nil)
+
+	args := params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{
+			{
+				ModelTag: st.ModelTag().String(),
+				TargetInfo: params.MigrationTargetInfo{
+					ControllerTag: randomControllerTag(),
+					Addrs:         []string{"1.1.1.1:1111"},
+					CACert:        "cert",
+					AuthTag:       names.NewUserTag("admin").String(),
+					Password:      "secret",
+				},
+			}, {
+				ModelTag: randomModelTag(), // Doesn't exist.
+			},
+		},
+	}
+	out, err := s.controller.MigrationPrechecks(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 2)
+
+	c.Check(out.Results[0], jc.DeepEquals, params.MigrationPrecheckResult{
+		ModelTag: st.ModelTag().String(),
+		Source:   []params.PrecheckProblem{{Entity: "machine 0", Message: "machine 0 is dying"}},
+		Target:   []params.PrecheckProblem{{Entity: "controller", Message: "upgrade in progress"}},
+	})
+	c.Check(out.Results[1].ModelTag, gc.Equals, args.Specs[1].ModelTag)
+	c.Check(out.Results[1].Error, gc.ErrorMatches, "unable to read model: .+")
+
+	// No migration is started.
+	active, err := st.IsMigrationActive()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(active, jc.IsFalse)
+}
+
+func (s *controllerSuite) TestMigrationPrechecksError(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	controller.SetPrecheckReport(s, coremigration.PrecheckReport{}, errors.New("boom"))
+
+	args := params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{{
+			ModelTag: st.ModelTag().String(),
+			TargetInfo: params.MigrationTargetInfo{
+				ControllerTag: randomControllerTag(),
+				Addrs:         []string{"1.1.1.1:1111"},
+				CACert:        "cert",
+				AuthTag:       names.NewUserTag("admin").String(),
+				Password:      "secret",
+			},
+		}},
+	}
+	out, err := s.controller.MigrationPrechecks(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 1)
+	c.Check(out.Results[0].Error, gc.ErrorMatches, "boom")
+}
+
+func (s *controllerSuite) TestMigrationPrechecksSpecError(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	args := params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{{
+			ModelTag: st.ModelTag().String(),
+		}},
+	}
+	out, err := s.controller.MigrationPrechecks(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 1)
+	c.Check(out.Results[0].Error, gc.ErrorMatches, "controller tag: .+ is not a valid tag")
+}
+
 func (s *controllerSuite) TestInitiateMigrationSkipPrechecks(c *gc.C) {
 	st := s.Factory.MakeModel(c, nil)
 	defer st.Close()
@@ -843,3 +1071,84 @@ func (s *controllerSuite) TestGetControllerAccessPermissions(c *gc.C) {
 		Message: "permission denied", Code: "unauthorized access",
 	})
 }
+
+func (s *controllerSuite) TestControllerHealth(c *gc.C) {
+	for i := 0; i < 2; i++ {
+		_, err := s.State.AddMachine("quantal", state.JobManageModel)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	err := s.State.DrainController("1")
+	c.Assert(err, jc.ErrorIsNil)
+	reported := time.Date(2017, 5, 1, 12, 0, 0, 0, time.UTC)
+	err = s.State.SetControllerHealthReport(state.ControllerHealthReport{
+		MachineId:        "0",
+		Updated:          reported,
+		APIConnections:   6,
+		AgentConnections: 4,
+		WorkerCount:      20,
+		UnhealthyWorkers: []state.WorkerHealth{{
+			Name:  "peer-grouper",
+			State: "stopped",
+			Error: "boom",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	members := []params.ReplicaSetMemberHealth{{
+		Id:        1,
+		Address:   "10.0.0.1:37017",
+		MachineId: "0",
+		State:     "PRIMARY",
+		Healthy:   true,
+		Voting:    true,
+	}}
+	controller.SetReplicaSetHealth(s, members, nil)
+
+	health, err := s.controller.ControllerHealth()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(health, jc.DeepEquals, params.ControllerHealth{
+		Machines: []params.ControllerMachineHealth{{
+			Id:               "0",
+			WantsVote:        true,
+			Reported:         &reported,
+			APIConnections:   6,
+			AgentConnections: 4,
+			Workers:          20,
+			UnhealthyWorkers: []params.WorkerHealth{{
+				Name:  "peer-grouper",
+				State: "stopped",
+				Error: "boom",
+			}},
+		}, {
+			Id:        "1",
+			WantsVote: true,
+			Draining:  true,
+		}},
+		ReplicaSet: members,
+	})
+}
+
+func (s *controllerSuite) TestControllerHealthReplicaSetError(c *gc.C) {
+	_, err := s.State.AddMachine("quantal", state.JobManageModel)
+	c.Assert(err, jc.ErrorIsNil)
+	controller.SetReplicaSetHealth(s, nil, errors.New("cannot get replica set status: boom"))
+
+	health, err := s.controller.ControllerHealth()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(health.Machines, gc.HasLen, 1)
+	c.Check(health.ReplicaSet, gc.HasLen, 0)
+	c.Check(health.ReplicaSetError, gc.Equals, "cannot get replica set status: boom")
+}
+
+func (s *controllerSuite) TestControllerHealthRequiresSuperuser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	endpoint, err := controller.NewControllerAPI(
+		facadetest.Context{
+			State_:     s.State,
+			Resources_: s.resources,
+			Auth_:      apiservertesting.FakeAuthorizer{Tag: user.UserTag()},
+		})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = endpoint.ControllerHealth()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
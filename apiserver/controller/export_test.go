@@ -4,16 +4,31 @@
 package controller
 
 import (
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/migration"
 	"github.com/juju/juju/state"
 )
 
+var RunMigrationPrechecks = &runMigrationPrechecks
+
 type patcher interface {
 	PatchValue(destination, source interface{})
 }
 
 func SetPrecheckResult(p patcher, err error) {
-	p.PatchValue(&runMigrationPrechecks, func(*state.State, migration.TargetInfo) error {
+	p.PatchValue(&runMigrationPrechecks, func(*state.State, migration.TargetInfo, migration.CloudRemap) error {
 		return err
 	})
 }
+
+func SetPrecheckReport(p patcher, report migration.PrecheckReport, err error) {
+	p.PatchValue(&runMigrationPrecheckReport, func(*state.State, migration.TargetInfo, migration.CloudRemap) (migration.PrecheckReport, error) {
+		return report, err
+	})
+}
+
+func SetReplicaSetHealth(p patcher, members []params.ReplicaSetMemberHealth, err error) {
+	p.PatchValue(&readReplicaSetHealth, func(*state.State) ([]params.ReplicaSetMemberHealth, error) {
+		return members, err
+	})
+}
@@ -0,0 +1,141 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/replicaset"
+	"github.com/juju/utils/set"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// jujuMachineKey is the replica set member tag which holds the id of
+// the machine hosting the member. It is set by the peergrouper.
+const jujuMachineKey = "juju-machine-id"
+
+// readReplicaSetHealth is patched out in tests, which don't run a
+// replica set that matches the controller machines.
+var readReplicaSetHealth = replicaSetHealth
+
+// ControllerHealth reports on the health of every controller machine,
+// the controller's mongo replica set, and the controller's backlog of
+// transactions and cleanups.
+func (c *ControllerAPI) ControllerHealth() (params.ControllerHealth, error) {
+	if err := c.checkHasAdmin(); err != nil {
+		return params.ControllerHealth{}, errors.Trace(err)
+	}
+
+	machines, err := controllerMachinesHealth(c.state)
+	if err != nil {
+		return params.ControllerHealth{}, errors.Trace(err)
+	}
+	result := params.ControllerHealth{Machines: machines}
+
+	// The rest of the report is useful even when the replica set
+	// can't be read, which is when it's most needed.
+	result.ReplicaSet, err = readReplicaSetHealth(c.state)
+	if err != nil {
+		result.ReplicaSetError = err.Error()
+	}
+
+	result.PendingTransactions, err = c.state.PendingTransactionCount()
+	if err != nil {
+		return params.ControllerHealth{}, errors.Trace(err)
+	}
+	result.CleanupBacklog, err = c.state.CleanupBacklog()
+	if err != nil {
+		return params.ControllerHealth{}, errors.Trace(err)
+	}
+	return result, nil
+}
+
+func controllerMachinesHealth(st *state.State) ([]params.ControllerMachineHealth, error) {
+	info, err := st.ControllerInfo()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	reports, err := st.ControllerHealthReports()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	reportsById := make(map[string]state.ControllerHealthReport)
+	for _, report := range reports {
+		reportsById[report.MachineId] = report
+	}
+	draining := set.NewStrings(info.DrainingMachineIds...)
+
+	result := make([]params.ControllerMachineHealth, len(info.MachineIds))
+	for i, id := range info.MachineIds {
+		m, err := st.Machine(id)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		alive, err := m.AgentPresence()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		health := params.ControllerMachineHealth{
+			Id:         id,
+			AgentAlive: alive,
+			WantsVote:  m.WantsVote(),
+			HasVote:    m.HasVote(),
+			Draining:   draining.Contains(id),
+		}
+		if report, ok := reportsById[id]; ok {
+			reported := report.Updated
+			health.Reported = &reported
+			health.APIConnections = report.APIConnections
+			health.AgentConnections = report.AgentConnections
+			health.Workers = report.WorkerCount
+			for _, worker := range report.UnhealthyWorkers {
+				health.UnhealthyWorkers = append(health.UnhealthyWorkers, params.WorkerHealth{
+					Name:  worker.Name,
+					State: worker.State,
+					Error: worker.Error,
+				})
+			}
+		}
+		result[i] = health
+	}
+	return result, nil
+}
+
+// replicaSetHealth returns the status of each member of the
+// controller's mongo replica set.
+func replicaSetHealth(st *state.State) ([]params.ReplicaSetMemberHealth, error) {
+	session := st.MongoSession().Copy()
+	defer session.Close()
+
+	status, err := replicaset.CurrentStatus(session)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get replica set status")
+	}
+	members, err := replicaset.CurrentMembers(session)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get replica set members")
+	}
+	configById := make(map[int]replicaset.Member)
+	for _, member := range members {
+		configById[member.Id] = member
+	}
+
+	result := make([]params.ReplicaSetMemberHealth, len(status.Members))
+	for i, member := range status.Members {
+		health := params.ReplicaSetMemberHealth{
+			Id:      member.Id,
+			Address: member.Address,
+			State:   member.State.String(),
+			Healthy: member.Healthy,
+			Message: member.ErrMsg,
+		}
+		if config, ok := configById[member.Id]; ok {
+			health.MachineId = config.Tags[jujuMachineKey]
+			health.Voting = config.Votes == nil || *config.Votes > 0
+		}
+		result[i] = health
+	}
+	return result, nil
+}
@@ -0,0 +1,161 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/tomb.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/rpc"
+)
+
+var (
+	// drainDisconnectDelay holds how long a draining API server
+	// waits before closing the connections of remote agents. This
+	// gives the agents time to learn the controller's new API
+	// addresses, which no longer include the draining server.
+	drainDisconnectDelay = 30 * time.Second
+
+	// drainReportInterval holds how often a draining API server
+	// records the number of remote agents still connected to it.
+	drainReportInterval = 5 * time.Second
+)
+
+// errControllerDraining is returned to remote agents which try to log
+// in to a draining API server. It carries the try-again code so that
+// agents back off and connect to another controller.
+var errControllerDraining = errors.Annotate(common.ErrTryAgain, "controller is draining")
+
+// isDraining returns true if the server's controller machine is being
+// drained.
+func (srv *Server) isDraining() bool {
+	return atomic.LoadInt32(&srv.draining) != 0
+}
+
+func (srv *Server) setDraining(draining bool) {
+	var value int32
+	if draining {
+		value = 1
+	}
+	atomic.StoreInt32(&srv.draining, value)
+}
+
+// isRemoteAgent returns true if the entity with the given tag is an
+// agent other than the one running this server.
+func (srv *Server) isRemoteAgent(tag names.Tag) bool {
+	return tag.Kind() != names.UserTagKind && tag != srv.tag
+}
+
+// trackAgentConn records the connection of a remote agent, so that it
+// can be closed if the controller is drained.
+func (srv *Server) trackAgentConn(conn *rpc.Conn) {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	if srv.agentConns == nil {
+		srv.agentConns = make(map[*rpc.Conn]struct{})
+	}
+	srv.agentConns[conn] = struct{}{}
+}
+
+func (srv *Server) untrackAgentConn(conn *rpc.Conn) {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	delete(srv.agentConns, conn)
+}
+
+func (srv *Server) agentConnCount() int {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	return len(srv.agentConns)
+}
+
+// closeAgentConns closes the connections of all remote agents.
+func (srv *Server) closeAgentConns() {
+	srv.mu.Lock()
+	conns := make([]*rpc.Conn, 0, len(srv.agentConns))
+	for conn := range srv.agentConns {
+		conns = append(conns, conn)
+	}
+	srv.mu.Unlock()
+
+	logger.Infof("closing %d agent connection(s) to draining controller", len(conns))
+	for _, conn := range conns {
+		// Closing a connection waits for its outstanding
+		// requests to complete, so don't wait for them in turn.
+		srv.wg.Add(1)
+		go func(conn *rpc.Conn) {
+			defer srv.wg.Done()
+			conn.Close()
+		}(conn)
+	}
+}
+
+// processControllerDrain watches for the server's controller machine
+// being drained. While it is drained, remote agents may not log in,
+// and those already connected are disconnected once they've had time
+// to learn of the other controllers. The number of agents still
+// connected is recorded so that the drain can be followed.
+func (srv *Server) processControllerDrain() error {
+	machineTag, ok := srv.tag.(names.MachineTag)
+	if !ok {
+		// Only controller machines can be drained.
+		<-srv.tomb.Dying()
+		return tomb.ErrDying
+	}
+	machineId := machineTag.Id()
+
+	w := srv.state.WatchControllerInfo()
+	defer w.Stop()
+
+	var disconnect, report <-chan time.Time
+	lastReported := -1
+	for {
+		select {
+		case <-srv.tomb.Dying():
+			return tomb.ErrDying
+		case <-w.Changes():
+			info, err := srv.state.ControllerInfo()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			draining := set.NewStrings(info.DrainingMachineIds...).Contains(machineId)
+			wasDraining := srv.isDraining()
+			srv.setDraining(draining)
+			switch {
+			case draining && !wasDraining:
+				logger.Infof("controller is draining, closing agent connections in %v", drainDisconnectDelay)
+				disconnect = srv.clock.After(drainDisconnectDelay)
+				report = srv.clock.After(0)
+				lastReported = -1
+			case !draining && wasDraining:
+				logger.Infof("controller is no longer draining")
+				disconnect, report = nil, nil
+			}
+		case <-disconnect:
+			disconnect = nil
+			srv.closeAgentConns()
+		case <-report:
+			count := srv.agentConnCount()
+			if count != lastReported {
+				err := srv.state.SetControllerDrainConnections(machineId, count)
+				if errors.IsNotFound(err) {
+					// The controller has been undrained; the
+					// watcher will tell us shortly.
+					report = nil
+					continue
+				} else if err != nil {
+					return errors.Trace(err)
+				}
+				lastReported = count
+			}
+			report = srv.clock.After(drainReportInterval)
+		}
+	}
+}
@@ -29,6 +29,7 @@ var (
 	BZMimeType            = bzMimeType
 	JSMimeType            = jsMimeType
 	SpritePath            = spritePath
+	SummariseWorkers      = summariseWorkers
 )
 
 func ServerMacaroon(srv *Server) (*macaroon.Macaroon, error) {
@@ -20,6 +20,10 @@ import (
 func init() {
 	// Version 0 is no longer supported.
 	common.RegisterStandardFacade("Firewaller", 3, NewFirewallerAPI)
+	// Version 4 adds GetExposeInfo.
+	common.RegisterStandardFacade("Firewaller", 4, NewFirewallerAPI)
+	// Version 5 adds WatchSubnets.
+	common.RegisterStandardFacade("Firewaller", 5, NewFirewallerAPI)
 }
 
 // FirewallerAPI provides access to the Firewaller API facade.
@@ -159,6 +163,38 @@ func (f *FirewallerAPI) watchOneEnvironOpenedPorts(tag names.Tag) (string, []str
 	return "", nil, watcher.EnsureErr(watch)
 }
 
+// WatchSubnets returns a new StringsWatcher for each given model tag,
+// reporting the CIDRs of subnets added, changed or removed.
+func (f *FirewallerAPI) WatchSubnets(args params.Entities) (params.StringsWatchResults, error) {
+	result := params.StringsWatchResults{
+		Results: make([]params.StringsWatchResult, len(args.Entities)),
+	}
+	if len(args.Entities) == 0 {
+		return result, nil
+	}
+	canWatch, err := f.accessEnviron()
+	if err != nil {
+		return params.StringsWatchResults{}, errors.Trace(err)
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseTag(entity.Tag)
+		if err != nil || !canWatch(tag) {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		watch := f.st.WatchSubnets()
+		// Consume the initial event and forward it to the result.
+		changes, ok := <-watch.Changes()
+		if !ok {
+			result.Results[i].Error = common.ServerError(watcher.EnsureErr(watch))
+			continue
+		}
+		result.Results[i].StringsWatcherId = f.resources.Register(watch)
+		result.Results[i].Changes = changes
+	}
+	return result, nil
+}
+
 // GetMachinePorts returns the port ranges opened on a machine for the specified
 // subnet as a map mapping port ranges to the tags of the units that opened
 // them.
@@ -286,6 +322,39 @@ func (f *FirewallerAPI) GetExposed(args params.Entities) (params.BoolResults, er
 	return result, nil
 }
 
+// GetExposeInfo returns, for each given application, whether it is
+// exposed and the source CIDRs from which its open ports may be
+// accessed when it is.
+func (f *FirewallerAPI) GetExposeInfo(args params.Entities) (params.ExposeInfoResults, error) {
+	result := params.ExposeInfoResults{
+		Results: make([]params.ExposeInfoResult, len(args.Entities)),
+	}
+	canAccess, err := f.accessApplication()
+	if err != nil {
+		return params.ExposeInfoResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseApplicationTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		application, err := f.getApplication(canAccess, tag)
+		if err == nil {
+			var cidrs []string
+			cidrs, err = application.ExposedIngressCIDRs()
+			if err == nil {
+				result.Results[i].Result = &params.ExposeInfo{
+					Exposed:      application.IsExposed(),
+					IngressCIDRs: cidrs,
+				}
+			}
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
 // GetAssignedMachine returns the assigned machine tag (if any) for
 // each given unit.
 func (f *FirewallerAPI) GetAssignedMachine(args params.Entities) (params.StringResults, error) {
@@ -77,6 +77,40 @@ func (s *firewallerSuite) TestGetExposed(c *gc.C) {
 	s.testGetExposed(c, s.firewaller)
 }
 
+func (s *firewallerSuite) TestGetExposeInfo(c *gc.C) {
+	err := s.service.SetExposedTo([]string{"10.0.0.0/8"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := addFakeEntities(params.Entities{Entities: []params.Entity{
+		{Tag: s.service.Tag().String()},
+	}})
+	result, err := s.firewaller.GetExposeInfo(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ExposeInfoResults{
+		Results: []params.ExposeInfoResult{
+			{Result: &params.ExposeInfo{Exposed: true, IngressCIDRs: []string{"10.0.0.0/8"}}},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.NotFoundError(`application "bar"`)},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	err = s.service.ClearExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	result, err = s.firewaller.GetExposeInfo(params.Entities{Entities: []params.Entity{
+		{Tag: s.service.Tag().String()},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ExposeInfoResults{
+		Results: []params.ExposeInfoResult{
+			{Result: &params.ExposeInfo{Exposed: false}},
+		},
+	})
+}
+
 func (s *firewallerSuite) TestGetAssignedMachine(c *gc.C) {
 	s.testGetAssignedMachine(c, s.firewaller)
 }
@@ -138,6 +172,40 @@ func (s *firewallerSuite) TestWatchOpenedPorts(c *gc.C) {
 	wc.AssertNoChange()
 }
 
+func (s *firewallerSuite) TestWatchSubnets(c *gc.C) {
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	_, err := s.State.AddSubnet(state.SubnetInfo{CIDR: "10.0.0.0/24", SpaceName: "mgmt"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: s.State.ModelTag().String()},
+		{Tag: s.machines[0].Tag().String()},
+		{Tag: "invalid-tag"},
+	}}
+	result, err := s.firewaller.WatchSubnets(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StringsWatchResults{
+		Results: []params.StringsWatchResult{
+			{Changes: []string{"10.0.0.0/24"}, StringsWatcherId: "1"},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	resource := s.resources.Get("1")
+	defer statetesting.AssertStop(c, resource)
+
+	wc := statetesting.NewStringsWatcherC(c, s.State, resource.(state.StringsWatcher))
+	wc.AssertNoChange()
+
+	_, err = s.State.AddSubnet(state.SubnetInfo{CIDR: "10.0.1.0/24", SpaceName: "mgmt"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange("10.0.1.0/24")
+	wc.AssertNoChange()
+}
+
 func (s *firewallerSuite) TestGetMachinePorts(c *gc.C) {
 	s.openPorts(c)
 
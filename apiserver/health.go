@@ -0,0 +1,106 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/tomb.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/worker/dependency"
+)
+
+// healthReportInterval holds how often the API server of a controller
+// machine records the machine's health.
+var healthReportInterval = time.Minute
+
+// processHealthReports periodically records the health of the server's
+// controller machine, so that it can be reported by any controller.
+func (srv *Server) processHealthReports() error {
+	machineTag, ok := srv.tag.(names.MachineTag)
+	if !ok {
+		// Only controller machines report their health.
+		<-srv.tomb.Dying()
+		return tomb.ErrDying
+	}
+	machineId := machineTag.Id()
+
+	next := srv.clock.After(0)
+	for {
+		select {
+		case <-srv.tomb.Dying():
+			return tomb.ErrDying
+		case <-next:
+			report := srv.healthReport(machineId)
+			if err := srv.state.SetControllerHealthReport(report); err != nil {
+				// A missed report shows up as a stale one; it's
+				// no reason to stop serving the API.
+				logger.Warningf("cannot record controller health: %v", err)
+			}
+			next = srv.clock.After(healthReportInterval)
+		}
+	}
+}
+
+// healthReport describes the current health of the server's controller
+// machine.
+func (srv *Server) healthReport(machineId string) state.ControllerHealthReport {
+	report := state.ControllerHealthReport{
+		MachineId:        machineId,
+		Updated:          srv.clock.Now(),
+		APIConnections:   int(srv.ConnectionCount()),
+		AgentConnections: srv.agentConnCount(),
+	}
+	if srv.dependencyReporter != nil {
+		report.WorkerCount, report.UnhealthyWorkers = summariseWorkers(srv.dependencyReporter.Report())
+	}
+	return report
+}
+
+// summariseWorkers returns the number of workers described by a
+// dependency engine report, and those which have failed. Workers which
+// are stopped only because they aren't needed, such as those which run
+// on the primary controller alone, aren't counted as failed.
+func summariseWorkers(engineReport map[string]interface{}) (int, []state.WorkerHealth) {
+	manifolds, _ := engineReport[dependency.KeyManifolds].(map[string]interface{})
+	var manifoldNames []string
+	for name := range manifolds {
+		manifoldNames = append(manifoldNames, name)
+	}
+	sort.Strings(manifoldNames)
+
+	var unhealthy []state.WorkerHealth
+	for _, name := range manifoldNames {
+		report, _ := manifolds[name].(map[string]interface{})
+		workerState, _ := report[dependency.KeyState].(string)
+		workerError, _ := report[dependency.KeyError].(string)
+		if workerState == "started" || !isWorkerFailure(workerError) {
+			continue
+		}
+		unhealthy = append(unhealthy, state.WorkerHealth{
+			Name:  name,
+			State: workerState,
+			Error: workerError,
+		})
+	}
+	return len(manifoldNames), unhealthy
+}
+
+func isWorkerFailure(message string) bool {
+	switch {
+	case message == "":
+		return false
+	case strings.HasSuffix(message, dependency.ErrMissing.Error()):
+		return false
+	case message == dependency.ErrUninstall.Error():
+		return false
+	case message == dependency.ErrBounce.Error():
+		return false
+	}
+	return true
+}
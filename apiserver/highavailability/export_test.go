@@ -0,0 +1,19 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package highavailability
+
+import (
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+type patcher interface {
+	PatchValue(destination, source interface{})
+}
+
+func SetReplicaSetMembers(p patcher, members []params.ReplicaSetMemberConfig, err error) {
+	p.PatchValue(&currentReplicaSetMembers, func(*state.State) ([]params.ReplicaSetMemberConfig, error) {
+		return members, err
+	})
+}
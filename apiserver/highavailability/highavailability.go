@@ -24,11 +24,20 @@ var logger = loggo.GetLogger("juju.apiserver.highavailability")
 
 func init() {
 	common.RegisterStandardFacade("HighAvailability", 2, NewHighAvailabilityAPI)
+	// Version 3 adds controller draining.
+	common.RegisterStandardFacade("HighAvailability", 3, NewHighAvailabilityAPI)
+	// Version 4 adds controller replica set member settings.
+	common.RegisterStandardFacade("HighAvailability", 4, NewHighAvailabilityAPI)
 }
 
 // HighAvailability defines the methods on the highavailability API end point.
 type HighAvailability interface {
 	EnableHA(args params.ControllersSpecs) (params.ControllersChangeResults, error)
+	DrainControllers(args params.Entities) (params.ErrorResults, error)
+	UndrainControllers(args params.Entities) (params.ErrorResults, error)
+	ControllerDrainStatus(args params.Entities) (params.ControllerDrainStatusResults, error)
+	SetControllerMemberSettings(args params.ControllerMemberSettingsArgs) (params.ErrorResults, error)
+	ControllerReplicaSet() (params.ControllerReplicaSet, error)
 }
 
 // HighAvailabilityAPI implements the HighAvailability interface and is the concrete
@@ -58,15 +67,8 @@ func NewHighAvailabilityAPI(st *state.State, resources facade.Resources, authori
 // controller has the number of machines specified.
 func (api *HighAvailabilityAPI) EnableHA(args params.ControllersSpecs) (params.ControllersChangeResults, error) {
 	results := params.ControllersChangeResults{}
-
-	if api.authorizer.AuthClient() {
-		admin, err := api.authorizer.HasPermission(permission.SuperuserAccess, api.state.ControllerTag())
-		if err != nil && !errors.IsNotFound(err) {
-			return results, errors.Trace(err)
-		}
-		if !admin {
-			return results, common.ServerError(common.ErrPerm)
-		}
+	if err := api.checkCanAdmin(); err != nil {
+		return results, err
 	}
 
 	if len(args.Specs) == 0 {
@@ -83,6 +85,87 @@ func (api *HighAvailabilityAPI) EnableHA(args params.ControllersSpecs) (params.C
 	return results, nil
 }
 
+// checkCanAdmin returns an error if the authenticated client is not a
+// controller superuser. Controller agents are always allowed.
+func (api *HighAvailabilityAPI) checkCanAdmin() error {
+	if !api.authorizer.AuthClient() {
+		return nil
+	}
+	admin, err := api.authorizer.HasPermission(permission.SuperuserAccess, api.state.ControllerTag())
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	if !admin {
+		return common.ServerError(common.ErrPerm)
+	}
+	return nil
+}
+
+// DrainControllers marks each of the given controller machines as
+// draining, so that agents move their connections to the other
+// controllers.
+func (api *HighAvailabilityAPI) DrainControllers(args params.Entities) (params.ErrorResults, error) {
+	return api.changeDrain(args, api.state.DrainController)
+}
+
+// UndrainControllers returns each of the given controller machines to
+// normal service.
+func (api *HighAvailabilityAPI) UndrainControllers(args params.Entities) (params.ErrorResults, error) {
+	return api.changeDrain(args, api.state.UndrainController)
+}
+
+func (api *HighAvailabilityAPI) changeDrain(args params.Entities, change func(string) error) (params.ErrorResults, error) {
+	results := params.ErrorResults{}
+	if err := api.checkCanAdmin(); err != nil {
+		return results, err
+	}
+	if !api.state.IsController() {
+		return results, errors.New("unsupported with hosted models")
+	}
+	blockChecker := common.NewBlockChecker(api.state)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return results, errors.Trace(err)
+	}
+
+	results.Results = make([]params.ErrorResult, len(args.Entities))
+	for i, entity := range args.Entities {
+		tag, err := names.ParseMachineTag(entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		results.Results[i].Error = common.ServerError(change(tag.Id()))
+	}
+	return results, nil
+}
+
+// ControllerDrainStatus reports how far the draining of each of the
+// given controller machines has got.
+func (api *HighAvailabilityAPI) ControllerDrainStatus(args params.Entities) (params.ControllerDrainStatusResults, error) {
+	results := params.ControllerDrainStatusResults{}
+	if err := api.checkCanAdmin(); err != nil {
+		return results, err
+	}
+	results.Results = make([]params.ControllerDrainStatusResult, len(args.Entities))
+	for i, entity := range args.Entities {
+		tag, err := names.ParseMachineTag(entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		status, err := api.state.ControllerDrainStatus(tag.Id())
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = &params.ControllerDrainStatus{
+			Draining:         status.Draining,
+			AgentConnections: status.AgentConnections,
+		}
+	}
+	return results, nil
+}
+
 // Convert machine ids to tags.
 func machineIdsToTags(ids ...string) []string {
 	var result []string
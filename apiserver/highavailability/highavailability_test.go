@@ -400,3 +400,165 @@ func (s *clientSuite) TestEnableHANoSpecs(c *gc.C) {
 	c.Check(err, jc.ErrorIsNil)
 	c.Check(results.Results, gc.HasLen, 0)
 }
+
+func (s *clientSuite) TestDrainControllers(c *gc.C) {
+	_, err := s.enableHA(c, 3, emptyCons, defaultSeries, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.haServer.DrainControllers(params.Entities{
+		Entities: []params.Entity{{Tag: "machine-1"}, {Tag: "machine-42"}, {Tag: "unit-foo-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Check(results.Results[0].Error, gc.IsNil)
+	c.Check(results.Results[1].Error, gc.ErrorMatches, "cannot drain controller 42: machine 42 is not a controller")
+	c.Check(results.Results[2].Error, jc.Satisfies, params.IsCodeUnauthorized)
+
+	info, err := s.State.ControllerInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(info.DrainingMachineIds, jc.DeepEquals, []string{"1"})
+
+	statuses, err := s.haServer.ControllerDrainStatus(params.Entities{
+		Entities: []params.Entity{{Tag: "machine-0"}, {Tag: "machine-1"}, {Tag: "machine-42"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(statuses.Results, gc.HasLen, 3)
+	c.Check(statuses.Results[0].Result, jc.DeepEquals, &params.ControllerDrainStatus{})
+	c.Check(statuses.Results[1].Result, jc.DeepEquals, &params.ControllerDrainStatus{
+		Draining:         true,
+		AgentConnections: -1,
+	})
+	c.Check(statuses.Results[2].Error, jc.Satisfies, params.IsCodeNotFound)
+
+	results, err = s.haServer.UndrainControllers(params.Entities{
+		Entities: []params.Entity{{Tag: "machine-1"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Check(results.Results[0].Error, gc.IsNil)
+
+	info, err = s.State.ControllerInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(info.DrainingMachineIds, gc.HasLen, 0)
+}
+
+func (s *clientSuite) TestBlockDrainControllers(c *gc.C) {
+	_, err := s.enableHA(c, 3, emptyCons, defaultSeries, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.BlockAllChanges(c, "TestBlockDrainControllers")
+
+	_, err = s.haServer.DrainControllers(params.Entities{
+		Entities: []params.Entity{{Tag: "machine-1"}},
+	})
+	s.AssertBlocked(c, err, "TestBlockDrainControllers")
+
+	info, err := s.State.ControllerInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(info.DrainingMachineIds, gc.HasLen, 0)
+}
+
+func (s *clientSuite) TestDrainControllersRequiresSuperuser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	authoriser := apiservertesting.FakeAuthorizer{Tag: user.UserTag()}
+	haServer, err := highavailability.NewHighAvailabilityAPI(s.State, s.resources, authoriser)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = haServer.DrainControllers(params.Entities{
+		Entities: []params.Entity{{Tag: "machine-0"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *clientSuite) TestSetControllerMemberSettings(c *gc.C) {
+	_, err := s.enableHA(c, 3, emptyCons, defaultSeries, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	voting := false
+	priority := 5.0
+	results, err := s.haServer.SetControllerMemberSettings(params.ControllerMemberSettingsArgs{
+		Settings: []params.ControllerMemberSettings{
+			{MachineTag: "machine-2", Voting: &voting},
+			{MachineTag: "machine-1", Priority: &priority},
+			{MachineTag: "machine-42", Voting: &voting},
+			{MachineTag: "unit-foo-0", Priority: &priority},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 4)
+	c.Check(results.Results[0].Error, gc.IsNil)
+	c.Check(results.Results[1].Error, gc.IsNil)
+	c.Check(results.Results[2].Error, gc.ErrorMatches, "cannot set voting of controller 42: machine 42 is not a controller")
+	c.Check(results.Results[3].Error, jc.Satisfies, params.IsCodeUnauthorized)
+
+	info, err := s.State.ControllerInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(info.NonVotingMachineIds, jc.DeepEquals, []string{"2"})
+	c.Check(info.MemberPriorities, jc.DeepEquals, map[string]float64{"1": 5})
+}
+
+func (s *clientSuite) TestBlockSetControllerMemberSettings(c *gc.C) {
+	_, err := s.enableHA(c, 3, emptyCons, defaultSeries, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.BlockAllChanges(c, "TestBlockSetControllerMemberSettings")
+
+	voting := false
+	_, err = s.haServer.SetControllerMemberSettings(params.ControllerMemberSettingsArgs{
+		Settings: []params.ControllerMemberSettings{{MachineTag: "machine-1", Voting: &voting}},
+	})
+	s.AssertBlocked(c, err, "TestBlockSetControllerMemberSettings")
+}
+
+func (s *clientSuite) TestControllerReplicaSet(c *gc.C) {
+	_, err := s.enableHA(c, 3, emptyCons, defaultSeries, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetControllerMemberVoting("2", false)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetControllerMemberPriority("1", 5)
+	c.Assert(err, jc.ErrorIsNil)
+
+	members := []params.ReplicaSetMemberConfig{{
+		Id:        1,
+		Address:   "10.0.0.1:37017",
+		MachineId: "0",
+		Voting:    true,
+		Priority:  1,
+	}}
+	highavailability.SetReplicaSetMembers(s, members, nil)
+
+	result, err := s.haServer.ControllerReplicaSet()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, params.ControllerReplicaSet{
+		Machines: []params.ControllerMember{{
+			MachineId: "0",
+			Voting:    true,
+			Priority:  1,
+			WantsVote: true,
+		}, {
+			MachineId: "1",
+			Voting:    true,
+			Priority:  5,
+			WantsVote: true,
+		}, {
+			MachineId: "2",
+			Priority:  1,
+		}},
+		Members: members,
+	})
+}
+
+func (s *clientSuite) TestControllerReplicaSetError(c *gc.C) {
+	highavailability.SetReplicaSetMembers(s, nil, errors.New("boom"))
+
+	_, err := s.haServer.ControllerReplicaSet()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *clientSuite) TestControllerReplicaSetRequiresSuperuser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	authoriser := apiservertesting.FakeAuthorizer{Tag: user.UserTag()}
+	haServer, err := highavailability.NewHighAvailabilityAPI(s.State, s.resources, authoriser)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = haServer.ControllerReplicaSet()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
@@ -0,0 +1,133 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package highavailability
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/replicaset"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// jujuMachineKey is the replica set member tag which holds the id of
+// the machine hosting the member. It is set by the peergrouper.
+const jujuMachineKey = "juju-machine-id"
+
+// currentReplicaSetMembers is patched out in tests, which don't run a
+// replica set that matches the controller machines.
+var currentReplicaSetMembers = replicaSetMembers
+
+// SetControllerMemberSettings records the replica set settings an
+// operator wants for each of the given controller machines. The
+// peergrouper applies them when it next updates the replica set.
+func (api *HighAvailabilityAPI) SetControllerMemberSettings(args params.ControllerMemberSettingsArgs) (params.ErrorResults, error) {
+	results := params.ErrorResults{}
+	if err := api.checkCanAdmin(); err != nil {
+		return results, err
+	}
+	if !api.state.IsController() {
+		return results, errors.New("unsupported with hosted models")
+	}
+	blockChecker := common.NewBlockChecker(api.state)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return results, errors.Trace(err)
+	}
+
+	results.Results = make([]params.ErrorResult, len(args.Settings))
+	for i, settings := range args.Settings {
+		tag, err := names.ParseMachineTag(settings.MachineTag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		results.Results[i].Error = common.ServerError(api.setMemberSettings(tag.Id(), settings))
+	}
+	return results, nil
+}
+
+func (api *HighAvailabilityAPI) setMemberSettings(machineId string, settings params.ControllerMemberSettings) error {
+	if settings.Priority != nil {
+		if err := api.state.SetControllerMemberPriority(machineId, *settings.Priority); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if settings.Voting != nil {
+		if err := api.state.SetControllerMemberVoting(machineId, *settings.Voting); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// ControllerReplicaSet returns the replica set settings chosen for
+// each controller machine, and the current configuration of the
+// controller's mongo replica set.
+func (api *HighAvailabilityAPI) ControllerReplicaSet() (params.ControllerReplicaSet, error) {
+	if err := api.checkCanAdmin(); err != nil {
+		return params.ControllerReplicaSet{}, err
+	}
+	info, err := api.state.ControllerInfo()
+	if err != nil {
+		return params.ControllerReplicaSet{}, errors.Trace(err)
+	}
+	nonVoting := set.NewStrings(info.NonVotingMachineIds...)
+
+	result := params.ControllerReplicaSet{
+		Machines: make([]params.ControllerMember, len(info.MachineIds)),
+	}
+	for i, id := range info.MachineIds {
+		m, err := api.state.Machine(id)
+		if err != nil {
+			return params.ControllerReplicaSet{}, errors.Trace(err)
+		}
+		priority, ok := info.MemberPriorities[id]
+		if !ok {
+			priority = state.DefaultMemberPriority
+		}
+		result.Machines[i] = params.ControllerMember{
+			MachineId: id,
+			Voting:    !nonVoting.Contains(id),
+			Priority:  priority,
+			WantsVote: m.WantsVote(),
+			HasVote:   m.HasVote(),
+		}
+	}
+
+	result.Members, err = currentReplicaSetMembers(api.state)
+	if err != nil {
+		return params.ControllerReplicaSet{}, errors.Trace(err)
+	}
+	return result, nil
+}
+
+// replicaSetMembers returns the configuration of each member of the
+// controller's mongo replica set.
+func replicaSetMembers(st *state.State) ([]params.ReplicaSetMemberConfig, error) {
+	session := st.MongoSession().Copy()
+	defer session.Close()
+
+	members, err := replicaset.CurrentMembers(session)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get replica set members")
+	}
+	result := make([]params.ReplicaSetMemberConfig, len(members))
+	for i, member := range members {
+		config := params.ReplicaSetMemberConfig{
+			Id:        member.Id,
+			Address:   member.Address,
+			MachineId: member.Tags[jujuMachineKey],
+			Voting:    member.Votes == nil || *member.Votes > 0,
+			Priority:  state.DefaultMemberPriority,
+		}
+		if member.Priority != nil {
+			config.Priority = *member.Priority
+		}
+		result[i] = config
+	}
+	return result, nil
+}
@@ -0,0 +1,238 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package hostfirewaller implements the API facade used by the
+// hostfirewaller worker, which manages the firewall of the machine
+// on which it runs.
+package hostfirewaller
+
+import (
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+func init() {
+	common.RegisterStandardFacade("HostFirewaller", 1, newFacade)
+}
+
+// Backend defines the State API used by the hostfirewaller facade.
+type Backend interface {
+	state.ModelAccessor
+	Machine(id string) (Machine, error)
+
+	// ModelMachineAddresses returns the addresses of all of the
+	// machines in the model, including containers.
+	ModelMachineAddresses() ([]network.Address, error)
+
+	// WatchModelMachineAddresses returns a watcher that notifies of
+	// changes that may affect the addresses of the model's machines.
+	WatchModelMachineAddresses() state.NotifyWatcher
+}
+
+// Machine defines the machine methods used by the hostfirewaller
+// facade.
+type Machine interface {
+	// OpenedPortRanges returns the port ranges opened on the
+	// machine in all subnets, mapped to the names of the units
+	// that opened them.
+	OpenedPortRanges() (map[network.PortRange]string, error)
+
+	// WatchOpenedPorts returns a watcher that notifies of changes
+	// to the ports opened on the machine.
+	WatchOpenedPorts() state.NotifyWatcher
+
+	// EgressRules returns the destinations to which the units on
+	// the machine may connect, or nil if egress is not restricted.
+	EgressRules() ([]network.EgressRule, error)
+
+	// WatchEgressRules returns a watcher that notifies of changes
+	// that may affect the machine's egress rules.
+	WatchEgressRules() state.NotifyWatcher
+}
+
+// Facade implements the API required by the hostfirewaller worker.
+type Facade struct {
+	*common.ModelWatcher
+
+	backend       Backend
+	resources     facade.Resources
+	accessMachine common.AuthFunc
+}
+
+// New returns a new API facade for the hostfirewaller worker.
+func New(backend Backend, resources facade.Resources, authorizer facade.Authorizer) (*Facade, error) {
+	if !authorizer.AuthMachineAgent() {
+		return nil, common.ErrPerm
+	}
+	return &Facade{
+		ModelWatcher:  common.NewModelWatcher(backend, resources, authorizer),
+		backend:       backend,
+		resources:     resources,
+		accessMachine: authorizer.AuthOwner,
+	}, nil
+}
+
+// OpenedPorts returns the port ranges opened on each of the given
+// machines, along with the tags of the units that opened them.
+func (f *Facade) OpenedPorts(args params.Entities) (params.MachinePortsResults, error) {
+	results := params.MachinePortsResults{
+		Results: make([]params.MachinePortsResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		machine, err := f.machine(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		portRanges, err := machine.OpenedPortRanges()
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		ports := make([]params.MachinePortRange, 0, len(portRanges))
+		for portRange, unitName := range portRanges {
+			ports = append(ports, params.MachinePortRange{
+				UnitTag:   names.NewUnitTag(unitName).String(),
+				PortRange: params.FromNetworkPortRange(portRange),
+			})
+		}
+		results.Results[i].Ports = ports
+	}
+	return results, nil
+}
+
+// WatchOpenedPorts returns a NotifyWatcher for each of the given
+// machines, which notifies of changes to the ports opened on the
+// machine.
+func (f *Facade) WatchOpenedPorts(args params.Entities) (params.NotifyWatchResults, error) {
+	results := params.NotifyWatchResults{
+		Results: make([]params.NotifyWatchResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		id, err := f.watch(arg.Tag, Machine.WatchOpenedPorts)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].NotifyWatcherId = id
+	}
+	return results, nil
+}
+
+// EgressRules returns the egress rules, in the form
+// "<cidr>:<port-range>", of each of the given machines. No rules are
+// returned for a machine whose egress is not restricted.
+func (f *Facade) EgressRules(args params.Entities) (params.EgressRulesResults, error) {
+	results := params.EgressRulesResults{
+		Results: make([]params.EgressRulesResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		machine, err := f.machine(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		rules, err := machine.EgressRules()
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		for _, rule := range rules {
+			results.Results[i].Rules = append(results.Results[i].Rules, rule.String())
+		}
+	}
+	return results, nil
+}
+
+// WatchEgressRules returns a NotifyWatcher for each of the given
+// machines, which notifies of changes that may affect the machine's
+// egress rules.
+func (f *Facade) WatchEgressRules(args params.Entities) (params.NotifyWatchResults, error) {
+	results := params.NotifyWatchResults{
+		Results: make([]params.NotifyWatchResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		id, err := f.watch(arg.Tag, Machine.WatchEgressRules)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].NotifyWatcherId = id
+	}
+	return results, nil
+}
+
+// ModelMachineAddresses returns the IP addresses of the machines in
+// the model, from which the hostfirewaller accepts all traffic so
+// that units may reach each other. Machine-local and link-local
+// addresses are omitted.
+func (f *Facade) ModelMachineAddresses() (params.StringsResult, error) {
+	addresses, err := f.backend.ModelMachineAddresses()
+	if err != nil {
+		return params.StringsResult{Error: common.ServerError(err)}, nil
+	}
+	seen := set.NewStrings()
+	for _, address := range addresses {
+		switch address.Type {
+		case network.IPv4Address, network.IPv6Address:
+		default:
+			continue
+		}
+		switch address.Scope {
+		case network.ScopeMachineLocal, network.ScopeLinkLocal:
+			continue
+		}
+		seen.Add(address.Value)
+	}
+	return params.StringsResult{Result: seen.SortedValues()}, nil
+}
+
+// WatchModelMachineAddresses returns a NotifyWatcher that notifies of
+// changes that may affect the addresses of the machines in the model.
+func (f *Facade) WatchModelMachineAddresses() (params.NotifyWatchResult, error) {
+	id, err := f.register(f.backend.WatchModelMachineAddresses())
+	if err != nil {
+		return params.NotifyWatchResult{Error: common.ServerError(err)}, nil
+	}
+	return params.NotifyWatchResult{NotifyWatcherId: id}, nil
+}
+
+// watch starts the watcher returned by getWatcher for the machine
+// with the given tag, and returns its id.
+func (f *Facade) watch(tag string, getWatcher func(Machine) state.NotifyWatcher) (string, error) {
+	machine, err := f.machine(tag)
+	if err != nil {
+		return "", err
+	}
+	return f.register(getWatcher(machine))
+}
+
+// register consumes the initial event of the watcher, and registers it
+// as a resource, returning its id.
+func (f *Facade) register(watch state.NotifyWatcher) (string, error) {
+	// Consume the initial event. Technically, API
+	// calls to Watch 'transmit' the initial event
+	// in the Watch response. But NotifyWatchers
+	// have no state to transmit.
+	if _, ok := <-watch.Changes(); ok {
+		return f.resources.Register(watch), nil
+	}
+	return "", watcher.EnsureErr(watch)
+}
+
+// machine returns the machine with the given tag, if the
+// authenticated agent is permitted to access it.
+func (f *Facade) machine(tag string) (Machine, error) {
+	machineTag, err := names.ParseMachineTag(tag)
+	if err != nil || !f.accessMachine(machineTag) {
+		return nil, common.ErrPerm
+	}
+	return f.backend.Machine(machineTag.Id())
+}
@@ -0,0 +1,251 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hostfirewaller_test
+
+import (
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/hostfirewaller"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing"
+)
+
+type facadeSuite struct {
+	testing.BaseSuite
+	backend    *mockBackend
+	resources  *common.Resources
+	authorizer apiservertesting.FakeAuthorizer
+	facade     *hostfirewaller.Facade
+}
+
+var _ = gc.Suite(&facadeSuite{})
+
+func (s *facadeSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.backend = &mockBackend{
+		machine: &mockMachine{
+			portRanges: map[network.PortRange]string{
+				{FromPort: 80, ToPort: 80, Protocol: "tcp"}: "wordpress/0",
+			},
+			egressRules: []network.EgressRule{
+				network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+			},
+			watcher:       &mockNotifyWatcher{changes: make(chan struct{}, 1)},
+			egressWatcher: &mockNotifyWatcher{changes: make(chan struct{}, 1)},
+		},
+		addresses: []network.Address{
+			network.NewScopedAddress("10.0.0.2", network.ScopeCloudLocal),
+			network.NewScopedAddress("54.0.0.1", network.ScopePublic),
+			network.NewScopedAddress("10.0.0.1", network.ScopeCloudLocal),
+			network.NewScopedAddress("10.0.0.1", network.ScopeCloudLocal),
+			network.NewScopedAddress("2001:db8::1", network.ScopeCloudLocal),
+			network.NewScopedAddress("127.0.0.1", network.ScopeMachineLocal),
+			network.NewScopedAddress("fe80::1", network.ScopeLinkLocal),
+			network.NewScopedAddress("example.com", network.ScopePublic),
+		},
+		addressesWatcher: &mockNotifyWatcher{changes: make(chan struct{}, 1)},
+	}
+	s.backend.machine.watcher.changes <- struct{}{}
+	s.backend.machine.egressWatcher.changes <- struct{}{}
+	s.backend.addressesWatcher.changes <- struct{}{}
+	s.resources = common.NewResources()
+	s.AddCleanup(func(*gc.C) { s.resources.StopAll() })
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewMachineTag("1"),
+	}
+	facade, err := hostfirewaller.New(s.backend, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	s.facade = facade
+}
+
+func (s *facadeSuite) TestNewNotMachineAgent(c *gc.C) {
+	s.authorizer.Tag = names.NewUnitTag("wordpress/0")
+	_, err := hostfirewaller.New(s.backend, s.resources, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *facadeSuite) TestOpenedPorts(c *gc.C) {
+	results, err := s.facade.OpenedPorts(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "machine-0"},
+			{Tag: "machine-1"},
+			{Tag: "unit-wordpress-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.MachinePortsResults{
+		Results: []params.MachinePortsResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{Ports: []params.MachinePortRange{{
+				UnitTag:   "unit-wordpress-0",
+				PortRange: params.PortRange{FromPort: 80, ToPort: 80, Protocol: "tcp"},
+			}}},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+	s.backend.CheckCalls(c, []jujutesting.StubCall{
+		{"Machine", []interface{}{"1"}},
+	})
+}
+
+func (s *facadeSuite) TestOpenedPortsError(c *gc.C) {
+	s.backend.SetErrors(errors.NotFoundf("machine 1"))
+	results, err := s.facade.OpenedPorts(params.Entities{
+		Entities: []params.Entity{{Tag: "machine-1"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "machine 1 not found")
+}
+
+func (s *facadeSuite) TestWatchOpenedPorts(c *gc.C) {
+	results, err := s.facade.WatchOpenedPorts(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "machine-0"},
+			{Tag: "machine-1"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.NotifyWatchResults{
+		Results: []params.NotifyWatchResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{NotifyWatcherId: "1"},
+		},
+	})
+	c.Assert(s.resources.Get("1"), gc.Equals, s.backend.machine.watcher)
+}
+
+func (s *facadeSuite) TestEgressRules(c *gc.C) {
+	results, err := s.facade.EgressRules(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "machine-0"},
+			{Tag: "machine-1"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.EgressRulesResults{
+		Results: []params.EgressRulesResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{Rules: []string{"10.0.0.0/8:443/tcp"}},
+		},
+	})
+}
+
+func (s *facadeSuite) TestWatchEgressRules(c *gc.C) {
+	results, err := s.facade.WatchEgressRules(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "machine-0"},
+			{Tag: "machine-1"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.NotifyWatchResults{
+		Results: []params.NotifyWatchResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{NotifyWatcherId: "1"},
+		},
+	})
+	c.Assert(s.resources.Get("1"), gc.Equals, s.backend.machine.egressWatcher)
+}
+
+func (s *facadeSuite) TestModelMachineAddresses(c *gc.C) {
+	result, err := s.facade.ModelMachineAddresses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StringsResult{
+		Result: []string{"10.0.0.1", "10.0.0.2", "2001:db8::1", "54.0.0.1"},
+	})
+}
+
+func (s *facadeSuite) TestModelMachineAddressesError(c *gc.C) {
+	s.backend.SetErrors(errors.New("boom"))
+	result, err := s.facade.ModelMachineAddresses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, "boom")
+}
+
+func (s *facadeSuite) TestWatchModelMachineAddresses(c *gc.C) {
+	result, err := s.facade.WatchModelMachineAddresses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.NotifyWatchResult{NotifyWatcherId: "1"})
+	c.Assert(s.resources.Get("1"), gc.Equals, s.backend.addressesWatcher)
+}
+
+type mockBackend struct {
+	jujutesting.Stub
+	machine          *mockMachine
+	addresses        []network.Address
+	addressesWatcher *mockNotifyWatcher
+}
+
+func (b *mockBackend) ModelConfig() (*config.Config, error) {
+	return nil, errors.NotImplementedf("ModelConfig")
+}
+
+func (b *mockBackend) WatchForModelConfigChanges() state.NotifyWatcher {
+	return nil
+}
+
+func (b *mockBackend) Machine(id string) (hostfirewaller.Machine, error) {
+	b.MethodCall(b, "Machine", id)
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	return b.machine, nil
+}
+
+func (b *mockBackend) ModelMachineAddresses() ([]network.Address, error) {
+	b.MethodCall(b, "ModelMachineAddresses")
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	return b.addresses, nil
+}
+
+func (b *mockBackend) WatchModelMachineAddresses() state.NotifyWatcher {
+	return b.addressesWatcher
+}
+
+type mockMachine struct {
+	portRanges    map[network.PortRange]string
+	egressRules   []network.EgressRule
+	watcher       *mockNotifyWatcher
+	egressWatcher *mockNotifyWatcher
+}
+
+func (m *mockMachine) OpenedPortRanges() (map[network.PortRange]string, error) {
+	return m.portRanges, nil
+}
+
+func (m *mockMachine) WatchOpenedPorts() state.NotifyWatcher {
+	return m.watcher
+}
+
+func (m *mockMachine) EgressRules() ([]network.EgressRule, error) {
+	return m.egressRules, nil
+}
+
+func (m *mockMachine) WatchEgressRules() state.NotifyWatcher {
+	return m.egressWatcher
+}
+
+type mockNotifyWatcher struct {
+	state.NotifyWatcher
+	changes chan struct{}
+}
+
+func (w *mockNotifyWatcher) Changes() <-chan struct{} {
+	return w.changes
+}
+
+func (w *mockNotifyWatcher) Stop() error {
+	return nil
+}
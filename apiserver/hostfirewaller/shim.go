@@ -0,0 +1,66 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hostfirewaller
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+)
+
+// newFacade wraps New to express the supplied *state.State as a Backend.
+func newFacade(st *state.State, res facade.Resources, auth facade.Authorizer) (*Facade, error) {
+	facade, err := New(backendShim{st}, res, auth)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return facade, nil
+}
+
+type backendShim struct {
+	*state.State
+}
+
+// Machine is part of the Backend interface.
+func (s backendShim) Machine(id string) (Machine, error) {
+	m, err := s.State.Machine(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return machineShim{m}, nil
+}
+
+// ModelMachineAddresses is part of the Backend interface.
+func (s backendShim) ModelMachineAddresses() ([]network.Address, error) {
+	machines, err := s.State.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var addresses []network.Address
+	for _, m := range machines {
+		addresses = append(addresses, m.Addresses()...)
+	}
+	return addresses, nil
+}
+
+type machineShim struct {
+	*state.Machine
+}
+
+// OpenedPortRanges is part of the Machine interface.
+func (m machineShim) OpenedPortRanges() (map[network.PortRange]string, error) {
+	allPorts, err := m.AllPorts()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	portRanges := make(map[network.PortRange]string)
+	for _, ports := range allPorts {
+		for portRange, unitName := range ports.AllPortRanges() {
+			portRanges[portRange] = unitName
+		}
+	}
+	return portRanges, nil
+}
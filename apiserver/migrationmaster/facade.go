@@ -8,8 +8,6 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/utils"
-	"github.com/juju/utils/set"
-	"github.com/juju/version"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
@@ -23,6 +21,12 @@ import (
 
 func init() {
 	common.RegisterStandardFacade("MigrationMaster", 1, newAPIForRegistration)
+
+	// Version 2 adds SetProgress.
+	common.RegisterStandardFacade("MigrationMaster", 2, newAPIForRegistration)
+
+	// Version 3 adds QueuePosition.
+	common.RegisterStandardFacade("MigrationMaster", 3, newAPIForRegistration)
 }
 
 // API implements the API required for the model migration
@@ -89,19 +93,29 @@ func (api *API) MigrationStatus() (params.MasterMigrationStatus, error) {
 	if err != nil {
 		return empty, errors.Annotate(err, "marshalling macaroons")
 	}
-	return params.MasterMigrationStatus{
-		Spec: params.MigrationSpec{
-			ModelTag: names.NewModelTag(mig.ModelUUID()).String(),
-			TargetInfo: params.MigrationTargetInfo{
-				ControllerTag: target.ControllerTag.String(),
-				Addrs:         target.Addrs,
-				CACert:        target.CACert,
-				AuthTag:       target.AuthTag.String(),
-				Password:      target.Password,
-				Macaroons:     string(macsJSON),
-			},
-			ExternalControl: mig.ExternalControl(),
+	spec := params.MigrationSpec{
+		ModelTag: names.NewModelTag(mig.ModelUUID()).String(),
+		TargetInfo: params.MigrationTargetInfo{
+			ControllerTag: target.ControllerTag.String(),
+			Addrs:         target.Addrs,
+			CACert:        target.CACert,
+			AuthTag:       target.AuthTag.String(),
+			Password:      target.Password,
+			Macaroons:     string(macsJSON),
 		},
+		ExternalControl: mig.ExternalControl(),
+	}
+	if remap := mig.CloudRemap(); !remap.IsEmpty() {
+		spec.CloudRemap = &params.MigrationCloudRemap{
+			Cloud:       remap.Cloud,
+			CloudRegion: remap.CloudRegion,
+		}
+		if remap.Credential.Id() != "" {
+			spec.CloudRemap.CredentialTag = remap.Credential.String()
+		}
+	}
+	return params.MasterMigrationStatus{
+		Spec:             spec,
 		MigrationId:      mig.Id(),
 		Phase:            phase.String(),
 		PhaseChangedTime: mig.PhaseChangedTime(),
@@ -172,6 +186,39 @@ func (api *API) SetStatusMessage(args params.SetMigrationStatusMessageArgs) erro
 	return errors.Annotate(err, "failed to set status message")
 }
 
+// SetProgress records structured details about how far the active
+// migration has got through its current phase.
+func (api *API) SetProgress(args params.SetMigrationProgressArgs) error {
+	mig, err := api.backend.LatestMigration()
+	if err != nil {
+		return errors.Annotate(err, "could not get migration")
+	}
+	err = mig.SetProgress(coremigration.Progress{
+		BinariesUploaded:      args.Progress.BinariesUploaded,
+		BinariesTotal:         args.Progress.BinariesTotal,
+		MinionReportsReceived: args.Progress.MinionReportsReceived,
+		MinionReportsExpected: args.Progress.MinionReportsExpected,
+	})
+	return errors.Annotate(err, "failed to set progress")
+}
+
+// QueuePosition reports where the active migration is in the
+// controller's queue of migrations.
+func (api *API) QueuePosition() (params.MigrationQueuePosition, error) {
+	mig, err := api.backend.LatestMigration()
+	if err != nil {
+		return params.MigrationQueuePosition{}, errors.Annotate(err, "could not get migration")
+	}
+	position, err := mig.QueuePosition()
+	if err != nil {
+		return params.MigrationQueuePosition{}, errors.Annotate(err, "retrieving queue position")
+	}
+	return params.MigrationQueuePosition{
+		Ahead: position.Ahead,
+		Limit: position.Limit,
+	}, nil
+}
+
 // Export serializes the model associated with the API connection.
 func (api *API) Export() (params.SerializedModel, error) {
 	var serialized params.SerializedModel
@@ -186,7 +233,7 @@ func (api *API) Export() (params.SerializedModel, error) {
 		return serialized, err
 	}
 	serialized.Bytes = bytes
-	serialized.Charms = getUsedCharms(model)
+	serialized.Charms = migration.UsedCharms(model)
 	serialized.Tools = getUsedTools(model)
 	serialized.Resources = getUsedResources(model)
 	return serialized, nil
@@ -269,83 +316,38 @@ func (api *API) MinionReports() (params.MinionReports, error) {
 	return out, nil
 }
 
-func getUsedCharms(model description.Model) []string {
-	result := set.NewStrings()
-	for _, application := range model.Applications() {
-		result.Add(application.CharmURL())
-	}
-	return result.Values()
-}
-
 func getUsedTools(model description.Model) []params.SerializedModelTools {
-	// Iterate through the model for all tools, and make a map of them.
-	usedVersions := make(map[version.Binary]bool)
-	// It is most likely that the preconditions will limit the number of
-	// tools versions in use, but that is not relied on here.
-	for _, machine := range model.Machines() {
-		addToolsVersionForMachine(machine, usedVersions)
-	}
-
-	for _, application := range model.Applications() {
-		for _, unit := range application.Units() {
-			tools := unit.Tools()
-			usedVersions[tools.Version()] = true
-		}
-	}
-
-	out := make([]params.SerializedModelTools, 0, len(usedVersions))
-	for v := range usedVersions {
+	usedTools := migration.UsedTools(model)
+	sizes := migration.UsedToolsSizes(model)
+	out := make([]params.SerializedModelTools, 0, len(usedTools))
+	for v, uri := range usedTools {
 		out = append(out, params.SerializedModelTools{
 			Version: v.String(),
-			URI:     common.ToolsURL("", v),
+			URI:     uri,
+			Size:    sizes[v],
 		})
 	}
 	return out
 }
 
-func addToolsVersionForMachine(machine description.Machine, usedVersions map[version.Binary]bool) {
-	tools := machine.Tools()
-	usedVersions[tools.Version()] = true
-	for _, container := range machine.Containers() {
-		addToolsVersionForMachine(container, usedVersions)
-	}
-}
-
 func getUsedResources(model description.Model) []params.SerializedModelResource {
 	var out []params.SerializedModelResource
-	for _, app := range model.Applications() {
-		for _, resource := range app.Resources() {
-			outRes := resourceToSerialized(app.Name(), resource)
-
-			// Hunt through the application's units and look for
-			// revisions of this resource. This is particularly
-			// efficient or clever but will be fine even with 1000's
-			// of units and 10's of resources.
-			outRes.UnitRevisions = make(map[string]params.SerializedModelResourceRevision)
-			for _, unit := range app.Units() {
-				for _, unitResource := range unit.Resources() {
-					if unitResource.Name() == resource.Name() {
-						outRes.UnitRevisions[unit.Name()] = revisionToSerialized(unitResource.Revision())
-					}
-				}
-			}
-
-			out = append(out, outRes)
+	for _, used := range migration.UsedResources(model) {
+		outRes := params.SerializedModelResource{
+			Application:         used.Application,
+			Name:                used.Resource.Name(),
+			ApplicationRevision: revisionToSerialized(used.Resource.ApplicationRevision()),
+			CharmStoreRevision:  revisionToSerialized(used.Resource.CharmStoreRevision()),
+			UnitRevisions:       make(map[string]params.SerializedModelResourceRevision),
 		}
-
+		for unitName, rev := range used.UnitRevisions {
+			outRes.UnitRevisions[unitName] = revisionToSerialized(rev)
+		}
+		out = append(out, outRes)
 	}
 	return out
 }
 
-func resourceToSerialized(app string, desc description.Resource) params.SerializedModelResource {
-	return params.SerializedModelResource{
-		Application:         app,
-		Name:                desc.Name(),
-		ApplicationRevision: revisionToSerialized(desc.ApplicationRevision()),
-		CharmStoreRevision:  revisionToSerialized(desc.CharmStoreRevision()),
-	}
-}
-
 func revisionToSerialized(rr description.ResourceRevision) params.SerializedModelResourceRevision {
 	if rr == nil {
 		return params.SerializedModelResourceRevision{}
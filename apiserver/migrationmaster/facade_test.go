@@ -121,6 +121,21 @@ func (s *Suite) TestMigrationStatusExternalControl(c *gc.C) {
 	c.Check(status.Spec.ExternalControl, jc.IsTrue)
 }
 
+func (s *Suite) TestMigrationStatusCloudRemap(c *gc.C) {
+	s.backend.migration.cloudRemap = coremigration.CloudRemap{
+		Cloud:       "openstack",
+		CloudRegion: "RegionOne",
+		Credential:  names.NewCloudCredentialTag("openstack/bob/default"),
+	}
+	status, err := s.mustMakeAPI(c).MigrationStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(status.Spec.CloudRemap, jc.DeepEquals, &params.MigrationCloudRemap{
+		Cloud:         "openstack",
+		CloudRegion:   "RegionOne",
+		CredentialTag: "cloudcred-openstack_bob_default",
+	})
+}
+
 func (s *Suite) TestModelInfo(c *gc.C) {
 	api := s.mustMakeAPI(c)
 	model, err := api.ModelInfo()
@@ -187,6 +202,51 @@ func (s *Suite) TestSetStatusMessageError(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "failed to set status message: blam")
 }
 
+func (s *Suite) TestSetProgress(c *gc.C) {
+	api := s.mustMakeAPI(c)
+
+	err := api.SetProgress(params.SetMigrationProgressArgs{
+		Progress: params.MigrationProgress{
+			BinariesUploaded:      10,
+			BinariesTotal:         100,
+			MinionReportsReceived: 1,
+			MinionReportsExpected: 3,
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.backend.migration.progressSet, jc.DeepEquals, coremigration.Progress{
+		BinariesUploaded:      10,
+		BinariesTotal:         100,
+		MinionReportsReceived: 1,
+		MinionReportsExpected: 3,
+	})
+}
+
+func (s *Suite) TestSetProgressError(c *gc.C) {
+	s.backend.migration.setProgressErr = errors.New("blam")
+	api := s.mustMakeAPI(c)
+
+	err := api.SetProgress(params.SetMigrationProgressArgs{})
+	c.Assert(err, gc.ErrorMatches, "failed to set progress: blam")
+}
+
+func (s *Suite) TestQueuePosition(c *gc.C) {
+	s.backend.migration.queuePosition = coremigration.QueuePosition{Ahead: 2, Limit: 4}
+	api := s.mustMakeAPI(c)
+
+	position, err := api.QueuePosition()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(position, gc.Equals, params.MigrationQueuePosition{Ahead: 2, Limit: 4})
+}
+
+func (s *Suite) TestQueuePositionError(c *gc.C) {
+	s.backend.migration.queuePositionErr = errors.New("blam")
+	api := s.mustMakeAPI(c)
+
+	_, err := api.QueuePosition()
+	c.Assert(err, gc.ErrorMatches, "retrieving queue position: blam")
+}
+
 func (s *Suite) TestPrechecks(c *gc.C) {
 	api := s.mustMakeAPI(c)
 	err := api.Prechecks()
@@ -204,6 +264,7 @@ func (s *Suite) TestExport(c *gc.C) {
 	m := s.model.AddMachine(description.MachineArgs{Id: names.NewMachineTag("9")})
 	m.SetTools(description.AgentToolsArgs{
 		Version: version.MustParseBinary(tools1),
+		Size:    1234,
 	})
 
 	res := app.AddResource(description.ResourceArgs{"bin"})
@@ -263,8 +324,8 @@ func (s *Suite) TestExport(c *gc.C) {
 
 	c.Check(serialized.Charms, gc.DeepEquals, []string{"cs:foo-0"})
 	c.Check(serialized.Tools, jc.SameContents, []params.SerializedModelTools{
-		{tools0, "/tools/" + tools0},
-		{tools1, "/tools/" + tools1},
+		{Version: tools0, URI: "/tools/" + tools0},
+		{Version: tools1, URI: "/tools/" + tools1, Size: 1234},
 	})
 	c.Check(serialized.Resources, gc.DeepEquals, []params.SerializedModelResource{{
 		Application: "foo",
@@ -457,13 +518,25 @@ func (b *stubBackend) Export() (description.Model, error) {
 type stubMigration struct {
 	state.ModelMigration
 
-	stub            *testing.Stub
-	setPhaseErr     error
-	phaseSet        coremigration.Phase
-	setMessageErr   error
-	messageSet      string
-	minionReports   *state.MinionReports
-	externalControl bool
+	stub             *testing.Stub
+	setPhaseErr      error
+	phaseSet         coremigration.Phase
+	setMessageErr    error
+	messageSet       string
+	setProgressErr   error
+	progressSet      coremigration.Progress
+	queuePosition    coremigration.QueuePosition
+	queuePositionErr error
+	minionReports    *state.MinionReports
+	externalControl  bool
+	cloudRemap       coremigration.CloudRemap
+}
+
+func (m *stubMigration) QueuePosition() (coremigration.QueuePosition, error) {
+	if m.queuePositionErr != nil {
+		return coremigration.QueuePosition{}, m.queuePositionErr
+	}
+	return m.queuePosition, nil
 }
 
 func (m *stubMigration) Id() string {
@@ -501,6 +574,10 @@ func (m *stubMigration) TargetInfo() (*coremigration.TargetInfo, error) {
 	}, nil
 }
 
+func (m *stubMigration) CloudRemap() coremigration.CloudRemap {
+	return m.cloudRemap
+}
+
 func (m *stubMigration) SetPhase(phase coremigration.Phase) error {
 	if m.setPhaseErr != nil {
 		return m.setPhaseErr
@@ -509,6 +586,14 @@ func (m *stubMigration) SetPhase(phase coremigration.Phase) error {
 	return nil
 }
 
+func (m *stubMigration) SetProgress(progress coremigration.Progress) error {
+	if m.setProgressErr != nil {
+		return m.setProgressErr
+	}
+	m.progressSet = progress
+	return nil
+}
+
 func (m *stubMigration) SetStatusMessage(message string) error {
 	if m.setMessageErr != nil {
 		return m.setMessageErr
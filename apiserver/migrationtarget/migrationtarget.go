@@ -4,6 +4,7 @@
 package migrationtarget
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/juju/errors"
@@ -12,8 +13,12 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/core/description"
 	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/instance"
 	"github.com/juju/juju/migration"
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
@@ -22,8 +27,18 @@ import (
 
 func init() {
 	common.RegisterStandardFacade("MigrationTarget", 1, newAPIWithRealEnviron)
+
+	// Version 2 adds PrecheckReport.
+	common.RegisterStandardFacade("MigrationTarget", 2, newAPIWithRealEnviron)
+
+	// Version 3 adds CheckMachines.
+	common.RegisterStandardFacade("MigrationTarget", 3, newAPIWithRealEnviron)
 }
 
+// newEnviron opens the Environ that CheckMachines checks a model's
+// instances against. It is a variable so tests can replace it.
+var newEnviron = environs.New
+
 // API implements the API required for the model migration
 // master worker when communicating with the target controller.
 type API struct {
@@ -74,7 +89,7 @@ func checkAuth(authorizer facade.Authorizer, st *state.State) error {
 // Prechecks ensure that the target controller is ready to accept a
 // model migration.
 func (api *API) Prechecks(model params.MigrationModelInfo) error {
-	ownerTag, err := names.ParseUserTag(model.OwnerTag)
+	modelInfo, err := migrationModelInfo(model)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -82,16 +97,58 @@ func (api *API) Prechecks(model params.MigrationModelInfo) error {
 	if err != nil {
 		return errors.Annotate(err, "creating backend")
 	}
-	return migration.TargetPrecheck(
-		backend,
-		coremigration.ModelInfo{
-			UUID:                   model.UUID,
-			Name:                   model.Name,
-			Owner:                  ownerTag,
-			AgentVersion:           model.AgentVersion,
-			ControllerAgentVersion: model.ControllerAgentVersion,
-		},
-	)
+	return migration.TargetPrecheck(backend, modelInfo)
+}
+
+// PrecheckReport runs the same checks as Prechecks but reports every
+// problem found rather than stopping at the first one.
+func (api *API) PrecheckReport(model params.MigrationModelInfo) (params.PrecheckProblems, error) {
+	modelInfo, err := migrationModelInfo(model)
+	if err != nil {
+		return params.PrecheckProblems{}, errors.Trace(err)
+	}
+	backend, err := migration.PrecheckShim(api.state)
+	if err != nil {
+		return params.PrecheckProblems{}, errors.Annotate(err, "creating backend")
+	}
+	problems, err := migration.TargetPrecheckReport(backend, modelInfo)
+	if err != nil {
+		return params.PrecheckProblems{}, errors.Trace(err)
+	}
+	result := params.PrecheckProblems{
+		Problems: make([]params.PrecheckProblem, len(problems)),
+	}
+	for i, problem := range problems {
+		result.Problems[i] = params.PrecheckProblem{
+			Entity:  problem.Entity,
+			Message: problem.Message,
+		}
+	}
+	return result, nil
+}
+
+func migrationModelInfo(model params.MigrationModelInfo) (coremigration.ModelInfo, error) {
+	ownerTag, err := names.ParseUserTag(model.OwnerTag)
+	if err != nil {
+		return coremigration.ModelInfo{}, errors.Trace(err)
+	}
+	var credTag names.CloudCredentialTag
+	if model.CloudCredentialTag != "" {
+		credTag, err = names.ParseCloudCredentialTag(model.CloudCredentialTag)
+		if err != nil {
+			return coremigration.ModelInfo{}, errors.Trace(err)
+		}
+	}
+	return coremigration.ModelInfo{
+		UUID:                   model.UUID,
+		Name:                   model.Name,
+		Owner:                  ownerTag,
+		AgentVersion:           model.AgentVersion,
+		ControllerAgentVersion: model.ControllerAgentVersion,
+		Cloud:                  model.Cloud,
+		CloudRegion:            model.CloudRegion,
+		CloudCredential:        credTag,
+	}, nil
 }
 
 // Import takes a serialized Juju model, deserializes it, and
@@ -214,3 +271,81 @@ func (api *API) AdoptResources(args params.AdoptResourcesArgs) error {
 	}
 	return errors.Trace(env.AdoptResources(model.ControllerUUID(), args.SourceControllerVersion))
 }
+
+// CheckMachines opens the cloud that the serialized model is to be
+// hosted on, using the cloud, region and credential recorded in the
+// model description, and returns an error for each provisioned machine
+// whose instance can't be found there. It is called during prechecks,
+// before the model is imported, to confirm that a model whose cloud
+// details have been remapped still refers to the same instances.
+func (api *API) CheckMachines(args params.SerializedModel) (params.ErrorResults, error) {
+	model, err := description.Deserialize(args.Bytes)
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	env, err := api.modelEnviron(model)
+	if err != nil {
+		return params.ErrorResults{}, errors.Annotate(err, "opening model environ")
+	}
+	instances, err := env.AllInstances()
+	if err != nil && errors.Cause(err) != environs.ErrNoInstances {
+		return params.ErrorResults{}, errors.Annotate(err, "listing instances")
+	}
+	instanceIds := make(map[instance.Id]bool)
+	for _, inst := range instances {
+		instanceIds[inst.Id()] = true
+	}
+
+	// Containers aren't cloud instances, so only the top level
+	// machines are checked.
+	var results []params.ErrorResult
+	for _, machine := range model.Machines() {
+		inst := machine.Instance()
+		if inst == nil {
+			continue
+		}
+		instanceId := instance.Id(inst.InstanceId())
+		if !instanceIds[instanceId] {
+			results = append(results, params.ErrorResult{
+				Error: common.ServerError(errors.Errorf(
+					"machine %s instance %q not found", machine.Id(), instanceId)),
+			})
+		}
+	}
+	return params.ErrorResults{Results: results}, nil
+}
+
+// modelEnviron returns an Environ for the cloud, region and credential
+// recorded in the model description. A credential that only refers to
+// one on this controller is looked up here.
+func (api *API) modelEnviron(model description.Model) (environs.Environ, error) {
+	modelCloud, err := api.state.Cloud(model.Cloud())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var credential *cloud.Credential
+	if creds := model.CloudCredential(); creds != nil {
+		var value cloud.Credential
+		if creds.Reference() {
+			credTag := names.NewCloudCredentialTag(fmt.Sprintf(
+				"%s/%s/%s", creds.Cloud(), creds.Owner(), creds.Name(),
+			))
+			value, err = api.state.CloudCredential(credTag)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+		} else {
+			value = cloud.NewCredential(cloud.AuthType(creds.AuthType()), creds.Attributes())
+		}
+		credential = &value
+	}
+	spec, err := environs.MakeCloudSpec(modelCloud, model.CloudRegion(), credential)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cfg, err := config.New(config.NoDefaults, model.Config())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return newEnviron(environs.OpenParams{Cloud: spec, Config: cfg})
+}
@@ -4,6 +4,7 @@
 package migrationtarget_test
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/juju/errors"
@@ -21,11 +22,13 @@ import (
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/core/description"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
 	"github.com/juju/juju/provider/dummy"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/stateenvirons"
 	statetesting "github.com/juju/juju/state/testing"
 	jujutesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
 )
 
 type Suite struct {
@@ -91,7 +94,7 @@ func (s *Suite) TestPrechecks(c *gc.C) {
 	args := params.MigrationModelInfo{
 		UUID:                   "uuid",
 		Name:                   "some-model",
-		OwnerTag:               names.NewUserTag("someone").String(),
+		OwnerTag:               s.Owner.String(),
 		AgentVersion:           s.controllerVersion(c),
 		ControllerAgentVersion: s.controllerVersion(c),
 	}
@@ -99,6 +102,19 @@ func (s *Suite) TestPrechecks(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *Suite) TestPrechecksUnknownOwner(c *gc.C) {
+	api := s.mustNewAPI(c)
+	args := params.MigrationModelInfo{
+		UUID:                   "uuid",
+		Name:                   "some-model",
+		OwnerTag:               names.NewUserTag("someone").String(),
+		AgentVersion:           s.controllerVersion(c),
+		ControllerAgentVersion: s.controllerVersion(c),
+	}
+	err := api.Prechecks(args)
+	c.Assert(err, gc.ErrorMatches, `model owner "someone" not found on target controller`)
+}
+
 func (s *Suite) TestPrechecksFail(c *gc.C) {
 	controllerVersion := s.controllerVersion(c)
 
@@ -114,6 +130,39 @@ func (s *Suite) TestPrechecksFail(c *gc.C) {
 	c.Assert(err, gc.NotNil)
 }
 
+func (s *Suite) TestPrecheckReport(c *gc.C) {
+	controllerVersion := s.controllerVersion(c)
+	modelVersion := controllerVersion
+	modelVersion.Minor++
+
+	api := s.mustNewAPI(c)
+	result, err := api.PrecheckReport(params.MigrationModelInfo{
+		UUID:                   "uuid",
+		Name:                   "some-model",
+		OwnerTag:               names.NewUserTag("someone").String(),
+		AgentVersion:           modelVersion,
+		ControllerAgentVersion: controllerVersion,
+		Cloud:                  "missing",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Problems, jc.DeepEquals, []params.PrecheckProblem{{
+		Entity:  "model",
+		Message: fmt.Sprintf("model has higher version than target controller (%s > %s)", modelVersion, controllerVersion),
+	}, {
+		Entity:  "model",
+		Message: `cloud "missing" not found on target controller`,
+	}, {
+		Entity:  "model",
+		Message: `model owner "someone" not found on target controller`,
+	}})
+}
+
+func (s *Suite) TestPrecheckReportBadOwner(c *gc.C) {
+	api := s.mustNewAPI(c)
+	_, err := api.PrecheckReport(params.MigrationModelInfo{OwnerTag: "bad"})
+	c.Assert(err, gc.ErrorMatches, `"bad" is not a valid tag`)
+}
+
 func (s *Suite) TestImport(c *gc.C) {
 	api := s.mustNewAPI(c)
 	tag := s.importModel(c, api)
@@ -249,6 +298,61 @@ func (s *Suite) TestAdoptResources(c *gc.C) {
 	env.Stub.CheckCall(c, 0, "AdoptResources", st.ControllerUUID(), version.MustParse("3.2.1"))
 }
 
+func (s *Suite) TestCheckMachines(c *gc.C) {
+	s.Factory.MakeMachine(c, &factory.MachineParams{InstanceId: "inst-0"})
+	_, bytes := s.makeExportedModel(c)
+
+	env := mockEnviron{Stub: &testing.Stub{}, instances: []instance.Instance{
+		&mockInstance{id: "inst-0"},
+	}}
+	s.PatchValue(migrationtarget.NewEnviron, func(args environs.OpenParams) (environs.Environ, error) {
+		c.Check(args.Cloud.Name, gc.Equals, "dummy")
+		c.Check(args.Config.Name(), gc.Equals, "some-model")
+		return &env, nil
+	})
+	api := s.mustNewAPI(c)
+
+	results, err := api.CheckMachines(params.SerializedModel{Bytes: bytes})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 0)
+	env.Stub.CheckCallNames(c, "AllInstances")
+}
+
+func (s *Suite) TestCheckMachinesMissingInstance(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, &factory.MachineParams{InstanceId: "inst-0"})
+	_, bytes := s.makeExportedModel(c)
+
+	env := mockEnviron{Stub: &testing.Stub{}}
+	env.Stub.SetErrors(environs.ErrNoInstances)
+	s.PatchValue(migrationtarget.NewEnviron, func(environs.OpenParams) (environs.Environ, error) {
+		return &env, nil
+	})
+	api := s.mustNewAPI(c)
+
+	results, err := api.CheckMachines(params.SerializedModel{Bytes: bytes})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches,
+		fmt.Sprintf(`machine %s instance "inst-0" not found`, machine.Id()))
+}
+
+func (s *Suite) TestCheckMachinesOpenFails(c *gc.C) {
+	_, bytes := s.makeExportedModel(c)
+	s.PatchValue(migrationtarget.NewEnviron, func(environs.OpenParams) (environs.Environ, error) {
+		return nil, errors.New("boom")
+	})
+	api := s.mustNewAPI(c)
+
+	_, err := api.CheckMachines(params.SerializedModel{Bytes: bytes})
+	c.Assert(err, gc.ErrorMatches, "opening model environ: boom")
+}
+
+func (s *Suite) TestCheckMachinesBadBytes(c *gc.C) {
+	api := s.mustNewAPI(c)
+	_, err := api.CheckMachines(params.SerializedModel{Bytes: []byte("foo")})
+	c.Assert(err, gc.ErrorMatches, "yaml: unmarshal errors:\n.*")
+}
+
 func (s *Suite) newAPI(environFunc stateenvirons.NewEnvironFunc) (*migrationtarget.API, *facadetest.Context, error) {
 	ctx := facadetest.Context{
 		State_:     s.State,
@@ -292,9 +396,24 @@ func (s *Suite) controllerVersion(c *gc.C) version.Number {
 type mockEnviron struct {
 	environs.Environ
 	*testing.Stub
+	instances []instance.Instance
+}
+
+func (e *mockEnviron) AllInstances() ([]instance.Instance, error) {
+	e.MethodCall(e, "AllInstances")
+	return e.instances, e.NextErr()
 }
 
 func (e *mockEnviron) AdoptResources(controllerUUID string, sourceVersion version.Number) error {
 	e.MethodCall(e, "AdoptResources", controllerUUID, sourceVersion)
 	return e.NextErr()
 }
+
+type mockInstance struct {
+	instance.Instance
+	id instance.Id
+}
+
+func (i *mockInstance) Id() instance.Id {
+	return i.id
+}
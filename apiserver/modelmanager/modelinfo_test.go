@@ -22,6 +22,7 @@ import (
 	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/core/migration"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
@@ -303,6 +304,40 @@ func (s *modelInfoSuite) TestRunningMigration(c *gc.C) {
 	c.Assert(migrationResult.End, gc.IsNil)
 }
 
+func (s *modelInfoSuite) TestRunningMigrationProgress(c *gc.C) {
+	start := time.Now().Add(-20 * time.Minute).UTC()
+	importStart := start.Add(time.Minute)
+	s.st.migration = &mockMigration{
+		status: "uploading model binaries into target controller",
+		start:  start,
+		phase:  migration.IMPORT,
+		progress: migration.Progress{
+			BinariesUploaded: 10,
+			BinariesTotal:    100,
+		},
+		phaseTimes: []migration.PhaseTime{
+			{Phase: migration.QUIESCE, Start: start},
+			{Phase: migration.IMPORT, Start: importStart},
+		},
+	}
+
+	results, err := s.modelmanager.ModelInfo(params.Entities{
+		Entities: []params.Entity{{coretesting.ModelTag.String()}},
+	})
+
+	c.Assert(err, jc.ErrorIsNil)
+	migrationResult := results.Results[0].Result.Migration
+	c.Assert(migrationResult.Phase, gc.Equals, "IMPORT")
+	c.Assert(migrationResult.Progress, jc.DeepEquals, &params.MigrationProgress{
+		BinariesUploaded: 10,
+		BinariesTotal:    100,
+	})
+	c.Assert(migrationResult.PhaseTimes, jc.DeepEquals, []params.MigrationPhaseTime{
+		{Phase: "QUIESCE", Start: start},
+		{Phase: "IMPORT", Start: importStart},
+	})
+}
+
 func (s *modelInfoSuite) TestFailedMigration(c *gc.C) {
 	start := time.Now().Add(-20 * time.Minute)
 	end := time.Now().Add(-10 * time.Minute)
@@ -760,6 +795,11 @@ func (m *mockModel) DestroyIncludingHosted() error {
 	return m.NextErr()
 }
 
+func (m *mockModel) DestroyReleasingStorage() error {
+	m.MethodCall(m, "DestroyReleasingStorage")
+	return m.NextErr()
+}
+
 type mockModelUser struct {
 	gitjujutesting.Stub
 	userName       string
@@ -771,9 +811,24 @@ type mockModelUser struct {
 type mockMigration struct {
 	state.ModelMigration
 
-	status string
-	start  time.Time
-	end    time.Time
+	status     string
+	start      time.Time
+	end        time.Time
+	phase      migration.Phase
+	progress   migration.Progress
+	phaseTimes []migration.PhaseTime
+}
+
+func (m *mockMigration) Phase() (migration.Phase, error) {
+	return m.phase, nil
+}
+
+func (m *mockMigration) Progress() migration.Progress {
+	return m.progress
+}
+
+func (m *mockMigration) PhaseTimes() []migration.PhaseTime {
+	return m.phaseTimes
 }
 
 func (m *mockMigration) StatusMessage() string {
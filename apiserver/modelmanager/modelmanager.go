@@ -38,6 +38,13 @@ var logger = loggo.GetLogger("juju.apiserver.modelmanager")
 
 func init() {
 	common.RegisterStandardFacade("ModelManager", 2, newFacade)
+
+	// Version 3 adds support for releasing storage when
+	// destroying models.
+	common.RegisterStandardFacade("ModelManager", 3, newFacade)
+
+	// Version 4 adds ExportModels.
+	common.RegisterStandardFacade("ModelManager", 4, newFacade)
 }
 
 // ModelManager defines the methods on the modelmanager API endpoint.
@@ -45,8 +52,9 @@ type ModelManager interface {
 	CreateModel(args params.ModelCreateArgs) (params.ModelInfo, error)
 	DumpModels(args params.Entities) params.MapResults
 	DumpModelsDB(args params.Entities) params.MapResults
+	ExportModels(args params.Entities) params.SerializedModelResults
 	ListModels(user params.Entity) (params.UserModelList, error)
-	DestroyModels(args params.Entities) (params.ErrorResults, error)
+	DestroyModels(args params.DestroyModelsParams) (params.ErrorResults, error)
 }
 
 // ModelManagerAPI implements the model manager interface and is
@@ -332,7 +340,9 @@ func (m *ModelManagerAPI) CreateModel(args params.ModelCreateArgs) (params.Model
 	return m.getModelInfo(model.ModelTag())
 }
 
-func (m *ModelManagerAPI) dumpModel(args params.Entity) (map[string]interface{}, error) {
+// exportModel returns the serialized description of the model, checking
+// that the user is a controller admin or an admin of the model.
+func (m *ModelManagerAPI) exportModel(args params.Entity) ([]byte, error) {
 	modelTag, err := names.ParseModelTag(args.Tag)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -359,6 +369,11 @@ func (m *ModelManagerAPI) dumpModel(args params.Entity) (map[string]interface{},
 	}
 
 	bytes, err := migration.ExportModel(st)
+	return bytes, errors.Trace(err)
+}
+
+func (m *ModelManagerAPI) dumpModel(args params.Entity) (map[string]interface{}, error) {
+	bytes, err := m.exportModel(args)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -425,6 +440,24 @@ func (m *ModelManagerAPI) DumpModels(args params.Entities) params.MapResults {
 	return results
 }
 
+// ExportModels returns the serialized description of each model, in
+// the same format used for model migration. The user needs to either be
+// a controller admin, or have admin privileges on the model itself.
+func (m *ModelManagerAPI) ExportModels(args params.Entities) params.SerializedModelResults {
+	results := params.SerializedModelResults{
+		Results: make([]params.SerializedModelResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		bytes, err := m.exportModel(entity)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Bytes = bytes
+	}
+	return results
+}
+
 // DumpModelsDB will gather all documents from all model collections
 // for the specified model. The map result contains a map of collection
 // names to lists of documents represented as maps.
@@ -490,7 +523,9 @@ func (m *ModelManagerAPI) ListModels(user params.Entity) (params.UserModelList,
 
 // DestroyModels will try to destroy the specified models.
 // If there is a block on destruction, this method will return an error.
-func (m *ModelManagerAPI) DestroyModels(args params.Entities) (params.ErrorResults, error) {
+// If ReleaseStorage is set, the models' cloud volumes are released
+// rather than destroyed.
+func (m *ModelManagerAPI) DestroyModels(args params.DestroyModelsParams) (params.ErrorResults, error) {
 	results := params.ErrorResults{
 		Results: make([]params.ErrorResult, len(args.Entities)),
 	}
@@ -503,6 +538,9 @@ func (m *ModelManagerAPI) DestroyModels(args params.Entities) (params.ErrorResul
 		if err := m.authCheck(model.Owner()); err != nil {
 			return errors.Trace(err)
 		}
+		if args.ReleaseStorage {
+			return errors.Trace(common.DestroyModelReleasingStorage(m.state, model.ModelTag()))
+		}
 		return errors.Trace(common.DestroyModel(m.state, model.ModelTag()))
 	}
 
@@ -647,6 +685,23 @@ func (m *ModelManagerAPI) getModelInfo(tag names.ModelTag) (params.ModelInfo, er
 			Start:  &startTime,
 			End:    endTime,
 		}
+		if phase, err := migration.Phase(); err == nil {
+			info.Migration.Phase = phase.String()
+		}
+		if progress := migration.Progress(); !progress.IsZero() {
+			info.Migration.Progress = &params.MigrationProgress{
+				BinariesUploaded:      progress.BinariesUploaded,
+				BinariesTotal:         progress.BinariesTotal,
+				MinionReportsReceived: progress.MinionReportsReceived,
+				MinionReportsExpected: progress.MinionReportsExpected,
+			}
+		}
+		for _, phaseTime := range migration.PhaseTimes() {
+			info.Migration.PhaseTimes = append(info.Migration.PhaseTimes, params.MigrationPhaseTime{
+				Phase: phaseTime.Phase.String(),
+				Start: phaseTime.Start,
+			})
+		}
 	}
 	return info, nil
 }
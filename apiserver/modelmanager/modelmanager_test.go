@@ -551,6 +551,33 @@ func (s *modelManagerSuite) TestDumpModelUsers(c *gc.C) {
 	}
 }
 
+func (s *modelManagerSuite) TestExportModels(c *gc.C) {
+	results := s.api.ExportModels(params.Entities{[]params.Entity{{
+		Tag: "application-foo",
+	}, {
+		Tag: s.st.ModelTag().String(),
+	}}})
+
+	c.Assert(results.Results, gc.HasLen, 2)
+	notApp, good := results.Results[0], results.Results[1]
+	c.Check(notApp.Bytes, gc.IsNil)
+	c.Check(notApp.Error.Message, gc.Equals, `"application-foo" is not a valid model tag`)
+
+	c.Check(good.Error, gc.IsNil)
+	c.Check(string(good.Bytes), jc.Contains, "model-uuid: deadbeef-0bad-400d-8000-4b1d0d06f00d")
+}
+
+func (s *modelManagerSuite) TestExportModelsUsers(c *gc.C) {
+	models := params.Entities{[]params.Entity{{Tag: s.st.ModelTag().String()}}}
+	s.setAPIUser(c, names.NewUserTag("otheruser"))
+	results := s.api.ExportModels(models)
+	c.Assert(results.Results, gc.HasLen, 1)
+	result := results.Results[0]
+	c.Assert(result.Bytes, gc.IsNil)
+	c.Assert(result.Error, gc.NotNil)
+	c.Check(result.Error.Message, gc.Equals, `permission denied`)
+}
+
 func (s *modelManagerSuite) TestDumpModelsDB(c *gc.C) {
 	results := s.api.DumpModelsDB(params.Entities{[]params.Entity{{
 		Tag: "bad-tag",
@@ -919,7 +946,7 @@ func (s *modelManagerStateSuite) TestDestroyOwnModel(c *gc.C) {
 	)
 	c.Assert(err, jc.ErrorIsNil)
 
-	results, err := s.modelmanager.DestroyModels(params.Entities{
+	results, err := s.modelmanager.DestroyModels(params.DestroyModelsParams{
 		Entities: []params.Entity{{"model-" + m.UUID}},
 	})
 	c.Assert(err, jc.ErrorIsNil)
@@ -931,6 +958,33 @@ func (s *modelManagerStateSuite) TestDestroyOwnModel(c *gc.C) {
 	c.Assert(model.Life(), gc.Not(gc.Equals), state.Alive)
 }
 
+func (s *modelManagerStateSuite) TestDestroyOwnModelReleasingStorage(c *gc.C) {
+	owner := names.NewUserTag("admin")
+	s.setAPIUser(c, owner)
+	m, err := s.modelmanager.CreateModel(createArgs(owner))
+	c.Assert(err, jc.ErrorIsNil)
+	st, err := s.State.ForModel(names.NewModelTag(m.UUID))
+	c.Assert(err, jc.ErrorIsNil)
+	defer st.Close()
+
+	s.modelmanager, err = modelmanager.NewModelManagerAPI(
+		common.NewModelManagerBackend(st), nil, s.authoriser,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.modelmanager.DestroyModels(params.DestroyModelsParams{
+		Entities:       []params.Entity{{"model-" + m.UUID}},
+		ReleaseStorage: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Life(), gc.Not(gc.Equals), state.Alive)
+}
+
 func (s *modelManagerStateSuite) TestAdminDestroysOtherModel(c *gc.C) {
 	// TODO(perrito666) Both users are admins in this case, this tesst is of dubious
 	// usefulness until proper controller permissions are in place.
@@ -950,7 +1004,7 @@ func (s *modelManagerStateSuite) TestAdminDestroysOtherModel(c *gc.C) {
 	other := s.AdminUserTag(c)
 	s.setAPIUser(c, other)
 
-	results, err := s.modelmanager.DestroyModels(params.Entities{
+	results, err := s.modelmanager.DestroyModels(params.DestroyModelsParams{
 		Entities: []params.Entity{{"model-" + m.UUID}},
 	})
 	c.Assert(err, jc.ErrorIsNil)
@@ -980,7 +1034,7 @@ func (s *modelManagerStateSuite) TestDestroyModelErrors(c *gc.C) {
 	user := names.NewUserTag("other@remote")
 	s.setAPIUser(c, user)
 
-	results, err := s.modelmanager.DestroyModels(params.Entities{
+	results, err := s.modelmanager.DestroyModels(params.DestroyModelsParams{
 		Entities: []params.Entity{
 			{"model-" + m.UUID},
 			{"model-9f484882-2f18-4fd2-967d-db9663db7bea"},
@@ -26,6 +26,10 @@ type BackupsListArgs struct {
 // BackupsDownloadArgs holds the args for the API Download method.
 type BackupsDownloadArgs struct {
 	ID string `json:"id"`
+
+	// Model, if set, identifies a model in the backup to download
+	// as a model archive instead of the backup itself.
+	Model string `json:"model,omitempty"`
 }
 
 // BackupsUploadArgs holds the args for the API Upload method.
@@ -76,4 +80,9 @@ type BackupsMetadataResult struct {
 type RestoreArgs struct {
 	// BackupId holds the id of the backup in server if any
 	BackupId string `json:"backup-id"`
+
+	// ToTime, if set, is the moment up to which the operations
+	// archived from the controller's oplog are replayed after the
+	// backup is restored.
+	ToTime *time.Time `json:"to-time,omitempty"`
 }
@@ -3,6 +3,8 @@
 
 package params
 
+import "time"
+
 // DestroyControllerArgs holds the arguments for destroying a controller.
 type DestroyControllerArgs struct {
 	// DestroyModels specifies whether or not the hosted models
@@ -87,3 +89,49 @@ const (
 	GrantControllerAccess  ControllerAction = "grant"
 	RevokeControllerAccess ControllerAction = "revoke"
 )
+
+// ControllerHealth holds a report on the health of a controller,
+// gathered from all of its machines.
+type ControllerHealth struct {
+	Machines            []ControllerMachineHealth `json:"machines"`
+	ReplicaSet          []ReplicaSetMemberHealth  `json:"replica-set,omitempty"`
+	ReplicaSetError     string                    `json:"replica-set-error,omitempty"`
+	PendingTransactions int                       `json:"pending-transactions"`
+	CleanupBacklog      int                       `json:"cleanup-backlog"`
+}
+
+// ControllerMachineHealth holds a report on the health of a single
+// controller machine. The connection and worker details are those last
+// reported by the machine's agent, at the time given by Reported; they
+// are missing if the agent hasn't reported.
+type ControllerMachineHealth struct {
+	Id               string         `json:"id"`
+	AgentAlive       bool           `json:"agent-alive"`
+	WantsVote        bool           `json:"wants-vote"`
+	HasVote          bool           `json:"has-vote"`
+	Draining         bool           `json:"draining"`
+	Reported         *time.Time     `json:"reported,omitempty"`
+	APIConnections   int            `json:"api-connections"`
+	AgentConnections int            `json:"agent-connections"`
+	Workers          int            `json:"workers"`
+	UnhealthyWorkers []WorkerHealth `json:"unhealthy-workers,omitempty"`
+}
+
+// WorkerHealth describes a worker which is not running as it should.
+type WorkerHealth struct {
+	Name  string `json:"name"`
+	State string `json:"state"`
+	Error string `json:"error,omitempty"`
+}
+
+// ReplicaSetMemberHealth holds the status of a member of the
+// controller's mongo replica set.
+type ReplicaSetMemberHealth struct {
+	Id        int    `json:"id"`
+	Address   string `json:"address"`
+	MachineId string `json:"machine-id,omitempty"`
+	State     string `json:"state"`
+	Healthy   bool   `json:"healthy"`
+	Voting    bool   `json:"voting"`
+	Message   string `json:"message,omitempty"`
+}
@@ -87,6 +87,19 @@ type MapResults struct {
 	Results []MapResult `json:"results"`
 }
 
+// SerializedModelResult holds the result of an API call that returns
+// a serialized model or an error.
+type SerializedModelResult struct {
+	Bytes []byte `json:"bytes,omitempty"`
+	Error *Error `json:"error,omitempty"`
+}
+
+// SerializedModelResults holds the bulk operation result of an API
+// call that returns serialized models.
+type SerializedModelResults struct {
+	Results []SerializedModelResult `json:"results"`
+}
+
 // ModelResult holds the result of an API call returning a name and UUID
 // for a model.
 type ModelResult struct {
@@ -130,6 +143,18 @@ type ModelCreateArgs struct {
 	CloudCredentialTag string `json:"credential,omitempty"`
 }
 
+// DestroyModelsParams holds the arguments for destroying models.
+// It is wire-compatible with Entities, which was previously used.
+type DestroyModelsParams struct {
+	// Entities holds the tags of the models to destroy.
+	Entities []Entity `json:"entities"`
+
+	// ReleaseStorage, if true, causes the models' cloud volumes
+	// to be released rather than destroyed, so that they may later
+	// be imported into another model.
+	ReleaseStorage bool `json:"release-storage,omitempty"`
+}
+
 // Model holds the result of an API call returning a name and UUID
 // for a model and the tag of the server in which it is running.
 type Model struct {
@@ -611,6 +636,7 @@ type ProvisioningInfo struct {
 	Placement        string                    `json:"placement"`
 	Jobs             []multiwatcher.MachineJob `json:"jobs"`
 	Volumes          []VolumeParams            `json:"volumes,omitempty"`
+	Filesystems      []FilesystemParams        `json:"filesystems,omitempty"`
 	Tags             map[string]string         `json:"tags,omitempty"`
 	SubnetsToZones   map[string][]string       `json:"subnets-to-zones,omitempty"`
 	ImageMetadata    []CloudImageMetadata      `json:"image-metadata,omitempty"`
@@ -31,6 +31,25 @@ type MigrationSpec struct {
 	// handling of the InitiateMigration API call to be bypassed. It
 	// is only honoured if ExternalControl is true.
 	SkipInitialPrechecks bool `json:"skip-initial-prechecks"`
+
+	// CloudRemap optionally describes how the model's cloud details
+	// are to be changed when it is imported into the target
+	// controller.
+	CloudRemap *MigrationCloudRemap `json:"cloud-remap,omitempty"`
+
+	// Queue indicates that the migration should wait until the
+	// controller's limit on concurrent migrations allows it to
+	// start.
+	Queue bool `json:"queue,omitempty"`
+}
+
+// MigrationCloudRemap holds the cloud, region and credential to use
+// for a model on a migration's target controller. Empty values are
+// left unchanged.
+type MigrationCloudRemap struct {
+	Cloud         string `json:"cloud,omitempty"`
+	CloudRegion   string `json:"cloud-region,omitempty"`
+	CredentialTag string `json:"credential-tag,omitempty"`
 }
 
 // MigrationTargetInfo holds the details required to connect to and
@@ -70,6 +89,34 @@ type SetMigrationStatusMessageArgs struct {
 	Message string `json:"message"`
 }
 
+// MigrationProgress holds structured details about how far a
+// migration has got through its current phase.
+type MigrationProgress struct {
+	BinariesUploaded      int64 `json:"binaries-uploaded"`
+	BinariesTotal         int64 `json:"binaries-total"`
+	MinionReportsReceived int   `json:"minion-reports-received"`
+	MinionReportsExpected int   `json:"minion-reports-expected"`
+}
+
+// SetMigrationProgressArgs provides structured migration progress
+// to the migrationmaster.SetProgress API method.
+type SetMigrationProgressArgs struct {
+	Progress MigrationProgress `json:"progress"`
+}
+
+// MigrationPhaseTime records when a migration entered a phase.
+type MigrationPhaseTime struct {
+	Phase string    `json:"phase"`
+	Start time.Time `json:"start"`
+}
+
+// MigrationQueuePosition describes where a queued migration is in
+// the controller's queue of migrations.
+type MigrationQueuePosition struct {
+	Ahead int `json:"ahead"`
+	Limit int `json:"limit"`
+}
+
 // SerializedModel wraps a buffer contain a serialised Juju model. It
 // also contains lists of the charms and tools used in the model.
 type SerializedModel struct {
@@ -89,6 +136,9 @@ type SerializedModelTools struct {
 	// with the API server scheme, address and model prefix before it
 	// can be used.
 	URI string `json:"uri"`
+
+	// Size holds the size of the tools binary in bytes, if known.
+	Size int64 `json:"size,omitempty"`
 }
 
 // SerializedModelResource holds the details for a single resource for
@@ -138,6 +188,37 @@ type MigrationModelInfo struct {
 	OwnerTag               string         `json:"owner-tag"`
 	AgentVersion           version.Number `json:"agent-version"`
 	ControllerAgentVersion version.Number `json:"controller-agent-version"`
+	Cloud                  string         `json:"cloud,omitempty"`
+	CloudRegion            string         `json:"cloud-region,omitempty"`
+	CloudCredentialTag     string         `json:"cloud-credential-tag,omitempty"`
+}
+
+// PrecheckProblem describes a single problem found by the model
+// migration prechecks.
+type PrecheckProblem struct {
+	Entity  string `json:"entity"`
+	Message string `json:"message"`
+}
+
+// PrecheckProblems holds the problems found by one side of the model
+// migration prechecks.
+type PrecheckProblems struct {
+	Problems []PrecheckProblem `json:"problems"`
+}
+
+// MigrationPrecheckResult holds the problems found by the source and
+// target prechecks for a single proposed model migration.
+type MigrationPrecheckResult struct {
+	ModelTag string            `json:"model-tag"`
+	Source   []PrecheckProblem `json:"source,omitempty"`
+	Target   []PrecheckProblem `json:"target,omitempty"`
+	Error    *Error            `json:"error,omitempty"`
+}
+
+// MigrationPrecheckResults is used to return the result of running
+// the prechecks for one or more proposed model migrations.
+type MigrationPrecheckResults struct {
+	Results []MigrationPrecheckResult `json:"results"`
 }
 
 // MigrationStatus reports the current status of a model migration.
@@ -112,6 +112,13 @@ type ModelMigrationStatus struct {
 	Status string     `json:"status"`
 	Start  *time.Time `json:"start"`
 	End    *time.Time `json:"end,omitempty"`
+
+	// Phase, Progress and PhaseTimes give structured details of how
+	// far the migration has got. They aren't set by older
+	// controllers.
+	Phase      string               `json:"phase,omitempty"`
+	Progress   *MigrationProgress   `json:"progress,omitempty"`
+	PhaseTimes []MigrationPhaseTime `json:"phase-times,omitempty"`
 }
 
 // ModelInfo holds information about the Juju model.
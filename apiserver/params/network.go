@@ -502,6 +502,26 @@ type MachinePortsResults struct {
 	Results []MachinePortsResult `json:"results"`
 }
 
+// ExposeInfo holds whether an application is exposed, and the source
+// CIDRs from which its open ports may be accessed when it is.
+type ExposeInfo struct {
+	Exposed      bool     `json:"exposed"`
+	IngressCIDRs []string `json:"ingress-cidrs,omitempty"`
+}
+
+// ExposeInfoResult holds a single result of the
+// FirewallerAPIV4.GetExposeInfo() API call.
+type ExposeInfoResult struct {
+	Result *ExposeInfo `json:"result,omitempty"`
+	Error  *Error      `json:"error,omitempty"`
+}
+
+// ExposeInfoResults holds all the results of the
+// FirewallerAPIV4.GetExposeInfo() API call.
+type ExposeInfoResults struct {
+	Results []ExposeInfoResult `json:"results"`
+}
+
 // APIHostPortsResult holds the result of an APIHostPorts
 // call. Each element in the top level slice holds
 // the addresses for one API server.
@@ -276,11 +276,77 @@ type ApplicationSetCharm struct {
 	// update during the upgrade. This field is only understood by Application
 	// facade version 2 and greater.
 	StorageConstraints map[string]StorageConstraints `json:"storage-constraints,omitempty"`
+
+	// RollingUpgrade, if set, causes the charm to be upgraded as a
+	// rolling upgrade, in which units upgrade only once released to
+	// it. This field is only understood by Application facade version
+	// 4 and greater.
+	RollingUpgrade *RollingUpgradeParams `json:"rolling-upgrade,omitempty"`
+}
+
+// RollingUpgradeParams holds the parameters of a rolling charm upgrade.
+type RollingUpgradeParams struct {
+	BatchSize   int  `json:"batch-size"`
+	LeaderFirst bool `json:"leader-first,omitempty"`
+}
+
+// RollingUpgrade describes a rolling charm upgrade in progress.
+type RollingUpgrade struct {
+	CharmURL         string   `json:"charm-url"`
+	PreviousCharmURL string   `json:"previous-charm-url"`
+	BatchSize        int      `json:"batch-size"`
+	LeaderFirst      bool     `json:"leader-first,omitempty"`
+	Released         []string `json:"released,omitempty"`
+	Paused           bool     `json:"paused,omitempty"`
+	PauseReason      string   `json:"pause-reason,omitempty"`
+}
+
+// RollingUpgradeResult holds the rolling charm upgrade in progress for
+// an application, if any, or an error.
+type RollingUpgradeResult struct {
+	Result *RollingUpgrade `json:"result,omitempty"`
+	Error  *Error          `json:"error,omitempty"`
+}
+
+// RollingUpgradeResults holds the results of a bulk RollingUpgrades call.
+type RollingUpgradeResults struct {
+	Results []RollingUpgradeResult `json:"results"`
+}
+
+// ReleaseRollingUpgradeUnits holds parameters for releasing units of an
+// application to its rolling charm upgrade.
+type ReleaseRollingUpgradeUnits struct {
+	ApplicationName string   `json:"application"`
+	Units           []string `json:"units"`
+}
+
+// ReleaseRollingUpgradeUnitsArgs holds multiple
+// ReleaseRollingUpgradeUnits parameters.
+type ReleaseRollingUpgradeUnitsArgs struct {
+	Args []ReleaseRollingUpgradeUnits `json:"args"`
+}
+
+// PauseRollingUpgrade holds parameters for pausing the rolling charm
+// upgrade of an application.
+type PauseRollingUpgrade struct {
+	ApplicationName string `json:"application"`
+	Reason          string `json:"reason"`
+}
+
+// PauseRollingUpgradeArgs holds multiple PauseRollingUpgrade parameters.
+type PauseRollingUpgradeArgs struct {
+	Args []PauseRollingUpgrade `json:"args"`
 }
 
 // ApplicationExpose holds the parameters for making the application Expose call.
 type ApplicationExpose struct {
 	ApplicationName string `json:"application"`
+
+	// ToCIDRs and ToSpaces, if specified, restrict access to the
+	// application to the given CIDRs and the subnets of the given
+	// spaces respectively.
+	ToCIDRs  []string `json:"to-cidrs,omitempty"`
+	ToSpaces []string `json:"to-spaces,omitempty"`
 }
 
 // ApplicationSet holds the parameters for an application Set
@@ -339,6 +405,59 @@ type ApplicationMetricCredentials struct {
 	Creds []ApplicationMetricCredential `json:"creds"`
 }
 
+// HookTimeouts holds the maximum durations that an application's hooks
+// may run for. A zero duration means no timeout.
+type HookTimeouts struct {
+	Default time.Duration            `json:"default,omitempty"`
+	Hooks   map[string]time.Duration `json:"hooks,omitempty"`
+}
+
+// ApplicationHookTimeouts holds parameters for the SetHookTimeouts call.
+type ApplicationHookTimeouts struct {
+	ApplicationName string       `json:"application"`
+	Timeouts        HookTimeouts `json:"timeouts"`
+}
+
+// ApplicationHookTimeoutsArgs holds multiple ApplicationHookTimeouts parameters.
+type ApplicationHookTimeoutsArgs struct {
+	Args []ApplicationHookTimeouts `json:"args"`
+}
+
+// HookTimeoutsResult holds the hook timeouts of an application, or an error.
+type HookTimeoutsResult struct {
+	Result HookTimeouts `json:"result"`
+	Error  *Error       `json:"error,omitempty"`
+}
+
+// HookTimeoutsResults holds the results of a bulk HookTimeouts call.
+type HookTimeoutsResults struct {
+	Results []HookTimeoutsResult `json:"results"`
+}
+
+// ApplicationEgressRules holds parameters for the SetEgressRules call.
+// Rules are in the form "<cidr>:<port-range>".
+type ApplicationEgressRules struct {
+	ApplicationName string   `json:"application"`
+	Rules           []string `json:"rules"`
+}
+
+// ApplicationEgressRulesArgs holds multiple ApplicationEgressRules parameters.
+type ApplicationEgressRulesArgs struct {
+	Args []ApplicationEgressRules `json:"args"`
+}
+
+// EgressRulesResult holds egress rules, in the form
+// "<cidr>:<port-range>", or an error.
+type EgressRulesResult struct {
+	Rules []string `json:"rules"`
+	Error *Error   `json:"error,omitempty"`
+}
+
+// EgressRulesResults holds the results of a bulk EgressRules call.
+type EgressRulesResults struct {
+	Results []EgressRulesResult `json:"results"`
+}
+
 // PublicAddress holds parameters for the PublicAddress call.
 type PublicAddress struct {
 	Target string `json:"target"`
@@ -393,6 +512,10 @@ type DestroyApplicationUnits struct {
 // ApplicationDestroy holds the parameters for making the application Destroy call.
 type ApplicationDestroy struct {
 	ApplicationName string `json:"application"`
+	// ReleaseStorage, if true, causes the application's cloud volumes
+	// to be released rather than destroyed, so that they may later be
+	// imported into another model.
+	ReleaseStorage bool `json:"release-storage,omitempty"`
 }
 
 // Creds holds credentials for identifying an entity.
@@ -728,6 +851,71 @@ type ControllersChanges struct {
 	Converted  []string `json:"converted,omitempty"`
 }
 
+// ControllerDrainStatus describes how far the draining of a
+// controller machine has got.
+type ControllerDrainStatus struct {
+	Draining         bool `json:"draining"`
+	AgentConnections int  `json:"agent-connections"`
+}
+
+// ControllerDrainStatusResult contains the drain status of a single
+// controller machine, or an error.
+type ControllerDrainStatusResult struct {
+	Result *ControllerDrainStatus `json:"result,omitempty"`
+	Error  *Error                 `json:"error,omitempty"`
+}
+
+// ControllerDrainStatusResults contains the results
+// of the ControllerDrainStatus API call.
+type ControllerDrainStatusResults struct {
+	Results []ControllerDrainStatusResult `json:"results"`
+}
+
+// ControllerMemberSettings holds the replica set settings an operator
+// wants for a controller machine. Settings which are nil are left
+// unchanged.
+type ControllerMemberSettings struct {
+	MachineTag string   `json:"machine-tag"`
+	Voting     *bool    `json:"voting,omitempty"`
+	Priority   *float64 `json:"priority,omitempty"`
+}
+
+// ControllerMemberSettingsArgs holds the arguments for the
+// SetControllerMemberSettings API call.
+type ControllerMemberSettingsArgs struct {
+	Settings []ControllerMemberSettings `json:"settings"`
+}
+
+// ControllerReplicaSet describes the replica set settings chosen for
+// each controller machine, and the resulting replica set
+// configuration.
+type ControllerReplicaSet struct {
+	Machines []ControllerMember       `json:"machines"`
+	Members  []ReplicaSetMemberConfig `json:"members"`
+}
+
+// ControllerMember describes the replica set settings of a controller
+// machine. Voting is false if an operator has chosen that the machine
+// never votes; Priority holds the election priority it has while
+// voting.
+type ControllerMember struct {
+	MachineId string  `json:"machine-id"`
+	Voting    bool    `json:"voting"`
+	Priority  float64 `json:"priority"`
+	WantsVote bool    `json:"wants-vote"`
+	HasVote   bool    `json:"has-vote"`
+}
+
+// ReplicaSetMemberConfig describes the configuration of a member of
+// the controller's mongo replica set.
+type ReplicaSetMemberConfig struct {
+	Id        int     `json:"id"`
+	Address   string  `json:"address"`
+	MachineId string  `json:"machine-id,omitempty"`
+	Voting    bool    `json:"voting"`
+	Priority  float64 `json:"priority"`
+}
+
 // FindToolsParams defines parameters for the FindTools method.
 type FindToolsParams struct {
 	// Number will be used to match tools versions exactly if non-zero.
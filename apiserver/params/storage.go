@@ -3,7 +3,11 @@
 
 package params
 
-import "github.com/juju/juju/storage"
+import (
+	"time"
+
+	"github.com/juju/juju/storage"
+)
 
 // MachineBlockDevices holds a machine tag and the block devices present
 // on that machine.
@@ -94,6 +98,10 @@ type StorageAttachment struct {
 	Kind     StorageKind `json:"kind"`
 	Location string      `json:"location"`
 	Life     Life        `json:"life"`
+
+	// Size is the size of the storage's volume or filesystem
+	// in MiB, or zero if it is not known.
+	Size uint64 `json:"size,omitempty"`
 }
 
 // StorageAttachmentId identifies a storage attachment by the tags of the
@@ -108,6 +116,133 @@ type StorageAttachmentIds struct {
 	Ids []StorageAttachmentId `json:"ids"`
 }
 
+// ImportStorageParams contains the parameters for importing an existing
+// volume or filesystem into the model as detached storage.
+type ImportStorageParams struct {
+	// Kind is the kind of storage to import the volume as.
+	Kind StorageKind `json:"kind"`
+
+	// Pool is the name of the storage pool, or storage provider
+	// type, that manages the volume.
+	Pool string `json:"pool"`
+
+	// ProviderId is the storage provider's ID for the volume.
+	ProviderId string `json:"provider-id"`
+
+	// StorageName is the name of the charm storage that the
+	// imported storage may be attached as.
+	StorageName string `json:"storage-name"`
+}
+
+// BulkImportStorageParams contains the parameters for importing a
+// collection of volumes or filesystems.
+type BulkImportStorageParams struct {
+	Storage []ImportStorageParams `json:"storage"`
+}
+
+// ResizeStorageParams contains the parameters for resizing the volume
+// backing a storage instance.
+type ResizeStorageParams struct {
+	// StorageTag is the tag of the storage instance to resize.
+	StorageTag string `json:"storage-tag"`
+
+	// Size is the new size of the storage in MiB.
+	Size uint64 `json:"size"`
+}
+
+// BulkResizeStorageParams contains the parameters for resizing a
+// collection of storage instances.
+type BulkResizeStorageParams struct {
+	Storage []ResizeStorageParams `json:"storage"`
+}
+
+// ImportStorageDetails contains the details of an imported storage
+// instance.
+type ImportStorageDetails struct {
+	StorageTag string `json:"storage-tag"`
+}
+
+// ImportStorageResult holds the result of an API call to import a
+// volume or filesystem.
+type ImportStorageResult struct {
+	Result *ImportStorageDetails `json:"result,omitempty"`
+	Error  *Error                `json:"error,omitempty"`
+}
+
+// ImportStorageResults holds the results of an API call to import a
+// collection of volumes or filesystems.
+type ImportStorageResults struct {
+	Results []ImportStorageResult `json:"results"`
+}
+
+// SnapshotDetails contains the details of a snapshot of the volume
+// backing a storage instance.
+type SnapshotDetails struct {
+	// SnapshotId is the storage provider's ID for the snapshot.
+	SnapshotId string `json:"snapshot-id"`
+
+	// StorageTag is the tag of the storage instance whose volume
+	// was snapshotted.
+	StorageTag string `json:"storage-tag"`
+
+	// VolumeTag is the tag of the volume that was snapshotted.
+	VolumeTag string `json:"volume-tag"`
+
+	// Size is the size of the snapshotted volume, in MiB.
+	Size uint64 `json:"size"`
+
+	// Created is the time at which the snapshot was taken.
+	Created time.Time `json:"created"`
+
+	// Pending is true if the snapshot has been requested, but is yet
+	// to be taken by the machine that manages the volume. A pending
+	// snapshot has no ID.
+	Pending bool `json:"pending,omitempty"`
+}
+
+// CreateSnapshotResult holds the result of an API call to snapshot
+// the volume backing a storage instance.
+type CreateSnapshotResult struct {
+	Result *SnapshotDetails `json:"result,omitempty"`
+	Error  *Error           `json:"error,omitempty"`
+}
+
+// CreateSnapshotResults holds the results of an API call to snapshot
+// the volumes backing a collection of storage instances.
+type CreateSnapshotResults struct {
+	Results []CreateSnapshotResult `json:"results"`
+}
+
+// ListSnapshotsResult holds the result of an API call to list the
+// snapshots of the volume backing a storage instance.
+type ListSnapshotsResult struct {
+	Result []SnapshotDetails `json:"result,omitempty"`
+	Error  *Error            `json:"error,omitempty"`
+}
+
+// ListSnapshotsResults holds the results of an API call to list the
+// snapshots of the volumes backing a collection of storage instances.
+type ListSnapshotsResults struct {
+	Results []ListSnapshotsResult `json:"results"`
+}
+
+// DeleteSnapshotParams identifies a snapshot of the volume backing a
+// storage instance, to be deleted.
+type DeleteSnapshotParams struct {
+	// StorageTag is the tag of the storage instance whose volume
+	// was snapshotted.
+	StorageTag string `json:"storage-tag"`
+
+	// SnapshotId is the storage provider's ID for the snapshot.
+	SnapshotId string `json:"snapshot-id"`
+}
+
+// DeleteSnapshotsParams holds the parameters for deleting a collection
+// of snapshots.
+type DeleteSnapshotsParams struct {
+	Snapshots []DeleteSnapshotParams `json:"snapshots"`
+}
+
 // StorageAttachmentIdsResult holds the result of an API call to retrieve the
 // IDs of a unit's attached storage instances.
 type StorageAttachmentIdsResult struct {
@@ -166,6 +301,9 @@ type MachineStorageIds struct {
 type Volume struct {
 	VolumeTag string     `json:"volume-tag"`
 	Info      VolumeInfo `json:"info"`
+	// Releasing indicates that the volume is to be released,
+	// rather than destroyed, when it is removed.
+	Releasing bool `json:"releasing,omitempty"`
 }
 
 // Volume describes a storage volume in the model.
@@ -209,6 +347,7 @@ type VolumeParams struct {
 	Provider   string                  `json:"provider"`
 	Attributes map[string]interface{}  `json:"attributes,omitempty"`
 	Tags       map[string]string       `json:"tags,omitempty"`
+	SnapshotId string                  `json:"snapshot-id,omitempty"`
 	Attachment *VolumeAttachmentParams `json:"attachment,omitempty"`
 }
 
@@ -270,6 +409,81 @@ type VolumeParamsResults struct {
 	Results []VolumeParamsResult `json:"results,omitempty"`
 }
 
+// VolumeResizeParams holds the parameters for resizing a volume.
+type VolumeResizeParams struct {
+	VolumeTag string `json:"volume-tag"`
+	VolumeId  string `json:"volume-id"`
+	Provider  string `json:"provider"`
+	// Size is the size in MiB that the volume is to be resized to.
+	Size uint64 `json:"size"`
+}
+
+// VolumeResizeParamsResult holds the parameters for resizing a volume.
+type VolumeResizeParamsResult struct {
+	Result VolumeResizeParams `json:"result"`
+	Error  *Error             `json:"error,omitempty"`
+}
+
+// VolumeResizeParamsResults holds the parameters for resizing multiple
+// volumes.
+type VolumeResizeParamsResults struct {
+	Results []VolumeResizeParamsResult `json:"results,omitempty"`
+}
+
+// VolumeSnapshotParams holds the parameters for the pending snapshot
+// operations on a machine-scoped volume.
+type VolumeSnapshotParams struct {
+	VolumeTag string `json:"volume-tag"`
+	VolumeId  string `json:"volume-id"`
+	Provider  string `json:"provider"`
+
+	// Create is true if a snapshot of the volume is to be taken.
+	Create bool `json:"create,omitempty"`
+
+	// Delete holds the IDs of the snapshots to be deleted.
+	Delete []string `json:"delete,omitempty"`
+}
+
+// VolumeSnapshotParamsResult holds the parameters for the pending
+// snapshot operations on a volume.
+type VolumeSnapshotParamsResult struct {
+	Result VolumeSnapshotParams `json:"result"`
+	Error  *Error               `json:"error,omitempty"`
+}
+
+// VolumeSnapshotParamsResults holds the parameters for the pending
+// snapshot operations on multiple volumes.
+type VolumeSnapshotParamsResults struct {
+	Results []VolumeSnapshotParamsResult `json:"results,omitempty"`
+}
+
+// VolumeSnapshot describes a snapshot of a machine-scoped volume.
+type VolumeSnapshot struct {
+	SnapshotId string    `json:"snapshot-id"`
+	Size       uint64    `json:"size"`
+	Created    time.Time `json:"created"`
+}
+
+// VolumeSnapshotOutcome records the outcome of the pending snapshot
+// operations on a machine-scoped volume.
+type VolumeSnapshotOutcome struct {
+	VolumeTag string `json:"volume-tag"`
+
+	// CreateAttempted is true if a requested snapshot was attempted,
+	// in which case Created holds the snapshot if it was taken.
+	CreateAttempted bool            `json:"create-attempted,omitempty"`
+	Created         *VolumeSnapshot `json:"created,omitempty"`
+
+	// Deleted holds the IDs of the snapshots that were deleted.
+	Deleted []string `json:"deleted,omitempty"`
+}
+
+// VolumeSnapshotOutcomes records the outcomes of the pending snapshot
+// operations on multiple volumes.
+type VolumeSnapshotOutcomes struct {
+	Outcomes []VolumeSnapshotOutcome `json:"outcomes"`
+}
+
 // VolumeAttachmentParamsResults holds provisioning parameters for a volume
 // attachment.
 type VolumeAttachmentParamsResult struct {
@@ -377,6 +591,29 @@ type FilesystemParamsResults struct {
 	Results []FilesystemParamsResult `json:"results,omitempty"`
 }
 
+// FilesystemResizeParams holds the parameters for resizing a filesystem.
+type FilesystemResizeParams struct {
+	FilesystemTag string `json:"filesystem-tag"`
+	VolumeTag     string `json:"volume-tag,omitempty"`
+	FilesystemId  string `json:"filesystem-id"`
+	Provider      string `json:"provider"`
+	// Size is the size in MiB that the filesystem is to be grown to.
+	Size uint64 `json:"size"`
+}
+
+// FilesystemResizeParamsResult holds the parameters for resizing a
+// filesystem.
+type FilesystemResizeParamsResult struct {
+	Result FilesystemResizeParams `json:"result"`
+	Error  *Error                 `json:"error,omitempty"`
+}
+
+// FilesystemResizeParamsResults holds the parameters for resizing
+// multiple filesystems.
+type FilesystemResizeParamsResults struct {
+	Results []FilesystemResizeParamsResult `json:"results,omitempty"`
+}
+
 // FilesystemAttachmentParamsResults holds provisioning parameters for a filesystem
 // attachment.
 type FilesystemAttachmentParamsResult struct {
@@ -662,6 +899,10 @@ type StorageAddParams struct {
 
 	// Constraints are specified storage constraints.
 	Constraints StorageConstraints `json:"storage"`
+
+	// SnapshotId, if non-empty, is the storage provider's ID for the
+	// volume snapshot from which to create the storage.
+	SnapshotId string `json:"snapshot-id,omitempty"`
 }
 
 // StoragesAddParams holds storage details to add to units dynamically.
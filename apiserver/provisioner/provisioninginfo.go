@@ -62,6 +62,11 @@ func (p *ProvisionerAPI) getProvisioningInfo(m *state.Machine) (*params.Provisio
 		return nil, errors.Trace(err)
 	}
 
+	filesystems, err := p.machineFilesystemParams(m)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
 	var jobs []multiwatcher.MachineJob
 	for _, job := range m.Jobs() {
 		jobs = append(jobs, job.ToParams())
@@ -96,6 +101,7 @@ func (p *ProvisionerAPI) getProvisioningInfo(m *state.Machine) (*params.Provisio
 		Placement:        m.Placement(),
 		Jobs:             jobs,
 		Volumes:          volumes,
+		Filesystems:      filesystems,
 		Tags:             tags,
 		SubnetsToZones:   subnetsToZones,
 		EndpointBindings: endpointBindings,
@@ -174,6 +180,59 @@ func (p *ProvisionerAPI) machineVolumeParams(m *state.Machine) ([]params.VolumeP
 	return allVolumeParams, nil
 }
 
+// machineFilesystemParams retrieves FilesystemParams for the unprovisioned
+// filesystems scoped to the machine. These filesystems are provisioned by
+// the machine's storage provisioner, but the machine's host may need to
+// prepare for them when creating the machine; the client should ignore
+// parameters that it does not know how to handle.
+func (p *ProvisionerAPI) machineFilesystemParams(m *state.Machine) ([]params.FilesystemParams, error) {
+	filesystemAttachments, err := p.st.MachineFilesystemAttachments(m.MachineTag())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(filesystemAttachments) == 0 {
+		return nil, nil
+	}
+	modelConfig, err := p.st.ModelConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	controllerCfg, err := p.st.ControllerConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var allFilesystemParams []params.FilesystemParams
+	for _, filesystemAttachment := range filesystemAttachments {
+		filesystemTag := filesystemAttachment.Filesystem()
+		if machineTag, ok := names.FilesystemMachine(filesystemTag); !ok || machineTag != m.MachineTag() {
+			continue
+		}
+		filesystem, err := p.st.Filesystem(filesystemTag)
+		if err != nil {
+			return nil, errors.Annotatef(err, "getting filesystem %q", filesystemTag.Id())
+		}
+		if _, ok := filesystem.Params(); !ok {
+			// Already provisioned.
+			continue
+		}
+		storageInstance, err := storagecommon.MaybeAssignedStorageInstance(
+			filesystem.Storage, p.st.StorageInstance,
+		)
+		if err != nil {
+			return nil, errors.Annotatef(err, "getting filesystem %q storage instance", filesystemTag.Id())
+		}
+		filesystemParams, err := storagecommon.FilesystemParams(
+			filesystem, storageInstance, modelConfig.UUID(), controllerCfg.ControllerUUID(),
+			modelConfig, p.storagePoolManager, p.storageProviderRegistry,
+		)
+		if err != nil {
+			return nil, errors.Annotatef(err, "getting filesystem %q parameters", filesystemTag.Id())
+		}
+		allFilesystemParams = append(allFilesystemParams, filesystemParams)
+	}
+	return allFilesystemParams, nil
+}
+
 // machineTags returns machine-specific tags to set on the instance.
 func (p *ProvisionerAPI) machineTags(m *state.Machine, jobs []multiwatcher.MachineJob) (map[string]string, error) {
 	// Names of all units deployed to the machine.
@@ -6,6 +6,7 @@ package provisioner_test
 import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/apiserver/provisioner"
@@ -113,6 +114,31 @@ func (s *withoutControllerSuite) TestProvisioningInfoWithStorage(c *gc.C) {
 	c.Assert(result, jc.DeepEquals, expected)
 }
 
+func (s *withoutControllerSuite) TestProvisioningInfoWithMachineScopedFilesystems(c *gc.C) {
+	template := state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+		Filesystems: []state.MachineFilesystemParams{{
+			Filesystem: state.FilesystemParams{Size: 1024, Pool: "rootfs"},
+		}},
+	}
+	m, err := s.State.AddOneMachine(template)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{{Tag: m.Tag().String()}}}
+	result, err := s.provisioner.ProvisioningInfo(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+
+	filesystems := result.Results[0].Result.Filesystems
+	c.Assert(filesystems, gc.HasLen, 1)
+	c.Assert(filesystems[0].FilesystemTag, gc.Equals, names.NewFilesystemTag(m.Id()+"/0").String())
+	c.Assert(filesystems[0].Provider, gc.Equals, "rootfs")
+	c.Assert(filesystems[0].Size, gc.Equals, uint64(1024))
+	c.Assert(filesystems[0].Attachment, gc.IsNil)
+}
+
 func (s *withoutControllerSuite) TestProvisioningInfoWithSingleNegativeAndPositiveSpaceInConstraints(c *gc.C) {
 	s.addSpacesAndSubnets(c)
 
@@ -42,6 +42,7 @@ import (
 	"github.com/juju/juju/state/presence"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/testing/factory"
+	"github.com/juju/juju/worker/dependency"
 	"github.com/juju/juju/worker/workertest"
 )
 
@@ -242,6 +243,130 @@ func (s *serverSuite) TestMachineLoginStartsPinger(c *gc.C) {
 	s.assertAlive(c, machine, true)
 }
 
+func (s *serverSuite) TestAgentLoginRefusedWhileDraining(c *gc.C) {
+	controllerJobs := []state.MachineJob{state.JobManageModel}
+	controller0 := s.Factory.MakeMachine(c, &factory.MachineParams{Jobs: controllerJobs})
+	s.Factory.MakeMachine(c, &factory.MachineParams{Jobs: controllerJobs})
+	machine, password := s.Factory.MakeMachineReturningPassword(
+		c, &factory.MachineParams{Nonce: "fake_nonce"})
+
+	cfg := defaultServerConfig(c, s.State)
+	cfg.Tag = controller0.Tag()
+	info, srv := newServerWithConfig(c, s.State, cfg)
+	defer assertStop(c, srv)
+	info.ModelTag = s.State.ModelTag()
+	info.Tag = machine.Tag()
+	info.Password = password
+	info.Nonce = "fake_nonce"
+
+	st, err := api.Open(info, fastDialOpts)
+	c.Assert(err, jc.ErrorIsNil)
+	st.Close()
+
+	err = s.State.DrainController(controller0.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		st, err = api.Open(info, fastDialOpts)
+		if err != nil {
+			break
+		}
+		st.Close()
+	}
+	c.Assert(err, gc.ErrorMatches, "controller is draining: try again")
+	c.Assert(err, jc.Satisfies, params.IsCodeTryAgain)
+
+	err = s.State.UndrainController(controller0.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		st, err = api.Open(info, fastDialOpts)
+		if err == nil {
+			st.Close()
+			break
+		}
+	}
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+type fakeDependencyReporter map[string]interface{}
+
+func (r fakeDependencyReporter) Report() map[string]interface{} {
+	return r
+}
+
+func (s *serverSuite) TestControllerHealthRecorded(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, &factory.MachineParams{
+		Jobs: []state.MachineJob{state.JobManageModel},
+	})
+	cfg := defaultServerConfig(c, s.State)
+	cfg.Tag = machine.Tag()
+	cfg.DependencyReporter = fakeDependencyReporter{
+		dependency.KeyManifolds: map[string]interface{}{
+			"api-caller": map[string]interface{}{
+				dependency.KeyState: "started",
+			},
+			"peer-grouper": map[string]interface{}{
+				dependency.KeyState: "stopped",
+				dependency.KeyError: "cannot get replica set status: boom",
+			},
+		},
+	}
+	_, srv := newServerWithConfig(c, s.State, cfg)
+	defer assertStop(c, srv)
+
+	var reports []state.ControllerHealthReport
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		var err error
+		reports, err = s.State.ControllerHealthReports()
+		c.Assert(err, jc.ErrorIsNil)
+		if len(reports) > 0 {
+			break
+		}
+	}
+	c.Assert(reports, gc.HasLen, 1)
+	c.Check(reports[0].MachineId, gc.Equals, machine.Id())
+	c.Check(reports[0].WorkerCount, gc.Equals, 2)
+	c.Check(reports[0].UnhealthyWorkers, jc.DeepEquals, []state.WorkerHealth{{
+		Name:  "peer-grouper",
+		State: "stopped",
+		Error: "cannot get replica set status: boom",
+	}})
+}
+
+func (s *serverSuite) TestSummariseWorkers(c *gc.C) {
+	count, unhealthy := apiserver.SummariseWorkers(map[string]interface{}{
+		dependency.KeyManifolds: map[string]interface{}{
+			"started": map[string]interface{}{
+				dependency.KeyState: "started",
+			},
+			"not-needed": map[string]interface{}{
+				dependency.KeyState: "stopped",
+				dependency.KeyError: dependency.ErrMissing.Error(),
+			},
+			"flagged-off": map[string]interface{}{
+				dependency.KeyState: "stopped",
+				dependency.KeyError: `"is-primary-controller-flag" not running: dependency not available`,
+			},
+			"failed": map[string]interface{}{
+				dependency.KeyState: "stopped",
+				dependency.KeyError: "boom",
+			},
+			"restarting": map[string]interface{}{
+				dependency.KeyState: "starting",
+				dependency.KeyError: "splat",
+			},
+		},
+	})
+	c.Check(count, gc.Equals, 5)
+	c.Check(unhealthy, jc.DeepEquals, []state.WorkerHealth{
+		{Name: "failed", State: "stopped", Error: "boom"},
+		{Name: "restarting", State: "starting", Error: "splat"},
+	})
+
+	count, unhealthy = apiserver.SummariseWorkers(map[string]interface{}{})
+	c.Check(count, gc.Equals, 0)
+	c.Check(unhealthy, gc.HasLen, 0)
+}
+
 func (s *serverSuite) TestUnitLoginStartsPinger(c *gc.C) {
 	// Create a new service and unit to verify "agent alive" behavior.
 	unit, password := s.Factory.MakeUnitReturningPassword(c, nil)
@@ -84,6 +84,14 @@ const (
 	filesystemAttachmentsCall               = "filesystemAttachments"
 	allFilesystemsCall                      = "allFilesystems"
 	addStorageForUnitCall                   = "addStorageForUnit"
+	detachStorageCall                       = "detachStorage"
+	attachStorageCall                       = "attachStorage"
+	checkExistingVolumeCall                 = "checkExistingVolume"
+	addExistingVolumeCall                   = "addExistingVolume"
+	addExistingFilesystemCall               = "addExistingFilesystem"
+	resizeStorageCall                       = "resizeStorage"
+	requestVolumeSnapshotCall               = "requestVolumeSnapshot"
+	destroyVolumeSnapshotCall               = "destroyVolumeSnapshot"
 	getBlockForTypeCall                     = "getBlockForType"
 	volumeAttachmentCall                    = "volumeAttachment"
 )
@@ -224,6 +232,44 @@ func (s *baseStorageSuite) constructState() *mockState {
 			s.calls = append(s.calls, addStorageForUnitCall)
 			return nil
 		},
+		detachStorage: func(storage names.StorageTag, unit names.UnitTag) error {
+			s.calls = append(s.calls, detachStorageCall)
+			if storage != s.storageTag || unit != s.unitTag {
+				return errors.NotFoundf("attachment of %s to %s", storage.Id(), unit.Id())
+			}
+			return nil
+		},
+		attachStorage: func(storage names.StorageTag, unit names.UnitTag) error {
+			s.calls = append(s.calls, attachStorageCall)
+			if storage != s.storageTag {
+				return errors.NotFoundf("%s", names.ReadableString(storage))
+			}
+			return nil
+		},
+		checkExistingVolume: func(volumeId, pool string) error {
+			s.calls = append(s.calls, checkExistingVolumeCall)
+			return nil
+		},
+		addExistingVolume: func(info state.VolumeInfo, storageName string) (names.StorageTag, error) {
+			s.calls = append(s.calls, addExistingVolumeCall)
+			return names.NewStorageTag(storageName + "/1"), nil
+		},
+		addExistingFilesystem: func(info state.FilesystemInfo, backingVolume state.VolumeInfo, storageName string) (names.StorageTag, error) {
+			s.calls = append(s.calls, addExistingFilesystemCall)
+			return names.NewStorageTag(storageName + "/1"), nil
+		},
+		resizeStorage: func(storage names.StorageTag, size uint64) error {
+			s.calls = append(s.calls, resizeStorageCall)
+			return nil
+		},
+		requestVolumeSnapshot: func(volume names.VolumeTag) error {
+			s.calls = append(s.calls, requestVolumeSnapshotCall)
+			return nil
+		},
+		destroyVolumeSnapshot: func(volume names.VolumeTag, snapshotId string) error {
+			s.calls = append(s.calls, destroyVolumeSnapshotCall)
+			return nil
+		},
 		getBlockForType: func(t state.BlockType) (state.Block, bool, error) {
 			s.calls = append(s.calls, getBlockForTypeCall)
 			val, found := s.blocks[t]
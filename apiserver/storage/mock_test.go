@@ -8,9 +8,11 @@ import (
 	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
 	jujustorage "github.com/juju/juju/storage"
+	coretesting "github.com/juju/juju/testing"
 )
 
 type mockPoolManager struct {
@@ -49,6 +51,8 @@ type mockState struct {
 	watchFilesystemAttachment           func(names.MachineTag, names.FilesystemTag) state.NotifyWatcher
 	watchVolumeAttachment               func(names.MachineTag, names.VolumeTag) state.NotifyWatcher
 	watchBlockDevices                   func(names.MachineTag) state.NotifyWatcher
+	watchVolume                         func(names.VolumeTag) state.NotifyWatcher
+	watchFilesystem                     func(names.FilesystemTag) state.NotifyWatcher
 	modelName                           string
 	modelTag                            names.ModelTag
 	volume                              func(tag names.VolumeTag) (state.Volume, error)
@@ -60,6 +64,14 @@ type mockState struct {
 	filesystemAttachments               func(filesystem names.FilesystemTag) ([]state.FilesystemAttachment, error)
 	allFilesystems                      func() ([]state.Filesystem, error)
 	addStorageForUnit                   func(u names.UnitTag, name string, cons state.StorageConstraints) error
+	detachStorage                       func(names.StorageTag, names.UnitTag) error
+	attachStorage                       func(names.StorageTag, names.UnitTag) error
+	checkExistingVolume                 func(string, string) error
+	addExistingVolume                   func(state.VolumeInfo, string) (names.StorageTag, error)
+	addExistingFilesystem               func(state.FilesystemInfo, state.VolumeInfo, string) (names.StorageTag, error)
+	resizeStorage                       func(names.StorageTag, uint64) error
+	requestVolumeSnapshot               func(names.VolumeTag) error
+	destroyVolumeSnapshot               func(names.VolumeTag, string) error
 	getBlockForType                     func(t state.BlockType) (state.Block, bool, error)
 	blockDevices                        func(names.MachineTag) ([]state.BlockDeviceInfo, error)
 }
@@ -112,6 +124,14 @@ func (st *mockState) WatchBlockDevices(mtag names.MachineTag) state.NotifyWatche
 	return st.watchBlockDevices(mtag)
 }
 
+func (st *mockState) WatchVolume(v names.VolumeTag) state.NotifyWatcher {
+	return st.watchVolume(v)
+}
+
+func (st *mockState) WatchFilesystem(f names.FilesystemTag) state.NotifyWatcher {
+	return st.watchFilesystem(f)
+}
+
 func (st *mockState) ModelName() (string, error) {
 	return st.modelName, nil
 }
@@ -156,6 +176,46 @@ func (st *mockState) AddStorageForUnit(u names.UnitTag, name string, cons state.
 	return st.addStorageForUnit(u, name, cons)
 }
 
+func (st *mockState) DetachStorage(storage names.StorageTag, unit names.UnitTag) error {
+	return st.detachStorage(storage, unit)
+}
+
+func (st *mockState) AttachStorage(storage names.StorageTag, unit names.UnitTag) error {
+	return st.attachStorage(storage, unit)
+}
+
+func (st *mockState) CheckExistingVolume(volumeId, pool string) error {
+	return st.checkExistingVolume(volumeId, pool)
+}
+
+func (st *mockState) AddExistingVolume(info state.VolumeInfo, storageName string) (names.StorageTag, error) {
+	return st.addExistingVolume(info, storageName)
+}
+
+func (st *mockState) AddExistingFilesystem(info state.FilesystemInfo, backingVolume state.VolumeInfo, storageName string) (names.StorageTag, error) {
+	return st.addExistingFilesystem(info, backingVolume, storageName)
+}
+
+func (st *mockState) ResizeStorage(storage names.StorageTag, size uint64) error {
+	return st.resizeStorage(storage, size)
+}
+
+func (st *mockState) RequestVolumeSnapshot(volume names.VolumeTag) error {
+	return st.requestVolumeSnapshot(volume)
+}
+
+func (st *mockState) DestroyVolumeSnapshot(volume names.VolumeTag, snapshotId string) error {
+	return st.destroyVolumeSnapshot(volume, snapshotId)
+}
+
+func (st *mockState) ModelConfig() (*config.Config, error) {
+	return config.New(config.UseDefaults, coretesting.FakeConfig())
+}
+
+func (st *mockState) ControllerTag() names.ControllerTag {
+	return coretesting.ControllerTag
+}
+
 func (st *mockState) GetBlockForType(t state.BlockType) (state.Block, bool, error) {
 	return st.getBlockForType(t)
 }
@@ -178,9 +238,11 @@ func (m *mockNotifyWatcher) Changes() <-chan struct{} {
 
 type mockVolume struct {
 	state.Volume
-	tag     names.VolumeTag
-	storage *names.StorageTag
-	info    *state.VolumeInfo
+	tag               names.VolumeTag
+	storage           *names.StorageTag
+	info              *state.VolumeInfo
+	snapshotRequested bool
+	snapshots         []state.VolumeSnapshot
 }
 
 func (m *mockVolume) StorageInstance() (names.StorageTag, error) {
@@ -208,6 +270,14 @@ func (m *mockVolume) Info() (state.VolumeInfo, error) {
 	return state.VolumeInfo{}, errors.NotProvisionedf("%v", m.tag)
 }
 
+func (m *mockVolume) SnapshotRequested() bool {
+	return m.snapshotRequested
+}
+
+func (m *mockVolume) Snapshots() []state.VolumeSnapshot {
+	return m.snapshots
+}
+
 func (m *mockVolume) Status() (status.StatusInfo, error) {
 	return status.StatusInfo{Status: status.Attached}, nil
 }
@@ -282,8 +352,8 @@ func (m *mockStorageInstance) Kind() state.StorageKind {
 	return m.kind
 }
 
-func (m *mockStorageInstance) Owner() names.Tag {
-	return m.owner
+func (m *mockStorageInstance) Owner() (names.Tag, bool) {
+	return m.owner, m.owner != nil
 }
 
 func (m *mockStorageInstance) Tag() names.Tag {
@@ -308,7 +378,7 @@ func (m *mockStorageAttachment) StorageInstance() names.StorageTag {
 }
 
 func (m *mockStorageAttachment) Unit() names.UnitTag {
-	return m.storage.Owner().(names.UnitTag)
+	return m.storage.owner.(names.UnitTag)
 }
 
 type mockVolumeAttachment struct {
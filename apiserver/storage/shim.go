@@ -10,6 +10,7 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/stateenvirons"
 	"github.com/juju/juju/storage/poolmanager"
@@ -22,6 +23,18 @@ import (
 
 func init() {
 	common.RegisterStandardFacade("Storage", 3, newAPI)
+
+	// Version 4 adds support for detaching and attaching storage.
+	common.RegisterStandardFacade("Storage", 4, newAPI)
+
+	// Version 5 adds support for importing storage.
+	common.RegisterStandardFacade("Storage", 5, newAPI)
+
+	// Version 6 adds support for snapshotting storage.
+	common.RegisterStandardFacade("Storage", 6, newAPI)
+
+	// Version 7 adds support for resizing storage.
+	common.RegisterStandardFacade("Storage", 7, newAPI)
 }
 
 func newAPI(
@@ -75,6 +88,12 @@ type storageAccess interface {
 	// WatchBlockDevices is required for storage functionality.
 	WatchBlockDevices(names.MachineTag) state.NotifyWatcher
 
+	// WatchVolume is required for storage functionality.
+	WatchVolume(names.VolumeTag) state.NotifyWatcher
+
+	// WatchFilesystem is required for storage functionality.
+	WatchFilesystem(names.FilesystemTag) state.NotifyWatcher
+
 	// BlockDevices is required for storage functionality.
 	BlockDevices(names.MachineTag) ([]state.BlockDeviceInfo, error)
 
@@ -111,6 +130,36 @@ type storageAccess interface {
 	// AddStorageForUnit is required for storage add functionality.
 	AddStorageForUnit(tag names.UnitTag, name string, cons state.StorageConstraints) error
 
+	// DetachStorage is required for storage detach functionality.
+	DetachStorage(names.StorageTag, names.UnitTag) error
+
+	// AttachStorage is required for storage attach functionality.
+	AttachStorage(names.StorageTag, names.UnitTag) error
+
+	// CheckExistingVolume is required for storage import functionality.
+	CheckExistingVolume(volumeId, pool string) error
+
+	// AddExistingVolume is required for storage import functionality.
+	AddExistingVolume(state.VolumeInfo, string) (names.StorageTag, error)
+
+	// AddExistingFilesystem is required for storage import functionality.
+	AddExistingFilesystem(state.FilesystemInfo, state.VolumeInfo, string) (names.StorageTag, error)
+
+	// ResizeStorage is required for storage resize functionality.
+	ResizeStorage(names.StorageTag, uint64) error
+
+	// RequestVolumeSnapshot is required for storage snapshot functionality.
+	RequestVolumeSnapshot(names.VolumeTag) error
+
+	// DestroyVolumeSnapshot is required for storage snapshot functionality.
+	DestroyVolumeSnapshot(names.VolumeTag, string) error
+
+	// ModelConfig is required for storage import functionality.
+	ModelConfig() (*config.Config, error)
+
+	// ControllerTag is required for storage import functionality.
+	ControllerTag() names.ControllerTag
+
 	// GetBlockForType is required to block operations.
 	GetBlockForType(t state.BlockType) (state.Block, bool, error)
 }
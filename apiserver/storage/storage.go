@@ -14,6 +14,7 @@ import (
 	"github.com/juju/juju/apiserver/common/storagecommon"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
@@ -195,9 +196,13 @@ func createStorageDetails(st storageAccess, si state.StorageInstance) (*params.S
 		}
 	}
 
+	var ownerTag string
+	if owner, ok := si.Owner(); ok {
+		ownerTag = owner.String()
+	}
 	return &params.StorageDetails{
 		StorageTag:  si.Tag().String(),
-		OwnerTag:    si.Owner().String(),
+		OwnerTag:    ownerTag,
 		Kind:        params.StorageKind(si.Kind()),
 		Status:      common.EntityStatusFromState(status),
 		Persistent:  persistent,
@@ -702,10 +707,565 @@ func (a *API) AddToUnit(args params.StoragesAddParams) (params.ErrorResults, err
 			continue
 		}
 
-		err = a.storage.AddStorageForUnit(u, one.StorageName, paramsToState(one.Constraints))
+		cons := paramsToState(one.Constraints)
+		cons.SnapshotId = one.SnapshotId
+		err = a.storage.AddStorageForUnit(u, one.StorageName, cons)
 		if err != nil {
 			result[i] = params.ErrorResult{Error: common.ServerError(err)}
 		}
 	}
 	return params.ErrorResults{Results: result}, nil
 }
+
+// Detach detaches the specified storage from the units it is attached
+// to, without destroying it. If an attachment's unit tag is empty, the
+// storage is detached from the unit that owns it.
+// A "CHANGE" block can block this operation.
+func (a *API) Detach(args params.StorageAttachmentIds) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	result := make([]params.ErrorResult, len(args.Ids))
+	for i, id := range args.Ids {
+		result[i].Error = common.ServerError(a.detachStorage(id))
+	}
+	return params.ErrorResults{Results: result}, nil
+}
+
+func (a *API) detachStorage(id params.StorageAttachmentId) error {
+	storageTag, err := names.ParseStorageTag(id.StorageTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if id.UnitTag != "" {
+		unitTag, err := names.ParseUnitTag(id.UnitTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return a.storage.DetachStorage(storageTag, unitTag)
+	}
+	si, err := a.storage.StorageInstance(storageTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	owner, ok := si.Owner()
+	if !ok {
+		return errors.Errorf("storage %s is not attached", storageTag.Id())
+	}
+	unitTag, ok := owner.(names.UnitTag)
+	if !ok {
+		return errors.NotSupportedf("detaching storage owned by %s", names.ReadableString(owner))
+	}
+	return a.storage.DetachStorage(storageTag, unitTag)
+}
+
+// Attach attaches existing, detached storage to units.
+// A "CHANGE" block can block this operation.
+func (a *API) Attach(args params.StorageAttachmentIds) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	result := make([]params.ErrorResult, len(args.Ids))
+	for i, id := range args.Ids {
+		storageTag, err := names.ParseStorageTag(id.StorageTag)
+		if err != nil {
+			result[i].Error = common.ServerError(err)
+			continue
+		}
+		unitTag, err := names.ParseUnitTag(id.UnitTag)
+		if err != nil {
+			result[i].Error = common.ServerError(err)
+			continue
+		}
+		err = a.storage.AttachStorage(storageTag, unitTag)
+		result[i].Error = common.ServerError(err)
+	}
+	return params.ErrorResults{Results: result}, nil
+}
+
+// Import imports existing volumes, or filesystems on existing volumes,
+// into the model as storage instances that are not attached to any unit.
+// The imported storage instances may then be attached to units with
+// Attach.
+//
+// A "CHANGE" block can block this operation.
+func (a *API) Import(args params.BulkImportStorageParams) (params.ImportStorageResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ImportStorageResults{}, errors.Trace(err)
+	}
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ImportStorageResults{}, errors.Trace(err)
+	}
+
+	modelConfig, err := a.storage.ModelConfig()
+	if err != nil {
+		return params.ImportStorageResults{}, errors.Trace(err)
+	}
+	resourceTags := tags.ResourceTags(
+		a.storage.ModelTag(),
+		a.storage.ControllerTag(),
+		modelConfig,
+	)
+
+	results := make([]params.ImportStorageResult, len(args.Storage))
+	for i, arg := range args.Storage {
+		storageTag, err := a.importStorage(arg, resourceTags)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		results[i].Result = &params.ImportStorageDetails{
+			StorageTag: storageTag.String(),
+		}
+	}
+	return params.ImportStorageResults{Results: results}, nil
+}
+
+func (a *API) importStorage(arg params.ImportStorageParams, resourceTags map[string]string) (names.StorageTag, error) {
+	if arg.Kind != params.StorageKindBlock && arg.Kind != params.StorageKindFilesystem {
+		return names.StorageTag{}, errors.NotValidf("storage kind %q", arg.Kind.String())
+	}
+	if !names.IsValidStorageName(arg.StorageName) {
+		return names.StorageTag{}, errors.NotValidf("storage name %q", arg.StorageName)
+	}
+	if arg.ProviderId == "" {
+		return names.StorageTag{}, errors.NotValidf("empty provider ID")
+	}
+	cfg, err := a.poolConfig(arg.Pool)
+	if err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	provider, err := a.registry.StorageProvider(cfg.Provider())
+	if err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	if !provider.Supports(storage.StorageKindBlock) {
+		return names.StorageTag{}, errors.NotSupportedf(
+			"importing storage with storage provider %q", cfg.Provider(),
+		)
+	}
+	volumeSource, err := provider.VolumeSource(cfg)
+	if err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	importer, ok := volumeSource.(storage.VolumeImporter)
+	if !ok {
+		return names.StorageTag{}, errors.NotSupportedf(
+			"importing volume with storage provider %q", cfg.Provider(),
+		)
+	}
+	// ImportVolume tags the volume as belonging to the model, so make
+	// sure it is not already in the model before changing anything.
+	if err := a.storage.CheckExistingVolume(arg.ProviderId, arg.Pool); err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	info, err := importer.ImportVolume(arg.ProviderId, resourceTags)
+	if err != nil {
+		return names.StorageTag{}, errors.Annotate(err, "importing volume")
+	}
+	volumeInfo := state.VolumeInfo{
+		HardwareId: info.HardwareId,
+		Size:       info.Size,
+		Pool:       arg.Pool,
+		VolumeId:   info.VolumeId,
+		Persistent: info.Persistent,
+	}
+	if arg.Kind == params.StorageKindBlock {
+		return a.storage.AddExistingVolume(volumeInfo, arg.StorageName)
+	}
+	filesystemInfo := state.FilesystemInfo{
+		Size: info.Size,
+		Pool: arg.Pool,
+	}
+	return a.storage.AddExistingFilesystem(filesystemInfo, volumeInfo, arg.StorageName)
+}
+
+// CreateSnapshots creates snapshots of the volumes backing the specified
+// storage instances. Storage may later be created from the snapshots by
+// adding storage to a unit.
+//
+// A "CHANGE" block can block this operation.
+func (a *API) CreateSnapshots(args params.Entities) (params.CreateSnapshotResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.CreateSnapshotResults{}, errors.Trace(err)
+	}
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.CreateSnapshotResults{}, errors.Trace(err)
+	}
+
+	modelConfig, err := a.storage.ModelConfig()
+	if err != nil {
+		return params.CreateSnapshotResults{}, errors.Trace(err)
+	}
+	resourceTags := tags.ResourceTags(
+		a.storage.ModelTag(),
+		a.storage.ControllerTag(),
+		modelConfig,
+	)
+
+	results := make([]params.CreateSnapshotResult, len(args.Entities))
+	for i, arg := range args.Entities {
+		storageTag, err := names.ParseStorageTag(arg.Tag)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		details, err := a.createSnapshot(storageTag, resourceTags)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		results[i].Result = details
+	}
+	return params.CreateSnapshotResults{Results: results}, nil
+}
+
+func (a *API) createSnapshot(storageTag names.StorageTag, resourceTags map[string]string) (*params.SnapshotDetails, error) {
+	volume, snapshotter, err := a.storageInstanceSnapshotter(storageTag, "snapshotting")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if snapshotter == nil {
+		// Machine-scoped volume sources can only be used by the
+		// machine that the volume is on, so the snapshot is taken
+		// by that machine's storage provisioner.
+		if err := a.storage.RequestVolumeSnapshot(volume.VolumeTag()); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return &params.SnapshotDetails{
+			StorageTag: storageTag.String(),
+			VolumeTag:  volume.VolumeTag().String(),
+			Pending:    true,
+		}, nil
+	}
+	info, err := volume.Info()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	results, err := snapshotter.CreateSnapshots([]storage.SnapshotParams{{
+		Volume:       volume.VolumeTag(),
+		VolumeId:     info.VolumeId,
+		ResourceTags: resourceTags,
+	}})
+	if err != nil {
+		return nil, errors.Annotate(err, "creating snapshot")
+	}
+	if len(results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results))
+	}
+	if results[0].Error != nil {
+		return nil, errors.Annotate(results[0].Error, "creating snapshot")
+	}
+	snapshot := results[0].Snapshot
+	return &params.SnapshotDetails{
+		SnapshotId: snapshot.SnapshotId,
+		StorageTag: storageTag.String(),
+		VolumeTag:  volume.VolumeTag().String(),
+		Size:       snapshot.Size,
+		Created:    snapshot.Created,
+	}, nil
+}
+
+// storageInstanceSnapshotter returns the provisioned volume backing the
+// specified storage instance, and the snapshotter of the model-scoped
+// storage provider that manages it. If the volume is machine-scoped,
+// the returned snapshotter is nil; snapshots of machine-scoped volumes
+// are managed by the storage provisioner of the machine, and recorded
+// in state.
+func (a *API) storageInstanceSnapshotter(storageTag names.StorageTag, operation string) (state.Volume, storage.VolumeSnapshotter, error) {
+	volume, err := a.storageInstanceBackingVolume(storageTag, operation)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	info, err := volume.Info()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	cfg, err := a.poolConfig(info.Pool)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	provider, err := a.registry.StorageProvider(cfg.Provider())
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if provider.Scope() != storage.ScopeEnviron {
+		return volume, nil, nil
+	}
+	volumeSource, err := provider.VolumeSource(cfg)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	snapshotter, ok := volumeSource.(storage.VolumeSnapshotter)
+	if !ok {
+		return nil, nil, errors.NotSupportedf(
+			"%s storage with storage provider %q", operation, cfg.Provider(),
+		)
+	}
+	return volume, snapshotter, nil
+}
+
+// ListSnapshots lists the snapshots of the volumes backing the specified
+// storage instances. Snapshots of machine-scoped volumes that have been
+// requested but not yet taken are listed as pending.
+func (a *API) ListSnapshots(args params.Entities) (params.ListSnapshotsResults, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.ListSnapshotsResults{}, errors.Trace(err)
+	}
+	results := make([]params.ListSnapshotsResult, len(args.Entities))
+	for i, arg := range args.Entities {
+		storageTag, err := names.ParseStorageTag(arg.Tag)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		snapshots, err := a.listSnapshots(storageTag)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		results[i].Result = snapshots
+	}
+	return params.ListSnapshotsResults{Results: results}, nil
+}
+
+func (a *API) listSnapshots(storageTag names.StorageTag) ([]params.SnapshotDetails, error) {
+	volume, snapshotter, err := a.storageInstanceSnapshotter(storageTag, "listing snapshots of")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return listVolumeSnapshots(storageTag, volume, snapshotter)
+}
+
+// listVolumeSnapshots lists the snapshots of the volume backing the
+// specified storage instance, using the snapshotter if the volume is
+// model-scoped, and the snapshots recorded in state otherwise.
+func listVolumeSnapshots(
+	storageTag names.StorageTag, volume state.Volume, snapshotter storage.VolumeSnapshotter,
+) ([]params.SnapshotDetails, error) {
+	var snapshots []params.SnapshotDetails
+	if snapshotter == nil {
+		for _, snapshot := range volume.Snapshots() {
+			if snapshot.Dying {
+				continue
+			}
+			snapshots = append(snapshots, params.SnapshotDetails{
+				SnapshotId: snapshot.SnapshotId,
+				StorageTag: storageTag.String(),
+				VolumeTag:  volume.VolumeTag().String(),
+				Size:       snapshot.Size,
+				Created:    snapshot.Created,
+			})
+		}
+		if volume.SnapshotRequested() {
+			snapshots = append(snapshots, params.SnapshotDetails{
+				StorageTag: storageTag.String(),
+				VolumeTag:  volume.VolumeTag().String(),
+				Pending:    true,
+			})
+		}
+		return snapshots, nil
+	}
+	info, err := volume.Info()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	results, err := snapshotter.ListSnapshots([]string{info.VolumeId})
+	if err != nil {
+		return nil, errors.Annotate(err, "listing snapshots")
+	}
+	if len(results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results))
+	}
+	if results[0].Error != nil {
+		return nil, errors.Annotate(results[0].Error, "listing snapshots")
+	}
+	for _, snapshot := range results[0].Snapshots {
+		snapshots = append(snapshots, params.SnapshotDetails{
+			SnapshotId: snapshot.SnapshotId,
+			StorageTag: storageTag.String(),
+			VolumeTag:  volume.VolumeTag().String(),
+			Size:       snapshot.Size,
+			Created:    snapshot.Created,
+		})
+	}
+	return snapshots, nil
+}
+
+// DeleteSnapshots deletes the specified snapshots of the volumes backing
+// storage instances. Snapshots of machine-scoped volumes are deleted by
+// the storage provisioner of the machine that manages the volume.
+//
+// A "REMOVE" block can block this operation.
+func (a *API) DeleteSnapshots(args params.DeleteSnapshotsParams) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.RemoveAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := make([]params.ErrorResult, len(args.Snapshots))
+	for i, arg := range args.Snapshots {
+		storageTag, err := names.ParseStorageTag(arg.StorageTag)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		err = a.deleteSnapshot(storageTag, arg.SnapshotId)
+		results[i].Error = common.ServerError(err)
+	}
+	return params.ErrorResults{Results: results}, nil
+}
+
+func (a *API) deleteSnapshot(storageTag names.StorageTag, snapshotId string) error {
+	volume, snapshotter, err := a.storageInstanceSnapshotter(storageTag, "deleting snapshots of")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if snapshotter == nil {
+		return a.storage.DestroyVolumeSnapshot(volume.VolumeTag(), snapshotId)
+	}
+	// Check that the snapshot belongs to the storage instance's volume,
+	// so that unrelated snapshots cannot be deleted.
+	snapshots, err := listVolumeSnapshots(storageTag, volume, snapshotter)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var found bool
+	for _, snapshot := range snapshots {
+		if snapshot.SnapshotId == snapshotId {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return errors.NotFoundf("snapshot %q of storage %s", snapshotId, storageTag.Id())
+	}
+	errs, err := snapshotter.DeleteSnapshots([]string{snapshotId})
+	if err != nil {
+		return errors.Annotate(err, "deleting snapshot")
+	}
+	if len(errs) != 1 {
+		return errors.Errorf("expected 1 result, got %d", len(errs))
+	}
+	return errors.Annotate(errs[0], "deleting snapshot")
+}
+
+// Resize requests that the volumes backing the specified storage
+// instances be grown to the specified sizes. The volumes are resized
+// by the storage provisioner, which then grows any filesystems on them.
+//
+// A "CHANGE" block can block this operation.
+func (a *API) Resize(args params.BulkResizeStorageParams) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := make([]params.ErrorResult, len(args.Storage))
+	for i, arg := range args.Storage {
+		err := a.resizeStorage(arg)
+		results[i].Error = common.ServerError(err)
+	}
+	return params.ErrorResults{Results: results}, nil
+}
+
+func (a *API) resizeStorage(arg params.ResizeStorageParams) error {
+	storageTag, err := names.ParseStorageTag(arg.StorageTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	volume, err := a.storageInstanceBackingVolume(storageTag, "resizing")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	info, err := volume.Info()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	cfg, err := a.poolConfig(info.Pool)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	provider, err := a.registry.StorageProvider(cfg.Provider())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// Machine-scoped volume sources can only be created by
+	// the machine that the volume is on, so the storage
+	// provisioner there reports if they cannot be resized.
+	if provider.Scope() == storage.ScopeEnviron {
+		volumeSource, err := provider.VolumeSource(cfg)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if _, ok := volumeSource.(storage.VolumeResizer); !ok {
+			return errors.NotSupportedf(
+				"resizing storage with storage provider %q", cfg.Provider(),
+			)
+		}
+	}
+	return a.storage.ResizeStorage(storageTag, arg.Size)
+}
+
+// storageInstanceBackingVolume returns the volume assigned to the
+// specified storage instance, or the volume backing its filesystem.
+// The operation is used to describe what cannot be done to storage
+// that is not backed by a volume.
+func (a *API) storageInstanceBackingVolume(storageTag names.StorageTag, operation string) (state.Volume, error) {
+	storageInstance, err := a.storage.StorageInstance(storageTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if storageInstance.Kind() == state.StorageKindBlock {
+		return a.storage.StorageInstanceVolume(storageTag)
+	}
+	filesystem, err := a.storage.StorageInstanceFilesystem(storageTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	volumeTag, err := filesystem.Volume()
+	if errors.Cause(err) == state.ErrNoBackingVolume {
+		return nil, errors.NotSupportedf(
+			"%s %s not backed by a volume", operation, names.ReadableString(storageTag),
+		)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return a.storage.Volume(volumeTag)
+}
+
+// poolConfig returns the configuration of the named storage pool. If
+// there is no such pool, the name is taken to be a storage provider
+// type, as it is when storage is added.
+func (a *API) poolConfig(poolName string) (*storage.Config, error) {
+	if poolName == "" {
+		return nil, errors.NotValidf("empty pool name")
+	}
+	cfg, err := a.poolManager.Get(poolName)
+	if errors.IsNotFound(err) {
+		providerType := storage.ProviderType(poolName)
+		if _, err := a.registry.StorageProvider(providerType); err != nil {
+			return nil, errors.NotFoundf("storage pool %q", poolName)
+		}
+		return storage.NewConfig(poolName, providerType, map[string]interface{}{})
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return cfg, nil
+}
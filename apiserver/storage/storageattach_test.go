@@ -0,0 +1,96 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+type storageAttachSuite struct {
+	baseStorageSuite
+}
+
+var _ = gc.Suite(&storageAttachSuite{})
+
+func (s *storageAttachSuite) TestDetach(c *gc.C) {
+	results, err := s.api.Detach(params.StorageAttachmentIds{[]params.StorageAttachmentId{{
+		StorageTag: s.storageTag.String(),
+		UnitTag:    s.unitTag.String(),
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{}},
+	})
+	s.assertCalls(c, []string{getBlockForTypeCall, detachStorageCall})
+}
+
+func (s *storageAttachSuite) TestDetachFromOwner(c *gc.C) {
+	results, err := s.api.Detach(params.StorageAttachmentIds{[]params.StorageAttachmentId{{
+		StorageTag: s.storageTag.String(),
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{}},
+	})
+	s.assertCalls(c, []string{getBlockForTypeCall, storageInstanceCall, detachStorageCall})
+}
+
+func (s *storageAttachSuite) TestDetachNotAttached(c *gc.C) {
+	s.storageInstance.owner = nil
+	results, err := s.api.Detach(params.StorageAttachmentIds{[]params.StorageAttachmentId{{
+		StorageTag: s.storageTag.String(),
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "storage data/0 is not attached")
+	s.assertCalls(c, []string{getBlockForTypeCall, storageInstanceCall})
+}
+
+func (s *storageAttachSuite) TestDetachInvalidTags(c *gc.C) {
+	results, err := s.api.Detach(params.StorageAttachmentIds{[]params.StorageAttachmentId{{
+		StorageTag: "foo",
+	}, {
+		StorageTag: s.storageTag.String(),
+		UnitTag:    "bar",
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `"foo" is not a valid tag`)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `"bar" is not a valid tag`)
+}
+
+func (s *storageAttachSuite) TestDetachBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestDetachBlocked")
+	_, err := s.api.Detach(params.StorageAttachmentIds{[]params.StorageAttachmentId{{
+		StorageTag: s.storageTag.String(),
+	}}})
+	s.assertBlocked(c, err, "TestDetachBlocked")
+}
+
+func (s *storageAttachSuite) TestAttach(c *gc.C) {
+	results, err := s.api.Attach(params.StorageAttachmentIds{[]params.StorageAttachmentId{{
+		StorageTag: s.storageTag.String(),
+		UnitTag:    "unit-mysql-1",
+	}, {
+		StorageTag: "storage-data-1",
+		UnitTag:    "unit-mysql-1",
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, "storage data/1 not found")
+	s.assertCalls(c, []string{getBlockForTypeCall, attachStorageCall, attachStorageCall})
+}
+
+func (s *storageAttachSuite) TestAttachBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestAttachBlocked")
+	_, err := s.api.Attach(params.StorageAttachmentIds{[]params.StorageAttachmentId{{
+		StorageTag: s.storageTag.String(),
+		UnitTag:    s.unitTag.String(),
+	}}})
+	s.assertBlocked(c, err, "TestAttachBlocked")
+}
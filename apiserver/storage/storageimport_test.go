@@ -0,0 +1,163 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	jujustorage "github.com/juju/juju/storage"
+	dummystorage "github.com/juju/juju/storage/provider/dummy"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type storageImportSuite struct {
+	baseStorageSuite
+	volumeSource *dummystorage.VolumeSource
+}
+
+var _ = gc.Suite(&storageImportSuite{})
+
+func (s *storageImportSuite) SetUpTest(c *gc.C) {
+	s.baseStorageSuite.SetUpTest(c)
+	s.volumeSource = &dummystorage.VolumeSource{
+		ImportVolumeFunc: func(volumeId string, resourceTags map[string]string) (jujustorage.VolumeInfo, error) {
+			return jujustorage.VolumeInfo{
+				VolumeId:   volumeId,
+				Size:       1024,
+				Persistent: true,
+			}, nil
+		},
+	}
+	s.registry.Providers["radiance"] = &dummystorage.StorageProvider{
+		VolumeSourceFunc: func(*jujustorage.Config) (jujustorage.VolumeSource, error) {
+			return s.volumeSource, nil
+		},
+	}
+	s.registry.Providers["filesystem-only"] = &dummystorage.StorageProvider{
+		SupportsFunc: func(kind jujustorage.StorageKind) bool {
+			return kind == jujustorage.StorageKindFilesystem
+		},
+	}
+}
+
+func (s *storageImportSuite) TestImportVolume(c *gc.C) {
+	var imported state.VolumeInfo
+	s.state.addExistingVolume = func(info state.VolumeInfo, storageName string) (names.StorageTag, error) {
+		s.calls = append(s.calls, addExistingVolumeCall)
+		imported = info
+		c.Assert(storageName, gc.Equals, "data")
+		return names.NewStorageTag("data/1"), nil
+	}
+	results, err := s.api.Import(params.BulkImportStorageParams{[]params.ImportStorageParams{{
+		Kind:        params.StorageKindBlock,
+		Pool:        "radiance",
+		ProviderId:  "foo",
+		StorageName: "data",
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ImportStorageResults{
+		Results: []params.ImportStorageResult{{
+			Result: &params.ImportStorageDetails{StorageTag: "storage-data-1"},
+		}},
+	})
+	c.Assert(imported, jc.DeepEquals, state.VolumeInfo{
+		VolumeId:   "foo",
+		Pool:       "radiance",
+		Size:       1024,
+		Persistent: true,
+	})
+	s.assertCalls(c, []string{getBlockForTypeCall, checkExistingVolumeCall, addExistingVolumeCall})
+	s.volumeSource.CheckCallNames(c, "ImportVolume")
+	resourceTags := s.volumeSource.Calls()[0].Args[1].(map[string]string)
+	c.Assert(resourceTags["juju-controller-uuid"], gc.Equals, coretesting.ControllerTag.Id())
+}
+
+func (s *storageImportSuite) TestImportFilesystem(c *gc.C) {
+	s.state.addExistingFilesystem = func(info state.FilesystemInfo, backingVolume state.VolumeInfo, storageName string) (names.StorageTag, error) {
+		s.calls = append(s.calls, addExistingFilesystemCall)
+		c.Assert(info, jc.DeepEquals, state.FilesystemInfo{Pool: "radiance", Size: 1024})
+		c.Assert(backingVolume.VolumeId, gc.Equals, "foo")
+		return names.NewStorageTag("pgdata/1"), nil
+	}
+	results, err := s.api.Import(params.BulkImportStorageParams{[]params.ImportStorageParams{{
+		Kind:        params.StorageKindFilesystem,
+		Pool:        "radiance",
+		ProviderId:  "foo",
+		StorageName: "pgdata",
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Result.StorageTag, gc.Equals, "storage-pgdata-1")
+	s.assertCalls(c, []string{getBlockForTypeCall, checkExistingVolumeCall, addExistingFilesystemCall})
+}
+
+func (s *storageImportSuite) TestImportErrors(c *gc.C) {
+	s.volumeSource.ImportVolumeFunc = func(string, map[string]string) (jujustorage.VolumeInfo, error) {
+		return jujustorage.VolumeInfo{}, errors.New("volume is in use")
+	}
+	results, err := s.api.Import(params.BulkImportStorageParams{[]params.ImportStorageParams{{
+		Kind: params.StorageKindUnknown, Pool: "radiance", ProviderId: "foo", StorageName: "data",
+	}, {
+		Kind: params.StorageKindBlock, Pool: "radiance", ProviderId: "foo", StorageName: "0data",
+	}, {
+		Kind: params.StorageKindBlock, Pool: "nope", ProviderId: "foo", StorageName: "data",
+	}, {
+		Kind: params.StorageKindBlock, Pool: "filesystem-only", ProviderId: "foo", StorageName: "data",
+	}, {
+		Kind: params.StorageKindBlock, Pool: "radiance", ProviderId: "foo", StorageName: "data",
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 5)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `storage kind "unknown" not valid`)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `storage name "0data" not valid`)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `storage pool "nope" not found`)
+	c.Assert(results.Results[3].Error, gc.ErrorMatches, `importing storage with storage provider "filesystem-only" not supported`)
+	c.Assert(results.Results[4].Error, gc.ErrorMatches, `importing volume: volume is in use`)
+	s.assertCalls(c, []string{getBlockForTypeCall, checkExistingVolumeCall})
+}
+
+func (s *storageImportSuite) TestImportAlreadyInModel(c *gc.C) {
+	s.state.checkExistingVolume = func(volumeId, pool string) error {
+		s.calls = append(s.calls, checkExistingVolumeCall)
+		c.Assert(volumeId, gc.Equals, "foo")
+		c.Assert(pool, gc.Equals, "radiance")
+		return errors.AlreadyExistsf("volume %q in model as volume 0", volumeId)
+	}
+	results, err := s.api.Import(params.BulkImportStorageParams{[]params.ImportStorageParams{{
+		Kind: params.StorageKindBlock, Pool: "radiance", ProviderId: "foo", StorageName: "data",
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `volume "foo" in model as volume 0 already exists`)
+	c.Assert(results.Results[0].Error.Code, gc.Equals, params.CodeAlreadyExists)
+	s.assertCalls(c, []string{getBlockForTypeCall, checkExistingVolumeCall})
+	// The volume is not tagged for the model again.
+	s.volumeSource.CheckNoCalls(c)
+}
+
+func (s *storageImportSuite) TestImportNotSupported(c *gc.C) {
+	s.registry.Providers["radiance"] = &dummystorage.StorageProvider{
+		VolumeSourceFunc: func(*jujustorage.Config) (jujustorage.VolumeSource, error) {
+			return struct{ jujustorage.VolumeSource }{s.volumeSource}, nil
+		},
+	}
+	results, err := s.api.Import(params.BulkImportStorageParams{[]params.ImportStorageParams{{
+		Kind: params.StorageKindBlock, Pool: "radiance", ProviderId: "foo", StorageName: "data",
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `importing volume with storage provider "radiance" not supported`)
+}
+
+func (s *storageImportSuite) TestImportBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestImportBlocked")
+	_, err := s.api.Import(params.BulkImportStorageParams{[]params.ImportStorageParams{{
+		Kind: params.StorageKindBlock, Pool: "radiance", ProviderId: "foo", StorageName: "data",
+	}}})
+	s.assertBlocked(c, err, "TestImportBlocked")
+}
@@ -0,0 +1,128 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	jujustorage "github.com/juju/juju/storage"
+	dummystorage "github.com/juju/juju/storage/provider/dummy"
+)
+
+type storageResizeSuite struct {
+	baseStorageSuite
+	volumeSource *dummystorage.VolumeSource
+}
+
+var _ = gc.Suite(&storageResizeSuite{})
+
+func (s *storageResizeSuite) SetUpTest(c *gc.C) {
+	s.baseStorageSuite.SetUpTest(c)
+	s.volumeSource = &dummystorage.VolumeSource{}
+	s.registry.Providers["radiance"] = &dummystorage.StorageProvider{
+		StorageScope: jujustorage.ScopeEnviron,
+		VolumeSourceFunc: func(*jujustorage.Config) (jujustorage.VolumeSource, error) {
+			return s.volumeSource, nil
+		},
+	}
+	s.registry.Providers["machinescoped"] = &dummystorage.StorageProvider{
+		StorageScope: jujustorage.ScopeMachine,
+	}
+	s.volume.info = &state.VolumeInfo{VolumeId: "vol-123", Pool: "radiance", Size: 1024}
+}
+
+func (s *storageResizeSuite) TestResize(c *gc.C) {
+	s.storageInstance.kind = state.StorageKindBlock
+	s.state.resizeStorage = func(storage names.StorageTag, size uint64) error {
+		s.calls = append(s.calls, resizeStorageCall)
+		c.Assert(storage, gc.Equals, s.storageTag)
+		c.Assert(size, gc.Equals, uint64(2048))
+		return nil
+	}
+	results, err := s.api.Resize(params.BulkResizeStorageParams{[]params.ResizeStorageParams{{
+		StorageTag: s.storageTag.String(),
+		Size:       2048,
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{[]params.ErrorResult{{}}})
+	s.assertCalls(c, []string{getBlockForTypeCall, storageInstanceCall, storageInstanceVolumeCall, resizeStorageCall})
+}
+
+func (s *storageResizeSuite) TestResizeFilesystem(c *gc.C) {
+	s.filesystem.volume = &s.volumeTag
+	results, err := s.api.Resize(params.BulkResizeStorageParams{[]params.ResizeStorageParams{{
+		StorageTag: s.storageTag.String(),
+		Size:       2048,
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	s.assertCalls(c, []string{getBlockForTypeCall, storageInstanceCall, storageInstanceFilesystemCall, volumeCall, resizeStorageCall})
+}
+
+func (s *storageResizeSuite) TestResizeMachineScoped(c *gc.C) {
+	// Whether machine-scoped storage can be resized is
+	// determined by the storage provisioner on the machine.
+	s.storageInstance.kind = state.StorageKindBlock
+	s.volume.info.Pool = "machinescoped"
+	results, err := s.api.Resize(params.BulkResizeStorageParams{[]params.ResizeStorageParams{{
+		StorageTag: s.storageTag.String(),
+		Size:       2048,
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	s.assertCalls(c, []string{getBlockForTypeCall, storageInstanceCall, storageInstanceVolumeCall, resizeStorageCall})
+}
+
+func (s *storageResizeSuite) TestResizeErrors(c *gc.C) {
+	s.state.resizeStorage = func(names.StorageTag, uint64) error {
+		return errors.New("new size 1024M must be larger than current size 1024M")
+	}
+	results, err := s.api.Resize(params.BulkResizeStorageParams{[]params.ResizeStorageParams{
+		{StorageTag: "foo", Size: 2048},
+		{StorageTag: "storage-data-1", Size: 2048},
+		{StorageTag: s.storageTag.String(), Size: 2048},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `"foo" is not a valid tag`)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `storage data/1 not found`)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `resizing storage data/0 not backed by a volume not supported`)
+
+	s.storageInstance.kind = state.StorageKindBlock
+	results, err = s.api.Resize(params.BulkResizeStorageParams{[]params.ResizeStorageParams{
+		{StorageTag: s.storageTag.String(), Size: 1024},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `new size 1024M must be larger than current size 1024M`)
+}
+
+func (s *storageResizeSuite) TestResizeNotSupported(c *gc.C) {
+	s.storageInstance.kind = state.StorageKindBlock
+	s.registry.Providers["radiance"] = &dummystorage.StorageProvider{
+		StorageScope: jujustorage.ScopeEnviron,
+		VolumeSourceFunc: func(*jujustorage.Config) (jujustorage.VolumeSource, error) {
+			return struct{ jujustorage.VolumeSource }{s.volumeSource}, nil
+		},
+	}
+	results, err := s.api.Resize(params.BulkResizeStorageParams{[]params.ResizeStorageParams{{
+		StorageTag: s.storageTag.String(),
+		Size:       2048,
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `resizing storage with storage provider "radiance" not supported`)
+}
+
+func (s *storageResizeSuite) TestResizeBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestResizeBlocked")
+	_, err := s.api.Resize(params.BulkResizeStorageParams{[]params.ResizeStorageParams{{
+		StorageTag: s.storageTag.String(),
+		Size:       2048,
+	}}})
+	s.assertBlocked(c, err, "TestResizeBlocked")
+}
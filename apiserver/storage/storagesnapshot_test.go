@@ -0,0 +1,257 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	jujustorage "github.com/juju/juju/storage"
+	dummystorage "github.com/juju/juju/storage/provider/dummy"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type storageSnapshotSuite struct {
+	baseStorageSuite
+	volumeSource *dummystorage.VolumeSource
+	created      time.Time
+}
+
+var _ = gc.Suite(&storageSnapshotSuite{})
+
+func (s *storageSnapshotSuite) SetUpTest(c *gc.C) {
+	s.baseStorageSuite.SetUpTest(c)
+	s.created = time.Date(2017, 4, 1, 12, 0, 0, 0, time.UTC)
+	s.volumeSource = &dummystorage.VolumeSource{
+		CreateSnapshotsFunc: func(args []jujustorage.SnapshotParams) ([]jujustorage.CreateSnapshotsResult, error) {
+			results := make([]jujustorage.CreateSnapshotsResult, len(args))
+			for i, arg := range args {
+				results[i].Snapshot = &jujustorage.Snapshot{
+					SnapshotId: "snap-" + arg.VolumeId,
+					VolumeId:   arg.VolumeId,
+					Size:       1024,
+					Created:    s.created,
+				}
+			}
+			return results, nil
+		},
+	}
+	s.registry.Providers["radiance"] = &dummystorage.StorageProvider{
+		StorageScope: jujustorage.ScopeEnviron,
+		VolumeSourceFunc: func(*jujustorage.Config) (jujustorage.VolumeSource, error) {
+			return s.volumeSource, nil
+		},
+	}
+	s.registry.Providers["machinescoped"] = &dummystorage.StorageProvider{
+		StorageScope: jujustorage.ScopeMachine,
+	}
+	s.volume.info = &state.VolumeInfo{VolumeId: "vol-123", Pool: "radiance", Size: 1024}
+}
+
+func (s *storageSnapshotSuite) TestCreateSnapshotsBlock(c *gc.C) {
+	s.storageInstance.kind = state.StorageKindBlock
+	results, err := s.api.CreateSnapshots(params.Entities{[]params.Entity{{Tag: s.storageTag.String()}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.CreateSnapshotResults{
+		Results: []params.CreateSnapshotResult{{
+			Result: &params.SnapshotDetails{
+				SnapshotId: "snap-vol-123",
+				StorageTag: "storage-data-0",
+				VolumeTag:  "volume-22",
+				Size:       1024,
+				Created:    s.created,
+			},
+		}},
+	})
+	s.assertCalls(c, []string{getBlockForTypeCall, storageInstanceCall, storageInstanceVolumeCall})
+	s.volumeSource.CheckCallNames(c, "CreateSnapshots")
+	args := s.volumeSource.Calls()[0].Args[0].([]jujustorage.SnapshotParams)
+	c.Assert(args, gc.HasLen, 1)
+	c.Assert(args[0].Volume, gc.Equals, s.volumeTag)
+	c.Assert(args[0].VolumeId, gc.Equals, "vol-123")
+	c.Assert(args[0].ResourceTags["juju-controller-uuid"], gc.Equals, coretesting.ControllerTag.Id())
+}
+
+func (s *storageSnapshotSuite) TestCreateSnapshotsFilesystem(c *gc.C) {
+	s.filesystem.volume = &s.volumeTag
+	results, err := s.api.CreateSnapshots(params.Entities{[]params.Entity{{Tag: s.storageTag.String()}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Result.SnapshotId, gc.Equals, "snap-vol-123")
+	s.assertCalls(c, []string{getBlockForTypeCall, storageInstanceCall, storageInstanceFilesystemCall, volumeCall})
+}
+
+func (s *storageSnapshotSuite) TestCreateSnapshotsErrors(c *gc.C) {
+	results, err := s.api.CreateSnapshots(params.Entities{[]params.Entity{
+		{Tag: "foo"},
+		{Tag: "storage-data-1"},
+		{Tag: s.storageTag.String()},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `"foo" is not a valid tag`)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `storage data/1 not found`)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `snapshotting storage data/0 not backed by a volume not supported`)
+}
+
+func (s *storageSnapshotSuite) TestCreateSnapshotsProviderError(c *gc.C) {
+	s.storageInstance.kind = state.StorageKindBlock
+	s.volumeSource.CreateSnapshotsFunc = func([]jujustorage.SnapshotParams) ([]jujustorage.CreateSnapshotsResult, error) {
+		return []jujustorage.CreateSnapshotsResult{{Error: errors.New("volume is busy")}}, nil
+	}
+	results, err := s.api.CreateSnapshots(params.Entities{[]params.Entity{{Tag: s.storageTag.String()}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `creating snapshot: volume is busy`)
+}
+
+func (s *storageSnapshotSuite) TestCreateSnapshotsMachineScoped(c *gc.C) {
+	s.storageInstance.kind = state.StorageKindBlock
+	s.volume.info.Pool = "machinescoped"
+	results, err := s.api.CreateSnapshots(params.Entities{[]params.Entity{{Tag: s.storageTag.String()}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.CreateSnapshotResults{
+		Results: []params.CreateSnapshotResult{{
+			Result: &params.SnapshotDetails{
+				StorageTag: "storage-data-0",
+				VolumeTag:  "volume-22",
+				Pending:    true,
+			},
+		}},
+	})
+	s.assertCalls(c, []string{
+		getBlockForTypeCall, storageInstanceCall, storageInstanceVolumeCall, requestVolumeSnapshotCall,
+	})
+	s.volumeSource.CheckNoCalls(c)
+}
+
+func (s *storageSnapshotSuite) TestCreateSnapshotsNotSupported(c *gc.C) {
+	s.storageInstance.kind = state.StorageKindBlock
+	s.registry.Providers["radiance"] = &dummystorage.StorageProvider{
+		VolumeSourceFunc: func(*jujustorage.Config) (jujustorage.VolumeSource, error) {
+			return struct{ jujustorage.VolumeSource }{s.volumeSource}, nil
+		},
+	}
+	results, err := s.api.CreateSnapshots(params.Entities{[]params.Entity{{Tag: s.storageTag.String()}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `snapshotting storage with storage provider "radiance" not supported`)
+}
+
+func (s *storageSnapshotSuite) TestCreateSnapshotsBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestCreateSnapshotsBlocked")
+	_, err := s.api.CreateSnapshots(params.Entities{[]params.Entity{{Tag: s.storageTag.String()}}})
+	s.assertBlocked(c, err, "TestCreateSnapshotsBlocked")
+}
+
+func (s *storageSnapshotSuite) TestListSnapshots(c *gc.C) {
+	s.storageInstance.kind = state.StorageKindBlock
+	s.volumeSource.ListSnapshotsFunc = func(volumeIds []string) ([]jujustorage.ListSnapshotsResult, error) {
+		c.Assert(volumeIds, jc.DeepEquals, []string{"vol-123"})
+		return []jujustorage.ListSnapshotsResult{{
+			Snapshots: []jujustorage.Snapshot{{
+				SnapshotId: "snap-vol-123",
+				VolumeId:   "vol-123",
+				Size:       1024,
+				Created:    s.created,
+			}},
+		}}, nil
+	}
+	results, err := s.api.ListSnapshots(params.Entities{[]params.Entity{{Tag: s.storageTag.String()}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ListSnapshotsResults{
+		Results: []params.ListSnapshotsResult{{
+			Result: []params.SnapshotDetails{{
+				SnapshotId: "snap-vol-123",
+				StorageTag: "storage-data-0",
+				VolumeTag:  "volume-22",
+				Size:       1024,
+				Created:    s.created,
+			}},
+		}},
+	})
+}
+
+func (s *storageSnapshotSuite) TestListSnapshotsMachineScoped(c *gc.C) {
+	s.storageInstance.kind = state.StorageKindBlock
+	s.volume.info.Pool = "machinescoped"
+	s.volume.snapshotRequested = true
+	s.volume.snapshots = []state.VolumeSnapshot{
+		{SnapshotId: "volume-0-0@1", Size: 1024, Created: s.created},
+		{SnapshotId: "volume-0-0@2", Size: 1024, Created: s.created, Dying: true},
+	}
+	results, err := s.api.ListSnapshots(params.Entities{[]params.Entity{{Tag: s.storageTag.String()}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ListSnapshotsResults{
+		Results: []params.ListSnapshotsResult{{
+			Result: []params.SnapshotDetails{{
+				SnapshotId: "volume-0-0@1",
+				StorageTag: "storage-data-0",
+				VolumeTag:  "volume-22",
+				Size:       1024,
+				Created:    s.created,
+			}, {
+				StorageTag: "storage-data-0",
+				VolumeTag:  "volume-22",
+				Pending:    true,
+			}},
+		}},
+	})
+	s.volumeSource.CheckNoCalls(c)
+}
+
+func (s *storageSnapshotSuite) TestDeleteSnapshots(c *gc.C) {
+	s.storageInstance.kind = state.StorageKindBlock
+	s.volumeSource.ListSnapshotsFunc = func(volumeIds []string) ([]jujustorage.ListSnapshotsResult, error) {
+		return []jujustorage.ListSnapshotsResult{{
+			Snapshots: []jujustorage.Snapshot{{SnapshotId: "snap-vol-123", VolumeId: "vol-123"}},
+		}}, nil
+	}
+	s.volumeSource.DeleteSnapshotsFunc = func(snapshotIds []string) ([]error, error) {
+		return make([]error, len(snapshotIds)), nil
+	}
+	results, err := s.api.DeleteSnapshots(params.DeleteSnapshotsParams{
+		Snapshots: []params.DeleteSnapshotParams{
+			{StorageTag: s.storageTag.String(), SnapshotId: "snap-vol-123"},
+			{StorageTag: s.storageTag.String(), SnapshotId: "snap-vol-456"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `snapshot "snap-vol-456" of storage data/0 not found`)
+	s.volumeSource.CheckCallNames(c, "ListSnapshots", "DeleteSnapshots", "ListSnapshots")
+	s.volumeSource.CheckCall(c, 1, "DeleteSnapshots", []string{"snap-vol-123"})
+}
+
+func (s *storageSnapshotSuite) TestDeleteSnapshotsMachineScoped(c *gc.C) {
+	s.storageInstance.kind = state.StorageKindBlock
+	s.volume.info.Pool = "machinescoped"
+	results, err := s.api.DeleteSnapshots(params.DeleteSnapshotsParams{
+		Snapshots: []params.DeleteSnapshotParams{
+			{StorageTag: s.storageTag.String(), SnapshotId: "volume-0-0@1"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	s.assertCalls(c, []string{
+		getBlockForTypeCall, storageInstanceCall, storageInstanceVolumeCall, destroyVolumeSnapshotCall,
+	})
+	s.volumeSource.CheckNoCalls(c)
+}
+
+func (s *storageSnapshotSuite) TestDeleteSnapshotsBlocked(c *gc.C) {
+	s.blockRemoveObject(c, "TestDeleteSnapshotsBlocked")
+	_, err := s.api.DeleteSnapshots(params.DeleteSnapshotsParams{
+		Snapshots: []params.DeleteSnapshotParams{
+			{StorageTag: s.storageTag.String(), SnapshotId: "snap-vol-123"},
+		},
+	})
+	s.assertBlocked(c, err, "TestDeleteSnapshotsBlocked")
+}
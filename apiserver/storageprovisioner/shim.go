@@ -24,6 +24,13 @@ import (
 
 func init() {
 	common.RegisterStandardFacade("StorageProvisioner", 3, newStorageProvisionerAPI)
+
+	// Version 4 adds support for resizing volumes and filesystems.
+	common.RegisterStandardFacade("StorageProvisioner", 4, newStorageProvisionerAPI)
+
+	// Version 5 adds support for taking and deleting snapshots of
+	// machine-scoped volumes.
+	common.RegisterStandardFacade("StorageProvisioner", 5, newStorageProvisionerAPI)
 }
 
 func newStorageProvisionerAPI(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*StorageProvisionerAPI, error) {
@@ -56,6 +63,11 @@ type Backend interface {
 	WatchMachineVolumes(names.MachineTag) state.StringsWatcher
 	WatchMachineVolumeAttachments(names.MachineTag) state.StringsWatcher
 	WatchVolumeAttachment(names.MachineTag, names.VolumeTag) state.NotifyWatcher
+	WatchModelVolumeResizes() state.StringsWatcher
+	WatchMachineVolumeResizes(names.MachineTag) state.StringsWatcher
+	WatchModelFilesystemResizes() state.StringsWatcher
+	WatchMachineFilesystemResizes(names.MachineTag) state.StringsWatcher
+	WatchMachineVolumeSnapshots(names.MachineTag) state.StringsWatcher
 
 	StorageInstance(names.StorageTag) (state.StorageInstance, error)
 
@@ -75,6 +87,9 @@ type Backend interface {
 	SetFilesystemAttachmentInfo(names.MachineTag, names.FilesystemTag, state.FilesystemAttachmentInfo) error
 	SetVolumeInfo(names.VolumeTag, state.VolumeInfo) error
 	SetVolumeAttachmentInfo(names.MachineTag, names.VolumeTag, state.VolumeAttachmentInfo) error
+
+	VolumeSnapshotTaken(names.VolumeTag, *state.VolumeSnapshot) error
+	RemoveVolumeSnapshots(names.VolumeTag, []string) error
 }
 
 type stateShim struct {
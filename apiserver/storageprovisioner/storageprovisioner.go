@@ -280,8 +280,10 @@ func (s *StorageProvisionerAPI) watchStorageEntities(
 		var w state.StringsWatcher
 		if tag, ok := tag.(names.MachineTag); ok {
 			w = watchMachineStorage(tag)
-		} else {
+		} else if watchEnvironStorage != nil {
 			w = watchEnvironStorage()
+		} else {
+			return "", nil, errors.NotSupportedf("watching model-scoped storage")
 		}
 		if changes, ok := <-w.Changes(); ok {
 			return s.resources.Register(w), changes, nil
@@ -302,6 +304,28 @@ func (s *StorageProvisionerAPI) watchStorageEntities(
 	return results, nil
 }
 
+// WatchVolumeResizes watches for changes to volumes scoped to the
+// entity with the tag passed to NewState, so that requests to resize
+// them may be observed.
+func (s *StorageProvisionerAPI) WatchVolumeResizes(args params.Entities) (params.StringsWatchResults, error) {
+	return s.watchStorageEntities(args, s.st.WatchModelVolumeResizes, s.st.WatchMachineVolumeResizes)
+}
+
+// WatchFilesystemResizes watches for changes to filesystems scoped to
+// the entity with the tag passed to NewState, so that requests to
+// resize them may be observed.
+func (s *StorageProvisionerAPI) WatchFilesystemResizes(args params.Entities) (params.StringsWatchResults, error) {
+	return s.watchStorageEntities(args, s.st.WatchModelFilesystemResizes, s.st.WatchMachineFilesystemResizes)
+}
+
+// WatchVolumeSnapshots watches for requests to take and delete snapshots
+// of volumes scoped to the machine with the tag passed to NewState.
+// Snapshots of model-scoped volumes are taken directly with the storage
+// provider, so they cannot be watched.
+func (s *StorageProvisionerAPI) WatchVolumeSnapshots(args params.Entities) (params.StringsWatchResults, error) {
+	return s.watchStorageEntities(args, nil, s.st.WatchMachineVolumeSnapshots)
+}
+
 // WatchVolumeAttachments watches for changes to volume attachments scoped to
 // the entity with the tag passed to NewState.
 func (s *StorageProvisionerAPI) WatchVolumeAttachments(args params.Entities) (params.MachineStorageIdsWatchResults, error) {
@@ -680,6 +704,183 @@ func (s *StorageProvisionerAPI) FilesystemParams(args params.Entities) (params.F
 	return results, nil
 }
 
+// VolumeResizeParams returns the parameters for resizing the volumes
+// with the specified tags. If a volume has no pending resize, an error
+// satisfying params.IsCodeNotFound is returned for it.
+func (s *StorageProvisionerAPI) VolumeResizeParams(args params.Entities) (params.VolumeResizeParamsResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.VolumeResizeParamsResults{}, err
+	}
+	results := params.VolumeResizeParamsResults{
+		Results: make([]params.VolumeResizeParamsResult, len(args.Entities)),
+	}
+	one := func(arg params.Entity) (params.VolumeResizeParams, error) {
+		tag, err := names.ParseVolumeTag(arg.Tag)
+		if err != nil || !canAccess(tag) {
+			return params.VolumeResizeParams{}, common.ErrPerm
+		}
+		volume, err := s.st.Volume(tag)
+		if errors.IsNotFound(err) {
+			return params.VolumeResizeParams{}, common.ErrPerm
+		} else if err != nil {
+			return params.VolumeResizeParams{}, err
+		}
+		size, ok := volume.RequestedSize()
+		if !ok {
+			return params.VolumeResizeParams{}, errors.NotFoundf("pending resize of %s", names.ReadableString(tag))
+		}
+		info, err := volume.Info()
+		if err != nil {
+			return params.VolumeResizeParams{}, err
+		}
+		providerType, _, err := storagecommon.StoragePoolConfig(info.Pool, s.poolManager, s.registry)
+		if err != nil {
+			return params.VolumeResizeParams{}, err
+		}
+		return params.VolumeResizeParams{
+			VolumeTag: tag.String(),
+			VolumeId:  info.VolumeId,
+			Provider:  string(providerType),
+			Size:      size,
+		}, nil
+	}
+	for i, arg := range args.Entities {
+		var result params.VolumeResizeParamsResult
+		resizeParams, err := one(arg)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		} else {
+			result.Result = resizeParams
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
+// FilesystemResizeParams returns the parameters for resizing the
+// filesystems with the specified tags. If a filesystem has no pending
+// resize, an error satisfying params.IsCodeNotFound is returned for it.
+func (s *StorageProvisionerAPI) FilesystemResizeParams(args params.Entities) (params.FilesystemResizeParamsResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.FilesystemResizeParamsResults{}, err
+	}
+	results := params.FilesystemResizeParamsResults{
+		Results: make([]params.FilesystemResizeParamsResult, len(args.Entities)),
+	}
+	one := func(arg params.Entity) (params.FilesystemResizeParams, error) {
+		tag, err := names.ParseFilesystemTag(arg.Tag)
+		if err != nil || !canAccess(tag) {
+			return params.FilesystemResizeParams{}, common.ErrPerm
+		}
+		filesystem, err := s.st.Filesystem(tag)
+		if errors.IsNotFound(err) {
+			return params.FilesystemResizeParams{}, common.ErrPerm
+		} else if err != nil {
+			return params.FilesystemResizeParams{}, err
+		}
+		size, ok := filesystem.RequestedSize()
+		if !ok {
+			return params.FilesystemResizeParams{}, errors.NotFoundf("pending resize of %s", names.ReadableString(tag))
+		}
+		info, err := filesystem.Info()
+		if err != nil {
+			return params.FilesystemResizeParams{}, err
+		}
+		providerType, _, err := storagecommon.StoragePoolConfig(info.Pool, s.poolManager, s.registry)
+		if err != nil {
+			return params.FilesystemResizeParams{}, err
+		}
+		result := params.FilesystemResizeParams{
+			FilesystemTag: tag.String(),
+			FilesystemId:  info.FilesystemId,
+			Provider:      string(providerType),
+			Size:          size,
+		}
+		if volumeTag, err := filesystem.Volume(); err == nil {
+			result.VolumeTag = volumeTag.String()
+		} else if errors.Cause(err) != state.ErrNoBackingVolume {
+			return params.FilesystemResizeParams{}, err
+		}
+		return result, nil
+	}
+	for i, arg := range args.Entities {
+		var result params.FilesystemResizeParamsResult
+		resizeParams, err := one(arg)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		} else {
+			result.Result = resizeParams
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
+// VolumeSnapshotParams returns the parameters for the pending snapshot
+// operations on the volumes with the specified tags. If a volume has
+// no pending snapshot operations, an error satisfying
+// params.IsCodeNotFound is returned for it.
+func (s *StorageProvisionerAPI) VolumeSnapshotParams(args params.Entities) (params.VolumeSnapshotParamsResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.VolumeSnapshotParamsResults{}, err
+	}
+	results := params.VolumeSnapshotParamsResults{
+		Results: make([]params.VolumeSnapshotParamsResult, len(args.Entities)),
+	}
+	one := func(arg params.Entity) (params.VolumeSnapshotParams, error) {
+		tag, err := names.ParseVolumeTag(arg.Tag)
+		if err != nil || !canAccess(tag) {
+			return params.VolumeSnapshotParams{}, common.ErrPerm
+		}
+		volume, err := s.st.Volume(tag)
+		if errors.IsNotFound(err) {
+			return params.VolumeSnapshotParams{}, common.ErrPerm
+		} else if err != nil {
+			return params.VolumeSnapshotParams{}, err
+		}
+		var remove []string
+		for _, snapshot := range volume.Snapshots() {
+			if snapshot.Dying {
+				remove = append(remove, snapshot.SnapshotId)
+			}
+		}
+		if !volume.SnapshotRequested() && len(remove) == 0 {
+			return params.VolumeSnapshotParams{}, errors.NotFoundf(
+				"pending snapshot operations on %s", names.ReadableString(tag),
+			)
+		}
+		info, err := volume.Info()
+		if err != nil {
+			return params.VolumeSnapshotParams{}, err
+		}
+		providerType, _, err := storagecommon.StoragePoolConfig(info.Pool, s.poolManager, s.registry)
+		if err != nil {
+			return params.VolumeSnapshotParams{}, err
+		}
+		return params.VolumeSnapshotParams{
+			VolumeTag: tag.String(),
+			VolumeId:  info.VolumeId,
+			Provider:  string(providerType),
+			Create:    volume.SnapshotRequested(),
+			Delete:    remove,
+		}, nil
+	}
+	for i, arg := range args.Entities {
+		var result params.VolumeSnapshotParamsResult
+		snapshotParams, err := one(arg)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		} else {
+			result.Result = snapshotParams
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
 // VolumeAttachmentParams returns the parameters for creating the volume
 // attachments with the specified IDs.
 func (s *StorageProvisionerAPI) VolumeAttachmentParams(
@@ -943,6 +1144,15 @@ func (s *StorageProvisionerAPI) SetVolumeInfo(args params.Volumes) (params.Error
 		} else if !canAccessVolume(volumeTag) {
 			return common.ErrPerm
 		}
+		if volume, err := s.st.Volume(volumeTag); err == nil {
+			if info, err := volume.Info(); err == nil {
+				// The volume has already been provisioned, and is
+				// being updated after being resized. The pool is
+				// not known to the storage provisioner, and may
+				// not change.
+				volumeInfo.Pool = info.Pool
+			}
+		}
 		err = s.st.SetVolumeInfo(volumeTag, volumeInfo)
 		if errors.IsNotFound(err) {
 			return common.ErrPerm
@@ -956,6 +1166,47 @@ func (s *StorageProvisionerAPI) SetVolumeInfo(args params.Volumes) (params.Error
 	return results, nil
 }
 
+// SetVolumeSnapshotOutcomes records the outcomes of the pending snapshot
+// operations on volumes: the snapshots taken, and those deleted.
+func (s *StorageProvisionerAPI) SetVolumeSnapshotOutcomes(args params.VolumeSnapshotOutcomes) (params.ErrorResults, error) {
+	canAccessVolume, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Outcomes)),
+	}
+	one := func(arg params.VolumeSnapshotOutcome) error {
+		volumeTag, err := names.ParseVolumeTag(arg.VolumeTag)
+		if err != nil || !canAccessVolume(volumeTag) {
+			return common.ErrPerm
+		}
+		if arg.CreateAttempted {
+			var snapshot *state.VolumeSnapshot
+			if arg.Created != nil {
+				snapshot = &state.VolumeSnapshot{
+					SnapshotId: arg.Created.SnapshotId,
+					Size:       arg.Created.Size,
+					Created:    arg.Created.Created,
+				}
+			}
+			if err := s.st.VolumeSnapshotTaken(volumeTag, snapshot); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		err = s.st.RemoveVolumeSnapshots(volumeTag, arg.Deleted)
+		if errors.IsNotFound(err) {
+			return common.ErrPerm
+		}
+		return errors.Trace(err)
+	}
+	for i, arg := range args.Outcomes {
+		err := one(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
 // SetFilesystemInfo records the details of newly provisioned filesystems.
 func (s *StorageProvisionerAPI) SetFilesystemInfo(args params.Filesystems) (params.ErrorResults, error) {
 	canAccessFilesystem, err := s.getStorageEntityAuthFunc()
@@ -972,6 +1223,15 @@ func (s *StorageProvisionerAPI) SetFilesystemInfo(args params.Filesystems) (para
 		} else if !canAccessFilesystem(filesystemTag) {
 			return common.ErrPerm
 		}
+		if filesystem, err := s.st.Filesystem(filesystemTag); err == nil {
+			if info, err := filesystem.Info(); err == nil {
+				// The filesystem has already been provisioned, and
+				// is being updated after being resized. The pool is
+				// not known to the storage provisioner, and may not
+				// change.
+				filesystemInfo.Pool = info.Pool
+			}
+		}
 		err = s.st.SetFilesystemInfo(filesystemTag, filesystemInfo)
 		if errors.IsNotFound(err) {
 			return common.ErrPerm
@@ -5,6 +5,7 @@ package storageprovisioner_test
 
 import (
 	"sort"
+	"time"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -409,6 +410,150 @@ func (s *provisionerSuite) TestVolumeParamsEmptyArgs(c *gc.C) {
 	c.Assert(results.Results, gc.HasLen, 0)
 }
 
+func (s *provisionerSuite) TestVolumeResizeParams(c *gc.C) {
+	s.setupVolumes(c)
+	storageTag, err := s.State.AddExistingVolume(state.VolumeInfo{
+		VolumeId: "ghi",
+		Pool:     "environscoped",
+		Size:     1024,
+	}, "data")
+	c.Assert(err, jc.ErrorIsNil)
+	volume, err := s.State.StorageInstanceVolume(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.ResizeStorage(storageTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.api.VolumeResizeParams(params.Entities{
+		Entities: []params.Entity{
+			{volume.VolumeTag().String()},
+			{"volume-2"},
+			{"volume-42"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.VolumeResizeParamsResults{
+		Results: []params.VolumeResizeParamsResult{
+			{Result: params.VolumeResizeParams{
+				VolumeTag: volume.VolumeTag().String(),
+				VolumeId:  "ghi",
+				Provider:  "environscoped",
+				Size:      2048,
+			}},
+			{Error: &params.Error{Message: "pending resize of volume 2 not found", Code: params.CodeNotFound}},
+			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+		},
+	})
+}
+
+func (s *provisionerSuite) TestSetVolumeInfoResized(c *gc.C) {
+	storageTag, err := s.State.AddExistingVolume(state.VolumeInfo{
+		VolumeId: "ghi",
+		Pool:     "environscoped",
+		Size:     1024,
+	}, "data")
+	c.Assert(err, jc.ErrorIsNil)
+	volume, err := s.State.StorageInstanceVolume(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.ResizeStorage(storageTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The storage provisioner does not know the pool of the
+	// volume it has resized; the existing pool is preserved.
+	results, err := s.api.SetVolumeInfo(params.Volumes{
+		Volumes: []params.Volume{{
+			VolumeTag: volume.VolumeTag().String(),
+			Info:      params.VolumeInfo{VolumeId: "ghi", Size: 2048},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{}},
+	})
+	volume, err = s.State.Volume(volume.VolumeTag())
+	c.Assert(err, jc.ErrorIsNil)
+	info, err := volume.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, state.VolumeInfo{VolumeId: "ghi", Pool: "environscoped", Size: 2048})
+	_, ok := volume.RequestedSize()
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *provisionerSuite) TestVolumeSnapshots(c *gc.C) {
+	s.setupVolumes(c)
+	volumeTag := names.NewVolumeTag("0/0")
+	err := s.State.RequestVolumeSnapshot(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.api.VolumeSnapshotParams(params.Entities{
+		Entities: []params.Entity{
+			{volumeTag.String()},
+			{"volume-2"},
+			{"volume-42"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.VolumeSnapshotParamsResults{
+		Results: []params.VolumeSnapshotParamsResult{
+			{Result: params.VolumeSnapshotParams{
+				VolumeTag: volumeTag.String(),
+				VolumeId:  "abc",
+				Provider:  "machinescoped",
+				Create:    true,
+			}},
+			{Error: &params.Error{Message: "pending snapshot operations on volume 2 not found", Code: params.CodeNotFound}},
+			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+		},
+	})
+
+	created := time.Date(2017, 6, 1, 10, 15, 0, 0, time.UTC)
+	errorResults, err := s.api.SetVolumeSnapshotOutcomes(params.VolumeSnapshotOutcomes{
+		Outcomes: []params.VolumeSnapshotOutcome{{
+			VolumeTag:       volumeTag.String(),
+			CreateAttempted: true,
+			Created: &params.VolumeSnapshot{
+				SnapshotId: "volume-0-0@1",
+				Size:       1024,
+				Created:    created,
+			},
+		}, {
+			VolumeTag: "volume-42",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errorResults, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+		},
+	})
+
+	// Once destroyed, the snapshot is to be deleted by the storage
+	// provisioner, and its record removed when it has been.
+	err = s.State.DestroyVolumeSnapshot(volumeTag, "volume-0-0@1")
+	c.Assert(err, jc.ErrorIsNil)
+	results, err = s.api.VolumeSnapshotParams(params.Entities{
+		Entities: []params.Entity{{volumeTag.String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Result, jc.DeepEquals, params.VolumeSnapshotParams{
+		VolumeTag: volumeTag.String(),
+		VolumeId:  "abc",
+		Provider:  "machinescoped",
+		Delete:    []string{"volume-0-0@1"},
+	})
+	errorResults, err = s.api.SetVolumeSnapshotOutcomes(params.VolumeSnapshotOutcomes{
+		Outcomes: []params.VolumeSnapshotOutcome{{
+			VolumeTag: volumeTag.String(),
+			Deleted:   []string{"volume-0-0@1"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errorResults.Results[0].Error, gc.IsNil)
+	volume, err := s.State.Volume(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volume.Snapshots(), gc.HasLen, 0)
+}
+
 func (s *provisionerSuite) TestFilesystemParams(c *gc.C) {
 	s.setupFilesystems(c)
 	results, err := s.api.FilesystemParams(params.Entities{
@@ -711,6 +856,37 @@ func (s *provisionerSuite) TestWatchVolumes(c *gc.C) {
 	wc.AssertNoChange()
 }
 
+func (s *provisionerSuite) TestWatchVolumeSnapshots(c *gc.C) {
+	s.setupVolumes(c)
+	err := s.State.RequestVolumeSnapshot(names.NewVolumeTag("0/0"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	args := params.Entities{Entities: []params.Entity{
+		{"machine-0"},
+		{s.State.ModelTag().String()},
+		{"machine-42"}},
+	}
+	result, err := s.api.WatchVolumeSnapshots(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StringsWatchResults{
+		Results: []params.StringsWatchResult{
+			{StringsWatcherId: "1", Changes: []string{"0/0"}},
+			{Error: &params.Error{
+				Message: "watching model-scoped storage not supported",
+				Code:    params.CodeNotSupported,
+			}},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	w := s.resources.Get("1")
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w.(state.StringsWatcher))
+	wc.AssertNoChange()
+}
+
 func (s *provisionerSuite) TestWatchVolumeAttachments(c *gc.C) {
 	s.setupVolumes(c)
 	s.factory.MakeMachine(c, nil)
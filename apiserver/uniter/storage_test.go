@@ -118,6 +118,11 @@ func (s *storageSuite) TestWatchStorageAttachmentVolume(c *gc.C) {
 			c.Assert(m, gc.DeepEquals, machineTag)
 			return blockDevicesWatcher
 		},
+		watchVolume: func(v names.VolumeTag) state.NotifyWatcher {
+			calls = append(calls, "WatchVolume")
+			c.Assert(v, gc.DeepEquals, volumeTag)
+			return volumeWatcher
+		},
 	}
 
 	storage, err := uniter.NewStorageAPI(state, resources, getCanAccess)
@@ -140,6 +145,7 @@ func (s *storageSuite) TestWatchStorageAttachmentVolume(c *gc.C) {
 		"StorageInstanceVolume",
 		"WatchVolumeAttachment",
 		"WatchBlockDevices",
+		"WatchVolume",
 		"WatchStorageAttachment",
 	})
 }
@@ -194,6 +200,11 @@ func (s *storageSuite) TestWatchStorageAttachmentFilesystem(c *gc.C) {
 			c.Assert(f, gc.DeepEquals, filesystemTag)
 			return filesystemWatcher
 		},
+		watchFilesystem: func(f names.FilesystemTag) state.NotifyWatcher {
+			calls = append(calls, "WatchFilesystem")
+			c.Assert(f, gc.DeepEquals, filesystemTag)
+			return filesystemWatcher
+		},
 	}
 
 	storage, err := uniter.NewStorageAPI(state, resources, getCanAccess)
@@ -215,6 +226,7 @@ func (s *storageSuite) TestWatchStorageAttachmentFilesystem(c *gc.C) {
 		"StorageInstance",
 		"StorageInstanceFilesystem",
 		"WatchFilesystemAttachment",
+		"WatchFilesystem",
 		"WatchStorageAttachment",
 	})
 }
@@ -486,6 +498,8 @@ type mockStorageState struct {
 	watchFilesystemAttachment     func(names.MachineTag, names.FilesystemTag) state.NotifyWatcher
 	watchVolumeAttachment         func(names.MachineTag, names.VolumeTag) state.NotifyWatcher
 	watchBlockDevices             func(names.MachineTag) state.NotifyWatcher
+	watchVolume                   func(names.VolumeTag) state.NotifyWatcher
+	watchFilesystem               func(names.FilesystemTag) state.NotifyWatcher
 	addUnitStorage                func(u names.UnitTag, name string, cons state.StorageConstraints) error
 	unitStorageConstraints        func(u names.UnitTag) (map[string]state.StorageConstraints, error)
 }
@@ -534,6 +548,14 @@ func (m *mockStorageState) WatchBlockDevices(mtag names.MachineTag) state.Notify
 	return m.watchBlockDevices(mtag)
 }
 
+func (m *mockStorageState) WatchVolume(v names.VolumeTag) state.NotifyWatcher {
+	return m.watchVolume(v)
+}
+
+func (m *mockStorageState) WatchFilesystem(f names.FilesystemTag) state.NotifyWatcher {
+	return m.watchFilesystem(f)
+}
+
 func (m *mockStorageState) AddStorageForUnit(tag names.UnitTag, name string, cons state.StorageConstraints) error {
 	return m.addUnitStorage(tag, name, cons)
 }
@@ -561,7 +561,10 @@ func (u *UniterAPIV3) charmModifiedVersion(tagStr string, canAccess func(names.T
 	if err != nil {
 		return -1, err
 	}
+	// During a rolling charm upgrade, units see the previous charm's
+	// modified version until they are released to the upgrade.
 	var service *state.Application
+	unitName := u.unit.Name()
 	switch entity := unitOrService.(type) {
 	case *state.Application:
 		service = entity
@@ -570,10 +573,63 @@ func (u *UniterAPIV3) charmModifiedVersion(tagStr string, canAccess func(names.T
 		if err != nil {
 			return -1, err
 		}
+		unitName = entity.Name()
 	default:
 		return -1, errors.BadRequestf("type %T does not have a CharmModifiedVersion", entity)
 	}
-	return service.CharmModifiedVersion(), nil
+	return service.UnitCharmModifiedVersion(unitName), nil
+}
+
+// HookTimeouts returns the hook timeouts of the applications of all
+// given units or applications.
+func (u *UniterAPIV3) HookTimeouts(args params.Entities) (params.HookTimeoutsResults, error) {
+	results := params.HookTimeoutsResults{
+		Results: make([]params.HookTimeoutsResult, len(args.Entities)),
+	}
+	accessUnitOrService := common.AuthAny(u.accessUnit, u.accessService)
+	canAccess, err := accessUnitOrService()
+	if err != nil {
+		return results, err
+	}
+	for i, entity := range args.Entities {
+		timeouts, err := u.hookTimeouts(entity.Tag, canAccess)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = params.HookTimeouts{
+			Default: timeouts.Default,
+			Hooks:   timeouts.Hooks,
+		}
+	}
+	return results, nil
+}
+
+func (u *UniterAPIV3) hookTimeouts(tagStr string, canAccess func(names.Tag) bool) (state.HookTimeouts, error) {
+	tag, err := names.ParseTag(tagStr)
+	if err != nil {
+		return state.HookTimeouts{}, common.ErrPerm
+	}
+	if !canAccess(tag) {
+		return state.HookTimeouts{}, common.ErrPerm
+	}
+	unitOrService, err := u.st.FindEntity(tag)
+	if err != nil {
+		return state.HookTimeouts{}, err
+	}
+	var service *state.Application
+	switch entity := unitOrService.(type) {
+	case *state.Application:
+		service = entity
+	case *state.Unit:
+		service, err = entity.Application()
+		if err != nil {
+			return state.HookTimeouts{}, err
+		}
+	default:
+		return state.HookTimeouts{}, errors.BadRequestf("type %T does not have hook timeouts", entity)
+	}
+	return service.HookTimeouts(), nil
 }
 
 // CharmURL returns the charm URL for all given units or services.
@@ -597,10 +653,19 @@ func (u *UniterAPIV3) CharmURL(args params.Entities) (params.StringBoolResults,
 			var unitOrService state.Entity
 			unitOrService, err = u.st.FindEntity(tag)
 			if err == nil {
-				charmURLer := unitOrService.(interface {
-					CharmURL() (*charm.URL, bool)
-				})
-				curl, ok := charmURLer.CharmURL()
+				var curl *charm.URL
+				var ok bool
+				if service, isService := unitOrService.(*state.Application); isService {
+					// During a rolling charm upgrade, the unit sees
+					// the previous charm until it is released to
+					// the upgrade.
+					curl, ok = service.UnitCharmURL(u.unit.Name())
+				} else {
+					charmURLer := unitOrService.(interface {
+						CharmURL() (*charm.URL, bool)
+					})
+					curl, ok = charmURLer.CharmURL()
+				}
 				if curl != nil {
 					result.Results[i].Result = curl.String()
 					result.Results[i].Ok = ok
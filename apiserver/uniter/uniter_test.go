@@ -854,6 +854,69 @@ func (s *uniterSuite) TestCharmModifiedVersion(c *gc.C) {
 	})
 }
 
+func (s *uniterSuite) TestCharmURLRollingUpgrade(c *gc.C) {
+	newCharm := s.Factory.MakeCharm(c, &jujuFactory.CharmParams{
+		Name: "wordpress",
+		URL:  "cs:quantal/wordpress-4",
+	})
+	err := s.wordpress.SetCharm(state.SetCharmConfig{
+		Charm:          newCharm,
+		RollingUpgrade: &state.RollingUpgradeParams{BatchSize: 1},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "application-wordpress"},
+	}}
+	assertCharm := func(curl *charm.URL, modifiedVersion int) {
+		urlResult, err := s.uniter.CharmURL(args)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(urlResult, gc.DeepEquals, params.StringBoolResults{
+			Results: []params.StringBoolResult{{Result: curl.String()}},
+		})
+		versionResult, err := s.uniter.CharmModifiedVersion(args)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(versionResult, gc.DeepEquals, params.IntResults{
+			Results: []params.IntResult{{Result: modifiedVersion}},
+		})
+	}
+
+	// The unit sees the previous charm until it is released.
+	assertCharm(s.wpCharm.URL(), 0)
+	err = s.wordpress.ReleaseRollingUpgradeUnits(s.wordpressUnit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	assertCharm(newCharm.URL(), 1)
+}
+
+func (s *uniterSuite) TestHookTimeouts(c *gc.C) {
+	err := s.wordpress.SetHookTimeouts(state.HookTimeouts{
+		Default: time.Minute,
+		Hooks:   map[string]time.Duration{"install": time.Hour},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	expected := params.HookTimeouts{
+		Default: time.Minute,
+		Hooks:   map[string]time.Duration{"install": time.Hour},
+	}
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "application-mysql"},
+		{Tag: "application-wordpress"},
+		{Tag: "unit-wordpress-0"},
+		{Tag: "application-foo"},
+	}}
+	result, err := s.uniter.HookTimeouts(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.HookTimeoutsResults{
+		Results: []params.HookTimeoutsResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{Result: expected},
+			{Result: expected},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
 func (s *uniterSuite) TestOpenPorts(c *gc.C) {
 	openedPorts, err := s.wordpressUnit.OpenedPorts()
 	c.Assert(err, jc.ErrorIsNil)
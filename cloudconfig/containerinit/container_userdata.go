@@ -110,7 +110,7 @@ func GenerateNetworkConfig(networkConfig *container.NetworkConfig) (string, erro
 			continue
 		}
 
-		output.WriteString("iface " + name + " inet static\n")
+		output.WriteString("iface " + name + " " + addressFamily(address) + " static\n")
 		output.WriteString("  address " + address + "\n")
 		if !gatewayHandled && prepared.GatewayAddress != "" {
 			_, network, err := net.ParseCIDR(address)
@@ -136,6 +136,16 @@ func GenerateNetworkConfig(networkConfig *container.NetworkConfig) (string, erro
 	return generatedConfig, nil
 }
 
+// addressFamily returns the ifupdown address family ("inet" or
+// "inet6") of the given CIDR address.
+func addressFamily(cidrAddress string) string {
+	ip, _, err := net.ParseCIDR(cidrAddress)
+	if err == nil && ip.To4() == nil {
+		return "inet6"
+	}
+	return "inet"
+}
+
 // PreparedConfig holds all the necessary information to render a persistent
 // network config to a file.
 type PreparedConfig struct {
@@ -192,6 +192,29 @@ func (s *UserDataSuite) TestGenerateNetworkConfig(c *gc.C) {
 	c.Assert(data, gc.Equals, s.expectedSampleConfig)
 }
 
+func (s *UserDataSuite) TestGenerateNetworkConfigIPv6(c *gc.C) {
+	netConfig := container.BridgeNetworkConfig("foo", 0, []network.InterfaceInfo{{
+		InterfaceName:  "any0",
+		CIDR:           "2001:db8::/64",
+		ConfigType:     network.ConfigStatic,
+		Address:        network.NewAddress("2001:db8::3"),
+		DNSServers:     network.NewAddresses("2001:db8::53"),
+		GatewayAddress: network.NewAddress("2001:db8::1"),
+	}})
+	data, err := containerinit.GenerateNetworkConfig(netConfig)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(data, gc.Equals, `
+auto any0 lo
+
+iface lo inet loopback
+  dns-nameservers 2001:db8::53
+
+iface any0 inet6 static
+  address 2001:db8::3/64
+  gateway 2001:db8::1
+`)
+}
+
 func (s *UserDataSuite) TestNewCloudInitConfigWithNetworksSampleConfig(c *gc.C) {
 	netConfig := container.BridgeNetworkConfig("foo", 0, s.fakeInterfaces)
 	cloudConf, err := containerinit.NewCloudInitConfigWithNetworks("quantal", netConfig)
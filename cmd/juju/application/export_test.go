@@ -5,6 +5,7 @@ package application
 
 import (
 	"github.com/juju/cmd"
+	"github.com/juju/utils/clock"
 	"gopkg.in/juju/charmrepo.v2-unstable/csclient"
 	"gopkg.in/macaroon-bakery.v1/httpbakery"
 
@@ -24,6 +25,8 @@ func NewUpgradeCharmCommandForTest(
 	newCharmUpgradeClient func(api.Connection) CharmUpgradeClient,
 	newModelConfigGetter func(api.Connection) ModelConfigGetter,
 	newResourceLister func(api.Connection) (ResourceLister, error),
+	newStatusClient func(api.Connection) StatusClient,
+	clock clock.Clock,
 ) cmd.Command {
 	cmd := &upgradeCharmCommand{
 		DeployResources:       deployResources,
@@ -33,6 +36,8 @@ func NewUpgradeCharmCommandForTest(
 		NewCharmUpgradeClient: newCharmUpgradeClient,
 		NewModelConfigGetter:  newModelConfigGetter,
 		NewResourceLister:     newResourceLister,
+		NewStatusClient:       newStatusClient,
+		Clock:                 clock,
 	}
 	cmd.SetClientStore(store)
 	cmd.SetAPIOpener(apiOpener)
@@ -46,6 +51,18 @@ func NewConfigCommandForTest(api configCommandAPI) cmd.Command {
 	})
 }
 
+// NewHookTimeoutsCommandForTest returns a HookTimeoutsCommand with the
+// api provided as specified.
+func NewHookTimeoutsCommandForTest(api hookTimeoutsAPI) cmd.Command {
+	return modelcmd.Wrap(&hookTimeoutsCommand{api: api})
+}
+
+// NewSetEgressCommandForTest returns a SetEgressCommand with the api
+// provided as specified.
+func NewSetEgressCommandForTest(api setEgressAPI) cmd.Command {
+	return modelcmd.Wrap(&setEgressCommand{api: api})
+}
+
 // NewAddUnitCommandForTest returns an AddUnitCommand with the api provided as specified.
 func NewAddUnitCommandForTest(api serviceAddUnitAPI) cmd.Command {
 	return modelcmd.Wrap(&addUnitCommand{
@@ -6,6 +6,7 @@ package application
 import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 
 	"github.com/juju/juju/api/application"
 	"github.com/juju/juju/cmd/juju/block"
@@ -19,8 +20,19 @@ var usageExposeDetails = `
 Adjusts the firewall rules and any relevant security mechanisms of the
 cloud to allow public access to the application.
 
+By default the application's open ports may be reached from anywhere.
+The --to-cidrs and --to-spaces options restrict access to the given
+CIDRs and to the subnets of the given spaces respectively. Exposing an
+application again replaces any previous restrictions.
+
+Exposure applies to all of the application's opened ports. Units open
+ports for the application as a whole rather than for particular
+endpoints, so exposure cannot be restricted to an endpoint.
+
 Examples:
     juju expose wordpress
+    juju expose wordpress --to-cidrs 10.0.0.0/8,192.168.1.0/24
+    juju expose wordpress --to-spaces mgmt
 
 See also: 
     unexpose`[1:]
@@ -34,6 +46,8 @@ func NewExposeCommand() cmd.Command {
 type exposeCommand struct {
 	modelcmd.ModelCommandBase
 	ApplicationName string
+	ToCIDRs         []string
+	ToSpaces        []string
 }
 
 func (c *exposeCommand) Info() *cmd.Info {
@@ -45,6 +59,12 @@ func (c *exposeCommand) Info() *cmd.Info {
 	}
 }
 
+func (c *exposeCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.Var(cmd.NewAppendStringsValue(&c.ToCIDRs), "to-cidrs", "Comma-separated CIDRs from which the application may be reached")
+	f.Var(cmd.NewAppendStringsValue(&c.ToSpaces), "to-spaces", "Comma-separated spaces whose subnets may reach the application")
+}
+
 func (c *exposeCommand) Init(args []string) error {
 	if len(args) == 0 {
 		return errors.New("no application name specified")
@@ -56,6 +76,7 @@ func (c *exposeCommand) Init(args []string) error {
 type serviceExposeAPI interface {
 	Close() error
 	Expose(serviceName string) error
+	ExposeTo(serviceName string, cidrs, spaces []string) error
 	Unexpose(serviceName string) error
 }
 
@@ -75,5 +96,10 @@ func (c *exposeCommand) Run(_ *cmd.Context) error {
 		return err
 	}
 	defer client.Close()
-	return block.ProcessBlockedError(client.Expose(c.ApplicationName), block.BlockChange)
+	if len(c.ToCIDRs) > 0 || len(c.ToSpaces) > 0 {
+		err = client.ExposeTo(c.ApplicationName, c.ToCIDRs, c.ToSpaces)
+	} else {
+		err = client.Expose(c.ApplicationName)
+	}
+	return block.ProcessBlockedError(err, block.BlockChange)
 }
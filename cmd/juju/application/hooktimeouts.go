@@ -0,0 +1,210 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// defaultHookTimeoutKey is the key used on the command line and in
+// output to refer to the timeout applied to hooks with no specific
+// timeout of their own.
+const defaultHookTimeoutKey = "default"
+
+var usageHookTimeoutsSummary = `
+Gets or sets the maximum time an application's hooks may run for.`[1:]
+
+var usageHookTimeoutsDetails = `
+A hook that runs for longer than its timeout is killed, along with any
+processes it started, and the unit is put into an error state with a
+"hook timed out" message. The hook can then be retried with
+` + "`juju resolved --retry`" + ` like any other failed hook.
+
+Timeouts may be set for individual hook kinds, such as "install" or
+"relation-changed", and a default may be set with the "default" key
+for all other hooks. Timeouts are given as durations, such as "90s" or
+"1h30m". A timeout of 0 removes it.
+
+With only an application name, the current timeouts are displayed.
+
+Examples:
+    juju hook-timeouts mysql
+    juju hook-timeouts mysql default=10m install=1h
+    juju hook-timeouts mysql install=0
+    juju hook-timeouts mysql --reset
+
+See also:
+    resolved`[1:]
+
+// NewHookTimeoutsCommand returns a command which gets or sets the hook
+// timeouts of an application.
+func NewHookTimeoutsCommand() cmd.Command {
+	return modelcmd.Wrap(&hookTimeoutsCommand{})
+}
+
+type hookTimeoutsAPI interface {
+	Close() error
+	HookTimeouts(application string) (params.HookTimeouts, error)
+	SetHookTimeouts(application string, timeouts params.HookTimeouts) error
+}
+
+// hookTimeoutsCommand gets or sets the hook timeouts of an application.
+type hookTimeoutsCommand struct {
+	modelcmd.ModelCommandBase
+	out cmd.Output
+	api hookTimeoutsAPI
+
+	applicationName string
+	updates         map[string]time.Duration
+	reset           bool
+}
+
+func (c *hookTimeoutsCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "hook-timeouts",
+		Args:    "<application> [<hook kind>=<duration> ...]",
+		Purpose: usageHookTimeoutsSummary,
+		Doc:     usageHookTimeoutsDetails,
+	}
+}
+
+func (c *hookTimeoutsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.reset, "reset", false, "Remove all hook timeouts")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"tabular": formatHookTimeoutsTabular,
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+	})
+}
+
+func (c *hookTimeoutsCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application name specified")
+	}
+	if !names.IsValidApplication(args[0]) {
+		return errors.Errorf("invalid application name %q", args[0])
+	}
+	c.applicationName, args = args[0], args[1:]
+	if c.reset && len(args) > 0 {
+		return errors.New("cannot specify timeouts with --reset")
+	}
+	if len(args) == 0 {
+		return nil
+	}
+	c.updates = make(map[string]time.Duration)
+	for _, arg := range args {
+		parts := strings.SplitN(arg, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return errors.Errorf("expected <hook kind>=<duration>, got %q", arg)
+		}
+		timeout, err := time.ParseDuration(parts[1])
+		if err != nil {
+			return errors.Annotatef(err, "invalid timeout for %q", parts[0])
+		}
+		if timeout < 0 {
+			return errors.Errorf("invalid timeout for %q: must not be negative", parts[0])
+		}
+		c.updates[parts[0]] = timeout
+	}
+	return nil
+}
+
+func (c *hookTimeoutsCommand) getAPI() (hookTimeoutsAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return application.NewClient(root), nil
+}
+
+func (c *hookTimeoutsCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if c.reset {
+		err := client.SetHookTimeouts(c.applicationName, params.HookTimeouts{})
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	timeouts, err := client.HookTimeouts(c.applicationName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(c.updates) == 0 {
+		return c.out.Write(ctx, hookTimeoutsMap(timeouts))
+	}
+	for kind, timeout := range c.updates {
+		if kind == defaultHookTimeoutKey {
+			timeouts.Default = timeout
+			continue
+		}
+		if timeouts.Hooks == nil {
+			timeouts.Hooks = make(map[string]time.Duration)
+		}
+		if timeout == 0 {
+			delete(timeouts.Hooks, kind)
+		} else {
+			timeouts.Hooks[kind] = timeout
+		}
+	}
+	err = client.SetHookTimeouts(c.applicationName, timeouts)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
+
+// hookTimeoutsMap returns the supplied timeouts as a map of hook kind
+// to duration string, suitable for output.
+func hookTimeoutsMap(timeouts params.HookTimeouts) map[string]string {
+	result := make(map[string]string)
+	if timeouts.Default != 0 {
+		result[defaultHookTimeoutKey] = timeouts.Default.String()
+	}
+	for kind, timeout := range timeouts.Hooks {
+		result[kind] = timeout.String()
+	}
+	return result
+}
+
+func formatHookTimeoutsTabular(writer io.Writer, value interface{}) error {
+	timeouts, ok := value.(map[string]string)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", timeouts, value)
+	}
+	if len(timeouts) == 0 {
+		fmt.Fprintln(writer, "No hook timeouts set.")
+		return nil
+	}
+	kinds := make([]string, 0, len(timeouts))
+	for kind := range timeouts {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Hook", "Timeout")
+	for _, kind := range kinds {
+		w.Println(kind, timeouts[kind])
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,160 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/testing"
+)
+
+type HookTimeoutsSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake *fakeHookTimeoutsAPI
+}
+
+var _ = gc.Suite(&HookTimeoutsSuite{})
+
+func (s *HookTimeoutsSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeHookTimeoutsAPI{
+		timeouts: params.HookTimeouts{
+			Default: 10 * time.Minute,
+			Hooks: map[string]time.Duration{
+				"install": time.Hour,
+			},
+		},
+	}
+}
+
+func (s *HookTimeoutsSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{},
+		err:  `no application name specified`,
+	}, {
+		args: []string{"mysql-0"},
+		err:  `invalid application name "mysql-0"`,
+	}, {
+		args: []string{"mysql", "install"},
+		err:  `expected <hook kind>=<duration>, got "install"`,
+	}, {
+		args: []string{"mysql", "=1m"},
+		err:  `expected <hook kind>=<duration>, got "=1m"`,
+	}, {
+		args: []string{"mysql", "install=forever"},
+		err:  `invalid timeout for "install": .*`,
+	}, {
+		args: []string{"mysql", "install=-1m"},
+		err:  `invalid timeout for "install": must not be negative`,
+	}, {
+		args: []string{"mysql", "--reset", "install=1m"},
+		err:  `cannot specify timeouts with --reset`,
+	}, {
+		args: []string{"mysql"},
+	}, {
+		args: []string{"mysql", "--reset"},
+	}, {
+		args: []string{"mysql", "default=5m", "install=1h"},
+	}} {
+		c.Logf("test %d: %q", i, test.args)
+		err := testing.InitCommand(application.NewHookTimeoutsCommandForTest(s.fake), test.args)
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
+
+func (s *HookTimeoutsSuite) TestShow(c *gc.C) {
+	ctx, err := testing.RunCommand(c, application.NewHookTimeoutsCommandForTest(s.fake), "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"Hook     Timeout\n"+
+		"default  10m0s\n"+
+		"install  1h0m0s\n",
+	)
+	s.fake.CheckCalls(c, []jujutesting.StubCall{
+		{"HookTimeouts", []interface{}{"mysql"}},
+		{"Close", nil},
+	})
+}
+
+func (s *HookTimeoutsSuite) TestShowYAML(c *gc.C) {
+	ctx, err := testing.RunCommand(c, application.NewHookTimeoutsCommandForTest(s.fake), "mysql", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"default: 10m0s\n"+
+		"install: 1h0m0s\n",
+	)
+}
+
+func (s *HookTimeoutsSuite) TestShowNone(c *gc.C) {
+	s.fake.timeouts = params.HookTimeouts{}
+	ctx, err := testing.RunCommand(c, application.NewHookTimeoutsCommandForTest(s.fake), "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "No hook timeouts set.\n")
+}
+
+func (s *HookTimeoutsSuite) TestSet(c *gc.C) {
+	_, err := testing.RunCommand(c, application.NewHookTimeoutsCommandForTest(s.fake),
+		"mysql", "default=5m", "install=0", "relation-changed=30s",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCalls(c, []jujutesting.StubCall{
+		{"HookTimeouts", []interface{}{"mysql"}},
+		{"SetHookTimeouts", []interface{}{"mysql", params.HookTimeouts{
+			Default: 5 * time.Minute,
+			Hooks: map[string]time.Duration{
+				"relation-changed": 30 * time.Second,
+			},
+		}}},
+		{"Close", nil},
+	})
+}
+
+func (s *HookTimeoutsSuite) TestReset(c *gc.C) {
+	_, err := testing.RunCommand(c, application.NewHookTimeoutsCommandForTest(s.fake), "mysql", "--reset")
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCalls(c, []jujutesting.StubCall{
+		{"SetHookTimeouts", []interface{}{"mysql", params.HookTimeouts{}}},
+		{"Close", nil},
+	})
+}
+
+func (s *HookTimeoutsSuite) TestSetError(c *gc.C) {
+	s.fake.SetErrors(nil, errors.New("boom"))
+	_, err := testing.RunCommand(c, application.NewHookTimeoutsCommandForTest(s.fake), "mysql", "install=1m")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type fakeHookTimeoutsAPI struct {
+	jujutesting.Stub
+	timeouts params.HookTimeouts
+}
+
+func (f *fakeHookTimeoutsAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return nil
+}
+
+func (f *fakeHookTimeoutsAPI) HookTimeouts(application string) (params.HookTimeouts, error) {
+	f.MethodCall(f, "HookTimeouts", application)
+	return f.timeouts, f.NextErr()
+}
+
+func (f *fakeHookTimeoutsAPI) SetHookTimeouts(application string, timeouts params.HookTimeouts) error {
+	f.MethodCall(f, "SetHookTimeouts", application, timeouts)
+	return f.NextErr()
+}
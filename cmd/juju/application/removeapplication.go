@@ -6,6 +6,7 @@ package application
 import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	"github.com/juju/romulus/api/budget"
 	wireformat "github.com/juju/romulus/wireformat/budget"
 	"gopkg.in/juju/charm.v6-unstable"
@@ -28,6 +29,7 @@ func NewRemoveApplicationCommand() cmd.Command {
 type removeApplicationCommand struct {
 	modelcmd.ModelCommandBase
 	ApplicationName string
+	ReleaseStorage  bool
 }
 
 var helpSummaryRmApp = `
@@ -42,9 +44,18 @@ before removing them. Removing units which are co-located with units of
 other charms or a Juju controller will not result in the removal of the
 machine.
 
+By default, the cloud volumes of the application's storage are destroyed
+along with the application. If --release-storage is specified, the volumes
+are instead detached and left intact in the cloud, tagged with the model
+and storage they were released from, so that they may later be imported
+into another model with "juju import-filesystem" or "juju import-volume".
+The application is not removed if any of its volumes are managed by a
+storage provider that cannot release volumes.
+
 Examples:
     juju remove-application hadoop
-    juju remove-application -m test-model mariadb`[1:]
+    juju remove-application -m test-model mariadb
+    juju remove-application --release-storage postgresql`[1:]
 
 func (c *removeApplicationCommand) Info() *cmd.Info {
 	return &cmd.Info{
@@ -55,6 +66,11 @@ func (c *removeApplicationCommand) Info() *cmd.Info {
 	}
 }
 
+func (c *removeApplicationCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.ReleaseStorage, "release-storage", false, "Release the application's cloud volumes rather than destroying them")
+}
+
 func (c *removeApplicationCommand) Init(args []string) error {
 	if len(args) == 0 {
 		return errors.Errorf("no application specified")
@@ -69,6 +85,7 @@ func (c *removeApplicationCommand) Init(args []string) error {
 type removeApplicationAPI interface {
 	Close() error
 	Destroy(serviceName string) error
+	DestroyReleasingStorage(serviceName string) error
 	DestroyUnits(unitNames ...string) error
 	GetCharmURL(serviceName string) (*charm.URL, error)
 	ModelUUID() string
@@ -88,7 +105,12 @@ func (c *removeApplicationCommand) Run(ctx *cmd.Context) error {
 		return err
 	}
 	defer client.Close()
-	err = block.ProcessBlockedError(client.Destroy(c.ApplicationName), block.BlockRemove)
+	if c.ReleaseStorage {
+		err = client.DestroyReleasingStorage(c.ApplicationName)
+	} else {
+		err = client.Destroy(c.ApplicationName)
+	}
+	err = block.ProcessBlockedError(err, block.BlockRemove)
 	if err != nil {
 		return err
 	}
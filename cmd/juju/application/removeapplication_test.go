@@ -66,6 +66,16 @@ func (s *RemoveApplicationSuite) TestLocalApplication(c *gc.C) {
 	s.stub.CheckNoCalls(c)
 }
 
+func (s *RemoveApplicationSuite) TestLocalApplicationReleasingStorage(c *gc.C) {
+	s.setupTestApplication(c)
+	err := runRemoveApplication(c, "--release-storage", "riak")
+	c.Assert(err, jc.ErrorIsNil)
+	riak, err := s.State.Application("riak")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(riak.Life(), gc.Equals, state.Dying)
+	s.stub.CheckNoCalls(c)
+}
+
 func (s *RemoveApplicationSuite) TestRemoteApplication(c *gc.C) {
 	_, err := s.State.AddRemoteApplication(state.AddRemoteApplicationParams{
 		Name:        "remote-app",
@@ -0,0 +1,219 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/status"
+)
+
+const (
+	upgradeLeaderFirst = "first"
+	upgradeLeaderLast  = "last"
+
+	defaultBatchTimeout = 30 * time.Minute
+
+	// rollingUpgradePollInterval is how often the status of the
+	// units being upgraded is checked.
+	rollingUpgradePollInterval = 5 * time.Second
+)
+
+// StatusClient defines a subset of the client facade, as required by
+// the upgrade-charm command to follow the progress of a rolling
+// upgrade.
+type StatusClient interface {
+	Status(patterns []string) (*params.FullStatus, error)
+}
+
+// rollingUpgrader releases the units of an application to its rolling
+// charm upgrade a batch at a time, waiting for each batch to settle
+// before releasing the next.
+type rollingUpgrader struct {
+	client       CharmUpgradeClient
+	status       StatusClient
+	clock        clock.Clock
+	ctx          *cmd.Context
+	application  string
+	batchTimeout time.Duration
+}
+
+// run drives the rolling upgrade to completion, or pauses it if a unit
+// fails to settle after upgrading.
+func (u *rollingUpgrader) run(upgrade *params.RollingUpgrade) error {
+	units, err := u.units()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	released := make(map[string]bool)
+	for _, name := range upgrade.Released {
+		released[name] = true
+	}
+
+	// When resuming, the units already released must settle before
+	// any more are released.
+	var settling []string
+	for _, name := range upgrade.Released {
+		if _, ok := units[name]; ok {
+			settling = append(settling, name)
+		}
+	}
+	if len(settling) > 0 {
+		if err := u.waitForBatch(upgrade.CharmURL, settling); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	pending := orderUnits(units, upgrade.LeaderFirst)
+	for i := 0; i < len(pending); {
+		var batch []string
+		for ; i < len(pending) && len(batch) < upgrade.BatchSize; i++ {
+			if !released[pending[i]] {
+				batch = append(batch, pending[i])
+			}
+		}
+		if len(batch) == 0 {
+			continue
+		}
+		u.ctx.Infof("Upgrading %s", strings.Join(batch, ", "))
+		if err := u.client.ReleaseRollingUpgradeUnits(u.application, batch...); err != nil {
+			return errors.Trace(err)
+		}
+		if err := u.waitForBatch(upgrade.CharmURL, batch); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	if err := u.client.FinishRollingUpgrade(u.application); err != nil {
+		return errors.Trace(err)
+	}
+	u.ctx.Infof("Upgraded all units of %q to charm %q.", u.application, upgrade.CharmURL)
+	return nil
+}
+
+// waitForBatch waits for the named units to run the specified charm,
+// with an active workload and an idle agent. If a unit is in error or
+// blocked, or the units do not settle within the batch timeout, the
+// rolling upgrade is paused and an error returned.
+func (u *rollingUpgrader) waitForBatch(charmURL string, batch []string) error {
+	timeout := u.clock.After(u.batchTimeout)
+	for {
+		units, err := u.units()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		settled, reason := batchSettled(units, charmURL, batch)
+		if reason != "" {
+			return u.pause(reason)
+		}
+		if settled {
+			return nil
+		}
+		select {
+		case <-timeout:
+			return u.pause(fmt.Sprintf(
+				"units %s did not settle within %v",
+				strings.Join(batch, ", "), u.batchTimeout,
+			))
+		case <-u.clock.After(rollingUpgradePollInterval):
+		}
+	}
+}
+
+// pause pauses the rolling upgrade for the given reason, and returns
+// an error describing how to resume it.
+func (u *rollingUpgrader) pause(reason string) error {
+	if err := u.client.PauseRollingUpgrade(u.application, reason); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Errorf(
+		"rolling upgrade paused: %s\nresolve the problem, then run %q to continue",
+		reason, "juju upgrade-charm "+u.application+" --resume",
+	)
+}
+
+// units returns the status of the application's units.
+func (u *rollingUpgrader) units() (map[string]params.UnitStatus, error) {
+	fullStatus, err := u.status.Status([]string{u.application})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	app, ok := fullStatus.Applications[u.application]
+	if !ok {
+		return nil, errors.NotFoundf("application %q", u.application)
+	}
+	return app.Units, nil
+}
+
+// batchSettled returns whether all of the units in the batch run the
+// specified charm and are active and idle. If any of them is in error
+// or blocked, it returns the reason the upgrade should be paused.
+func batchSettled(units map[string]params.UnitStatus, charmURL string, batch []string) (bool, string) {
+	settled := true
+	for _, name := range batch {
+		unit, ok := units[name]
+		if !ok {
+			// The unit has been removed.
+			continue
+		}
+		switch status.Status(unit.WorkloadStatus.Status) {
+		case status.Error, status.Blocked:
+			return false, fmt.Sprintf("unit %s is %s: %s", name, unit.WorkloadStatus.Status, unit.WorkloadStatus.Info)
+		}
+		// The unit's charm is only reported when it differs from
+		// the application's.
+		upgraded := unit.Charm == "" || unit.Charm == charmURL
+		if !upgraded ||
+			status.Status(unit.WorkloadStatus.Status) != status.Active ||
+			status.Status(unit.AgentStatus.Status) != status.Idle {
+			settled = false
+		}
+	}
+	return settled, ""
+}
+
+// orderUnits returns the names of the units in the order they are to
+// be upgraded: by unit number, with the leader first or last.
+func orderUnits(units map[string]params.UnitStatus, leaderFirst bool) []string {
+	var leader string
+	var ordered []string
+	for name, unit := range units {
+		if unit.Leader {
+			leader = name
+			continue
+		}
+		ordered = append(ordered, name)
+	}
+	sort.Sort(unitsByNumber(ordered))
+	if leader == "" {
+		return ordered
+	}
+	if leaderFirst {
+		return append([]string{leader}, ordered...)
+	}
+	return append(ordered, leader)
+}
+
+// unitsByNumber sorts unit names of the same application by unit number.
+type unitsByNumber []string
+
+func (u unitsByNumber) Len() int      { return len(u) }
+func (u unitsByNumber) Swap(i, j int) { u[i], u[j] = u[j], u[i] }
+func (u unitsByNumber) Less(i, j int) bool {
+	return unitNumber(u[i]) < unitNumber(u[j])
+}
+
+func unitNumber(unitName string) int {
+	n, _ := strconv.Atoi(unitName[strings.LastIndex(unitName, "/")+1:])
+	return n
+}
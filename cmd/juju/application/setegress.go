@@ -0,0 +1,170 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/network"
+)
+
+var usageSetEgressSummary = `
+Gets or sets the destinations an application's units may connect to.`[1:]
+
+var usageSetEgressDetails = `
+Egress rules restrict the outgoing traffic of an application's units to
+the given destinations. Each rule is of the form <cidr>:<port-range>,
+where the port range is a port or range of ports with an optional
+protocol, such as "443", "8000-8080/tcp" or "53/udp". The given rules
+replace any rules previously set.
+
+Rules are enforced by the host firewall of the machines hosting the
+units, when the model's host-firewall-mode is set. As the units on a
+machine share its network, a machine's egress is only restricted once
+all of the applications with units on it have egress rules. DNS and
+connections to the controllers are always allowed, as are connections
+to the units of remote applications related across models.
+
+Egress rules are not enforced by cloud security groups, such as those
+of EC2 and OpenStack, and cannot yet be declared in bundles.
+
+With only an application name, the current rules are displayed.
+
+Examples:
+    juju set-egress mysql
+    juju set-egress mysql 10.0.0.0/8:443 10.0.0.0/8:53/udp
+    juju set-egress mysql 2001:db8::/32:5432
+    juju set-egress mysql --reset
+
+See also:
+    expose
+    model-config`[1:]
+
+// NewSetEgressCommand returns a command which gets or sets the egress
+// rules of an application.
+func NewSetEgressCommand() cmd.Command {
+	return modelcmd.Wrap(&setEgressCommand{})
+}
+
+type setEgressAPI interface {
+	Close() error
+	EgressRules(application string) ([]network.EgressRule, error)
+	SetEgressRules(application string, rules []network.EgressRule) error
+}
+
+// setEgressCommand gets or sets the egress rules of an application.
+type setEgressCommand struct {
+	modelcmd.ModelCommandBase
+	out cmd.Output
+	api setEgressAPI
+
+	applicationName string
+	rules           []network.EgressRule
+	reset           bool
+}
+
+func (c *setEgressCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "set-egress",
+		Args:    "<application> [<cidr>:<port-range> ...]",
+		Purpose: usageSetEgressSummary,
+		Doc:     usageSetEgressDetails,
+	}
+}
+
+func (c *setEgressCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.reset, "reset", false, "Remove all egress rules")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"tabular": formatEgressRulesTabular,
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+	})
+}
+
+func (c *setEgressCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application name specified")
+	}
+	if !names.IsValidApplication(args[0]) {
+		return errors.Errorf("invalid application name %q", args[0])
+	}
+	c.applicationName, args = args[0], args[1:]
+	if c.reset && len(args) > 0 {
+		return errors.New("cannot specify rules with --reset")
+	}
+	for _, arg := range args {
+		rule, err := network.ParseEgressRule(arg)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		c.rules = append(c.rules, rule)
+	}
+	return nil
+}
+
+func (c *setEgressCommand) getAPI() (setEgressAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return application.NewClient(root), nil
+}
+
+func (c *setEgressCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if c.reset || len(c.rules) > 0 {
+		err := client.SetEgressRules(c.applicationName, c.rules)
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	rules, err := client.EgressRules(c.applicationName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	result := make([]string, len(rules))
+	for i, rule := range rules {
+		result[i] = rule.String()
+	}
+	return c.out.Write(ctx, result)
+}
+
+func formatEgressRulesTabular(writer io.Writer, value interface{}) error {
+	rules, ok := value.([]string)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", rules, value)
+	}
+	if len(rules) == 0 {
+		fmt.Fprintln(writer, "No egress rules set.")
+		return nil
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Destination", "Ports")
+	for _, in := range rules {
+		rule, err := network.ParseEgressRule(in)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		w.Println(rule.DestinationCIDR, rule.PortRange)
+	}
+	return tw.Flush()
+}
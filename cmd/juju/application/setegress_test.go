@@ -0,0 +1,147 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/testing"
+)
+
+type SetEgressSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake *fakeSetEgressAPI
+}
+
+var _ = gc.Suite(&SetEgressSuite{})
+
+func (s *SetEgressSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeSetEgressAPI{
+		rules: []network.EgressRule{
+			network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+			network.MustNewEgressRule("udp", 53, 53, "2001:db8::/32"),
+		},
+	}
+}
+
+func (s *SetEgressSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{},
+		err:  `no application name specified`,
+	}, {
+		args: []string{"mysql-0"},
+		err:  `invalid application name "mysql-0"`,
+	}, {
+		args: []string{"mysql", "443/tcp"},
+		err:  `invalid egress rule "443/tcp", expected <cidr>:<port-range>`,
+	}, {
+		args: []string{"mysql", "10.0.0.0:443"},
+		err:  `invalid CIDR address: 10.0.0.0`,
+	}, {
+		args: []string{"mysql", "--reset", "10.0.0.0/8:443"},
+		err:  `cannot specify rules with --reset`,
+	}, {
+		args: []string{"mysql"},
+	}, {
+		args: []string{"mysql", "--reset"},
+	}, {
+		args: []string{"mysql", "10.0.0.0/8:443", "2001:db8::/32:53/udp"},
+	}} {
+		c.Logf("test %d: %q", i, test.args)
+		err := testing.InitCommand(application.NewSetEgressCommandForTest(s.fake), test.args)
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
+
+func (s *SetEgressSuite) TestShow(c *gc.C) {
+	ctx, err := testing.RunCommand(c, application.NewSetEgressCommandForTest(s.fake), "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"Destination    Ports\n"+
+		"10.0.0.0/8     443/tcp\n"+
+		"2001:db8::/32  53/udp\n",
+	)
+	s.fake.CheckCalls(c, []jujutesting.StubCall{
+		{"EgressRules", []interface{}{"mysql"}},
+		{"Close", nil},
+	})
+}
+
+func (s *SetEgressSuite) TestShowYAML(c *gc.C) {
+	ctx, err := testing.RunCommand(c, application.NewSetEgressCommandForTest(s.fake), "mysql", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"- 10.0.0.0/8:443/tcp\n"+
+		"- 2001:db8::/32:53/udp\n",
+	)
+}
+
+func (s *SetEgressSuite) TestShowNone(c *gc.C) {
+	s.fake.rules = nil
+	ctx, err := testing.RunCommand(c, application.NewSetEgressCommandForTest(s.fake), "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "No egress rules set.\n")
+}
+
+func (s *SetEgressSuite) TestSet(c *gc.C) {
+	_, err := testing.RunCommand(c, application.NewSetEgressCommandForTest(s.fake),
+		"mysql", "10.0.0.0/8:443", "10.0.0.0/8:53/udp",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCalls(c, []jujutesting.StubCall{
+		{"SetEgressRules", []interface{}{"mysql", []network.EgressRule{
+			network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+			network.MustNewEgressRule("udp", 53, 53, "10.0.0.0/8"),
+		}}},
+		{"Close", nil},
+	})
+}
+
+func (s *SetEgressSuite) TestReset(c *gc.C) {
+	_, err := testing.RunCommand(c, application.NewSetEgressCommandForTest(s.fake), "mysql", "--reset")
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCalls(c, []jujutesting.StubCall{
+		{"SetEgressRules", []interface{}{"mysql", []network.EgressRule(nil)}},
+		{"Close", nil},
+	})
+}
+
+func (s *SetEgressSuite) TestSetError(c *gc.C) {
+	s.fake.SetErrors(errors.New("boom"))
+	_, err := testing.RunCommand(c, application.NewSetEgressCommandForTest(s.fake), "mysql", "10.0.0.0/8:443")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type fakeSetEgressAPI struct {
+	jujutesting.Stub
+	rules []network.EgressRule
+}
+
+func (f *fakeSetEgressAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return nil
+}
+
+func (f *fakeSetEgressAPI) EgressRules(application string) ([]network.EgressRule, error) {
+	f.MethodCall(f, "EgressRules", application)
+	return f.rules, f.NextErr()
+}
+
+func (f *fakeSetEgressAPI) SetEgressRules(application string, rules []network.EgressRule) error {
+	f.MethodCall(f, "SetEgressRules", application, rules)
+	return f.NextErr()
+}
@@ -7,10 +7,12 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
+	"github.com/juju/utils/clock"
 	"gopkg.in/juju/charm.v6-unstable"
 	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
 	"gopkg.in/juju/charmrepo.v2-unstable"
@@ -24,6 +26,7 @@ import (
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/charms"
 	"github.com/juju/juju/api/modelconfig"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/charmstore"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
@@ -48,6 +51,9 @@ func NewUpgradeCharmCommand() cmd.Command {
 		NewModelConfigGetter: func(conn api.Connection) ModelConfigGetter {
 			return modelconfig.NewClient(conn)
 		},
+		NewStatusClient: func(conn api.Connection) StatusClient {
+			return conn.Client()
+		},
 		NewResourceLister: func(conn api.Connection) (ResourceLister, error) {
 			resclient, err := resourceadapters.NewAPIClient(conn)
 			if err != nil {
@@ -55,6 +61,7 @@ func NewUpgradeCharmCommand() cmd.Command {
 			}
 			return resclient, nil
 		},
+		Clock: clock.WallClock,
 	}
 	return modelcmd.Wrap(cmd)
 }
@@ -64,6 +71,10 @@ func NewUpgradeCharmCommand() cmd.Command {
 type CharmUpgradeClient interface {
 	GetCharmURL(string) (*charm.URL, error)
 	SetCharm(application.SetCharmConfig) error
+	RollingUpgrade(string) (*params.RollingUpgrade, error)
+	ReleaseRollingUpgradeUnits(string, ...string) error
+	PauseRollingUpgrade(string, string) error
+	FinishRollingUpgrade(string) error
 }
 
 // CharmClient defines a subset of the charms facade, as required
@@ -97,6 +108,8 @@ type upgradeCharmCommand struct {
 	NewCharmUpgradeClient func(api.Connection) CharmUpgradeClient
 	NewModelConfigGetter  func(api.Connection) ModelConfigGetter
 	NewResourceLister     func(api.Connection) (ResourceLister, error)
+	NewStatusClient       func(api.Connection) StatusClient
+	Clock                 clock.Clock
 
 	ApplicationName string
 	ForceUnits      bool
@@ -119,6 +132,22 @@ type upgradeCharmCommand struct {
 	// Storage is a map of storage constraints, keyed on the storage name
 	// defined in charm storage metadata, to add or update during upgrade.
 	Storage map[string]storage.Constraints
+
+	// BatchSize, if non-zero, is the number of units to upgrade at a
+	// time in a rolling upgrade.
+	BatchSize int
+
+	// Leader is "first" or "last", and determines whether the leader
+	// is upgraded before or after the other units in a rolling upgrade.
+	Leader string
+
+	// BatchTimeout is how long to wait for a batch of units to settle
+	// after upgrading, before pausing a rolling upgrade.
+	BatchTimeout time.Duration
+
+	// Resume is true if a paused or interrupted rolling upgrade is to
+	// be continued.
+	Resume bool
 }
 
 const upgradeCharmDoc = `
@@ -192,6 +221,23 @@ would specify revision number 5 of the wordpress charm.
 Use of the --force-units flag is not generally recommended; units upgraded while in an
 error state will not have upgrade-charm hooks executed, and may cause unexpected
 behavior.
+
+By default all of the application's units upgrade at once. The --batch-size
+flag instead upgrades the units as a rolling upgrade, a batch of the given
+size at a time. Before the next batch is upgraded, each unit in the batch
+must be running the new charm, with an active workload and an idle agent.
+The leader is upgraded after all of the other units, unless --leader=first
+is specified. Units yet to be upgraded continue to run the previous charm.
+
+  juju upgrade-charm foo --batch-size 2 --leader first
+
+If a unit goes into an error or blocked state, or a batch does not settle
+within --batch-timeout, the rolling upgrade is paused. Once the problem is
+resolved, the upgrade is continued with the --resume flag; this is also how
+to continue an upgrade after the command is interrupted. No other charm
+upgrade of the application may start until the rolling upgrade completes.
+
+  juju upgrade-charm foo --resume
 `
 
 func (c *upgradeCharmCommand) Info() *cmd.Info {
@@ -214,6 +260,10 @@ func (c *upgradeCharmCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(stringMap{&c.Resources}, "resource", "Resource to be uploaded to the controller")
 	f.Var(storageFlag{&c.Storage, nil}, "storage", "Charm storage constraints")
 	f.Var(&c.Config, "config", "Path to yaml-formatted application config")
+	f.IntVar(&c.BatchSize, "batch-size", 0, "Upgrade units as a rolling upgrade, this many at a time")
+	f.StringVar(&c.Leader, "leader", upgradeLeaderLast, "Upgrade the leader first or last in a rolling upgrade")
+	f.DurationVar(&c.BatchTimeout, "batch-timeout", defaultBatchTimeout, "Pause a rolling upgrade if a batch does not settle within this time")
+	f.BoolVar(&c.Resume, "resume", false, "Continue a paused or interrupted rolling upgrade")
 }
 
 func (c *upgradeCharmCommand) Init(args []string) error {
@@ -237,6 +287,23 @@ func (c *upgradeCharmCommand) Init(args []string) error {
 	if c.SwitchURL != "" && c.CharmPath != "" {
 		return errors.Errorf("--switch and --path are mutually exclusive")
 	}
+	if c.BatchSize < 0 {
+		return errors.Errorf("--batch-size must be positive")
+	}
+	if c.Leader != upgradeLeaderFirst && c.Leader != upgradeLeaderLast {
+		return errors.Errorf("--leader must be %q or %q", upgradeLeaderFirst, upgradeLeaderLast)
+	}
+	if c.BatchTimeout <= 0 {
+		return errors.Errorf("--batch-timeout must be positive")
+	}
+	if c.Resume {
+		if c.BatchSize != 0 {
+			return errors.Errorf("--resume and --batch-size are mutually exclusive")
+		}
+		if c.SwitchURL != "" || c.CharmPath != "" || c.Revision != -1 {
+			return errors.Errorf("--resume cannot be used with --switch, --path or --revision")
+		}
+	}
 	return nil
 }
 
@@ -266,6 +333,16 @@ func (c *upgradeCharmCommand) Run(ctx *cmd.Context) error {
 	}
 
 	charmUpgradeClient := c.NewCharmUpgradeClient(apiRoot)
+	if c.Resume {
+		upgrade, err := charmUpgradeClient.RollingUpgrade(c.ApplicationName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if upgrade == nil {
+			return errors.Errorf("no rolling upgrade of application %q in progress", c.ApplicationName)
+		}
+		return c.rollingUpgrade(ctx, apiRoot, charmUpgradeClient, upgrade)
+	}
 	oldURL, err := charmUpgradeClient.GetCharmURL(c.ApplicationName)
 	if err != nil {
 		return errors.Trace(err)
@@ -341,7 +418,46 @@ func (c *upgradeCharmCommand) Run(ctx *cmd.Context) error {
 		ResourceIDs:        ids,
 		StorageConstraints: c.Storage,
 	}
-	return block.ProcessBlockedError(charmUpgradeClient.SetCharm(cfg), block.BlockChange)
+	if c.BatchSize > 0 {
+		cfg.RollingUpgrade = &params.RollingUpgradeParams{
+			BatchSize:   c.BatchSize,
+			LeaderFirst: c.Leader == upgradeLeaderFirst,
+		}
+	}
+	if err := charmUpgradeClient.SetCharm(cfg); err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	if cfg.RollingUpgrade == nil {
+		return nil
+	}
+	upgrade, err := charmUpgradeClient.RollingUpgrade(c.ApplicationName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if upgrade == nil {
+		// The upgrade was finished by someone else.
+		return nil
+	}
+	return c.rollingUpgrade(ctx, apiRoot, charmUpgradeClient, upgrade)
+}
+
+// rollingUpgrade upgrades the application's units in batches, as
+// described by the rolling upgrade in progress.
+func (c *upgradeCharmCommand) rollingUpgrade(
+	ctx *cmd.Context,
+	apiRoot api.Connection,
+	client CharmUpgradeClient,
+	upgrade *params.RollingUpgrade,
+) error {
+	upgrader := &rollingUpgrader{
+		client:       client,
+		status:       c.NewStatusClient(apiRoot),
+		clock:        c.Clock,
+		ctx:          ctx,
+		application:  c.ApplicationName,
+		batchTimeout: c.BatchTimeout,
+	}
+	return block.ProcessBlockedError(upgrader.run(upgrade), block.BlockChange)
 }
 
 // upgradeResources pushes metadata up to the server for each resource defined
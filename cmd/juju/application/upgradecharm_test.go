@@ -10,6 +10,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -32,6 +33,7 @@ import (
 	"github.com/juju/juju/api/application"
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/charms"
+	"github.com/juju/juju/apiserver/params"
 	jujucharmstore "github.com/juju/juju/charmstore"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/environs/config"
@@ -59,6 +61,8 @@ type UpgradeCharmSuite struct {
 	charmUpgradeClient mockCharmUpgradeClient
 	modelConfigGetter  mockModelConfigGetter
 	resourceLister     mockResourceLister
+	statusClient       mockStatusClient
+	clock              *testing.Clock
 	cmd                cmd.Command
 }
 
@@ -117,6 +121,8 @@ func (s *UpgradeCharmSuite) SetUpTest(c *gc.C) {
 	s.charmUpgradeClient = mockCharmUpgradeClient{charmURL: currentCharmURL}
 	s.modelConfigGetter = mockModelConfigGetter{}
 	s.resourceLister = mockResourceLister{}
+	s.statusClient = mockStatusClient{}
+	s.clock = testing.NewClock(time.Now())
 
 	store := jujuclienttesting.NewMemStore()
 	store.CurrentControllerName = "foo"
@@ -158,6 +164,11 @@ func (s *UpgradeCharmSuite) SetUpTest(c *gc.C) {
 			s.AddCall("NewResourceLister", conn)
 			return &s.resourceLister, s.NextErr()
 		},
+		func(conn api.Connection) StatusClient {
+			s.AddCall("NewStatusClient", conn)
+			return &s.statusClient
+		},
+		s.clock,
 	)
 }
 
@@ -227,6 +238,140 @@ func (s *UpgradeCharmSuite) TestConfigSettingsMinFacadeVersion(c *gc.C) {
 		"updating config at upgrade-charm time is not supported by server version 1.2.3")
 }
 
+func (s *UpgradeCharmSuite) TestInitRollingUpgradeErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"foo", "--batch-size", "-1"},
+		err:  "--batch-size must be positive",
+	}, {
+		args: []string{"foo", "--batch-size", "1", "--leader", "middle"},
+		err:  `--leader must be "first" or "last"`,
+	}, {
+		args: []string{"foo", "--batch-size", "1", "--batch-timeout", "0s"},
+		err:  "--batch-timeout must be positive",
+	}, {
+		args: []string{"foo", "--resume", "--batch-size", "1"},
+		err:  "--resume and --batch-size are mutually exclusive",
+	}, {
+		args: []string{"foo", "--resume", "--revision", "2"},
+		err:  "--resume cannot be used with --switch, --path or --revision",
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.runUpgradeCharm(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *UpgradeCharmSuite) setRollingUpgrade(released ...string) {
+	s.charmUpgradeClient.rollingUpgrade = &params.RollingUpgrade{
+		CharmURL:         "cs:quantal/foo-2",
+		PreviousCharmURL: "cs:quantal/foo-1",
+		BatchSize:        2,
+		Released:         released,
+	}
+	s.statusClient.units = map[string]params.UnitStatus{
+		"foo/0":  settledUnit(false),
+		"foo/1":  settledUnit(true),
+		"foo/2":  settledUnit(false),
+		"foo/10": settledUnit(false),
+	}
+}
+
+func settledUnit(leader bool) params.UnitStatus {
+	return params.UnitStatus{
+		AgentStatus:    params.DetailedStatus{Status: "idle"},
+		WorkloadStatus: params.DetailedStatus{Status: "active"},
+		Leader:         leader,
+	}
+}
+
+func (s *UpgradeCharmSuite) TestRollingUpgrade(c *gc.C) {
+	s.setRollingUpgrade()
+	_, err := s.runUpgradeCharm(c, "foo", "--batch-size", "2")
+	c.Assert(err, jc.ErrorIsNil)
+	s.charmUpgradeClient.CheckCall(c, 1, "SetCharm", application.SetCharmConfig{
+		ApplicationName: "foo",
+		CharmID: jujucharmstore.CharmID{
+			URL:     s.resolvedCharmURL,
+			Channel: csclientparams.StableChannel,
+		},
+		RollingUpgrade: &params.RollingUpgradeParams{BatchSize: 2},
+	})
+	// The leader is upgraded last.
+	calls := s.charmUpgradeClient.Calls()[2:]
+	c.Assert(calls, jc.DeepEquals, []testing.StubCall{
+		{"RollingUpgrade", []interface{}{"foo"}},
+		{"ReleaseRollingUpgradeUnits", []interface{}{"foo", []string{"foo/0", "foo/2"}}},
+		{"ReleaseRollingUpgradeUnits", []interface{}{"foo", []string{"foo/10", "foo/1"}}},
+		{"FinishRollingUpgrade", []interface{}{"foo"}},
+	})
+}
+
+func (s *UpgradeCharmSuite) TestRollingUpgradeLeaderFirst(c *gc.C) {
+	s.setRollingUpgrade()
+	s.charmUpgradeClient.rollingUpgrade.LeaderFirst = true
+	_, err := s.runUpgradeCharm(c, "foo", "--batch-size", "2", "--leader", "first")
+	c.Assert(err, jc.ErrorIsNil)
+	s.charmUpgradeClient.CheckCall(c, 3, "ReleaseRollingUpgradeUnits", "foo", []string{"foo/1", "foo/0"})
+	s.charmUpgradeClient.CheckCall(c, 4, "ReleaseRollingUpgradeUnits", "foo", []string{"foo/2", "foo/10"})
+}
+
+func (s *UpgradeCharmSuite) TestRollingUpgradePausesOnError(c *gc.C) {
+	s.setRollingUpgrade()
+	s.statusClient.units["foo/2"] = params.UnitStatus{
+		AgentStatus: params.DetailedStatus{Status: "idle"},
+		WorkloadStatus: params.DetailedStatus{
+			Status: "error",
+			Info:   `hook failed: "upgrade-charm"`,
+		},
+	}
+	_, err := s.runUpgradeCharm(c, "foo", "--batch-size", "2")
+	c.Assert(err, gc.ErrorMatches, `rolling upgrade paused: unit foo/2 is error: hook failed: "upgrade-charm"
+resolve the problem, then run "juju upgrade-charm foo --resume" to continue`)
+	calls := s.charmUpgradeClient.Calls()[2:]
+	c.Assert(calls, jc.DeepEquals, []testing.StubCall{
+		{"RollingUpgrade", []interface{}{"foo"}},
+		{"ReleaseRollingUpgradeUnits", []interface{}{"foo", []string{"foo/0", "foo/2"}}},
+		{"PauseRollingUpgrade", []interface{}{"foo", `unit foo/2 is error: hook failed: "upgrade-charm"`}},
+	})
+}
+
+func (s *UpgradeCharmSuite) TestRollingUpgradePausesOnTimeout(c *gc.C) {
+	s.setRollingUpgrade()
+	s.statusClient.units["foo/0"] = params.UnitStatus{
+		AgentStatus:    params.DetailedStatus{Status: "executing"},
+		WorkloadStatus: params.DetailedStatus{Status: "maintenance"},
+	}
+	go func() {
+		// Wait for both the timeout and the poll timer to be
+		// started, before passing the batch timeout.
+		c.Check(s.clock.WaitAdvance(defaultBatchTimeout, coretesting.LongWait, 2), jc.ErrorIsNil)
+	}()
+	_, err := s.runUpgradeCharm(c, "foo", "--batch-size", "2")
+	c.Assert(err, gc.ErrorMatches, `rolling upgrade paused: units foo/0, foo/2 did not settle within 30m0s
+.*`)
+	s.charmUpgradeClient.CheckCall(c, 4, "PauseRollingUpgrade", "foo", "units foo/0, foo/2 did not settle within 30m0s")
+}
+
+func (s *UpgradeCharmSuite) TestRollingUpgradeResume(c *gc.C) {
+	s.setRollingUpgrade("foo/0", "foo/2")
+	_, err := s.runUpgradeCharm(c, "foo", "--resume")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.charmUpgradeClient.Calls(), jc.DeepEquals, []testing.StubCall{
+		{"RollingUpgrade", []interface{}{"foo"}},
+		{"ReleaseRollingUpgradeUnits", []interface{}{"foo", []string{"foo/10", "foo/1"}}},
+		{"FinishRollingUpgrade", []interface{}{"foo"}},
+	})
+	s.charmAdder.CheckNoCalls(c)
+}
+
+func (s *UpgradeCharmSuite) TestRollingUpgradeResumeNotInProgress(c *gc.C) {
+	_, err := s.runUpgradeCharm(c, "foo", "--resume")
+	c.Assert(err, gc.ErrorMatches, `no rolling upgrade of application "foo" in progress`)
+}
+
 type UpgradeCharmErrorsStateSuite struct {
 	jujutesting.RepoSuite
 	handler charmstore.HTTPCloseHandler
@@ -718,7 +863,8 @@ func (m *mockCharmClient) CharmInfo(curl string) (*charms.CharmInfo, error) {
 type mockCharmUpgradeClient struct {
 	CharmUpgradeClient
 	testing.Stub
-	charmURL *charm.URL
+	charmURL       *charm.URL
+	rollingUpgrade *params.RollingUpgrade
 }
 
 func (m *mockCharmUpgradeClient) GetCharmURL(applicationName string) (*charm.URL, error) {
@@ -731,6 +877,40 @@ func (m *mockCharmUpgradeClient) SetCharm(cfg application.SetCharmConfig) error
 	return m.NextErr()
 }
 
+func (m *mockCharmUpgradeClient) RollingUpgrade(applicationName string) (*params.RollingUpgrade, error) {
+	m.MethodCall(m, "RollingUpgrade", applicationName)
+	return m.rollingUpgrade, m.NextErr()
+}
+
+func (m *mockCharmUpgradeClient) ReleaseRollingUpgradeUnits(applicationName string, units ...string) error {
+	m.MethodCall(m, "ReleaseRollingUpgradeUnits", applicationName, units)
+	return m.NextErr()
+}
+
+func (m *mockCharmUpgradeClient) PauseRollingUpgrade(applicationName, reason string) error {
+	m.MethodCall(m, "PauseRollingUpgrade", applicationName, reason)
+	return m.NextErr()
+}
+
+func (m *mockCharmUpgradeClient) FinishRollingUpgrade(applicationName string) error {
+	m.MethodCall(m, "FinishRollingUpgrade", applicationName)
+	return m.NextErr()
+}
+
+type mockStatusClient struct {
+	testing.Stub
+	units map[string]params.UnitStatus
+}
+
+func (m *mockStatusClient) Status(patterns []string) (*params.FullStatus, error) {
+	m.MethodCall(m, "Status", patterns)
+	return &params.FullStatus{
+		Applications: map[string]params.ApplicationStatus{
+			"foo": {Units: m.units},
+		},
+	}, m.NextErr()
+}
+
 type mockModelConfigGetter struct {
 	ModelConfigGetter
 	testing.Stub
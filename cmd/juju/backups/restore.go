@@ -54,6 +54,8 @@ type restoreCommand struct {
 	backupId       string
 	bootstrap      bool
 	buildAgent     bool
+	toTimeStr      string
+	toTime         time.Time
 
 	newAPIClientFunc         func() (RestoreAPI, error)
 	newEnvironFunc           func(environs.OpenParams) (environs.Environ, error)
@@ -72,6 +74,9 @@ type RestoreAPI interface {
 
 	// RestoreReader is taken from backups.Client.
 	RestoreReader(r io.ReadSeeker, meta *params.BackupsMetadataResult, newClient backups.ClientConnection) error
+
+	// RestoreToTime is taken from backups.Client.
+	RestoreToTime(backupId string, toTime time.Time, newClient backups.ClientConnection) error
 }
 
 var restoreDoc = `
@@ -89,6 +94,17 @@ If the provided state cannot be restored, this command will fail with
 an appropriate message.  For instance, if the existing bootstrap
 instance is already running then the command will fail with a message
 to that effect.
+
+The controller continuously archives its database's oplog next to its
+backups. When restoring a backup by id, --to-time replays the archived
+operations made after the backup was created and before the given time,
+expressed in RFC3339 format (e.g. 2017-06-01T10:15:00Z). This recovers
+everything up to a moment just before, say, an accidental destroy-model
+or remove-application. The oplog archive is only kept by the controller
+that made it, so --to-time can't be used with --file.
+
+Examples:
+    juju restore-backup --id 20170601-093000.<model UUID> --to-time 2017-06-01T10:15:00Z
 `
 
 var BootstrapFunc = bootstrap.Bootstrap
@@ -111,6 +127,7 @@ func (c *restoreCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.filename, "file", "", "Provide a file to be used as the backup.")
 	f.StringVar(&c.backupId, "id", "", "Provide the name of the backup to be restored")
 	f.BoolVar(&c.buildAgent, "build-agent", false, "Build binary agent if bootstraping a new machine")
+	f.StringVar(&c.toTimeStr, "to-time", "", "Replay archived operations made before this time (RFC3339) after restoring")
 }
 
 // Init is where the preconditions for this commands can be checked.
@@ -124,6 +141,16 @@ func (c *restoreCommand) Init(args []string) error {
 	if c.backupId != "" && c.bootstrap {
 		return errors.Errorf("it is not possible to rebootstrap and restore from an id.")
 	}
+	if c.toTimeStr != "" {
+		if c.backupId == "" {
+			return errors.Errorf("--to-time can only be used when restoring from a backup id.")
+		}
+		toTime, err := time.Parse(time.RFC3339, c.toTimeStr)
+		if err != nil {
+			return errors.Errorf("invalid --to-time %q: expected RFC3339 format, e.g. 2017-06-01T10:15:00Z", c.toTimeStr)
+		}
+		c.toTime = toTime.UTC()
+	}
 
 	var err error
 	if c.filename != "" {
@@ -410,9 +437,15 @@ func (c *restoreCommand) Run(ctx *cmd.Context) error {
 
 	// We have a backup client, now use the relevant method
 	// to restore the backup.
-	if c.filename != "" {
+	switch {
+	case c.filename != "":
 		err = client.RestoreReader(archive, meta, c.newClient)
-	} else {
+	case !c.toTime.IsZero():
+		err = client.RestoreToTime(c.backupId, c.toTime, c.newClient)
+		if errors.IsNotSupported(err) {
+			return errors.New("controller does not support restoring to a point in time")
+		}
+	default:
 		err = client.Restore(c.backupId, c.newClient)
 	}
 	if err != nil {
@@ -6,6 +6,7 @@ package backups_test
 import (
 	"io"
 	"sort"
+	"time"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -104,6 +105,42 @@ func (s *restoreSuite) TestRestoreArgs(c *gc.C) {
 
 	_, err = testing.RunCommand(c, s.command, "restore", "--id", "anid", "-b")
 	c.Assert(err, gc.ErrorMatches, "it is not possible to rebootstrap and restore from an id.")
+
+	_, err = testing.RunCommand(c, s.command, "restore", "--file", "afile", "--to-time", "2017-06-01T10:15:00Z")
+	c.Assert(err, gc.ErrorMatches, "--to-time can only be used when restoring from a backup id.")
+
+	_, err = testing.RunCommand(c, s.command, "restore", "--id", "anid", "--to-time", "yesterday")
+	c.Assert(err, gc.ErrorMatches, `invalid --to-time "yesterday": expected RFC3339 format, e.g. 2017-06-01T10:15:00Z`)
+}
+
+func (s *restoreSuite) TestRestoreToTime(c *gc.C) {
+	api := &mockRestoreToTimeAPI{}
+	s.command = backups.NewRestoreCommandForTest(s.store, api, nil, nil, nil)
+	ctx, err := testing.RunCommand(c, s.command, "restore", "--id", "anid", "--to-time", "2017-06-01T12:15:00+02:00")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(api.backupId, gc.Equals, "anid")
+	c.Check(api.toTime.Equal(time.Date(2017, 6, 1, 10, 15, 0, 0, time.UTC)), jc.IsTrue)
+	c.Check(testing.Stdout(ctx), gc.Equals, "restore from \"anid\" completed\n")
+}
+
+func (s *restoreSuite) TestRestoreToTimeNotSupported(c *gc.C) {
+	api := &mockRestoreToTimeAPI{err: errors.NotSupportedf("restoring to a point in time")}
+	s.command = backups.NewRestoreCommandForTest(s.store, api, nil, nil, nil)
+	_, err := testing.RunCommand(c, s.command, "restore", "--id", "anid", "--to-time", "2017-06-01T10:15:00Z")
+	c.Assert(err, gc.ErrorMatches, "controller does not support restoring to a point in time")
+}
+
+type mockRestoreToTimeAPI struct {
+	mockRestoreAPI
+	backupId string
+	toTime   time.Time
+	err      error
+}
+
+func (m *mockRestoreToTimeAPI) RestoreToTime(backupId string, toTime time.Time, _ apibackups.ClientConnection) error {
+	m.backupId = backupId
+	m.toTime = toTime
+	return m.err
 }
 
 // TODO(wallyworld) - add more api related unit tests
@@ -0,0 +1,178 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"strconv"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/highavailability"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// maxMemberPriority is the highest election priority mongo allows a
+// replica set member.
+const maxMemberPriority = 1000
+
+func newSetControllerMemberCommand() cmd.Command {
+	command := &setControllerMemberCommand{}
+	command.newAPIFunc = func() (SetControllerMemberAPI, error) {
+		root, err := command.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Annotate(err, "cannot get API connection")
+		}
+		return highavailability.NewClient(root), nil
+	}
+	return modelcmd.WrapController(command)
+}
+
+// SetControllerMemberAPI defines the methods on the client api that the
+// set-controller-member command calls.
+type SetControllerMemberAPI interface {
+	Close() error
+	SetControllerMemberVoting(machineId string, voting bool) error
+	SetControllerMemberPriority(machineId string, priority float64) error
+}
+
+// setControllerMemberCommand changes the replica set settings of a
+// controller machine.
+type setControllerMemberCommand struct {
+	modelcmd.ControllerCommandBase
+
+	// newAPIFunc returns the HA client to be used by the command.
+	newAPIFunc func() (SetControllerMemberAPI, error)
+
+	machineId string
+	voting    bool
+	nonVoting bool
+	priority  *float64
+}
+
+const setControllerMemberDoc = `
+set-controller-member changes how a controller machine takes part in
+the controller's database replica set.
+
+A machine set as --non-voting keeps a full copy of the database but
+never has a vote in the election of the replica set's primary, and is
+never elected primary itself. This suits a controller kept in a remote
+region for disaster recovery, whose slow links shouldn't hold up the
+other controllers. The machine keeps its vote until another controller
+can take its place without leaving an even number of votes; use
+enable-ha to add one. enable-ha neither promotes nor removes a
+non-voting controller.
+
+--priority sets the machine's election priority while it votes, from 0
+to 1000. The voting machine with the highest priority is preferred as
+primary, and a machine with priority 0 is never elected primary. The
+default priority is 1.
+
+Use show-controller --ha to see the settings of each controller machine
+and the resulting replica set configuration.
+
+Examples:
+    juju set-controller-member 3 --non-voting
+    juju set-controller-member 3 --voting
+    juju set-controller-member 0 --priority 10
+
+See also:
+    enable-ha
+    show-controller
+`
+
+func (c *setControllerMemberCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "set-controller-member",
+		Args:    "<machine>",
+		Purpose: "Set whether a controller machine votes, and its election priority.",
+		Doc:     setControllerMemberDoc,
+	}
+}
+
+func (c *setControllerMemberCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.BoolVar(&c.voting, "voting", false, "Allow the machine to vote")
+	f.BoolVar(&c.nonVoting, "non-voting", false, "Never give the machine a vote")
+	f.Var(priorityValue{&c.priority}, "priority", "The machine's election priority while voting (0-1000)")
+}
+
+func (c *setControllerMemberCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no controller machine specified")
+	}
+	if !names.IsValidMachine(args[0]) || names.IsContainerMachine(args[0]) {
+		return errors.Errorf("invalid controller machine %q", args[0])
+	}
+	c.machineId = args[0]
+	if c.voting && c.nonVoting {
+		return errors.New("cannot specify both --voting and --non-voting")
+	}
+	if !c.voting && !c.nonVoting && c.priority == nil {
+		return errors.New("no settings specified: use --voting, --non-voting or --priority")
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run changes the controller machine's settings.
+func (c *setControllerMemberCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	if c.priority != nil {
+		if err := api.SetControllerMemberPriority(c.machineId, *c.priority); err != nil {
+			return c.processError(err)
+		}
+		ctx.Infof("Controller machine %s has election priority %v", c.machineId, *c.priority)
+	}
+	if c.voting || c.nonVoting {
+		if err := api.SetControllerMemberVoting(c.machineId, c.voting); err != nil {
+			return c.processError(err)
+		}
+		if c.voting {
+			ctx.Infof("Controller machine %s may vote", c.machineId)
+		} else {
+			ctx.Infof("Controller machine %s will not vote", c.machineId)
+		}
+	}
+	return nil
+}
+
+func (c *setControllerMemberCommand) processError(err error) error {
+	if errors.IsNotSupported(err) {
+		return errors.New("controller does not support controller member settings")
+	}
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
+
+// priorityValue implements gnuflag.Value for an optional election
+// priority.
+type priorityValue struct {
+	priority **float64
+}
+
+func (v priorityValue) Set(s string) error {
+	priority, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return errors.Errorf("invalid priority %q", s)
+	}
+	if priority < 0 || priority > maxMemberPriority {
+		return errors.Errorf("priority must be between 0 and %d", maxMemberPriority)
+	}
+	*v.priority = &priority
+	return nil
+}
+
+func (v priorityValue) String() string {
+	if *v.priority == nil {
+		return ""
+	}
+	return strconv.FormatFloat(**v.priority, 'g', -1, 64)
+}
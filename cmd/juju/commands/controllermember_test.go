@@ -0,0 +1,144 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type SetControllerMemberSuite struct {
+	coretesting.FakeJujuXDGDataHomeSuite
+	api   *fakeControllerMemberAPI
+	store *jujuclienttesting.MemStore
+}
+
+var _ = gc.Suite(&SetControllerMemberSuite{})
+
+func (s *SetControllerMemberSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api = &fakeControllerMemberAPI{}
+
+	s.store = jujuclienttesting.NewMemStore()
+	err := s.store.AddController("ctrl", jujuclient.ControllerDetails{
+		ControllerUUID: "eeeeeeee-0bad-400d-8000-4b1d0d06f00d",
+		CACert:         "somecert",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.SetCurrentController("ctrl")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.UpdateAccount("ctrl", jujuclient.AccountDetails{
+		User: "admin",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *SetControllerMemberSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := &setControllerMemberCommand{}
+	command.newAPIFunc = func() (SetControllerMemberAPI, error) { return s.api, nil }
+	command.SetClientStore(s.store)
+	return coretesting.RunCommand(c, modelcmd.WrapController(command), args...)
+}
+
+func (s *SetControllerMemberSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no controller machine specified",
+	}, {
+		args: []string{"foo", "--voting"},
+		err:  `invalid controller machine "foo"`,
+	}, {
+		args: []string{"1"},
+		err:  "no settings specified: use --voting, --non-voting or --priority",
+	}, {
+		args: []string{"1", "--voting", "--non-voting"},
+		err:  "cannot specify both --voting and --non-voting",
+	}, {
+		args: []string{"1", "--priority", "high"},
+		err:  `invalid value "high" for flag --priority: invalid priority "high"`,
+	}, {
+		args: []string{"1", "--priority", "1001"},
+		err:  `invalid value "1001" for flag --priority: priority must be between 0 and 1000`,
+	}, {
+		args: []string{"1", "2", "--voting"},
+		err:  `unrecognized args: \["2"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.run(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.api.CheckNoCalls(c)
+}
+
+func (s *SetControllerMemberSuite) TestNonVoting(c *gc.C) {
+	ctx, err := s.run(c, "3", "--non-voting")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(coretesting.Stderr(ctx), gc.Equals, "Controller machine 3 will not vote\n")
+	s.api.CheckCalls(c, []jujutesting.StubCall{
+		{"SetControllerMemberVoting", []interface{}{"3", false}},
+		{"Close", nil},
+	})
+}
+
+func (s *SetControllerMemberSuite) TestVotingAndPriority(c *gc.C) {
+	ctx, err := s.run(c, "3", "--voting", "--priority", "2.5")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(coretesting.Stderr(ctx), gc.Equals, ""+
+		"Controller machine 3 has election priority 2.5\n"+
+		"Controller machine 3 may vote\n")
+	s.api.CheckCalls(c, []jujutesting.StubCall{
+		{"SetControllerMemberPriority", []interface{}{"3", 2.5}},
+		{"SetControllerMemberVoting", []interface{}{"3", true}},
+		{"Close", nil},
+	})
+}
+
+func (s *SetControllerMemberSuite) TestError(c *gc.C) {
+	s.api.SetErrors(errors.New("cannot remove the vote from the last voting controller"))
+	_, err := s.run(c, "0", "--non-voting")
+	c.Assert(err, gc.ErrorMatches, "cannot remove the vote from the last voting controller")
+}
+
+func (s *SetControllerMemberSuite) TestNotSupported(c *gc.C) {
+	s.api.SetErrors(errors.NotSupportedf("controller member settings"))
+	_, err := s.run(c, "0", "--priority", "0")
+	c.Assert(err, gc.ErrorMatches, "controller does not support controller member settings")
+}
+
+func (s *SetControllerMemberSuite) TestBlocked(c *gc.C) {
+	s.api.SetErrors(common.OperationBlockedError("TestBlocked"))
+	_, err := s.run(c, "0", "--non-voting")
+	coretesting.AssertOperationWasBlocked(c, err, ".*TestBlocked.*")
+}
+
+type fakeControllerMemberAPI struct {
+	jujutesting.Stub
+}
+
+func (f *fakeControllerMemberAPI) Close() error {
+	f.AddCall("Close")
+	return nil
+}
+
+func (f *fakeControllerMemberAPI) SetControllerMemberVoting(machineId string, voting bool) error {
+	f.AddCall("SetControllerMemberVoting", machineId, voting)
+	return f.NextErr()
+}
+
+func (f *fakeControllerMemberAPI) SetControllerMemberPriority(machineId string, priority float64) error {
+	f.AddCall("SetControllerMemberPriority", machineId, priority)
+	return f.NextErr()
+}
@@ -0,0 +1,157 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/series"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/juju/paths"
+	"github.com/juju/juju/network/ssh"
+)
+
+// hookRecordingsDir returns the directory on the unit's machine in which
+// the unit agent stores recordings of failed hooks. The recordings are
+// read with shell commands, so the machine is expected to run Linux.
+func hookRecordingsDir(unitName string) string {
+	dataDir := paths.MustSucceed(paths.DataDir(series.LatestLts()))
+	return path.Join(agent.Dir(dataDir, names.NewUnitTag(unitName)), "state", "hook-recordings")
+}
+
+func newDownloadHookRecordingCommand(hostChecker ssh.ReachableChecker) cmd.Command {
+	c := new(downloadHookRecordingCommand)
+	c.setHostChecker(hostChecker)
+	return modelcmd.Wrap(c)
+}
+
+// downloadHookRecordingCommand downloads a recording of a failed hook
+// from a unit, so that the hook can be replayed with replay-hook.
+type downloadHookRecordingCommand struct {
+	sshCommand
+	recording string
+	output    string
+	list      bool
+}
+
+const downloadHookRecordingDoc = `
+Downloads a recording of a failed hook from a unit.
+
+When the "record-failed-hooks" model config setting is true, unit agents
+record the context in which any hook fails: its environment, the charm
+config, leader and relation settings, and the responses given to the hook
+tools it ran. A recording can then be replayed locally, against a copy of
+the charm, with "juju replay-hook".
+
+By default the most recent recording is downloaded; a particular recording
+may be named instead. Use --list to see the recordings held by the unit.
+
+Examples:
+    juju model-config record-failed-hooks=true
+    juju download-hook-recording mysql/0 --list
+    juju download-hook-recording mysql/0
+    juju download-hook-recording mysql/0 install-20170301T120000.000000000.yaml -o install.yaml
+
+See also:
+    replay-hook
+    debug-hooks
+`
+
+func (c *downloadHookRecordingCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "download-hook-recording",
+		Args:    "<unit name> [<recording>]",
+		Purpose: "Download a recording of a failed hook from a unit.",
+		Doc:     downloadHookRecordingDoc,
+	}
+}
+
+func (c *downloadHookRecordingCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.sshCommand.SetFlags(f)
+	f.StringVar(&c.output, "o", "", "Filename for the downloaded recording")
+	f.StringVar(&c.output, "output", "", "")
+	f.BoolVar(&c.list, "list", false, "List the recordings held by the unit")
+}
+
+func (c *downloadHookRecordingCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.Errorf("no unit name specified")
+	}
+	c.Target, args = args[0], args[1:]
+	if !names.IsValidUnit(c.Target) {
+		return errors.Errorf("%q is not a valid unit name", c.Target)
+	}
+	if len(args) > 0 {
+		c.recording, args = args[0], args[1:]
+		if c.recording == "" || strings.ContainsAny(c.recording, `/'\`) {
+			return errors.Errorf("invalid recording name %q", c.recording)
+		}
+	}
+	if c.list && (c.recording != "" || c.output != "") {
+		return errors.New("cannot specify a recording or output file with --list")
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// remoteCommand returns the command to run on the unit's machine to list
+// the recordings, or to write the selected recording to stdout.
+func (c *downloadHookRecordingCommand) remoteCommand() string {
+	dir := hookRecordingsDir(c.Target)
+	switch {
+	case c.list:
+		return fmt.Sprintf("sudo ls -1t '%s'", dir)
+	case c.recording != "":
+		return fmt.Sprintf("sudo cat '%s'", path.Join(dir, c.recording))
+	default:
+		return fmt.Sprintf(`sudo sh -c 'cat "$(ls -1t %s/*.yaml | head -n 1)"'`, dir)
+	}
+}
+
+// outputFilename returns the name of the file to write the recording to.
+func (c *downloadHookRecordingCommand) outputFilename() string {
+	if c.output != "" {
+		return c.output
+	}
+	if c.recording != "" {
+		return c.recording
+	}
+	return strings.Replace(c.Target, "/", "-", -1) + "-hook-recording.yaml"
+}
+
+// Run connects to the unit's machine via SSH and lists or downloads
+// the hook recordings held there.
+func (c *downloadHookRecordingCommand) Run(ctx *cmd.Context) error {
+	c.pty = false
+	c.Args = []string{c.remoteCommand()}
+	if c.list {
+		return c.sshCommand.Run(ctx)
+	}
+
+	filename := ctx.AbsPath(c.outputFilename())
+	f, err := os.Create(filename)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	sshCtx := *ctx
+	sshCtx.Stdout = f
+	err = c.sshCommand.Run(&sshCtx)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(filename)
+		return errors.Annotate(err, "cannot download hook recording")
+	}
+	ctx.Infof("downloaded hook recording to %s", filename)
+	return nil
+}
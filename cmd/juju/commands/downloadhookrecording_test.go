@@ -0,0 +1,84 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	coretesting "github.com/juju/juju/testing"
+)
+
+type DownloadHookRecordingSuite struct {
+	coretesting.FakeJujuXDGDataHomeSuite
+}
+
+var _ = gc.Suite(&DownloadHookRecordingSuite{})
+
+func (s *DownloadHookRecordingSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{},
+		err:  `no unit name specified`,
+	}, {
+		args: []string{"mysql"},
+		err:  `"mysql" is not a valid unit name`,
+	}, {
+		args: []string{"mysql/0", "../uniter"},
+		err:  `invalid recording name "../uniter"`,
+	}, {
+		args: []string{"mysql/0", "--list", "install.yaml"},
+		err:  `cannot specify a recording or output file with --list`,
+	}, {
+		args: []string{"mysql/0", "install.yaml", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}, {
+		args: []string{"mysql/0"},
+	}, {
+		args: []string{"mysql/0", "--list"},
+	}, {
+		args: []string{"mysql/0", "install.yaml", "-o", "local.yaml"},
+	}} {
+		c.Logf("test %d: %q", i, test.args)
+		err := coretesting.InitCommand(&downloadHookRecordingCommand{}, test.args)
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
+
+func (s *DownloadHookRecordingSuite) TestRemoteCommand(c *gc.C) {
+	for i, test := range []struct {
+		args    []string
+		command string
+		output  string
+	}{{
+		args:    []string{"mysql/0"},
+		command: `sudo sh -c 'cat "$(ls -1t /var/lib/juju/agents/unit-mysql-0/state/hook-recordings/*.yaml | head -n 1)"'`,
+		output:  "mysql-0-hook-recording.yaml",
+	}, {
+		args:    []string{"mysql/0", "--list"},
+		command: `sudo ls -1t '/var/lib/juju/agents/unit-mysql-0/state/hook-recordings'`,
+		output:  "mysql-0-hook-recording.yaml",
+	}, {
+		args:    []string{"mysql/0", "install.yaml"},
+		command: `sudo cat '/var/lib/juju/agents/unit-mysql-0/state/hook-recordings/install.yaml'`,
+		output:  "install.yaml",
+	}, {
+		args:    []string{"mysql/0", "install.yaml", "--output", "local.yaml"},
+		command: `sudo cat '/var/lib/juju/agents/unit-mysql-0/state/hook-recordings/install.yaml'`,
+		output:  "local.yaml",
+	}} {
+		c.Logf("test %d: %q", i, test.args)
+		command := &downloadHookRecordingCommand{}
+		err := coretesting.InitCommand(command, test.args)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(command.remoteCommand(), gc.Equals, test.command)
+		c.Check(command.outputFilename(), gc.Equals, test.output)
+	}
+}
@@ -0,0 +1,232 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/highavailability"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+func newDrainControllerCommand() cmd.Command {
+	command := &drainControllerCommand{}
+	command.newAPIFunc = command.newDrainAPI
+	command.clock = clock.WallClock
+	command.pollInterval = 2 * time.Second
+	return modelcmd.WrapController(command)
+}
+
+func newUndrainControllerCommand() cmd.Command {
+	command := &undrainControllerCommand{}
+	command.newAPIFunc = command.newDrainAPI
+	return modelcmd.WrapController(command)
+}
+
+// DrainControllerAPI defines the methods on the client api that the
+// drain-controller and undrain-controller commands call.
+type DrainControllerAPI interface {
+	Close() error
+	DrainController(machineId string) error
+	UndrainController(machineId string) error
+	ControllerDrainStatus(machineId string) (params.ControllerDrainStatus, error)
+}
+
+// drainCommandBase holds what drain-controller and undrain-controller
+// have in common.
+type drainCommandBase struct {
+	modelcmd.ControllerCommandBase
+
+	// newAPIFunc returns the HA client to be used by the command.
+	newAPIFunc func() (DrainControllerAPI, error)
+
+	// machineId holds the id of the controller machine to drain
+	// or undrain.
+	machineId string
+}
+
+func (c *drainCommandBase) newDrainAPI() (DrainControllerAPI, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get API connection")
+	}
+	return highavailability.NewClient(root), nil
+}
+
+func (c *drainCommandBase) init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no controller machine specified")
+	}
+	if !names.IsValidMachine(args[0]) || names.IsContainerMachine(args[0]) {
+		return errors.Errorf("invalid controller machine %q", args[0])
+	}
+	c.machineId = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// drainControllerCommand takes a controller machine out of service so
+// that it can be maintained.
+type drainControllerCommand struct {
+	drainCommandBase
+
+	noWait       bool
+	timeout      time.Duration
+	clock        clock.Clock
+	pollInterval time.Duration
+}
+
+const drainControllerDoc = `
+drain-controller moves agents' API connections away from a controller
+machine in a highly available controller, so that the machine can be
+taken down for maintenance without disrupting them.
+
+The draining machine stops accepting logins from agents, and its
+addresses are no longer published to agents, which are told to
+connect to the other controllers instead. Agents still connected to
+it are disconnected shortly afterwards. The machine's database can no
+longer be elected primary of the controller's replica set, and gives
+up its vote when another controller's database is ready to take it. If
+it already holds the primary, the primary steps down so that another
+controller's database takes over.
+
+By default the command waits until no agents remain connected to the
+machine, or until --timeout passes. Use --no-wait to return as soon as
+the drain has started.
+
+At least one other controller machine must remain in service. Return
+the machine to service with undrain-controller.
+
+Examples:
+    juju drain-controller 1
+    juju drain-controller 2 --timeout 20m
+
+See also:
+    enable-ha
+    undrain-controller
+`
+
+func (c *drainControllerCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "drain-controller",
+		Args:    "<machine>",
+		Purpose: "Move agent connections away from a controller machine for maintenance.",
+		Doc:     drainControllerDoc,
+	}
+}
+
+func (c *drainControllerCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.BoolVar(&c.noWait, "no-wait", false, "Return as soon as the drain has started")
+	f.DurationVar(&c.timeout, "timeout", 10*time.Minute, "How long to wait for agents to disconnect")
+}
+
+func (c *drainControllerCommand) Init(args []string) error {
+	if c.timeout <= 0 {
+		return errors.New("--timeout must be positive")
+	}
+	return c.init(args)
+}
+
+// Run drains the controller machine and, unless --no-wait is given,
+// waits for the agents connected to it to move to other controllers.
+func (c *drainControllerCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	if err := api.DrainController(c.machineId); err != nil {
+		if errors.IsNotSupported(err) {
+			return errors.New("controller does not support draining controller machines")
+		}
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	if c.noWait {
+		ctx.Infof("Draining controller machine %s", c.machineId)
+		return nil
+	}
+
+	deadline := c.clock.Now().Add(c.timeout)
+	lastCount := -1
+	for {
+		status, err := api.ControllerDrainStatus(c.machineId)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if !status.Draining {
+			return errors.Errorf("controller machine %s was undrained", c.machineId)
+		}
+		if status.AgentConnections == 0 {
+			break
+		}
+		if status.AgentConnections > 0 && status.AgentConnections != lastCount {
+			ctx.Infof("Waiting for %d agent connection(s) to move to other controllers", status.AgentConnections)
+			lastCount = status.AgentConnections
+		}
+		if !c.clock.Now().Before(deadline) {
+			return errors.Errorf("timed out waiting for agents to leave controller machine %s, which is still draining", c.machineId)
+		}
+		<-c.clock.After(c.pollInterval)
+	}
+	ctx.Infof("Controller machine %s drained", c.machineId)
+	return nil
+}
+
+// undrainControllerCommand returns a drained controller machine to
+// service.
+type undrainControllerCommand struct {
+	drainCommandBase
+}
+
+const undrainControllerDoc = `
+undrain-controller returns a controller machine drained with
+drain-controller to service. Its addresses are published to agents
+again, and it accepts their logins once more.
+
+Examples:
+    juju undrain-controller 1
+
+See also:
+    drain-controller
+`
+
+func (c *undrainControllerCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "undrain-controller",
+		Args:    "<machine>",
+		Purpose: "Return a drained controller machine to service.",
+		Doc:     undrainControllerDoc,
+	}
+}
+
+func (c *undrainControllerCommand) Init(args []string) error {
+	return c.init(args)
+}
+
+// Run undrains the controller machine.
+func (c *undrainControllerCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	if err := api.UndrainController(c.machineId); err != nil {
+		if errors.IsNotSupported(err) {
+			return errors.New("controller does not support draining controller machines")
+		}
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	ctx.Infof("Controller machine %s returned to service", c.machineId)
+	return nil
+}
@@ -0,0 +1,230 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type DrainControllerSuite struct {
+	coretesting.FakeJujuXDGDataHomeSuite
+	api   *fakeDrainAPI
+	store *jujuclienttesting.MemStore
+}
+
+var _ = gc.Suite(&DrainControllerSuite{})
+
+func (s *DrainControllerSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api = &fakeDrainAPI{}
+
+	s.store = jujuclienttesting.NewMemStore()
+	err := s.store.AddController("ctrl", jujuclient.ControllerDetails{
+		ControllerUUID: "eeeeeeee-0bad-400d-8000-4b1d0d06f00d",
+		CACert:         "somecert",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.SetCurrentController("ctrl")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.UpdateAccount("ctrl", jujuclient.AccountDetails{
+		User: "admin",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *DrainControllerSuite) runDrain(c *gc.C, clock *advancingClock, args ...string) (*cmd.Context, error) {
+	command := &drainControllerCommand{
+		clock:        clock,
+		pollInterval: time.Second,
+	}
+	command.newAPIFunc = func() (DrainControllerAPI, error) { return s.api, nil }
+	command.SetClientStore(s.store)
+	return coretesting.RunCommand(c, modelcmd.WrapController(command), args...)
+}
+
+func (s *DrainControllerSuite) runUndrain(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := &undrainControllerCommand{}
+	command.newAPIFunc = func() (DrainControllerAPI, error) { return s.api, nil }
+	command.SetClientStore(s.store)
+	return coretesting.RunCommand(c, modelcmd.WrapController(command), args...)
+}
+
+func (s *DrainControllerSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no controller machine specified",
+	}, {
+		args: []string{"foo"},
+		err:  `invalid controller machine "foo"`,
+	}, {
+		args: []string{"0/lxd/1"},
+		err:  `invalid controller machine "0/lxd/1"`,
+	}, {
+		args: []string{"1", "2"},
+		err:  `unrecognized args: \["2"\]`,
+	}, {
+		args: []string{"1", "--timeout", "0s"},
+		err:  "--timeout must be positive",
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.runDrain(c, newAdvancingClock(0), test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.api.CheckNoCalls(c)
+}
+
+func (s *DrainControllerSuite) TestDrainWaits(c *gc.C) {
+	s.api.statuses = []params.ControllerDrainStatus{
+		{Draining: true, AgentConnections: -1},
+		{Draining: true, AgentConnections: 3},
+		{Draining: true, AgentConnections: 3},
+		{Draining: true, AgentConnections: 1},
+		{Draining: true, AgentConnections: 0},
+	}
+	ctx, err := s.runDrain(c, newAdvancingClock(0), "1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(coretesting.Stderr(ctx), gc.Equals, ""+
+		"Waiting for 3 agent connection(s) to move to other controllers\n"+
+		"Waiting for 1 agent connection(s) to move to other controllers\n"+
+		"Controller machine 1 drained\n")
+	s.api.CheckCallNames(c,
+		"DrainController",
+		"ControllerDrainStatus",
+		"ControllerDrainStatus",
+		"ControllerDrainStatus",
+		"ControllerDrainStatus",
+		"ControllerDrainStatus",
+		"Close",
+	)
+	s.api.CheckCall(c, 0, "DrainController", "1")
+}
+
+func (s *DrainControllerSuite) TestDrainNoWait(c *gc.C) {
+	ctx, err := s.runDrain(c, newAdvancingClock(0), "1", "--no-wait")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(coretesting.Stderr(ctx), gc.Equals, "Draining controller machine 1\n")
+	s.api.CheckCallNames(c, "DrainController", "Close")
+}
+
+func (s *DrainControllerSuite) TestDrainTimeout(c *gc.C) {
+	s.api.statuses = []params.ControllerDrainStatus{
+		{Draining: true, AgentConnections: 2},
+		{Draining: true, AgentConnections: 2},
+		{Draining: true, AgentConnections: 2},
+	}
+	_, err := s.runDrain(c, newAdvancingClock(time.Minute), "1", "--timeout", "2m")
+	c.Assert(err, gc.ErrorMatches, "timed out waiting for agents to leave controller machine 1, which is still draining")
+	s.api.CheckCallNames(c,
+		"DrainController",
+		"ControllerDrainStatus",
+		"ControllerDrainStatus",
+		"Close",
+	)
+}
+
+func (s *DrainControllerSuite) TestDrainUndrainedWhileWaiting(c *gc.C) {
+	s.api.statuses = []params.ControllerDrainStatus{
+		{Draining: true, AgentConnections: 2},
+		{Draining: false},
+	}
+	_, err := s.runDrain(c, newAdvancingClock(0), "1")
+	c.Assert(err, gc.ErrorMatches, "controller machine 1 was undrained")
+}
+
+func (s *DrainControllerSuite) TestDrainError(c *gc.C) {
+	s.api.SetErrors(errors.New("cannot drain the only available controller"))
+	_, err := s.runDrain(c, newAdvancingClock(0), "1")
+	c.Assert(err, gc.ErrorMatches, "cannot drain the only available controller")
+}
+
+func (s *DrainControllerSuite) TestDrainNotSupported(c *gc.C) {
+	s.api.SetErrors(errors.NotSupportedf("draining controllers"))
+	_, err := s.runDrain(c, newAdvancingClock(0), "1")
+	c.Assert(err, gc.ErrorMatches, "controller does not support draining controller machines")
+}
+
+func (s *DrainControllerSuite) TestBlockDrain(c *gc.C) {
+	s.api.SetErrors(common.OperationBlockedError("TestBlockDrain"))
+	_, err := s.runDrain(c, newAdvancingClock(0), "1")
+	coretesting.AssertOperationWasBlocked(c, err, ".*TestBlockDrain.*")
+}
+
+func (s *DrainControllerSuite) TestUndrain(c *gc.C) {
+	ctx, err := s.runUndrain(c, "1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(coretesting.Stderr(ctx), gc.Equals, "Controller machine 1 returned to service\n")
+	s.api.CheckCalls(c, []jujutesting.StubCall{
+		{"UndrainController", []interface{}{"1"}},
+		{"Close", nil},
+	})
+}
+
+func (s *DrainControllerSuite) TestUndrainError(c *gc.C) {
+	s.api.SetErrors(errors.New("boom"))
+	_, err := s.runUndrain(c, "1")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type fakeDrainAPI struct {
+	jujutesting.Stub
+	statuses []params.ControllerDrainStatus
+}
+
+func (f *fakeDrainAPI) Close() error {
+	f.AddCall("Close")
+	return nil
+}
+
+func (f *fakeDrainAPI) DrainController(machineId string) error {
+	f.AddCall("DrainController", machineId)
+	return f.NextErr()
+}
+
+func (f *fakeDrainAPI) UndrainController(machineId string) error {
+	f.AddCall("UndrainController", machineId)
+	return f.NextErr()
+}
+
+func (f *fakeDrainAPI) ControllerDrainStatus(machineId string) (params.ControllerDrainStatus, error) {
+	f.AddCall("ControllerDrainStatus", machineId)
+	if len(f.statuses) == 0 {
+		return params.ControllerDrainStatus{}, errors.New("no more statuses")
+	}
+	status := f.statuses[0]
+	f.statuses = f.statuses[1:]
+	return status, f.NextErr()
+}
+
+// advancingClock moves on by a fixed step each time it is asked the
+// time, and never makes its callers wait.
+type advancingClock struct {
+	fakeWatchClock
+	step time.Duration
+}
+
+func newAdvancingClock(step time.Duration) *advancingClock {
+	return &advancingClock{fakeWatchClock{watchStart}, step}
+}
+
+func (c *advancingClock) Now() time.Time {
+	now := c.now
+	c.now = c.now.Add(c.step)
+	return now
+}
@@ -0,0 +1,143 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/worker/uniter/runner/dryrun"
+)
+
+func newDryRunHooksCommand() cmd.Command {
+	return &dryRunHooksCommand{
+		runDryRun: dryrun.Run,
+	}
+}
+
+// dryRunHooksCommand runs a local charm's hooks against a simulated model.
+type dryRunHooksCommand struct {
+	cmd.CommandBase
+	out       cmd.Output
+	runDryRun func(dryrun.Params) (*dryrun.Report, error)
+
+	scenarioPath string
+	charmDir     string
+	jujudPath    string
+}
+
+const dryRunHooksDoc = `
+Runs a local charm's hooks against a simulated model, without deploying it.
+
+The simulated model is described by a YAML scenario file, which names the
+unit running the hooks and may give its leadership, charm config, leader
+settings, addresses and relations. Each relation names the charm endpoint it
+is on, the unit's own settings and the settings of each remote unit:
+
+    unit: wordpress/0
+    leader: true
+    config:
+      title: My Blog
+    relations:
+    - endpoint: db
+      units:
+        mysql/0: {host: 10.0.0.2, user: admin}
+
+By default the hooks run when a unit is deployed and its relations are
+established are run in turn: install, leader-elected (or, for a unit that
+is not the leader, leader-settings-changed), config-changed and start,
+followed by relation-joined and relation-changed for each remote unit of
+each relation. A "hooks" list in the scenario selects the hooks to run
+instead.
+
+The hooks run from a temporary copy of the charm, and their hook tools are
+answered by the simulated model, which they may change as a deployed unit
+would. Each hook runs in its own Linux user, mount, network and process
+namespaces, with no access to the network, with the host's filesystems
+read-only apart from the temporary directory holding the charm, and with
+none of the invoking shell's environment. Where hooks cannot be run that
+way, as on other platforms, when run as root or when the kernel does not
+allow unprivileged users to create namespaces, no hooks are run, the
+report only lists the hooks that would have run and why they did not, and
+the command fails.
+
+The hooks' output is written to stderr. The report, written to stdout,
+lists each hook with the hook tool calls it made and any failure, and the
+state of the simulated unit once all the hooks have run. The command fails
+if any hook fails.
+
+The hook tools are provided by the jujud executable, which is looked for
+alongside the juju executable and then in the PATH unless specified with
+--jujud.
+
+Examples:
+    juju dry-run-hooks scenario.yaml --charm-dir ~/charms/wordpress
+    juju dry-run-hooks scenario.yaml --format json > report.json
+
+See also:
+    replay-hook
+`
+
+func (c *dryRunHooksCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "dry-run-hooks",
+		Args:    "<scenario>",
+		Purpose: "Run a local charm's hooks against a simulated model.",
+		Doc:     dryRunHooksDoc,
+	}
+}
+
+func (c *dryRunHooksCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+	f.StringVar(&c.charmDir, "charm-dir", ".", "The directory holding the charm")
+	f.StringVar(&c.jujudPath, "jujud", "", "The path to the jujud executable")
+}
+
+func (c *dryRunHooksCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no scenario specified")
+	}
+	c.scenarioPath, args = args[0], args[1:]
+	return cmd.CheckEmpty(args)
+}
+
+func (c *dryRunHooksCommand) Run(ctx *cmd.Context) error {
+	scenario, err := dryrun.ReadScenario(ctx.AbsPath(c.scenarioPath))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	jujudPath := c.jujudPath
+	if jujudPath == "" {
+		if jujudPath, err = findJujud(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	report, err := c.runDryRun(dryrun.Params{
+		Scenario:  scenario,
+		CharmDir:  ctx.AbsPath(c.charmDir),
+		JujudPath: jujudPath,
+		Stdout:    ctx.Stderr,
+		Stderr:    ctx.Stderr,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := c.out.Write(ctx, report); err != nil {
+		return errors.Trace(err)
+	}
+	if report.NotRun != "" {
+		return errors.Errorf("no hooks were run: %s", report.NotRun)
+	}
+	if failed := report.Failed(); len(failed) > 0 {
+		return errors.Errorf("hooks failed: %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
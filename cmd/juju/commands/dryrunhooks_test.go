@@ -0,0 +1,122 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/runner/dryrun"
+	"github.com/juju/juju/worker/uniter/runner/replay"
+)
+
+type DryRunHooksSuite struct {
+	coretesting.FakeJujuXDGDataHomeSuite
+	scenarioPath string
+	params       []dryrun.Params
+	report       *dryrun.Report
+}
+
+var _ = gc.Suite(&DryRunHooksSuite{})
+
+func (s *DryRunHooksSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.params = nil
+	s.report = &dryrun.Report{
+		Hooks: []dryrun.HookResult{{
+			Hook: "install",
+			ToolCalls: []replay.ToolCall{{
+				Command: "status-set",
+				Args:    []string{"active"},
+			}},
+		}, {
+			Hook:    "start",
+			Skipped: true,
+		}},
+		State: dryrun.State{
+			UnitStatus:        "active",
+			ApplicationStatus: "unknown",
+		},
+	}
+	s.scenarioPath = filepath.Join(c.MkDir(), "scenario.yaml")
+	err := ioutil.WriteFile(s.scenarioPath, []byte("unit: wordpress/0\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *DryRunHooksSuite) newCommand() *dryRunHooksCommand {
+	return &dryRunHooksCommand{
+		runDryRun: func(p dryrun.Params) (*dryrun.Report, error) {
+			s.params = append(s.params, p)
+			return s.report, nil
+		},
+	}
+}
+
+func (s *DryRunHooksSuite) TestInit(c *gc.C) {
+	err := coretesting.InitCommand(s.newCommand(), nil)
+	c.Assert(err, gc.ErrorMatches, "no scenario specified")
+	err = coretesting.InitCommand(s.newCommand(), []string{"a.yaml", "b.yaml"})
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["b.yaml"\]`)
+}
+
+func (s *DryRunHooksSuite) TestRun(c *gc.C) {
+	charmDir := c.MkDir()
+	ctx, err := coretesting.RunCommand(c, s.newCommand(),
+		s.scenarioPath, "--charm-dir", charmDir, "--jujud", "/path/to/jujud",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.params, gc.HasLen, 1)
+	c.Assert(s.params[0].Scenario, jc.DeepEquals, &dryrun.Scenario{Unit: "wordpress/0"})
+	c.Assert(s.params[0].CharmDir, gc.Equals, charmDir)
+	c.Assert(s.params[0].JujudPath, gc.Equals, "/path/to/jujud")
+	c.Assert(coretesting.Stdout(ctx), gc.Equals, `
+hooks:
+- hook: install
+  tool-calls:
+  - command: status-set
+    args:
+    - active
+    code: 0
+- hook: start
+  skipped: true
+state:
+  unit-status: active
+  application-status: unknown
+`[1:])
+}
+
+func (s *DryRunHooksSuite) TestRunHookFails(c *gc.C) {
+	s.report.Hooks[0].Error = "exit status 1"
+	_, err := coretesting.RunCommand(c, s.newCommand(), s.scenarioPath, "--format", "json", "--jujud", "/path/to/jujud")
+	c.Assert(err, gc.ErrorMatches, "hooks failed: install")
+}
+
+func (s *DryRunHooksSuite) TestRunBadScenario(c *gc.C) {
+	err := ioutil.WriteFile(s.scenarioPath, []byte("unit: wordpress\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = coretesting.RunCommand(c, s.newCommand(), s.scenarioPath, "--jujud", "/path/to/jujud")
+	c.Assert(err, gc.ErrorMatches, `unit name "wordpress" not valid`)
+	c.Assert(s.params, gc.HasLen, 0)
+}
+
+func (s *DryRunHooksSuite) TestRunNotRun(c *gc.C) {
+	s.report.Hooks[0].ToolCalls = nil
+	s.report.NotRun = "hooks are not run as root"
+	ctx, err := coretesting.RunCommand(c, s.newCommand(), s.scenarioPath, "--jujud", "/path/to/jujud")
+	c.Assert(err, gc.ErrorMatches, "no hooks were run: hooks are not run as root")
+	c.Assert(coretesting.Stdout(ctx), gc.Equals, `
+hooks:
+- hook: install
+- hook: start
+  skipped: true
+state:
+  unit-status: active
+  application-status: unknown
+not-run: hooks are not run as root
+`[1:])
+}
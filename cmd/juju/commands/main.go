@@ -275,6 +275,9 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(newResolvedCommand())
 	r.Register(newDebugLogCommand())
 	r.Register(newDebugHooksCommand(nil))
+	r.Register(newDownloadHookRecordingCommand(nil))
+	r.Register(newReplayHookCommand())
+	r.Register(newDryRunHooksCommand())
 
 	// Configuration commands.
 	r.Register(model.NewModelGetConstraintsCommand())
@@ -334,6 +337,9 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(model.NewShowCommand())
 
 	r.Register(newMigrateCommand())
+	r.Register(model.NewExportCommand())
+	r.Register(model.NewImportCommand())
+	r.Register(model.NewRestoreModelCommand())
 	if featureflag.Enabled(feature.DeveloperMode) {
 		r.Register(model.NewDumpCommand())
 		r.Register(model.NewDumpDBCommand())
@@ -347,6 +353,9 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 
 	// Manage controller availability
 	r.Register(newEnableHACommand())
+	r.Register(newDrainControllerCommand())
+	r.Register(newUndrainControllerCommand())
+	r.Register(newSetControllerMemberCommand())
 
 	// Manage and control services
 	r.Register(application.NewAddUnitCommand())
@@ -356,6 +365,8 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(application.NewUnexposeCommand())
 	r.Register(application.NewServiceGetConstraintsCommand())
 	r.Register(application.NewServiceSetConstraintsCommand())
+	r.Register(application.NewHookTimeoutsCommand())
+	r.Register(application.NewSetEgressCommand())
 
 	// Operation protection commands
 	r.Register(block.NewDisableCommand())
@@ -364,6 +375,14 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 
 	// Manage storage
 	r.Register(storage.NewAddCommand())
+	r.Register(storage.NewAttachStorageCommand())
+	r.Register(storage.NewDetachStorageCommand())
+	r.Register(storage.NewImportVolumeCommand())
+	r.Register(storage.NewImportFilesystemCommand())
+	r.Register(storage.NewCreateSnapshotCommand())
+	r.Register(storage.NewListSnapshotsCommand())
+	r.Register(storage.NewRemoveSnapshotCommand())
+	r.Register(storage.NewResizeStorageCommand())
 	r.Register(storage.NewListCommand())
 	r.Register(storage.NewPoolCreateCommand())
 	r.Register(storage.NewPoolListCommand())
@@ -397,6 +416,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(controller.NewEnableDestroyControllerCommand())
 	r.Register(controller.NewShowControllerCommand())
 	r.Register(controller.NewGetConfigCommand())
+	r.Register(controller.NewControllerHealthCommand())
 
 	// Debug Metrics
 	r.Register(metricsdebug.New())
@@ -402,6 +402,7 @@ var commandNames = []string{
 	"agree",
 	"agreements",
 	"allocate",
+	"attach-storage",
 	"autoload-credentials",
 	"backups",
 	"bootstrap",
@@ -416,22 +417,29 @@ var commandNames = []string{
 	"create-backup",
 	"create-budget",
 	"create-storage-pool",
+	"create-storage-snapshot",
 	"credentials",
 	"controller-config",
+	"controller-health",
 	"debug-hooks",
 	"debug-log",
 	"remove-user",
 	"deploy",
 	"destroy-controller",
 	"destroy-model",
+	"detach-storage",
 	"disable-command",
 	"disable-user",
 	"disabled-commands",
 	"download-backup",
+	"download-hook-recording",
+	"drain-controller",
+	"dry-run-hooks",
 	"enable-ha",
 	"enable-command",
 	"enable-destroy-controller",
 	"enable-user",
+	"export-model",
 	"expose",
 	"get-constraints",
 	"get-model-constraints",
@@ -439,7 +447,11 @@ var commandNames = []string{
 	"gui",
 	"help",
 	"help-tool",
+	"hook-timeouts",
+	"import-filesystem",
+	"import-model",
 	"import-ssh-key",
+	"import-volume",
 	"kill-controller",
 	"list-actions",
 	"list-agreements",
@@ -458,6 +470,7 @@ var commandNames = []string{
 	"list-spaces",
 	"list-storage",
 	"list-storage-pools",
+	"list-storage-snapshots",
 	"list-subnets",
 	"list-users",
 	"login",
@@ -480,9 +493,13 @@ var commandNames = []string{
 	"remove-machine",
 	"remove-relation",
 	"remove-ssh-key",
+	"remove-storage-snapshot",
 	"remove-unit",
+	"replay-hook",
+	"resize-storage",
 	"resolved",
 	"restore-backup",
+	"restore-model",
 	"retry-provisioning",
 	"revoke",
 	"run",
@@ -490,8 +507,10 @@ var commandNames = []string{
 	"scp",
 	"set-budget",
 	"set-constraints",
+	"set-controller-member",
 	"set-default-credential",
 	"set-default-region",
+	"set-egress",
 	"set-meter-status",
 	"set-model-constraints",
 	"set-plan",
@@ -513,9 +532,11 @@ var commandNames = []string{
 	"status",
 	"storage",
 	"storage-pools",
+	"storage-snapshots",
 	"subnets",
 	"switch",
 	"sync-tools",
+	"undrain-controller",
 	"unexpose",
 	"update-allocation",
 	"upload-backup",
@@ -4,23 +4,35 @@
 package commands
 
 import (
+	"fmt"
+	"io"
 	"strings"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
 	"gopkg.in/macaroon-bakery.v1/httpbakery"
 	"gopkg.in/macaroon.v1"
 
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/controller"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/jujuclient"
 )
 
 func newMigrateCommand() cmd.Command {
 	var cmd migrateCommand
 	cmd.newAPIRoot = cmd.JujuCommandBase.NewAPIRoot
+	cmd.clock = clock.WallClock
+	cmd.watchInterval = 2 * time.Second
 	return modelcmd.WrapController(&cmd)
 }
 
@@ -30,12 +42,35 @@ type migrateCommand struct {
 	newAPIRoot       func(jujuclient.ClientStore, string, string) (api.Connection, error)
 	api              migrateAPI
 	model            string
+	models           []string
+	owner            string
+	cloud            string
 	targetController string
+	targetCloud      string
+	targetRegion     string
+	targetCredential string
+	dryRun           bool
+	watch            bool
+	out              cmd.Output
+
+	// modelInfoAPI is used to select models by cloud and, along
+	// with clock and watchInterval, to follow the progress of
+	// migrations with --watch.
+	modelInfoAPI  modelInfoAPI
+	clock         clock.Clock
+	watchInterval time.Duration
 }
 
 type migrateAPI interface {
 	AllModels() ([]base.UserModel, error)
 	InitiateMigration(spec controller.MigrationSpec) (string, error)
+	InitiateMigrations(specs []controller.MigrationSpec) ([]controller.InitiateMigrationResult, error)
+	MigrationPrechecks(spec controller.MigrationSpec) (coremigration.PrecheckReport, error)
+}
+
+type modelInfoAPI interface {
+	ModelInfo([]names.ModelTag) ([]params.ModelInfoResult, error)
+	Close() error
 }
 
 const migrateDoc = `
@@ -59,11 +94,53 @@ for details of how to do this.
 
 This command only starts a model migration - it does not wait for its
 completion. The progress of a migration can be tracked using the
-"status" command and by consulting the logs.
+"show-model" command and by consulting the logs. Alternatively, use
+--watch to wait for the migration to finish, printing its progress as
+it goes: the current phase and how long it has taken, how much of the
+model's charms, tools and resources have been sent to the target
+controller along with an estimate of how long the rest will take, and
+how many of the model's agents have reported back.
+
+When the target controller knows the model's cloud by a different
+name, use --target-cloud and --target-region to say which of its
+clouds and regions the model lives in. --target-credential names a
+credential for that cloud, already added to the target controller,
+that the model should use from then on, and is required if the model
+uses a credential. The credential is given as "name" or "owner/name";
+the owner defaults to the user logged in to the target controller.
+The model's machines must still be visible using the new cloud details
+or the migration will be aborted before the model is transferred.
+
+Several models may be migrated at once, either by naming each of them
+or by selecting every model owned by a user with --owner, or every
+model on a cloud with --cloud. The migrations are queued on the
+controller, which runs no more of them at a time than its
+max-concurrent-migrations setting allows. A model which can't be
+migrated doesn't stop the others, and a summary of every migration is
+reported at the end. With --watch, the command waits for all of the
+migrations to finish before reporting.
+
+With --dry-run, no migration is started. Instead every check made on
+the source and target controllers before a migration is run, and all
+of the problems found are reported together. The command fails if any
+problems are found. With --format yaml or json, a report is always
+written, with "ok" set when the model can be migrated.
+
+Examples:
+
+    juju migrate mymodel othercontroller
+    juju migrate --watch mymodel othercontroller
+    juju migrate --dry-run mymodel othercontroller
+    juju migrate --dry-run --format yaml mymodel othercontroller
+    juju migrate --target-cloud aws-east --target-credential bob/prod mymodel othercontroller
+    juju migrate model1 model2 model3 othercontroller
+    juju migrate --owner bob --watch othercontroller
+    juju migrate --cloud aws othercontroller
 
 See also:
     login
     controllers
+    show-model
     status
 `
 
@@ -71,29 +148,78 @@ See also:
 func (c *migrateCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "migrate",
-		Args:    "<model-name> <target-controller-name>",
+		Args:    "<model-name> [<model-name>...] <target-controller-name>",
 		Purpose: "Migrate a hosted model to another controller.",
 		Doc:     migrateDoc,
 	}
 }
 
+// SetFlags implements cmd.Command.
+func (c *migrateCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.BoolVar(&c.dryRun, "dry-run", false, "Report problems that would prevent the migration without starting it")
+	f.BoolVar(&c.watch, "watch", false, "Wait for the migration to finish, reporting its progress")
+	f.StringVar(&c.targetCloud, "target-cloud", "", "Cloud on the target controller to host the model")
+	f.StringVar(&c.targetRegion, "target-region", "", "Region of the target cloud to host the model")
+	f.StringVar(&c.targetCredential, "target-credential", "", "Credential on the target controller for the model to use")
+	f.StringVar(&c.owner, "owner", "", "Migrate every model owned by this user")
+	f.StringVar(&c.cloud, "cloud", "", "Migrate every model on this cloud")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatMigrateTabular,
+	})
+}
+
 // Init implements cmd.Command.
 func (c *migrateCommand) Init(args []string) error {
-	if len(args) < 1 {
-		return errors.New("model not specified")
+	if c.selectsModels() {
+		switch len(args) {
+		case 0:
+			return errors.New("target controller not specified")
+		case 1:
+		default:
+			return errors.New("model names can't be combined with --owner or --cloud")
+		}
+	} else {
+		if len(args) < 1 {
+			return errors.New("model not specified")
+		}
+		if len(args) < 2 {
+			return errors.New("target controller not specified")
+		}
+		c.models = args[:len(args)-1]
+		c.model = c.models[0]
 	}
-	if len(args) < 2 {
-		return errors.New("target controller not specified")
+
+	if c.targetRegion != "" && c.targetCloud == "" {
+		return errors.New("--target-region requires --target-cloud")
+	}
+	if c.targetCredential != "" && c.targetCloud == "" {
+		return errors.New("--target-credential requires --target-cloud")
+	}
+	if c.watch && c.dryRun {
+		return errors.New("--watch cannot be used with --dry-run")
 	}
-	if len(args) > 2 {
-		return errors.New("too many arguments specified")
+	if c.dryRun && c.isBatch() {
+		return errors.New("--dry-run can only be used with a single model")
 	}
 
-	c.model = args[0]
-	c.targetController = args[1]
+	c.targetController = args[len(args)-1]
 	return nil
 }
 
+// selectsModels returns true if the models to migrate are chosen with
+// --owner or --cloud rather than by name.
+func (c *migrateCommand) selectsModels() bool {
+	return c.owner != "" || c.cloud != ""
+}
+
+// isBatch returns true if more than one model may be migrated.
+func (c *migrateCommand) isBatch() bool {
+	return c.selectsModels() || len(c.models) > 1
+}
+
 func (c *migrateCommand) getMigrationSpec() (*controller.MigrationSpec, error) {
 	store := c.ClientStore()
 
@@ -123,9 +249,26 @@ func (c *migrateCommand) getMigrationSpec() (*controller.MigrationSpec, error) {
 		TargetUser:           accountInfo.User,
 		TargetPassword:       accountInfo.Password,
 		TargetMacaroons:      macs,
+		TargetCloud:          c.targetCloud,
+		TargetCloudRegion:    c.targetRegion,
+		TargetCredential:     c.targetCredentialId(accountInfo.User),
 	}, nil
 }
 
+// targetCredentialId returns the ID of the credential given with
+// --target-credential, qualified with the target cloud and, if the
+// credential was given without one, the owner.
+func (c *migrateCommand) targetCredentialId(user string) string {
+	if c.targetCredential == "" {
+		return ""
+	}
+	owner, name := user, c.targetCredential
+	if i := strings.Index(name, "/"); i >= 0 {
+		owner, name = name[:i], name[i+1:]
+	}
+	return c.targetCloud + "/" + owner + "/" + name
+}
+
 // Run implements cmd.Command.
 func (c *migrateCommand) Run(ctx *cmd.Context) error {
 	spec, err := c.getMigrationSpec()
@@ -136,28 +279,278 @@ func (c *migrateCommand) Run(ctx *cmd.Context) error {
 	if err != nil {
 		return err
 	}
+	if c.isBatch() {
+		return c.runBatch(ctx, api, spec)
+	}
 	spec.ModelUUID, err = c.findModelUUID(ctx, api)
 	if err != nil {
 		return err
 	}
+	if c.dryRun {
+		return c.runPrechecks(ctx, api, spec)
+	}
 	id, err := api.InitiateMigration(*spec)
-	if err != nil {
+	if errors.IsNotSupported(err) && c.targetCloud != "" {
+		return errors.New("controller does not support remapping the model's cloud")
+	} else if err != nil {
 		return err
 	}
 	ctx.Infof("Migration started with ID %q", id)
+	if c.watch {
+		return c.watchMigration(ctx, spec.ModelUUID)
+	}
+	return nil
+}
+
+// watchMigration follows the progress of the model's migration until
+// it completes or is aborted.
+func (c *migrateCommand) watchMigration(ctx *cmd.Context, modelUUID string) error {
+	api, err := c.getModelInfoAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	watch := &migrationWatch{modelUUID: modelUUID}
+	if err := c.pollMigrations(ctx, api, []*migrationWatch{watch}); err != nil {
+		return errors.Trace(err)
+	}
+	if watch.aborted {
+		return errors.Errorf("migration aborted: %s", watch.message)
+	}
+	ctx.Infof("Model %q migrated to %q", c.model, c.targetController)
 	return nil
 }
 
+// migrationWatch tracks the progress of one model's migration.
+type migrationWatch struct {
+	modelUUID string
+
+	// name is used to tell the progress reports of the models
+	// apart when more than one migration is being watched.
+	name string
+
+	lastReport string
+	finished   bool
+	aborted    bool
+	message    string
+}
+
+// update records the model's latest migration status, returning a
+// report of its progress if that has changed.
+func (w *migrationWatch) update(result params.ModelInfoResult, now time.Time) (string, error) {
+	if params.IsCodeNotFound(result.Error) {
+		// The model is removed from the source controller once
+		// the migration has succeeded.
+		w.finished = true
+		return "", nil
+	} else if result.Error != nil {
+		return "", errors.Trace(result.Error)
+	}
+	migration := result.Result.Migration
+	if migration == nil {
+		return "", nil
+	}
+	if migration.Phase == coremigration.ABORTDONE.String() {
+		w.finished = true
+		w.aborted = true
+		w.message = migration.Status
+	} else if migration.End != nil {
+		w.finished = true
+	}
+	report := formatMigrationProgress(migration, now)
+	if report == w.lastReport {
+		return "", nil
+	}
+	w.lastReport = report
+	if w.name != "" {
+		report = w.name + ": " + report
+	}
+	return report, nil
+}
+
+// pollMigrations polls the source controller for the status of the
+// models' migrations, reporting any change in their progress, until
+// every migration has completed or been aborted.
+func (c *migrateCommand) pollMigrations(ctx *cmd.Context, api modelInfoAPI, watches []*migrationWatch) error {
+	for {
+		var pending []*migrationWatch
+		var tags []names.ModelTag
+		for _, watch := range watches {
+			if !watch.finished {
+				pending = append(pending, watch)
+				tags = append(tags, names.NewModelTag(watch.modelUUID))
+			}
+		}
+		if len(pending) == 0 {
+			return nil
+		}
+
+		results, err := api.ModelInfo(tags)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if len(results) != len(tags) {
+			return errors.Errorf("expected %d result(s), got %d", len(tags), len(results))
+		}
+		now := c.clock.Now()
+		finished := true
+		for i, result := range results {
+			report, err := pending[i].update(result, now)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if report != "" {
+				ctx.Infof("%s", report)
+			}
+			finished = finished && pending[i].finished
+		}
+		if finished {
+			return nil
+		}
+		<-c.clock.After(c.watchInterval)
+	}
+}
+
+// formatMigrationProgress renders the status of a migration as a
+// single line. When binaries are being uploaded, it includes an
+// estimate of how long the upload will take to finish.
+func formatMigrationProgress(migration *params.ModelMigrationStatus, now time.Time) string {
+	progress := common.MigrationProgressFromParams(migration, now)
+	if progress == nil {
+		// The controller doesn't report structured progress.
+		return migration.Status
+	}
+	parts := []string{progress.Phase}
+	var phaseElapsed time.Duration
+	if n := len(migration.PhaseTimes); n > 0 {
+		phaseElapsed = now.Sub(migration.PhaseTimes[n-1].Start)
+		parts[0] += fmt.Sprintf(" (%s)", progress.Phases[n-1].Elapsed)
+	}
+	if migration.Status != "" {
+		parts = append(parts, migration.Status)
+	}
+	if progress.Binaries != "" {
+		binaries := "binaries " + progress.Binaries
+		if remaining, ok := binariesRemaining(migration.Progress, phaseElapsed); ok {
+			binaries += fmt.Sprintf(", about %s remaining", remaining)
+		}
+		parts = append(parts, binaries)
+	}
+	if progress.MinionReports != "" {
+		parts = append(parts, "agent reports "+progress.MinionReports)
+	}
+	return strings.Join(parts, "; ")
+}
+
+// binariesRemaining estimates how long the binaries upload will take
+// to finish, assuming the rate so far is kept up. The total includes
+// the sizes of the model's tools and resources from the start, but a
+// charm's size is only known once it has been fetched, so the estimate
+// may grow a little while charms are sent.
+func binariesRemaining(progress *params.MigrationProgress, elapsed time.Duration) (time.Duration, bool) {
+	uploaded, total := progress.BinariesUploaded, progress.BinariesTotal
+	if uploaded <= 0 || uploaded >= total || elapsed <= 0 {
+		return 0, false
+	}
+	remaining := time.Duration(float64(elapsed) * float64(total-uploaded) / float64(uploaded))
+	return (remaining / time.Second) * time.Second, true
+}
+
+// runPrechecks runs the migration prechecks without starting a
+// migration and reports any problems found.
+func (c *migrateCommand) runPrechecks(ctx *cmd.Context, api migrateAPI, spec *controller.MigrationSpec) error {
+	report, err := api.MigrationPrechecks(*spec)
+	if errors.IsNotSupported(err) {
+		return errors.New("controller does not support migration dry runs")
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	// The report is written even when there are no problems so that
+	// scripts using a structured format always get a result to parse.
+	if err := c.out.Write(ctx, precheckReportOutput{
+		OK:     report.OK(),
+		Source: precheckProblemsOutput(report.Source),
+		Target: precheckProblemsOutput(report.Target),
+	}); err != nil {
+		return errors.Trace(err)
+	}
+	if report.OK() {
+		ctx.Infof("Model %q can be migrated to %q", c.model, c.targetController)
+		return nil
+	}
+	return errors.Errorf("found %d problem(s) preventing migration", len(report.Source)+len(report.Target))
+}
+
+type precheckReportOutput struct {
+	OK     bool                    `yaml:"ok" json:"ok"`
+	Source []precheckProblemOutput `yaml:"source,omitempty" json:"source,omitempty"`
+	Target []precheckProblemOutput `yaml:"target,omitempty" json:"target,omitempty"`
+}
+
+type precheckProblemOutput struct {
+	Entity  string `yaml:"entity" json:"entity"`
+	Message string `yaml:"message" json:"message"`
+}
+
+func precheckProblemsOutput(problems []coremigration.PrecheckProblem) []precheckProblemOutput {
+	var out []precheckProblemOutput
+	for _, problem := range problems {
+		out = append(out, precheckProblemOutput{
+			Entity:  problem.Entity,
+			Message: problem.Message,
+		})
+	}
+	return out
+}
+
+func formatMigrateTabular(writer io.Writer, value interface{}) error {
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	switch value := value.(type) {
+	case precheckReportOutput:
+		if value.OK {
+			// Success is reported on stderr.
+			return nil
+		}
+		w.Println("Controller", "Entity", "Problem")
+		for _, problem := range value.Source {
+			w.Println("source", problem.Entity, problem.Message)
+		}
+		for _, problem := range value.Target {
+			w.Println("target", problem.Entity, problem.Message)
+		}
+	case []batchMigrationOutput:
+		w.Println("Model", "Status", "Message")
+		for _, migration := range value {
+			w.Println(migration.Model, migration.Status, migration.Message)
+		}
+	default:
+		return errors.Errorf("unexpected value of type %T", value)
+	}
+	return tw.Flush()
+}
+
 func (c *migrateCommand) findModelUUID(ctx *cmd.Context, api migrateAPI) (string, error) {
 	models, err := api.AllModels()
 	if err != nil {
 		return "", errors.Trace(err)
 	}
+	model, err := matchModel(ctx, models, c.model)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return model.UUID, nil
+}
+
+// matchModel returns the model with the given name, which may be
+// qualified with its owner.
+func matchModel(ctx *cmd.Context, models []base.UserModel, modelName string) (base.UserModel, error) {
 	// Look for the uuid based on name. If the model name doesn't container a
 	// slash, then only accept the model name if there exists only one model
 	// with that name.
 	owner := ""
-	name := c.model
+	name := modelName
 	if strings.Contains(name, "/") {
 		values := strings.SplitN(name, "/", 2)
 		owner = values[0]
@@ -171,15 +564,15 @@ func (c *migrateCommand) findModelUUID(ctx *cmd.Context, api migrateAPI) (string
 	}
 	switch len(matches) {
 	case 0:
-		return "", errors.NotFoundf("model matching %q", c.model)
+		return base.UserModel{}, errors.NotFoundf("model matching %q", modelName)
 	case 1:
-		return matches[0].UUID, nil
+		return matches[0], nil
 	default:
 		ctx.Infof("Multiple potential matches found, please specify owner to disambiguate:")
 		for _, match := range matches {
 			ctx.Infof("  %s/%s", match.Owner, match.Name)
 		}
-		return "", errors.New("multiple models match name")
+		return base.UserModel{}, errors.New("multiple models match name")
 	}
 }
 
@@ -190,6 +583,13 @@ func (c *migrateCommand) getAPI() (migrateAPI, error) {
 	return c.NewControllerAPIClient()
 }
 
+func (c *migrateCommand) getModelInfoAPI() (modelInfoAPI, error) {
+	if c.modelInfoAPI != nil {
+		return c.modelInfoAPI, nil
+	}
+	return c.NewModelManagerAPIClient()
+}
+
 func (c *migrateCommand) getTargetControllerMacaroons() ([]macaroon.Slice, error) {
 	apiContext, err := c.APIContext()
 	if err != nil {
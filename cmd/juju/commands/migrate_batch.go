@@ -0,0 +1,210 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/controller"
+	"github.com/juju/juju/environs/bootstrap"
+)
+
+const (
+	batchStarted  = "started"
+	batchFailed   = "failed"
+	batchMigrated = "migrated"
+	batchAborted  = "aborted"
+)
+
+// batchMigrationOutput describes the outcome of one of the migrations
+// started by a batch migration.
+type batchMigrationOutput struct {
+	Model       string `yaml:"model" json:"model"`
+	MigrationId string `yaml:"migration-id,omitempty" json:"migration-id,omitempty"`
+	Status      string `yaml:"status" json:"status"`
+	Message     string `yaml:"message,omitempty" json:"message,omitempty"`
+}
+
+// runBatch starts a queued migration for each of the selected models,
+// carrying on past any which fail, and reports the outcome of each.
+func (c *migrateCommand) runBatch(ctx *cmd.Context, api migrateAPI, template *controller.MigrationSpec) error {
+	models, failed, err := c.selectBatchModels(ctx, api)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(models) == 0 && len(failed) == 0 {
+		return errors.New("no models selected for migration")
+	}
+
+	var summary []batchMigrationOutput
+	if len(models) > 0 {
+		specs := make([]controller.MigrationSpec, len(models))
+		for i, model := range models {
+			specs[i] = *template
+			specs[i].ModelUUID = model.UUID
+			specs[i].Queue = true
+		}
+		results, err := api.InitiateMigrations(specs)
+		if errors.IsNotSupported(err) {
+			return errors.New("controller does not support migrating models in batches")
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+
+		var watches []*migrationWatch
+		for i, result := range results {
+			out := batchMigrationOutput{
+				Model:       batchModelName(models[i]),
+				MigrationId: result.MigrationId,
+				Status:      batchStarted,
+			}
+			if result.Error != nil {
+				out.Status = batchFailed
+				out.Message = result.Error.Error()
+			} else {
+				ctx.Infof("Migration of %q started with ID %q", out.Model, out.MigrationId)
+				watches = append(watches, &migrationWatch{
+					modelUUID: result.ModelUUID,
+					name:      out.Model,
+				})
+			}
+			summary = append(summary, out)
+		}
+
+		if c.watch && len(watches) > 0 {
+			if err := c.watchBatch(ctx, watches, summary); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+	summary = append(summary, failed...)
+
+	if err := c.out.Write(ctx, summary); err != nil {
+		return errors.Trace(err)
+	}
+	failures := 0
+	for _, out := range summary {
+		if out.Status == batchFailed || out.Status == batchAborted {
+			failures++
+		}
+	}
+	if failures > 0 {
+		return errors.Errorf("%d of %d model migration(s) failed", failures, len(summary))
+	}
+	return nil
+}
+
+// watchBatch waits for the batch's migrations to complete, recording
+// the outcome of each in the summary.
+func (c *migrateCommand) watchBatch(ctx *cmd.Context, watches []*migrationWatch, summary []batchMigrationOutput) error {
+	api, err := c.getModelInfoAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	if err := c.pollMigrations(ctx, api, watches); err != nil {
+		return errors.Trace(err)
+	}
+	for _, watch := range watches {
+		for i := range summary {
+			if summary[i].Model != watch.name {
+				continue
+			}
+			if watch.aborted {
+				summary[i].Status = batchAborted
+				summary[i].Message = watch.message
+			} else {
+				summary[i].Status = batchMigrated
+			}
+		}
+	}
+	return nil
+}
+
+// selectBatchModels returns the models to migrate, chosen either by
+// name or with --owner and --cloud. Models named on the command line
+// which can't be found are returned as failures rather than stopping
+// the batch.
+func (c *migrateCommand) selectBatchModels(ctx *cmd.Context, api migrateAPI) ([]base.UserModel, []batchMigrationOutput, error) {
+	all, err := api.AllModels()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if !c.selectsModels() {
+		var models []base.UserModel
+		var failed []batchMigrationOutput
+		for _, name := range c.models {
+			model, err := matchModel(ctx, all, name)
+			if err != nil {
+				failed = append(failed, batchMigrationOutput{
+					Model:   name,
+					Status:  batchFailed,
+					Message: err.Error(),
+				})
+				continue
+			}
+			models = append(models, model)
+		}
+		return models, failed, nil
+	}
+
+	var models []base.UserModel
+	for _, model := range all {
+		if model.Name == bootstrap.ControllerModelName {
+			continue
+		}
+		if c.owner != "" && model.Owner != c.owner {
+			continue
+		}
+		models = append(models, model)
+	}
+	if c.cloud != "" && len(models) > 0 {
+		models, err = c.filterModelsByCloud(models)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+	}
+	return models, nil, nil
+}
+
+// filterModelsByCloud returns the models which are on the cloud given
+// with --cloud.
+func (c *migrateCommand) filterModelsByCloud(models []base.UserModel) ([]base.UserModel, error) {
+	api, err := c.getModelInfoAPI()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer api.Close()
+
+	tags := make([]names.ModelTag, len(models))
+	for i, model := range models {
+		tags[i] = names.NewModelTag(model.UUID)
+	}
+	results, err := api.ModelInfo(tags)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results) != len(tags) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(tags), len(results))
+	}
+	cloudTag := names.NewCloudTag(c.cloud).String()
+	var selected []base.UserModel
+	for i, result := range results {
+		if result.Error != nil {
+			return nil, errors.Annotatef(result.Error, "getting details of model %q", batchModelName(models[i]))
+		}
+		if result.Result.CloudTag == cloudTag {
+			selected = append(selected, models[i])
+		}
+	}
+	return selected, nil
+}
+
+func batchModelName(model base.UserModel) string {
+	return model.Owner + "/" + model.Name
+}
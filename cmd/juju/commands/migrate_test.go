@@ -6,19 +6,25 @@ package commands
 import (
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/juju/cmd"
+	"github.com/juju/errors"
 	cookiejar "github.com/juju/persistent-cookiejar"
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/clock"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
 	"gopkg.in/macaroon-bakery.v1/httpbakery"
 	"gopkg.in/macaroon.v1"
 
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/controller"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
+	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/jujuclient"
 	"github.com/juju/juju/jujuclient/jujuclienttesting"
 	"github.com/juju/juju/testing"
@@ -27,6 +33,7 @@ import (
 type MigrateSuite struct {
 	testing.FakeJujuXDGDataHomeSuite
 	api                 *fakeMigrateAPI
+	modelInfoAPI        *fakeModelInfoAPI
 	targetControllerAPI *fakeTargetControllerAPI
 	store               *jujuclienttesting.MemStore
 	password            string
@@ -88,6 +95,8 @@ func (s *MigrateSuite) SetUpTest(c *gc.C) {
 		}},
 	}
 
+	s.modelInfoAPI = &fakeModelInfoAPI{}
+
 	mac0, err := macaroon.New([]byte("secret0"), "id0", "location0")
 	c.Assert(err, jc.ErrorIsNil)
 	mac1, err := macaroon.New([]byte("secret1"), "id1", "location1")
@@ -134,9 +143,14 @@ func (s *MigrateSuite) TestMissingTargetController(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "target controller not specified")
 }
 
-func (s *MigrateSuite) TestTooManyArgs(c *gc.C) {
-	_, err := s.makeAndRun(c, "one", "too", "many")
-	c.Assert(err, gc.ErrorMatches, "too many arguments specified")
+func (s *MigrateSuite) TestModelNamesWithSelector(c *gc.C) {
+	_, err := s.makeAndRun(c, "--owner", "bob", "model", "target")
+	c.Assert(err, gc.ErrorMatches, "model names can't be combined with --owner or --cloud")
+}
+
+func (s *MigrateSuite) TestSelectorMissingTargetController(c *gc.C) {
+	_, err := s.makeAndRun(c, "--cloud", "aws")
+	c.Assert(err, gc.ErrorMatches, "target controller not specified")
 }
 
 func (s *MigrateSuite) TestSuccess(c *gc.C) {
@@ -154,6 +168,55 @@ func (s *MigrateSuite) TestSuccess(c *gc.C) {
 	})
 }
 
+func (s *MigrateSuite) TestTargetCloud(c *gc.C) {
+	_, err := s.makeAndRun(c,
+		"--target-cloud", "cumulus",
+		"--target-region", "west",
+		"--target-credential", "prod",
+		"model", "target",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.api.specSeen, jc.DeepEquals, &controller.MigrationSpec{
+		ModelUUID:            modelUUID,
+		TargetControllerUUID: targetControllerUUID,
+		TargetAddrs:          []string{"1.2.3.4:5"},
+		TargetCACert:         "cert",
+		TargetUser:           "target",
+		TargetPassword:       "secret",
+		TargetCloud:          "cumulus",
+		TargetCloudRegion:    "west",
+		TargetCredential:     "cumulus/target/prod",
+	})
+}
+
+func (s *MigrateSuite) TestTargetCredentialOwner(c *gc.C) {
+	_, err := s.makeAndRun(c,
+		"--target-cloud", "cumulus",
+		"--target-credential", "bob/prod",
+		"model", "target",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.api.specSeen.TargetCloud, gc.Equals, "cumulus")
+	c.Check(s.api.specSeen.TargetCloudRegion, gc.Equals, "")
+	c.Check(s.api.specSeen.TargetCredential, gc.Equals, "cumulus/bob/prod")
+}
+
+func (s *MigrateSuite) TestTargetRegionWithoutCloud(c *gc.C) {
+	_, err := s.makeAndRun(c, "--target-region", "west", "model", "target")
+	c.Assert(err, gc.ErrorMatches, "--target-region requires --target-cloud")
+}
+
+func (s *MigrateSuite) TestTargetCredentialWithoutCloud(c *gc.C) {
+	_, err := s.makeAndRun(c, "--target-credential", "prod", "model", "target")
+	c.Assert(err, gc.ErrorMatches, "--target-credential requires --target-cloud")
+}
+
+func (s *MigrateSuite) TestTargetCloudNotSupported(c *gc.C) {
+	s.api.initiateErr = errors.NotSupportedf("cloud remapping")
+	_, err := s.makeAndRun(c, "--target-cloud", "cumulus", "model", "target")
+	c.Assert(err, gc.ErrorMatches, "controller does not support remapping the model's cloud")
+}
+
 func (s *MigrateSuite) TestSuccessMacaroons(c *gc.C) {
 	err := s.store.UpdateAccount("target", jujuclient.AccountDetails{
 		User:     "target",
@@ -210,13 +273,324 @@ func (s *MigrateSuite) TestControllerDoesntExist(c *gc.C) {
 	c.Check(s.api.specSeen, gc.IsNil) // API shouldn't have been called
 }
 
+func (s *MigrateSuite) TestDryRunSuccess(c *gc.C) {
+	ctx, err := s.makeAndRun(c, "--dry-run", "model", "target")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(testing.Stderr(ctx), gc.Equals, "Model \"model\" can be migrated to \"target\"\n")
+	c.Check(testing.Stdout(ctx), gc.Equals, "")
+	c.Check(s.api.specSeen, gc.IsNil) // No migration should be started.
+	c.Check(s.api.precheckSpec, jc.DeepEquals, &controller.MigrationSpec{
+		ModelUUID:            modelUUID,
+		TargetControllerUUID: targetControllerUUID,
+		TargetAddrs:          []string{"1.2.3.4:5"},
+		TargetCACert:         "cert",
+		TargetUser:           "target",
+		TargetPassword:       "secret",
+	})
+}
+
+func (s *MigrateSuite) setPrecheckProblems() {
+	s.api.precheckProbs = coremigration.PrecheckReport{
+		Source: []coremigration.PrecheckProblem{
+			{Entity: "machine 0", Message: "machine 0 is dying"},
+			{Entity: "unit foo/0", Message: "unit foo/0 is upgrading"},
+		},
+		Target: []coremigration.PrecheckProblem{
+			{Entity: "model", Message: `cloud "aws" not found on target controller`},
+		},
+	}
+}
+
+func (s *MigrateSuite) TestDryRunProblems(c *gc.C) {
+	s.setPrecheckProblems()
+	ctx, err := s.makeAndRun(c, "--dry-run", "model", "target")
+	c.Assert(err, gc.ErrorMatches, `found 3 problem\(s\) preventing migration`)
+
+	expected := `
+Controller  Entity      Problem
+source      machine 0   machine 0 is dying
+source      unit foo/0  unit foo/0 is upgrading
+target      model       cloud "aws" not found on target controller
+`[1:]
+	c.Check(testing.Stdout(ctx), gc.Equals, expected)
+	c.Check(s.api.specSeen, gc.IsNil)
+}
+
+func (s *MigrateSuite) TestDryRunProblemsYAML(c *gc.C) {
+	s.setPrecheckProblems()
+	ctx, err := s.makeAndRun(c, "--dry-run", "--format", "yaml", "model", "target")
+	c.Assert(err, gc.ErrorMatches, `found 3 problem\(s\) preventing migration`)
+
+	expected := `
+ok: false
+source:
+- entity: machine 0
+  message: machine 0 is dying
+- entity: unit foo/0
+  message: unit foo/0 is upgrading
+target:
+- entity: model
+  message: cloud "aws" not found on target controller
+`[1:]
+	c.Check(testing.Stdout(ctx), gc.Equals, expected)
+}
+
+func (s *MigrateSuite) TestDryRunSuccessYAML(c *gc.C) {
+	ctx, err := s.makeAndRun(c, "--dry-run", "--format", "yaml", "model", "target")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(ctx), gc.Equals, "ok: true\n")
+	c.Check(s.api.specSeen, gc.IsNil)
+}
+
+func (s *MigrateSuite) TestDryRunSuccessJSON(c *gc.C) {
+	ctx, err := s.makeAndRun(c, "--dry-run", "--format", "json", "model", "target")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(ctx), gc.Equals, `{"ok":true}`+"\n")
+}
+
+func (s *MigrateSuite) TestDryRunNotSupported(c *gc.C) {
+	s.api.precheckErr = errors.NotSupportedf("MigrationPrechecks")
+	_, err := s.makeAndRun(c, "--dry-run", "model", "target")
+	c.Assert(err, gc.ErrorMatches, "controller does not support migration dry runs")
+}
+
+func (s *MigrateSuite) TestWatchWithDryRun(c *gc.C) {
+	_, err := s.makeAndRun(c, "--watch", "--dry-run", "model", "target")
+	c.Assert(err, gc.ErrorMatches, "--watch cannot be used with --dry-run")
+}
+
+var watchStart = time.Date(2017, 3, 1, 10, 0, 0, 0, time.UTC)
+
+func migrationStatus(phase string, progress *params.MigrationProgress, end *time.Time) params.ModelInfoResult {
+	phaseTimes := []params.MigrationPhaseTime{{Phase: "QUIESCE", Start: watchStart}}
+	if phase != "QUIESCE" {
+		phaseTimes = append(phaseTimes, params.MigrationPhaseTime{
+			Phase: phase,
+			Start: watchStart.Add(time.Minute),
+		})
+	}
+	return params.ModelInfoResult{Result: &params.ModelInfo{
+		Migration: &params.ModelMigrationStatus{
+			Status:     "migrating: " + strings.ToLower(phase),
+			Phase:      phase,
+			Progress:   progress,
+			PhaseTimes: phaseTimes,
+			Start:      &watchStart,
+			End:        end,
+		},
+	}}
+}
+
+func (s *MigrateSuite) TestWatch(c *gc.C) {
+	end := watchStart.Add(5 * time.Minute)
+	importing := migrationStatus("IMPORT", &params.MigrationProgress{
+		BinariesUploaded: 25 * 1024 * 1024,
+		BinariesTotal:    100 * 1024 * 1024,
+	}, nil)
+	s.modelInfoAPI.results = []params.ModelInfoResult{
+		migrationStatus("QUIESCE", nil, nil),
+		importing,
+		importing,
+		migrationStatus("VALIDATION", &params.MigrationProgress{
+			MinionReportsReceived: 2,
+			MinionReportsExpected: 3,
+		}, nil),
+		migrationStatus("DONE", nil, &end),
+	}
+	ctx, err := s.makeAndRun(c, "--watch", "model", "target")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(testing.Stderr(ctx), gc.Equals, `
+Migration started with ID "uuid:0"
+QUIESCE (3m0s); migrating: quiesce
+IMPORT (2m0s); migrating: import; binaries 25 MiB of 100 MiB, about 6m0s remaining
+VALIDATION (2m0s); migrating: validation; agent reports 2 of 3
+DONE (4m0s); migrating: done
+Model "model" migrated to "target"
+`[1:])
+	c.Check(s.modelInfoAPI.tags, jc.DeepEquals, []names.ModelTag{names.NewModelTag(modelUUID)})
+	c.Check(s.modelInfoAPI.closed, jc.IsTrue)
+}
+
+func (s *MigrateSuite) TestWatchAborted(c *gc.C) {
+	end := watchStart.Add(2 * time.Minute)
+	s.modelInfoAPI.results = []params.ModelInfoResult{
+		migrationStatus("ABORTDONE", nil, &end),
+	}
+	_, err := s.makeAndRun(c, "--watch", "model", "target")
+	c.Assert(err, gc.ErrorMatches, "migration aborted: migrating: abortdone")
+}
+
+func (s *MigrateSuite) TestWatchModelRemoved(c *gc.C) {
+	s.modelInfoAPI.results = []params.ModelInfoResult{
+		migrationStatus("QUIESCE", nil, nil),
+		{Error: &params.Error{Code: params.CodeNotFound, Message: "not found"}},
+	}
+	ctx, err := s.makeAndRun(c, "--watch", "model", "target")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stderr(ctx), jc.Contains, `Model "model" migrated to "target"`)
+}
+
+func (s *MigrateSuite) TestWatchNoStructuredProgress(c *gc.C) {
+	end := watchStart.Add(2 * time.Minute)
+	s.modelInfoAPI.results = []params.ModelInfoResult{{Result: &params.ModelInfo{
+		Migration: &params.ModelMigrationStatus{
+			Status: "migration completed",
+			Start:  &watchStart,
+			End:    &end,
+		},
+	}}}
+	ctx, err := s.makeAndRun(c, "--watch", "model", "target")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stderr(ctx), jc.Contains, "migration completed\n")
+}
+
+func (s *MigrateSuite) TestBatch(c *gc.C) {
+	ctx, err := s.makeAndRun(c, "model", "alpha/production", "target")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.api.specSeen, gc.IsNil)
+	c.Assert(s.api.batchSeen, gc.HasLen, 2)
+	c.Check(s.api.batchSeen[0], jc.DeepEquals, controller.MigrationSpec{
+		ModelUUID:            modelUUID,
+		TargetControllerUUID: targetControllerUUID,
+		TargetAddrs:          []string{"1.2.3.4:5"},
+		TargetCACert:         "cert",
+		TargetUser:           "target",
+		TargetPassword:       "secret",
+		Queue:                true,
+	})
+	c.Check(s.api.batchSeen[1].ModelUUID, gc.Equals, "prod-1-uuid")
+	c.Check(s.api.batchSeen[1].Queue, jc.IsTrue)
+
+	c.Check(testing.Stderr(ctx), gc.Equals, `
+Migration of "owner/model" started with ID "deadbeef-0bad-400d-8000-4b1d0d06f00d:0"
+Migration of "alpha/production" started with ID "prod-1-uuid:0"
+`[1:])
+	c.Check(testing.Stdout(ctx), gc.Equals, `
+Model             Status   Message
+owner/model       started  
+alpha/production  started  
+`[1:])
+}
+
+func (s *MigrateSuite) TestBatchPartialFailure(c *gc.C) {
+	s.api.batchErrs = map[string]error{
+		"prod-1-uuid": errors.New("model is being upgraded"),
+	}
+	ctx, err := s.makeAndRun(c, "--format", "yaml", "model", "alpha/production", "wat", "target")
+	c.Assert(err, gc.ErrorMatches, `2 of 3 model migration\(s\) failed`)
+
+	// The migrations which could be started are still started.
+	c.Assert(s.api.batchSeen, gc.HasLen, 2)
+	c.Check(testing.Stdout(ctx), gc.Equals, `
+- model: owner/model
+  migration-id: deadbeef-0bad-400d-8000-4b1d0d06f00d:0
+  status: started
+- model: alpha/production
+  status: failed
+  message: model is being upgraded
+- model: wat
+  status: failed
+  message: model matching "wat" not found
+`[1:])
+}
+
+func (s *MigrateSuite) TestBatchOwner(c *gc.C) {
+	s.api.models = append(s.api.models, base.UserModel{
+		Name:  "controller",
+		UUID:  "controller-uuid",
+		Owner: "omega",
+	}, base.UserModel{
+		Name:  "staging",
+		UUID:  "staging-uuid",
+		Owner: "omega",
+	})
+	_, err := s.makeAndRun(c, "--owner", "omega", "target")
+	c.Assert(err, jc.ErrorIsNil)
+
+	var uuids []string
+	for _, spec := range s.api.batchSeen {
+		uuids = append(uuids, spec.ModelUUID)
+	}
+	c.Check(uuids, jc.DeepEquals, []string{"prod-2-uuid", "staging-uuid"})
+}
+
+func (s *MigrateSuite) TestBatchCloud(c *gc.C) {
+	s.modelInfoAPI.results = []params.ModelInfoResult{
+		{Result: &params.ModelInfo{CloudTag: "cloud-aws"}},
+		{Result: &params.ModelInfo{CloudTag: "cloud-maas"}},
+		{Result: &params.ModelInfo{CloudTag: "cloud-aws"}},
+	}
+	_, err := s.makeAndRun(c, "--cloud", "aws", "target")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.modelInfoAPI.tags, jc.DeepEquals, []names.ModelTag{
+		names.NewModelTag(modelUUID),
+		names.NewModelTag("prod-1-uuid"),
+		names.NewModelTag("prod-2-uuid"),
+	})
+	var uuids []string
+	for _, spec := range s.api.batchSeen {
+		uuids = append(uuids, spec.ModelUUID)
+	}
+	c.Check(uuids, jc.DeepEquals, []string{modelUUID, "prod-2-uuid"})
+}
+
+func (s *MigrateSuite) TestBatchNoModelsSelected(c *gc.C) {
+	_, err := s.makeAndRun(c, "--owner", "nobody", "target")
+	c.Assert(err, gc.ErrorMatches, "no models selected for migration")
+	c.Check(s.api.batchSeen, gc.IsNil)
+}
+
+func (s *MigrateSuite) TestBatchWatch(c *gc.C) {
+	end := watchStart.Add(5 * time.Minute)
+	s.modelInfoAPI.results = []params.ModelInfoResult{
+		migrationStatus("QUIESCE", nil, nil),
+		migrationStatus("QUIESCE", nil, nil),
+		migrationStatus("DONE", nil, &end),
+		migrationStatus("ABORTDONE", nil, &end),
+	}
+	ctx, err := s.makeAndRun(c, "--watch", "model", "alpha/production", "target")
+	c.Assert(err, gc.ErrorMatches, `1 of 2 model migration\(s\) failed`)
+
+	c.Check(testing.Stderr(ctx), gc.Equals, `
+Migration of "owner/model" started with ID "deadbeef-0bad-400d-8000-4b1d0d06f00d:0"
+Migration of "alpha/production" started with ID "prod-1-uuid:0"
+owner/model: QUIESCE (3m0s); migrating: quiesce
+alpha/production: QUIESCE (3m0s); migrating: quiesce
+owner/model: DONE (4m0s); migrating: done
+alpha/production: ABORTDONE (4m0s); migrating: abortdone
+`[1:])
+	c.Check(testing.Stdout(ctx), gc.Equals, `
+Model             Status    Message
+owner/model       migrated  
+alpha/production  aborted   migrating: abortdone
+`[1:])
+	c.Check(s.modelInfoAPI.closed, jc.IsTrue)
+}
+
+func (s *MigrateSuite) TestBatchWithDryRun(c *gc.C) {
+	_, err := s.makeAndRun(c, "--dry-run", "--owner", "omega", "target")
+	c.Assert(err, gc.ErrorMatches, "--dry-run can only be used with a single model")
+}
+
+func (s *MigrateSuite) TestBatchNotSupported(c *gc.C) {
+	s.api.initiateErr = errors.NotSupportedf("queued migrations")
+	_, err := s.makeAndRun(c, "model", "alpha/production", "target")
+	c.Assert(err, gc.ErrorMatches, "controller does not support migrating models in batches")
+}
+
 func (s *MigrateSuite) makeAndRun(c *gc.C, args ...string) (*cmd.Context, error) {
 	return s.run(c, s.makeCommand(), args...)
 }
 
 func (s *MigrateSuite) makeCommand() *migrateCommand {
 	cmd := &migrateCommand{
-		api: s.api,
+		api:          s.api,
+		modelInfoAPI: s.modelInfoAPI,
+		clock:        fakeWatchClock{watchStart.Add(3 * time.Minute)},
 		newAPIRoot: func(jujuclient.ClientStore, string, string) (api.Connection, error) {
 			return s.targetControllerAPI, nil
 		},
@@ -230,19 +604,95 @@ func (s *MigrateSuite) run(c *gc.C, cmd *migrateCommand, args ...string) (*cmd.C
 }
 
 type fakeMigrateAPI struct {
-	specSeen *controller.MigrationSpec
-	models   []base.UserModel
+	specSeen      *controller.MigrationSpec
+	initiateErr   error
+	batchSeen     []controller.MigrationSpec
+	batchErrs     map[string]error
+	models        []base.UserModel
+	precheckSpec  *controller.MigrationSpec
+	precheckErr   error
+	precheckProbs coremigration.PrecheckReport
 }
 
 func (a *fakeMigrateAPI) InitiateMigration(spec controller.MigrationSpec) (string, error) {
 	a.specSeen = &spec
+	if a.initiateErr != nil {
+		return "", a.initiateErr
+	}
 	return "uuid:0", nil
 }
 
+func (a *fakeMigrateAPI) InitiateMigrations(specs []controller.MigrationSpec) ([]controller.InitiateMigrationResult, error) {
+	a.batchSeen = specs
+	if a.initiateErr != nil {
+		return nil, a.initiateErr
+	}
+	results := make([]controller.InitiateMigrationResult, len(specs))
+	for i, spec := range specs {
+		results[i].ModelUUID = spec.ModelUUID
+		if err := a.batchErrs[spec.ModelUUID]; err != nil {
+			results[i].Error = err
+			continue
+		}
+		results[i].MigrationId = spec.ModelUUID + ":0"
+	}
+	return results, nil
+}
+
 func (a *fakeMigrateAPI) AllModels() ([]base.UserModel, error) {
 	return a.models, nil
 }
 
+func (a *fakeMigrateAPI) MigrationPrechecks(spec controller.MigrationSpec) (coremigration.PrecheckReport, error) {
+	a.precheckSpec = &spec
+	return a.precheckProbs, a.precheckErr
+}
+
+type fakeModelInfoAPI struct {
+	results []params.ModelInfoResult
+	tags    []names.ModelTag
+	closed  bool
+}
+
+func (a *fakeModelInfoAPI) ModelInfo(tags []names.ModelTag) ([]params.ModelInfoResult, error) {
+	a.tags = tags
+	if len(a.results) < len(tags) {
+		return nil, errors.New("no more results")
+	}
+	results := a.results[:len(tags)]
+	a.results = a.results[len(tags):]
+	return results, nil
+}
+
+func (a *fakeModelInfoAPI) Close() error {
+	a.closed = true
+	return nil
+}
+
+// fakeWatchClock always reports the same time, and never makes its
+// callers wait.
+type fakeWatchClock struct {
+	now time.Time
+}
+
+func (c fakeWatchClock) Now() time.Time {
+	return c.now
+}
+
+func (c fakeWatchClock) After(time.Duration) <-chan time.Time {
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+	return ch
+}
+
+func (c fakeWatchClock) AfterFunc(d time.Duration, f func()) clock.Timer {
+	panic("unexpected call to AfterFunc")
+}
+
+func (c fakeWatchClock) NewTimer(d time.Duration) clock.Timer {
+	panic("unexpected call to NewTimer")
+}
+
 type fakeModelAPI struct {
 	model string
 }
@@ -0,0 +1,122 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/juju/names"
+	"github.com/juju/juju/worker/uniter/runner/replay"
+)
+
+func newReplayHookCommand() cmd.Command {
+	return &replayHookCommand{
+		runReplay: replay.Run,
+	}
+}
+
+// replayHookCommand replays a recorded hook locally.
+type replayHookCommand struct {
+	cmd.CommandBase
+	runReplay func(replay.Params) error
+
+	recordingPath string
+	charmDir      string
+	jujudPath     string
+}
+
+const replayHookDoc = `
+Replays a recorded hook locally, against a copy of the charm.
+
+The recording, downloaded with "juju download-hook-recording", holds the
+environment the hook ran in and the responses given to each hook tool it
+ran. The hook is run from the charm directory with that environment, and
+its hook tools are answered from the recording rather than by a unit
+agent, so that a failure can be reproduced without access to the model.
+Hook tool invocations that were not recorded fail.
+
+The hook tools are provided by the jujud executable, which is looked for
+alongside the juju executable and then in the PATH unless specified with
+--jujud.
+
+Examples:
+    juju replay-hook mysql-0-hook-recording.yaml --charm-dir ~/charms/mysql
+
+See also:
+    download-hook-recording
+`
+
+func (c *replayHookCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "replay-hook",
+		Args:    "<recording>",
+		Purpose: "Replay a recorded hook locally.",
+		Doc:     replayHookDoc,
+	}
+}
+
+func (c *replayHookCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	f.StringVar(&c.charmDir, "charm-dir", ".", "The directory holding the charm")
+	f.StringVar(&c.jujudPath, "jujud", "", "The path to the jujud executable")
+}
+
+func (c *replayHookCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no recording specified")
+	}
+	c.recordingPath, args = args[0], args[1:]
+	return cmd.CheckEmpty(args)
+}
+
+func (c *replayHookCommand) Run(ctx *cmd.Context) error {
+	rec, err := replay.ReadRecording(ctx.AbsPath(c.recordingPath))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	jujudPath := c.jujudPath
+	if jujudPath == "" {
+		if jujudPath, err = findJujud(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	ctx.Infof("replaying %q hook recorded on %s at %s", rec.Hook, rec.Unit, rec.Recorded.Format("2006-01-02 15:04:05"))
+	if rec.Error != "" {
+		ctx.Infof("recorded error: %s", rec.Error)
+	}
+	err = c.runReplay(replay.Params{
+		Recording: rec,
+		CharmDir:  ctx.AbsPath(c.charmDir),
+		JujudPath: jujudPath,
+		Stdout:    ctx.Stdout,
+		Stderr:    ctx.Stderr,
+	})
+	if err != nil {
+		return errors.Annotatef(err, "replayed %q hook failed", rec.Hook)
+	}
+	ctx.Infof("replayed %q hook succeeded", rec.Hook)
+	return nil
+}
+
+// findJujud returns the path to the jujud executable, looking first
+// alongside the running executable and then in the PATH.
+func findJujud() (string, error) {
+	if self, err := filepath.Abs(os.Args[0]); err == nil {
+		jujud := filepath.Join(filepath.Dir(self), names.Jujud)
+		if _, err := os.Stat(jujud); err == nil {
+			return jujud, nil
+		}
+	}
+	jujud, err := exec.LookPath(names.Jujud)
+	if err != nil {
+		return "", errors.Errorf("cannot find %s; specify it with --jujud", names.Jujud)
+	}
+	return jujud, nil
+}
@@ -0,0 +1,85 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/runner/replay"
+)
+
+type ReplayHookSuite struct {
+	coretesting.FakeJujuXDGDataHomeSuite
+	recordingPath string
+	params        []replay.Params
+	replayErr     error
+}
+
+var _ = gc.Suite(&ReplayHookSuite{})
+
+func (s *ReplayHookSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.params = nil
+	s.replayErr = nil
+	var err error
+	s.recordingPath, err = replay.WriteRecording(c.MkDir(), &replay.Recording{
+		Unit:     "mysql/0",
+		Hook:     "install",
+		Recorded: time.Date(2017, 3, 1, 12, 0, 0, 0, time.UTC),
+		Error:    "exit status 1",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ReplayHookSuite) newCommand() *replayHookCommand {
+	return &replayHookCommand{
+		runReplay: func(p replay.Params) error {
+			s.params = append(s.params, p)
+			return s.replayErr
+		},
+	}
+}
+
+func (s *ReplayHookSuite) TestInit(c *gc.C) {
+	err := coretesting.InitCommand(s.newCommand(), nil)
+	c.Assert(err, gc.ErrorMatches, "no recording specified")
+	err = coretesting.InitCommand(s.newCommand(), []string{"a.yaml", "b.yaml"})
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["b.yaml"\]`)
+}
+
+func (s *ReplayHookSuite) TestRun(c *gc.C) {
+	charmDir := c.MkDir()
+	ctx, err := coretesting.RunCommand(c, s.newCommand(),
+		s.recordingPath, "--charm-dir", charmDir, "--jujud", "/path/to/jujud",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.params, gc.HasLen, 1)
+	c.Assert(s.params[0].Recording.Hook, gc.Equals, "install")
+	c.Assert(s.params[0].CharmDir, gc.Equals, charmDir)
+	c.Assert(s.params[0].JujudPath, gc.Equals, "/path/to/jujud")
+	c.Assert(coretesting.Stderr(ctx), gc.Equals, ""+
+		"replaying \"install\" hook recorded on mysql/0 at 2017-03-01 12:00:00\n"+
+		"recorded error: exit status 1\n"+
+		"replayed \"install\" hook succeeded\n",
+	)
+}
+
+func (s *ReplayHookSuite) TestRunHookFails(c *gc.C) {
+	s.replayErr = errors.New("exit status 1")
+	_, err := coretesting.RunCommand(c, s.newCommand(), s.recordingPath, "--jujud", "/path/to/jujud")
+	c.Assert(err, gc.ErrorMatches, `replayed "install" hook failed: exit status 1`)
+	c.Assert(s.params, gc.HasLen, 1)
+}
+
+func (s *ReplayHookSuite) TestRunBadRecording(c *gc.C) {
+	_, err := coretesting.RunCommand(c, s.newCommand(), filepath.Join(c.MkDir(), "missing.yaml"), "--jujud", "/path/to/jujud")
+	c.Assert(err, gc.ErrorMatches, "cannot read hook recording: .*")
+	c.Assert(s.params, gc.HasLen, 0)
+}
@@ -6,6 +6,7 @@ package commands
 import (
 	"encoding/base64"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -14,6 +15,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
 	"github.com/juju/utils"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
 
 	actionapi "github.com/juju/juju/api/action"
@@ -44,6 +46,9 @@ type runCommand struct {
 	units     []string
 	commands  string
 	timeAfter func(time.Duration) <-chan time.Time
+
+	maxParallel int
+	batchSize   int
 }
 
 const runDoc = `
@@ -81,6 +86,23 @@ command and its arguments with "--", to tell "juju run" to stop processing
 those arguments. For example:
 
     juju run --all -- hostname -f
+
+By default the command is started on all of the targets at once. To limit
+the number of targets it runs on at any one time, use --max-parallel; as
+the command completes on one target it is started on the next. Alternatively,
+--batch-size runs the command on the targets in batches of the given size,
+waiting for each batch to complete before starting the next. If the command
+fails or times out on any target in a batch, no further batches are run.
+With either option, the --timeout applies to each target individually, and
+applications and --all are expanded to their units and machines (including
+containers) before the command is run.
+
+With either option, the results for each target include its exit code,
+even when zero, along with stdout, stderr and when the command was
+enqueued, started and completed. For example, to restart a
+service on the units of an application two at a time:
+
+    juju run --application mysql --batch-size 2 --format json -- sudo service mysql restart
 `
 
 func (c *runCommand) Info() *cmd.Info {
@@ -105,6 +127,8 @@ func (c *runCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(cmd.NewStringsValue(nil, &c.machines), "machine", "One or more machine ids")
 	f.Var(cmd.NewStringsValue(nil, &c.services), "application", "One or more application names")
 	f.Var(cmd.NewStringsValue(nil, &c.units), "unit", "One or more unit ids")
+	f.IntVar(&c.maxParallel, "max-parallel", 0, "The maximum number of targets to run the commands on at once")
+	f.IntVar(&c.batchSize, "batch-size", 0, "Run the commands on the targets in batches of this size, stopping after a failed batch")
 }
 
 func (c *runCommand) Init(args []string) error {
@@ -138,6 +162,16 @@ func (c *runCommand) Init(args []string) error {
 		}
 	}
 
+	if c.maxParallel < 0 {
+		return errors.Errorf("--max-parallel must not be negative")
+	}
+	if c.batchSize < 0 {
+		return errors.Errorf("--batch-size must not be negative")
+	}
+	if c.maxParallel > 0 && c.batchSize > 0 {
+		return errors.Errorf("You cannot specify both --max-parallel and --batch-size")
+	}
+
 	var nameErrors []string
 	for _, machineId := range c.machines {
 		if !names.IsValidMachine(machineId) {
@@ -210,6 +244,51 @@ func ConvertActionResults(result params.ActionResult, query actionQuery) map[str
 	return values
 }
 
+// convertScheduledActionResults converts the results like
+// ConvertActionResults, adding the exit code even when it is zero and
+// when the commands were enqueued, started and completed, so that the
+// targets of a run scheduled with --max-parallel or --batch-size can
+// be told apart.
+func convertScheduledActionResults(result params.ActionResult, query actionQuery) map[string]interface{} {
+	values := ConvertActionResults(result, query)
+	if _, ok := values["Error"]; ok {
+		return values
+	}
+	if _, ok := values["ReturnCode"]; !ok {
+		if res, ok := result.Output["Code"].(string); ok {
+			if code, err := strconv.Atoi(res); err == nil {
+				values["ReturnCode"] = code
+			}
+		}
+	}
+	timing := make(map[string]string)
+	for k, v := range map[string]time.Time{
+		"Enqueued":  result.Enqueued,
+		"Started":   result.Started,
+		"Completed": result.Completed,
+	} {
+		if !v.IsZero() {
+			timing[k] = v.String()
+		}
+	}
+	if !result.Started.IsZero() && !result.Completed.IsZero() {
+		timing["Duration"] = result.Completed.Sub(result.Started).String()
+	}
+	if len(timing) > 0 {
+		values["Timing"] = timing
+	}
+	return values
+}
+
+// convertResults converts the results of the commands run on a target
+// for output.
+func (c *runCommand) convertResults(result params.ActionResult, query actionQuery) map[string]interface{} {
+	if c.maxParallel > 0 || c.batchSize > 0 {
+		return convertScheduledActionResults(result, query)
+	}
+	return ConvertActionResults(result, query)
+}
+
 func (c *runCommand) Run(ctx *cmd.Context) error {
 	client, err := getRunAPIClient(c)
 	if err != nil {
@@ -217,6 +296,10 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 	}
 	defer client.Close()
 
+	if c.maxParallel > 0 || c.batchSize > 0 {
+		return c.runScheduled(ctx, client)
+	}
+
 	var runResults []params.ActionResult
 	if c.all {
 		runResults, err = client.RunOnAllMachines(c.commands, c.timeout)
@@ -235,6 +318,21 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 		return block.ProcessBlockedError(err, block.BlockChange)
 	}
 
+	actionsToQuery := queryActions(ctx, runResults)
+	if len(actionsToQuery) == 0 {
+		return errors.New("no actions were successfully enqueued, aborting")
+	}
+
+	values, actionsToQuery, err := c.waitForActions(client, actionsToQuery)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.writeResults(ctx, values, actionsToQuery)
+}
+
+// queryActions returns the actions to query for the results of the
+// enqueued actions, reporting any that could not be enqueued.
+func queryActions(ctx *cmd.Context, runResults []params.ActionResult) []actionQuery {
 	actionsToQuery := []actionQuery{}
 	for _, result := range runResults {
 		if result.Error != nil {
@@ -268,17 +366,19 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 				tag:          receiverTag,
 			}})
 	}
+	return actionsToQuery
+}
 
-	if len(actionsToQuery) == 0 {
-		return errors.New("no actions were successfully enqueued, aborting")
-	}
-
+// waitForActions polls for the results of the given actions until they
+// have all completed or the command's timeout expires. It returns the
+// converted results, and the actions that did not complete in time.
+func (c *runCommand) waitForActions(client RunClient, actionsToQuery []actionQuery) ([]interface{}, []actionQuery, error) {
 	timeout := c.timeAfter(c.timeout)
 	values := []interface{}{}
 	for len(actionsToQuery) > 0 {
 		actionResults, err := client.Actions(entities(actionsToQuery))
 		if err != nil {
-			return errors.Trace(err)
+			return nil, nil, errors.Trace(err)
 		}
 
 		newActionsToQuery := []actionQuery{}
@@ -291,7 +391,7 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 				}
 			}
 
-			values = append(values, ConvertActionResults(result, actionsToQuery[i]))
+			values = append(values, c.convertResults(result, actionsToQuery[i]))
 		}
 		actionsToQuery = newActionsToQuery
 
@@ -311,10 +411,15 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 			}
 		}
 	}
+	return values, actionsToQuery, nil
+}
 
+// writeResults writes the converted action results, and returns an
+// error naming the receivers of any actions that timed out.
+func (c *runCommand) writeResults(ctx *cmd.Context, values []interface{}, timedOut []actionQuery) error {
 	// If we are just dealing with one result, AND we are using the default
 	// format, then pretend we were running it locally.
-	if len(actionsToQuery) == 0 && len(values) == 1 && c.out.Name() == "default" {
+	if len(timedOut) == 0 && len(values) == 1 && c.out.Name() == "default" {
 		result, ok := values[0].(map[string]interface{})
 		if !ok {
 			return errors.New("couldn't read action output")
@@ -341,24 +446,315 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 		}
 	}
 
-	if n := len(actionsToQuery); n > 0 {
+	if n := len(timedOut); n > 0 {
 		// There are action results remaining, so return an error.
 		suffix := ""
 		if n > 1 {
 			suffix = "s"
 		}
-		receivers := make([]string, n)
-		for i, actionToQuery := range actionsToQuery {
-			receivers[i] = names.ReadableString(actionToQuery.receiver.tag)
-		}
 		return errors.Errorf(
 			"timed out waiting for result%s from: %s",
-			suffix, strings.Join(receivers, ", "),
+			suffix, readableReceivers(timedOut),
 		)
 	}
 	return nil
 }
 
+// runScheduled runs the commands on each of the targets individually,
+// either keeping at most --max-parallel of them running at once, or in
+// batches of --batch-size, stopping after the first batch in which the
+// commands fail on any target.
+func (c *runCommand) runScheduled(ctx *cmd.Context, client RunClient) error {
+	targets, err := c.expandTargets()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(targets) == 0 {
+		return errors.New("no targets to run the commands on")
+	}
+
+	var values []interface{}
+	var timedOut []actionQuery
+	var notRun []names.Tag
+	if c.batchSize > 0 {
+		values, timedOut, notRun, err = c.runBatches(ctx, client, targets)
+	} else {
+		values, timedOut, err = c.runParallel(ctx, client, targets)
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(notRun) == 0 {
+		return c.writeResults(ctx, values, timedOut)
+	}
+
+	if len(values) > 0 {
+		if err := c.out.Write(ctx, values); err != nil {
+			return err
+		}
+	}
+	if len(timedOut) > 0 {
+		fmt.Fprintf(ctx.GetStderr(), "timed out waiting for results from: %s\n", readableReceivers(timedOut))
+	}
+	readable := make([]string, len(notRun))
+	for i, tag := range notRun {
+		readable[i] = names.ReadableString(tag)
+	}
+	return errors.Errorf("batch failed, commands not run on: %s", strings.Join(readable, ", "))
+}
+
+// runBatches runs the commands on the targets in batches of
+// --batch-size, waiting for each batch to complete before starting the
+// next. If the commands fail or time out on any target in a batch, no
+// further batches are run, and the remaining targets are returned.
+func (c *runCommand) runBatches(ctx *cmd.Context, client RunClient, targets []names.Tag) (
+	values []interface{}, timedOut []actionQuery, notRun []names.Tag, err error,
+) {
+	for len(targets) > 0 {
+		n := c.batchSize
+		if n > len(targets) {
+			n = len(targets)
+		}
+		batch := targets[:n]
+		targets = targets[n:]
+
+		queries, err := c.enqueue(ctx, client, batch)
+		if err != nil {
+			return nil, nil, nil, errors.Trace(err)
+		}
+		batchValues, remaining, err := c.waitForActions(client, queries)
+		if err != nil {
+			return nil, nil, nil, errors.Trace(err)
+		}
+		values = append(values, batchValues...)
+		timedOut = append(timedOut, remaining...)
+		if len(queries) < len(batch) || len(remaining) > 0 || anyFailed(batchValues) {
+			return values, timedOut, targets, nil
+		}
+	}
+	return values, timedOut, nil, nil
+}
+
+// runParallel runs the commands on the targets, starting the commands
+// on another target whenever fewer than --max-parallel are running.
+// Each target is given --timeout to complete from when the commands
+// were started on it.
+func (c *runCommand) runParallel(ctx *cmd.Context, client RunClient, targets []names.Tag) (
+	values []interface{}, timedOut []actionQuery, err error,
+) {
+	var running []actionQuery
+	var deadlines []<-chan time.Time
+	for len(targets) > 0 || len(running) > 0 {
+		for len(targets) > 0 && len(running) < c.maxParallel {
+			queries, err := c.enqueue(ctx, client, targets[:1])
+			if err != nil {
+				return nil, nil, errors.Trace(err)
+			}
+			targets = targets[1:]
+			deadline := c.timeAfter(c.timeout)
+			for _, query := range queries {
+				running = append(running, query)
+				deadlines = append(deadlines, deadline)
+			}
+		}
+		if len(running) == 0 {
+			continue
+		}
+
+		actionResults, err := client.Actions(entities(running))
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		var stillRunning []actionQuery
+		var stillDeadlines []<-chan time.Time
+		for i, result := range actionResults.Results {
+			if result.Error == nil {
+				switch result.Status {
+				case params.ActionRunning, params.ActionPending:
+					select {
+					case <-deadlines[i]:
+						timedOut = append(timedOut, running[i])
+					default:
+						stillRunning = append(stillRunning, running[i])
+						stillDeadlines = append(stillDeadlines, deadlines[i])
+					}
+					continue
+				}
+			}
+			values = append(values, c.convertResults(result, running[i]))
+		}
+		finished := len(running) - len(stillRunning)
+		running, deadlines = stillRunning, stillDeadlines
+
+		// Only wait if no slots were freed for further targets.
+		if len(running) > 0 && (finished == 0 || len(targets) == 0) {
+			<-c.timeAfter(1 * time.Second)
+		}
+	}
+	return values, timedOut, nil
+}
+
+// enqueue starts the commands running on the given machine and unit
+// targets, returning the actions to query for their results.
+func (c *runCommand) enqueue(ctx *cmd.Context, client RunClient, targets []names.Tag) ([]actionQuery, error) {
+	runParams := params.RunParams{
+		Commands: c.commands,
+		Timeout:  c.timeout,
+	}
+	for _, tag := range targets {
+		switch tag.(type) {
+		case names.MachineTag:
+			runParams.Machines = append(runParams.Machines, tag.Id())
+		case names.UnitTag:
+			runParams.Units = append(runParams.Units, tag.Id())
+		}
+	}
+	runResults, err := client.Run(runParams)
+	if err != nil {
+		return nil, block.ProcessBlockedError(err, block.BlockChange)
+	}
+	return queryActions(ctx, runResults), nil
+}
+
+// expandTargets returns the individual machines and units that the
+// commands are to be run on, in a stable order.
+func (c *runCommand) expandTargets() ([]names.Tag, error) {
+	var status *params.FullStatus
+	if c.all || len(c.services) > 0 {
+		client, err := getRunStatusAPIClient(c)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		defer client.Close()
+		if status, err = client.Status(nil); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+
+	var targets []names.Tag
+	seen := make(set.Strings)
+	add := func(tag names.Tag) {
+		if !seen.Contains(tag.String()) {
+			seen.Add(tag.String())
+			targets = append(targets, tag)
+		}
+	}
+	if c.all {
+		for _, id := range statusMachineIds(status.Machines) {
+			add(names.NewMachineTag(id))
+		}
+		return targets, nil
+	}
+	for _, id := range c.machines {
+		add(names.NewMachineTag(id))
+	}
+	for _, name := range c.services {
+		units, err := applicationUnits(status, name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, unit := range units {
+			add(names.NewUnitTag(unit))
+		}
+	}
+	for _, unit := range c.units {
+		add(names.NewUnitTag(unit))
+	}
+	return targets, nil
+}
+
+// statusMachineIds returns the ids of the given machines and all of
+// their containers, each machine followed by its containers.
+func statusMachineIds(machines map[string]params.MachineStatus) []string {
+	var ids []string
+	for id := range machines {
+		ids = append(ids, id)
+	}
+	sort.Sort(byNumericSuffix(ids))
+	var result []string
+	for _, id := range ids {
+		result = append(result, id)
+		result = append(result, statusMachineIds(machines[id].Containers)...)
+	}
+	return result
+}
+
+// applicationUnits returns the names of the units of the named
+// application, including those of a subordinate application.
+func applicationUnits(status *params.FullStatus, name string) ([]string, error) {
+	application, ok := status.Applications[name]
+	if !ok {
+		return nil, errors.NotFoundf("application %q", name)
+	}
+	var units []string
+	for unit := range application.Units {
+		units = append(units, unit)
+	}
+	for _, principal := range application.SubordinateTo {
+		for _, unit := range status.Applications[principal].Units {
+			for subordinate := range unit.Subordinates {
+				if strings.HasPrefix(subordinate, name+"/") {
+					units = append(units, subordinate)
+				}
+			}
+		}
+	}
+	sort.Sort(byNumericSuffix(units))
+	return units, nil
+}
+
+// byNumericSuffix sorts machine ids and unit names so that, for
+// example, "mysql/2" comes before "mysql/10".
+type byNumericSuffix []string
+
+func (s byNumericSuffix) Len() int      { return len(s) }
+func (s byNumericSuffix) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s byNumericSuffix) Less(i, j int) bool {
+	pi, ni := splitNumericSuffix(s[i])
+	pj, nj := splitNumericSuffix(s[j])
+	if pi != pj {
+		return pi < pj
+	}
+	return ni < nj
+}
+
+func splitNumericSuffix(s string) (string, int) {
+	i := strings.LastIndex(s, "/") + 1
+	n, err := strconv.Atoi(s[i:])
+	if err != nil {
+		return s, 0
+	}
+	return s[:i], n
+}
+
+// anyFailed reports whether any of the converted action results
+// records an error or a non-zero exit code.
+func anyFailed(values []interface{}) bool {
+	for _, value := range values {
+		result, ok := value.(map[string]interface{})
+		if !ok {
+			return true
+		}
+		if _, ok := result["Error"]; ok {
+			return true
+		}
+		if code, ok := result["ReturnCode"].(int); ok && code != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// readableReceivers returns a human readable list of the receivers of
+// the given actions.
+func readableReceivers(actions []actionQuery) string {
+	receivers := make([]string, len(actions))
+	for i, action := range actions {
+		receivers[i] = names.ReadableString(action.receiver.tag)
+	}
+	return strings.Join(receivers, ", ")
+}
+
 type actionReceiver struct {
 	receiverType string
 	tag          names.Tag
@@ -386,6 +782,21 @@ var getRunAPIClient = func(c *runCommand) (RunClient, error) {
 	return actionapi.NewClient(root), errors.Trace(err)
 }
 
+// runStatusClient exposes the capabilities required to expand the
+// targets of a run into individual machines and units.
+type runStatusClient interface {
+	Status(patterns []string) (*params.FullStatus, error)
+	Close() error
+}
+
+var getRunStatusAPIClient = func(c *runCommand) (runStatusClient, error) {
+	client, err := c.NewAPIClient()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return client, nil
+}
+
 // getActionResult abstracts over the action CLI function that we use here to fetch results
 var getActionResult = func(c RunClient, actionId string, wait *time.Timer) (params.ActionResult, error) {
 	return action.GetActionResult(c, actionId, wait)
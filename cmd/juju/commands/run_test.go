@@ -117,6 +117,18 @@ func (*RunSuite) TestTargetArgParsing(c *gc.C) {
 		machines: []string{"0"},
 		services: []string{"mysql"},
 		units:    []string{"wordpress/0", "wordpress/1"},
+	}, {
+		message:  "max-parallel and batch-size",
+		args:     []string{"--all", "--max-parallel=2", "--batch-size=2", "sudo reboot"},
+		errMatch: `You cannot specify both --max-parallel and --batch-size`,
+	}, {
+		message:  "negative max-parallel",
+		args:     []string{"--all", "--max-parallel=-1", "sudo reboot"},
+		errMatch: `--max-parallel must not be negative`,
+	}, {
+		message:  "negative batch-size",
+		args:     []string{"--all", "--batch-size=-1", "sudo reboot"},
+		errMatch: `--batch-size must not be negative`,
 	}} {
 		c.Log(fmt.Sprintf("%v: %s", i, test.message))
 		cmd := &runCommand{}
@@ -227,6 +239,14 @@ func (s *RunSuite) TestConvertRunResults(c *gc.C) {
 			"Message":    "msg",
 			"ReturnCode": 42,
 		},
+	}, {
+		message: "zero return code and timing are left out",
+		results: timedActionResult("0"),
+		query:   makeActionQuery(validUUID, "MachineId", names.NewMachineTag("1")),
+		expected: map[string]interface{}{
+			"MachineId": "1",
+			"Stdout":    "",
+		},
 	}} {
 		c.Log(fmt.Sprintf("%v: %s", i, test.message))
 		result := ConvertActionResults(test.results, test.query)
@@ -234,6 +254,41 @@ func (s *RunSuite) TestConvertRunResults(c *gc.C) {
 	}
 }
 
+func timedActionResult(code string) params.ActionResult {
+	result := makeActionResult(mockResponse{
+		machineTag: "machine-1",
+		code:       code,
+	}, "action-"+validUUID)
+	result.Enqueued = time.Date(2017, 3, 1, 12, 0, 0, 0, time.UTC)
+	result.Started = time.Date(2017, 3, 1, 12, 0, 1, 0, time.UTC)
+	result.Completed = time.Date(2017, 3, 1, 12, 0, 3, 500000000, time.UTC)
+	return result
+}
+
+func (s *RunSuite) TestConvertScheduledRunResults(c *gc.C) {
+	timing := map[string]string{
+		"Enqueued":  "2017-03-01 12:00:00 +0000 UTC",
+		"Started":   "2017-03-01 12:00:01 +0000 UTC",
+		"Completed": "2017-03-01 12:00:03.5 +0000 UTC",
+		"Duration":  "2.5s",
+	}
+	query := makeActionQuery(validUUID, "MachineId", names.NewMachineTag("1"))
+	result := convertScheduledActionResults(timedActionResult("0"), query)
+	c.Check(result, jc.DeepEquals, map[string]interface{}{
+		"MachineId":  "1",
+		"Stdout":     "",
+		"ReturnCode": 0,
+		"Timing":     timing,
+	})
+	result = convertScheduledActionResults(timedActionResult("3"), query)
+	c.Check(result, jc.DeepEquals, map[string]interface{}{
+		"MachineId":  "1",
+		"Stdout":     "",
+		"ReturnCode": 3,
+		"Timing":     timing,
+	})
+}
+
 func (s *RunSuite) TestRunForMachineAndUnit(c *gc.C) {
 	mock := s.setupMockAPI()
 	machineResponse := mockResponse{
@@ -416,6 +471,155 @@ func (c *mockClock) After(d time.Duration) <-chan time.Time {
 	return ch
 }
 
+// scheduledTimeAfter is used in place of time.After for runs scheduled
+// with --max-parallel or --batch-size: the poll interval elapses
+// immediately, and the timeout never does.
+func scheduledTimeAfter(d time.Duration) <-chan time.Time {
+	ch := make(chan time.Time)
+	if d == time.Second {
+		close(ch)
+	}
+	return ch
+}
+
+func (s *RunSuite) TestMaxParallel(c *gc.C) {
+	mock := s.setupMockAPI()
+	var results []interface{}
+	for _, id := range []string{"0", "1", "2"} {
+		mock.setResponse(id, mockResponse{
+			stdout:     "machine " + id,
+			code:       "0",
+			machineTag: "machine-" + id,
+		})
+		result := mock.runResponses[id]
+		mock.addActionResponse(id, result)
+		results = append(results, convertScheduledActionResults(
+			result, makeActionQuery(mock.receiverIdMap[id], "MachineId", names.NewMachineTag(id)),
+		))
+	}
+	var buf bytes.Buffer
+	err := cmd.FormatJson(&buf, results)
+	c.Assert(err, jc.ErrorIsNil)
+
+	context, err := testing.RunCommand(c, newRunCommand(scheduledTimeAfter),
+		"--format=json", "--machine=0,1,2", "--max-parallel=2", "hostname",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(context), gc.Equals, buf.String())
+	c.Check(mock.runCalls, gc.HasLen, 3)
+	for i, call := range mock.runCalls {
+		c.Check(call.Machines, jc.DeepEquals, []string{fmt.Sprint(i)})
+		c.Check(call.Commands, gc.Equals, "hostname")
+	}
+}
+
+func (s *RunSuite) TestBatchSizeStopsOnFailure(c *gc.C) {
+	mock := s.setupMockAPI()
+	s.PatchValue(&getRunStatusAPIClient, func(_ *runCommand) (runStatusClient, error) {
+		return &mockRunStatusAPI{status: &params.FullStatus{
+			Applications: map[string]params.ApplicationStatus{
+				"mysql": {Units: map[string]params.UnitStatus{
+					"mysql/0":  {},
+					"mysql/1":  {},
+					"mysql/2":  {},
+					"mysql/10": {},
+				}},
+			},
+		}}, nil
+	})
+	var results []interface{}
+	for _, unit := range []string{"mysql/0", "mysql/1"} {
+		code := "0"
+		if unit == "mysql/1" {
+			code = "1"
+		}
+		mock.setResponse(unit, mockResponse{
+			stdout:  "restarted",
+			code:    code,
+			unitTag: names.NewUnitTag(unit).String(),
+		})
+		result := mock.runResponses[unit]
+		mock.addActionResponse(unit, result)
+		results = append(results, convertScheduledActionResults(
+			result, makeActionQuery(mock.receiverIdMap[unit], "UnitId", names.NewUnitTag(unit)),
+		))
+	}
+	var buf bytes.Buffer
+	err := cmd.FormatJson(&buf, results)
+	c.Assert(err, jc.ErrorIsNil)
+
+	context, err := testing.RunCommand(c, newRunCommand(scheduledTimeAfter),
+		"--format=json", "--application=mysql", "--batch-size=2", "restart",
+	)
+	c.Assert(err, gc.ErrorMatches, "batch failed, commands not run on: unit mysql/2, unit mysql/10")
+	c.Check(testing.Stdout(context), gc.Equals, buf.String())
+	c.Check(mock.runCalls, gc.HasLen, 1)
+	c.Check(mock.runCalls[0].Units, jc.DeepEquals, []string{"mysql/0", "mysql/1"})
+}
+
+func (s *RunSuite) TestExpandTargets(c *gc.C) {
+	s.PatchValue(&getRunStatusAPIClient, func(_ *runCommand) (runStatusClient, error) {
+		return &mockRunStatusAPI{status: &params.FullStatus{
+			Machines: map[string]params.MachineStatus{
+				"10": {},
+				"2": {Containers: map[string]params.MachineStatus{
+					"2/lxd/1": {},
+					"2/lxd/0": {},
+				}},
+			},
+			Applications: map[string]params.ApplicationStatus{
+				"mysql": {Units: map[string]params.UnitStatus{
+					"mysql/0": {Subordinates: map[string]params.UnitStatus{
+						"logging/1": {},
+					}},
+					"mysql/1": {Subordinates: map[string]params.UnitStatus{
+						"logging/0": {},
+					}},
+				}},
+				"logging": {SubordinateTo: []string{"mysql"}},
+			},
+		}}, nil
+	})
+	for i, test := range []struct {
+		command  runCommand
+		expected []names.Tag
+		err      string
+	}{{
+		command: runCommand{all: true},
+		expected: []names.Tag{
+			names.NewMachineTag("2"),
+			names.NewMachineTag("2/lxd/0"),
+			names.NewMachineTag("2/lxd/1"),
+			names.NewMachineTag("10"),
+		},
+	}, {
+		command: runCommand{
+			machines: []string{"0"},
+			services: []string{"logging", "mysql"},
+			units:    []string{"mysql/0"},
+		},
+		expected: []names.Tag{
+			names.NewMachineTag("0"),
+			names.NewUnitTag("logging/0"),
+			names.NewUnitTag("logging/1"),
+			names.NewUnitTag("mysql/0"),
+			names.NewUnitTag("mysql/1"),
+		},
+	}, {
+		command: runCommand{services: []string{"wordpress"}},
+		err:     `application "wordpress" not found`,
+	}} {
+		c.Logf("test %d", i)
+		targets, err := test.command.expandTargets()
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, test.err)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(targets, jc.DeepEquals, test.expected)
+	}
+}
+
 func (s *RunSuite) TestBlockAllMachines(c *gc.C) {
 	mock := s.setupMockAPI()
 	// Block operation
@@ -509,6 +713,7 @@ type mockRunAPI struct {
 	actionResponses map[string]params.ActionResult
 	receiverIdMap   map[string]string
 	block           bool
+	runCalls        []params.RunParams
 }
 
 type mockResponse struct {
@@ -581,6 +786,13 @@ func (m *mockRunAPI) setResponse(id string, mock mockResponse) {
 	m.runResponses[id] = makeActionResult(mock, actionTag.String())
 }
 
+func (m *mockRunAPI) addActionResponse(id string, result params.ActionResult) {
+	if m.actionResponses == nil {
+		m.actionResponses = make(map[string]params.ActionResult)
+	}
+	m.actionResponses[m.receiverIdMap[id]] = result
+}
+
 func (*mockRunAPI) Close() error {
 	return nil
 }
@@ -616,6 +828,7 @@ func (m *mockRunAPI) RunOnAllMachines(commands string, timeout time.Duration) ([
 
 func (m *mockRunAPI) Run(runParams params.RunParams) ([]params.ActionResult, error) {
 	var result []params.ActionResult
+	m.runCalls = append(m.runCalls, runParams)
 
 	if m.block {
 		return result, common.OperationBlockedError("the operation has been blocked")
@@ -659,3 +872,15 @@ func (m *mockRunAPI) Actions(actionTags params.Entities) (params.ActionResults,
 
 // validUUID is a UUID used in tests
 var validUUID = "01234567-89ab-cdef-0123-456789abcdef"
+
+type mockRunStatusAPI struct {
+	status *params.FullStatus
+}
+
+func (m *mockRunStatusAPI) Status(patterns []string) (*params.FullStatus, error) {
+	return m.status, nil
+}
+
+func (*mockRunStatusAPI) Close() error {
+	return nil
+}
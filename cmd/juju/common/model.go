@@ -7,10 +7,13 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/dustin/go-humanize"
 	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
 	"github.com/juju/juju/apiserver/params"
+	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/status"
-	"gopkg.in/juju/names.v2"
 )
 
 // ModelInfo contains information about a model.
@@ -44,6 +47,23 @@ type ModelStatus struct {
 	Migration      string        `json:"migration,omitempty" yaml:"migration,omitempty"`
 	MigrationStart string        `json:"migration-start,omitempty" yaml:"migration-start,omitempty"`
 	MigrationEnd   string        `json:"migration-end,omitempty" yaml:"migration-end,omitempty"`
+
+	MigrationProgress *ModelMigrationProgress `json:"migration-progress,omitempty" yaml:"migration-progress,omitempty"`
+}
+
+// ModelMigrationProgress describes how far a model migration has got.
+type ModelMigrationProgress struct {
+	Phase         string                `json:"phase" yaml:"phase"`
+	Binaries      string                `json:"binaries,omitempty" yaml:"binaries,omitempty"`
+	MinionReports string                `json:"minion-reports,omitempty" yaml:"minion-reports,omitempty"`
+	Phases        []ModelMigrationPhase `json:"phases,omitempty" yaml:"phases,omitempty"`
+}
+
+// ModelMigrationPhase records how long a model migration spent in one
+// of its phases.
+type ModelMigrationPhase struct {
+	Phase   string `json:"phase" yaml:"phase"`
+	Elapsed string `json:"elapsed" yaml:"elapsed"`
 }
 
 // ModelUserInfo defines the serialization behaviour of the model user
@@ -78,6 +98,7 @@ func ModelInfoFromParams(info params.ModelInfo, now time.Time) (ModelInfo, error
 		status.Migration = info.Migration.Status
 		status.MigrationStart = friendlyDuration(info.Migration.Start, now)
 		status.MigrationEnd = friendlyDuration(info.Migration.End, now)
+		status.MigrationProgress = MigrationProgressFromParams(info.Migration, now)
 	}
 	cloudTag, err := names.ParseCloudTag(info.CloudTag)
 	if err != nil {
@@ -98,6 +119,44 @@ func ModelInfoFromParams(info params.ModelInfo, now time.Time) (ModelInfo, error
 	}, nil
 }
 
+// MigrationProgressFromParams translates the structured progress in a
+// params.ModelMigrationStatus to a ModelMigrationProgress. It returns
+// nil if the controller didn't report any.
+func MigrationProgressFromParams(migration *params.ModelMigrationStatus, now time.Time) *ModelMigrationProgress {
+	if migration == nil || migration.Phase == "" {
+		return nil
+	}
+	out := &ModelMigrationProgress{
+		Phase: migration.Phase,
+	}
+	if progress := migration.Progress; progress != nil {
+		if progress.BinariesTotal > 0 {
+			out.Binaries = fmt.Sprintf("%s of %s",
+				humanize.IBytes(uint64(progress.BinariesUploaded)),
+				humanize.IBytes(uint64(progress.BinariesTotal)))
+		}
+		if progress.MinionReportsExpected > 0 {
+			out.MinionReports = fmt.Sprintf("%d of %d",
+				progress.MinionReportsReceived, progress.MinionReportsExpected)
+		}
+	}
+	end := now
+	if migration.End != nil {
+		end = *migration.End
+	}
+	phases := make([]coremigration.PhaseTime, len(migration.PhaseTimes))
+	for i, phaseTime := range migration.PhaseTimes {
+		phases[i].Start = phaseTime.Start
+	}
+	for i, elapsed := range coremigration.PhaseDurations(phases, end) {
+		out.Phases = append(out.Phases, ModelMigrationPhase{
+			Phase:   migration.PhaseTimes[i].Phase,
+			Elapsed: ((elapsed / time.Second) * time.Second).String(),
+		})
+	}
+	return out
+}
+
 // ModelMachineInfoFromParams translates []params.ModelMachineInfo to a map of
 // machine ids to ModelMachineInfo.
 func ModelMachineInfoFromParams(machines []params.ModelMachineInfo) map[string]ModelMachineInfo {
@@ -36,6 +36,21 @@ func NewShowControllerCommandForTest(testStore jujuclient.ClientStore, api func(
 	}
 }
 
+// NewShowControllerHACommandForTest returns a showControllerCommand
+// which shows the replica set of each controller using the HA API
+// provided.
+func NewShowControllerHACommandForTest(
+	testStore jujuclient.ClientStore,
+	api func(string) ControllerAccessAPI,
+	haAPI func(string) ControllerReplicaSetAPI,
+) *showControllerCommand {
+	return &showControllerCommand{
+		store: testStore,
+		api:   api,
+		haAPI: haAPI,
+	}
+}
+
 type AddModelCommand struct {
 	*addModelCommand
 }
@@ -90,6 +105,17 @@ func NewEnableDestroyControllerCommandForTest(api removeBlocksAPI, store jujucli
 	return modelcmd.WrapController(c)
 }
 
+// NewControllerHealthCommandForTest returns a controllerHealthCommand
+// with the API and clock provided as specified.
+func NewControllerHealthCommandForTest(api controllerHealthAPI, store jujuclient.ClientStore, clock clock.Clock) cmd.Command {
+	c := &controllerHealthCommand{
+		api:   api,
+		clock: clock,
+	}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
 // NewDestroyCommandForTest returns a DestroyCommand with the controller and
 // client endpoints mocked out.
 func NewDestroyCommandForTest(
@@ -0,0 +1,314 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/clock"
+
+	apicontroller "github.com/juju/juju/api/controller"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// staleHealthReport holds how old a controller machine's health report
+// may be before it is flagged as a problem. Machines report every
+// minute.
+const staleHealthReport = 5 * time.Minute
+
+// NewControllerHealthCommand returns a command that reports on the
+// health of a controller.
+func NewControllerHealthCommand() cmd.Command {
+	return modelcmd.WrapController(&controllerHealthCommand{
+		clock: clock.WallClock,
+	})
+}
+
+// controllerHealthCommand reports on the health of every machine in a
+// controller.
+type controllerHealthCommand struct {
+	modelcmd.ControllerCommandBase
+	api   controllerHealthAPI
+	clock clock.Clock
+	out   cmd.Output
+}
+
+type controllerHealthAPI interface {
+	Close() error
+	ControllerHealth() (params.ControllerHealth, error)
+}
+
+const controllerHealthDoc = `
+controller-health gathers the state of every machine in a controller
+into a single report. It includes, for each controller machine, whether
+its agent is alive, whether it votes in the controller's database
+replica set, whether it is draining, how many API connections it is
+serving, and any of its agent's workers which have failed. Workers
+which are stopped only because they aren't needed on that machine are
+not reported.
+
+The status of each member of the database replica set is shown, along
+with the number of database transactions which are still pending and
+the number of cleanups queued across all models.
+
+Anything which looks wrong is summarised under "Problems". The details
+for each machine are those last reported by its agent; a machine whose
+agent hasn't reported for more than 5 minutes is flagged.
+
+Examples:
+
+    juju controller-health
+    juju controller-health -c mycontroller --format yaml
+
+See also:
+    enable-ha
+    drain-controller
+    show-controller
+`
+
+func (c *controllerHealthCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "controller-health",
+		Purpose: "Reports on the health of a controller's machines.",
+		Doc:     strings.TrimSpace(controllerHealthDoc),
+	}
+}
+
+func (c *controllerHealthCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"json":    cmd.FormatJson,
+		"tabular": formatControllerHealthTabular,
+		"yaml":    cmd.FormatYaml,
+	})
+}
+
+func (c *controllerHealthCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+func (c *controllerHealthCommand) getAPI() (controllerHealthAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return apicontroller.NewClient(root), nil
+}
+
+func (c *controllerHealthCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	health, err := client.ControllerHealth()
+	if errors.IsNotSupported(err) {
+		return errors.New("controller does not support health reports")
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	return c.out.Write(ctx, controllerHealthFromParams(health, c.clock.Now()))
+}
+
+type controllerHealthOutput struct {
+	Machines            []machineHealthOutput `yaml:"machines" json:"machines"`
+	ReplicaSet          []memberHealthOutput  `yaml:"replica-set,omitempty" json:"replica-set,omitempty"`
+	ReplicaSetError     string                `yaml:"replica-set-error,omitempty" json:"replica-set-error,omitempty"`
+	PendingTransactions int                   `yaml:"pending-transactions" json:"pending-transactions"`
+	CleanupBacklog      int                   `yaml:"cleanup-backlog" json:"cleanup-backlog"`
+	Problems            []string              `yaml:"problems,omitempty" json:"problems,omitempty"`
+}
+
+type machineHealthOutput struct {
+	Id               string               `yaml:"id" json:"id"`
+	Agent            string               `yaml:"agent" json:"agent"`
+	Vote             string               `yaml:"vote" json:"vote"`
+	Draining         bool                 `yaml:"draining,omitempty" json:"draining,omitempty"`
+	Reported         string               `yaml:"reported,omitempty" json:"reported,omitempty"`
+	APIConnections   int                  `yaml:"api-connections" json:"api-connections"`
+	AgentConnections int                  `yaml:"agent-connections" json:"agent-connections"`
+	Workers          int                  `yaml:"workers" json:"workers"`
+	FailedWorkers    []workerHealthOutput `yaml:"failed-workers,omitempty" json:"failed-workers,omitempty"`
+}
+
+type workerHealthOutput struct {
+	Name  string `yaml:"name" json:"name"`
+	State string `yaml:"state" json:"state"`
+	Error string `yaml:"error,omitempty" json:"error,omitempty"`
+}
+
+type memberHealthOutput struct {
+	Id        int    `yaml:"id" json:"id"`
+	Address   string `yaml:"address" json:"address"`
+	MachineId string `yaml:"machine,omitempty" json:"machine,omitempty"`
+	State     string `yaml:"state" json:"state"`
+	Healthy   bool   `yaml:"healthy" json:"healthy"`
+	Voting    bool   `yaml:"voting" json:"voting"`
+	Message   string `yaml:"message,omitempty" json:"message,omitempty"`
+}
+
+// controllerHealthFromParams converts the controller's health report
+// into the command's output, noting any problems found in it.
+func controllerHealthFromParams(health params.ControllerHealth, now time.Time) controllerHealthOutput {
+	out := controllerHealthOutput{
+		ReplicaSetError:     health.ReplicaSetError,
+		PendingTransactions: health.PendingTransactions,
+		CleanupBacklog:      health.CleanupBacklog,
+	}
+	problem := func(format string, args ...interface{}) {
+		out.Problems = append(out.Problems, fmt.Sprintf(format, args...))
+	}
+
+	for _, machine := range health.Machines {
+		m := machineHealthOutput{
+			Id:               machine.Id,
+			Agent:            "down",
+			Vote:             voteStatus(machine.WantsVote, machine.HasVote),
+			Draining:         machine.Draining,
+			APIConnections:   machine.APIConnections,
+			AgentConnections: machine.AgentConnections,
+			Workers:          machine.Workers,
+		}
+		if machine.AgentAlive {
+			m.Agent = "alive"
+		} else {
+			problem("machine %s: agent is down", machine.Id)
+		}
+		if machine.WantsVote != machine.HasVote {
+			problem("machine %s: replica set vote is %s", machine.Id, m.Vote)
+		}
+		if machine.Reported == nil {
+			problem("machine %s: no health report", machine.Id)
+		} else {
+			age := now.Sub(*machine.Reported)
+			m.Reported = formatAge(age)
+			if age > staleHealthReport {
+				problem("machine %s: health last reported %s", machine.Id, m.Reported)
+			}
+		}
+		for _, worker := range machine.UnhealthyWorkers {
+			m.FailedWorkers = append(m.FailedWorkers, workerHealthOutput{
+				Name:  worker.Name,
+				State: worker.State,
+				Error: worker.Error,
+			})
+			problem("machine %s: worker %q %s: %s", machine.Id, worker.Name, worker.State, worker.Error)
+		}
+		out.Machines = append(out.Machines, m)
+	}
+
+	if health.ReplicaSetError != "" {
+		problem("replica set: %s", health.ReplicaSetError)
+	}
+	havePrimary := false
+	for _, member := range health.ReplicaSet {
+		out.ReplicaSet = append(out.ReplicaSet, memberHealthOutput{
+			Id:        member.Id,
+			Address:   member.Address,
+			MachineId: member.MachineId,
+			State:     member.State,
+			Healthy:   member.Healthy,
+			Voting:    member.Voting,
+			Message:   member.Message,
+		})
+		if member.State == "PRIMARY" {
+			havePrimary = true
+		}
+		if !member.Healthy {
+			problem("replica set member %d (%s) is unhealthy", member.Id, member.Address)
+		}
+	}
+	if len(health.ReplicaSet) > 0 && !havePrimary {
+		problem("replica set has no primary")
+	}
+	return out
+}
+
+// voteStatus describes a controller machine's part in the replica set
+// election.
+func voteStatus(wantsVote, hasVote bool) string {
+	switch {
+	case wantsVote && hasVote:
+		return "voting"
+	case wantsVote:
+		return "adding-vote"
+	case hasVote:
+		return "removing-vote"
+	}
+	return "no-vote"
+}
+
+// formatAge describes how long ago something happened, to the second.
+func formatAge(age time.Duration) string {
+	if age < 0 {
+		age = 0
+	}
+	return (age / time.Second * time.Second).String() + " ago"
+}
+
+func formatControllerHealthTabular(writer io.Writer, value interface{}) error {
+	health, ok := value.(controllerHealthOutput)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", health, value)
+	}
+
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+
+	w.Println("Machine", "Agent", "Vote", "Draining", "API", "Agents", "Workers", "Failed", "Reported")
+	for _, m := range health.Machines {
+		draining := ""
+		if m.Draining {
+			draining = "yes"
+		}
+		reported := m.Reported
+		if reported == "" {
+			reported = "never"
+		}
+		w.Println(m.Id, m.Agent, m.Vote, draining, m.APIConnections, m.AgentConnections,
+			m.Workers, len(m.FailedWorkers), reported)
+	}
+
+	if len(health.ReplicaSet) > 0 {
+		w.Println()
+		w.Println("Member", "Address", "Machine", "State", "Healthy", "Voting")
+		for _, member := range health.ReplicaSet {
+			w.Println(member.Id, member.Address, member.MachineId, member.State,
+				yesNo(member.Healthy), yesNo(member.Voting))
+		}
+	}
+
+	w.Println()
+	w.Println("Pending transactions:", health.PendingTransactions)
+	w.Println("Cleanup backlog:", health.CleanupBacklog)
+
+	if len(health.Problems) > 0 {
+		w.Println()
+		w.Println("Problems:")
+		for _, problem := range health.Problems {
+			w.Println("  " + problem)
+		}
+	}
+	w.Flush()
+	return nil
+}
+
+func yesNo(value bool) string {
+	if value {
+		return "yes"
+	}
+	return "no"
+}
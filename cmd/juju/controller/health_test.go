@@ -0,0 +1,197 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/testing"
+)
+
+type ControllerHealthSuite struct {
+	baseControllerSuite
+	api *fakeControllerHealthAPI
+	now time.Time
+}
+
+var _ = gc.Suite(&ControllerHealthSuite{})
+
+func (s *ControllerHealthSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+	s.createTestClientStore(c)
+	s.now = time.Date(2017, 5, 1, 12, 0, 0, 0, time.UTC)
+	s.api = &fakeControllerHealthAPI{}
+}
+
+func (s *ControllerHealthSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := controller.NewControllerHealthCommandForTest(s.api, s.store, jujutesting.NewClock(s.now))
+	return testing.RunCommand(c, command, args...)
+}
+
+func (s *ControllerHealthSuite) reportedAgo(d time.Duration) *time.Time {
+	reported := s.now.Add(-d)
+	return &reported
+}
+
+func (s *ControllerHealthSuite) TestInit(c *gc.C) {
+	command := controller.NewControllerHealthCommandForTest(s.api, s.store, jujutesting.NewClock(s.now))
+	err := testing.InitCommand(command, []string{"foo"})
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["foo"\]`)
+}
+
+func (s *ControllerHealthSuite) TestTabular(c *gc.C) {
+	s.api.health = params.ControllerHealth{
+		Machines: []params.ControllerMachineHealth{{
+			Id:               "0",
+			AgentAlive:       true,
+			WantsVote:        true,
+			HasVote:          true,
+			Reported:         s.reportedAgo(30 * time.Second),
+			APIConnections:   6,
+			AgentConnections: 4,
+			Workers:          20,
+		}, {
+			Id:        "1",
+			WantsVote: true,
+			Draining:  true,
+			Reported:  s.reportedAgo(10 * time.Minute),
+			Workers:   18,
+			UnhealthyWorkers: []params.WorkerHealth{{
+				Name:  "peer-grouper",
+				State: "stopped",
+				Error: "boom",
+			}},
+		}},
+		ReplicaSet: []params.ReplicaSetMemberHealth{{
+			Id:        1,
+			Address:   "10.0.0.1:37017",
+			MachineId: "0",
+			State:     "PRIMARY",
+			Healthy:   true,
+			Voting:    true,
+		}, {
+			Id:        2,
+			Address:   "10.0.0.2:37017",
+			MachineId: "1",
+			State:     "SECONDARY",
+			Voting:    true,
+		}},
+		PendingTransactions: 2,
+		CleanupBacklog:      1,
+	}
+
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(ctx), gc.Equals, ""+
+		"Machine Agent Vote        Draining API Agents Workers Failed Reported\n"+
+		"0       alive voting               6   4      20      0      30s ago\n"+
+		"1       down  adding-vote yes      0   0      18      1      10m0s ago\n"+
+		"\n"+
+		"Member Address        Machine State     Healthy Voting\n"+
+		"1      10.0.0.1:37017 0       PRIMARY   yes     yes\n"+
+		"2      10.0.0.2:37017 1       SECONDARY no      yes\n"+
+		"\n"+
+		"Pending transactions: 2\n"+
+		"Cleanup backlog:      1\n"+
+		"\n"+
+		"Problems:\n"+
+		"  machine 1: agent is down\n"+
+		"  machine 1: replica set vote is adding-vote\n"+
+		"  machine 1: health last reported 10m0s ago\n"+
+		"  machine 1: worker \"peer-grouper\" stopped: boom\n"+
+		"  replica set member 2 (10.0.0.2:37017) is unhealthy\n")
+}
+
+func (s *ControllerHealthSuite) TestProblems(c *gc.C) {
+	s.api.health = params.ControllerHealth{
+		Machines: []params.ControllerMachineHealth{{
+			Id:         "0",
+			AgentAlive: true,
+			HasVote:    true,
+		}},
+		ReplicaSetError: "cannot get replica set status: boom",
+	}
+	problems := s.runProblems(c)
+	c.Check(problems, jc.DeepEquals, []interface{}{
+		"machine 0: replica set vote is removing-vote",
+		"machine 0: no health report",
+		"replica set: cannot get replica set status: boom",
+	})
+
+	s.api.health = params.ControllerHealth{
+		ReplicaSet: []params.ReplicaSetMemberHealth{{
+			Id:      1,
+			Address: "10.0.0.1:37017",
+			State:   "SECONDARY",
+			Healthy: true,
+		}},
+	}
+	problems = s.runProblems(c)
+	c.Check(problems, jc.DeepEquals, []interface{}{
+		"replica set has no primary",
+	})
+}
+
+func (s *ControllerHealthSuite) TestHealthy(c *gc.C) {
+	s.api.health = params.ControllerHealth{
+		Machines: []params.ControllerMachineHealth{{
+			Id:         "0",
+			AgentAlive: true,
+			WantsVote:  true,
+			HasVote:    true,
+			Reported:   s.reportedAgo(time.Minute),
+		}},
+		ReplicaSet: []params.ReplicaSetMemberHealth{{
+			Id:      1,
+			Address: "10.0.0.1:37017",
+			State:   "PRIMARY",
+			Healthy: true,
+			Voting:  true,
+		}},
+	}
+	c.Check(s.runProblems(c), gc.IsNil)
+}
+
+func (s *ControllerHealthSuite) runProblems(c *gc.C) interface{} {
+	ctx, err := s.run(c, "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	var out map[string]interface{}
+	err = json.Unmarshal([]byte(testing.Stdout(ctx)), &out)
+	c.Assert(err, jc.ErrorIsNil)
+	return out["problems"]
+}
+
+func (s *ControllerHealthSuite) TestNotSupported(c *gc.C) {
+	s.api.err = errors.NotSupportedf("ControllerHealth")
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "controller does not support health reports")
+}
+
+func (s *ControllerHealthSuite) TestError(c *gc.C) {
+	s.api.err = errors.New("boom")
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type fakeControllerHealthAPI struct {
+	health params.ControllerHealth
+	err    error
+}
+
+func (f *fakeControllerHealthAPI) Close() error {
+	return nil
+}
+
+func (f *fakeControllerHealthAPI) ControllerHealth() (params.ControllerHealth, error) {
+	return f.health, f.err
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/controller"
+	"github.com/juju/juju/api/highavailability"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/environs/bootstrap"
@@ -28,12 +29,18 @@ var usageShowControllerDetails = `
 Shows extended information about a controller(s) as well as related models
 and user login details.
 
+Use --ha to also show the replica set settings of each controller
+machine, as set with set-controller-member, and the resulting
+configuration of the controller's database replica set.
+
 Examples:
     juju show-controller
     juju show-controller aws google
+    juju show-controller --ha
     
 See also: 
-    controllers`[1:]
+    controllers
+    set-controller-member`[1:]
 
 type showControllerCommand struct {
 	modelcmd.JujuCommandBase
@@ -41,9 +48,11 @@ type showControllerCommand struct {
 	out   cmd.Output
 	store jujuclient.ClientStore
 	api   func(controllerName string) ControllerAccessAPI
+	haAPI func(controllerName string) ControllerReplicaSetAPI
 
 	controllerNames []string
 	showPasswords   bool
+	showHA          bool
 }
 
 // NewShowControllerCommand returns a command to show details of the desired controllers.
@@ -74,6 +83,7 @@ func (c *showControllerCommand) Info() *cmd.Info {
 func (c *showControllerCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.JujuCommandBase.SetFlags(f)
 	f.BoolVar(&c.showPasswords, "show-password", false, "Show password for logged in user")
+	f.BoolVar(&c.showHA, "ha", false, "Show the controller's replica set configuration")
 	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
 		"yaml": cmd.FormatYaml,
 		"json": cmd.FormatJson,
@@ -89,6 +99,24 @@ type ControllerAccessAPI interface {
 	Close() error
 }
 
+// ControllerReplicaSetAPI defines a subset of the
+// api/highavailability/Client API.
+type ControllerReplicaSetAPI interface {
+	ControllerReplicaSet() (params.ControllerReplicaSet, error)
+	Close() error
+}
+
+func (c *showControllerCommand) getHAAPI(controllerName string) (ControllerReplicaSetAPI, error) {
+	if c.haAPI != nil {
+		return c.haAPI(controllerName), nil
+	}
+	api, err := c.NewAPIRoot(c.store, controllerName, "")
+	if err != nil {
+		return nil, errors.Annotate(err, "opening API connection")
+	}
+	return highavailability.NewClient(api), nil
+}
+
 func (c *showControllerCommand) getAPI(controllerName string) (ControllerAccessAPI, error) {
 	if c.api != nil {
 		return c.api(controllerName), nil
@@ -150,6 +178,9 @@ func (c *showControllerCommand) Run(ctx *cmd.Context) error {
 			continue
 		}
 		c.convertControllerForShow(&details, controllerName, one, access, allModels, modelStatus)
+		if c.showHA {
+			c.convertReplicaSetForShow(controllerName, &details)
+		}
 		controllers[controllerName] = details
 	}
 	return c.out.Write(ctx, controllers)
@@ -204,6 +235,9 @@ type ShowControllerDetails struct {
 	// Account is the account details for the user logged into this controller.
 	Account *AccountDetails `yaml:"account,omitempty" json:"account,omitempty"`
 
+	// ReplicaSet describes the controller's replica set, if requested.
+	ReplicaSet *ReplicaSetDetails `yaml:"replica-set,omitempty" json:"replica-set,omitempty"`
+
 	// Errors is a collection of errors related to accessing this controller details.
 	Errors []string `yaml:"errors,omitempty" json:"errors,omitempty"`
 }
@@ -256,6 +290,40 @@ type ModelDetails struct {
 	CoreCount *int `yaml:"core-count,omitempty" json:"core-count,omitempty"`
 }
 
+// ReplicaSetDetails holds details of a controller's replica set to show.
+type ReplicaSetDetails struct {
+	// Machines holds the replica set settings of each controller
+	// machine, keyed by machine id.
+	Machines map[string]ControllerMemberDetails `yaml:"machines" json:"machines"`
+
+	// Members holds the configuration of each member of the
+	// controller's replica set.
+	Members []ReplicaSetMemberDetails `yaml:"members" json:"members"`
+}
+
+// ControllerMemberDetails holds the replica set settings of a
+// controller machine to show.
+type ControllerMemberDetails struct {
+	// NonVoting is true if the machine has been set never to vote.
+	NonVoting bool `yaml:"non-voting,omitempty" json:"non-voting,omitempty"`
+
+	// Priority holds the machine's election priority while voting.
+	Priority float64 `yaml:"priority" json:"priority"`
+
+	// Vote describes the machine's current part in the election.
+	Vote string `yaml:"vote" json:"vote"`
+}
+
+// ReplicaSetMemberDetails holds the configuration of a replica set
+// member to show.
+type ReplicaSetMemberDetails struct {
+	Id       int     `yaml:"id" json:"id"`
+	Address  string  `yaml:"address" json:"address"`
+	Machine  string  `yaml:"machine,omitempty" json:"machine,omitempty"`
+	Voting   bool    `yaml:"voting" json:"voting"`
+	Priority float64 `yaml:"priority" json:"priority"`
+}
+
 // AccountDetails holds details of an account to show.
 type AccountDetails struct {
 	// User is the username for the account.
@@ -384,6 +452,44 @@ func (c *showControllerCommand) convertMachinesForShow(
 	}
 }
 
+func (c *showControllerCommand) convertReplicaSetForShow(controllerName string, controller *ShowControllerDetails) {
+	client, err := c.getHAAPI(controllerName)
+	if err != nil {
+		controller.Errors = append(controller.Errors, err.Error())
+		return
+	}
+	defer client.Close()
+
+	replicaSet, err := client.ControllerReplicaSet()
+	if errors.IsNotSupported(err) {
+		err = errors.New("controller does not support showing its replica set")
+	}
+	if err != nil {
+		controller.Errors = append(controller.Errors, err.Error())
+		return
+	}
+	details := &ReplicaSetDetails{
+		Machines: make(map[string]ControllerMemberDetails),
+	}
+	for _, m := range replicaSet.Machines {
+		details.Machines[m.MachineId] = ControllerMemberDetails{
+			NonVoting: !m.Voting,
+			Priority:  m.Priority,
+			Vote:      voteStatus(m.WantsVote, m.HasVote),
+		}
+	}
+	for _, member := range replicaSet.Members {
+		details.Members = append(details.Members, ReplicaSetMemberDetails{
+			Id:       member.Id,
+			Address:  member.Address,
+			Machine:  member.MachineId,
+			Voting:   member.Voting,
+			Priority: member.Priority,
+		})
+	}
+	controller.ReplicaSet = details
+}
+
 func haStatus(hasVote bool, wantsVote bool, statusStr string) string {
 	if statusStr == string(status.Down) {
 		return "down, lost connection"
@@ -13,6 +13,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/controller"
 	"github.com/juju/juju/jujuclient"
 	"github.com/juju/juju/jujuclient/jujuclienttesting"
@@ -126,6 +127,115 @@ mallards:
 	s.assertShowController(c, "mallards", "--show-password")
 }
 
+func (s *ShowControllerSuite) TestShowControllerHA(c *gc.C) {
+	s.controllersYaml = `controllers:
+  mallards:
+    uuid: this-is-another-uuid
+    api-endpoints: [this-is-another-of-many-api-endpoints]
+    ca-cert: this-is-another-ca-cert
+    cloud: mallards
+`
+	s.fakeController.store = s.createTestClientStore(c)
+	haAPI := &fakeReplicaSetAPI{
+		replicaSet: params.ControllerReplicaSet{
+			Machines: []params.ControllerMember{{
+				MachineId: "0",
+				Voting:    true,
+				Priority:  10,
+				WantsVote: true,
+				HasVote:   true,
+			}, {
+				MachineId: "1",
+				Priority:  1,
+				HasVote:   true,
+			}},
+			Members: []params.ReplicaSetMemberConfig{{
+				Id:        1,
+				Address:   "10.0.0.1:37017",
+				MachineId: "0",
+				Voting:    true,
+				Priority:  10,
+			}, {
+				Id:        2,
+				Address:   "10.0.0.2:37017",
+				MachineId: "1",
+				Voting:    true,
+				Priority:  1,
+			}},
+		},
+	}
+
+	s.expectedOutput = `
+mallards:
+  details:
+    uuid: this-is-another-uuid
+    api-endpoints: [this-is-another-of-many-api-endpoints]
+    ca-cert: this-is-another-ca-cert
+    cloud: mallards
+    agent-version: 999.99.99
+  models:
+    controller:
+      uuid: abc
+      machine-count: 2
+      core-count: 4
+    my-model:
+      uuid: def
+      machine-count: 2
+      core-count: 4
+  current-model: my-model
+  account:
+    user: admin
+    access: superuser
+  replica-set:
+    machines:
+      "0":
+        priority: 10
+        vote: voting
+      "1":
+        non-voting: true
+        priority: 1
+        vote: removing-vote
+    members:
+    - id: 1
+      address: 10.0.0.1:37017
+      machine: "0"
+      voting: true
+      priority: 10
+    - id: 2
+      address: 10.0.0.2:37017
+      machine: "1"
+      voting: true
+      priority: 1
+`[1:]
+
+	command := controller.NewShowControllerHACommandForTest(s.store, s.api, func(string) controller.ControllerReplicaSetAPI {
+		return haAPI
+	})
+	ctx, err := testing.RunCommand(c, command, "mallards", "--ha")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(ctx), gc.Equals, s.expectedOutput)
+}
+
+func (s *ShowControllerSuite) TestShowControllerHANotSupported(c *gc.C) {
+	s.controllersYaml = `controllers:
+  mallards:
+    uuid: this-is-another-uuid
+    api-endpoints: [this-is-another-of-many-api-endpoints]
+    ca-cert: this-is-another-ca-cert
+    cloud: mallards
+`
+	s.fakeController.store = s.createTestClientStore(c)
+	haAPI := &fakeReplicaSetAPI{err: errors.NotSupportedf("controller member settings")}
+
+	command := controller.NewShowControllerHACommandForTest(s.store, s.api, func(string) controller.ControllerReplicaSetAPI {
+		return haAPI
+	})
+	ctx, err := testing.RunCommand(c, command, "mallards", "--ha", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(ctx), jc.Contains, `"errors":["controller does not support showing its replica set"]`)
+	c.Check(testing.Stdout(ctx), gc.Not(jc.Contains), `"replica-set"`)
+}
+
 func (s *ShowControllerSuite) TestShowControllerWithBootstrapConfig(c *gc.C) {
 	s.controllersYaml = `controllers:
   mallards:
@@ -386,3 +496,16 @@ func (c *fakeController) AllModels() (result []base.UserModel, _ error) {
 func (*fakeController) Close() error {
 	return nil
 }
+
+type fakeReplicaSetAPI struct {
+	replicaSet params.ControllerReplicaSet
+	err        error
+}
+
+func (f *fakeReplicaSetAPI) ControllerReplicaSet() (params.ControllerReplicaSet, error) {
+	return f.replicaSet, f.err
+}
+
+func (*fakeReplicaSetAPI) Close() error {
+	return nil
+}
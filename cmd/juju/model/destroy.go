@@ -48,9 +48,10 @@ type destroyCommand struct {
 	// sleepFunc is used when calling the timed function to get model status updates.
 	sleepFunc func(time.Duration)
 
-	envName   string
-	assumeYes bool
-	api       DestroyModelAPI
+	envName        string
+	assumeYes      bool
+	releaseStorage bool
+	api            DestroyModelAPI
 }
 
 var destroyDoc = `
@@ -60,10 +61,19 @@ there. Due to the irreversible nature of the command, it will prompt for
 confirmation (unless overridden with the '-y' option) before taking any
 action.
 
+By default, the model's cloud volumes are destroyed along with the model.
+If --release-storage is specified, the volumes are instead detached and
+left intact in the cloud, tagged with the model and storage they were
+released from, so that they may later be imported into another model with
+"juju import-filesystem" or "juju import-volume". The model is not destroyed
+if any of its volumes are managed by a storage provider that cannot release
+volumes.
+
 Examples:
 
     juju destroy-model test
     juju destroy-model -y mymodel
+    juju destroy-model --release-storage mymodel
 
 See also:
     destroy-controller
@@ -79,6 +89,7 @@ Continue [y/N]? `[1:]
 type DestroyModelAPI interface {
 	Close() error
 	DestroyModel(names.ModelTag) error
+	DestroyModelReleasingStorage(names.ModelTag) error
 	ModelStatus(models ...names.ModelTag) ([]base.ModelStatus, error)
 }
 
@@ -97,6 +108,7 @@ func (c *destroyCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
 	f.BoolVar(&c.assumeYes, "y", false, "Do not prompt for confirmation")
 	f.BoolVar(&c.assumeYes, "yes", false, "")
+	f.BoolVar(&c.releaseStorage, "release-storage", false, "Release the model's cloud volumes rather than destroying them")
 }
 
 // Init implements Command.Init.
@@ -165,7 +177,12 @@ func (c *destroyCommand) Run(ctx *cmd.Context) error {
 
 	// Attempt to destroy the model.
 	ctx.Infof("Destroying model")
-	err = api.DestroyModel(names.NewModelTag(modelDetails.ModelUUID))
+	modelTag := names.NewModelTag(modelDetails.ModelUUID)
+	if c.releaseStorage {
+		err = api.DestroyModelReleasingStorage(modelTag)
+	} else {
+		err = api.DestroyModel(modelTag)
+	}
 	if err != nil {
 		return c.handleError(errors.Annotate(err, "cannot destroy model"), modelName)
 	}
@@ -40,6 +40,7 @@ type fakeAPI struct {
 	env             map[string]interface{}
 	statusCallCount int
 	modelInfoErr    []*params.Error
+	releasedStorage bool
 }
 
 func (f *fakeAPI) Close() error { return nil }
@@ -48,6 +49,11 @@ func (f *fakeAPI) DestroyModel(names.ModelTag) error {
 	return f.err
 }
 
+func (f *fakeAPI) DestroyModelReleasingStorage(names.ModelTag) error {
+	f.releasedStorage = true
+	return f.err
+}
+
 func (f *fakeAPI) ModelStatus(models ...names.ModelTag) ([]base.ModelStatus, error) {
 	var err *params.Error = &params.Error{Code: params.CodeNotFound}
 	if f.statusCallCount < len(f.modelInfoErr) {
@@ -147,6 +153,13 @@ func (s *DestroySuite) TestDestroy(c *gc.C) {
 	checkModelRemovedFromStore(c, "test1:admin/test2", s.store)
 }
 
+func (s *DestroySuite) TestDestroyReleasingStorage(c *gc.C) {
+	_, err := s.runDestroyCommand(c, "test2", "-y", "--release-storage")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.releasedStorage, jc.IsTrue)
+	checkModelRemovedFromStore(c, "test1:admin/test2", s.store)
+}
+
 func (s *DestroySuite) TestDestroyBlocks(c *gc.C) {
 	checkModelExistsInStore(c, "test1:admin/test2", s.store)
 	s.api.modelInfoErr = []*params.Error{{}, {Code: params.CodeNotFound}}
@@ -107,3 +107,25 @@ func NewRevokeCommandForTest(api RevokeModelAPI, store jujuclient.ClientStore) (
 	cmd.SetClientStore(store)
 	return modelcmd.WrapController(cmd), &RevokeCommand{cmd}
 }
+
+// NewExportCommandForTest returns an ExportCommand with the apis provided as specified.
+func NewExportCommandForTest(api ExportModelAPI, downloader ModelDownloader, store jujuclient.ClientStore) cmd.Command {
+	cmd := &exportCommand{api: api, downloader: downloader}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewImportCommandForTest returns an ImportCommand with the api provided as specified.
+func NewImportCommandForTest(api ImportModelAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &importCommand{api: api}
+	cmd.SetClientStore(store)
+	return modelcmd.WrapController(cmd)
+}
+
+// NewRestoreModelCommandForTest returns a RestoreModelCommand with the
+// apis provided as specified.
+func NewRestoreModelCommandForTest(backupsAPI RestoreModelBackupsAPI, importAPI ImportModelAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &restoreModelCommand{backupsAPI: backupsAPI, importAPI: importAPI}
+	cmd.SetClientStore(store)
+	return modelcmd.WrapController(cmd)
+}
@@ -0,0 +1,147 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"os"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/modelmanager"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/migration"
+)
+
+// NewExportCommand returns a fully constructed export-model command.
+func NewExportCommand() cmd.Command {
+	return modelcmd.Wrap(&exportCommand{})
+}
+
+type exportCommand struct {
+	modelcmd.ModelCommandBase
+	api        ExportModelAPI
+	downloader ModelDownloader
+
+	filename string
+}
+
+const exportModelHelpDoc = `
+Writes a self-contained archive of the model to the given file. The
+archive holds the model's description along with the charms, agent
+binaries and resources the model uses, so it can be imported with
+"juju import-model" into a controller that has no network access to
+this one.
+
+The model is left running on this controller. The imported model stays
+inactive until its agents have been pointed at the new controller and
+it is activated with "juju import-model --activate"; only then does the
+new controller take over the model's machines.
+
+Examples:
+
+    juju export-model mymodel.tar.gz
+    juju export-model -m othermodel othermodel.tar.gz
+
+See also:
+    import-model
+    migrate
+`
+
+// Info implements Command.
+func (c *exportCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "export-model",
+		Args:    "<file>",
+		Purpose: "Exports a model and its binaries to an archive file.",
+		Doc:     exportModelHelpDoc,
+	}
+}
+
+// Init implements Command.
+func (c *exportCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no archive file specified")
+	}
+	c.filename, args = args[0], args[1:]
+	return cmd.CheckEmpty(args)
+}
+
+// ExportModelAPI specifies the used function calls of the ModelManager.
+type ExportModelAPI interface {
+	Close() error
+	ExportModel(names.ModelTag) ([]byte, error)
+}
+
+// ModelDownloader downloads the binaries used by a model.
+type ModelDownloader interface {
+	migration.CharmDownloader
+	migration.ToolsDownloader
+	migration.ResourceDownloader
+	Close() error
+}
+
+func (c *exportCommand) getAPI() (ExportModelAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewControllerAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return modelmanager.NewClient(root), nil
+}
+
+func (c *exportCommand) getDownloader() (ModelDownloader, error) {
+	if c.downloader != nil {
+		return c.downloader, nil
+	}
+	return c.NewAPIClient()
+}
+
+// Run implements Command.
+func (c *exportCommand) Run(ctx *cmd.Context) error {
+	downloader, err := c.getDownloader()
+	if err != nil {
+		return err
+	}
+	defer downloader.Close()
+
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	modelDetails, err := c.ClientStore().ModelByName(c.ControllerName(), c.ModelName())
+	if err != nil {
+		return errors.Annotate(err, "getting model details")
+	}
+	bytes, err := client.ExportModel(names.NewModelTag(modelDetails.ModelUUID))
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	filename := ctx.AbsPath(c.filename)
+	f, err := os.Create(filename)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = migration.WriteModelArchive(f, migration.WriteModelArchiveConfig{
+		Model:              bytes,
+		CharmDownloader:    downloader,
+		ToolsDownloader:    downloader,
+		ResourceDownloader: downloader,
+	})
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(filename)
+		return errors.Annotate(err, "writing model archive")
+	}
+	ctx.Infof("Model %q exported to %s", c.ModelName(), c.filename)
+	return nil
+}
@@ -0,0 +1,159 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/migration"
+	"github.com/juju/juju/testing"
+)
+
+type ExportCommandSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake       fakeExportClient
+	downloader fakeModelDownloader
+	store      *jujuclienttesting.MemStore
+}
+
+var _ = gc.Suite(&ExportCommandSuite{})
+
+type fakeExportClient struct {
+	gitjujutesting.Stub
+	bytes []byte
+}
+
+func (f *fakeExportClient) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeExportClient) ExportModel(model names.ModelTag) ([]byte, error) {
+	f.MethodCall(f, "ExportModel", model)
+	return f.bytes, f.NextErr()
+}
+
+type fakeModelDownloader struct {
+	gitjujutesting.Stub
+}
+
+func (f *fakeModelDownloader) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeModelDownloader) OpenCharm(curl *charm.URL) (io.ReadCloser, error) {
+	f.MethodCall(f, "OpenCharm", curl)
+	return nil, errors.NotImplementedf("OpenCharm")
+}
+
+func (f *fakeModelDownloader) OpenURI(uri string, query url.Values) (io.ReadCloser, error) {
+	f.MethodCall(f, "OpenURI", uri, query)
+	return nil, errors.NotImplementedf("OpenURI")
+}
+
+func (f *fakeModelDownloader) OpenResource(application, name string) (io.ReadCloser, error) {
+	f.MethodCall(f, "OpenResource", application, name)
+	return nil, errors.NotImplementedf("OpenResource")
+}
+
+// serializedTestModel returns a serialized model without any
+// applications or machines, and so without any binaries.
+func serializedTestModel(c *gc.C) []byte {
+	m := description.NewModel(description.ModelArgs{
+		Owner: names.NewUserTag("admin"),
+		Config: map[string]interface{}{
+			"name":          "mymodel",
+			"uuid":          testing.ModelTag.Id(),
+			"agent-version": version.MustParse("2.2.0").String(),
+		},
+	})
+	bytes, err := description.Serialize(m)
+	c.Assert(err, jc.ErrorIsNil)
+	return bytes
+}
+
+func (s *ExportCommandSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = fakeExportClient{bytes: serializedTestModel(c)}
+	s.downloader = fakeModelDownloader{}
+	s.store = jujuclienttesting.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	err := s.store.UpdateModel("testing", "admin/mymodel", jujuclient.ModelDetails{
+		testing.ModelTag.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.store.Models["testing"].CurrentModel = "admin/mymodel"
+}
+
+func (s *ExportCommandSuite) runExport(c *gc.C, args ...string) (string, error) {
+	dir := c.MkDir()
+	cmd := model.NewExportCommandForTest(&s.fake, &s.downloader, s.store)
+	ctx := testing.ContextForDir(c, dir)
+	err := testing.InitCommand(cmd, args)
+	if err != nil {
+		return dir, err
+	}
+	return dir, cmd.Run(ctx)
+}
+
+func (s *ExportCommandSuite) TestInit(c *gc.C) {
+	_, err := s.runExport(c)
+	c.Assert(err, gc.ErrorMatches, "no archive file specified")
+	_, err = s.runExport(c, "a.tgz", "b.tgz")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["b.tgz"\]`)
+}
+
+func (s *ExportCommandSuite) TestExport(c *gc.C) {
+	dir, err := s.runExport(c, "mymodel.tgz")
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCalls(c, []gitjujutesting.StubCall{
+		{"ExportModel", []interface{}{testing.ModelTag}},
+		{"Close", nil},
+	})
+	s.downloader.CheckCallNames(c, "Close")
+
+	f, err := os.Open(filepath.Join(dir, "mymodel.tgz"))
+	c.Assert(err, jc.ErrorIsNil)
+	defer f.Close()
+	archive, err := migration.ReadModelArchive(f)
+	c.Assert(err, jc.ErrorIsNil)
+	defer archive.Close()
+	c.Assert(archive.Model.Bytes, jc.DeepEquals, s.fake.bytes)
+}
+
+func (s *ExportCommandSuite) TestExportError(c *gc.C) {
+	s.fake.SetErrors(errors.New("boom"))
+	dir, err := s.runExport(c, "mymodel.tgz")
+	c.Assert(err, gc.ErrorMatches, "boom")
+	_, err = os.Stat(filepath.Join(dir, "mymodel.tgz"))
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+}
+
+func (s *ExportCommandSuite) TestWriteErrorRemovesFile(c *gc.C) {
+	s.fake.bytes = []byte("not a model")
+	dir, err := s.runExport(c, "mymodel.tgz")
+	c.Assert(err, gc.ErrorMatches, "writing model archive: .*")
+	_, err = os.Stat(filepath.Join(dir, "mymodel.tgz"))
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+}
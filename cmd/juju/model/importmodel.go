@@ -0,0 +1,297 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"io"
+	"os"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/version"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/migrationtarget"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/description"
+	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/migration"
+	"github.com/juju/juju/resource"
+	"github.com/juju/juju/tools"
+)
+
+// NewImportCommand returns a fully constructed import-model command.
+func NewImportCommand() cmd.Command {
+	return modelcmd.WrapController(&importCommand{})
+}
+
+type importCommand struct {
+	modelcmd.ControllerCommandBase
+	api ImportModelAPI
+
+	filename  string
+	activate  bool
+	modelUUID string
+}
+
+const importModelHelpDoc = `
+Imports a model from an archive written by "juju export-model". The
+model, along with its charms, agent binaries and resources, is loaded
+into the controller the same way a migrated model would be. If any
+step fails, the partially imported model is removed again.
+
+The model keeps the UUID it had on the controller it was exported
+from. Its machine and unit agents are not told about this controller,
+and the controller the model was exported from still manages its
+machines. The imported model is therefore left inactive: it cannot be
+used and its cloud resources are not taken over. Once the model's
+agents have been reconfigured to connect to this controller, activate
+the model with --activate and its UUID. The model should then be
+removed from the original controller without destroying its machines.
+
+Only controller administrators may import models.
+
+Examples:
+
+    juju import-model mymodel.tar.gz
+    juju import-model -c othercontroller mymodel.tar.gz
+    juju import-model --activate 0a8bd8c2-7e0d-4a4a-8a4e-2d5f4ba9a4f1
+
+See also:
+    export-model
+    migrate
+`
+
+// Info implements Command.
+func (c *importCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "import-model",
+		Args:    "<file> | --activate <model UUID>",
+		Purpose: "Imports a model archive into a controller.",
+		Doc:     importModelHelpDoc,
+	}
+}
+
+// SetFlags implements Command.
+func (c *importCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.BoolVar(&c.activate, "activate", false, "Activate a previously imported model")
+}
+
+// Init implements Command.
+func (c *importCommand) Init(args []string) error {
+	if c.activate {
+		if len(args) == 0 {
+			return errors.New("no model UUID specified")
+		}
+		c.modelUUID, args = args[0], args[1:]
+		if !names.IsValidModel(c.modelUUID) {
+			return errors.NotValidf("model UUID %q", c.modelUUID)
+		}
+		return cmd.CheckEmpty(args)
+	}
+	if len(args) == 0 {
+		return errors.New("no archive file specified")
+	}
+	c.filename, args = args[0], args[1:]
+	return cmd.CheckEmpty(args)
+}
+
+// ImportModelAPI specifies the used function calls of the
+// MigrationTarget facade.
+type ImportModelAPI interface {
+	Close() error
+	Prechecks(coremigration.ModelInfo) error
+	Import([]byte) error
+	Abort(modelUUID string) error
+	Activate(modelUUID string) error
+	AdoptResources(modelUUID string) error
+	CheckMachines(modelBytes []byte) ([]error, error)
+	UploadCharm(modelUUID string, curl *charm.URL, content io.ReadSeeker) (*charm.URL, error)
+	UploadTools(modelUUID string, r io.ReadSeeker, vers version.Binary, additionalSeries ...string) (tools.List, error)
+	UploadResource(modelUUID string, res resource.Resource, r io.ReadSeeker) error
+	SetPlaceholderResource(modelUUID string, res resource.Resource) error
+	SetUnitResource(modelUUID, unit string, res resource.Resource) error
+}
+
+type importModelAPI struct {
+	*migrationtarget.Client
+	closer io.Closer
+}
+
+func (a importModelAPI) Close() error {
+	return a.closer.Close()
+}
+
+func (c *importCommand) getAPI() (ImportModelAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return importModelAPI{migrationtarget.NewClient(root), root}, nil
+}
+
+// Run implements Command.
+func (c *importCommand) Run(ctx *cmd.Context) error {
+	if c.activate {
+		client, err := c.getAPI()
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+		if err := activateModel(client, c.modelUUID); err != nil {
+			return errors.Trace(err)
+		}
+		ctx.Infof("Model %s activated", c.modelUUID)
+		return nil
+	}
+
+	f, err := os.Open(ctx.AbsPath(c.filename))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer f.Close()
+	archive, err := migration.ReadModelArchive(f)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer archive.Close()
+
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	modelInfo, err := importArchive(ctx, client, archive)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("Model %q imported but not yet active.", modelInfo.Name)
+	ctx.Infof("Once its agents connect to this controller, run:")
+	ctx.Infof("    juju import-model --activate %s", modelInfo.UUID)
+	return nil
+}
+
+// importArchive imports the model in the archive through client,
+// leaving it inactive. If any step fails, the partially imported
+// model is removed again.
+func importArchive(ctx *cmd.Context, client ImportModelAPI, archive *migration.ModelArchive) (coremigration.ModelInfo, error) {
+	modelInfo, err := archiveModelInfo(archive.Model.Bytes)
+	if err != nil {
+		return coremigration.ModelInfo{}, errors.Trace(err)
+	}
+	if err := client.Prechecks(modelInfo); err != nil {
+		return coremigration.ModelInfo{}, errors.Annotate(err, "target prechecks failed")
+	}
+	ctx.Infof("Importing model %q", modelInfo.Name)
+	if err := client.Import(archive.Model.Bytes); err != nil {
+		return coremigration.ModelInfo{}, errors.Annotate(err, "importing model")
+	}
+	if err := uploadArchiveBinaries(client, archive, modelInfo.UUID); err != nil {
+		abortImport(client, modelInfo.UUID)
+		return coremigration.ModelInfo{}, errors.Trace(err)
+	}
+	return modelInfo, nil
+}
+
+// abortImport removes a partially imported model, logging any failure
+// to do so.
+func abortImport(client ImportModelAPI, modelUUID string) {
+	if err := client.Abort(modelUUID); err != nil {
+		logger.Errorf("cannot remove partially imported model: %v", err)
+	}
+}
+
+// activateModel marks the imported model as ready to use and makes
+// this controller the owner of the model's cloud resources.
+func activateModel(client ImportModelAPI, modelUUID string) error {
+	if err := client.Activate(modelUUID); err != nil {
+		return errors.Annotate(err, "activating model")
+	}
+	if err := client.AdoptResources(modelUUID); err != nil {
+		logger.Warningf("cannot take ownership of the model's cloud resources: %v", err)
+	}
+	return nil
+}
+
+// uploadArchiveBinaries uploads the archived binaries into the
+// imported model.
+func uploadArchiveBinaries(client ImportModelAPI, archive *migration.ModelArchive, modelUUID string) error {
+	uploader := &modelUploader{client, modelUUID}
+	err := migration.UploadBinaries(migration.UploadBinariesConfig{
+		Charms:             archive.Model.Charms,
+		CharmDownloader:    archive,
+		CharmUploader:      uploader,
+		Tools:              archive.Model.Tools,
+		ToolsSizes:         archive.Model.ToolsSizes,
+		ToolsDownloader:    archive,
+		ToolsUploader:      uploader,
+		Resources:          archive.Model.Resources,
+		ResourceDownloader: archive,
+		ResourceUploader:   uploader,
+	})
+	return errors.Annotate(err, "uploading binaries")
+}
+
+// archiveModelInfo returns the details of the serialized model needed
+// by the target prechecks.
+func archiveModelInfo(bytes []byte) (coremigration.ModelInfo, error) {
+	model, err := description.Deserialize(bytes)
+	if err != nil {
+		return coremigration.ModelInfo{}, errors.Trace(err)
+	}
+	config := model.Config()
+	name, _ := config["name"].(string)
+	agentVersionStr, _ := config["agent-version"].(string)
+	agentVersion, err := version.Parse(agentVersionStr)
+	if err != nil {
+		return coremigration.ModelInfo{}, errors.Annotate(err, "model agent version")
+	}
+	// The archive doesn't record the version of the controller it
+	// was exported from, but it was at least the model's version.
+	return coremigration.ModelInfo{
+		UUID:                   model.Tag().Id(),
+		Owner:                  model.Owner(),
+		Name:                   name,
+		AgentVersion:           agentVersion,
+		ControllerAgentVersion: agentVersion,
+	}, nil
+}
+
+// modelUploader binds the model UUID to the upload calls of the
+// MigrationTarget API.
+type modelUploader struct {
+	client    ImportModelAPI
+	modelUUID string
+}
+
+// UploadTools is part of migration.ToolsUploader.
+func (u *modelUploader) UploadTools(r io.ReadSeeker, vers version.Binary, additionalSeries ...string) (tools.List, error) {
+	return u.client.UploadTools(u.modelUUID, r, vers, additionalSeries...)
+}
+
+// UploadCharm is part of migration.CharmUploader.
+func (u *modelUploader) UploadCharm(curl *charm.URL, content io.ReadSeeker) (*charm.URL, error) {
+	return u.client.UploadCharm(u.modelUUID, curl, content)
+}
+
+// UploadResource is part of migration.ResourceUploader.
+func (u *modelUploader) UploadResource(res resource.Resource, content io.ReadSeeker) error {
+	return u.client.UploadResource(u.modelUUID, res, content)
+}
+
+// SetPlaceholderResource is part of migration.ResourceUploader.
+func (u *modelUploader) SetPlaceholderResource(res resource.Resource) error {
+	return u.client.SetPlaceholderResource(u.modelUUID, res)
+}
+
+// SetUnitResource is part of migration.ResourceUploader.
+func (u *modelUploader) SetUnitResource(unitName string, res resource.Resource) error {
+	return u.client.SetUnitResource(u.modelUUID, unitName, res)
+}
@@ -0,0 +1,204 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/cmd/juju/model"
+	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/migration"
+	"github.com/juju/juju/resource"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/tools"
+)
+
+type ImportCommandSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake  fakeImportClient
+	store *jujuclienttesting.MemStore
+	dir   string
+	bytes []byte
+}
+
+var _ = gc.Suite(&ImportCommandSuite{})
+
+type fakeImportClient struct {
+	gitjujutesting.Stub
+	missingMachines []error
+}
+
+func (f *fakeImportClient) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeImportClient) Prechecks(info coremigration.ModelInfo) error {
+	f.MethodCall(f, "Prechecks", info)
+	return f.NextErr()
+}
+
+func (f *fakeImportClient) Import(bytes []byte) error {
+	f.MethodCall(f, "Import", bytes)
+	return f.NextErr()
+}
+
+func (f *fakeImportClient) Abort(modelUUID string) error {
+	f.MethodCall(f, "Abort", modelUUID)
+	return f.NextErr()
+}
+
+func (f *fakeImportClient) Activate(modelUUID string) error {
+	f.MethodCall(f, "Activate", modelUUID)
+	return f.NextErr()
+}
+
+func (f *fakeImportClient) AdoptResources(modelUUID string) error {
+	f.MethodCall(f, "AdoptResources", modelUUID)
+	return f.NextErr()
+}
+
+func (f *fakeImportClient) CheckMachines(modelBytes []byte) ([]error, error) {
+	f.MethodCall(f, "CheckMachines", modelBytes)
+	return f.missingMachines, f.NextErr()
+}
+
+func (f *fakeImportClient) UploadCharm(modelUUID string, curl *charm.URL, content io.ReadSeeker) (*charm.URL, error) {
+	f.MethodCall(f, "UploadCharm", modelUUID, curl)
+	return curl, f.NextErr()
+}
+
+func (f *fakeImportClient) UploadTools(modelUUID string, r io.ReadSeeker, vers version.Binary, additionalSeries ...string) (tools.List, error) {
+	f.MethodCall(f, "UploadTools", modelUUID, vers)
+	return tools.List{&tools.Tools{Version: vers}}, f.NextErr()
+}
+
+func (f *fakeImportClient) UploadResource(modelUUID string, res resource.Resource, r io.ReadSeeker) error {
+	f.MethodCall(f, "UploadResource", modelUUID, res)
+	return f.NextErr()
+}
+
+func (f *fakeImportClient) SetPlaceholderResource(modelUUID string, res resource.Resource) error {
+	f.MethodCall(f, "SetPlaceholderResource", modelUUID, res)
+	return f.NextErr()
+}
+
+func (f *fakeImportClient) SetUnitResource(modelUUID, unit string, res resource.Resource) error {
+	f.MethodCall(f, "SetUnitResource", modelUUID, unit, res)
+	return f.NextErr()
+}
+
+func (s *ImportCommandSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = fakeImportClient{}
+	s.store = jujuclienttesting.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+
+	s.dir = c.MkDir()
+	s.bytes = serializedTestModel(c)
+	f, err := os.Create(filepath.Join(s.dir, "mymodel.tgz"))
+	c.Assert(err, jc.ErrorIsNil)
+	defer f.Close()
+	err = migration.WriteModelArchive(f, migration.WriteModelArchiveConfig{
+		Model:              s.bytes,
+		CharmDownloader:    &fakeModelDownloader{},
+		ToolsDownloader:    &fakeModelDownloader{},
+		ResourceDownloader: &fakeModelDownloader{},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ImportCommandSuite) runImport(c *gc.C, args ...string) error {
+	cmd := model.NewImportCommandForTest(&s.fake, s.store)
+	err := testing.InitCommand(cmd, args)
+	if err != nil {
+		return err
+	}
+	return cmd.Run(testing.ContextForDir(c, s.dir))
+}
+
+func (s *ImportCommandSuite) TestInit(c *gc.C) {
+	err := s.runImport(c)
+	c.Assert(err, gc.ErrorMatches, "no archive file specified")
+	err = s.runImport(c, "a.tgz", "b.tgz")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["b.tgz"\]`)
+	err = s.runImport(c, "--activate")
+	c.Assert(err, gc.ErrorMatches, "no model UUID specified")
+	err = s.runImport(c, "--activate", "mymodel.tgz")
+	c.Assert(err, gc.ErrorMatches, `model UUID "mymodel.tgz" not valid`)
+	err = s.runImport(c, "--activate", testing.ModelTag.Id(), "extra")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+func (s *ImportCommandSuite) TestImport(c *gc.C) {
+	err := s.runImport(c, "mymodel.tgz")
+	c.Assert(err, jc.ErrorIsNil)
+
+	uuid := testing.ModelTag.Id()
+	s.fake.CheckCalls(c, []gitjujutesting.StubCall{
+		{"Prechecks", []interface{}{coremigration.ModelInfo{
+			UUID:                   uuid,
+			Owner:                  names.NewUserTag("admin"),
+			Name:                   "mymodel",
+			AgentVersion:           version.MustParse("2.2.0"),
+			ControllerAgentVersion: version.MustParse("2.2.0"),
+		}}},
+		{"Import", []interface{}{s.bytes}},
+		{"Close", nil},
+	})
+}
+
+func (s *ImportCommandSuite) TestActivate(c *gc.C) {
+	uuid := testing.ModelTag.Id()
+	err := s.runImport(c, "--activate", uuid)
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCalls(c, []gitjujutesting.StubCall{
+		{"Activate", []interface{}{uuid}},
+		{"AdoptResources", []interface{}{uuid}},
+		{"Close", nil},
+	})
+}
+
+func (s *ImportCommandSuite) TestActivateAdoptResourcesFails(c *gc.C) {
+	s.fake.SetErrors(nil, errors.New("boom"))
+	err := s.runImport(c, "--activate", testing.ModelTag.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCallNames(c, "Activate", "AdoptResources", "Close")
+}
+
+func (s *ImportCommandSuite) TestPrechecksFail(c *gc.C) {
+	s.fake.SetErrors(errors.New("model with same UUID already exists"))
+	err := s.runImport(c, "mymodel.tgz")
+	c.Assert(err, gc.ErrorMatches, "target prechecks failed: model with same UUID already exists")
+	s.fake.CheckCallNames(c, "Prechecks", "Close")
+}
+
+func (s *ImportCommandSuite) TestActivateFails(c *gc.C) {
+	s.fake.SetErrors(errors.New("boom"))
+	err := s.runImport(c, "--activate", testing.ModelTag.Id())
+	c.Assert(err, gc.ErrorMatches, "activating model: boom")
+	s.fake.CheckCallNames(c, "Activate", "Close")
+}
+
+func (s *ImportCommandSuite) TestMissingFile(c *gc.C) {
+	err := s.runImport(c, "missing.tgz")
+	c.Assert(err, gc.ErrorMatches, "open .*missing.tgz: no such file or directory")
+	s.fake.CheckNoCalls(c)
+}
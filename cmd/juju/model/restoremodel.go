@@ -0,0 +1,188 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"io"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/backups"
+	"github.com/juju/juju/api/migrationtarget"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/environs/bootstrap"
+	"github.com/juju/juju/migration"
+)
+
+// NewRestoreModelCommand returns a fully constructed restore-model
+// command.
+func NewRestoreModelCommand() cmd.Command {
+	return modelcmd.WrapController(&restoreModelCommand{})
+}
+
+type restoreModelCommand struct {
+	modelcmd.ControllerCommandBase
+	backupsAPI RestoreModelBackupsAPI
+	importAPI  ImportModelAPI
+
+	backupId string
+	model    string
+}
+
+const restoreModelHelpDoc = `
+Restores a single model, as it was when the given backup was made,
+without restoring the rest of the controller. The controller extracts
+the model from the backup and it is then imported the same way
+"juju import-model" imports a model archive.
+
+The model may be given by name, as owner/name, or by UUID. It replaces
+the original model, keeping its name and UUID, so the original must
+have been destroyed first.
+
+A backup holds the model's records, not its machines. The restored
+model refers to the same cloud instances as the original, so it can
+only be restored while those instances still exist, for example when
+the model's records were lost but its machines were kept. If any of
+the model's machines no longer has an instance in the cloud, the
+model is not restored. Restoring a model onto new machines is not
+supported.
+
+Only controller administrators may restore models.
+
+Examples:
+
+    juju restore-model 20170601-101500.abcd1234 mymodel
+    juju restore-model 20170601-101500.abcd1234 bob/mymodel
+
+See also:
+    create-backup
+    backups
+    import-model
+`
+
+// Info implements Command.
+func (c *restoreModelCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "restore-model",
+		Args:    "<backup ID> <model>",
+		Purpose: "Restores a single model from a controller backup.",
+		Doc:     restoreModelHelpDoc,
+	}
+}
+
+// Init implements Command.
+func (c *restoreModelCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no backup ID specified")
+	case 1:
+		return errors.New("no model specified")
+	}
+	c.backupId, c.model, args = args[0], args[1], args[2:]
+	return cmd.CheckEmpty(args)
+}
+
+// RestoreModelBackupsAPI specifies the used function calls of the
+// Backups facade.
+type RestoreModelBackupsAPI interface {
+	Close() error
+	DownloadModel(backupId, model string) (io.ReadCloser, error)
+}
+
+func (c *restoreModelCommand) getBackupsAPI() (RestoreModelBackupsAPI, error) {
+	if c.backupsAPI != nil {
+		return c.backupsAPI, nil
+	}
+	// Backups are kept by the controller model.
+	root, err := c.NewModelAPIRoot(bootstrap.ControllerModelName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	client, err := backups.NewClient(root)
+	if err != nil {
+		root.Close()
+		return nil, errors.Trace(err)
+	}
+	return client, nil
+}
+
+func (c *restoreModelCommand) getImportAPI() (ImportModelAPI, error) {
+	if c.importAPI != nil {
+		return c.importAPI, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return importModelAPI{migrationtarget.NewClient(root), root}, nil
+}
+
+// checkMachines confirms that every provisioned machine in the
+// serialized model still has an instance in the cloud. Destroying a
+// model destroys its machines, so restoring a destroyed model's
+// records would otherwise leave agents that can never run.
+func checkMachines(client ImportModelAPI, modelBytes []byte) error {
+	problems, err := client.CheckMachines(modelBytes)
+	if errors.IsNotSupported(err) {
+		logger.Warningf("cannot check the model's machines still exist: %v", err)
+		return nil
+	} else if err != nil {
+		return errors.Annotate(err, "checking model machines")
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	messages := make([]string, len(problems))
+	for i, problem := range problems {
+		messages[i] = problem.Error()
+	}
+	return errors.Errorf("cannot restore model, its machines no longer exist in the cloud: %s",
+		strings.Join(messages, "; "))
+}
+
+// Run implements Command.
+func (c *restoreModelCommand) Run(ctx *cmd.Context) error {
+	backupsClient, err := c.getBackupsAPI()
+	if err != nil {
+		return err
+	}
+	defer backupsClient.Close()
+
+	ctx.Infof("Extracting model %q from backup %q", c.model, c.backupId)
+	reader, err := backupsClient.DownloadModel(c.backupId, c.model)
+	if errors.IsNotSupported(err) {
+		return errors.New("controller does not support restoring a single model")
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	archive, err := migration.ReadModelArchive(reader)
+	reader.Close()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer archive.Close()
+
+	client, err := c.getImportAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	if err := checkMachines(client, archive.Model.Bytes); err != nil {
+		return errors.Trace(err)
+	}
+	modelInfo, err := importArchive(ctx, client, archive)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// The original model has been destroyed, so no other controller
+	// manages the restored model's resources.
+	if err := activateModel(client, modelInfo.UUID); err != nil {
+		abortImport(client, modelInfo.UUID)
+		return errors.Trace(err)
+	}
+	ctx.Infof("Model %q restored", modelInfo.Name)
+	return nil
+}
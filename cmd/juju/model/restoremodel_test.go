@@ -0,0 +1,165 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/cmd/juju/model"
+	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/migration"
+	"github.com/juju/juju/testing"
+)
+
+type RestoreModelCommandSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	backups fakeRestoreModelBackupsClient
+	fake    fakeImportClient
+	store   *jujuclienttesting.MemStore
+	bytes   []byte
+}
+
+var _ = gc.Suite(&RestoreModelCommandSuite{})
+
+type fakeRestoreModelBackupsClient struct {
+	gitjujutesting.Stub
+	archive []byte
+}
+
+func (f *fakeRestoreModelBackupsClient) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeRestoreModelBackupsClient) DownloadModel(backupId, model string) (io.ReadCloser, error) {
+	f.MethodCall(f, "DownloadModel", backupId, model)
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return ioutil.NopCloser(bytes.NewReader(f.archive)), nil
+}
+
+func (s *RestoreModelCommandSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = fakeImportClient{}
+	s.store = jujuclienttesting.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+
+	s.bytes = serializedTestModel(c)
+	var buf bytes.Buffer
+	err := migration.WriteModelArchive(&buf, migration.WriteModelArchiveConfig{
+		Model:              s.bytes,
+		CharmDownloader:    &fakeModelDownloader{},
+		ToolsDownloader:    &fakeModelDownloader{},
+		ResourceDownloader: &fakeModelDownloader{},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backups = fakeRestoreModelBackupsClient{archive: buf.Bytes()}
+}
+
+func (s *RestoreModelCommandSuite) runRestoreModel(c *gc.C, args ...string) error {
+	cmd := model.NewRestoreModelCommandForTest(&s.backups, &s.fake, s.store)
+	err := testing.InitCommand(cmd, args)
+	if err != nil {
+		return err
+	}
+	return cmd.Run(testing.Context(c))
+}
+
+func (s *RestoreModelCommandSuite) TestInit(c *gc.C) {
+	err := s.runRestoreModel(c)
+	c.Assert(err, gc.ErrorMatches, "no backup ID specified")
+	err = s.runRestoreModel(c, "backup-id")
+	c.Assert(err, gc.ErrorMatches, "no model specified")
+	err = s.runRestoreModel(c, "backup-id", "mymodel", "extra")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+func (s *RestoreModelCommandSuite) TestRestoreModel(c *gc.C) {
+	err := s.runRestoreModel(c, "backup-id", "admin/mymodel")
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.backups.CheckCalls(c, []gitjujutesting.StubCall{
+		{"DownloadModel", []interface{}{"backup-id", "admin/mymodel"}},
+		{"Close", nil},
+	})
+	uuid := testing.ModelTag.Id()
+	s.fake.CheckCalls(c, []gitjujutesting.StubCall{
+		{"CheckMachines", []interface{}{s.bytes}},
+		{"Prechecks", []interface{}{coremigration.ModelInfo{
+			UUID:                   uuid,
+			Owner:                  names.NewUserTag("admin"),
+			Name:                   "mymodel",
+			AgentVersion:           version.MustParse("2.2.0"),
+			ControllerAgentVersion: version.MustParse("2.2.0"),
+		}}},
+		{"Import", []interface{}{s.bytes}},
+		{"Activate", []interface{}{uuid}},
+		{"AdoptResources", []interface{}{uuid}},
+		{"Close", nil},
+	})
+}
+
+func (s *RestoreModelCommandSuite) TestPrechecksFail(c *gc.C) {
+	s.fake.SetErrors(nil, errors.New("model with same UUID already exists"))
+	err := s.runRestoreModel(c, "backup-id", "mymodel")
+	c.Assert(err, gc.ErrorMatches, "target prechecks failed: model with same UUID already exists")
+	s.fake.CheckCallNames(c, "CheckMachines", "Prechecks", "Close")
+}
+
+func (s *RestoreModelCommandSuite) TestMachinesMissing(c *gc.C) {
+	s.fake.missingMachines = []error{
+		errors.New(`machine 0 instance "i-0" not found`),
+		errors.New(`machine 1 instance "i-1" not found`),
+	}
+	err := s.runRestoreModel(c, "backup-id", "mymodel")
+	c.Assert(err, gc.ErrorMatches, `cannot restore model, its machines no longer exist in the cloud: `+
+		`machine 0 instance "i-0" not found; machine 1 instance "i-1" not found`)
+	s.fake.CheckCallNames(c, "CheckMachines", "Close")
+}
+
+func (s *RestoreModelCommandSuite) TestCheckMachinesNotSupported(c *gc.C) {
+	s.fake.SetErrors(errors.NotSupportedf("CheckMachines"))
+	err := s.runRestoreModel(c, "backup-id", "mymodel")
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCallNames(c, "CheckMachines", "Prechecks", "Import", "Activate", "AdoptResources", "Close")
+}
+
+func (s *RestoreModelCommandSuite) TestActivateFailAborts(c *gc.C) {
+	s.fake.SetErrors(nil, nil, nil, errors.New("boom"))
+	err := s.runRestoreModel(c, "backup-id", "mymodel")
+	c.Assert(err, gc.ErrorMatches, "activating model: boom")
+	s.fake.CheckCallNames(c, "CheckMachines", "Prechecks", "Import", "Activate", "Abort", "Close")
+	s.fake.CheckCall(c, 4, "Abort", testing.ModelTag.Id())
+}
+
+func (s *RestoreModelCommandSuite) TestDownloadFails(c *gc.C) {
+	s.backups.SetErrors(errors.NotFoundf(`model "mymodel" in backup`))
+	err := s.runRestoreModel(c, "backup-id", "mymodel")
+	c.Assert(err, gc.ErrorMatches, `model "mymodel" in backup not found`)
+	s.backups.CheckCallNames(c, "DownloadModel", "Close")
+	s.fake.CheckNoCalls(c)
+}
+
+func (s *RestoreModelCommandSuite) TestNotSupported(c *gc.C) {
+	s.backups.SetErrors(errors.NotSupportedf("downloading a model from a backup"))
+	err := s.runRestoreModel(c, "backup-id", "mymodel")
+	c.Assert(err, gc.ErrorMatches, "controller does not support restoring a single model")
+	s.fake.CheckNoCalls(c)
+}
@@ -175,6 +175,32 @@ func (s *ShowCommandSuite) TestShowFormatJson(c *gc.C) {
 	c.Assert(testing.Stdout(ctx), jc.JSONEquals, s.expectedOutput)
 }
 
+func (s *ShowCommandSuite) TestShowMigrationProgress(c *gc.C) {
+	start := *s.fake.info.Migration.Start
+	s.fake.info.Migration.Phase = "IMPORT"
+	s.fake.info.Migration.Progress = &params.MigrationProgress{
+		BinariesUploaded: 10 * 1024 * 1024,
+		BinariesTotal:    100 * 1024 * 1024,
+	}
+	s.fake.info.Migration.PhaseTimes = []params.MigrationPhaseTime{
+		{Phase: "QUIESCE", Start: start},
+		{Phase: "IMPORT", Start: start.Add(time.Minute)},
+	}
+	status := s.expectedOutput["mymodel"].(attrs)["status"].(attrs)
+	status["migration-progress"] = attrs{
+		"phase":    "IMPORT",
+		"binaries": "10 MiB of 100 MiB",
+		"phases": []interface{}{
+			attrs{"phase": "QUIESCE", "elapsed": "1m0s"},
+			attrs{"phase": "IMPORT", "elapsed": "23h49m15s"},
+		},
+	}
+
+	ctx, err := testing.RunCommand(c, s.newShowCommand(), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), jc.YAMLEquals, s.expectedOutput)
+}
+
 func (s *ShowCommandSuite) TestUnrecognizedArg(c *gc.C) {
 	_, err := testing.RunCommand(c, s.newShowCommand(), "admin", "whoops")
 	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["whoops"\]`)
@@ -10,6 +10,7 @@ import (
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
 
@@ -73,6 +74,18 @@ Examples:
       juju add-storage u/0 data=1 
     or
       juju add-storage u/0 data 
+
+
+    # Add 1 storage instance for "data" storage to unit u/0,
+    # restoring its volume from a snapshot:
+
+      juju add-storage u/0 data --from-snapshot snap-123456
+
+When --from-snapshot is specified, exactly one storage directive must be
+given, and the storage must be block storage. The new volume is created
+from the snapshot, which must belong to the storage provider of the
+specified or default pool. Snapshots are created with
+create-storage-snapshot.
 `
 	addCommandAgs = `
 <unit name> <storage directive> ...
@@ -92,6 +105,16 @@ type addCommand struct {
 	// defined in charm storage metadata.
 	storageCons map[string]storage.Constraints
 	newAPIFunc  func() (StorageAddAPI, error)
+
+	// snapshotId is the ID of the snapshot from which to
+	// create the storage's volume, if any.
+	snapshotId string
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *addCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.StorageCommandBase.SetFlags(f)
+	f.StringVar(&c.snapshotId, "from-snapshot", "", "Create the storage from the specified volume snapshot")
 }
 
 // Init implements Command.Init.
@@ -107,7 +130,20 @@ func (c *addCommand) Init(args []string) (err error) {
 	c.unitTag = names.NewUnitTag(u).String()
 
 	c.storageCons, err = storage.ParseConstraintsMap(args[1:], false)
-	return
+	if err != nil {
+		return err
+	}
+	if c.snapshotId != "" {
+		if len(c.storageCons) != 1 {
+			return errors.New("--from-snapshot requires exactly one storage directive")
+		}
+		for _, cons := range c.storageCons {
+			if cons.Count > 1 {
+				return errors.New("--from-snapshot cannot be used to add more than one storage instance")
+			}
+		}
+	}
+	return nil
 }
 
 // Info implements Command.Info.
@@ -196,6 +232,7 @@ func (c *addCommand) createStorageAddParams() []params.StorageAddParams {
 					&cons.Size,
 					&cons.Count,
 				},
+				SnapshotId: c.snapshotId,
 			})
 	}
 
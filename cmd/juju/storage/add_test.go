@@ -76,6 +76,16 @@ var errorTsts = []tstData{
 		expectedErr: `storage "data" specified more than once`,
 		visibleErr:  `storage "data" specified more than once`,
 	},
+	{
+		args:        []string{"tst/123", "data", "logs", "--from-snapshot", "snap-123"},
+		expectedErr: `--from-snapshot requires exactly one storage directive`,
+		visibleErr:  `--from-snapshot requires exactly one storage directive`,
+	},
+	{
+		args:        []string{"tst/123", "data=3", "--from-snapshot", "snap-123"},
+		expectedErr: `--from-snapshot cannot be used to add more than one storage instance`,
+		visibleErr:  `--from-snapshot cannot be used to add more than one storage instance`,
+	},
 }
 
 func (s *addSuite) TestAddArgs(c *gc.C) {
@@ -106,6 +116,18 @@ func (s *addSuite) TestAddSuccess(c *gc.C) {
 	}
 }
 
+func (s *addSuite) TestAddFromSnapshot(c *gc.C) {
+	var added []params.StorageAddParams
+	s.mockAPI.addToUnitFunc = func(storages []params.StorageAddParams) ([]params.ErrorResult, error) {
+		added = storages
+		return make([]params.ErrorResult, len(storages)), nil
+	}
+	s.args = []string{"tst/123", "data=ebs", "--from-snapshot", "snap-123"}
+	s.assertAddOutput(c, "added \"data\"\n", "")
+	c.Assert(added, gc.HasLen, 1)
+	c.Assert(added[0].SnapshotId, gc.Equals, "snap-123")
+}
+
 func (s *addSuite) TestAddOperationAborted(c *gc.C) {
 	s.args = []string{"tst/123", "data=676"}
 	s.mockAPI.addToUnitFunc = func(storages []params.StorageAddParams) ([]params.ErrorResult, error) {
@@ -0,0 +1,79 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/testing"
+)
+
+type AttachStorageSuite struct {
+	SubStorageSuite
+	api *mockAttachAPI
+}
+
+var _ = gc.Suite(&AttachStorageSuite{})
+
+func (s *AttachStorageSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.api = &mockAttachAPI{}
+}
+
+func (s *AttachStorageSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	return testing.RunCommand(c, storage.NewAttachStorageCommandForTest(s.api, s.store), args...)
+}
+
+func (s *AttachStorageSuite) TestInitErrors(c *gc.C) {
+	_, err := s.run(c, "mysql/0")
+	c.Assert(err, gc.ErrorMatches, "attach-storage requires a unit ID and at least one storage ID")
+	_, err = s.run(c, "mysql", "data/0")
+	c.Assert(err, gc.ErrorMatches, `unit ID "mysql" not valid`)
+	_, err = s.run(c, "mysql/0", "data")
+	c.Assert(err, gc.ErrorMatches, `storage ID "data" not valid`)
+}
+
+func (s *AttachStorageSuite) TestAttach(c *gc.C) {
+	ctx, err := s.run(c, "mysql/1", "data/0")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "Attach", "mysql/1", []string{"data/0"})
+	c.Assert(testing.Stderr(ctx), gc.Equals, "attaching data/0\n")
+}
+
+func (s *AttachStorageSuite) TestAttachFailure(c *gc.C) {
+	s.api.results = []params.ErrorResult{
+		{Error: common.ServerError(errors.New("storage is still being detached"))},
+	}
+	ctx, err := s.run(c, "mysql/1", "data/0")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(testing.Stderr(ctx), gc.Equals, "attaching data/0: storage is still being detached\n")
+}
+
+type mockAttachAPI struct {
+	jujutesting.Stub
+	results []params.ErrorResult
+}
+
+func (a *mockAttachAPI) Close() error {
+	a.MethodCall(a, "Close")
+	return a.NextErr()
+}
+
+func (a *mockAttachAPI) Attach(unitId string, ids []string) ([]params.ErrorResult, error) {
+	a.MethodCall(a, "Attach", unitId, ids)
+	if err := a.NextErr(); err != nil {
+		return nil, err
+	}
+	if a.results != nil {
+		return a.results, nil
+	}
+	return make([]params.ErrorResult, len(ids)), nil
+}
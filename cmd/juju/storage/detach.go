@@ -0,0 +1,122 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewDetachStorageCommand returns a command used to detach storage
+// from the units it is attached to.
+func NewDetachStorageCommand() cmd.Command {
+	cmd := &detachStorageCommand{}
+	cmd.newAPIFunc = func() (StorageDetachAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const detachStorageCommandDoc = `
+Detaches storage from the unit it is attached to, without destroying it.
+
+The unit's charm is notified with the storage-detaching hook, after which
+the storage's volume or filesystem is detached from the unit's machine.
+The storage then remains in the model, unattached, until it is attached
+to another unit with "juju attach-storage". Detached storage is not
+removed along with the unit it was attached to.
+
+Only storage whose volume or filesystem can outlive the machine it is
+attached to can be detached. Storage cannot be detached if the unit's
+charm requires it.
+
+Examples:
+    juju detach-storage data/0
+
+See also:
+    attach-storage
+    storage
+`
+
+// detachStorageCommand detaches storage from units.
+type detachStorageCommand struct {
+	StorageCommandBase
+	newAPIFunc func() (StorageDetachAPI, error)
+	storageIds []string
+}
+
+// Init implements Command.Init.
+func (c *detachStorageCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("detach-storage requires at least one storage ID")
+	}
+	for _, id := range args {
+		if !names.IsValidStorage(id) {
+			return errors.NotValidf("storage ID %q", id)
+		}
+	}
+	c.storageIds = args
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *detachStorageCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "detach-storage",
+		Purpose: "Detaches storage from units.",
+		Doc:     detachStorageCommandDoc,
+		Args:    "<storage> [<storage> ...]",
+	}
+}
+
+// Run implements Command.Run.
+func (c *detachStorageCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	results, err := api.Detach(c.storageIds)
+	if err != nil {
+		if params.IsCodeUnauthorized(err) {
+			common.PermissionsMessage(ctx.Stderr, "detach storage")
+		}
+		return err
+	}
+	return reportStorageResults(ctx, "detaching", c.storageIds, results)
+}
+
+// StorageDetachAPI defines the API methods that the detach-storage
+// command uses.
+type StorageDetachAPI interface {
+	Close() error
+	Detach([]string) ([]params.ErrorResult, error)
+}
+
+// reportStorageResults reports the results of an operation on each of
+// the identified storage instances, returning cmd.ErrSilent if any of
+// them failed.
+func reportStorageResults(ctx *cmd.Context, operation string, storageIds []string, results []params.ErrorResult) error {
+	var failed bool
+	for i, result := range results {
+		if result.Error != nil {
+			fmt.Fprintf(ctx.Stderr, "%s %s: %v\n", operation, storageIds[i], result.Error)
+			failed = true
+			continue
+		}
+		ctx.Infof("%s %s", operation, storageIds[i])
+	}
+	if failed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
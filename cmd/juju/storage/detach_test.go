@@ -0,0 +1,87 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/testing"
+)
+
+type DetachStorageSuite struct {
+	SubStorageSuite
+	api *mockDetachAPI
+}
+
+var _ = gc.Suite(&DetachStorageSuite{})
+
+func (s *DetachStorageSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.api = &mockDetachAPI{}
+}
+
+func (s *DetachStorageSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	return testing.RunCommand(c, storage.NewDetachStorageCommandForTest(s.api, s.store), args...)
+}
+
+func (s *DetachStorageSuite) TestInitErrors(c *gc.C) {
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "detach-storage requires at least one storage ID")
+	_, err = s.run(c, "data")
+	c.Assert(err, gc.ErrorMatches, `storage ID "data" not valid`)
+}
+
+func (s *DetachStorageSuite) TestDetach(c *gc.C) {
+	ctx, err := s.run(c, "data/0", "logs/1")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "Detach", []string{"data/0", "logs/1"})
+	c.Assert(testing.Stderr(ctx), gc.Equals, "detaching data/0\ndetaching logs/1\n")
+}
+
+func (s *DetachStorageSuite) TestDetachFailure(c *gc.C) {
+	s.api.results = []params.ErrorResult{
+		{Error: common.ServerError(errors.New("storage is not alive"))},
+		{},
+	}
+	ctx, err := s.run(c, "data/0", "logs/1")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(testing.Stderr(ctx), gc.Equals, `
+detaching data/0: storage is not alive
+detaching logs/1
+`[1:])
+}
+
+func (s *DetachStorageSuite) TestDetachAPIError(c *gc.C) {
+	s.api.SetErrors(errors.New("boom"))
+	_, err := s.run(c, "data/0")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type mockDetachAPI struct {
+	jujutesting.Stub
+	results []params.ErrorResult
+}
+
+func (a *mockDetachAPI) Close() error {
+	a.MethodCall(a, "Close")
+	return a.NextErr()
+}
+
+func (a *mockDetachAPI) Detach(ids []string) ([]params.ErrorResult, error) {
+	a.MethodCall(a, "Detach", ids)
+	if err := a.NextErr(); err != nil {
+		return nil, err
+	}
+	if a.results != nil {
+		return a.results, nil
+	}
+	return make([]params.ErrorResult, len(ids)), nil
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/storage"
 )
 
 var (
@@ -54,3 +55,67 @@ func NewAddCommandForTest(api StorageAddAPI, store jujuclient.ClientStore) cmd.C
 	cmd.SetClientStore(store)
 	return modelcmd.Wrap(cmd)
 }
+
+func NewDetachStorageCommandForTest(api StorageDetachAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &detachStorageCommand{newAPIFunc: func() (StorageDetachAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewAttachStorageCommandForTest(api StorageAttachAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &attachStorageCommand{newAPIFunc: func() (StorageAttachAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewImportVolumeCommandForTest(api StorageImportAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &importStorageCommand{kind: storage.StorageKindBlock, newAPIFunc: func() (StorageImportAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewImportFilesystemCommandForTest(api StorageImportAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &importStorageCommand{kind: storage.StorageKindFilesystem, newAPIFunc: func() (StorageImportAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewCreateSnapshotCommandForTest(api StorageSnapshotAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &createSnapshotCommand{newAPIFunc: func() (StorageSnapshotAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewListSnapshotsCommandForTest(api StorageSnapshotAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &listSnapshotsCommand{newAPIFunc: func() (StorageSnapshotAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewRemoveSnapshotCommandForTest(api StorageSnapshotAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &removeSnapshotCommand{newAPIFunc: func() (StorageSnapshotAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewResizeStorageCommandForTest(api StorageResizeAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &resizeStorageCommand{newAPIFunc: func() (StorageResizeAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
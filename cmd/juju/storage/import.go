@@ -0,0 +1,145 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/storage"
+)
+
+// NewImportVolumeCommand returns a command used to import an existing
+// volume into the model as block storage.
+func NewImportVolumeCommand() cmd.Command {
+	return newImportStorageCommand(storage.StorageKindBlock)
+}
+
+// NewImportFilesystemCommand returns a command used to import an existing
+// volume containing a filesystem into the model as filesystem storage.
+func NewImportFilesystemCommand() cmd.Command {
+	return newImportStorageCommand(storage.StorageKindFilesystem)
+}
+
+func newImportStorageCommand(kind storage.StorageKind) cmd.Command {
+	cmd := &importStorageCommand{kind: kind}
+	cmd.newAPIFunc = func() (StorageImportAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const importVolumeCommandDoc = `
+Imports an existing volume into the model as block storage.
+
+The volume, created outside of Juju, is identified by the ID its storage
+provider knows it by, and must not be in use. It is brought under the
+management of the model as a storage instance with the specified charm
+storage name, which is not attached to any unit. The storage instance
+can then be attached to a unit whose charm declares block storage of
+that name, with attach-storage.
+
+Examples:
+    juju import-volume ebs vol-123456 data
+
+See also:
+    import-filesystem
+    attach-storage
+    storage-pools
+`
+
+const importFilesystemCommandDoc = `
+Imports an existing volume containing a filesystem into the model as
+filesystem storage.
+
+The volume, created outside of Juju, is identified by the ID its storage
+provider knows it by, and must not be in use. It is brought under the
+management of the model as a storage instance with the specified charm
+storage name, which is not attached to any unit. The storage instance
+can then be attached to a unit whose charm declares filesystem storage
+of that name, with attach-storage. The filesystem on the volume is
+mounted as it is; it is not reformatted, so the data on it is preserved.
+
+Examples:
+    juju import-filesystem ebs vol-123456 pgdata
+
+See also:
+    import-volume
+    attach-storage
+    storage-pools
+`
+
+// importStorageCommand imports an existing volume into the model.
+type importStorageCommand struct {
+	StorageCommandBase
+	newAPIFunc  func() (StorageImportAPI, error)
+	kind        storage.StorageKind
+	storagePool string
+	providerId  string
+	storageName string
+}
+
+// Init implements Command.Init.
+func (c *importStorageCommand) Init(args []string) error {
+	if len(args) < 3 {
+		return errors.Errorf(
+			"%s requires a storage pool or provider, a provider ID, and a storage name",
+			c.Info().Name,
+		)
+	}
+	if !names.IsValidStorageName(args[2]) {
+		return errors.NotValidf("storage name %q", args[2])
+	}
+	c.storagePool = args[0]
+	c.providerId = args[1]
+	c.storageName = args[2]
+	return cmd.CheckEmpty(args[3:])
+}
+
+// Info implements Command.Info.
+func (c *importStorageCommand) Info() *cmd.Info {
+	info := &cmd.Info{
+		Name:    "import-volume",
+		Purpose: "Imports an existing volume into the model as block storage.",
+		Doc:     importVolumeCommandDoc,
+		Args:    "<storage-pool|storage-provider> <provider-id> <storage-name>",
+	}
+	if c.kind == storage.StorageKindFilesystem {
+		info.Name = "import-filesystem"
+		info.Purpose = "Imports an existing volume into the model as filesystem storage."
+		info.Doc = importFilesystemCommandDoc
+	}
+	return info
+}
+
+// Run implements Command.Run.
+func (c *importStorageCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	ctx.Infof("importing %q from storage pool %q as storage %q", c.providerId, c.storagePool, c.storageName)
+	storageTag, err := api.Import(c.kind, c.storagePool, c.providerId, c.storageName)
+	if err != nil {
+		if params.IsCodeUnauthorized(err) {
+			common.PermissionsMessage(ctx.Stderr, "import storage")
+		}
+		return err
+	}
+	ctx.Infof("imported storage %s", storageTag.Id())
+	return nil
+}
+
+// StorageImportAPI defines the API methods that the import-volume and
+// import-filesystem commands use.
+type StorageImportAPI interface {
+	Close() error
+	Import(storage.StorageKind, string, string, string) (names.StorageTag, error)
+}
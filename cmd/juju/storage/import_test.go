@@ -0,0 +1,76 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/cmd/juju/storage"
+	jujustorage "github.com/juju/juju/storage"
+	"github.com/juju/juju/testing"
+)
+
+type ImportStorageSuite struct {
+	SubStorageSuite
+	api *mockImportAPI
+}
+
+var _ = gc.Suite(&ImportStorageSuite{})
+
+func (s *ImportStorageSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.api = &mockImportAPI{}
+}
+
+func (s *ImportStorageSuite) TestInitErrors(c *gc.C) {
+	_, err := testing.RunCommand(c, storage.NewImportVolumeCommandForTest(s.api, s.store), "ebs", "vol-123")
+	c.Assert(err, gc.ErrorMatches, "import-volume requires a storage pool or provider, a provider ID, and a storage name")
+	_, err = testing.RunCommand(c, storage.NewImportFilesystemCommandForTest(s.api, s.store), "ebs", "vol-123", "0data")
+	c.Assert(err, gc.ErrorMatches, `storage name "0data" not valid`)
+	_, err = testing.RunCommand(c, storage.NewImportFilesystemCommandForTest(s.api, s.store), "ebs", "vol-123", "data", "extra")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+func (s *ImportStorageSuite) TestImportVolume(c *gc.C) {
+	ctx, err := testing.RunCommand(c, storage.NewImportVolumeCommandForTest(s.api, s.store), "ebs", "vol-123", "data")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "Import", jujustorage.StorageKindBlock, "ebs", "vol-123", "data")
+	c.Assert(testing.Stderr(ctx), gc.Equals, `
+importing "vol-123" from storage pool "ebs" as storage "data"
+imported storage data/0
+`[1:])
+}
+
+func (s *ImportStorageSuite) TestImportFilesystem(c *gc.C) {
+	_, err := testing.RunCommand(c, storage.NewImportFilesystemCommandForTest(s.api, s.store), "ebs", "vol-123", "pgdata")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "Import", jujustorage.StorageKindFilesystem, "ebs", "vol-123", "pgdata")
+}
+
+func (s *ImportStorageSuite) TestImportError(c *gc.C) {
+	s.api.SetErrors(errors.New("volume is in use"))
+	_, err := testing.RunCommand(c, storage.NewImportVolumeCommandForTest(s.api, s.store), "ebs", "vol-123", "data")
+	c.Assert(err, gc.ErrorMatches, "volume is in use")
+}
+
+type mockImportAPI struct {
+	jujutesting.Stub
+}
+
+func (a *mockImportAPI) Close() error {
+	a.MethodCall(a, "Close")
+	return a.NextErr()
+}
+
+func (a *mockImportAPI) Import(kind jujustorage.StorageKind, pool, providerId, storageName string) (names.StorageTag, error) {
+	a.MethodCall(a, "Import", kind, pool, providerId, storageName)
+	if err := a.NextErr(); err != nil {
+		return names.StorageTag{}, err
+	}
+	return names.NewStorageTag(storageName + "/0"), nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewResizeStorageCommand returns a command used to grow storage.
+func NewResizeStorageCommand() cmd.Command {
+	cmd := &resizeStorageCommand{}
+	cmd.newAPIFunc = func() (StorageResizeAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const resizeStorageCommandDoc = `
+Grows the specified storage instance to the specified size.
+
+The size is a number with an optional multiplier suffix (M, G, T, P, E,
+Z or Y); the default is megabytes. Storage can only be grown, so the new
+size must be larger than the current size.
+
+The storage's volume is resized by the storage provider, and then any
+filesystem on the volume is extended to fill it. The storage remains
+attached while it is resized. Once the resize is complete, the
+"<name>-storage-resized" hook is run for each unit the storage is
+attached to. Storage that is not backed by a volume, and volumes whose
+storage provider does not support resizing, cannot be resized.
+
+Examples:
+    juju resize-storage pgdata/0 200G
+
+See also:
+    storage
+    show-storage
+`
+
+// resizeStorageCommand grows a storage instance.
+type resizeStorageCommand struct {
+	StorageCommandBase
+	newAPIFunc func() (StorageResizeAPI, error)
+	storageId  string
+	size       uint64
+}
+
+// Init implements Command.Init.
+func (c *resizeStorageCommand) Init(args []string) error {
+	if len(args) != 2 {
+		return errors.New("resize-storage requires a storage ID and a size")
+	}
+	if !names.IsValidStorage(args[0]) {
+		return errors.NotValidf("storage ID %q", args[0])
+	}
+	size, err := utils.ParseSize(args[1])
+	if err != nil {
+		return errors.Annotate(err, "cannot parse size")
+	}
+	if size == 0 {
+		return errors.New("size must be greater than zero")
+	}
+	c.storageId = args[0]
+	c.size = size
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *resizeStorageCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "resize-storage",
+		Purpose: "Grows storage to a larger size.",
+		Doc:     resizeStorageCommandDoc,
+		Args:    "<storage> <size>",
+	}
+}
+
+// Run implements Command.Run.
+func (c *resizeStorageCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	if err := api.Resize(c.storageId, c.size); err != nil {
+		if params.IsCodeUnauthorized(err) {
+			common.PermissionsMessage(ctx.Stderr, "resize storage")
+		}
+		return err
+	}
+	ctx.Infof("resizing %s to %dM", c.storageId, c.size)
+	return nil
+}
+
+// StorageResizeAPI defines the API methods that the resize-storage
+// command uses.
+type StorageResizeAPI interface {
+	Close() error
+	Resize(storageId string, size uint64) error
+}
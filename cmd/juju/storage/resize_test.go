@@ -0,0 +1,68 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/testing"
+)
+
+type ResizeStorageSuite struct {
+	SubStorageSuite
+	api *mockResizeAPI
+}
+
+var _ = gc.Suite(&ResizeStorageSuite{})
+
+func (s *ResizeStorageSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.api = &mockResizeAPI{}
+}
+
+func (s *ResizeStorageSuite) TestInitErrors(c *gc.C) {
+	s.testInitError(c, []string{}, "resize-storage requires a storage ID and a size")
+	s.testInitError(c, []string{"data/0"}, "resize-storage requires a storage ID and a size")
+	s.testInitError(c, []string{"data/0", "2G", "extra"}, "resize-storage requires a storage ID and a size")
+	s.testInitError(c, []string{"foo", "2G"}, `storage ID "foo" not valid`)
+	s.testInitError(c, []string{"data/0", "big"}, `cannot parse size: .*`)
+	s.testInitError(c, []string{"data/0", "0"}, "size must be greater than zero")
+}
+
+func (s *ResizeStorageSuite) testInitError(c *gc.C, args []string, expect string) {
+	_, err := testing.RunCommand(c, storage.NewResizeStorageCommandForTest(s.api, s.store), args...)
+	c.Assert(err, gc.ErrorMatches, expect)
+}
+
+func (s *ResizeStorageSuite) TestResize(c *gc.C) {
+	ctx, err := testing.RunCommand(c, storage.NewResizeStorageCommandForTest(s.api, s.store), "data/0", "2G")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCallNames(c, "Resize", "Close")
+	s.api.CheckCall(c, 0, "Resize", "data/0", uint64(2048))
+	c.Assert(testing.Stderr(ctx), gc.Equals, "resizing data/0 to 2048M\n")
+}
+
+func (s *ResizeStorageSuite) TestResizeError(c *gc.C) {
+	s.api.SetErrors(errors.New("new size 1024M must be larger than current size 1024M"))
+	_, err := testing.RunCommand(c, storage.NewResizeStorageCommandForTest(s.api, s.store), "data/0", "1024")
+	c.Assert(err, gc.ErrorMatches, "new size 1024M must be larger than current size 1024M")
+}
+
+type mockResizeAPI struct {
+	jujutesting.Stub
+}
+
+func (a *mockResizeAPI) Close() error {
+	a.MethodCall(a, "Close")
+	return a.NextErr()
+}
+
+func (a *mockResizeAPI) Resize(storageId string, size uint64) error {
+	a.MethodCall(a, "Resize", storageId, size)
+	return a.NextErr()
+}
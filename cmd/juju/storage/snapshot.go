@@ -0,0 +1,387 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// NewCreateSnapshotCommand returns a command used to snapshot the
+// volumes of storage instances.
+func NewCreateSnapshotCommand() cmd.Command {
+	cmd := &createSnapshotCommand{}
+	cmd.newAPIFunc = func() (StorageSnapshotAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const createSnapshotCommandDoc = `
+Creates a snapshot of the volume of each of the specified storage instances.
+
+For block storage, the storage's volume is snapshotted; for filesystem
+storage, the volume backing the filesystem is snapshotted. Storage that
+is not backed by a volume cannot be snapshotted. The snapshot is taken
+while the storage remains attached, so it is only crash-consistent;
+quiesce the application first if it needs an application-consistent copy.
+
+The ID of each snapshot created is printed. Volumes managed by a
+machine-scoped storage provider, such as loop, are snapshotted by the
+agent of the machine that the volume is on; the snapshot is reported as
+pending, and its ID is shown by storage-snapshots once it has been taken.
+Such snapshots are stored on that machine, and can only be restored to
+storage on the same machine.
+
+A snapshot can be restored to new block storage with
+add-storage --from-snapshot.
+
+Examples:
+    juju create-storage-snapshot pgdata/0
+    juju create-storage-snapshot data/0 data/1
+
+See also:
+    add-storage
+    remove-storage-snapshot
+    storage
+    storage-snapshots
+`
+
+// createSnapshotCommand snapshots the volumes of storage instances.
+type createSnapshotCommand struct {
+	StorageCommandBase
+	newAPIFunc func() (StorageSnapshotAPI, error)
+	storageIds []string
+}
+
+// Init implements Command.Init.
+func (c *createSnapshotCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("create-storage-snapshot requires at least one storage ID")
+	}
+	for _, id := range args {
+		if !names.IsValidStorage(id) {
+			return errors.NotValidf("storage ID %q", id)
+		}
+	}
+	c.storageIds = args
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *createSnapshotCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "create-storage-snapshot",
+		Purpose: "Creates snapshots of storage volumes.",
+		Doc:     createSnapshotCommandDoc,
+		Args:    "<storage> [<storage> ...]",
+	}
+}
+
+// Run implements Command.Run.
+func (c *createSnapshotCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	results, err := api.CreateSnapshots(c.storageIds)
+	if err != nil {
+		if params.IsCodeUnauthorized(err) {
+			common.PermissionsMessage(ctx.Stderr, "create storage snapshots")
+		}
+		return err
+	}
+	var failed bool
+	for i, result := range results {
+		if result.Error != nil {
+			fmt.Fprintf(ctx.Stderr, "snapshotting %s: %v\n", c.storageIds[i], result.Error)
+			failed = true
+			continue
+		}
+		if result.Result.Pending {
+			fmt.Fprintf(ctx.Stdout, "%s: snapshot pending\n", c.storageIds[i])
+			continue
+		}
+		fmt.Fprintf(ctx.Stdout, "%s: %s\n", c.storageIds[i], result.Result.SnapshotId)
+	}
+	if failed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+// NewListSnapshotsCommand returns a command used to list the snapshots
+// of the volumes of storage instances.
+func NewListSnapshotsCommand() cmd.Command {
+	cmd := &listSnapshotsCommand{}
+	cmd.newAPIFunc = func() (StorageSnapshotAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const listSnapshotsCommandDoc = `
+Lists the snapshots of the volume of each of the specified storage instances.
+
+Snapshots of volumes managed by a machine-scoped storage provider, such
+as loop, that have been requested but not yet taken by the machine's
+agent are listed as pending.
+
+Examples:
+    juju storage-snapshots pgdata/0
+    juju storage-snapshots data/0 data/1 --format yaml
+
+See also:
+    create-storage-snapshot
+    remove-storage-snapshot
+`
+
+// listSnapshotsCommand lists the snapshots of the volumes of storage
+// instances.
+type listSnapshotsCommand struct {
+	StorageCommandBase
+	newAPIFunc func() (StorageSnapshotAPI, error)
+	storageIds []string
+	out        cmd.Output
+}
+
+// SnapshotInfo defines the serialization behaviour of storage snapshot
+// information.
+type SnapshotInfo struct {
+	Id      string     `yaml:"id,omitempty" json:"id,omitempty"`
+	Size    uint64     `yaml:"size,omitempty" json:"size,omitempty"`
+	Created *time.Time `yaml:"created,omitempty" json:"created,omitempty"`
+	Pending bool       `yaml:"pending,omitempty" json:"pending,omitempty"`
+}
+
+// Init implements Command.Init.
+func (c *listSnapshotsCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("storage-snapshots requires at least one storage ID")
+	}
+	for _, id := range args {
+		if !names.IsValidStorage(id) {
+			return errors.NotValidf("storage ID %q", id)
+		}
+	}
+	c.storageIds = args
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *listSnapshotsCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "storage-snapshots",
+		Purpose: "Lists snapshots of storage volumes.",
+		Doc:     listSnapshotsCommandDoc,
+		Args:    "<storage> [<storage> ...]",
+		Aliases: []string{"list-storage-snapshots"},
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *listSnapshotsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.StorageCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatSnapshotListTabular,
+	})
+}
+
+// Run implements Command.Run.
+func (c *listSnapshotsCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	results, err := api.ListSnapshots(c.storageIds)
+	if err != nil {
+		if params.IsCodeUnauthorized(err) {
+			common.PermissionsMessage(ctx.Stderr, "list storage snapshots")
+		}
+		return err
+	}
+	var failed bool
+	snapshots := make(map[string][]SnapshotInfo)
+	for i, result := range results {
+		if result.Error != nil {
+			fmt.Fprintf(ctx.Stderr, "listing snapshots of %s: %v\n", c.storageIds[i], result.Error)
+			failed = true
+			continue
+		}
+		for _, details := range result.Result {
+			info := SnapshotInfo{
+				Id:      details.SnapshotId,
+				Size:    details.Size,
+				Pending: details.Pending,
+			}
+			if !details.Pending {
+				created := details.Created
+				info.Created = &created
+			}
+			snapshots[c.storageIds[i]] = append(snapshots[c.storageIds[i]], info)
+		}
+	}
+	if len(snapshots) == 0 {
+		if !failed {
+			ctx.Infof("No storage snapshots to display.")
+		}
+	} else if err := c.out.Write(ctx, snapshots); err != nil {
+		return err
+	}
+	if failed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+// formatSnapshotListTabular writes a tabular summary of storage
+// snapshots.
+func formatSnapshotListTabular(writer io.Writer, value interface{}) error {
+	snapshots, ok := value.(map[string][]SnapshotInfo)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", snapshots, value)
+	}
+	tw := output.TabWriter(writer)
+	print := func(values ...string) {
+		fmt.Fprintln(tw, strings.Join(values, "\t"))
+	}
+	print("Storage", "Snapshot", "Size", "Created")
+
+	storageIds := make([]string, 0, len(snapshots))
+	for id := range snapshots {
+		storageIds = append(storageIds, id)
+	}
+	sort.Strings(storageIds)
+	for _, storageId := range storageIds {
+		for _, info := range snapshots[storageId] {
+			if info.Pending {
+				print(storageId, "(pending)", "", "")
+				continue
+			}
+			var size, created string
+			if info.Size > 0 {
+				size = humanize.IBytes(info.Size * humanize.MiByte)
+			}
+			if info.Created != nil {
+				created = info.Created.Format(time.RFC3339)
+			}
+			print(storageId, info.Id, size, created)
+		}
+	}
+	return tw.Flush()
+}
+
+// NewRemoveSnapshotCommand returns a command used to remove snapshots
+// of the volume of a storage instance.
+func NewRemoveSnapshotCommand() cmd.Command {
+	cmd := &removeSnapshotCommand{}
+	cmd.newAPIFunc = func() (StorageSnapshotAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const removeSnapshotCommandDoc = `
+Removes the specified snapshots of the volume of a storage instance.
+
+Snapshots of volumes managed by a machine-scoped storage provider, such
+as loop, are removed by the agent of the machine that the volume is on,
+and are no longer listed once their removal has been requested.
+
+Examples:
+    juju remove-storage-snapshot pgdata/0 snap-0123456789abcdef0
+
+See also:
+    create-storage-snapshot
+    storage-snapshots
+`
+
+// removeSnapshotCommand removes snapshots of the volume of a storage
+// instance.
+type removeSnapshotCommand struct {
+	StorageCommandBase
+	newAPIFunc  func() (StorageSnapshotAPI, error)
+	storageId   string
+	snapshotIds []string
+}
+
+// Init implements Command.Init.
+func (c *removeSnapshotCommand) Init(args []string) error {
+	if len(args) < 2 {
+		return errors.New("remove-storage-snapshot requires a storage ID and at least one snapshot ID")
+	}
+	if !names.IsValidStorage(args[0]) {
+		return errors.NotValidf("storage ID %q", args[0])
+	}
+	c.storageId = args[0]
+	c.snapshotIds = args[1:]
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *removeSnapshotCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "remove-storage-snapshot",
+		Purpose: "Removes snapshots of storage volumes.",
+		Doc:     removeSnapshotCommandDoc,
+		Args:    "<storage> <snapshot> [<snapshot> ...]",
+	}
+}
+
+// Run implements Command.Run.
+func (c *removeSnapshotCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	results, err := api.DeleteSnapshots(c.storageId, c.snapshotIds)
+	if err != nil {
+		if params.IsCodeUnauthorized(err) {
+			common.PermissionsMessage(ctx.Stderr, "remove storage snapshots")
+		}
+		return err
+	}
+	var failed bool
+	for i, result := range results {
+		if result.Error != nil {
+			fmt.Fprintf(ctx.Stderr, "removing snapshot %s: %v\n", c.snapshotIds[i], result.Error)
+			failed = true
+		}
+	}
+	if failed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+// StorageSnapshotAPI defines the API methods that the storage snapshot
+// commands use.
+type StorageSnapshotAPI interface {
+	Close() error
+	CreateSnapshots([]string) ([]params.CreateSnapshotResult, error)
+	ListSnapshots([]string) ([]params.ListSnapshotsResult, error)
+	DeleteSnapshots(string, []string) ([]params.ErrorResult, error)
+}
@@ -0,0 +1,229 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/testing"
+)
+
+type CreateSnapshotSuite struct {
+	SubStorageSuite
+	api *mockSnapshotAPI
+}
+
+var _ = gc.Suite(&CreateSnapshotSuite{})
+
+func (s *CreateSnapshotSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.api = &mockSnapshotAPI{}
+}
+
+func (s *CreateSnapshotSuite) TestInitErrors(c *gc.C) {
+	_, err := testing.RunCommand(c, storage.NewCreateSnapshotCommandForTest(s.api, s.store))
+	c.Assert(err, gc.ErrorMatches, "create-storage-snapshot requires at least one storage ID")
+	_, err = testing.RunCommand(c, storage.NewCreateSnapshotCommandForTest(s.api, s.store), "foo")
+	c.Assert(err, gc.ErrorMatches, `storage ID "foo" not valid`)
+}
+
+func (s *CreateSnapshotSuite) TestCreateSnapshots(c *gc.C) {
+	ctx, err := testing.RunCommand(c, storage.NewCreateSnapshotCommandForTest(s.api, s.store), "data/0", "data/1")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "CreateSnapshots", []string{"data/0", "data/1"})
+	c.Assert(testing.Stdout(ctx), gc.Equals, `
+data/0: snap-data-0
+data/1: snap-data-1
+`[1:])
+}
+
+func (s *CreateSnapshotSuite) TestCreateSnapshotsPartialFailure(c *gc.C) {
+	ctx, err := testing.RunCommand(c, storage.NewCreateSnapshotCommandForTest(s.api, s.store), "data/0", "fail/0")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "data/0: snap-data-0\n")
+	c.Assert(testing.Stderr(ctx), gc.Equals, "snapshotting fail/0: volume is busy\n")
+}
+
+func (s *CreateSnapshotSuite) TestCreateSnapshotsPending(c *gc.C) {
+	ctx, err := testing.RunCommand(c, storage.NewCreateSnapshotCommandForTest(s.api, s.store), "loop/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "loop/0: snapshot pending\n")
+}
+
+func (s *CreateSnapshotSuite) TestCreateSnapshotsError(c *gc.C) {
+	s.api.SetErrors(errors.New("boom"))
+	_, err := testing.RunCommand(c, storage.NewCreateSnapshotCommandForTest(s.api, s.store), "data/0")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type mockSnapshotAPI struct {
+	jujutesting.Stub
+}
+
+func (a *mockSnapshotAPI) Close() error {
+	a.MethodCall(a, "Close")
+	return a.NextErr()
+}
+
+func (a *mockSnapshotAPI) CreateSnapshots(storageIds []string) ([]params.CreateSnapshotResult, error) {
+	a.MethodCall(a, "CreateSnapshots", storageIds)
+	if err := a.NextErr(); err != nil {
+		return nil, err
+	}
+	results := make([]params.CreateSnapshotResult, len(storageIds))
+	for i, id := range storageIds {
+		if id == "fail/0" {
+			results[i].Error = &params.Error{Message: "volume is busy"}
+			continue
+		}
+		if id == "loop/0" {
+			results[i].Result = &params.SnapshotDetails{Pending: true}
+			continue
+		}
+		results[i].Result = &params.SnapshotDetails{
+			SnapshotId: "snap-" + strings.Replace(id, "/", "-", -1),
+		}
+	}
+	return results, nil
+}
+
+func (a *mockSnapshotAPI) ListSnapshots(storageIds []string) ([]params.ListSnapshotsResult, error) {
+	a.MethodCall(a, "ListSnapshots", storageIds)
+	if err := a.NextErr(); err != nil {
+		return nil, err
+	}
+	results := make([]params.ListSnapshotsResult, len(storageIds))
+	for i, id := range storageIds {
+		switch id {
+		case "fail/0":
+			results[i].Error = &params.Error{Message: "volume is busy"}
+		case "loop/0":
+			results[i].Result = []params.SnapshotDetails{{
+				SnapshotId: "volume-0-0@1",
+				Size:       1024,
+				Created:    time.Date(2017, 6, 1, 10, 15, 0, 0, time.UTC),
+			}, {
+				Pending: true,
+			}}
+		case "data/0":
+			results[i].Result = []params.SnapshotDetails{{
+				SnapshotId: "snap-data-0",
+				Size:       2048,
+				Created:    time.Date(2017, 6, 2, 10, 15, 0, 0, time.UTC),
+			}}
+		}
+	}
+	return results, nil
+}
+
+func (a *mockSnapshotAPI) DeleteSnapshots(storageId string, snapshotIds []string) ([]params.ErrorResult, error) {
+	a.MethodCall(a, "DeleteSnapshots", storageId, snapshotIds)
+	if err := a.NextErr(); err != nil {
+		return nil, err
+	}
+	results := make([]params.ErrorResult, len(snapshotIds))
+	for i, id := range snapshotIds {
+		if id == "snap-fail" {
+			results[i].Error = &params.Error{Message: "snapshot is busy"}
+		}
+	}
+	return results, nil
+}
+
+type ListSnapshotsSuite struct {
+	SubStorageSuite
+	api *mockSnapshotAPI
+}
+
+var _ = gc.Suite(&ListSnapshotsSuite{})
+
+func (s *ListSnapshotsSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.api = &mockSnapshotAPI{}
+}
+
+func (s *ListSnapshotsSuite) TestInitErrors(c *gc.C) {
+	_, err := testing.RunCommand(c, storage.NewListSnapshotsCommandForTest(s.api, s.store))
+	c.Assert(err, gc.ErrorMatches, "storage-snapshots requires at least one storage ID")
+	_, err = testing.RunCommand(c, storage.NewListSnapshotsCommandForTest(s.api, s.store), "foo")
+	c.Assert(err, gc.ErrorMatches, `storage ID "foo" not valid`)
+}
+
+func (s *ListSnapshotsSuite) TestListSnapshotsTabular(c *gc.C) {
+	ctx, err := testing.RunCommand(c, storage.NewListSnapshotsCommandForTest(s.api, s.store), "loop/0", "data/0")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "ListSnapshots", []string{"loop/0", "data/0"})
+	c.Assert(testing.Stdout(ctx), gc.Equals, `
+Storage  Snapshot      Size    Created
+data/0   snap-data-0   2.0GiB  2017-06-02T10:15:00Z
+loop/0   volume-0-0@1  1.0GiB  2017-06-01T10:15:00Z
+loop/0   (pending)             
+`[1:])
+}
+
+func (s *ListSnapshotsSuite) TestListSnapshotsYAML(c *gc.C) {
+	ctx, err := testing.RunCommand(c, storage.NewListSnapshotsCommandForTest(s.api, s.store), "loop/0", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, `
+loop/0:
+- id: volume-0-0@1
+  size: 1024
+  created: 2017-06-01T10:15:00Z
+- pending: true
+`[1:])
+}
+
+func (s *ListSnapshotsSuite) TestListSnapshotsNone(c *gc.C) {
+	ctx, err := testing.RunCommand(c, storage.NewListSnapshotsCommandForTest(s.api, s.store), "empty/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "")
+	c.Assert(testing.Stderr(ctx), gc.Equals, "No storage snapshots to display.\n")
+}
+
+func (s *ListSnapshotsSuite) TestListSnapshotsPartialFailure(c *gc.C) {
+	ctx, err := testing.RunCommand(c, storage.NewListSnapshotsCommandForTest(s.api, s.store), "data/0", "fail/0")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(testing.Stdout(ctx), jc.Contains, "snap-data-0")
+	c.Assert(testing.Stderr(ctx), gc.Equals, "listing snapshots of fail/0: volume is busy\n")
+}
+
+type RemoveSnapshotSuite struct {
+	SubStorageSuite
+	api *mockSnapshotAPI
+}
+
+var _ = gc.Suite(&RemoveSnapshotSuite{})
+
+func (s *RemoveSnapshotSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.api = &mockSnapshotAPI{}
+}
+
+func (s *RemoveSnapshotSuite) TestInitErrors(c *gc.C) {
+	_, err := testing.RunCommand(c, storage.NewRemoveSnapshotCommandForTest(s.api, s.store), "data/0")
+	c.Assert(err, gc.ErrorMatches, "remove-storage-snapshot requires a storage ID and at least one snapshot ID")
+	_, err = testing.RunCommand(c, storage.NewRemoveSnapshotCommandForTest(s.api, s.store), "foo", "snap-123")
+	c.Assert(err, gc.ErrorMatches, `storage ID "foo" not valid`)
+}
+
+func (s *RemoveSnapshotSuite) TestRemoveSnapshots(c *gc.C) {
+	_, err := testing.RunCommand(c, storage.NewRemoveSnapshotCommandForTest(s.api, s.store), "data/0", "snap-123", "snap-456")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "DeleteSnapshots", "data/0", []string{"snap-123", "snap-456"})
+}
+
+func (s *RemoveSnapshotSuite) TestRemoveSnapshotsPartialFailure(c *gc.C) {
+	ctx, err := testing.RunCommand(c, storage.NewRemoveSnapshotCommandForTest(s.api, s.store), "data/0", "snap-123", "snap-fail")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(testing.Stderr(ctx), gc.Equals, "removing snapshot snap-fail: snapshot is busy\n")
+}
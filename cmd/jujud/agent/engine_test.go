@@ -111,6 +111,7 @@ var (
 	notMigratingMachineWorkers = []string{
 		"api-address-updater",
 		"disk-manager",
+		"host-firewaller",
 		// "host-key-reporter", not stable, exits when done
 		"log-sender",
 		"logging-config-updater",
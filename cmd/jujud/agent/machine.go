@@ -69,6 +69,7 @@ import (
 	"github.com/juju/juju/service"
 	"github.com/juju/juju/service/common"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
 	"github.com/juju/juju/state/multiwatcher"
 	"github.com/juju/juju/state/stateenvirons"
 	"github.com/juju/juju/state/statemetrics"
@@ -91,6 +92,7 @@ import (
 	"github.com/juju/juju/worker/migrationmaster"
 	"github.com/juju/juju/worker/modelworkermanager"
 	"github.com/juju/juju/worker/mongoupgrader"
+	"github.com/juju/juju/worker/oplogarchiver"
 	"github.com/juju/juju/worker/peergrouper"
 	"github.com/juju/juju/worker/provisioner"
 	"github.com/juju/juju/worker/singular"
@@ -1078,6 +1080,10 @@ func (a *MachineAgent) startStateWorkers(
 			a.startWorkerAfterUpgrade(singularRunner, "txnpruner", func() (worker.Worker, error) {
 				return txnpruner.New(st, time.Hour*2, clock.WallClock), nil
 			})
+
+			a.startWorkerAfterUpgrade(singularRunner, "oplogarchiver", func() (worker.Worker, error) {
+				return oplogarchiver.New(oplogArchiver{st}, time.Second*10, clock.WallClock), nil
+			})
 		default:
 			return nil, errors.Errorf("unknown job type %q", job)
 		}
@@ -1233,6 +1239,7 @@ func (a *MachineAgent) newAPIserverWorker(
 		NewObserver:                   newObserver,
 		StatePool:                     statePool,
 		RegisterIntrospectionHandlers: registerIntrospectionHandlers,
+		DependencyReporter:            dependencyReporter,
 	})
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot start api server worker")
@@ -1668,6 +1675,20 @@ func (c singularStateConn) Ping() error {
 	return c.session.Ping()
 }
 
+// oplogArchiver implements oplogarchiver.OplogArchiver on top of a
+// State connection.
+type oplogArchiver struct {
+	st *state.State
+}
+
+func (a oplogArchiver) TailOplog(since time.Time) oplogarchiver.OplogTailer {
+	return backups.TailOplog(a.st, since)
+}
+
+func (a oplogArchiver) ArchiveOplog(now time.Time) error {
+	return backups.ArchiveOplog(a.st, now)
+}
+
 func metricAPI(st api.Connection) (metricsmanager.MetricsManagerClient, error) {
 	client, err := metricsmanager.NewClient(st)
 	if err != nil {
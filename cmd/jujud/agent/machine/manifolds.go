@@ -9,6 +9,7 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/pubsub"
+	"github.com/juju/utils"
 	"github.com/juju/utils/proxy"
 	"github.com/juju/utils/voyeur"
 	"github.com/prometheus/client_golang/prometheus"
@@ -33,6 +34,7 @@ import (
 	"github.com/juju/juju/worker/diskmanager"
 	"github.com/juju/juju/worker/fortress"
 	"github.com/juju/juju/worker/gate"
+	"github.com/juju/juju/worker/hostfirewaller"
 	"github.com/juju/juju/worker/hostkeyreporter"
 	"github.com/juju/juju/worker/identityfilewriter"
 	"github.com/juju/juju/worker/logforwarder"
@@ -320,7 +322,7 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 		migrationFortressName: ifFullyUpgraded(fortress.Manifold()),
 		migrationInactiveFlagName: migrationflag.Manifold(migrationflag.ManifoldConfig{
 			APICallerName: apiCallerName,
-			Check:         migrationflag.IsTerminal,
+			Check:         migrationflag.IsTerminalOrQueued,
 			NewFacade:     migrationflag.NewFacade,
 			NewWorker:     migrationflag.NewWorker,
 		}),
@@ -473,6 +475,17 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewFacade:     hostkeyreporter.NewFacade,
 			NewWorker:     hostkeyreporter.NewWorker,
 		})),
+
+		// The host firewaller renders the ports opened on the
+		// machine into host firewall rules, when enabled by the
+		// model's host-firewall-mode.
+		hostFirewallerName: ifNotMigrating(hostfirewaller.Manifold(hostfirewaller.ManifoldConfig{
+			AgentName:     agentName,
+			APICallerName: apiCallerName,
+			NewFacade:     hostfirewaller.NewFacade,
+			NewWorker:     hostfirewaller.NewWorker,
+			RunCommand:    utils.RunCommand,
+		})),
 		logForwarderName: ifFullyUpgraded(logforwarder.Manifold(logforwarder.ManifoldConfig{
 			StateName:     stateName,
 			APICallerName: apiCallerName,
@@ -536,5 +549,6 @@ const (
 	toolsVersionCheckerName  = "tools-version-checker"
 	machineActionName        = "machine-action-runner"
 	hostKeyReporterName      = "host-key-reporter"
+	hostFirewallerName       = "host-firewaller"
 	logForwarderName         = "log-forwarder"
 )
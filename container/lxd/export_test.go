@@ -6,6 +6,8 @@
 package lxd
 
 var (
-	NICDevice      = nicDevice
-	NetworkDevices = networkDevices
+	NICDevice         = nicDevice
+	NetworkDevices    = networkDevices
+	FilesystemDevices = filesystemDevices
+	RunCommand        = &runCommand
 )
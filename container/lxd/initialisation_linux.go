@@ -22,6 +22,7 @@ import (
 	"github.com/lxc/lxd/shared"
 
 	"github.com/juju/juju/container"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/tools/lxdclient"
 )
 
@@ -213,9 +214,7 @@ var interfaceAddrs = func() ([]net.Addr, error) {
 }
 
 func editLXDBridgeFile(input string, subnet string) string {
-	buffer := bytes.Buffer{}
-
-	newValues := map[string]string{
+	return setLXDBridgeValues(input, map[string]string{
 		"USE_LXD_BRIDGE":      "true",
 		"EXISTING_BRIDGE":     "",
 		"LXD_BRIDGE":          "lxdbr0",
@@ -226,7 +225,33 @@ func editLXDBridgeFile(input string, subnet string) string {
 		"LXD_IPV4_DHCP_MAX":   "253",
 		"LXD_IPV4_NAT":        "true",
 		"LXD_IPV6_PROXY":      "false",
-	}
+	})
+}
+
+// editLXDBridgeFileIPv6 configures lxdbr0 with an IPv6 subnet only,
+// for hosts that have no IPv4 connectivity to NAT containers onto.
+func editLXDBridgeFileIPv6(input string, subnet string) string {
+	return setLXDBridgeValues(input, map[string]string{
+		"USE_LXD_BRIDGE":      "true",
+		"EXISTING_BRIDGE":     "",
+		"LXD_BRIDGE":          "lxdbr0",
+		"LXD_IPV4_ADDR":       "",
+		"LXD_IPV4_NETMASK":    "",
+		"LXD_IPV4_NETWORK":    "",
+		"LXD_IPV4_DHCP_RANGE": "",
+		"LXD_IPV4_DHCP_MAX":   "",
+		"LXD_IPV4_NAT":        "false",
+		"LXD_IPV6_ADDR":       fmt.Sprintf("%s:%s::1", lxdIPv6Prefix, subnet),
+		"LXD_IPV6_MASK":       "64",
+		"LXD_IPV6_NETWORK":    fmt.Sprintf("%s:%s::1/64", lxdIPv6Prefix, subnet),
+		"LXD_IPV6_NAT":        "true",
+		"LXD_IPV6_PROXY":      "false",
+	})
+}
+
+func setLXDBridgeValues(input string, newValues map[string]string) string {
+	buffer := bytes.Buffer{}
+
 	found := map[string]bool{}
 
 	for _, line := range strings.Split(input, "\n") {
@@ -348,6 +373,59 @@ func findNextAvailableIPv4Subnet() (string, error) {
 	return "", errors.New("could not find unused subnet")
 }
 
+// lxdIPv6Prefix is the /48 unique local prefix from which IPv6-only
+// hosts allocate the /64 for lxdbr0.
+const lxdIPv6Prefix = "fd42:4a55:4a55"
+
+// findNextAvailableIPv6Subnet is the IPv6 counterpart of
+// findNextAvailableIPv4Subnet: it scans the machine's interfaces for
+// /64 networks within lxdIPv6Prefix and returns the fourth group of
+// the next one not in use, in hex.
+func findNextAvailableIPv6Subnet() (string, error) {
+	_, prefixNetwork, err := net.ParseCIDR(lxdIPv6Prefix + "::/48")
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+
+	addrs, err := interfaceAddrs()
+	if err != nil {
+		return "", errors.Annotatef(err, "cannot get network interface addresses")
+	}
+
+	max := 0
+	usedSubnets := make(map[int]bool)
+
+	for _, address := range addrs {
+		addr, network, err := net.ParseCIDR(address.String())
+		if err != nil {
+			logger.Debugf("cannot parse address %q: %v (ignoring)", address.String(), err)
+			continue
+		}
+		if !prefixNetwork.Contains(addr) {
+			logger.Debugf("find available subnet, skipping %q", network.String())
+			continue
+		}
+		subnet := int(addr[7])
+		usedSubnets[subnet] = true
+		if subnet > max {
+			max = subnet
+		}
+	}
+
+	if len(usedSubnets) == 0 {
+		return "0", nil
+	}
+
+	for i := 0; i < 256; i++ {
+		max = (max + 1) % 256
+		if _, inUse := usedSubnets[max]; !inUse {
+			return fmt.Sprintf("%x", max), nil
+		}
+	}
+
+	return "", errors.New("could not find unused subnet")
+}
+
 func parseLXDBridgeConfigValues(input string) map[string]string {
 	values := make(map[string]string)
 
@@ -380,12 +458,20 @@ func parseLXDBridgeConfigValues(input string) map[string]string {
 
 // bridgeConfiguration ensures that input has a valid setting for
 // LXD_IPV4_ADDR, returning the existing input if is already set, and
-// allocating the next available subnet if it is not.
+// allocating the next available subnet if it is not. On IPv6-only
+// hosts LXD_IPV6_ADDR is ensured instead.
 func bridgeConfiguration(input string) (string, error) {
 	values := parseLXDBridgeConfigValues(input)
 	ipAddr := net.ParseIP(values["LXD_IPV4_ADDR"])
 
 	if ipAddr == nil || ipAddr.To4() == nil {
+		addrs, err := interfaceAddrs()
+		if err != nil {
+			return "", errors.Annotatef(err, "cannot get network interface addresses")
+		}
+		if network.IPv6Only(addrs) {
+			return ipv6BridgeConfiguration(input, values)
+		}
 		logger.Infof("LXD_IPV4_ADDR is not set; searching for unused subnet")
 		subnet, err := findNextAvailableIPv4Subnet()
 		if err != nil {
@@ -396,3 +482,17 @@ func bridgeConfiguration(input string) (string, error) {
 	}
 	return input, nil
 }
+
+func ipv6BridgeConfiguration(input string, values map[string]string) (string, error) {
+	ipAddr := net.ParseIP(values["LXD_IPV6_ADDR"])
+	if ipAddr != nil && ipAddr.To4() == nil {
+		return input, nil
+	}
+	logger.Infof("LXD_IPV6_ADDR is not set on IPv6-only host; searching for unused subnet")
+	subnet, err := findNextAvailableIPv6Subnet()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	logger.Infof("setting LXD_IPV6_ADDR=%s:%s::1", lxdIPv6Prefix, subnet)
+	return editLXDBridgeFileIPv6(input, subnet), nil
+}
@@ -471,3 +471,61 @@ func (s *InitialiserSuite) TestBridgeConfigurationWithNewSubnet(c *gc.C) {
 	actualValues := parseLXDBridgeConfigValues(result)
 	c.Assert(expectedValues, gc.DeepEquals, actualValues)
 }
+
+func (s *InitialiserSuite) TestFindAvailableIPv6SubnetWithNoAddresses(c *gc.C) {
+	s.PatchValue(&interfaceAddrs, func() ([]net.Addr, error) {
+		return testAddresses(c, "2001:db8::1/64")
+	})
+	subnet, err := findNextAvailableIPv6Subnet()
+	c.Assert(err, gc.IsNil)
+	c.Assert(subnet, gc.Equals, "0")
+}
+
+func (s *InitialiserSuite) TestFindAvailableIPv6SubnetWithExistingNetworks(c *gc.C) {
+	s.PatchValue(&interfaceAddrs, func() ([]net.Addr, error) {
+		return testAddresses(c, "2001:db8::1/64", "fd42:4a55:4a55:9::1/64", "fd42:4a55:4a55:3::1/64")
+	})
+	subnet, err := findNextAvailableIPv6Subnet()
+	c.Assert(err, gc.IsNil)
+	c.Assert(subnet, gc.Equals, "a")
+}
+
+func (s *InitialiserSuite) TestBridgeConfigurationIPv6Only(c *gc.C) {
+	s.PatchValue(&interfaceAddrs, func() ([]net.Addr, error) {
+		return testAddresses(c, "127.0.0.1/8", "::1/128", "2001:db8::1/64", "fe80::aa8e:a275:7ae0:34af/64")
+	})
+
+	expectedValues := map[string]string{
+		"USE_LXD_BRIDGE":      "true",
+		"EXISTING_BRIDGE":     "",
+		"LXD_BRIDGE":          "lxdbr0",
+		"LXD_IPV4_ADDR":       "",
+		"LXD_IPV4_NETMASK":    "",
+		"LXD_IPV4_NETWORK":    "",
+		"LXD_IPV4_DHCP_RANGE": "",
+		"LXD_IPV4_DHCP_MAX":   "",
+		"LXD_IPV4_NAT":        "false",
+		"LXD_IPV6_ADDR":       "fd42:4a55:4a55:0::1",
+		"LXD_IPV6_MASK":       "64",
+		"LXD_IPV6_NETWORK":    "fd42:4a55:4a55:0::1/64",
+		"LXD_IPV6_NAT":        "true",
+		"LXD_IPV6_PROXY":      "false",
+	}
+
+	result, err := bridgeConfiguration(`LXD_IPV4_ADDR=""`)
+	c.Assert(err, gc.IsNil)
+	actualValues := parseLXDBridgeConfigValues(result)
+	c.Assert(actualValues, gc.DeepEquals, expectedValues)
+}
+
+func (s *InitialiserSuite) TestBridgeConfigurationIPv6OnlyNoChangeRequired(c *gc.C) {
+	s.PatchValue(&interfaceAddrs, func() ([]net.Addr, error) {
+		return testAddresses(c, "2001:db8::1/64")
+	})
+	input := `LXD_IPV4_ADDR=""
+LXD_IPV6_ADDR="fd42:4a55:4a55:0::1"
+`
+	result, err := bridgeConfiguration(input)
+	c.Assert(err, gc.IsNil)
+	c.Assert(result, gc.Equals, input)
+}
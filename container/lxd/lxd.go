@@ -157,6 +157,20 @@ func (manager *containerManager) CreateContainer(
 		logger.Infof("instance %q configured with %v network devices", name, nics)
 	}
 
+	devices := make(lxdclient.Devices)
+	for devName, device := range nics {
+		devices[devName] = device
+	}
+	if storageConfig != nil {
+		disks, err := filesystemDevices(name, instanceConfig.DataDir, storageConfig.Filesystems)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		for devName, device := range disks {
+			devices[devName] = device
+		}
+	}
+
 	// Push the required /etc/network/interfaces file to the container.
 	// By pushing this file (which happens after LXD init, and before LXD
 	// start) we ensure that we get Juju's version of ENI, as opposed to
@@ -174,7 +188,7 @@ func (manager *containerManager) CreateContainer(
 		Name:     name,
 		Image:    manager.client.ImageNameForSeries(series),
 		Metadata: metadata,
-		Devices:  nics,
+		Devices:  devices,
 		Profiles: profiles,
 		Files: lxdclient.Files{
 			lxdclient.File{
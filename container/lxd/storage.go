@@ -0,0 +1,109 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build go1.3
+
+package lxd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider"
+	"github.com/juju/juju/tools/lxdclient"
+)
+
+// runCommand runs a command on the host, returning its combined output.
+var runCommand = utils.RunCommand
+
+// filesystemDevices prepares the host directories for the container's
+// lxd-bind filesystems, and returns the disk devices that bind them
+// into the container at the paths expected by the lxd-bind storage
+// provider. Filesystems with other providers are ignored.
+func filesystemDevices(containerName, dataDir string, filesystems []storage.FilesystemParams) (lxdclient.Devices, error) {
+	devices := make(lxdclient.Devices)
+	storageDir := filepath.Join(dataDir, "storage")
+	for _, f := range filesystems {
+		if f.Provider != provider.LXDBindProviderType {
+			continue
+		}
+		cfg, err := provider.ParseLXDBindConfig(f.Attributes)
+		if err != nil {
+			return nil, errors.Annotatef(err, "filesystem %s", f.Tag.Id())
+		}
+		source, err := ensureBindSource(cfg, containerName, f.Tag)
+		if err != nil {
+			return nil, errors.Annotatef(err, "preparing filesystem %s", f.Tag.Id())
+		}
+		devices[f.Tag.String()] = lxdclient.Device{
+			"type":   "disk",
+			"source": source,
+			"path":   provider.LXDBindFilesystemPath(storageDir, f.Tag),
+		}
+	}
+	return devices, nil
+}
+
+// ensureBindSource ensures that the host directory for the filesystem
+// exists, returning its path. If the host path is on ZFS or btrfs, the
+// directory is created as a dataset or subvolume respectively, so that
+// the pool's quota may be applied to it.
+func ensureBindSource(cfg *provider.LXDBindConfig, containerName string, tag names.FilesystemTag) (string, error) {
+	containerDir := filepath.Join(cfg.HostPath, containerName)
+	source := filepath.Join(containerDir, tag.String())
+	if _, err := os.Stat(source); err == nil {
+		// The directory was created by a previous attempt
+		// to create the container, or the container is
+		// being recreated; the data is reused.
+		return source, nil
+	} else if !os.IsNotExist(err) {
+		return "", errors.Trace(err)
+	}
+	if err := os.MkdirAll(containerDir, 0755); err != nil {
+		return "", errors.Trace(err)
+	}
+	fsType, err := runCommand("stat", "-f", "-c", "%T", cfg.HostPath)
+	if err != nil {
+		return "", errors.Annotatef(err, "getting filesystem type of %q", cfg.HostPath)
+	}
+	quota := fmt.Sprintf("%dM", cfg.Quota)
+	switch fsType = strings.TrimSpace(fsType); fsType {
+	case "zfs":
+		parent, err := runCommand("zfs", "list", "-H", "-o", "name", cfg.HostPath)
+		if err != nil {
+			return "", errors.Annotatef(err, "getting ZFS dataset for %q", cfg.HostPath)
+		}
+		args := []string{"create", "-o", "mountpoint=" + source}
+		if cfg.Quota > 0 {
+			args = append(args, "-o", "quota="+quota)
+		}
+		dataset := fmt.Sprintf("%s/%s-%s", strings.TrimSpace(parent), containerName, tag.String())
+		if _, err := runCommand("zfs", append(args, dataset)...); err != nil {
+			return "", errors.Annotatef(err, "creating ZFS dataset %q", dataset)
+		}
+	case "btrfs":
+		if _, err := runCommand("btrfs", "subvolume", "create", source); err != nil {
+			return "", errors.Annotatef(err, "creating btrfs subvolume %q", source)
+		}
+		if cfg.Quota > 0 {
+			if _, err := runCommand("btrfs", "qgroup", "limit", quota, source); err != nil {
+				return "", errors.Annotatef(err, "limiting btrfs subvolume %q", source)
+			}
+		}
+	default:
+		if cfg.Quota > 0 {
+			return "", errors.NotSupportedf("quota on %s host path %q", fsType, cfg.HostPath)
+		}
+		if err := os.Mkdir(source, 0755); err != nil {
+			return "", errors.Trace(err)
+		}
+	}
+	return source, nil
+}
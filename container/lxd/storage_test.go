@@ -0,0 +1,135 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build go1.3
+
+package lxd_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/container/lxd"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider"
+	"github.com/juju/juju/tools/lxdclient"
+)
+
+type storageSuite struct {
+	jujutesting.IsolationSuite
+	hostPath string
+	commands []string
+	outputs  map[string]string
+}
+
+var _ = gc.Suite(&storageSuite{})
+
+func (s *storageSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.hostPath = c.MkDir()
+	s.commands = nil
+	s.outputs = map[string]string{
+		"stat -f -c %T " + s.hostPath: "ext2/ext3\n",
+	}
+	s.PatchValue(lxd.RunCommand, func(cmd string, args ...string) (string, error) {
+		command := strings.Join(append([]string{cmd}, args...), " ")
+		s.commands = append(s.commands, command)
+		return s.outputs[command], nil
+	})
+}
+
+func (s *storageSuite) filesystemParams(providerType storage.ProviderType, quota string) storage.FilesystemParams {
+	attrs := map[string]interface{}{"host-path": s.hostPath}
+	if quota != "" {
+		attrs["quota"] = quota
+	}
+	return storage.FilesystemParams{
+		Tag:        names.NewFilesystemTag("0/lxd/0/1"),
+		Size:       1024,
+		Provider:   providerType,
+		Attributes: attrs,
+	}
+}
+
+func (s *storageSuite) TestFilesystemDevices(c *gc.C) {
+	devices, err := lxd.FilesystemDevices("juju-0-lxd-0", "/var/lib/juju", []storage.FilesystemParams{
+		s.filesystemParams(provider.LXDBindProviderType, ""),
+		s.filesystemParams(provider.RootfsProviderType, ""),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	source := filepath.Join(s.hostPath, "juju-0-lxd-0", "filesystem-0-lxd-0-1")
+	c.Assert(devices, jc.DeepEquals, lxdclient.Devices{
+		"filesystem-0-lxd-0-1": lxdclient.Device{
+			"type":   "disk",
+			"source": source,
+			"path":   "/var/lib/juju/storage/lxd-bind/0/lxd/0/1",
+		},
+	})
+	c.Assert(source, jc.IsDirectory)
+}
+
+func (s *storageSuite) TestFilesystemDevicesExisting(c *gc.C) {
+	source := filepath.Join(s.hostPath, "juju-0-lxd-0", "filesystem-0-lxd-0-1")
+	err := os.MkdirAll(source, 0755)
+	c.Assert(err, jc.ErrorIsNil)
+
+	devices, err := lxd.FilesystemDevices("juju-0-lxd-0", "/var/lib/juju", []storage.FilesystemParams{
+		s.filesystemParams(provider.LXDBindProviderType, "1G"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(devices, gc.HasLen, 1)
+	c.Assert(s.commands, gc.HasLen, 0)
+}
+
+func (s *storageSuite) TestFilesystemDevicesQuotaNotSupported(c *gc.C) {
+	_, err := lxd.FilesystemDevices("juju-0-lxd-0", "/var/lib/juju", []storage.FilesystemParams{
+		s.filesystemParams(provider.LXDBindProviderType, "1G"),
+	})
+	c.Assert(err, gc.ErrorMatches, `preparing filesystem 0/lxd/0/1: quota on ext2/ext3 host path ".*" not supported`)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *storageSuite) TestFilesystemDevicesZFS(c *gc.C) {
+	s.outputs["stat -f -c %T "+s.hostPath] = "zfs\n"
+	s.outputs["zfs list -H -o name "+s.hostPath] = "tank/juju\n"
+
+	_, err := lxd.FilesystemDevices("juju-0-lxd-0", "/var/lib/juju", []storage.FilesystemParams{
+		s.filesystemParams(provider.LXDBindProviderType, "1G"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	source := filepath.Join(s.hostPath, "juju-0-lxd-0", "filesystem-0-lxd-0-1")
+	c.Assert(s.commands, jc.DeepEquals, []string{
+		"stat -f -c %T " + s.hostPath,
+		"zfs list -H -o name " + s.hostPath,
+		"zfs create -o mountpoint=" + source + " -o quota=1024M tank/juju/juju-0-lxd-0-filesystem-0-lxd-0-1",
+	})
+}
+
+func (s *storageSuite) TestFilesystemDevicesBtrfs(c *gc.C) {
+	s.outputs["stat -f -c %T "+s.hostPath] = "btrfs\n"
+
+	_, err := lxd.FilesystemDevices("juju-0-lxd-0", "/var/lib/juju", []storage.FilesystemParams{
+		s.filesystemParams(provider.LXDBindProviderType, "1G"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	source := filepath.Join(s.hostPath, "juju-0-lxd-0", "filesystem-0-lxd-0-1")
+	c.Assert(s.commands, jc.DeepEquals, []string{
+		"stat -f -c %T " + s.hostPath,
+		"btrfs subvolume create " + source,
+		"btrfs qgroup limit 1024M " + source,
+	})
+}
+
+func (s *storageSuite) TestFilesystemDevicesInvalidConfig(c *gc.C) {
+	params := s.filesystemParams(provider.LXDBindProviderType, "")
+	params.Attributes["host-path"] = "relative"
+	_, err := lxd.FilesystemDevices("juju-0-lxd-0", "/var/lib/juju", []storage.FilesystemParams{params})
+	c.Assert(err, gc.ErrorMatches, `filesystem 0/lxd/0/1: host-path "relative" \(must be an absolute path\) not valid`)
+}
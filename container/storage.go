@@ -3,6 +3,8 @@
 
 package container
 
+import "github.com/juju/juju/storage"
+
 // StorageConfig defines how the container will be configured to support
 // storage requirements.
 type StorageConfig struct {
@@ -10,4 +12,10 @@ type StorageConfig struct {
 	// AllowMount is true is the container is required to allow
 	// mounting block devices.
 	AllowMount bool
+
+	// Filesystems holds the parameters of the machine-scoped
+	// filesystems that will be provisioned in the container.
+	// Container managers may use these to prepare host storage
+	// for the container.
+	Filesystems []storage.FilesystemParams
 }
@@ -73,6 +73,12 @@ const (
 	// detault
 	MongoMemoryProfile = "mongo-memory-profile"
 
+	// MaxConcurrentMigrations sets the number of model migrations
+	// the controller will run at once. Queued migrations wait until
+	// fewer than this many migrations are in progress. Zero means
+	// there is no limit.
+	MaxConcurrentMigrations = "max-concurrent-migrations"
+
 	// Attribute Defaults
 
 	// DefaultAuditingEnabled contains the default value for the
@@ -91,6 +97,10 @@ const (
 
 	// DefaultMongoMemoryProfile is the default profile used by mongo.
 	DefaultMongoMemoryProfile = MongoProfLow
+
+	// DefaultMaxConcurrentMigrations is the default number of model
+	// migrations the controller will run at once.
+	DefaultMaxConcurrentMigrations = 4
 )
 
 // ControllerOnlyConfigAttributes are attributes which are only relevant
@@ -107,6 +117,7 @@ var ControllerOnlyConfigAttributes = []string{
 	SetNUMAControlPolicyKey,
 	StatePort,
 	MongoMemoryProfile,
+	MaxConcurrentMigrations,
 }
 
 // ControllerOnlyAttribute returns true if the specified attribute name
@@ -269,6 +280,15 @@ func (c Config) AllowModelAccess() bool {
 	return value
 }
 
+// MaxConcurrentMigrations returns the number of model migrations the
+// controller will run at once, or zero if there is no limit.
+func (c Config) MaxConcurrentMigrations() int {
+	if value, ok := c[MaxConcurrentMigrations]; ok {
+		return value.(int)
+	}
+	return DefaultMaxConcurrentMigrations
+}
+
 // Validate ensures that config is a valid configuration.
 func Validate(c Config) error {
 	if v, ok := c[IdentityPublicKey].(string); ok {
@@ -310,6 +330,10 @@ func Validate(c Config) error {
 		}
 	}
 
+	if v, ok := c[MaxConcurrentMigrations].(int); ok && v < 0 {
+		return errors.Errorf("%s: expected a non-negative number, got %d", MaxConcurrentMigrations, v)
+	}
+
 	return nil
 }
 
@@ -330,6 +354,7 @@ var configChecker = schema.FieldMap(schema.Fields{
 	AutocertDNSNameKey:      schema.String(),
 	AllowModelAccessKey:     schema.Bool(),
 	MongoMemoryProfile:      schema.String(),
+	MaxConcurrentMigrations: schema.ForceInt(),
 }, schema.Defaults{
 	APIPort:                 DefaultAPIPort,
 	AuditingEnabled:         DefaultAuditingEnabled,
@@ -341,4 +366,5 @@ var configChecker = schema.FieldMap(schema.Fields{
 	AutocertDNSNameKey:      schema.Omit,
 	AllowModelAccessKey:     schema.Omit,
 	MongoMemoryProfile:      schema.Omit,
+	MaxConcurrentMigrations: schema.Omit,
 })
@@ -121,8 +121,27 @@ var validateTests = []struct {
 		controller.CACertKey:         testing.CACert,
 	},
 	expectError: `invalid identity public key: wrong length for base64 key, got 3 want 32`,
+}, {
+	about: "negative max-concurrent-migrations",
+	config: controller.Config{
+		controller.MaxConcurrentMigrations: -1,
+		controller.CACertKey:               testing.CACert,
+	},
+	expectError: `max-concurrent-migrations: expected a non-negative number, got -1`,
 }}
 
+func (s *ConfigSuite) TestMaxConcurrentMigrations(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.MaxConcurrentMigrations(), gc.Equals, controller.DefaultMaxConcurrentMigrations)
+
+	cfg, err = controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, map[string]interface{}{
+		controller.MaxConcurrentMigrations: "2",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.MaxConcurrentMigrations(), gc.Equals, 2)
+}
+
 func (s *ConfigSuite) TestValidate(c *gc.C) {
 	for i, test := range validateTests {
 		c.Logf("test %d: %v", i, test.about)
@@ -5,6 +5,7 @@ package description
 
 import (
 	"encoding/base64"
+	"time"
 
 	"github.com/juju/utils/set"
 
@@ -29,8 +30,12 @@ type Application interface {
 	CharmModifiedVersion() int
 	ForceCharm() bool
 	Exposed() bool
+	ExposedCIDRs() []string
+	ExposedSpaces() []string
 	MinUnits() int
 
+	EgressRules() []string
+
 	EndpointBindings() map[string]string
 
 	Settings() map[string]interface{}
@@ -41,6 +46,9 @@ type Application interface {
 	MetricsCredentials() []byte
 	StorageConstraints() map[string]StorageConstraint
 
+	DefaultHookTimeout() time.Duration
+	HookTimeouts() map[string]time.Duration
+
 	Resources() []Resource
 	AddResource(ResourceArgs) Resource
 
@@ -69,6 +77,16 @@ type application struct {
 	Exposed_    bool `yaml:"exposed,omitempty"`
 	MinUnits_   int  `yaml:"min-units,omitempty"`
 
+	// ExposedCIDRs and ExposedSpaces restrict the sources from which
+	// an exposed application may be accessed.
+	ExposedCIDRs_  []string `yaml:"exposed-cidrs,omitempty"`
+	ExposedSpaces_ []string `yaml:"exposed-spaces,omitempty"`
+
+	// EgressRules holds the destinations, in the form
+	// "<cidr>:<port-range>", to which the application's units
+	// may connect.
+	EgressRules_ []string `yaml:"egress-rules,omitempty"`
+
 	Status_        *status `yaml:"status"`
 	StatusHistory_ `yaml:"status-history"`
 
@@ -81,6 +99,10 @@ type application struct {
 
 	MetricsCredentials_ string `yaml:"metrics-creds,omitempty"`
 
+	// Hook timeouts are stored as strings parseable by time.ParseDuration.
+	DefaultHookTimeout_ string            `yaml:"default-hook-timeout,omitempty"`
+	HookTimeouts_       map[string]string `yaml:"hook-timeouts,omitempty"`
+
 	// unit count will be assumed by the number of units associated.
 	Units_ units `yaml:"units"`
 
@@ -102,13 +124,18 @@ type ApplicationArgs struct {
 	CharmModifiedVersion int
 	ForceCharm           bool
 	Exposed              bool
+	ExposedCIDRs         []string
+	ExposedSpaces        []string
 	MinUnits             int
+	EgressRules          []string
 	EndpointBindings     map[string]string
 	Settings             map[string]interface{}
 	Leader               string
 	LeadershipSettings   map[string]interface{}
 	StorageConstraints   map[string]StorageConstraintArgs
 	MetricsCredentials   []byte
+	DefaultHookTimeout   time.Duration
+	HookTimeouts         map[string]time.Duration
 }
 
 func newApplication(args ApplicationArgs) *application {
@@ -122,7 +149,10 @@ func newApplication(args ApplicationArgs) *application {
 		CharmModifiedVersion_: args.CharmModifiedVersion,
 		ForceCharm_:           args.ForceCharm,
 		Exposed_:              args.Exposed,
+		ExposedCIDRs_:         args.ExposedCIDRs,
+		ExposedSpaces_:        args.ExposedSpaces,
 		MinUnits_:             args.MinUnits,
+		EgressRules_:          args.EgressRules,
 		EndpointBindings_:     args.EndpointBindings,
 		Settings_:             args.Settings,
 		Leader_:               args.Leader,
@@ -130,6 +160,15 @@ func newApplication(args ApplicationArgs) *application {
 		MetricsCredentials_:   creds,
 		StatusHistory_:        newStatusHistory(),
 	}
+	if args.DefaultHookTimeout != 0 {
+		app.DefaultHookTimeout_ = args.DefaultHookTimeout.String()
+	}
+	if len(args.HookTimeouts) > 0 {
+		app.HookTimeouts_ = make(map[string]string)
+		for kind, timeout := range args.HookTimeouts {
+			app.HookTimeouts_[kind] = timeout.String()
+		}
+	}
 	app.setUnits(nil)
 	app.setResources(nil)
 	if len(args.StorageConstraints) > 0 {
@@ -186,11 +225,26 @@ func (a *application) Exposed() bool {
 	return a.Exposed_
 }
 
+// ExposedCIDRs implements Application.
+func (a *application) ExposedCIDRs() []string {
+	return a.ExposedCIDRs_
+}
+
+// ExposedSpaces implements Application.
+func (a *application) ExposedSpaces() []string {
+	return a.ExposedSpaces_
+}
+
 // MinUnits implements Application.
 func (a *application) MinUnits() int {
 	return a.MinUnits_
 }
 
+// EgressRules implements Application.
+func (a *application) EgressRules() []string {
+	return a.EgressRules_
+}
+
 // EndpointBindings implements Application.
 func (a *application) EndpointBindings() map[string]string {
 	return a.EndpointBindings_
@@ -230,6 +284,27 @@ func (a *application) MetricsCredentials() []byte {
 	return creds
 }
 
+// DefaultHookTimeout implements Application.
+func (a *application) DefaultHookTimeout() time.Duration {
+	// Decode errors are thrown away here as the value is checked
+	// when importing, and generated from a time.Duration otherwise.
+	timeout, _ := time.ParseDuration(a.DefaultHookTimeout_)
+	return timeout
+}
+
+// HookTimeouts implements Application.
+func (a *application) HookTimeouts() map[string]time.Duration {
+	if len(a.HookTimeouts_) == 0 {
+		return nil
+	}
+	result := make(map[string]time.Duration)
+	for kind, value := range a.HookTimeouts_ {
+		timeout, _ := time.ParseDuration(value)
+		result[kind] = timeout
+	}
+	return result
+}
+
 // Status implements Application.
 func (a *application) Status() Status {
 	// To avoid typed nils check nil here.
@@ -386,35 +461,45 @@ var applicationDeserializationFuncs = map[int]applicationDeserializationFunc{
 
 func importApplicationV1(source map[string]interface{}) (*application, error) {
 	fields := schema.Fields{
-		"name":                schema.String(),
-		"series":              schema.String(),
-		"subordinate":         schema.Bool(),
-		"charm-url":           schema.String(),
-		"cs-channel":          schema.String(),
-		"charm-mod-version":   schema.Int(),
-		"force-charm":         schema.Bool(),
-		"exposed":             schema.Bool(),
-		"min-units":           schema.Int(),
-		"status":              schema.StringMap(schema.Any()),
-		"endpoint-bindings":   schema.StringMap(schema.String()),
-		"settings":            schema.StringMap(schema.Any()),
-		"leader":              schema.String(),
-		"leadership-settings": schema.StringMap(schema.Any()),
-		"storage-constraints": schema.StringMap(schema.StringMap(schema.Any())),
-		"metrics-creds":       schema.String(),
-		"resources":           schema.StringMap(schema.Any()),
-		"units":               schema.StringMap(schema.Any()),
+		"name":                 schema.String(),
+		"series":               schema.String(),
+		"subordinate":          schema.Bool(),
+		"charm-url":            schema.String(),
+		"cs-channel":           schema.String(),
+		"charm-mod-version":    schema.Int(),
+		"force-charm":          schema.Bool(),
+		"exposed":              schema.Bool(),
+		"exposed-cidrs":        schema.List(schema.String()),
+		"exposed-spaces":       schema.List(schema.String()),
+		"min-units":            schema.Int(),
+		"egress-rules":         schema.List(schema.String()),
+		"status":               schema.StringMap(schema.Any()),
+		"endpoint-bindings":    schema.StringMap(schema.String()),
+		"settings":             schema.StringMap(schema.Any()),
+		"leader":               schema.String(),
+		"leadership-settings":  schema.StringMap(schema.Any()),
+		"storage-constraints":  schema.StringMap(schema.StringMap(schema.Any())),
+		"metrics-creds":        schema.String(),
+		"default-hook-timeout": schema.String(),
+		"hook-timeouts":        schema.StringMap(schema.String()),
+		"resources":            schema.StringMap(schema.Any()),
+		"units":                schema.StringMap(schema.Any()),
 	}
 
 	defaults := schema.Defaults{
-		"subordinate":         false,
-		"force-charm":         false,
-		"exposed":             false,
-		"min-units":           int64(0),
-		"leader":              "",
-		"metrics-creds":       "",
-		"storage-constraints": schema.Omit,
-		"endpoint-bindings":   schema.Omit,
+		"subordinate":          false,
+		"force-charm":          false,
+		"exposed":              false,
+		"exposed-cidrs":        schema.Omit,
+		"exposed-spaces":       schema.Omit,
+		"min-units":            int64(0),
+		"egress-rules":         schema.Omit,
+		"leader":               "",
+		"metrics-creds":        "",
+		"default-hook-timeout": "",
+		"hook-timeouts":        schema.Omit,
+		"storage-constraints":  schema.Omit,
+		"endpoint-bindings":    schema.Omit,
 	}
 	addAnnotationSchema(fields, defaults)
 	addConstraintsSchema(fields, defaults)
@@ -437,7 +522,10 @@ func importApplicationV1(source map[string]interface{}) (*application, error) {
 		CharmModifiedVersion_: int(valid["charm-mod-version"].(int64)),
 		ForceCharm_:           valid["force-charm"].(bool),
 		Exposed_:              valid["exposed"].(bool),
+		ExposedCIDRs_:         convertToStringSlice(valid["exposed-cidrs"]),
+		ExposedSpaces_:        convertToStringSlice(valid["exposed-spaces"]),
 		MinUnits_:             int(valid["min-units"].(int64)),
+		EgressRules_:          convertToStringSlice(valid["egress-rules"]),
 		EndpointBindings_:     convertToStringMap(valid["endpoint-bindings"]),
 		Settings_:             valid["settings"].(map[string]interface{}),
 		Leader_:               valid["leader"].(string),
@@ -474,6 +562,21 @@ func importApplicationV1(source map[string]interface{}) (*application, error) {
 	}
 	result.MetricsCredentials_ = encodedCreds
 
+	if timeout := valid["default-hook-timeout"].(string); timeout != "" {
+		if _, err := time.ParseDuration(timeout); err != nil {
+			return nil, errors.Annotate(err, "default hook timeout not valid")
+		}
+		result.DefaultHookTimeout_ = timeout
+	}
+	if timeouts, ok := valid["hook-timeouts"]; ok {
+		result.HookTimeouts_ = convertToStringMap(timeouts)
+		for kind, timeout := range result.HookTimeouts_ {
+			if _, err := time.ParseDuration(timeout); err != nil {
+				return nil, errors.Annotatef(err, "%q hook timeout not valid", kind)
+			}
+		}
+	}
+
 	status, err := importStatus(valid["status"].(map[string]interface{}))
 	if err != nil {
 		return nil, errors.Trace(err)
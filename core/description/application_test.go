@@ -4,6 +4,8 @@
 package description
 
 import (
+	"time"
+
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
@@ -251,6 +253,58 @@ func (s *ApplicationSerializationSuite) TestStorageConstraints(c *gc.C) {
 	c.Check(second.Count(), gc.Equals, uint64(7))
 }
 
+func (s *ApplicationSerializationSuite) TestHookTimeouts(c *gc.C) {
+	args := minimalApplicationArgs()
+	args.DefaultHookTimeout = 5 * time.Minute
+	args.HookTimeouts = map[string]time.Duration{
+		"install":          time.Hour,
+		"relation-changed": 30 * time.Second,
+	}
+	initial := minimalApplication(args)
+
+	application := s.exportImport(c, initial)
+	c.Assert(application.DefaultHookTimeout(), gc.Equals, 5*time.Minute)
+	c.Assert(application.HookTimeouts(), jc.DeepEquals, args.HookTimeouts)
+}
+
+func (s *ApplicationSerializationSuite) TestExposedIngress(c *gc.C) {
+	args := minimalApplicationArgs()
+	args.Exposed = true
+	args.ExposedCIDRs = []string{"10.0.0.0/8"}
+	args.ExposedSpaces = []string{"mgmt"}
+	initial := minimalApplication(args)
+
+	application := s.exportImport(c, initial)
+	c.Assert(application.Exposed(), jc.IsTrue)
+	c.Assert(application.ExposedCIDRs(), jc.DeepEquals, []string{"10.0.0.0/8"})
+	c.Assert(application.ExposedSpaces(), jc.DeepEquals, []string{"mgmt"})
+}
+
+func (s *ApplicationSerializationSuite) TestEgressRules(c *gc.C) {
+	args := minimalApplicationArgs()
+	args.EgressRules = []string{"10.0.0.0/8:443/tcp", "10.0.0.0/8:53/udp"}
+	initial := minimalApplication(args)
+
+	application := s.exportImport(c, initial)
+	c.Assert(application.EgressRules(), jc.DeepEquals, args.EgressRules)
+}
+
+func (s *ApplicationSerializationSuite) TestHookTimeoutsInvalid(c *gc.C) {
+	initial := minimalApplication()
+	initial.HookTimeouts_ = map[string]string{"install": "forever"}
+	bytes, err := yaml.Marshal(applications{
+		Version:       1,
+		Applications_: []*application{initial},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = importApplications(source)
+	c.Assert(err, gc.ErrorMatches, `application 0: "install" hook timeout not valid: .*`)
+}
+
 func (s *ApplicationSerializationSuite) TestLeaderValid(c *gc.C) {
 	args := minimalApplicationArgs()
 	args.Leader = "ubuntu/1"
@@ -16,6 +16,11 @@ type CloudCredential interface {
 	Name() string
 	AuthType() string
 	Attributes() map[string]string
+
+	// Reference returns true if the credential only refers to a
+	// credential that already exists on the controller the model is
+	// imported into, and so carries no auth type or attributes.
+	Reference() bool
 }
 
 // CloudCredentialArgs is an argument struct used to create a new internal
@@ -26,6 +31,7 @@ type CloudCredentialArgs struct {
 	Name       string
 	AuthType   string
 	Attributes map[string]string
+	Reference  bool
 }
 
 func newCloudCredential(args CloudCredentialArgs) *cloudCredential {
@@ -36,6 +42,7 @@ func newCloudCredential(args CloudCredentialArgs) *cloudCredential {
 		Name_:       args.Name,
 		AuthType_:   args.AuthType,
 		Attributes_: args.Attributes,
+		Reference_:  args.Reference,
 	}
 }
 
@@ -48,6 +55,7 @@ type cloudCredential struct {
 	Name_       string            `yaml:"name"`
 	AuthType_   string            `yaml:"auth-type"`
 	Attributes_ map[string]string `yaml:"attributes,omitempty"`
+	Reference_  bool              `yaml:"reference,omitempty"`
 }
 
 // Owner implements CloudCredential.
@@ -75,6 +83,11 @@ func (c *cloudCredential) Attributes() map[string]string {
 	return c.Attributes_
 }
 
+// Reference implements CloudCredential.
+func (c *cloudCredential) Reference() bool {
+	return c.Reference_
+}
+
 // importCloudCredential constructs a new CloudCredential from a map
 // representing a serialised CloudCredential instance.
 func importCloudCredential(source map[string]interface{}) (*cloudCredential, error) {
@@ -104,10 +117,12 @@ func importCloudCredentialV1(source map[string]interface{}) (*cloudCredential, e
 		"name":       schema.String(),
 		"auth-type":  schema.String(),
 		"attributes": schema.StringMap(schema.String()),
+		"reference":  schema.Bool(),
 	}
 	// Some values don't have to be there.
 	defaults := schema.Defaults{
 		"attributes": schema.Omit,
+		"reference":  false,
 	}
 	checker := schema.FieldMap(fields, defaults)
 
@@ -119,11 +134,12 @@ func importCloudCredentialV1(source map[string]interface{}) (*cloudCredential, e
 	// From here we know that the map returned from the schema coercion
 	// contains fields of the right type.
 	creds := &cloudCredential{
-		Version:   1,
-		Owner_:    valid["owner"].(string),
-		Cloud_:    valid["cloud"].(string),
-		Name_:     valid["name"].(string),
-		AuthType_: valid["auth-type"].(string),
+		Version:    1,
+		Owner_:     valid["owner"].(string),
+		Cloud_:     valid["cloud"].(string),
+		Name_:      valid["name"].(string),
+		AuthType_:  valid["auth-type"].(string),
+		Reference_: valid["reference"].(bool),
 	}
 	if attributes, found := valid["attributes"]; found {
 		creds.Attributes_ = convertToStringMap(attributes)
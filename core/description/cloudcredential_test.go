@@ -79,6 +79,28 @@ func (s *CloudCredentialSerializationSuite) TestAllArgs(c *gc.C) {
 	c.Check(creds.Name(), gc.Equals, args.Name)
 	c.Check(creds.AuthType(), gc.Equals, args.AuthType)
 	c.Check(creds.Attributes(), jc.DeepEquals, args.Attributes)
+	c.Check(creds.Reference(), jc.IsFalse)
+}
+
+func (s *CloudCredentialSerializationSuite) TestParsingSerializedReference(c *gc.C) {
+	initial := newCloudCredential(CloudCredentialArgs{
+		Owner:     names.NewUserTag("me"),
+		Cloud:     names.NewCloudTag("altostratus"),
+		Name:      "creds",
+		Reference: true,
+	})
+
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	imported, err := importCloudCredential(source)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(imported, jc.DeepEquals, initial)
+	c.Assert(imported.Reference(), jc.IsTrue)
 }
 
 func (s *CloudCredentialSerializationSuite) TestParsingSerializedData(c *gc.C) {
@@ -397,7 +397,7 @@ type Storage interface {
 	Tag() names.StorageTag
 	Kind() string
 	// Owner returns the tag of the application or unit that owns this storage
-	// instance.
+	// instance, or nil if the storage is detached.
 	Owner() (names.Tag, error)
 	Name() string
 
@@ -23,6 +23,7 @@ type Model interface {
 
 	Cloud() string
 	CloudRegion() string
+	SetCloud(cloud, region string)
 	CloudCredential() CloudCredential
 	SetCloudCredential(CloudCredentialArgs)
 	Tag() names.ModelTag
@@ -559,6 +560,12 @@ func (m *model) CloudRegion() string {
 	return m.CloudRegion_
 }
 
+// SetCloud implements Model.
+func (m *model) SetCloud(cloud, region string) {
+	m.Cloud_ = cloud
+	m.CloudRegion_ = region
+}
+
 // CloudCredential implements Model.
 func (m *model) CloudCredential() CloudCredential {
 	if m.CloudCredential_ == nil {
@@ -90,6 +90,18 @@ func (*ModelSerializationSuite) TestCloudCredentials(c *gc.C) {
 	c.Check(creds.Attributes(), jc.DeepEquals, args.Attributes)
 }
 
+func (s *ModelSerializationSuite) TestSetCloud(c *gc.C) {
+	model := NewModel(ModelArgs{
+		Owner:       names.NewUserTag("me"),
+		Cloud:       "altostratus",
+		CloudRegion: "east",
+	})
+	model.SetCloud("cirrus", "west")
+	model = s.exportImport(c, model)
+	c.Check(model.Cloud(), gc.Equals, "cirrus")
+	c.Check(model.CloudRegion(), gc.Equals, "west")
+}
+
 func (s *ModelSerializationSuite) exportImport(c *gc.C, initial Model) Model {
 	bytes, err := Serialize(initial)
 	c.Assert(err, jc.ErrorIsNil)
@@ -17,7 +17,7 @@ type storages struct {
 type storage struct {
 	ID_    string `yaml:"id"`
 	Kind_  string `yaml:"kind"`
-	Owner_ string `yaml:"owner"`
+	Owner_ string `yaml:"owner,omitempty"`
 	Name_  string `yaml:"name"`
 
 	Attachments_ []string `yaml:"attachments"`
@@ -88,10 +88,8 @@ func (s *storage) Validate() error {
 	if s.ID_ == "" {
 		return errors.NotValidf("storage missing id")
 	}
-	if s.Owner_ == "" {
-		return errors.NotValidf("storage %q missing owner", s.ID_)
-	}
-	// Also check that the owner and attachments are valid.
+	// Detached storage has no owner, but an owner that is set must be
+	// valid.
 	if _, err := s.Owner(); err != nil {
 		return errors.Wrap(err, errors.NotValidf("storage %q invalid owner", s.ID_))
 	}
@@ -146,9 +144,13 @@ func importStorageV1(source map[string]interface{}) (*storage, error) {
 		"attachments": schema.List(schema.String()),
 	}
 
-	// Normally a list would have defaults, but in this case storage
-	// should always have at least one attachment.
-	checker := schema.FieldMap(fields, nil) // no defaults
+	// Detached storage has no owner. Normally a list would have
+	// defaults, but the attachments are always written, even when
+	// empty.
+	defaults := schema.Defaults{
+		"owner": "",
+	}
+	checker := schema.FieldMap(fields, defaults)
 
 	coerced, err := checker.Coerce(source, nil)
 	if err != nil {
@@ -121,3 +121,31 @@ func (s *StorageSerializationSuite) TestParsingSerializedData(c *gc.C) {
 	storage := s.exportImport(c, original)
 	c.Assert(storage, jc.DeepEquals, original)
 }
+
+func (s *StorageSerializationSuite) TestStorageValidDetached(c *gc.C) {
+	args := testStorageArgs()
+	args.Owner = nil
+	args.Attachments = nil
+	storage := newStorage(args)
+	c.Assert(storage.Validate(), jc.ErrorIsNil)
+	owner, err := storage.Owner()
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(owner, gc.IsNil)
+}
+
+func (s *StorageSerializationSuite) TestStorageInvalidOwner(c *gc.C) {
+	storage := testStorage()
+	storage.Owner_ = "foo"
+	err := storage.Validate()
+	c.Check(err, gc.ErrorMatches, `storage "db/0" invalid owner not valid`)
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *StorageSerializationSuite) TestParsingSerializedDataDetached(c *gc.C) {
+	args := testStorageArgs()
+	args.Owner = nil
+	args.Attachments = nil
+	original := newStorage(args)
+	storage := s.exportImport(c, original)
+	c.Assert(storage, jc.DeepEquals, original)
+}
@@ -0,0 +1,76 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+)
+
+// CloudRemap describes how a model's cloud details are rewritten when
+// it is imported into a migration's target controller. This allows a
+// model to move between controllers whose cloud definitions have
+// different names but refer to the same cloud.
+type CloudRemap struct {
+	// Cloud holds the name of the cloud on the target controller. It
+	// is empty if the model's cloud isn't remapped.
+	Cloud string
+
+	// CloudRegion holds the name of the region of Cloud to use. It
+	// may only be set if Cloud is.
+	CloudRegion string
+
+	// Credential identifies the credential on the target controller
+	// to use in place of the model's credential. It is the zero
+	// value if the credential isn't remapped.
+	Credential names.CloudCredentialTag
+}
+
+// IsEmpty returns true if the remap doesn't change anything.
+func (r CloudRemap) IsEmpty() bool {
+	return r.Cloud == "" && r.CloudRegion == "" && r.Credential.Id() == ""
+}
+
+// Validate returns an error if the CloudRemap contains bad data. Nil
+// is returned otherwise.
+func (r CloudRemap) Validate() error {
+	if r.Cloud != "" && !names.IsValidCloud(r.Cloud) {
+		return errors.NotValidf("cloud %q", r.Cloud)
+	}
+	if r.CloudRegion != "" && r.Cloud == "" {
+		return errors.NotValidf("cloud region without cloud")
+	}
+	if r.Credential.Id() != "" && r.Cloud != "" && r.Credential.Cloud().Id() != r.Cloud {
+		return errors.NotValidf("credential %q for cloud %q", r.Credential.Id(), r.Cloud)
+	}
+	return nil
+}
+
+// CheckCredential returns an error if the cloud of a model using the
+// given credential is remapped without also remapping the credential.
+// A credential belongs to a single cloud, so the model's credential
+// can't be used with the cloud it is remapped to.
+func (r CloudRemap) CheckCredential(modelCredential names.CloudCredentialTag) error {
+	if r.Cloud == "" || r.Credential.Id() != "" || modelCredential.Id() == "" {
+		return nil
+	}
+	return errors.NewNotValid(nil, fmt.Sprintf(
+		"model uses credential %q, so a target credential is required to remap its cloud to %q",
+		modelCredential.Id(), r.Cloud,
+	))
+}
+
+// Apply returns a copy of info with the remapped cloud details.
+func (r CloudRemap) Apply(info ModelInfo) ModelInfo {
+	if r.Cloud != "" {
+		info.Cloud = r.Cloud
+		info.CloudRegion = r.CloudRegion
+	}
+	if r.Credential.Id() != "" {
+		info.CloudCredential = r.Credential
+	}
+	return info
+}
@@ -0,0 +1,119 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/core/migration"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type CloudRemapSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(new(CloudRemapSuite))
+
+func (s *CloudRemapSuite) TestIsEmpty(c *gc.C) {
+	c.Check(migration.CloudRemap{}.IsEmpty(), jc.IsTrue)
+	c.Check(migration.CloudRemap{Cloud: "openstack"}.IsEmpty(), jc.IsFalse)
+	c.Check(migration.CloudRemap{
+		Credential: names.NewCloudCredentialTag("openstack/bob/default"),
+	}.IsEmpty(), jc.IsFalse)
+}
+
+func (s *CloudRemapSuite) TestValidation(c *gc.C) {
+	tests := []struct {
+		label        string
+		remap        migration.CloudRemap
+		errorPattern string
+	}{{
+		"empty",
+		migration.CloudRemap{},
+		"",
+	}, {
+		"cloud and region",
+		migration.CloudRemap{Cloud: "openstack", CloudRegion: "RegionOne"},
+		"",
+	}, {
+		"credential only",
+		migration.CloudRemap{Credential: names.NewCloudCredentialTag("openstack/bob/default")},
+		"",
+	}, {
+		"matching credential",
+		migration.CloudRemap{
+			Cloud:      "openstack",
+			Credential: names.NewCloudCredentialTag("openstack/bob/default"),
+		},
+		"",
+	}, {
+		"invalid cloud",
+		migration.CloudRemap{Cloud: "open stack"},
+		`cloud "open stack" not valid`,
+	}, {
+		"region without cloud",
+		migration.CloudRemap{CloudRegion: "RegionOne"},
+		"cloud region without cloud not valid",
+	}, {
+		"credential for other cloud",
+		migration.CloudRemap{
+			Cloud:      "openstack",
+			Credential: names.NewCloudCredentialTag("aws/bob/default"),
+		},
+		`credential "aws/bob/default" for cloud "openstack" not valid`,
+	}}
+
+	for _, test := range tests {
+		c.Logf("- %s", test.label)
+		err := test.remap.Validate()
+		if test.errorPattern == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.errorPattern)
+		}
+	}
+}
+
+func (s *CloudRemapSuite) TestApply(c *gc.C) {
+	info := migration.ModelInfo{
+		UUID:        "uuid",
+		Name:        "model",
+		Cloud:       "mystack",
+		CloudRegion: "one",
+	}
+	c.Check(migration.CloudRemap{}.Apply(info), jc.DeepEquals, info)
+
+	cred := names.NewCloudCredentialTag("openstack/bob/default")
+	remapped := migration.CloudRemap{
+		Cloud:      "openstack",
+		Credential: cred,
+	}.Apply(info)
+	c.Check(remapped, jc.DeepEquals, migration.ModelInfo{
+		UUID:            "uuid",
+		Name:            "model",
+		Cloud:           "openstack",
+		CloudCredential: cred,
+	})
+}
+
+func (s *CloudRemapSuite) TestCheckCredential(c *gc.C) {
+	modelCred := names.NewCloudCredentialTag("aws/bob/default")
+	targetCred := names.NewCloudCredentialTag("openstack/bob/default")
+
+	c.Check(migration.CloudRemap{}.CheckCredential(modelCred), jc.ErrorIsNil)
+	c.Check(migration.CloudRemap{Cloud: "openstack"}.CheckCredential(names.CloudCredentialTag{}), jc.ErrorIsNil)
+	c.Check(migration.CloudRemap{
+		Cloud:      "openstack",
+		Credential: targetCred,
+	}.CheckCredential(modelCred), jc.ErrorIsNil)
+
+	err := migration.CloudRemap{Cloud: "openstack"}.CheckCredential(modelCred)
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches,
+		`model uses credential "aws/bob/default", so a target credential is required to remap its cloud to "openstack"`)
+}
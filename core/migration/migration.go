@@ -36,6 +36,10 @@ type MigrationStatus struct {
 	// TargetInfo contains the details of how to connect to the target
 	// controller.
 	TargetInfo TargetInfo
+
+	// CloudRemap describes how the model's cloud details are changed
+	// when it is imported into the target controller.
+	CloudRemap CloudRemap
 }
 
 // SerializedModel wraps a buffer contain a serialised Juju model as
@@ -53,6 +57,10 @@ type SerializedModel struct {
 	// source controller.
 	Tools map[version.Binary]string // version -> tools URI
 
+	// ToolsSizes holds the size in bytes of each tools binary in
+	// Tools, where it is known. It is only used to report progress.
+	ToolsSizes map[version.Binary]int64
+
 	// Resources represents all the resources in use in the model.
 	Resources []SerializedModelResource
 }
@@ -72,6 +80,17 @@ type ModelInfo struct {
 	Name                   string
 	AgentVersion           version.Number
 	ControllerAgentVersion version.Number
+
+	// Cloud and CloudRegion name the cloud the model is deployed
+	// to. They may be empty when the source controller doesn't
+	// report them, in which case they aren't checked.
+	Cloud       string
+	CloudRegion string
+
+	// CloudCredential identifies a credential which must already
+	// exist on the target controller for the model to use. It is
+	// only set when the model's credential is remapped.
+	CloudCredential names.CloudCredentialTag
 }
 
 func (i *ModelInfo) Validate() error {
@@ -20,6 +20,7 @@ const (
 	DONE
 	ABORT
 	ABORTDONE
+	QUEUED
 )
 
 var phaseNames = []string{
@@ -35,6 +36,7 @@ var phaseNames = []string{
 	"DONE",
 	"ABORT",
 	"ABORTDONE",
+	"QUEUED",
 }
 
 // String returns the name of an model migration phase constant.
@@ -93,6 +95,7 @@ func (p Phase) IsRunning() bool {
 // The keys are the "from" states and the values enumerate the
 // possible "to" states.
 var validTransitions = map[Phase][]Phase{
+	QUEUED:      {QUIESCE, ABORT},
 	QUIESCE:     {IMPORT, ABORT},
 	IMPORT:      {VALIDATION, ABORT},
 	VALIDATION:  {SUCCESS, ABORT},
@@ -47,6 +47,7 @@ func (s *PhaseSuite) TestParseInvalid(c *gc.C) {
 }
 
 func (s *PhaseSuite) TestIsTerminal(c *gc.C) {
+	c.Check(migration.QUEUED.IsTerminal(), jc.IsFalse)
 	c.Check(migration.QUIESCE.IsTerminal(), jc.IsFalse)
 	c.Check(migration.SUCCESS.IsTerminal(), jc.IsFalse)
 	c.Check(migration.ABORT.IsTerminal(), jc.IsFalse)
@@ -58,6 +59,7 @@ func (s *PhaseSuite) TestIsTerminal(c *gc.C) {
 func (s *PhaseSuite) TestIsRunning(c *gc.C) {
 	c.Check(migration.UNKNOWN.IsRunning(), jc.IsFalse)
 	c.Check(migration.NONE.IsRunning(), jc.IsFalse)
+	c.Check(migration.QUEUED.IsRunning(), jc.IsFalse)
 
 	c.Check(migration.QUIESCE.IsRunning(), jc.IsTrue)
 	c.Check(migration.IMPORT.IsRunning(), jc.IsTrue)
@@ -77,4 +79,7 @@ func (s *PhaseSuite) TestCanTransitionTo(c *gc.C) {
 	c.Check(migration.QUIESCE.CanTransitionTo(migration.IMPORT), jc.IsTrue)
 	c.Check(migration.QUIESCE.CanTransitionTo(migration.Phase(-1)), jc.IsFalse)
 	c.Check(migration.ABORT.CanTransitionTo(migration.QUIESCE), jc.IsFalse)
+	c.Check(migration.QUEUED.CanTransitionTo(migration.QUIESCE), jc.IsTrue)
+	c.Check(migration.QUEUED.CanTransitionTo(migration.ABORT), jc.IsTrue)
+	c.Check(migration.QUEUED.CanTransitionTo(migration.IMPORT), jc.IsFalse)
 }
@@ -0,0 +1,27 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration
+
+// PrecheckProblem describes something found by the migration
+// prechecks that would prevent a model from being migrated.
+type PrecheckProblem struct {
+	// Entity names what the problem was found with, such as
+	// "model", "machine 0" or "unit mysql/0".
+	Entity string
+
+	// Message describes the problem.
+	Message string
+}
+
+// PrecheckReport holds every problem found by the migration
+// prechecks for a model, on both the source and target controllers.
+type PrecheckReport struct {
+	Source []PrecheckProblem
+	Target []PrecheckProblem
+}
+
+// OK returns true if the prechecks found no problems.
+func (r PrecheckReport) OK() bool {
+	return len(r.Source) == 0 && len(r.Target) == 0
+}
@@ -0,0 +1,56 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration
+
+import (
+	"time"
+)
+
+// Progress holds structured details about how far a migration has
+// got through its current phase.
+type Progress struct {
+	// BinariesUploaded holds the number of bytes of charms, tools and
+	// resources sent to the target controller so far.
+	BinariesUploaded int64
+
+	// BinariesTotal holds the number of bytes of charms, tools and
+	// resources known to need sending to the target controller. The
+	// size of a charm or tools binary is only known once it has been
+	// fetched, so the total grows during the upload.
+	BinariesTotal int64
+
+	// MinionReportsReceived holds the number of migration minions
+	// which have reported back for the current phase.
+	MinionReportsReceived int
+
+	// MinionReportsExpected holds the number of migration minions
+	// which are expected to report back for the current phase.
+	MinionReportsExpected int
+}
+
+// IsZero returns true if no progress has been recorded.
+func (p Progress) IsZero() bool {
+	return p == Progress{}
+}
+
+// PhaseTime records when a migration entered a phase.
+type PhaseTime struct {
+	Phase Phase
+	Start time.Time
+}
+
+// PhaseDurations returns how long a migration spent in each of the
+// phases given, which must be in the order they were entered. The
+// last phase is assumed to have lasted until end.
+func PhaseDurations(phases []PhaseTime, end time.Time) []time.Duration {
+	durations := make([]time.Duration, len(phases))
+	for i, phase := range phases {
+		until := end
+		if i+1 < len(phases) {
+			until = phases[i+1].Start
+		}
+		durations[i] = until.Sub(phase.Start)
+	}
+	return durations
+}
@@ -0,0 +1,44 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/migration"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ProgressSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(new(ProgressSuite))
+
+func (s *ProgressSuite) TestIsZero(c *gc.C) {
+	c.Check(migration.Progress{}.IsZero(), jc.IsTrue)
+	c.Check(migration.Progress{MinionReportsExpected: 1}.IsZero(), jc.IsFalse)
+}
+
+func (s *ProgressSuite) TestPhaseDurations(c *gc.C) {
+	start := time.Date(2017, 3, 1, 10, 0, 0, 0, time.UTC)
+	phases := []migration.PhaseTime{
+		{Phase: migration.QUIESCE, Start: start},
+		{Phase: migration.IMPORT, Start: start.Add(time.Minute)},
+		{Phase: migration.VALIDATION, Start: start.Add(11 * time.Minute)},
+	}
+	durations := migration.PhaseDurations(phases, start.Add(12*time.Minute))
+	c.Check(durations, jc.DeepEquals, []time.Duration{
+		time.Minute,
+		10 * time.Minute,
+		time.Minute,
+	})
+}
+
+func (s *ProgressSuite) TestPhaseDurationsEmpty(c *gc.C) {
+	c.Check(migration.PhaseDurations(nil, time.Now()), gc.HasLen, 0)
+}
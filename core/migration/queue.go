@@ -0,0 +1,21 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration
+
+// QueuePosition describes where a migration waiting in the QUEUED
+// phase is in the controller's queue of migrations.
+type QueuePosition struct {
+	// Ahead holds the number of active migrations in the controller
+	// which were started before this one.
+	Ahead int
+
+	// Limit holds the maximum number of migrations the controller
+	// will run at once. Zero means there is no limit.
+	Limit int
+}
+
+// CanStart returns true if the migration may leave the QUEUED phase.
+func (p QueuePosition) CanStart() bool {
+	return p.Limit <= 0 || p.Ahead < p.Limit
+}
@@ -0,0 +1,25 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/migration"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type QueueSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(new(QueueSuite))
+
+func (s *QueueSuite) TestCanStart(c *gc.C) {
+	c.Check(migration.QueuePosition{Ahead: 0, Limit: 1}.CanStart(), jc.IsTrue)
+	c.Check(migration.QueuePosition{Ahead: 3, Limit: 4}.CanStart(), jc.IsTrue)
+	c.Check(migration.QueuePosition{Ahead: 4, Limit: 4}.CanStart(), jc.IsFalse)
+	c.Check(migration.QueuePosition{Ahead: 10, Limit: 0}.CanStart(), jc.IsTrue)
+}
@@ -55,6 +55,14 @@ type StartInstanceParams struct {
 	// for attachment to the instance being started.
 	Volumes []storage.VolumeParams
 
+	// Filesystems is a set of parameters for machine-scoped filesystems
+	// that will be provisioned on the machine by its storage provisioner.
+	//
+	// StartInstance may use these to prepare the machine for the
+	// filesystems, e.g. by binding host directories into a container.
+	// Filesystems that do not require preparation should be ignored.
+	Filesystems []storage.FilesystemParams
+
 	// NetworkInfo is an optional list of network interface details,
 	// necessary to configure on the instance.
 	NetworkInfo []network.InterfaceInfo
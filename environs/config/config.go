@@ -23,6 +23,7 @@ import (
 	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/juju/osenv"
 	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/juju/network"
 )
 
 var logger = loggo.GetLogger("juju.environs.config")
@@ -43,6 +44,20 @@ const (
 	FwNone = "none"
 )
 
+const (
+	// HostFwNone requests that machine agents do not manage the
+	// firewall of the machines they run on.
+	HostFwNone = "none"
+
+	// HostFwIPTables requests that machine agents render the ports
+	// opened on their machines as iptables and ip6tables rules.
+	HostFwIPTables = "iptables"
+
+	// HostFwNFTables requests that machine agents render the ports
+	// opened on their machines as nftables rules.
+	HostFwNFTables = "nftables"
+)
+
 // TODO(katco-): Please grow this over time.
 // Centralized place to store values of config keys. This transitions
 // mistakes in referencing key-values to a compile-time error.
@@ -129,6 +144,18 @@ const (
 	// automatically retry a hook that has failed
 	AutomaticallyRetryHooks = "automatically-retry-hooks"
 
+	// RecordFailedHooksKey determines whether the uniter will record
+	// the context of failed hooks so that they can be replayed offline.
+	RecordFailedHooksKey = "record-failed-hooks"
+
+	// HostFirewallModeKey determines whether, and with which tool,
+	// machine agents manage the firewall of the machines they run on.
+	HostFirewallModeKey = "host-firewall-mode"
+
+	// AddressPreferenceKey determines which address family is chosen
+	// when a machine has equally suitable IPv4 and IPv6 addresses.
+	AddressPreferenceKey = "address-preference"
+
 	// TransmitVendorMetricsKey is the key for whether the controller sends
 	// metrics collected in this model for anonymized aggregate analytics.
 	TransmitVendorMetricsKey = "transmit-vendor-metrics"
@@ -791,6 +818,35 @@ func (c *Config) AutomaticallyRetryHooks() bool {
 	}
 }
 
+// RecordFailedHooks returns whether the uniter should record the
+// context of failed hooks, for replaying them offline.
+func (c *Config) RecordFailedHooks() bool {
+	value, _ := c.defined[RecordFailedHooksKey].(bool)
+	return value
+}
+
+// HostFirewallMode returns how machine agents should manage the
+// firewall of their machines (HostFwNone, HostFwIPTables or
+// HostFwNFTables).
+func (c *Config) HostFirewallMode() string {
+	if value, _ := c.defined[HostFirewallModeKey].(string); value != "" {
+		return value
+	}
+	return HostFwNone
+}
+
+// AddressPreference returns the address family to favour when choosing
+// a machine's public and private addresses.
+func (c *Config) AddressPreference() network.AddressPreference {
+	value, _ := c.defined[AddressPreferenceKey].(string)
+	pref, err := network.ParseAddressPreference(value)
+	if err != nil {
+		// The schema guarantees a valid value.
+		return network.PreferIPv4
+	}
+	return pref
+}
+
 // TransmitVendorMetrics returns whether the controller sends charm-collected metrics
 // in this model for anonymized aggregate analytics. By default this should be true.
 func (c *Config) TransmitVendorMetrics() bool {
@@ -994,6 +1050,9 @@ var alwaysOptional = schema.Defaults{
 	"disable-network-management": schema.Omit,
 	IgnoreMachineAddresses:       schema.Omit,
 	AutomaticallyRetryHooks:      schema.Omit,
+	RecordFailedHooksKey:         schema.Omit,
+	HostFirewallModeKey:          schema.Omit,
+	AddressPreferenceKey:         schema.Omit,
 	"test-mode":                  schema.Omit,
 	TransmitVendorMetricsKey:     schema.Omit,
 	NetBondReconfigureDelayKey:   schema.Omit,
@@ -1352,6 +1411,35 @@ data of the store. (default false)`,
 		Type:        environschema.Tbool,
 		Group:       environschema.EnvironGroup,
 	},
+	RecordFailedHooksKey: {
+		Description: "Determines whether the uniter should record the context of failed hooks, so they can be replayed with replay-hook",
+		Type:        environschema.Tbool,
+		Group:       environschema.EnvironGroup,
+	},
+	HostFirewallModeKey: {
+		Description: `The mode to use for firewalling on the machines themselves.
+
+'none' leaves the machines' firewalls alone.
+
+'iptables' and 'nftables' request that machine agents drop incoming
+traffic, other than to the ports opened by units on the machine, SSH,
+and the controller's API, or from the model's machines, using the
+named tool. This is useful for
+clouds without security groups, such as MAAS, LXD and manual clouds.
+They also enforce the egress rules of applications set with
+set-egress.`,
+		Type:   environschema.Tstring,
+		Values: []interface{}{HostFwNone, HostFwIPTables, HostFwNFTables},
+		Group:  environschema.EnvironGroup,
+	},
+	AddressPreferenceKey: {
+		Description: `The address family to favour when a machine has both IPv4 and IPv6 addresses of the same scope ('ipv4' or 'ipv6').
+Machines with addresses of only one family always use that family.
+With 'ipv6', applications exposed without restriction are also reachable from all IPv6 addresses.`,
+		Type:   environschema.Tstring,
+		Values: []interface{}{string(network.PreferIPv4), string(network.PreferIPv6)},
+		Group:  environschema.EnvironGroup,
+	},
 	TransmitVendorMetricsKey: {
 		Description: "Determines whether metrics declared by charms deployed into this model are sent for anonymized aggregate analytics",
 		Type:        environschema.Tbool,
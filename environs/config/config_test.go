@@ -23,6 +23,7 @@ import (
 	"github.com/juju/juju/cert"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/juju/osenv"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/testing"
 )
 
@@ -999,6 +1000,53 @@ func (s *ConfigSuite) TestAutoHookRetryTrueEnv(c *gc.C) {
 	c.Assert(config.AutomaticallyRetryHooks(), gc.Equals, true)
 }
 
+func (s *ConfigSuite) TestRecordFailedHooksDefault(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{})
+	c.Assert(config.RecordFailedHooks(), jc.IsFalse)
+}
+
+func (s *ConfigSuite) TestRecordFailedHooks(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{
+		"record-failed-hooks": "true"})
+	c.Assert(config.RecordFailedHooks(), jc.IsTrue)
+}
+
+func (s *ConfigSuite) TestHostFirewallModeDefault(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{})
+	c.Assert(config.HostFirewallMode(), gc.Equals, "none")
+}
+
+func (s *ConfigSuite) TestHostFirewallMode(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{
+		"host-firewall-mode": "nftables"})
+	c.Assert(config.HostFirewallMode(), gc.Equals, "nftables")
+}
+
+func (s *ConfigSuite) TestHostFirewallModeInvalid(c *gc.C) {
+	_, err := config.New(config.UseDefaults, sampleConfig.Merge(testing.Attrs{
+		"host-firewall-mode": "pf",
+	}))
+	c.Assert(err, gc.ErrorMatches, `host-firewall-mode: expected one of .*`)
+}
+
+func (s *ConfigSuite) TestAddressPreferenceDefault(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{})
+	c.Assert(config.AddressPreference(), gc.Equals, network.PreferIPv4)
+}
+
+func (s *ConfigSuite) TestAddressPreference(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{
+		"address-preference": "ipv6"})
+	c.Assert(config.AddressPreference(), gc.Equals, network.PreferIPv6)
+}
+
+func (s *ConfigSuite) TestAddressPreferenceInvalid(c *gc.C) {
+	_, err := config.New(config.UseDefaults, sampleConfig.Merge(testing.Attrs{
+		"address-preference": "ipx",
+	}))
+	c.Assert(err, gc.ErrorMatches, `address-preference: expected one of .*`)
+}
+
 func (s *ConfigSuite) TestProxyValuesWithFallback(c *gc.C) {
 	s.addJujuFiles(c)
 
@@ -35,6 +35,11 @@ const (
 	// the service or unit that owns the Juju storage instance
 	// that an IaaS storage resource is assigned to.
 	JujuStorageOwner = JujuTagPrefix + "storage-owner"
+
+	// JujuReleasedFromModel is the tag name used for identifying
+	// the Juju model that an IaaS storage resource was released
+	// from. Released resources are no longer managed by Juju.
+	JujuReleasedFromModel = JujuTagPrefix + "released-from-model-uuid"
 )
 
 // ResourceTagger is an interface that can provide resource tags.
@@ -0,0 +1,138 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package featuretests
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/sshclient"
+	"github.com/juju/juju/controller"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/mongo"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/provider/dummy"
+	"github.com/juju/juju/state"
+)
+
+// ipv6Suite runs a dummy provider model whose machines have only IPv6
+// addresses, and checks that nothing along the way assumes an IPv4
+// address exists.
+type ipv6Suite struct {
+	jujutesting.JujuConnSuite
+}
+
+// ipv6Addresses are the provider addresses of an IPv6-only machine:
+// one of each scope, with no IPv4 address at all.
+func ipv6Addresses() []network.Address {
+	return network.NewAddresses(
+		"::1",
+		"fe80::10",
+		"fd00::10",
+		"2001:db8::10",
+	)
+}
+
+func (s *ipv6Suite) addIPv6OnlyMachine(c *gc.C) *state.Machine {
+	m, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = m.SetProviderAddresses(ipv6Addresses()...)
+	c.Assert(err, jc.ErrorIsNil)
+	return m
+}
+
+func (s *ipv6Suite) TestDummyInstanceAddresses(c *gc.C) {
+	inst, _ := jujutesting.AssertStartInstance(c, s.Environ, s.ControllerConfig.ControllerUUID(), "1")
+	dummy.SetInstanceAddresses(inst, ipv6Addresses())
+
+	addrs, err := inst.Addresses()
+	c.Assert(err, jc.ErrorIsNil)
+	public, ok := network.SelectPublicAddress(addrs)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(public.Value, gc.Equals, "2001:db8::10")
+	internal, ok := network.SelectInternalAddress(addrs, false)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(internal.Value, gc.Equals, "fd00::10")
+}
+
+func (s *ipv6Suite) TestMachinePreferredAddresses(c *gc.C) {
+	m := s.addIPv6OnlyMachine(c)
+
+	public, err := m.PublicAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(public.Value, gc.Equals, "2001:db8::10")
+	private, err := m.PrivateAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(private.Value, gc.Equals, "fd00::10")
+}
+
+func (s *ipv6Suite) TestAddressPreference(c *gc.C) {
+	err := s.State.UpdateModelConfig(map[string]interface{}{
+		"address-preference": "ipv6",
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	m, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = m.SetProviderAddresses(append(
+		network.NewAddresses("8.8.8.8", "10.0.0.10"),
+		ipv6Addresses()...,
+	)...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	public, err := m.PublicAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(public.Value, gc.Equals, "2001:db8::10")
+	private, err := m.PrivateAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(private.Value, gc.Equals, "fd00::10")
+}
+
+func (s *ipv6Suite) TestSSHAddresses(c *gc.C) {
+	m := s.addIPv6OnlyMachine(c)
+	client := sshclient.NewFacade(s.APIState)
+
+	public, err := client.PublicAddress(m.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(public, gc.Equals, "2001:db8::10")
+
+	all, err := client.AllAddresses(m.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	hostPorts := network.FilterUnusableHostPorts(network.NewHostPorts(22, all...))
+	c.Assert(network.HostPortsToStrings(hostPorts), jc.SameContents, []string{
+		"[fd00::10]:22",
+		"[2001:db8::10]:22",
+	})
+}
+
+func (s *ipv6Suite) TestControllerAddresses(c *gc.C) {
+	hostPorts := network.AddressesWithPort(ipv6Addresses(), controller.DefaultStatePort)
+
+	c.Assert(network.SelectInternalHostPort(hostPorts, false), gc.Equals, "[fd00::10]:37017")
+	c.Assert(mongo.SelectPeerHostPort(hostPorts), gc.Equals, "[fd00::10]:37017")
+	c.Assert(mongo.SelectPeerHostPortBySpace(hostPorts, "missing"), gc.Equals, "[fd00::10]:37017")
+
+	err := s.State.SetAPIHostPorts([][]network.HostPort{hostPorts})
+	c.Assert(err, jc.ErrorIsNil)
+	stored, err := s.State.APIHostPorts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(network.HostPortsHasIPv4Address(stored[0]), jc.IsFalse)
+	c.Assert(network.SelectPublicHostPort(stored[0]), gc.Equals, "[2001:db8::10]:37017")
+}
+
+func (s *ipv6Suite) TestExposeAllowsIPv6(c *gc.C) {
+	err := s.State.UpdateModelConfig(map[string]interface{}{
+		"address-preference": "ipv6",
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	app := s.Factory.MakeApplication(c, nil)
+	err = app.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// In a model preferring IPv6, a plain expose must not leave
+	// IPv6-only machines unreachable.
+	cidrs, err := app.ExposedIngressCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidrs, jc.SameContents, []string{"0.0.0.0/0", "::/0"})
+}
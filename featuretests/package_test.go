@@ -47,6 +47,7 @@ func init() {
 	gc.Suite(&cmdSubnetSuite{})
 	gc.Suite(&dblogSuite{})
 	gc.Suite(&dumpLogsCommandSuite{})
+	gc.Suite(&ipv6Suite{})
 	gc.Suite(&undertakerSuite{})
 	gc.Suite(&upgradeSuite{})
 	gc.Suite(&CmdRelationSuite{})
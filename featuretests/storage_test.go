@@ -234,6 +234,8 @@ environscoped-block:
   provider: environscoped-block
 loop:
   provider: loop
+lxd-bind:
+  provider: lxd-bind
 machinescoped:
   provider: machinescoped
 rootfs:
@@ -255,6 +257,7 @@ block                loop                 it=works
 environscoped        environscoped        
 environscoped-block  environscoped-block  
 loop                 loop                 
+lxd-bind             lxd-bind             
 machinescoped        machinescoped        
 rootfs               rootfs               
 static               static               
@@ -0,0 +1,464 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"github.com/juju/version"
+	"gopkg.in/juju/charm.v6-unstable"
+	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/core/migration"
+	"github.com/juju/juju/resource"
+)
+
+// A model archive is a gzipped tarball holding everything needed to
+// recreate a model on a controller that cannot reach the model's
+// original controller:
+//
+//	model.yaml                  the serialized model description
+//	charms/<escaped charm URL>  the archive of each charm in use
+//	tools/<version>.tgz         each agent binary in use
+//	resources/<app>/<name>      each application resource revision
+const (
+	archiveModelFile    = "model.yaml"
+	archiveCharmsDir    = "charms"
+	archiveToolsDir     = "tools"
+	archiveResourcesDir = "resources"
+)
+
+// NewSerializedModel returns a SerializedModel for the serialized
+// model description, listing the charms, tools and resources used by
+// the model. The tools URIs are relative to a model's API endpoint.
+func NewSerializedModel(modelBytes []byte) (migration.SerializedModel, error) {
+	model, err := description.Deserialize(modelBytes)
+	if err != nil {
+		return migration.SerializedModel{}, errors.Trace(err)
+	}
+	resources, err := serializedResources(model)
+	if err != nil {
+		return migration.SerializedModel{}, errors.Trace(err)
+	}
+	return migration.SerializedModel{
+		Bytes:      modelBytes,
+		Charms:     UsedCharms(model),
+		Tools:      UsedTools(model),
+		ToolsSizes: UsedToolsSizes(model),
+		Resources:  resources,
+	}, nil
+}
+
+// UsedCharms returns the sorted URLs of the charms used by the
+// applications in the model.
+func UsedCharms(model description.Model) []string {
+	result := set.NewStrings()
+	for _, application := range model.Applications() {
+		result.Add(application.CharmURL())
+	}
+	return result.SortedValues()
+}
+
+// UsedTools returns the versions of the agent binaries used by the
+// model's machines, containers and units, mapped to their URIs
+// relative to a model's API endpoint.
+func UsedTools(model description.Model) map[version.Binary]string {
+	used := make(map[version.Binary]string)
+	for v := range usedAgentTools(model) {
+		used[v] = common.ToolsURL("", v)
+	}
+	return used
+}
+
+// UsedToolsSizes returns the sizes recorded in the model description
+// of the agent binaries used by the model, keyed by version. Versions
+// without a recorded size are omitted.
+func UsedToolsSizes(model description.Model) map[version.Binary]int64 {
+	sizes := make(map[version.Binary]int64)
+	for v, tools := range usedAgentTools(model) {
+		if tools.Size() > 0 {
+			sizes[v] = tools.Size()
+		}
+	}
+	return sizes
+}
+
+// usedAgentTools returns the agent tools of the model's machines,
+// containers and units, keyed by version.
+func usedAgentTools(model description.Model) map[version.Binary]description.AgentTools {
+	used := make(map[version.Binary]description.AgentTools)
+	add := func(tools description.AgentTools) {
+		v := tools.Version()
+		if existing, ok := used[v]; !ok || existing.Size() == 0 {
+			used[v] = tools
+		}
+	}
+	var addMachine func(description.Machine)
+	addMachine = func(machine description.Machine) {
+		add(machine.Tools())
+		for _, container := range machine.Containers() {
+			addMachine(container)
+		}
+	}
+	for _, machine := range model.Machines() {
+		addMachine(machine)
+	}
+	for _, application := range model.Applications() {
+		for _, unit := range application.Units() {
+			add(unit.Tools())
+		}
+	}
+	return used
+}
+
+// UsedResource holds an application resource in a model description
+// along with the revisions of it used by the application's units.
+type UsedResource struct {
+	Application   string
+	Resource      description.Resource
+	UnitRevisions map[string]description.ResourceRevision
+}
+
+// UsedResources returns the resources of the applications in the
+// model, along with the revisions of each used by the units.
+func UsedResources(model description.Model) []UsedResource {
+	var out []UsedResource
+	for _, app := range model.Applications() {
+		for _, res := range app.Resources() {
+			used := UsedResource{
+				Application:   app.Name(),
+				Resource:      res,
+				UnitRevisions: make(map[string]description.ResourceRevision),
+			}
+			for _, unit := range app.Units() {
+				for _, unitRes := range unit.Resources() {
+					if unitRes.Name() == res.Name() {
+						used.UnitRevisions[unit.Name()] = unitRes.Revision()
+					}
+				}
+			}
+			out = append(out, used)
+		}
+	}
+	return out
+}
+
+func serializedResources(model description.Model) ([]migration.SerializedModelResource, error) {
+	var out []migration.SerializedModelResource
+	for _, used := range UsedResources(model) {
+		app, name := used.Application, used.Resource.Name()
+		appRev, err := resourceRevision(app, name, used.Resource.ApplicationRevision())
+		if err != nil {
+			return nil, errors.Annotatef(err, "resource %s/%s", app, name)
+		}
+		csRev, err := resourceRevision(app, name, used.Resource.CharmStoreRevision())
+		if err != nil {
+			return nil, errors.Annotatef(err, "resource %s/%s", app, name)
+		}
+		unitRevs := make(map[string]resource.Resource)
+		for unitName, rev := range used.UnitRevisions {
+			unitRev, err := resourceRevision(app, name, rev)
+			if err != nil {
+				return nil, errors.Annotatef(err, "resource %s/%s", unitName, name)
+			}
+			unitRevs[unitName] = unitRev
+		}
+		out = append(out, migration.SerializedModelResource{
+			ApplicationRevision: appRev,
+			CharmStoreRevision:  csRev,
+			UnitRevisions:       unitRevs,
+		})
+	}
+	return out, nil
+}
+
+func resourceRevision(app, name string, rev description.ResourceRevision) (resource.Resource, error) {
+	if rev == nil {
+		return resource.Resource{
+			Resource:      charmresource.Resource{Meta: charmresource.Meta{Name: name}},
+			ApplicationID: app,
+		}, nil
+	}
+	type_, err := charmresource.ParseType(rev.Type())
+	if err != nil {
+		return resource.Resource{}, errors.Trace(err)
+	}
+	origin, err := charmresource.ParseOrigin(rev.Origin())
+	if err != nil {
+		return resource.Resource{}, errors.Trace(err)
+	}
+	fp, err := charmresource.ParseFingerprint(rev.FingerprintHex())
+	if err != nil {
+		return resource.Resource{}, errors.Annotate(err, "invalid fingerprint")
+	}
+	return resource.Resource{
+		Resource: charmresource.Resource{
+			Meta: charmresource.Meta{
+				Name:        name,
+				Type:        type_,
+				Path:        rev.Path(),
+				Description: rev.Description(),
+			},
+			Origin:      origin,
+			Revision:    rev.Revision(),
+			Size:        rev.Size(),
+			Fingerprint: fp,
+		},
+		ApplicationID: app,
+		Username:      rev.Username(),
+		Timestamp:     rev.Timestamp(),
+	}, nil
+}
+
+// WriteModelArchiveConfig holds what WriteModelArchive needs to
+// gather a model and its binaries.
+type WriteModelArchiveConfig struct {
+	// Model is the serialized description of the model.
+	Model []byte
+
+	CharmDownloader    CharmDownloader
+	ToolsDownloader    ToolsDownloader
+	ResourceDownloader ResourceDownloader
+}
+
+// Validate makes sure that all the config values are set.
+func (c *WriteModelArchiveConfig) Validate() error {
+	if len(c.Model) == 0 {
+		return errors.NotValidf("empty Model")
+	}
+	if c.CharmDownloader == nil {
+		return errors.NotValidf("missing CharmDownloader")
+	}
+	if c.ToolsDownloader == nil {
+		return errors.NotValidf("missing ToolsDownloader")
+	}
+	if c.ResourceDownloader == nil {
+		return errors.NotValidf("missing ResourceDownloader")
+	}
+	return nil
+}
+
+// WriteModelArchive writes a self-contained archive of the model to w:
+// the serialized model along with the charms, tools and resources it
+// uses, downloaded the same way UploadBinaries downloads them. The
+// archive can be read back with ReadModelArchive.
+func WriteModelArchive(w io.Writer, config WriteModelArchiveConfig) error {
+	if err := config.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	serialized, err := NewSerializedModel(config.Model)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	gzw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gzw)
+	if err := writeArchiveFile(tw, archiveModelFile, ioutil.NopCloser(bytes.NewReader(config.Model))); err != nil {
+		return errors.Trace(err)
+	}
+	for _, charmURL := range serialized.Charms {
+		logger.Debugf("archiving charm %s", charmURL)
+		curl, err := charm.ParseURL(charmURL)
+		if err != nil {
+			return errors.Annotate(err, "bad charm URL")
+		}
+		reader, err := config.CharmDownloader.OpenCharm(curl)
+		if err != nil {
+			return errors.Annotate(err, "cannot open charm")
+		}
+		if err := writeArchiveFile(tw, charmArchivePath(charmURL), reader); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	for v, uri := range serialized.Tools {
+		logger.Debugf("archiving tools %s", v)
+		reader, err := config.ToolsDownloader.OpenURI(uri, nil)
+		if err != nil {
+			return errors.Annotate(err, "cannot open tools")
+		}
+		if err := writeArchiveFile(tw, toolsArchivePath(v), reader); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	for _, res := range serialized.Resources {
+		rev := res.ApplicationRevision
+		if rev.IsPlaceholder() {
+			continue
+		}
+		logger.Debugf("archiving resource %s/%s", rev.ApplicationID, rev.Name)
+		reader, err := config.ResourceDownloader.OpenResource(rev.ApplicationID, rev.Name)
+		if err != nil {
+			return errors.Annotate(err, "cannot open resource")
+		}
+		if err := writeArchiveFile(tw, resourceArchivePath(rev.ApplicationID, rev.Name), reader); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(gzw.Close())
+}
+
+// writeArchiveFile adds the content of reader to the archive under
+// name, and closes reader. The content is streamed through a temporary
+// file because tar headers need the size up front.
+func writeArchiveFile(tw *tar.Writer, name string, reader io.ReadCloser) error {
+	defer reader.Close()
+	content, size, cleanup, err := streamThroughTempFile(reader)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer cleanup()
+	if err := tw.WriteHeader(&tar.Header{
+		Name: name,
+		Mode: 0644,
+		Size: size,
+	}); err != nil {
+		return errors.Annotatef(err, "cannot write %s", name)
+	}
+	if _, err := io.Copy(tw, content); err != nil {
+		return errors.Annotatef(err, "cannot write %s", name)
+	}
+	return nil
+}
+
+func charmArchivePath(charmURL string) string {
+	return path.Join(archiveCharmsDir, url.QueryEscape(charmURL))
+}
+
+func toolsArchivePath(v version.Binary) string {
+	return path.Join(archiveToolsDir, v.String()+".tgz")
+}
+
+func resourceArchivePath(app, name string) string {
+	return path.Join(archiveResourcesDir, url.QueryEscape(app), url.QueryEscape(name))
+}
+
+// ModelArchive is a model archive, as written by WriteModelArchive,
+// unpacked for import. It serves the archived charms, tools and
+// resources through the CharmDownloader, ToolsDownloader and
+// ResourceDownloader interfaces, so it can be used as the source of
+// UploadBinaries.
+type ModelArchive struct {
+	// Model describes the serialized model and the binaries it uses.
+	Model migration.SerializedModel
+
+	dir string
+}
+
+// ReadModelArchive unpacks the model archive read from r into a
+// temporary directory. Close must be called to remove it.
+func ReadModelArchive(r io.Reader) (_ *ModelArchive, err error) {
+	dir, err := ioutil.TempDir("", "juju-model-archive")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer func() {
+		if err != nil {
+			os.RemoveAll(dir)
+		}
+	}()
+
+	gzr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot read model archive")
+	}
+	tr := tar.NewReader(gzr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, errors.Annotate(err, "cannot read model archive")
+		}
+		if hdr.Typeflag != tar.TypeReg && hdr.Typeflag != tar.TypeRegA {
+			continue
+		}
+		name := path.Clean(hdr.Name)
+		if path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+			return nil, errors.NotValidf("model archive entry %q", hdr.Name)
+		}
+		target := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err := writeFile(target, tr); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+
+	modelBytes, err := ioutil.ReadFile(filepath.Join(dir, archiveModelFile))
+	if os.IsNotExist(err) {
+		return nil, errors.NotValidf("model archive without %s", archiveModelFile)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	serialized, err := NewSerializedModel(modelBytes)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot read model description")
+	}
+	return &ModelArchive{
+		Model: serialized,
+		dir:   dir,
+	}, nil
+}
+
+func writeFile(target string, r io.Reader) error {
+	f, err := os.OpenFile(target, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer f.Close()
+	_, err = io.Copy(f, r)
+	return errors.Trace(err)
+}
+
+// Close removes the unpacked archive.
+func (a *ModelArchive) Close() error {
+	return errors.Trace(os.RemoveAll(a.dir))
+}
+
+// OpenCharm is part of the CharmDownloader interface.
+func (a *ModelArchive) OpenCharm(curl *charm.URL) (io.ReadCloser, error) {
+	return a.open(charmArchivePath(curl.String()), "charm %s", curl)
+}
+
+// OpenURI is part of the ToolsDownloader interface. It accepts the
+// tools URIs listed in a.Model.Tools.
+func (a *ModelArchive) OpenURI(uri string, _ url.Values) (io.ReadCloser, error) {
+	for v, toolsURI := range a.Model.Tools {
+		if toolsURI == uri {
+			return a.open(toolsArchivePath(v), "tools %s", v)
+		}
+	}
+	return nil, errors.NotFoundf("tools at %q", uri)
+}
+
+// OpenResource is part of the ResourceDownloader interface.
+func (a *ModelArchive) OpenResource(application, name string) (io.ReadCloser, error) {
+	return a.open(resourceArchivePath(application, name), "resource %s/%s", application, name)
+}
+
+func (a *ModelArchive) open(name string, format string, args ...interface{}) (io.ReadCloser, error) {
+	f, err := os.Open(filepath.Join(a.dir, filepath.FromSlash(name)))
+	if os.IsNotExist(err) {
+		return nil, errors.NotFoundf("%s in model archive", fmt.Sprintf(format, args...))
+	}
+	return f, errors.Trace(err)
+}
@@ -0,0 +1,284 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/migration"
+	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/testing/factory"
+)
+
+type ArchiveSuite struct {
+	statetesting.StateSuite
+	charmURL string
+	tools    []version.Binary
+}
+
+var _ = gc.Suite(&ArchiveSuite{})
+
+func (s *ArchiveSuite) SetUpTest(c *gc.C) {
+	s.StateSuite.SetUpTest(c)
+	machine := s.Factory.MakeMachine(c, nil)
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Machine: machine})
+	app, err := unit.Application()
+	c.Assert(err, jc.ErrorIsNil)
+	curl, _ := app.CharmURL()
+	s.charmURL = curl.String()
+
+	machineTools, err := machine.AgentTools()
+	c.Assert(err, jc.ErrorIsNil)
+	unitTools, err := unit.AgentTools()
+	c.Assert(err, jc.ErrorIsNil)
+	s.tools = []version.Binary{machineTools.Version, unitTools.Version}
+}
+
+// serializedModel exports the model, adding an uploaded resource
+// "blob" and a placeholder resource "placeholder" to its application.
+func (s *ArchiveSuite) serializedModel(c *gc.C) []byte {
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	fp, err := charmresource.GenerateFingerprint(strings.NewReader("blob"))
+	c.Assert(err, jc.ErrorIsNil)
+	app := model.Applications()[0]
+	res := app.AddResource(description.ResourceArgs{"blob"})
+	res.SetApplicationRevision(description.ResourceRevisionArgs{
+		Revision:       1,
+		Type:           "file",
+		Path:           "blob.tgz",
+		Origin:         "upload",
+		FingerprintHex: fp.String(),
+		Size:           4,
+		Timestamp:      time.Now(),
+		Username:       "bob",
+	})
+	unit := app.Units()[0]
+	unit.AddResource(description.UnitResourceArgs{
+		Name: "blob",
+		RevisionArgs: description.ResourceRevisionArgs{
+			Revision:       1,
+			Type:           "file",
+			Path:           "blob.tgz",
+			Origin:         "upload",
+			FingerprintHex: fp.String(),
+			Size:           4,
+			Timestamp:      time.Now(),
+			Username:       "bob",
+		},
+	})
+	placeholder := app.AddResource(description.ResourceArgs{"placeholder"})
+	placeholder.SetApplicationRevision(description.ResourceRevisionArgs{
+		Type:           "file",
+		Path:           "placeholder.tgz",
+		Origin:         "upload",
+		FingerprintHex: fp.String(),
+	})
+
+	bytes, err := description.Serialize(model)
+	c.Assert(err, jc.ErrorIsNil)
+	return bytes
+}
+
+func (s *ArchiveSuite) TestNewSerializedModel(c *gc.C) {
+	bytes := s.serializedModel(c)
+
+	serialized, err := migration.NewSerializedModel(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(serialized.Bytes, gc.DeepEquals, bytes)
+	c.Check(serialized.Charms, gc.DeepEquals, []string{s.charmURL})
+	for _, v := range s.tools {
+		c.Check(serialized.Tools[v], gc.Equals, "/tools/"+v.String())
+	}
+	c.Assert(serialized.Resources, gc.HasLen, 2)
+	blob := serialized.Resources[0]
+	c.Check(blob.ApplicationRevision.Name, gc.Equals, "blob")
+	c.Check(blob.ApplicationRevision.IsPlaceholder(), jc.IsFalse)
+	c.Check(blob.UnitRevisions, gc.HasLen, 1)
+	c.Check(serialized.Resources[1].ApplicationRevision.IsPlaceholder(), jc.IsTrue)
+}
+
+func (s *ArchiveSuite) TestUsedToolsSizes(c *gc.C) {
+	v1 := version.MustParseBinary("2.1.0-trusty-amd64")
+	v2 := version.MustParseBinary("2.1.0-xenial-amd64")
+	v3 := version.MustParseBinary("2.2.0-xenial-amd64")
+	model := description.NewModel(description.ModelArgs{
+		Owner: names.NewUserTag("admin"),
+	})
+	machine := model.AddMachine(description.MachineArgs{Id: names.NewMachineTag("0")})
+	machine.SetTools(description.AgentToolsArgs{Version: v1, Size: 100})
+	container := machine.AddContainer(description.MachineArgs{Id: names.NewMachineTag("0/lxd/0")})
+	container.SetTools(description.AgentToolsArgs{Version: v2, Size: 200})
+	app := model.AddApplication(description.ApplicationArgs{
+		Tag:      names.NewApplicationTag("foo"),
+		CharmURL: "cs:foo-0",
+	})
+	unit := app.AddUnit(description.UnitArgs{Tag: names.NewUnitTag("foo/0")})
+	unit.SetTools(description.AgentToolsArgs{Version: v3})
+
+	c.Check(migration.UsedToolsSizes(model), jc.DeepEquals, map[version.Binary]int64{
+		v1: 100,
+		v2: 200,
+	})
+	c.Check(migration.UsedTools(model), gc.HasLen, 3)
+}
+
+func (s *ArchiveSuite) TestUsedResources(c *gc.C) {
+	model, err := description.Deserialize(s.serializedModel(c))
+	c.Assert(err, jc.ErrorIsNil)
+
+	used := migration.UsedResources(model)
+	c.Assert(used, gc.HasLen, 2)
+	app := model.Applications()[0]
+	unitName := app.Units()[0].Name()
+	c.Check(used[0].Application, gc.Equals, app.Name())
+	c.Check(used[0].Resource.Name(), gc.Equals, "blob")
+	c.Assert(used[0].UnitRevisions, gc.HasLen, 1)
+	c.Check(used[0].UnitRevisions[unitName].Revision(), gc.Equals, 1)
+	c.Check(used[1].Resource.Name(), gc.Equals, "placeholder")
+	c.Check(used[1].UnitRevisions, gc.HasLen, 0)
+}
+
+func (s *ArchiveSuite) TestWriteModelArchiveConfigValidate(c *gc.C) {
+	downloader := &fakeDownloader{}
+	validConfig := migration.WriteModelArchiveConfig{
+		Model:              []byte("model"),
+		CharmDownloader:    downloader,
+		ToolsDownloader:    downloader,
+		ResourceDownloader: downloader,
+	}
+
+	check := func(modify func(*migration.WriteModelArchiveConfig), missing string) {
+		config := validConfig
+		modify(&config)
+		err := migration.WriteModelArchive(ioutil.Discard, config)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, ".*"+missing+".*")
+	}
+
+	check(func(c *migration.WriteModelArchiveConfig) { c.Model = nil }, "Model")
+	check(func(c *migration.WriteModelArchiveConfig) { c.CharmDownloader = nil }, "CharmDownloader")
+	check(func(c *migration.WriteModelArchiveConfig) { c.ToolsDownloader = nil }, "ToolsDownloader")
+	check(func(c *migration.WriteModelArchiveConfig) { c.ResourceDownloader = nil }, "ResourceDownloader")
+}
+
+func (s *ArchiveSuite) TestRoundTrip(c *gc.C) {
+	model := s.serializedModel(c)
+	downloader := &fakeDownloader{}
+	var buf bytes.Buffer
+	err := migration.WriteModelArchive(&buf, migration.WriteModelArchiveConfig{
+		Model:              model,
+		CharmDownloader:    downloader,
+		ToolsDownloader:    downloader,
+		ResourceDownloader: downloader,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(downloader.charms, gc.DeepEquals, []string{s.charmURL})
+	// The placeholder resource has no content to archive.
+	c.Check(downloader.resources, gc.HasLen, 1)
+
+	archive, err := migration.ReadModelArchive(&buf)
+	c.Assert(err, jc.ErrorIsNil)
+	defer archive.Close()
+	c.Assert(archive.Model.Bytes, gc.DeepEquals, model)
+
+	uploader := &fakeUploader{
+		tools:     make(map[version.Binary]string),
+		resources: make(map[string]string),
+	}
+	err = migration.UploadBinaries(migration.UploadBinariesConfig{
+		Charms:             archive.Model.Charms,
+		CharmDownloader:    archive,
+		CharmUploader:      uploader,
+		Tools:              archive.Model.Tools,
+		ToolsDownloader:    archive,
+		ToolsUploader:      uploader,
+		Resources:          archive.Model.Resources,
+		ResourceDownloader: archive,
+		ResourceUploader:   uploader,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(uploader.charms, gc.DeepEquals, []string{s.charmURL})
+	for _, v := range s.tools {
+		c.Check(uploader.tools[v], gc.Equals, "/tools/"+v.String())
+	}
+	app := archive.Model.Resources[0].ApplicationRevision.ApplicationID
+	c.Check(uploader.resources, jc.DeepEquals, map[string]string{
+		app + "/blob":        "blob",
+		app + "/placeholder": "<placeholder>",
+	})
+	c.Check(uploader.unitResources, gc.HasLen, 1)
+}
+
+func (s *ArchiveSuite) TestOpenMissing(c *gc.C) {
+	var buf bytes.Buffer
+	writeTestArchive(c, &buf, map[string]string{
+		"model.yaml": string(s.serializedModel(c)),
+	})
+	archive, err := migration.ReadModelArchive(&buf)
+	c.Assert(err, jc.ErrorIsNil)
+	defer archive.Close()
+
+	_, err = archive.OpenResource("foo", "bar")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+	c.Check(err, gc.ErrorMatches, "resource foo/bar in model archive not found")
+	_, err = archive.OpenURI("/tools/nope", nil)
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ArchiveSuite) TestReadModelArchiveMissingModel(c *gc.C) {
+	var buf bytes.Buffer
+	writeTestArchive(c, &buf, map[string]string{
+		"charms/foo": "foo",
+	})
+	_, err := migration.ReadModelArchive(&buf)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, "model archive without model.yaml not valid")
+}
+
+func (s *ArchiveSuite) TestReadModelArchiveRejectsEscapingPaths(c *gc.C) {
+	var buf bytes.Buffer
+	writeTestArchive(c, &buf, map[string]string{
+		"../evil": "evil",
+	})
+	_, err := migration.ReadModelArchive(&buf)
+	c.Assert(err, gc.ErrorMatches, `model archive entry "../evil" not valid`)
+}
+
+func (s *ArchiveSuite) TestReadModelArchiveNotGzipped(c *gc.C) {
+	_, err := migration.ReadModelArchive(strings.NewReader("not an archive"))
+	c.Assert(err, gc.ErrorMatches, "cannot read model archive: .*")
+}
+
+func writeTestArchive(c *gc.C, buf *bytes.Buffer, files map[string]string) {
+	gzw := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gzw)
+	for name, content := range files {
+		err := tw.WriteHeader(&tar.Header{
+			Name: name,
+			Mode: 0644,
+			Size: int64(len(content)),
+		})
+		c.Assert(err, jc.ErrorIsNil, gc.Commentf("writing %s", name))
+		_, err = tw.Write([]byte(content))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	c.Assert(tw.Close(), jc.ErrorIsNil)
+	c.Assert(gzw.Close(), jc.ErrorIsNil)
+}
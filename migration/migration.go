@@ -47,6 +47,39 @@ func ExportModel(st StateExporter) ([]byte, error) {
 	return bytes, nil
 }
 
+// RemapCloud rewrites the serialized model description so that the
+// model is hosted on the cloud, region and credential given by the
+// remap. The credential is recorded by reference only; it must already
+// exist on the controller the model is imported into.
+func RemapCloud(bytes []byte, remap migration.CloudRemap) ([]byte, error) {
+	if remap.IsEmpty() {
+		return bytes, nil
+	}
+	if err := remap.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	model, err := description.Deserialize(bytes)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if remap.Cloud != "" {
+		model.SetCloud(remap.Cloud, remap.CloudRegion)
+	}
+	if remap.Credential.Id() != "" {
+		model.SetCloudCredential(description.CloudCredentialArgs{
+			Owner:     remap.Credential.Owner(),
+			Cloud:     remap.Credential.Cloud(),
+			Name:      remap.Credential.Name(),
+			Reference: true,
+		})
+	}
+	bytes, err = description.Serialize(model)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return bytes, nil
+}
+
 // ImportModel deserializes a model description from the bytes, transforms
 // the model config based on information from the controller model, and then
 // imports that as a new database model.
@@ -113,9 +146,20 @@ type UploadBinariesConfig struct {
 	ToolsDownloader ToolsDownloader
 	ToolsUploader   ToolsUploader
 
+	// ToolsSizes, if not nil, holds the known sizes of the tools in
+	// Tools so they can be counted in the total before the tools are
+	// fetched.
+	ToolsSizes map[version.Binary]int64
+
 	Resources          []migration.SerializedModelResource
 	ResourceDownloader ResourceDownloader
 	ResourceUploader   ResourceUploader
+
+	// Progress, if not nil, is called as binaries are sent to the
+	// target controller with the number of bytes sent so far and
+	// the total number of bytes known to need sending. The total
+	// grows as charms, and tools of unknown size, are fetched.
+	Progress func(uploaded, total int64)
 }
 
 // Validate makes sure that all the config values are non-nil.
@@ -143,47 +187,154 @@ func (c *UploadBinariesConfig) Validate() error {
 
 // UploadBinaries will send binaries stored in the source blobstore to
 // the target controller.
+//
+// Each binary is sent as soon as it has been fetched, so no more than
+// one is held on disk at a time.
 func UploadBinaries(config UploadBinariesConfig) error {
 	if err := config.Validate(); err != nil {
 		return errors.Trace(err)
 	}
-	if err := uploadCharms(config); err != nil {
+	tracker := newUploadTracker(config)
+	tracker.reportProgress()
+	if err := uploadCharms(config, tracker); err != nil {
 		return errors.Trace(err)
 	}
-	if err := uploadTools(config); err != nil {
+	if err := uploadTools(config, tracker); err != nil {
 		return errors.Trace(err)
 	}
-	if err := uploadResources(config); err != nil {
+	if err := uploadResources(config, tracker); err != nil {
 		return errors.Trace(err)
 	}
 	return nil
 }
 
-func streamThroughTempFile(r io.Reader) (_ io.ReadSeeker, cleanup func(), err error) {
+// uploadTracker sends binaries to the target controller one at a time,
+// counting the bytes read from each as it is uploaded and passing the
+// count to report.
+//
+// The size of a charm binary isn't known until it has been fetched, so
+// the total starts as the size of the model's resources and of the tools
+// whose sizes are known, and grows as charms and other tools are
+// fetched.
+type uploadTracker struct {
+	uploaded int64
+	total    int64
+	current  int64
+	report   func(uploaded, total int64)
+}
+
+func newUploadTracker(config UploadBinariesConfig) *uploadTracker {
+	t := &uploadTracker{report: config.Progress}
+	for v := range config.Tools {
+		t.total += config.ToolsSizes[v]
+	}
+	for _, res := range config.Resources {
+		if !res.ApplicationRevision.IsPlaceholder() {
+			t.total += res.ApplicationRevision.Size
+		}
+	}
+	return t
+}
+
+// send fetches a binary from r and uploads it using upload. The binary
+// is copied to a temporary file first because uploaders need to seek
+// within it. expectedSize is the part of the binary's size which is
+// already included in the total.
+func (t *uploadTracker) send(r io.ReadCloser, expectedSize int64, upload func(io.ReadSeeker) error) error {
+	content, size, cleanup, err := streamThroughTempFile(r)
+	r.Close()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer cleanup()
+
+	t.total += size - expectedSize
+	t.current = 0
+	t.reportProgress()
+	err = upload(&trackingReader{
+		ReadSeeker: content,
+		tracker:    t,
+		size:       size,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	t.uploaded += size
+	t.current = 0
+	t.reportProgress()
+	return nil
+}
+
+func (t *uploadTracker) reportProgress() {
+	if t.report != nil {
+		t.report(t.uploaded+t.current, t.total)
+	}
+}
+
+// trackingReader reports the position reached in a binary to its
+// uploadTracker. The position is used rather than a count of bytes
+// read so that uploaders may seek without inflating progress.
+type trackingReader struct {
+	io.ReadSeeker
+	tracker *uploadTracker
+	size    int64
+	pos     int64
+}
+
+func (r *trackingReader) Read(p []byte) (int, error) {
+	n, err := r.ReadSeeker.Read(p)
+	r.pos += int64(n)
+	r.update()
+	return n, err
+}
+
+func (r *trackingReader) Seek(offset int64, whence int) (int64, error) {
+	pos, err := r.ReadSeeker.Seek(offset, whence)
+	if err == nil {
+		r.pos = pos
+	}
+	return pos, err
+}
+
+func (r *trackingReader) update() {
+	pos := r.pos
+	if pos > r.size {
+		pos = r.size
+	}
+	if pos > r.tracker.current {
+		r.tracker.current = pos
+		r.tracker.reportProgress()
+	}
+}
+
+func streamThroughTempFile(r io.Reader) (_ io.ReadSeeker, size int64, cleanup func(), err error) {
 	tempFile, err := ioutil.TempFile("", "juju-migrate-binary")
 	if err != nil {
-		return nil, nil, errors.Trace(err)
+		return nil, 0, nil, errors.Trace(err)
 	}
 	defer func() {
 		if err != nil {
+			tempFile.Close()
 			os.Remove(tempFile.Name())
 		}
 	}()
-	_, err = io.Copy(tempFile, r)
+	size, err = io.Copy(tempFile, r)
 	if err != nil {
-		return nil, nil, errors.Trace(err)
+		return nil, 0, nil, errors.Trace(err)
+	}
+	if _, err = tempFile.Seek(0, 0); err != nil {
+		return nil, 0, nil, errors.Trace(err)
 	}
-	tempFile.Seek(0, 0)
 	rmTempFile := func() {
 		filename := tempFile.Name()
 		tempFile.Close()
 		os.Remove(filename)
 	}
 
-	return tempFile, rmTempFile, nil
+	return tempFile, size, rmTempFile, nil
 }
 
-func uploadCharms(config UploadBinariesConfig) error {
+func uploadCharms(config UploadBinariesConfig, tracker *uploadTracker) error {
 	// It is critical that charms are uploaded in ascending charm URL
 	// order so that charm revisions end up the same in the target as
 	// they were in the source.
@@ -201,25 +352,23 @@ func uploadCharms(config UploadBinariesConfig) error {
 		if err != nil {
 			return errors.Annotate(err, "cannot open charm")
 		}
-		defer reader.Close()
-
-		content, cleanup, err := streamThroughTempFile(reader)
+		err = tracker.send(reader, 0, func(content io.ReadSeeker) error {
+			if usedCurl, err := config.CharmUploader.UploadCharm(curl, content); err != nil {
+				return errors.Annotate(err, "cannot upload charm")
+			} else if usedCurl.String() != curl.String() {
+				// The target controller shouldn't assign a different charm URL.
+				return errors.Errorf("charm %s unexpectedly assigned %s", curl, usedCurl)
+			}
+			return nil
+		})
 		if err != nil {
 			return errors.Trace(err)
 		}
-		defer cleanup()
-
-		if usedCurl, err := config.CharmUploader.UploadCharm(curl, content); err != nil {
-			return errors.Annotate(err, "cannot upload charm")
-		} else if usedCurl.String() != curl.String() {
-			// The target controller shouldn't assign a different charm URL.
-			return errors.Errorf("charm %s unexpectedly assigned %s", curl, usedCurl)
-		}
 	}
 	return nil
 }
 
-func uploadTools(config UploadBinariesConfig) error {
+func uploadTools(config UploadBinariesConfig, tracker *uploadTracker) error {
 	for v, uri := range config.Tools {
 		logger.Debugf("sending tools to target: %s", v)
 
@@ -227,30 +376,30 @@ func uploadTools(config UploadBinariesConfig) error {
 		if err != nil {
 			return errors.Annotate(err, "cannot open charm")
 		}
-		defer reader.Close()
-
-		content, cleanup, err := streamThroughTempFile(reader)
+		v := v
+		err = tracker.send(reader, config.ToolsSizes[v], func(content io.ReadSeeker) error {
+			if _, err := config.ToolsUploader.UploadTools(content, v); err != nil {
+				return errors.Annotate(err, "cannot upload tools")
+			}
+			return nil
+		})
 		if err != nil {
 			return errors.Trace(err)
 		}
-		defer cleanup()
-
-		if _, err := config.ToolsUploader.UploadTools(content, v); err != nil {
-			return errors.Annotate(err, "cannot upload tools")
-		}
 	}
 	return nil
 }
 
-func uploadResources(config UploadBinariesConfig) error {
+func uploadResources(config UploadBinariesConfig, tracker *uploadTracker) error {
 	for _, res := range config.Resources {
-		if res.ApplicationRevision.IsPlaceholder() {
-			err := config.ResourceUploader.SetPlaceholderResource(res.ApplicationRevision)
+		appRev := res.ApplicationRevision
+		if appRev.IsPlaceholder() {
+			err := config.ResourceUploader.SetPlaceholderResource(appRev)
 			if err != nil {
 				return errors.Annotate(err, "cannot set placeholder resource")
 			}
 		} else {
-			err := uploadAppResource(config, res.ApplicationRevision)
+			err := uploadAppResource(config, tracker, appRev)
 			if err != nil {
 				return errors.Trace(err)
 			}
@@ -267,25 +416,20 @@ func uploadResources(config UploadBinariesConfig) error {
 	return nil
 }
 
-func uploadAppResource(config UploadBinariesConfig, rev resource.Resource) error {
+func uploadAppResource(config UploadBinariesConfig, tracker *uploadTracker, rev resource.Resource) error {
 	logger.Debugf("opening application resource for %s: %s", rev.ApplicationID, rev.Name)
 	reader, err := config.ResourceDownloader.OpenResource(rev.ApplicationID, rev.Name)
 	if err != nil {
 		return errors.Annotate(err, "cannot open resource")
 	}
-	defer reader.Close()
 
 	// TODO(menn0) - validate that the downloaded revision matches
 	// the expected metadata. Check revision and fingerprint.
 
-	content, cleanup, err := streamThroughTempFile(reader)
-	if err != nil {
-		return errors.Trace(err)
-	}
-	defer cleanup()
-
-	if err := config.ResourceUploader.UploadResource(rev, content); err != nil {
-		return errors.Annotate(err, "cannot upload resource")
-	}
-	return nil
+	return tracker.send(reader, rev.Size, func(content io.ReadSeeker) error {
+		if err := config.ResourceUploader.UploadResource(rev, content); err != nil {
+			return errors.Annotate(err, "cannot upload resource")
+		}
+		return nil
+	})
 }
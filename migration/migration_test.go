@@ -16,6 +16,7 @@ import (
 	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/component/all"
 	"github.com/juju/juju/core/description"
@@ -185,6 +186,122 @@ func (s *ImportSuite) TestBinariesMigration(c *gc.C) {
 	c.Assert(uploader.unitResources, jc.SameContents, []string{"app1/99-blob1"})
 }
 
+func (s *ImportSuite) TestBinariesMigrationProgress(c *gc.C) {
+	downloader := &fakeDownloader{}
+	uploader := &fakeUploader{
+		tools:     make(map[version.Binary]string),
+		resources: make(map[string]string),
+	}
+	res := resourcetesting.NewResource(c, nil, "blob0", "app0", "blob0").Resource
+	type report struct {
+		uploaded, total int64
+	}
+	var reports []report
+	config := migration.UploadBinariesConfig{
+		Charms:          []string{"cs:trusty/postgresql-42"},
+		CharmDownloader: downloader,
+		CharmUploader:   uploader,
+		Tools: map[version.Binary]string{
+			version.MustParseBinary("2.1.0-trusty-amd64"): "/tools/0",
+		},
+		ToolsDownloader:    downloader,
+		ToolsUploader:      uploader,
+		Resources:          []coremigration.SerializedModelResource{{ApplicationRevision: res}},
+		ResourceDownloader: downloader,
+		ResourceUploader:   uploader,
+		Progress: func(uploaded, total int64) {
+			reports = append(reports, report{uploaded, total})
+		},
+	}
+	err := migration.UploadBinaries(config)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Only the resource's size is known before anything is fetched;
+	// the charm and tools sizes are added as they're fetched.
+	charmSize := int64(len("cs:trusty/postgresql-42 content"))
+	toolsSize := int64(len("/tools/0"))
+	total := charmSize + toolsSize + res.Size
+	c.Assert(len(reports) > 2, jc.IsTrue)
+	c.Check(reports[0], gc.Equals, report{0, res.Size})
+	c.Check(reports[len(reports)-1], gc.Equals, report{total, total})
+	for i, r := range reports {
+		c.Check(r.uploaded <= r.total, jc.IsTrue)
+		if i > 0 {
+			c.Check(r.uploaded >= reports[i-1].uploaded, jc.IsTrue)
+			c.Check(r.total >= reports[i-1].total, jc.IsTrue)
+		}
+	}
+}
+
+func (s *ImportSuite) TestBinariesMigrationProgressKnownToolsSize(c *gc.C) {
+	downloader := &fakeDownloader{}
+	uploader := &fakeUploader{
+		tools:     make(map[version.Binary]string),
+		resources: make(map[string]string),
+	}
+	v := version.MustParseBinary("2.1.0-trusty-amd64")
+	toolsSize := int64(len("/tools/0"))
+	var totals []int64
+	config := migration.UploadBinariesConfig{
+		CharmDownloader:    downloader,
+		CharmUploader:      uploader,
+		Tools:              map[version.Binary]string{v: "/tools/0"},
+		ToolsSizes:         map[version.Binary]int64{v: toolsSize},
+		ToolsDownloader:    downloader,
+		ToolsUploader:      uploader,
+		ResourceDownloader: downloader,
+		ResourceUploader:   uploader,
+		Progress: func(uploaded, total int64) {
+			totals = append(totals, total)
+		},
+	}
+	err := migration.UploadBinaries(config)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The tools size is counted before the tools are fetched and
+	// isn't counted again once they have been.
+	c.Assert(totals, gc.Not(gc.HasLen), 0)
+	for _, total := range totals {
+		c.Check(total, gc.Equals, toolsSize)
+	}
+}
+
+func (s *ImportSuite) TestBinariesSentAsFetched(c *gc.C) {
+	var events []string
+	downloader := &orderingDownloader{fakeDownloader: &fakeDownloader{}, events: &events}
+	uploader := &orderingUploader{
+		fakeUploader: &fakeUploader{
+			tools:     make(map[version.Binary]string),
+			resources: make(map[string]string),
+		},
+		events: &events,
+	}
+	config := migration.UploadBinariesConfig{
+		Charms:          []string{"local:trusty/magic-2", "local:trusty/magic-10"},
+		CharmDownloader: downloader,
+		CharmUploader:   uploader,
+		Tools: map[version.Binary]string{
+			version.MustParseBinary("2.1.0-trusty-amd64"): "/tools/0",
+		},
+		ToolsDownloader:    downloader,
+		ToolsUploader:      uploader,
+		ResourceDownloader: downloader,
+		ResourceUploader:   uploader,
+	}
+	err := migration.UploadBinaries(config)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Each binary is uploaded before the next is fetched.
+	c.Assert(events, jc.DeepEquals, []string{
+		"open local:trusty/magic-2",
+		"upload local:trusty/magic-2",
+		"open local:trusty/magic-10",
+		"upload local:trusty/magic-10",
+		"open /tools/0",
+		"upload 2.1.0-trusty-amd64",
+	})
+}
+
 func (s *ImportSuite) TestWrongCharmURLAssigned(c *gc.C) {
 	downloader := &fakeDownloader{}
 	uploader := &fakeUploader{
@@ -233,6 +350,38 @@ func (d *fakeDownloader) OpenResource(app, name string) (io.ReadCloser, error) {
 	return ioutil.NopCloser(bytes.NewReader([]byte(name))), nil
 }
 
+// orderingDownloader records when each binary is fetched.
+type orderingDownloader struct {
+	*fakeDownloader
+	events *[]string
+}
+
+func (d *orderingDownloader) OpenCharm(curl *charm.URL) (io.ReadCloser, error) {
+	*d.events = append(*d.events, "open "+curl.String())
+	return d.fakeDownloader.OpenCharm(curl)
+}
+
+func (d *orderingDownloader) OpenURI(uri string, query url.Values) (io.ReadCloser, error) {
+	*d.events = append(*d.events, "open "+uri)
+	return d.fakeDownloader.OpenURI(uri, query)
+}
+
+// orderingUploader records when each binary is uploaded.
+type orderingUploader struct {
+	*fakeUploader
+	events *[]string
+}
+
+func (f *orderingUploader) UploadCharm(u *charm.URL, r io.ReadSeeker) (*charm.URL, error) {
+	*f.events = append(*f.events, "upload "+u.String())
+	return f.fakeUploader.UploadCharm(u, r)
+}
+
+func (f *orderingUploader) UploadTools(r io.ReadSeeker, v version.Binary, series ...string) (tools.List, error) {
+	*f.events = append(*f.events, "upload "+v.String())
+	return f.fakeUploader.UploadTools(r, v, series...)
+}
+
 type fakeUploader struct {
 	tools            map[version.Binary]string
 	charms           []string
@@ -286,6 +435,83 @@ func (f *fakeUploader) SetUnitResource(unit string, res resource.Resource) error
 	return nil
 }
 
+type RemapCloudSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&RemapCloudSuite{})
+
+func (s *RemapCloudSuite) serializedModel(c *gc.C) []byte {
+	model := description.NewModel(description.ModelArgs{
+		Owner:       names.NewUserTag("bob"),
+		Cloud:       "stratus",
+		CloudRegion: "east",
+		Config:      map[string]interface{}{"uuid": utils.MustNewUUID().String()},
+	})
+	model.SetCloudCredential(description.CloudCredentialArgs{
+		Owner:    names.NewUserTag("bob"),
+		Cloud:    names.NewCloudTag("stratus"),
+		Name:     "default",
+		AuthType: "userpass",
+		Attributes: map[string]string{
+			"user": "bob",
+		},
+	})
+	bytes, err := description.Serialize(model)
+	c.Assert(err, jc.ErrorIsNil)
+	return bytes
+}
+
+func (s *RemapCloudSuite) TestEmptyRemap(c *gc.C) {
+	bytes := s.serializedModel(c)
+	out, err := migration.RemapCloud(bytes, coremigration.CloudRemap{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(out, jc.DeepEquals, bytes)
+}
+
+func (s *RemapCloudSuite) TestRemapCloud(c *gc.C) {
+	out, err := migration.RemapCloud(s.serializedModel(c), coremigration.CloudRemap{
+		Cloud:       "cumulus",
+		CloudRegion: "west",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := description.Deserialize(out)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(model.Cloud(), gc.Equals, "cumulus")
+	c.Check(model.CloudRegion(), gc.Equals, "west")
+	c.Check(model.CloudCredential().Name(), gc.Equals, "default")
+	c.Check(model.CloudCredential().AuthType(), gc.Equals, "userpass")
+}
+
+func (s *RemapCloudSuite) TestRemapCredential(c *gc.C) {
+	out, err := migration.RemapCloud(s.serializedModel(c), coremigration.CloudRemap{
+		Cloud:      "cumulus",
+		Credential: names.NewCloudCredentialTag("cumulus/alice/other"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := description.Deserialize(out)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(model.Cloud(), gc.Equals, "cumulus")
+	c.Check(model.CloudRegion(), gc.Equals, "")
+	creds := model.CloudCredential()
+	c.Check(creds.Owner(), gc.Equals, "alice")
+	c.Check(creds.Cloud(), gc.Equals, "cumulus")
+	c.Check(creds.Name(), gc.Equals, "other")
+	c.Check(creds.Reference(), jc.IsTrue)
+	c.Check(creds.AuthType(), gc.Equals, "")
+	c.Check(creds.Attributes(), gc.HasLen, 0)
+}
+
+func (s *RemapCloudSuite) TestInvalidRemap(c *gc.C) {
+	_, err := migration.RemapCloud(s.serializedModel(c), coremigration.CloudRemap{
+		CloudRegion: "west",
+	})
+	c.Check(err, gc.ErrorMatches, "cloud region without cloud not valid")
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
+
 type ExportSuite struct {
 	statetesting.StateSuite
 }
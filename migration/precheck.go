@@ -33,6 +33,8 @@ type PrecheckBackend interface {
 	AllApplications() ([]PrecheckApplication, error)
 	ControllerBackend() (PrecheckBackendCloser, error)
 	CloudCredential(tag names.CloudCredentialTag) (cloud.Credential, error)
+	Cloud(name string) (cloud.Cloud, error)
+	User(tag names.UserTag) (PrecheckUser, error)
 	ListPendingResources(string) ([]resource.Resource, error)
 }
 
@@ -54,6 +56,12 @@ type PrecheckModel interface {
 	CloudCredential() (names.CloudCredentialTag, bool)
 }
 
+// PrecheckUser describes the state interface for a user needed by
+// the migration prechecks.
+type PrecheckUser interface {
+	IsDisabled() bool
+}
+
 // PrecheckMachine describes the state interface for a machine needed
 // by migration prechecks.
 type PrecheckMachine interface {
@@ -74,6 +82,7 @@ type PrecheckApplication interface {
 	CharmURL() (*charm.URL, bool)
 	AllUnits() ([]PrecheckUnit, error)
 	MinUnits() int
+	RollingUpgrade() (state.RollingUpgrade, bool)
 }
 
 // PrecheckUnit describes state interface for a unit needed by
@@ -92,22 +101,39 @@ type PrecheckUnit interface {
 // sure that the preconditions for model migration are met. The
 // backend provided must be for the model to be migrated.
 func SourcePrecheck(backend PrecheckBackend) error {
-	if err := checkModel(backend); err != nil {
+	return errors.Trace(sourcePrecheck(backend, &precheckReport{}))
+}
+
+// SourcePrecheckReport runs the same checks as SourcePrecheck, but
+// rather than stopping at the first problem it returns every problem
+// found. An error is only returned if the checks could not be run.
+func SourcePrecheckReport(backend PrecheckBackend) ([]coremigration.PrecheckProblem, error) {
+	report := &precheckReport{collectAll: true}
+	if err := sourcePrecheck(backend, report); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return report.problems, nil
+}
+
+func sourcePrecheck(backend PrecheckBackend, report *precheckReport) error {
+	if err := checkModel(backend, report); err != nil {
 		return errors.Trace(err)
 	}
 
-	if err := checkMachines(backend); err != nil {
+	if err := checkMachines(backend, report); err != nil {
 		return errors.Trace(err)
 	}
 
-	if err := checkApplications(backend); err != nil {
+	if err := checkApplications(backend, report); err != nil {
 		return errors.Trace(err)
 	}
 
 	if cleanupNeeded, err := backend.NeedsCleanup(); err != nil {
 		return errors.Annotate(err, "checking cleanups")
 	} else if cleanupNeeded {
-		return errors.New("cleanup needed")
+		if err := report.add("model", errors.New("cleanup needed")); err != nil {
+			return errors.Trace(err)
+		}
 	}
 
 	// Check the source controller.
@@ -116,22 +142,28 @@ func SourcePrecheck(backend PrecheckBackend) error {
 		return errors.Trace(err)
 	}
 	defer controllerBackend.Close()
-	if err := checkController(controllerBackend); err != nil {
+	report.entityPrefix = "controller "
+	if err := checkController(controllerBackend, report); err != nil {
 		return errors.Annotate(err, "controller")
 	}
 	return nil
 }
 
-func checkModel(backend PrecheckBackend) error {
+func checkModel(backend PrecheckBackend, report *precheckReport) error {
 	model, err := backend.Model()
 	if err != nil {
 		return errors.Annotate(err, "retrieving model")
 	}
 	if model.Life() != state.Alive {
-		return errors.Errorf("model is %s", model.Life())
+		if err := report.add("model", errors.Errorf("model is %s", model.Life())); err != nil {
+			return errors.Trace(err)
+		}
 	}
 	if model.MigrationMode() == state.MigrationModeImporting {
-		return errors.New("model is being imported as part of another migration")
+		err := report.add("model", errors.New("model is being imported as part of another migration"))
+		if err != nil {
+			return errors.Trace(err)
+		}
 	}
 	if credTag, found := model.CloudCredential(); found {
 		creds, err := backend.CloudCredential(credTag)
@@ -139,7 +171,9 @@ func checkModel(backend PrecheckBackend) error {
 			return errors.Trace(err)
 		}
 		if creds.Revoked {
-			return errors.New("model has revoked credentials")
+			if err := report.add("model", errors.New("model has revoked credentials")); err != nil {
+				return errors.Trace(err)
+			}
 		}
 	}
 	return nil
@@ -149,6 +183,21 @@ func checkModel(backend PrecheckBackend) error {
 // sure that the preconditions for model migration are met. The
 // backend provided must be for the target controller.
 func TargetPrecheck(backend PrecheckBackend, modelInfo coremigration.ModelInfo) error {
+	return errors.Trace(targetPrecheck(backend, modelInfo, &precheckReport{}))
+}
+
+// TargetPrecheckReport runs the same checks as TargetPrecheck, but
+// rather than stopping at the first problem it returns every problem
+// found. An error is only returned if the checks could not be run.
+func TargetPrecheckReport(backend PrecheckBackend, modelInfo coremigration.ModelInfo) ([]coremigration.PrecheckProblem, error) {
+	report := &precheckReport{collectAll: true}
+	if err := targetPrecheck(backend, modelInfo, report); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return report.problems, nil
+}
+
+func targetPrecheck(backend PrecheckBackend, modelInfo coremigration.ModelInfo, report *precheckReport) error {
 	if err := modelInfo.Validate(); err != nil {
 		return errors.Trace(err)
 	}
@@ -163,7 +212,9 @@ func TargetPrecheck(backend PrecheckBackend, modelInfo coremigration.ModelInfo)
 	if migrating, err := backend.IsMigrationActive(modelInfo.UUID); err != nil {
 		return errors.Annotate(err, "checking for active migration")
 	} else if migrating {
-		return errors.New("model is being migrated out of target controller")
+		if err := report.add("model", errors.New("model is being migrated out of target controller")); err != nil {
+			return errors.Trace(err)
+		}
 	}
 
 	controllerVersion, err := backend.AgentVersion()
@@ -172,16 +223,36 @@ func TargetPrecheck(backend PrecheckBackend, modelInfo coremigration.ModelInfo)
 	}
 
 	if controllerVersion.Compare(modelInfo.AgentVersion) < 0 {
-		return errors.Errorf("model has higher version than target controller (%s > %s)",
-			modelInfo.AgentVersion, controllerVersion)
+		err := report.add("model", errors.Errorf(
+			"model has higher version than target controller (%s > %s)",
+			modelInfo.AgentVersion, controllerVersion))
+		if err != nil {
+			return errors.Trace(err)
+		}
 	}
 
 	if !controllerVersionCompatible(modelInfo.ControllerAgentVersion, controllerVersion) {
-		return errors.Errorf("source controller has higher version than target controller (%s > %s)",
-			modelInfo.ControllerAgentVersion, controllerVersion)
+		err := report.add("controller", errors.Errorf(
+			"source controller has higher version than target controller (%s > %s)",
+			modelInfo.ControllerAgentVersion, controllerVersion))
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	if err := checkController(backend, report); err != nil {
+		return errors.Trace(err)
 	}
 
-	if err := checkController(backend); err != nil {
+	if err := checkTargetCloud(backend, modelInfo, report); err != nil {
+		return errors.Trace(err)
+	}
+
+	if err := checkTargetOwner(backend, modelInfo, report); err != nil {
+		return errors.Trace(err)
+	}
+
+	if err := checkTargetCredential(backend, modelInfo, report); err != nil {
 		return errors.Trace(err)
 	}
 
@@ -195,16 +266,84 @@ func TargetPrecheck(backend PrecheckBackend, modelInfo coremigration.ModelInfo)
 		// from a previous migration attempt. It will be removed
 		// before the next import.
 		if model.UUID() == modelInfo.UUID && model.MigrationMode() != state.MigrationModeImporting {
-			return errors.Errorf("model with same UUID already exists (%s)", modelInfo.UUID)
+			err := report.add("model", errors.Errorf("model with same UUID already exists (%s)", modelInfo.UUID))
+			if err != nil {
+				return errors.Trace(err)
+			}
 		}
 		if model.Name() == modelInfo.Name && model.Owner() == modelInfo.Owner {
-			return errors.Errorf("model named %q already exists", model.Name())
+			if err := report.add("model", errors.Errorf("model named %q already exists", model.Name())); err != nil {
+				return errors.Trace(err)
+			}
 		}
 	}
 
 	return nil
 }
 
+// checkTargetCloud checks that the cloud and region the model is
+// deployed to are known to the target controller.
+func checkTargetCloud(backend PrecheckBackend, modelInfo coremigration.ModelInfo, report *precheckReport) error {
+	if modelInfo.Cloud == "" {
+		return nil
+	}
+	modelCloud, err := backend.Cloud(modelInfo.Cloud)
+	if errors.IsNotFound(err) {
+		return report.add("model", errors.Errorf("cloud %q not found on target controller", modelInfo.Cloud))
+	} else if err != nil {
+		return errors.Annotate(err, "retrieving cloud")
+	}
+	if modelInfo.CloudRegion == "" {
+		return nil
+	}
+	if _, err := cloud.RegionByName(modelCloud.Regions, modelInfo.CloudRegion); err != nil {
+		return report.add("model", errors.Errorf(
+			"cloud region %q not found on target controller", modelInfo.CloudRegion))
+	}
+	return nil
+}
+
+// checkTargetOwner checks that a local model owner exists and is
+// enabled on the target controller.
+func checkTargetOwner(backend PrecheckBackend, modelInfo coremigration.ModelInfo, report *precheckReport) error {
+	if !modelInfo.Owner.IsLocal() {
+		return nil
+	}
+	user, err := backend.User(modelInfo.Owner)
+	if errors.IsNotFound(err) || errors.IsUserNotFound(err) {
+		return report.add("model", errors.Errorf(
+			"model owner %q not found on target controller", modelInfo.Owner.Id()))
+	} else if err != nil {
+		return errors.Annotate(err, "retrieving model owner")
+	}
+	if user.IsDisabled() {
+		return report.add("model", errors.Errorf(
+			"model owner %q is disabled on target controller", modelInfo.Owner.Id()))
+	}
+	return nil
+}
+
+// checkTargetCredential checks that the credential the model has been
+// remapped to, if any, is usable on the target controller.
+func checkTargetCredential(backend PrecheckBackend, modelInfo coremigration.ModelInfo, report *precheckReport) error {
+	credTag := modelInfo.CloudCredential
+	if credTag.Id() == "" {
+		return nil
+	}
+	creds, err := backend.CloudCredential(credTag)
+	if errors.IsNotFound(err) {
+		return report.add("model", errors.Errorf(
+			"cloud credential %q not found on target controller", credTag.Id()))
+	} else if err != nil {
+		return errors.Annotate(err, "retrieving cloud credential")
+	}
+	if creds.Revoked {
+		return report.add("model", errors.Errorf(
+			"cloud credential %q is revoked on target controller", credTag.Id()))
+	}
+	return nil
+}
+
 func controllerVersionCompatible(sourceVersion, targetVersion version.Number) bool {
 	// Compare source controller version to target controller version, only
 	// considering major and minor version numbers. Downgrades between
@@ -222,26 +361,30 @@ func versionToMajMin(ver version.Number) version.Number {
 	return ver
 }
 
-func checkController(backend PrecheckBackend) error {
+func checkController(backend PrecheckBackend, report *precheckReport) error {
 	model, err := backend.Model()
 	if err != nil {
 		return errors.Annotate(err, "retrieving model")
 	}
 	if model.Life() != state.Alive {
-		return errors.Errorf("model is %s", model.Life())
+		if err := report.add("model", errors.Errorf("model is %s", model.Life())); err != nil {
+			return errors.Trace(err)
+		}
 	}
 
 	if upgrading, err := backend.IsUpgrading(); err != nil {
 		return errors.Annotate(err, "checking for upgrades")
 	} else if upgrading {
-		return errors.New("upgrade in progress")
+		if err := report.add("controller", errors.New("upgrade in progress")); err != nil {
+			return errors.Trace(err)
+		}
 	}
 
-	err = checkMachines(backend)
+	err = checkMachines(backend, report)
 	return errors.Trace(err)
 }
 
-func checkMachines(backend PrecheckBackend) error {
+func checkMachines(backend PrecheckBackend, report *precheckReport) error {
 	modelVersion, err := backend.AgentVersion()
 	if err != nil {
 		return errors.Annotate(err, "retrieving model version")
@@ -252,37 +395,49 @@ func checkMachines(backend PrecheckBackend) error {
 		return errors.Annotate(err, "retrieving machines")
 	}
 	for _, machine := range machines {
+		entity := "machine " + machine.Id()
 		if machine.Life() != state.Alive {
-			return errors.Errorf("machine %s is %s", machine.Id(), machine.Life())
+			if err := report.add(entity, errors.Errorf("machine %s is %s", machine.Id(), machine.Life())); err != nil {
+				return errors.Trace(err)
+			}
 		}
 
 		if statusInfo, err := machine.InstanceStatus(); err != nil {
 			return errors.Annotatef(err, "retrieving machine %s instance status", machine.Id())
 		} else if statusInfo.Status != status.Running {
-			return newStatusError("machine %s not running", machine.Id(), statusInfo.Status)
+			err := report.add(entity, newStatusError("machine %s not running", machine.Id(), statusInfo.Status))
+			if err != nil {
+				return errors.Trace(err)
+			}
 		}
 
 		if statusInfo, err := common.MachineStatus(machine); err != nil {
 			return errors.Annotatef(err, "retrieving machine %s status", machine.Id())
 		} else if statusInfo.Status != status.Started {
-			return newStatusError("machine %s agent not functioning at this time",
-				machine.Id(), statusInfo.Status)
+			err := report.add(entity, newStatusError("machine %s agent not functioning at this time",
+				machine.Id(), statusInfo.Status))
+			if err != nil {
+				return errors.Trace(err)
+			}
 		}
 
 		if rebootAction, err := machine.ShouldRebootOrShutdown(); err != nil {
 			return errors.Annotatef(err, "retrieving machine %s reboot status", machine.Id())
 		} else if rebootAction != state.ShouldDoNothing {
-			return errors.Errorf("machine %s is scheduled to %s", machine.Id(), rebootAction)
+			err := report.add(entity, errors.Errorf("machine %s is scheduled to %s", machine.Id(), rebootAction))
+			if err != nil {
+				return errors.Trace(err)
+			}
 		}
 
-		if err := checkAgentTools(modelVersion, machine, "machine "+machine.Id()); err != nil {
+		if err := checkAgentTools(modelVersion, machine, entity, report); err != nil {
 			return errors.Trace(err)
 		}
 	}
 	return nil
 }
 
-func checkApplications(backend PrecheckBackend) error {
+func checkApplications(backend PrecheckBackend, report *precheckReport) error {
 	modelVersion, err := backend.AgentVersion()
 	if err != nil {
 		return errors.Annotate(err, "retrieving model version")
@@ -292,10 +447,20 @@ func checkApplications(backend PrecheckBackend) error {
 		return errors.Annotate(err, "retrieving applications")
 	}
 	for _, app := range apps {
+		entity := "application " + app.Name()
 		if app.Life() != state.Alive {
-			return errors.Errorf("application %s is %s", app.Name(), app.Life())
+			if err := report.add(entity, errors.Errorf("application %s is %s", app.Name(), app.Life())); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		if _, ok := app.RollingUpgrade(); ok {
+			err := report.add(entity, errors.Errorf(
+				"application %s has a rolling charm upgrade in progress", app.Name()))
+			if err != nil {
+				return errors.Trace(err)
+			}
 		}
-		err := checkUnits(app, modelVersion)
+		err := checkUnits(app, modelVersion, report)
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -304,47 +469,59 @@ func checkApplications(backend PrecheckBackend) error {
 		if err != nil {
 			return errors.Annotate(err, "checking resources")
 		}
-		if len(resources) > 0 {
-			resName := resources[0].Name
-			return errors.Errorf("resource %q is pending for application %s", resName, app.Name())
+		for _, res := range resources {
+			err := report.add(entity, errors.Errorf(
+				"resource %q is pending for application %s", res.Name, app.Name()))
+			if err != nil {
+				return errors.Trace(err)
+			}
 		}
 	}
 	return nil
 }
 
-func checkUnits(app PrecheckApplication, modelVersion version.Number) error {
+func checkUnits(app PrecheckApplication, modelVersion version.Number, report *precheckReport) error {
 	units, err := app.AllUnits()
 	if err != nil {
 		return errors.Annotatef(err, "retrieving units for %s", app.Name())
 	}
 	if len(units) < app.MinUnits() {
-		return errors.Errorf("application %s is below its minimum units threshold", app.Name())
+		err := report.add("application "+app.Name(), errors.Errorf(
+			"application %s is below its minimum units threshold", app.Name()))
+		if err != nil {
+			return errors.Trace(err)
+		}
 	}
 
 	appCharmURL, _ := app.CharmURL()
 
 	for _, unit := range units {
+		entity := "unit " + unit.Name()
 		if unit.Life() != state.Alive {
-			return errors.Errorf("unit %s is %s", unit.Name(), unit.Life())
+			if err := report.add(entity, errors.Errorf("unit %s is %s", unit.Name(), unit.Life())); err != nil {
+				return errors.Trace(err)
+			}
 		}
 
-		if err := checkUnitAgentStatus(unit); err != nil {
+		if err := checkUnitAgentStatus(unit, report); err != nil {
 			return errors.Trace(err)
 		}
 
-		if err := checkAgentTools(modelVersion, unit, "unit "+unit.Name()); err != nil {
+		if err := checkAgentTools(modelVersion, unit, entity, report); err != nil {
 			return errors.Trace(err)
 		}
 
 		unitCharmURL, _ := unit.CharmURL()
 		if appCharmURL.String() != unitCharmURL.String() {
-			return errors.Errorf("unit %s is upgrading", unit.Name())
+			if err := report.add(entity, errors.Errorf("unit %s is upgrading", unit.Name())); err != nil {
+				return errors.Trace(err)
+			}
 		}
 	}
 	return nil
 }
 
-func checkUnitAgentStatus(unit PrecheckUnit) error {
+func checkUnitAgentStatus(unit PrecheckUnit, report *precheckReport) error {
 	statusData, _ := common.UnitStatus(unit)
 	if statusData.Err != nil {
 		return errors.Annotatef(statusData.Err, "retrieving unit %s status", unit.Name())
@@ -354,20 +531,20 @@ func checkUnitAgentStatus(unit PrecheckUnit) error {
 	case status.Idle, status.Executing:
 		// These two are fine.
 	default:
-		return newStatusError("unit %s not idle or executing", unit.Name(), agentStatus)
+		return report.add("unit "+unit.Name(), newStatusError("unit %s not idle or executing", unit.Name(), agentStatus))
 	}
 	return nil
 }
 
-func checkAgentTools(modelVersion version.Number, agent agentToolsGetter, agentLabel string) error {
+func checkAgentTools(modelVersion version.Number, agent agentToolsGetter, agentLabel string, report *precheckReport) error {
 	tools, err := agent.AgentTools()
 	if err != nil {
 		return errors.Annotatef(err, "retrieving tools for %s", agentLabel)
 	}
 	agentVersion := tools.Version.Number
 	if agentVersion != modelVersion {
-		return errors.Errorf("%s tools don't match model (%s != %s)",
-			agentLabel, agentVersion, modelVersion)
+		return report.add(agentLabel, errors.Errorf("%s tools don't match model (%s != %s)",
+			agentLabel, agentVersion, modelVersion))
 	}
 	return nil
 }
@@ -383,3 +560,24 @@ func newStatusError(format, id string, s status.Status) error {
 	}
 	return errors.New(msg)
 }
+
+// precheckReport collects the problems found by the prechecks. Unless
+// collectAll is set, recording a problem also returns it as an error,
+// so that the checks stop at the first problem found.
+type precheckReport struct {
+	collectAll   bool
+	entityPrefix string
+	problems     []coremigration.PrecheckProblem
+}
+
+// add records a problem with the named entity.
+func (r *precheckReport) add(entity string, problem error) error {
+	r.problems = append(r.problems, coremigration.PrecheckProblem{
+		Entity:  r.entityPrefix + entity,
+		Message: problem.Error(),
+	})
+	if r.collectAll {
+		return nil
+	}
+	return problem
+}
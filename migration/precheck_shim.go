@@ -6,6 +6,7 @@ package migration
 import (
 	"github.com/juju/errors"
 	"github.com/juju/version"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/state"
@@ -57,6 +58,15 @@ func (s *precheckShim) IsMigrationActive(modelUUID string) (bool, error) {
 	return state.IsMigrationActive(s.State, modelUUID)
 }
 
+// User implements PrecheckBackend.
+func (s *precheckShim) User(tag names.UserTag) (PrecheckUser, error) {
+	user, err := s.State.User(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return user, nil
+}
+
 // AgentVersion implements PrecheckBackend.
 func (s *precheckShim) AgentVersion() (version.Number, error) {
 	cfg, err := s.State.ModelConfig()
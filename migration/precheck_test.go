@@ -169,6 +169,19 @@ func (s *SourcePrecheckSuite) TestDyingApplication(c *gc.C) {
 	c.Assert(err.Error(), gc.Equals, "application foo is dying")
 }
 
+func (s *SourcePrecheckSuite) TestRollingUpgradeInProgress(c *gc.C) {
+	backend := &fakeBackend{
+		apps: []migration.PrecheckApplication{
+			&fakeApp{
+				name:    "foo",
+				rolling: true,
+			},
+		},
+	}
+	err := migration.SourcePrecheck(backend)
+	c.Assert(err.Error(), gc.Equals, "application foo has a rolling charm upgrade in progress")
+}
+
 func (s *SourcePrecheckSuite) TestWithPendingMinUnits(c *gc.C) {
 	backend := &fakeBackend{
 		apps: []migration.PrecheckApplication{
@@ -316,6 +329,44 @@ func (s *SourcePrecheckSuite) TestProvisioningControllerMachine(c *gc.C) {
 	c.Assert(err.Error(), gc.Equals, "controller: machine 0 not running (allocating)")
 }
 
+func (s *SourcePrecheckSuite) TestReportSuccess(c *gc.C) {
+	backend := newHappyBackend()
+	backend.controllerBackend = newHappyBackend()
+	problems, err := migration.SourcePrecheckReport(backend)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(problems, gc.HasLen, 0)
+}
+
+func (s *SourcePrecheckSuite) TestReportCollectsAllProblems(c *gc.C) {
+	backend := newBackendWithDyingMachine()
+	backend.cleanupNeeded = true
+	backend.apps = []migration.PrecheckApplication{
+		&fakeApp{
+			name:     "spanner",
+			charmURL: "cs:spanner-3",
+			units: []migration.PrecheckUnit{
+				&fakeUnit{name: "spanner/0", charmURL: "cs:spanner-2"},
+			},
+		},
+	}
+	backend.controllerBackend = newBackendWithProvisioningMachine()
+	problems, err := migration.SourcePrecheckReport(backend)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(problems, jc.DeepEquals, []coremigration.PrecheckProblem{
+		{Entity: "machine 0", Message: "machine 0 is dying"},
+		{Entity: "unit spanner/0", Message: "unit spanner/0 is upgrading"},
+		{Entity: "model", Message: "cleanup needed"},
+		{Entity: "controller machine 0", Message: "machine 0 not running (allocating)"},
+	})
+}
+
+func (s *SourcePrecheckSuite) TestReportRetrievalError(c *gc.C) {
+	backend := newHappyBackend()
+	backend.pendingResourcesErr = errors.New("blam")
+	_, err := migration.SourcePrecheckReport(backend)
+	c.Assert(err, gc.ErrorMatches, "checking resources: blam")
+}
+
 type TargetPrecheckSuite struct {
 	precheckBaseSuite
 	modelInfo coremigration.ModelInfo
@@ -523,6 +574,119 @@ func (s *TargetPrecheckSuite) TestUUIDAlreadyExistsButImporting(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *TargetPrecheckSuite) TestCloudNotFound(c *gc.C) {
+	backend := newFakeBackend()
+	backend.cloudErr = errors.NotFoundf("cloud %q", "aws")
+	s.modelInfo.Cloud = "aws"
+	err := migration.TargetPrecheck(backend, s.modelInfo)
+	c.Assert(err, gc.ErrorMatches, `cloud "aws" not found on target controller`)
+}
+
+func (s *TargetPrecheckSuite) TestCloudError(c *gc.C) {
+	backend := newFakeBackend()
+	backend.cloudErr = errors.New("boom")
+	s.modelInfo.Cloud = "aws"
+	err := migration.TargetPrecheck(backend, s.modelInfo)
+	c.Assert(err, gc.ErrorMatches, "retrieving cloud: boom")
+}
+
+func (s *TargetPrecheckSuite) TestCloudRegionNotFound(c *gc.C) {
+	backend := newFakeBackend()
+	backend.cloud = cloud.Cloud{Regions: []cloud.Region{{Name: "us-east-1"}}}
+	s.modelInfo.Cloud = "aws"
+	s.modelInfo.CloudRegion = "eu-west-1"
+	err := migration.TargetPrecheck(backend, s.modelInfo)
+	c.Assert(err, gc.ErrorMatches, `cloud region "eu-west-1" not found on target controller`)
+}
+
+func (s *TargetPrecheckSuite) TestCloudRegionFound(c *gc.C) {
+	backend := newFakeBackend()
+	backend.cloud = cloud.Cloud{Regions: []cloud.Region{{Name: "us-east-1"}}}
+	s.modelInfo.Cloud = "aws"
+	s.modelInfo.CloudRegion = "us-east-1"
+	err := migration.TargetPrecheck(backend, s.modelInfo)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *TargetPrecheckSuite) TestOwnerNotFound(c *gc.C) {
+	backend := newFakeBackend()
+	backend.userErr = errors.NotFoundf("user %q", "owner")
+	err := migration.TargetPrecheck(backend, s.modelInfo)
+	c.Assert(err, gc.ErrorMatches, `model owner "owner" not found on target controller`)
+}
+
+func (s *TargetPrecheckSuite) TestOwnerDisabled(c *gc.C) {
+	backend := newFakeBackend()
+	backend.userDisabled = true
+	err := migration.TargetPrecheck(backend, s.modelInfo)
+	c.Assert(err, gc.ErrorMatches, `model owner "owner" is disabled on target controller`)
+}
+
+func (s *TargetPrecheckSuite) TestExternalOwnerNotChecked(c *gc.C) {
+	backend := newFakeBackend()
+	backend.userErr = errors.New("should not be called")
+	s.modelInfo.Owner = names.NewUserTag("bob@external")
+	err := migration.TargetPrecheck(backend, s.modelInfo)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *TargetPrecheckSuite) TestRemappedCredential(c *gc.C) {
+	backend := newFakeBackend()
+	s.modelInfo.CloudCredential = names.NewCloudCredentialTag("openstack/bob/default")
+	err := migration.TargetPrecheck(backend, s.modelInfo)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *TargetPrecheckSuite) TestRemappedCredentialNotFound(c *gc.C) {
+	backend := newFakeBackend()
+	backend.credentialsErr = errors.NotFoundf("credential")
+	s.modelInfo.CloudCredential = names.NewCloudCredentialTag("openstack/bob/default")
+	err := migration.TargetPrecheck(backend, s.modelInfo)
+	c.Assert(err, gc.ErrorMatches, `cloud credential "openstack/bob/default" not found on target controller`)
+}
+
+func (s *TargetPrecheckSuite) TestRemappedCredentialRevoked(c *gc.C) {
+	backend := newFakeBackend()
+	backend.credentials = cloud.NewCredential(cloud.UserPassAuthType, nil)
+	backend.credentials.Revoked = true
+	s.modelInfo.CloudCredential = names.NewCloudCredentialTag("openstack/bob/default")
+	err := migration.TargetPrecheck(backend, s.modelInfo)
+	c.Assert(err, gc.ErrorMatches, `cloud credential "openstack/bob/default" is revoked on target controller`)
+}
+
+func (s *TargetPrecheckSuite) TestCredentialNotRemapped(c *gc.C) {
+	backend := newFakeBackend()
+	backend.credentialsErr = errors.New("should not be called")
+	err := migration.TargetPrecheck(backend, s.modelInfo)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *TargetPrecheckSuite) TestReportCollectsAllProblems(c *gc.C) {
+	backend := newBackendWithDyingMachine()
+	backend.isUpgrading = true
+	backend.userDisabled = true
+	backend.models = []migration.PrecheckModel{
+		&fakeModel{uuid: modelUUID},
+	}
+	s.modelInfo.Cloud = "aws"
+	s.modelInfo.CloudRegion = "us-east-1"
+	problems, err := migration.TargetPrecheckReport(backend, s.modelInfo)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(problems, jc.DeepEquals, []coremigration.PrecheckProblem{
+		{Entity: "controller", Message: "upgrade in progress"},
+		{Entity: "machine 0", Message: "machine 0 is dying"},
+		{Entity: "model", Message: `cloud region "us-east-1" not found on target controller`},
+		{Entity: "model", Message: `model owner "owner" is disabled on target controller`},
+		{Entity: "model", Message: "model with same UUID already exists (model-uuid)"},
+	})
+}
+
+func (s *TargetPrecheckSuite) TestReportInvalidModelInfo(c *gc.C) {
+	s.modelInfo.UUID = ""
+	_, err := migration.TargetPrecheckReport(newFakeBackend(), s.modelInfo)
+	c.Assert(err, gc.ErrorMatches, "empty UUID not valid")
+}
+
 type precheckRunner func(migration.PrecheckBackend) error
 
 type precheckBaseSuite struct {
@@ -652,6 +816,12 @@ type fakeBackend struct {
 	credentials    cloud.Credential
 	credentialsErr error
 
+	cloud    cloud.Cloud
+	cloudErr error
+
+	userDisabled bool
+	userErr      error
+
 	pendingResources    []resource.Resource
 	pendingResourcesErr error
 
@@ -690,6 +860,17 @@ func (b *fakeBackend) CloudCredential(tag names.CloudCredentialTag) (cloud.Crede
 	return b.credentials, b.credentialsErr
 }
 
+func (b *fakeBackend) Cloud(name string) (cloud.Cloud, error) {
+	return b.cloud, b.cloudErr
+}
+
+func (b *fakeBackend) User(tag names.UserTag) (migration.PrecheckUser, error) {
+	if b.userErr != nil {
+		return nil, b.userErr
+	}
+	return &fakeUser{disabled: b.userDisabled}, nil
+}
+
 func (b *fakeBackend) AllMachines() ([]migration.PrecheckMachine, error) {
 	return b.machines, b.allMachinesErr
 }
@@ -710,6 +891,14 @@ func (b *fakeBackend) ControllerBackend() (migration.PrecheckBackendCloser, erro
 	return b.controllerBackend, nil
 }
 
+type fakeUser struct {
+	disabled bool
+}
+
+func (u *fakeUser) IsDisabled() bool {
+	return u.disabled
+}
+
 type fakeModel struct {
 	uuid          string
 	name          string
@@ -811,6 +1000,7 @@ type fakeApp struct {
 	charmURL string
 	units    []migration.PrecheckUnit
 	minunits int
+	rolling  bool
 }
 
 func (a *fakeApp) Name() string {
@@ -837,6 +1027,15 @@ func (a *fakeApp) MinUnits() int {
 	return a.minunits
 }
 
+func (a *fakeApp) RollingUpgrade() (state.RollingUpgrade, bool) {
+	if !a.rolling {
+		return state.RollingUpgrade{}, false
+	}
+	return state.RollingUpgrade{
+		RollingUpgradeParams: state.RollingUpgradeParams{BatchSize: 1},
+	}, true
+}
+
 type fakeUnit struct {
 	name        string
 	version     version.Binary
@@ -299,7 +299,11 @@ func SelectPeerHostPortBySpace(hostPorts []network.HostPort, space network.Space
 
 	if !foundHostPortsInSpaces {
 		logger.Debugf("Failed to select hostPort by space - trying by scope from %+v", hostPorts)
-		suitableHostPorts = network.SelectMongoHostPortsByScope(hostPorts, true)
+		// On IPv6-only machines ip6-localhost would otherwise win over
+		// the machine's real address; see SelectPeerHostPort.
+		allowMachineLocal := network.HostPortsHasIPv4Address(hostPorts) ||
+			len(network.FilterUnusableHostPorts(hostPorts)) == 0
+		suitableHostPorts = network.SelectMongoHostPortsByScope(hostPorts, allowMachineLocal)
 	}
 	return suitableHostPorts[0]
 }
@@ -716,6 +716,21 @@ func (s *MongoSuite) TestSelectPeerHostPort(c *gc.C) {
 	c.Assert(address, gc.Equals, "10.0.0.1:"+strconv.Itoa(controller.DefaultStatePort))
 }
 
+func (s *MongoSuite) TestSelectPeerHostPortBySpaceIPv6Only(c *gc.C) {
+	hostPorts := network.NewHostPorts(controller.DefaultStatePort, "::1", "2001:db8::1")
+	hostPorts[1].Scope = network.ScopePublic
+
+	address := mongo.SelectPeerHostPortBySpace(hostPorts, "missing")
+	c.Assert(address, gc.Equals, "[2001:db8::1]:"+strconv.Itoa(controller.DefaultStatePort))
+}
+
+func (s *MongoSuite) TestSelectPeerHostPortBySpaceMachineLocalOnly(c *gc.C) {
+	hostPorts := network.NewHostPorts(controller.DefaultStatePort, "::1")
+
+	address := mongo.SelectPeerHostPortBySpace(hostPorts, "missing")
+	c.Assert(address, gc.Equals, "[::1]:"+strconv.Itoa(controller.DefaultStatePort))
+}
+
 func (s *MongoSuite) TestGenerateSharedSecret(c *gc.C) {
 	secret, err := mongo.GenerateSharedSecret()
 	c.Assert(err, jc.ErrorIsNil)
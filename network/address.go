@@ -365,64 +365,137 @@ func SelectMongoHostPortsByScope(hostPorts []HostPort, machineLocal bool) []stri
 // are no suitable addresses, then ok is false (and an empty address is
 // returned). If a suitable address is then ok is true.
 func SelectPublicAddress(addresses []Address) (Address, bool) {
+	return PreferIPv4.SelectPublicAddress(addresses)
+}
+
+// SelectPublicHostPort picks one HostPort from a slice that would be
+// appropriate to display as a publicly accessible endpoint. If there
+// are no suitable candidates, the empty string is returned.
+func SelectPublicHostPort(hps []HostPort) string {
+	return PreferIPv4.SelectPublicHostPort(hps)
+}
+
+// SelectInternalAddress picks one address from a slice that can be
+// used as an endpoint for juju internal communication. If there are
+// are no suitable addresses, then ok is false (and an empty address is
+// returned). If a suitable address was found then ok is true.
+func SelectInternalAddress(addresses []Address, machineLocal bool) (Address, bool) {
+	return PreferIPv4.SelectInternalAddress(addresses, machineLocal)
+}
+
+// SelectInternalHostPort picks one HostPort from a slice that can be
+// used as an endpoint for juju internal communication and returns it
+// in its NetAddr form. If there are no suitable addresses, the empty
+// string is returned.
+func SelectInternalHostPort(hps []HostPort, machineLocal bool) string {
+	return PreferIPv4.SelectInternalHostPort(hps, machineLocal)
+}
+
+// SelectInternalHostPorts picks the best matching HostPorts from a
+// slice that can be used as an endpoint for juju internal
+// communication and returns them in NetAddr form. If there are no
+// suitable addresses, an empty slice is returned.
+func SelectInternalHostPorts(hps []HostPort, machineLocal bool) []string {
+	return PreferIPv4.SelectInternalHostPorts(hps, machineLocal)
+}
+
+// PrioritizeInternalHostPorts orders the provided addresses by best
+// match for use as an endpoint for juju internal communication and
+// returns them in NetAddr form. If there are no suitable addresses
+// then an empty slice is returned.
+func PrioritizeInternalHostPorts(hps []HostPort, machineLocal bool) []string {
+	return PreferIPv4.PrioritizeInternalHostPorts(hps, machineLocal)
+}
+
+// AddressPreference determines which address family is chosen when
+// selecting between addresses that are otherwise equally suitable.
+type AddressPreference string
+
+const (
+	// PreferIPv4 favours IPv4 addresses over IPv6 ones. It is the
+	// default, and the behaviour of the package-level Select*
+	// functions.
+	PreferIPv4 AddressPreference = "ipv4"
+
+	// PreferIPv6 favours IPv6 addresses over IPv4 ones.
+	PreferIPv6 AddressPreference = "ipv6"
+)
+
+// ParseAddressPreference returns the AddressPreference corresponding
+// to the given string. The empty string yields PreferIPv4.
+func ParseAddressPreference(value string) (AddressPreference, error) {
+	switch p := AddressPreference(value); p {
+	case "":
+		return PreferIPv4, nil
+	case PreferIPv4, PreferIPv6:
+		return p, nil
+	}
+	return "", errors.NotValidf("address preference %q", value)
+}
+
+// preferred reports whether addr belongs to the preferred family.
+// Hostnames never do, as they may resolve to either.
+func (p AddressPreference) preferred(addr Address) bool {
+	if p == PreferIPv6 {
+		return addr.Type == IPv6Address
+	}
+	return addr.Type == IPv4Address
+}
+
+// SelectPublicAddress is like the package-level SelectPublicAddress,
+// but favours addresses of the preferred family.
+func (p AddressPreference) SelectPublicAddress(addresses []Address) (Address, bool) {
 	index := bestAddressIndex(len(addresses), func(i int) Address {
 		return addresses[i]
-	}, publicMatch)
+	}, p.publicMatch)
 	if index < 0 {
 		return Address{}, false
 	}
 	return addresses[index], true
 }
 
-// SelectPublicHostPort picks one HostPort from a slice that would be
-// appropriate to display as a publicly accessible endpoint. If there
-// are no suitable candidates, the empty string is returned.
-func SelectPublicHostPort(hps []HostPort) string {
+// SelectPublicHostPort is like the package-level SelectPublicHostPort,
+// but favours addresses of the preferred family.
+func (p AddressPreference) SelectPublicHostPort(hps []HostPort) string {
 	index := bestAddressIndex(len(hps), func(i int) Address {
 		return hps[i].Address
-	}, publicMatch)
+	}, p.publicMatch)
 	if index < 0 {
 		return ""
 	}
 	return hps[index].NetAddr()
 }
 
-// SelectInternalAddress picks one address from a slice that can be
-// used as an endpoint for juju internal communication. If there are
-// are no suitable addresses, then ok is false (and an empty address is
-// returned). If a suitable address was found then ok is true.
-func SelectInternalAddress(addresses []Address, machineLocal bool) (Address, bool) {
+// SelectInternalAddress is like the package-level SelectInternalAddress,
+// but favours addresses of the preferred family.
+func (p AddressPreference) SelectInternalAddress(addresses []Address, machineLocal bool) (Address, bool) {
 	index := bestAddressIndex(len(addresses), func(i int) Address {
 		return addresses[i]
-	}, internalAddressMatcher(machineLocal))
+	}, p.internalAddressMatcher(machineLocal))
 	if index < 0 {
 		return Address{}, false
 	}
 	return addresses[index], true
 }
 
-// SelectInternalHostPort picks one HostPort from a slice that can be
-// used as an endpoint for juju internal communication and returns it
-// in its NetAddr form. If there are no suitable addresses, the empty
-// string is returned.
-func SelectInternalHostPort(hps []HostPort, machineLocal bool) string {
+// SelectInternalHostPort is like the package-level SelectInternalHostPort,
+// but favours addresses of the preferred family.
+func (p AddressPreference) SelectInternalHostPort(hps []HostPort, machineLocal bool) string {
 	index := bestAddressIndex(len(hps), func(i int) Address {
 		return hps[i].Address
-	}, internalAddressMatcher(machineLocal))
+	}, p.internalAddressMatcher(machineLocal))
 	if index < 0 {
 		return ""
 	}
 	return hps[index].NetAddr()
 }
 
-// SelectInternalHostPorts picks the best matching HostPorts from a
-// slice that can be used as an endpoint for juju internal
-// communication and returns them in NetAddr form. If there are no
-// suitable addresses, an empty slice is returned.
-func SelectInternalHostPorts(hps []HostPort, machineLocal bool) []string {
+// SelectInternalHostPorts is like the package-level
+// SelectInternalHostPorts, but favours addresses of the preferred family.
+func (p AddressPreference) SelectInternalHostPorts(hps []HostPort, machineLocal bool) []string {
 	indexes := bestAddressIndexes(len(hps), func(i int) Address {
 		return hps[i].Address
-	}, internalAddressMatcher(machineLocal))
+	}, p.internalAddressMatcher(machineLocal))
 
 	out := make([]string, 0, len(indexes))
 	for _, index := range indexes {
@@ -431,14 +504,13 @@ func SelectInternalHostPorts(hps []HostPort, machineLocal bool) []string {
 	return out
 }
 
-// PrioritizeInternalHostPorts orders the provided addresses by best
-// match for use as an endpoint for juju internal communication and
-// returns them in NetAddr form. If there are no suitable addresses
-// then an empty slice is returned.
-func PrioritizeInternalHostPorts(hps []HostPort, machineLocal bool) []string {
+// PrioritizeInternalHostPorts is like the package-level
+// PrioritizeInternalHostPorts, but favours addresses of the preferred
+// family.
+func (p AddressPreference) PrioritizeInternalHostPorts(hps []HostPort, machineLocal bool) []string {
 	indexes := prioritizedAddressIndexes(len(hps), func(i int) Address {
 		return hps[i].Address
-	}, internalAddressMatcher(machineLocal))
+	}, p.internalAddressMatcher(machineLocal))
 
 	out := make([]string, 0, len(indexes))
 	for _, index := range indexes {
@@ -447,62 +519,62 @@ func PrioritizeInternalHostPorts(hps []HostPort, machineLocal bool) []string {
 	return out
 }
 
-func publicMatch(addr Address) scopeMatch {
+func (p AddressPreference) publicMatch(addr Address) scopeMatch {
 	switch addr.Scope {
 	case ScopePublic:
-		if addr.Type == IPv4Address {
-			return exactScopeIPv4
+		if p.preferred(addr) {
+			return exactScopePreferred
 		}
 		return exactScope
 	case ScopeCloudLocal, ScopeUnknown:
-		if addr.Type == IPv4Address {
-			return fallbackScopeIPv4
+		if p.preferred(addr) {
+			return fallbackScopePreferred
 		}
 		return fallbackScope
 	}
 	return invalidScope
 }
 
-func internalAddressMatcher(machineLocal bool) scopeMatchFunc {
+func (p AddressPreference) internalAddressMatcher(machineLocal bool) scopeMatchFunc {
 	if machineLocal {
-		return cloudOrMachineLocalMatch
+		return p.cloudOrMachineLocalMatch
 	}
-	return cloudLocalMatch
+	return p.cloudLocalMatch
 }
 
-func cloudLocalMatch(addr Address) scopeMatch {
+func (p AddressPreference) cloudLocalMatch(addr Address) scopeMatch {
 	switch addr.Scope {
 	case ScopeCloudLocal:
-		if addr.Type == IPv4Address {
-			return exactScopeIPv4
+		if p.preferred(addr) {
+			return exactScopePreferred
 		}
 		return exactScope
 	case ScopePublic, ScopeUnknown:
-		if addr.Type == IPv4Address {
-			return fallbackScopeIPv4
+		if p.preferred(addr) {
+			return fallbackScopePreferred
 		}
 		return fallbackScope
 	}
 	return invalidScope
 }
 
-func cloudOrMachineLocalMatch(addr Address) scopeMatch {
+func (p AddressPreference) cloudOrMachineLocalMatch(addr Address) scopeMatch {
 	if addr.Scope == ScopeMachineLocal {
-		if addr.Type == IPv4Address {
-			return exactScopeIPv4
+		if p.preferred(addr) {
+			return exactScopePreferred
 		}
 		return exactScope
 	}
-	return cloudLocalMatch(addr)
+	return p.cloudLocalMatch(addr)
 }
 
 type scopeMatch int
 
 const (
 	invalidScope scopeMatch = iota
-	exactScopeIPv4
+	exactScopePreferred
 	exactScope
-	fallbackScopeIPv4
+	fallbackScopePreferred
 	fallbackScope
 )
 
@@ -529,7 +601,7 @@ func bestAddressIndexes(numAddr int, getAddrFunc addressByIndexFunc, matchFunc s
 	matches := filterAndCollateAddressIndexes(numAddr, getAddrFunc, matchFunc)
 
 	// Retrieve the indexes of the addresses with the best scope and type match.
-	allowedMatchTypes := []scopeMatch{exactScopeIPv4, exactScope, fallbackScopeIPv4, fallbackScope}
+	allowedMatchTypes := []scopeMatch{exactScopePreferred, exactScope, fallbackScopePreferred, fallbackScope}
 	for _, matchType := range allowedMatchTypes {
 		indexes, ok := matches[matchType]
 		if ok && len(indexes) > 0 {
@@ -544,7 +616,7 @@ func prioritizedAddressIndexes(numAddr int, getAddrFunc addressByIndexFunc, matc
 	matches := filterAndCollateAddressIndexes(numAddr, getAddrFunc, matchFunc)
 
 	// Retrieve the indexes of the addresses with the best scope and type match.
-	allowedMatchTypes := []scopeMatch{exactScopeIPv4, exactScope, fallbackScopeIPv4, fallbackScope}
+	allowedMatchTypes := []scopeMatch{exactScopePreferred, exactScope, fallbackScopePreferred, fallbackScope}
 	var prioritized []int
 	for _, matchType := range allowedMatchTypes {
 		indexes, ok := matches[matchType]
@@ -561,7 +633,7 @@ func filterAndCollateAddressIndexes(numAddr int, getAddrFunc addressByIndexFunc,
 	for i := 0; i < numAddr; i++ {
 		matchType := matchFunc(getAddrFunc(i))
 		switch matchType {
-		case exactScopeIPv4, exactScope, fallbackScopeIPv4, fallbackScope:
+		case exactScopePreferred, exactScope, fallbackScopePreferred, fallbackScope:
 			matches[matchType] = append(matches[matchType], i)
 		}
 	}
@@ -575,7 +647,8 @@ func filterAndCollateAddressIndexes(numAddr int, getAddrFunc addressByIndexFunc,
 // - machine-local next;
 // - link-local next;
 // - non-hostnames with unknown scope last.
-func (a Address) sortOrder() int {
+// Within each scope, addresses of the preferred family come first.
+func (p AddressPreference) sortOrder(a Address) int {
 	order := 0xFF
 	switch a.Scope {
 	case ScopePublic:
@@ -593,23 +666,26 @@ func (a Address) sortOrder() int {
 		if a.Value == "localhost" {
 			order++
 		}
-	case IPv6Address:
-		// Prefer IPv4 over IPv6 addresses.
-		order++
-	case IPv4Address:
+	case IPv4Address, IPv6Address:
+		if !p.preferred(a) {
+			order++
+		}
 	}
 	return order
 }
 
-type addressesPreferringIPv4Slice []Address
+type addressesByPreference struct {
+	addrs []Address
+	pref  AddressPreference
+}
 
-func (a addressesPreferringIPv4Slice) Len() int      { return len(a) }
-func (a addressesPreferringIPv4Slice) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
-func (a addressesPreferringIPv4Slice) Less(i, j int) bool {
-	addr1 := a[i]
-	addr2 := a[j]
-	order1 := addr1.sortOrder()
-	order2 := addr2.sortOrder()
+func (a addressesByPreference) Len() int      { return len(a.addrs) }
+func (a addressesByPreference) Swap(i, j int) { a.addrs[i], a.addrs[j] = a.addrs[j], a.addrs[i] }
+func (a addressesByPreference) Less(i, j int) bool {
+	addr1 := a.addrs[i]
+	addr2 := a.addrs[j]
+	order1 := a.pref.sortOrder(addr1)
+	order2 := a.pref.sortOrder(addr2)
 	if order1 == order2 {
 		return addr1.Value < addr2.Value
 	}
@@ -617,9 +693,16 @@ func (a addressesPreferringIPv4Slice) Less(i, j int) bool {
 }
 
 // SortAddresses sorts the given Address slice according to the sortOrder of
-// each address. See Address.sortOrder() for more info.
+// each address, preferring IPv4 addresses. See AddressPreference.sortOrder()
+// for more info.
 func SortAddresses(addrs []Address) {
-	sort.Sort(addressesPreferringIPv4Slice(addrs))
+	PreferIPv4.SortAddresses(addrs)
+}
+
+// SortAddresses sorts the given Address slice, favouring addresses of
+// the preferred family within each scope.
+func (p AddressPreference) SortAddresses(addrs []Address) {
+	sort.Sort(addressesByPreference{addrs, p})
 }
 
 // DecimalToIPv4 converts a decimal to the dotted quad IP address format.
@@ -734,6 +734,70 @@ func (*AddressSuite) TestSortAddresses(c *gc.C) {
 	))
 }
 
+func (*AddressSuite) TestSortAddressesPreferringIPv6(c *gc.C) {
+	addrs := network.NewAddresses(
+		"127.0.0.1",
+		"::1",
+		"fc00::1",
+		"2001:db8::1",
+		"8.8.8.8",
+		"172.16.0.1",
+		"example.com",
+	)
+	network.PreferIPv6.SortAddresses(addrs)
+	c.Assert(addrs, jc.DeepEquals, network.NewAddresses(
+		"2001:db8::1",
+		"8.8.8.8",
+		"example.com",
+		"fc00::1",
+		"172.16.0.1",
+		"::1",
+		"127.0.0.1",
+	))
+}
+
+func (*AddressSuite) TestSelectPreferringIPv6(c *gc.C) {
+	addrs := network.NewAddresses(
+		"8.8.8.8",
+		"2001:db8::1",
+		"10.0.0.1",
+		"fc00::1",
+	)
+	public, ok := network.PreferIPv6.SelectPublicAddress(addrs)
+	c.Check(ok, jc.IsTrue)
+	c.Check(public.Value, gc.Equals, "2001:db8::1")
+	internal, ok := network.PreferIPv6.SelectInternalAddress(addrs, false)
+	c.Check(ok, jc.IsTrue)
+	c.Check(internal.Value, gc.Equals, "fc00::1")
+
+	// IPv4 is still used when there is no IPv6 address of the scope.
+	public, ok = network.PreferIPv6.SelectPublicAddress(addrs[:1])
+	c.Check(ok, jc.IsTrue)
+	c.Check(public.Value, gc.Equals, "8.8.8.8")
+
+	// The package-level functions keep preferring IPv4.
+	public, ok = network.SelectPublicAddress(addrs)
+	c.Check(ok, jc.IsTrue)
+	c.Check(public.Value, gc.Equals, "8.8.8.8")
+}
+
+func (*AddressSuite) TestParseAddressPreference(c *gc.C) {
+	for _, t := range []struct {
+		value    string
+		expected network.AddressPreference
+	}{
+		{"", network.PreferIPv4},
+		{"ipv4", network.PreferIPv4},
+		{"ipv6", network.PreferIPv6},
+	} {
+		pref, err := network.ParseAddressPreference(t.value)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(pref, gc.Equals, t.expected)
+	}
+	_, err := network.ParseAddressPreference("ipx")
+	c.Assert(err, gc.ErrorMatches, `address preference "ipx" not valid`)
+}
+
 func (*AddressSuite) TestIPv4ToDecimal(c *gc.C) {
 	zeroIP, err := network.IPv4ToDecimal(net.ParseIP("0.0.0.0"))
 	c.Assert(err, jc.ErrorIsNil)
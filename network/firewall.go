@@ -24,6 +24,18 @@ type IngressRule struct {
 	SourceCIDRs []string
 }
 
+// DefaultIngressCIDRs returns the source CIDRs from which ports may be
+// accessed when no restriction is given: all IPv4 addresses.
+func DefaultIngressCIDRs() []string {
+	return []string{"0.0.0.0/0"}
+}
+
+// DefaultIPv6IngressCIDR is the source CIDR from which ports may be
+// accessed over IPv6 when no restriction is given. It is only used for
+// models that prefer IPv6 addresses, as not all providers support IPv6
+// ingress rules.
+const DefaultIPv6IngressCIDR = "::/0"
+
 // NewIngressRule returns an IngressRule for the specified port
 // range. If no explicit source ranges are specified, there is no
 // restriction from where incoming traffic originates.
@@ -108,3 +120,106 @@ func (p IngressRuleSlice) Less(i, j int) bool {
 func SortIngressRules(IngressRules []IngressRule) {
 	sort.Sort(IngressRuleSlice(IngressRules))
 }
+
+// EgressRule represents a range of ports to which outgoing packets
+// are allowed to a destination.
+type EgressRule struct {
+	// PortRange is the range of ports to which outgoing
+	// packets are allowed.
+	PortRange
+
+	// DestinationCIDR is the IP address block, expressed in CIDR
+	// format, to which outgoing packets are allowed.
+	DestinationCIDR string
+}
+
+// NewEgressRule returns an EgressRule allowing outgoing packets to
+// the specified port range of the specified destination CIDR.
+func NewEgressRule(protocol string, from, to int, destinationCIDR string) (EgressRule, error) {
+	rule := EgressRule{
+		PortRange: PortRange{
+			Protocol: strings.ToLower(protocol),
+			FromPort: from,
+			ToPort:   to,
+		},
+		DestinationCIDR: destinationCIDR,
+	}
+	if err := rule.Validate(); err != nil {
+		return EgressRule{}, errors.Trace(err)
+	}
+	return rule, nil
+}
+
+// MustNewEgressRule returns an EgressRule allowing outgoing packets to
+// the specified port range of the specified destination CIDR.
+// The method will panic if there is an error.
+func MustNewEgressRule(protocol string, from, to int, destinationCIDR string) EgressRule {
+	rule, err := NewEgressRule(protocol, from, to, destinationCIDR)
+	if err != nil {
+		panic(err)
+	}
+	return rule
+}
+
+// ParseEgressRule builds an EgressRule from the provided string, of
+// the form "<cidr>:<port-range>". The port range is parsed with
+// ParsePortRange. Example strings: "10.0.0.0/8:443",
+// "192.168.1.0/24:8000-8080/udp", "2001:db8::/32:53/udp".
+func ParseEgressRule(inRule string) (EgressRule, error) {
+	sep := strings.LastIndex(inRule, ":")
+	if sep == -1 {
+		return EgressRule{}, errors.Errorf("invalid egress rule %q, expected <cidr>:<port-range>", inRule)
+	}
+	portRange, err := ParsePortRange(inRule[sep+1:])
+	if err != nil {
+		return EgressRule{}, errors.Trace(err)
+	}
+	rule := EgressRule{
+		PortRange:       portRange,
+		DestinationCIDR: inRule[:sep],
+	}
+	if err := rule.Validate(); err != nil {
+		return EgressRule{}, errors.Trace(err)
+	}
+	return rule, nil
+}
+
+// Validate returns an error if the rule's port range or destination
+// CIDR is not valid.
+func (r EgressRule) Validate() error {
+	if err := r.PortRange.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if _, _, err := net.ParseCIDR(r.DestinationCIDR); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// String is the string representation of EgressRule, as accepted
+// by ParseEgressRule.
+func (r EgressRule) String() string {
+	return r.DestinationCIDR + ":" + r.PortRange.String()
+}
+
+// GoString is used to print values passed as an operand to a %#v format.
+func (r EgressRule) GoString() string {
+	return r.String()
+}
+
+type egressRuleSlice []EgressRule
+
+func (p egressRuleSlice) Len() int      { return len(p) }
+func (p egressRuleSlice) Swap(i, j int) { p[i], p[j] = p[j], p[i] }
+func (p egressRuleSlice) Less(i, j int) bool {
+	if p[i].DestinationCIDR != p[j].DestinationCIDR {
+		return p[i].DestinationCIDR < p[j].DestinationCIDR
+	}
+	return portRangeSlice{p[i].PortRange, p[j].PortRange}.Less(0, 1)
+}
+
+// SortEgressRules sorts the given rules, first by destination, then
+// by protocol and ports.
+func SortEgressRules(egressRules []EgressRule) {
+	sort.Sort(egressRuleSlice(egressRules))
+}
@@ -26,6 +26,10 @@ func (*FirewallSuite) TestStrings(c *gc.C) {
 	c.Assert(rule.String(), gc.Equals, "80/tcp")
 	c.Assert(rule.GoString(), gc.Equals, "80/tcp")
 
+	rule = network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0", network.DefaultIPv6IngressCIDR)
+	c.Assert(rule.String(), gc.Equals, "80/tcp from 0.0.0.0/0,::/0")
+	c.Assert(rule.GoString(), gc.Equals, "80/tcp from 0.0.0.0/0,::/0")
+
 	rule = network.MustNewIngressRule("tcp", 80, 100)
 	c.Assert(rule.String(), gc.Equals, "80-100/tcp")
 	c.Assert(rule.GoString(), gc.Equals, "80-100/tcp")
@@ -72,3 +76,73 @@ func (*FirewallSuite) TestNewIngressRuleBadCIDR(c *gc.C) {
 	_, err := network.NewIngressRule("tcp", 80, 100, "0.0.0.0/0", "192.168.0/24")
 	c.Assert(err, gc.ErrorMatches, "invalid CIDR address: 192.168.0/24")
 }
+
+func (*FirewallSuite) TestNewEgressRule(c *gc.C) {
+	rule, err := network.NewEgressRule("TCP", 443, 443, "10.0.0.0/8")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rule.Protocol, gc.Equals, "tcp")
+	c.Assert(rule.FromPort, gc.Equals, 443)
+	c.Assert(rule.ToPort, gc.Equals, 443)
+	c.Assert(rule.DestinationCIDR, gc.Equals, "10.0.0.0/8")
+	c.Assert(rule.String(), gc.Equals, "10.0.0.0/8:443/tcp")
+}
+
+func (*FirewallSuite) TestNewEgressRuleBadCIDR(c *gc.C) {
+	_, err := network.NewEgressRule("tcp", 80, 100, "192.168.0/24")
+	c.Assert(err, gc.ErrorMatches, "invalid CIDR address: 192.168.0/24")
+}
+
+func (*FirewallSuite) TestParseEgressRule(c *gc.C) {
+	for i, test := range []struct {
+		in     string
+		expect network.EgressRule
+	}{{
+		in:     "10.0.0.0/8:443",
+		expect: network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+	}, {
+		in:     "192.168.1.0/24:8000-8080/udp",
+		expect: network.MustNewEgressRule("udp", 8000, 8080, "192.168.1.0/24"),
+	}, {
+		in:     "2001:db8::/32:53/udp",
+		expect: network.MustNewEgressRule("udp", 53, 53, "2001:db8::/32"),
+	}} {
+		c.Logf("test %d: %s", i, test.in)
+		rule, err := network.ParseEgressRule(test.in)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(rule, jc.DeepEquals, test.expect)
+	}
+}
+
+func (*FirewallSuite) TestParseEgressRuleInvalid(c *gc.C) {
+	for i, test := range []struct {
+		in  string
+		err string
+	}{{
+		in:  "443/tcp",
+		err: `invalid egress rule "443/tcp", expected <cidr>:<port-range>`,
+	}, {
+		in:  "10.0.0.0:443",
+		err: "invalid CIDR address: 10.0.0.0",
+	}, {
+		in:  "10.0.0.0/8:http",
+		err: `invalid port "http": .*`,
+	}} {
+		c.Logf("test %d: %s", i, test.in)
+		_, err := network.ParseEgressRule(test.in)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (*FirewallSuite) TestSortEgressRules(c *gc.C) {
+	rules := []network.EgressRule{
+		network.MustNewEgressRule("udp", 53, 53, "10.0.0.0/8"),
+		network.MustNewEgressRule("tcp", 443, 443, "192.168.0.0/16"),
+		network.MustNewEgressRule("tcp", 80, 80, "10.0.0.0/8"),
+	}
+	network.SortEgressRules(rules)
+	c.Assert(rules, jc.DeepEquals, []network.EgressRule{
+		network.MustNewEgressRule("tcp", 80, 80, "10.0.0.0/8"),
+		network.MustNewEgressRule("udp", 53, 53, "10.0.0.0/8"),
+		network.MustNewEgressRule("tcp", 443, 443, "192.168.0.0/16"),
+	})
+}
@@ -102,15 +102,18 @@ func HostsWithoutPort(hps []HostPort) []Address {
 	return addrs
 }
 
-type hostPortsPreferringIPv4Slice []HostPort
-
-func (hp hostPortsPreferringIPv4Slice) Len() int      { return len(hp) }
-func (hp hostPortsPreferringIPv4Slice) Swap(i, j int) { hp[i], hp[j] = hp[j], hp[i] }
-func (hp hostPortsPreferringIPv4Slice) Less(i, j int) bool {
-	hp1 := hp[i]
-	hp2 := hp[j]
-	order1 := hp1.sortOrder()
-	order2 := hp2.sortOrder()
+type hostPortsByPreference struct {
+	hps  []HostPort
+	pref AddressPreference
+}
+
+func (hp hostPortsByPreference) Len() int      { return len(hp.hps) }
+func (hp hostPortsByPreference) Swap(i, j int) { hp.hps[i], hp.hps[j] = hp.hps[j], hp.hps[i] }
+func (hp hostPortsByPreference) Less(i, j int) bool {
+	hp1 := hp.hps[i]
+	hp2 := hp.hps[j]
+	order1 := hp.pref.sortOrder(hp1.Address)
+	order2 := hp.pref.sortOrder(hp2.Address)
 	if order1 == order2 {
 		if hp1.Address.Value == hp2.Address.Value {
 			return hp1.Port < hp2.Port
@@ -121,9 +124,16 @@ func (hp hostPortsPreferringIPv4Slice) Less(i, j int) bool {
 }
 
 // SortHostPorts sorts the given HostPort slice according to the sortOrder of
-// each HostPort's embedded Address. See Address.sortOrder() for more info.
+// each HostPort's embedded Address, preferring IPv4 addresses. See
+// AddressPreference.sortOrder() for more info.
 func SortHostPorts(hps []HostPort) {
-	sort.Sort(hostPortsPreferringIPv4Slice(hps))
+	PreferIPv4.SortHostPorts(hps)
+}
+
+// SortHostPorts sorts the given HostPort slice, favouring addresses of
+// the preferred family within each scope.
+func (p AddressPreference) SortHostPorts(hps []HostPort) {
+	sort.Sort(hostPortsByPreference{hps, p})
 }
 
 var netLookupIP = net.LookupIP
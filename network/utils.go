@@ -180,6 +180,28 @@ func SupportsIPv6() bool {
 	return true
 }
 
+// IPv6Only reports whether the given interface addresses include a
+// usable IPv6 address but no usable IPv4 one, where loopback and
+// link-local addresses are not considered usable. Addresses that cannot
+// be parsed are ignored.
+func IPv6Only(addrs []net.Addr) bool {
+	hasIPv6 := false
+	for _, addr := range addrs {
+		ip, _, err := net.ParseCIDR(addr.String())
+		if err != nil {
+			continue
+		}
+		if ip.IsLoopback() || ip.IsLinkLocalUnicast() {
+			continue
+		}
+		if ip.To4() != nil {
+			return false
+		}
+		hasIPv6 = true
+	}
+	return hasIPv6
+}
+
 // SysClassNetRoot is the full Linux SYSFS path containing information about
 // each network interface on the system. Used as argument to
 // ParseInterfaceType().
@@ -139,6 +139,34 @@ func (s *UtilsSuite) TestSupportsIPv6OK(c *gc.C) {
 	c.Check(network.SupportsIPv6(), jc.IsTrue)
 }
 
+func (*UtilsSuite) TestIPv6Only(c *gc.C) {
+	for i, t := range []struct {
+		addrs    []string
+		expected bool
+	}{
+		{nil, false},
+		{[]string{"127.0.0.1/8", "::1/128"}, false},
+		{[]string{"10.0.0.1/24"}, false},
+		{[]string{"10.0.0.1/24", "2001:db8::1/64"}, false},
+		{[]string{"fe80::1/64"}, false},
+		{[]string{"127.0.0.1/8", "169.254.0.1/16", "fe80::1/64", "2001:db8::1/64"}, true},
+		{[]string{"fd00::1/64"}, true},
+		{[]string{"bogus", "2001:db8::1/64"}, true},
+	} {
+		c.Logf("test %d: %v", i, t.addrs)
+		var addrs []net.Addr
+		for _, a := range t.addrs {
+			addrs = append(addrs, fakeAddr(a))
+		}
+		c.Check(network.IPv6Only(addrs), gc.Equals, t.expected)
+	}
+}
+
+type fakeAddr string
+
+func (a fakeAddr) Network() string { return "ip+net" }
+func (a fakeAddr) String() string  { return string(a) }
+
 func (*UtilsSuite) TestParseInterfaceType(c *gc.C) {
 	fakeSysPath := filepath.Join(c.MkDir(), network.SysClassNetPath)
 	err := os.MkdirAll(fakeSysPath, 0700)
@@ -40,6 +40,7 @@ import (
 	"github.com/juju/utils/arch"
 	"github.com/juju/utils/clock"
 	"github.com/juju/utils/series"
+	"github.com/juju/utils/set"
 	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/environschema.v1"
@@ -1412,21 +1413,7 @@ func (e *environ) OpenPorts(rules []network.IngressRule) error {
 	}
 	estate.mu.Lock()
 	defer estate.mu.Unlock()
-	for _, r := range rules {
-		if len(r.SourceCIDRs) == 0 {
-			r.SourceCIDRs = []string{"0.0.0.0/0"}
-		}
-		found := false
-		for _, rule := range estate.globalRules {
-			if r.String() == rule.String() {
-				found = true
-			}
-		}
-		if !found {
-			estate.globalRules = append(estate.globalRules, r)
-		}
-	}
-
+	estate.globalRules = openIngressRules(estate.globalRules, rules)
 	return nil
 }
 
@@ -1440,13 +1427,7 @@ func (e *environ) ClosePorts(rules []network.IngressRule) error {
 	}
 	estate.mu.Lock()
 	defer estate.mu.Unlock()
-	for _, r := range rules {
-		for i, rule := range estate.globalRules {
-			if r.String() == rule.String() {
-				estate.globalRules = estate.globalRules[:i+copy(estate.globalRules[i:], estate.globalRules[i+1:])]
-			}
-		}
-	}
+	estate.globalRules = closeIngressRules(estate.globalRules, rules)
 	return nil
 }
 
@@ -1576,20 +1557,7 @@ func (inst *dummyInstance) OpenPorts(machineId string, rules []network.IngressRu
 		InstanceId: inst.Id(),
 		Rules:      rules,
 	}
-	for _, r := range rules {
-		if len(r.SourceCIDRs) == 0 {
-			r.SourceCIDRs = []string{"0.0.0.0/0"}
-		}
-		found := false
-		for _, rule := range inst.rules {
-			if r.String() == rule.String() {
-				found = true
-			}
-		}
-		if !found {
-			inst.rules = append(inst.rules, r)
-		}
-	}
+	inst.rules = openIngressRules(inst.rules, rules)
 	return nil
 }
 
@@ -1613,13 +1581,7 @@ func (inst *dummyInstance) ClosePorts(machineId string, rules []network.IngressR
 		InstanceId: inst.Id(),
 		Rules:      rules,
 	}
-	for _, r := range rules {
-		for i, rule := range inst.rules {
-			if r.String() == rule.String() {
-				inst.rules = inst.rules[:i+copy(inst.rules[i:], inst.rules[i+1:])]
-			}
-		}
-	}
+	inst.rules = closeIngressRules(inst.rules, rules)
 	return nil
 }
 
@@ -1644,6 +1606,65 @@ func (inst *dummyInstance) IngressRules(machineId string) (rules []network.Ingre
 	return
 }
 
+// openIngressRules returns the existing rules with the source CIDRs
+// of the given rules added. Rules without source CIDRs are taken to
+// allow access from anywhere.
+func openIngressRules(existing, rules []network.IngressRule) []network.IngressRule {
+	sources := ingressRuleSources(existing)
+	for portRange, cidrs := range ingressRuleSources(rules) {
+		if _, ok := sources[portRange]; !ok {
+			sources[portRange] = set.NewStrings()
+		}
+		sources[portRange] = sources[portRange].Union(cidrs)
+	}
+	return ingressRulesFromSources(sources)
+}
+
+// closeIngressRules returns the existing rules with the source CIDRs
+// of the given rules removed. Rules left without any source CIDRs are
+// removed entirely.
+func closeIngressRules(existing, rules []network.IngressRule) []network.IngressRule {
+	sources := ingressRuleSources(existing)
+	for portRange, cidrs := range ingressRuleSources(rules) {
+		if remaining, ok := sources[portRange]; ok {
+			sources[portRange] = remaining.Difference(cidrs)
+		}
+	}
+	return ingressRulesFromSources(sources)
+}
+
+func ingressRuleSources(rules []network.IngressRule) map[network.PortRange]set.Strings {
+	sources := make(map[network.PortRange]set.Strings)
+	for _, rule := range rules {
+		cidrs := rule.SourceCIDRs
+		if len(cidrs) == 0 {
+			cidrs = []string{"0.0.0.0/0"}
+		}
+		if _, ok := sources[rule.PortRange]; !ok {
+			sources[rule.PortRange] = set.NewStrings()
+		}
+		for _, cidr := range cidrs {
+			sources[rule.PortRange].Add(cidr)
+		}
+	}
+	return sources
+}
+
+func ingressRulesFromSources(sources map[network.PortRange]set.Strings) []network.IngressRule {
+	var rules []network.IngressRule
+	for portRange, cidrs := range sources {
+		if cidrs.IsEmpty() {
+			continue
+		}
+		rules = append(rules, network.IngressRule{
+			PortRange:   portRange,
+			SourceCIDRs: cidrs.SortedValues(),
+		})
+	}
+	network.SortIngressRules(rules)
+	return rules
+}
+
 // providerDelay controls the delay before dummy responds.
 // non empty values in JUJU_DUMMY_DELAY will be parsed as
 // time.Durations into this value.
@@ -5,6 +5,7 @@ package ec2
 
 import (
 	"regexp"
+	"strconv"
 	"sync"
 	"time"
 
@@ -60,6 +61,7 @@ const (
 	deviceInUse        = "InvalidDevice.InUse"
 	attachmentNotFound = "InvalidAttachment.NotFound"
 	volumeNotFound     = "InvalidVolume.NotFound"
+	snapshotNotFound   = "InvalidSnapshot.NotFound"
 )
 
 const (
@@ -248,6 +250,9 @@ type ebsVolumeSource struct {
 }
 
 var _ storage.VolumeSource = (*ebsVolumeSource)(nil)
+var _ storage.VolumeImporter = (*ebsVolumeSource)(nil)
+var _ storage.VolumeSnapshotter = (*ebsVolumeSource)(nil)
+var _ storage.VolumeReleaser = (*ebsVolumeSource)(nil)
 
 // parseVolumeOptions uses storage volume parameters to make a struct used to create volumes.
 func parseVolumeOptions(size uint64, attrs map[string]interface{}) (_ ec2.CreateVolume, _ error) {
@@ -343,8 +348,21 @@ func (v *ebsVolumeSource) createVolume(p storage.VolumeParams, instances instanc
 		// because we need to know what its AZ is.
 		return nil, nil, errors.Trace(err)
 	}
-	vol, _ := parseVolumeOptions(p.Size, p.Attributes)
+	size := p.Size
+	if p.SnapshotId != "" {
+		// A volume created from a snapshot must be at least
+		// as large as the snapshot.
+		snapshot, err := describeSnapshot(v.env.ec2, p.SnapshotId)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		if snapshot.Size > size {
+			size = snapshot.Size
+		}
+	}
+	vol, _ := parseVolumeOptions(size, p.Attributes)
 	vol.AvailZone = inst.AvailZone
+	vol.SnapshotId = p.SnapshotId
 	resp, err := v.env.ec2.CreateVolume(vol)
 	if err != nil {
 		return nil, nil, errors.Trace(err)
@@ -441,11 +459,165 @@ func (v *ebsVolumeSource) DescribeVolumes(volIds []string) ([]storage.DescribeVo
 	return results, nil
 }
 
+// ImportVolume is specified on the storage.VolumeImporter interface.
+func (v *ebsVolumeSource) ImportVolume(volumeId string, resourceTags map[string]string) (storage.VolumeInfo, error) {
+	volume, err := describeVolume(v.env.ec2, volumeId)
+	if err != nil {
+		return storage.VolumeInfo{}, errors.Trace(err)
+	}
+	if volume.Status != volumeStatusAvailable {
+		return storage.VolumeInfo{}, errors.Errorf(
+			"cannot import volume %q with status %q", volumeId, volume.Status,
+		)
+	}
+	if err := tagResources(v.env.ec2, resourceTags, volumeId); err != nil {
+		return storage.VolumeInfo{}, errors.Annotate(err, "tagging volume")
+	}
+	return storage.VolumeInfo{
+		VolumeId:   volumeId,
+		Size:       gibToMib(uint64(volume.Size)),
+		Persistent: true,
+	}, nil
+}
+
+// CreateSnapshots is specified on the storage.VolumeSnapshotter interface.
+func (v *ebsVolumeSource) CreateSnapshots(params []storage.SnapshotParams) ([]storage.CreateSnapshotsResult, error) {
+	results := make([]storage.CreateSnapshotsResult, len(params))
+	for i, p := range params {
+		snapshot, err := v.createSnapshot(p)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "creating snapshot of volume %q", p.VolumeId)
+			continue
+		}
+		results[i].Snapshot = snapshot
+	}
+	return results, nil
+}
+
+func (v *ebsVolumeSource) createSnapshot(p storage.SnapshotParams) (*storage.Snapshot, error) {
+	resp, err := v.env.ec2.CreateSnapshot(p.VolumeId, resourceName(p.Volume, v.envName))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	resourceTags := make(map[string]string)
+	for k, v := range p.ResourceTags {
+		resourceTags[k] = v
+	}
+	resourceTags[tagName] = resourceName(p.Volume, v.envName)
+	if err := tagResources(v.env.ec2, resourceTags, resp.Snapshot.Id); err != nil {
+		return nil, errors.Annotate(err, "tagging snapshot")
+	}
+	snapshot, err := ebsSnapshot(resp.Snapshot)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &snapshot, nil
+}
+
+// ListSnapshots is specified on the storage.VolumeSnapshotter interface.
+func (v *ebsVolumeSource) ListSnapshots(volIds []string) ([]storage.ListSnapshotsResult, error) {
+	filter := ec2.NewFilter()
+	filter.Add("volume-id", volIds...)
+	resp, err := v.env.ec2.Snapshots(nil, filter)
+	if err != nil {
+		return nil, errors.Annotate(err, "querying snapshots")
+	}
+	results := make([]storage.ListSnapshotsResult, len(volIds))
+	byVolumeId := make(map[string][]storage.Snapshot)
+	for _, ebsSnap := range resp.Snapshots {
+		snapshot, err := ebsSnapshot(ebsSnap)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		byVolumeId[snapshot.VolumeId] = append(byVolumeId[snapshot.VolumeId], snapshot)
+	}
+	for i, volId := range volIds {
+		results[i].Snapshots = byVolumeId[volId]
+	}
+	return results, nil
+}
+
+// DeleteSnapshots is specified on the storage.VolumeSnapshotter interface.
+func (v *ebsVolumeSource) DeleteSnapshots(snapshotIds []string) ([]error, error) {
+	results := make([]error, len(snapshotIds))
+	for i, snapshotId := range snapshotIds {
+		if _, err := v.env.ec2.DeleteSnapshots([]string{snapshotId}); err != nil {
+			if ec2ErrCode(err) == snapshotNotFound {
+				continue
+			}
+			results[i] = errors.Annotatef(err, "deleting snapshot %q", snapshotId)
+		}
+	}
+	return results, nil
+}
+
+func describeSnapshot(client *ec2.EC2, snapshotId string) (*storage.Snapshot, error) {
+	resp, err := client.Snapshots([]string{snapshotId}, nil)
+	if err != nil {
+		if ec2ErrCode(err) == snapshotNotFound {
+			return nil, errors.NotFoundf("snapshot %q", snapshotId)
+		}
+		return nil, errors.Annotate(err, "querying snapshot")
+	}
+	if len(resp.Snapshots) == 0 {
+		return nil, errors.NotFoundf("snapshot %q", snapshotId)
+	} else if len(resp.Snapshots) != 1 {
+		return nil, errors.Errorf("expected one snapshot, got %d", len(resp.Snapshots))
+	}
+	snapshot, err := ebsSnapshot(resp.Snapshots[0])
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &snapshot, nil
+}
+
+// ebsSnapshot converts an EC2 snapshot to a storage.Snapshot.
+func ebsSnapshot(snapshot ec2.Snapshot) (storage.Snapshot, error) {
+	sizeInGib, err := strconv.ParseUint(snapshot.VolumeSize, 10, 64)
+	if err != nil {
+		return storage.Snapshot{}, errors.Annotatef(err, "parsing size of snapshot %q", snapshot.Id)
+	}
+	var created time.Time
+	if snapshot.StartTime != "" {
+		created, err = time.Parse(time.RFC3339, snapshot.StartTime)
+		if err != nil {
+			return storage.Snapshot{}, errors.Annotatef(err, "parsing start time of snapshot %q", snapshot.Id)
+		}
+	}
+	return storage.Snapshot{
+		SnapshotId: snapshot.Id,
+		VolumeId:   snapshot.VolumeId,
+		Size:       gibToMib(sizeInGib),
+		Created:    created.UTC(),
+	}, nil
+}
+
 // DestroyVolumes is specified on the storage.VolumeSource interface.
 func (v *ebsVolumeSource) DestroyVolumes(volIds []string) ([]error, error) {
 	return destroyVolumes(v.env.ec2, volIds), nil
 }
 
+// ReleaseVolumes is specified on the storage.VolumeReleaser interface.
+//
+// The model and controller tags are cleared, so that the volumes are
+// neither listed by, nor destroyed along with, the model or controller.
+// The storage instance tag is left as it was, and the model that the
+// volumes were released from is recorded.
+func (v *ebsVolumeSource) ReleaseVolumes(volIds []string) ([]error, error) {
+	releaseTags := map[string]string{
+		tags.JujuModel:             "",
+		tags.JujuController:        "",
+		tags.JujuReleasedFromModel: v.modelUUID,
+	}
+	results := make([]error, len(volIds))
+	for i, volumeId := range volIds {
+		if err := tagResources(v.env.ec2, releaseTags, volumeId); err != nil {
+			results[i] = errors.Annotatef(err, "releasing volume %q", volumeId)
+		}
+	}
+	return results, nil
+}
+
 func destroyVolumes(client *ec2.EC2, volIds []string) []error {
 	var wg sync.WaitGroup
 	wg.Add(len(volIds))
@@ -418,6 +418,49 @@ func (s *ebsSuite) TestDescribeVolumesNotFound(c *gc.C) {
 	c.Assert(vols[0].Error, gc.ErrorMatches, "vol-42 not found")
 }
 
+func (s *ebsSuite) TestImportVolume(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	s.assertCreateVolumes(c, vs, "")
+
+	c.Assert(vs, gc.Implements, new(storage.VolumeImporter))
+	info, err := vs.(storage.VolumeImporter).ImportVolume("vol-2", map[string]string{
+		"juju-model-uuid": "foo",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, storage.VolumeInfo{
+		Size:       30720,
+		VolumeId:   "vol-2",
+		Persistent: true,
+	})
+
+	ec2Client := ec2.StorageEC2(vs)
+	ec2Vols, err := ec2Client.Volumes([]string{"vol-2"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ec2Vols.Volumes, gc.HasLen, 1)
+	c.Assert(ec2Vols.Volumes[0].Tags, jc.SameContents, []awsec2.Tag{
+		{"Name", "juju-sample-volume-2"},
+		{"abc", "123"},
+		{"juju-model-uuid", "foo"},
+	})
+}
+
+func (s *ebsSuite) TestImportVolumeInUse(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	params := s.setupAttachVolumesTest(c, vs, ec2test.Running)
+	_, err := vs.AttachVolumes(params)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = vs.(storage.VolumeImporter).ImportVolume("vol-0", nil)
+	c.Assert(err, gc.ErrorMatches, `cannot import volume "vol-0" with status "in-use"`)
+}
+
+func (s *ebsSuite) TestImportVolumeNotFound(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	_, err := vs.(storage.VolumeImporter).ImportVolume("vol-42", nil)
+	c.Assert(err, gc.ErrorMatches, "vol-42 not found")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func (s *ebsSuite) TestListVolumes(c *gc.C) {
 	vs := s.volumeSource(c, nil)
 	s.assertCreateVolumes(c, vs, "")
@@ -429,6 +472,34 @@ func (s *ebsSuite) TestListVolumes(c *gc.C) {
 	c.Assert(volIds, jc.SameContents, []string{"vol-0"})
 }
 
+func (s *ebsSuite) TestReleaseVolumes(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	s.assertCreateVolumes(c, vs, "")
+
+	c.Assert(vs, gc.Implements, new(storage.VolumeReleaser))
+	errs, err := vs.(storage.VolumeReleaser).ReleaseVolumes([]string{"vol-0"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, jc.DeepEquals, []error{nil})
+
+	// The released volume is no longer listed,
+	// so it will not be destroyed with the model.
+	volIds, err := vs.ListVolumes()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volIds, gc.HasLen, 0)
+
+	ec2Client := ec2.StorageEC2(vs)
+	ec2Vols, err := ec2Client.Volumes([]string{"vol-0"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ec2Vols.Volumes, gc.HasLen, 1)
+	tags := make(map[string]string)
+	for _, tag := range ec2Vols.Volumes[0].Tags {
+		tags[tag.Key] = tag.Value
+	}
+	c.Assert(tags["juju-model-uuid"], gc.Equals, "")
+	c.Assert(tags["juju-controller-uuid"], gc.Equals, "")
+	c.Assert(tags["juju-released-from-model-uuid"], gc.Equals, "deadbeef-0bad-400d-8000-4b1d0d06f00d")
+}
+
 func (s *ebsSuite) TestListVolumesIgnoresRootDisks(c *gc.C) {
 	s.srv.ec2srv.SetCreateRootDisks(true)
 	s.srv.ec2srv.NewInstances(1, "m1.medium", imageId, ec2test.Pending, nil)
@@ -1164,20 +1164,31 @@ func (e *environ) allModelVolumes(includeRootDisks bool) ([]string, error) {
 	return listVolumes(e.ec2, filter, includeRootDisks)
 }
 
+// rulesToIPPerms maps ingress rules to EC2 IP permissions. The EC2 API
+// client only supports IPv4 source ranges, so IPv6 source CIDRs are
+// skipped, along with any rule that has no IPv4 source CIDRs.
 func rulesToIPPerms(rules []network.IngressRule) []ec2.IPPerm {
-	ipPerms := make([]ec2.IPPerm, len(rules))
-	for i, r := range rules {
-		ipPerms[i] = ec2.IPPerm{
+	ipPerms := make([]ec2.IPPerm, 0, len(rules))
+	for _, r := range rules {
+		ipPerm := ec2.IPPerm{
 			Protocol: r.Protocol,
 			FromPort: r.FromPort,
 			ToPort:   r.ToPort,
 		}
 		if len(r.SourceCIDRs) == 0 {
-			ipPerms[i].SourceIPs = []string{defaultRouteCIDRBlock}
-		} else {
-			ipPerms[i].SourceIPs = make([]string, len(r.SourceCIDRs))
-			copy(ipPerms[i].SourceIPs, r.SourceCIDRs)
+			ipPerm.SourceIPs = []string{defaultRouteCIDRBlock}
+		}
+		for _, cidr := range r.SourceCIDRs {
+			if ip, _, err := net.ParseCIDR(cidr); err == nil && ip.To4() == nil {
+				logger.Warningf("ignoring IPv6 source %q of ingress rule %v: not supported", cidr, r.PortRange)
+				continue
+			}
+			ipPerm.SourceIPs = append(ipPerm.SourceIPs, cidr)
+		}
+		if len(ipPerm.SourceIPs) == 0 {
+			continue
 		}
+		ipPerms = append(ipPerms, ipPerm)
 	}
 	return ipPerms
 }
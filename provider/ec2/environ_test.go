@@ -157,6 +157,18 @@ func (*Suite) TestPortsToIPPerms(c *gc.C) {
 			ToPort:    82,
 			SourceIPs: []string{"192.168.1.0/24", "0.0.0.0/0"},
 		}},
+	}, {
+		about: "IPv6 source ranges",
+		rules: []network.IngressRule{
+			network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0", "::/0"),
+			network.MustNewIngressRule("tcp", 443, 443, "2001:db8::/32"),
+		},
+		expected: []amzec2.IPPerm{{
+			Protocol:  "tcp",
+			FromPort:  80,
+			ToPort:    80,
+			SourceIPs: []string{"0.0.0.0/0"},
+		}},
 	}}
 
 	for i, t := range testCases {
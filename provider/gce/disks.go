@@ -70,6 +70,8 @@ type volumeSource struct {
 	modelUUID string
 }
 
+var _ storage.VolumeSnapshotter = (*volumeSource)(nil)
+
 func (g *storageProvider) VolumeSource(cfg *storage.Config) (storage.VolumeSource, error) {
 	environConfig := g.env.Config()
 	source := &volumeSource{
@@ -198,13 +200,26 @@ func (v *volumeSource) createOneVolume(p storage.VolumeParams, instances instanc
 	if err != nil {
 		return nil, nil, errors.Annotate(err, "cannot create a new volume name")
 	}
+	size := p.Size
+	if p.SnapshotId != "" {
+		// A disk created from a snapshot must be at least
+		// as large as the snapshot's source disk.
+		snapshot, err := v.gce.Snapshot(p.SnapshotId)
+		if err != nil {
+			return nil, nil, errors.Annotatef(err, "cannot get snapshot %q", p.SnapshotId)
+		}
+		if snapshot.Size > size {
+			size = snapshot.Size
+		}
+	}
 	// TODO(perrito666) the volumeName is arbitrary and it was crafted this
 	// way to help solve the need to have zone all over the place.
 	disk := google.DiskSpec{
-		SizeHintGB:         mibToGib(p.Size),
+		SizeHintGB:         mibToGib(size),
 		Name:               volumeName,
 		PersistentDiskType: persistentType,
 		Description:        v.modelUUID,
+		SourceSnapshot:     p.SnapshotId,
 	}
 
 	gceDisks, err := v.gce.CreateDisks(zone, []google.DiskSpec{disk})
@@ -415,3 +430,73 @@ func (v *volumeSource) detachOneVolume(attachParam storage.VolumeAttachmentParam
 	}
 	return v.gce.DetachDisk(zone, string(instId), volumeName)
 }
+
+func (v *volumeSource) CreateSnapshots(params []storage.SnapshotParams) ([]storage.CreateSnapshotsResult, error) {
+	results := make([]storage.CreateSnapshotsResult, len(params))
+	for i, p := range params {
+		snapshot, err := v.createOneSnapshot(p)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "cannot create snapshot of %q", p.VolumeId)
+			continue
+		}
+		results[i].Snapshot = snapshot
+	}
+	return results, nil
+}
+
+func (v *volumeSource) createOneSnapshot(p storage.SnapshotParams) (*storage.Snapshot, error) {
+	zone, _, err := parseVolumeId(p.VolumeId)
+	if err != nil {
+		return nil, errors.Annotate(err, "invalid volume name")
+	}
+	snapshotUUID, err := utils.NewUUID()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot generate uuid to name the snapshot")
+	}
+	// Snapshot names must start with a letter, so the UUID
+	// cannot be used on its own.
+	snapshotName := "snap-" + snapshotUUID.String()
+	snapshot, err := v.gce.CreateSnapshot(zone, p.VolumeId, snapshotName, v.modelUUID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := gceToJujuSnapshot(snapshot)
+	return &result, nil
+}
+
+func (v *volumeSource) ListSnapshots(volNames []string) ([]storage.ListSnapshotsResult, error) {
+	snapshots, err := v.gce.Snapshots()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot list snapshots")
+	}
+	byVolumeName := make(map[string][]storage.Snapshot)
+	for _, snapshot := range snapshots {
+		byVolumeName[snapshot.SourceDisk] = append(
+			byVolumeName[snapshot.SourceDisk], gceToJujuSnapshot(snapshot),
+		)
+	}
+	results := make([]storage.ListSnapshotsResult, len(volNames))
+	for i, volName := range volNames {
+		results[i].Snapshots = byVolumeName[volName]
+	}
+	return results, nil
+}
+
+func (v *volumeSource) DeleteSnapshots(snapshotNames []string) ([]error, error) {
+	results := make([]error, len(snapshotNames))
+	for i, snapshotName := range snapshotNames {
+		if err := v.gce.RemoveSnapshot(snapshotName); err != nil {
+			results[i] = errors.Annotatef(err, "cannot delete snapshot %q", snapshotName)
+		}
+	}
+	return results, nil
+}
+
+func gceToJujuSnapshot(snapshot *google.Snapshot) storage.Snapshot {
+	return storage.Snapshot{
+		SnapshotId: snapshot.Name,
+		VolumeId:   snapshot.SourceDisk,
+		Size:       snapshot.Size,
+		Created:    snapshot.Created,
+	}
+}
@@ -302,3 +302,93 @@ func (s *volumeSourceSuite) TestDetachVolumes(c *gc.C) {
 	c.Assert(call[0].InstanceId, gc.Equals, string(s.instId))
 	c.Assert(call[0].VolumeName, gc.Equals, volName)
 }
+
+func (s *volumeSourceSuite) TestCreateVolumesFromSnapshot(c *gc.C) {
+	s.FakeConn.Insts = []google.Instance{*s.BaseInstance}
+	s.FakeConn.GoogleDisks = []*google.Disk{s.BaseDisk}
+	s.FakeConn.GoogleDisk = s.BaseDisk
+	s.FakeConn.GoogleSnapshot = &google.Snapshot{
+		Name:       "snap-0",
+		SourceDisk: s.BaseDisk.Name,
+		Size:       20 * 1024,
+	}
+	s.FakeConn.AttachedDisk = &google.AttachedDisk{
+		VolumeName: s.BaseDisk.Name,
+		DeviceName: "home-zone-1234567",
+		Mode:       "READ_WRITE",
+	}
+	s.params[0].SnapshotId = "snap-0"
+	res, err := s.source.CreateVolumes(s.params)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(res, gc.HasLen, 1)
+	c.Assert(res[0].Error, jc.ErrorIsNil)
+
+	// The disk is at least as large as the snapshot.
+	createCalled, call := s.FakeConn.WasCalled("CreateDisks")
+	c.Assert(createCalled, jc.IsTrue)
+	c.Check(call, gc.HasLen, 1)
+	c.Assert(call[0].Disks[0].SourceSnapshot, gc.Equals, "snap-0")
+	c.Assert(call[0].Disks[0].SizeHintGB, gc.Equals, uint64(20))
+}
+
+func (s *volumeSourceSuite) TestCreateSnapshots(c *gc.C) {
+	s.FakeConn.GoogleSnapshot = &google.Snapshot{
+		Name:       "snap-0",
+		SourceDisk: "home-zone--volume-name",
+		Size:       10 * 1024,
+	}
+	snapshotter := s.source.(storage.VolumeSnapshotter)
+	res, err := snapshotter.CreateSnapshots([]storage.SnapshotParams{{
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: "home-zone--volume-name",
+	}, {
+		Volume:   names.NewVolumeTag("1"),
+		VolumeId: "invalid",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res, gc.HasLen, 2)
+	c.Assert(res[0].Error, jc.ErrorIsNil)
+	c.Assert(res[0].Snapshot, jc.DeepEquals, &storage.Snapshot{
+		SnapshotId: "snap-0",
+		VolumeId:   "home-zone--volume-name",
+		Size:       10 * 1024,
+	})
+	c.Assert(res[1].Error, gc.ErrorMatches, `cannot create snapshot of "invalid": invalid volume name: malformed volume id "invalid"`)
+
+	createCalled, call := s.FakeConn.WasCalled("CreateSnapshot")
+	c.Assert(createCalled, jc.IsTrue)
+	c.Check(call, gc.HasLen, 1)
+	c.Assert(call[0].ZoneName, gc.Equals, "home-zone")
+	c.Assert(call[0].VolumeName, gc.Equals, "home-zone--volume-name")
+	c.Assert(call[0].SnapshotName, jc.HasPrefix, "snap-")
+	c.Assert(call[0].Description, gc.Equals, s.Env.Config().UUID())
+}
+
+func (s *volumeSourceSuite) TestListSnapshots(c *gc.C) {
+	s.FakeConn.GoogleSnapshots = []*google.Snapshot{
+		{Name: "snap-0", SourceDisk: "home-zone--a"},
+		{Name: "snap-1", SourceDisk: "home-zone--b"},
+		{Name: "snap-2", SourceDisk: "home-zone--a"},
+	}
+	snapshotter := s.source.(storage.VolumeSnapshotter)
+	res, err := snapshotter.ListSnapshots([]string{"home-zone--a", "home-zone--c"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res, gc.HasLen, 2)
+	c.Assert(res[0].Snapshots, gc.HasLen, 2)
+	c.Assert(res[0].Snapshots[0].SnapshotId, gc.Equals, "snap-0")
+	c.Assert(res[0].Snapshots[1].SnapshotId, gc.Equals, "snap-2")
+	c.Assert(res[1].Snapshots, gc.HasLen, 0)
+}
+
+func (s *volumeSourceSuite) TestDeleteSnapshots(c *gc.C) {
+	snapshotter := s.source.(storage.VolumeSnapshotter)
+	errs, err := snapshotter.DeleteSnapshots([]string{"snap-0"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, gc.HasLen, 1)
+	c.Assert(errs[0], jc.ErrorIsNil)
+
+	removeCalled, call := s.FakeConn.WasCalled("RemoveSnapshot")
+	c.Assert(removeCalled, jc.IsTrue)
+	c.Check(call, gc.HasLen, 1)
+	c.Assert(call[0].SnapshotName, gc.Equals, "snap-0")
+}
@@ -56,6 +56,16 @@ type gceConnection interface {
 	DetachDisk(zone, instanceId, volumeName string) error
 	// InstanceDisks returns a list of the disks attached to the passed instance.
 	InstanceDisks(zone, instanceId string) ([]*google.AttachedDisk, error)
+	// CreateSnapshot will create a snapshot named <snapshotName> of the
+	// <volumeName> disk in <zone>, and return a Snapshot representing it.
+	CreateSnapshot(zone, volumeName, snapshotName, description string) (*google.Snapshot, error)
+	// Snapshots will return a list of the snapshots in the project.
+	Snapshots() ([]*google.Snapshot, error)
+	// Snapshot will return a Snapshot representing the snapshot
+	// identified by the passed <name> or error.
+	Snapshot(name string) (*google.Snapshot, error)
+	// RemoveSnapshot will destroy the snapshot identified by <name>.
+	RemoveSnapshot(name string) error
 	// ListMachineTypes returns a list of machines available in the project and zone provided.
 	ListMachineTypes(zone string) ([]google.MachineType, error)
 }
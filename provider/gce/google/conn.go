@@ -76,6 +76,15 @@ type rawConnectionWrapper interface {
 	InstanceDisks(project, zone, instanceId string) ([]*compute.AttachedDisk, error)
 	// ListMachineTypes returns a list of machines available in the project and zone provided.
 	ListMachineTypes(projectID, zone string) (*compute.MachineTypeList, error)
+	// CreateSnapshot will create a snapshot, matching the specified
+	// spec, of the disk identified by diskId.
+	CreateSnapshot(project, zone, diskId string, spec *compute.Snapshot) error
+	// ListSnapshots returns a list of the snapshots in the project.
+	ListSnapshots(project string) ([]*compute.Snapshot, error)
+	// GetSnapshot will return the snapshot identified by id.
+	GetSnapshot(project, id string) (*compute.Snapshot, error)
+	// RemoveSnapshot will delete the snapshot identified by id.
+	RemoveSnapshot(project, id string) error
 }
 
 // TODO(ericsnow) Add specific error types for common failures
@@ -4,6 +4,8 @@
 package google_test
 
 import (
+	"time"
+
 	jc "github.com/juju/testing/checkers"
 	"google.golang.org/api/compute/v1"
 	gc "gopkg.in/check.v1"
@@ -142,3 +144,53 @@ func (s *connSuite) TestConnectionInstanceDisks(c *gc.C) {
 	c.Check(s.FakeConn.Calls[0].ZoneName, gc.Equals, "home-zone")
 	c.Check(s.FakeConn.Calls[0].InstanceId, gc.Equals, "a-fake-instance")
 }
+
+func (s *connSuite) TestConnectionCreateSnapshot(c *gc.C) {
+	s.FakeConn.Snapshot = &compute.Snapshot{
+		Name:              "snap-0",
+		SourceDisk:        "/projects/spam/zones/home-zone/disks/" + fakeVolName,
+		DiskSizeGb:        10,
+		CreationTimestamp: "2017-05-16T04:35:29.000-07:00",
+	}
+	snapshot, err := s.Conn.CreateSnapshot("home-zone", fakeVolName, "snap-0", "a-model-uuid")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshot.Name, gc.Equals, "snap-0")
+	c.Assert(snapshot.SourceDisk, gc.Equals, fakeVolName)
+	c.Assert(snapshot.Size, gc.Equals, uint64(10*1024))
+	c.Assert(snapshot.Created, gc.Equals, time.Date(2017, 5, 16, 11, 35, 29, 0, time.UTC))
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 2)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "CreateSnapshot")
+	c.Check(s.FakeConn.Calls[0].ProjectID, gc.Equals, "spam")
+	c.Check(s.FakeConn.Calls[0].ZoneName, gc.Equals, "home-zone")
+	c.Check(s.FakeConn.Calls[0].ID, gc.Equals, fakeVolName)
+	c.Check(s.FakeConn.Calls[0].Snapshot, jc.DeepEquals, &compute.Snapshot{
+		Name:        "snap-0",
+		Description: "a-model-uuid",
+	})
+	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "GetSnapshot")
+	c.Check(s.FakeConn.Calls[1].ID, gc.Equals, "snap-0")
+}
+
+func (s *connSuite) TestConnectionSnapshots(c *gc.C) {
+	s.FakeConn.Snapshots = []*compute.Snapshot{{Name: "snap-0"}, {Name: "snap-1"}}
+	snapshots, err := s.Conn.Snapshots()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshots, gc.HasLen, 2)
+	c.Assert(snapshots[0].Name, gc.Equals, "snap-0")
+	c.Assert(snapshots[1].Name, gc.Equals, "snap-1")
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "ListSnapshots")
+	c.Check(s.FakeConn.Calls[0].ProjectID, gc.Equals, "spam")
+}
+
+func (s *connSuite) TestConnectionRemoveSnapshot(c *gc.C) {
+	err := s.Conn.RemoveSnapshot("snap-0")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "RemoveSnapshot")
+	c.Check(s.FakeConn.Calls[0].ProjectID, gc.Equals, "spam")
+	c.Check(s.FakeConn.Calls[0].ID, gc.Equals, "snap-0")
+}
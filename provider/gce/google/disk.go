@@ -4,6 +4,8 @@
 package google
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	jujuos "github.com/juju/utils/os"
 	"github.com/juju/utils/series"
@@ -114,6 +116,10 @@ type DiskSpec struct {
 	// Description was picked because it is not mutable (actually no field is) for disks.
 	// There is a metadata API but it is not supported for disks for the moment.
 	Description string
+	// SourceSnapshot is the name of the snapshot from which the disk
+	// should be created, or empty if the disk should be created empty.
+	// (detached only)
+	SourceSnapshot string
 }
 
 // TooSmall checks the spec's size hint and indicates whether or not
@@ -175,13 +181,17 @@ func (ds *DiskSpec) newDetached() (*compute.Disk, error) {
 	if ds.PersistentDiskType == DiskLocalSSD {
 		return nil, errors.New("cannot create local ssd disks detached")
 	}
-	return &compute.Disk{
+	disk := &compute.Disk{
 		Name:        ds.Name,
 		SizeGb:      int64(ds.SizeGB()),
 		SourceImage: ds.ImageURL,
 		Type:        string(ds.PersistentDiskType),
 		Description: ds.Description,
-	}, nil
+	}
+	if ds.SourceSnapshot != "" {
+		disk.SourceSnapshot = "global/snapshots/" + ds.SourceSnapshot
+	}
+	return disk, nil
 }
 
 // AttachedDisk represents a disk that is attached to an instance.
@@ -228,3 +238,34 @@ func NewDisk(cd *compute.Disk) *Disk {
 	}
 	return d
 }
+
+// Snapshot represents a gce snapshot of a disk.
+type Snapshot struct {
+	// Name is a unique identifier string for each snapshot.
+	Name string
+	// Description holds the description field for a snapshot, we
+	// store env UUID here.
+	Description string
+	// SourceDisk is the name of the disk the snapshot was taken of.
+	SourceDisk string
+	// Size is the size of the source disk, in MiB.
+	Size uint64
+	// Created is the time at which the snapshot was taken.
+	Created time.Time
+	// Status holds the status of the snapshot.
+	Status string
+}
+
+func NewSnapshot(cs *compute.Snapshot) *Snapshot {
+	// The creation timestamp is in RFC3339 format; if it cannot
+	// be parsed, the zero time is used.
+	created, _ := time.Parse(time.RFC3339, cs.CreationTimestamp)
+	return &Snapshot{
+		Name:        cs.Name,
+		Description: cs.Description,
+		SourceDisk:  sourceToVolumeName(cs.SourceDisk),
+		Size:        gibToMib(cs.DiskSizeGb),
+		Created:     created.UTC(),
+		Status:      cs.Status,
+	}
+}
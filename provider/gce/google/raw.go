@@ -254,6 +254,50 @@ func (rc *rawConn) GetDisk(project, zone, id string) (*compute.Disk, error) {
 	return disk, nil
 }
 
+func (rc *rawConn) CreateSnapshot(project, zone, diskId string, spec *compute.Snapshot) error {
+	call := rc.Disks.CreateSnapshot(project, zone, diskId, spec)
+	op, err := call.Do()
+	if err != nil {
+		return errors.Annotatef(err, "could not create snapshot of disk %q", diskId)
+	}
+	return errors.Trace(rc.waitOperation(project, op, attemptsLong))
+}
+
+func (rc *rawConn) ListSnapshots(project string) ([]*compute.Snapshot, error) {
+	call := rc.Snapshots.List(project)
+	var results []*compute.Snapshot
+	for {
+		snapshotList, err := call.Do()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, snapshot := range snapshotList.Items {
+			results = append(results, snapshot)
+		}
+		if snapshotList.NextPageToken == "" {
+			break
+		}
+		call = call.PageToken(snapshotList.NextPageToken)
+	}
+	return results, nil
+}
+
+func (rc *rawConn) GetSnapshot(project, id string) (*compute.Snapshot, error) {
+	snapshot, err := rc.Snapshots.Get(project, id).Do()
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get snapshot %q in project %q", id, project)
+	}
+	return snapshot, nil
+}
+
+func (rc *rawConn) RemoveSnapshot(project, id string) error {
+	op, err := rc.Snapshots.Delete(project, id).Do()
+	if err != nil {
+		return errors.Annotatef(err, "could not delete snapshot %q", id)
+	}
+	return errors.Trace(rc.waitOperation(project, op, attemptsLong))
+}
+
 func (rc *rawConn) AttachDisk(project, zone, instanceId string, disk *compute.AttachedDisk) error {
 	call := rc.Instances.AttachDisk(project, zone, instanceId, disk)
 	_, err := call.Do() // Perhaps return something from the Op
@@ -162,6 +162,7 @@ type fakeCall struct {
 	DeviceName   string
 	ComputeDisk  *compute.Disk
 	Metadata     *compute.Metadata
+	Snapshot     *compute.Snapshot
 }
 
 type fakeConn struct {
@@ -177,6 +178,8 @@ type fakeConn struct {
 	Disks         []*compute.Disk
 	Disk          *compute.Disk
 	AttachedDisks []*compute.AttachedDisk
+	Snapshots     []*compute.Snapshot
+	Snapshot      *compute.Snapshot
 }
 
 func (rc *fakeConn) GetProject(projectID string) (*compute.Project, error) {
@@ -480,3 +483,64 @@ func (rc *fakeConn) SetMetadata(projectID, zone, instanceID string, metadata *co
 	}
 	return err
 }
+
+func (rc *fakeConn) CreateSnapshot(project, zone, diskId string, spec *compute.Snapshot) error {
+	call := fakeCall{
+		FuncName:  "CreateSnapshot",
+		ProjectID: project,
+		ZoneName:  zone,
+		ID:        diskId,
+		Snapshot:  spec,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return err
+}
+
+func (rc *fakeConn) ListSnapshots(project string) ([]*compute.Snapshot, error) {
+	call := fakeCall{
+		FuncName:  "ListSnapshots",
+		ProjectID: project,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return rc.Snapshots, err
+}
+
+func (rc *fakeConn) GetSnapshot(project, id string) (*compute.Snapshot, error) {
+	call := fakeCall{
+		FuncName:  "GetSnapshot",
+		ProjectID: project,
+		ID:        id,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return rc.Snapshot, err
+}
+
+func (rc *fakeConn) RemoveSnapshot(project, id string) error {
+	call := fakeCall{
+		FuncName:  "RemoveSnapshot",
+		ProjectID: project,
+		ID:        id,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return err
+}
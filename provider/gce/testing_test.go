@@ -463,6 +463,8 @@ type fakeConnCall struct {
 	Mode         string
 	Key          string
 	Value        string
+	SnapshotName string
+	Description  string
 }
 
 type fakeConn struct {
@@ -478,6 +480,9 @@ type fakeConn struct {
 	AttachedDisk  *google.AttachedDisk
 	AttachedDisks []*google.AttachedDisk
 
+	GoogleSnapshots []*google.Snapshot
+	GoogleSnapshot  *google.Snapshot
+
 	Err        error
 	FailOnCall int
 }
@@ -641,6 +646,40 @@ func (fc *fakeConn) InstanceDisks(zone, instanceId string) ([]*google.AttachedDi
 	return fc.AttachedDisks, fc.err()
 }
 
+func (fc *fakeConn) CreateSnapshot(zone, volumeName, snapshotName, description string) (*google.Snapshot, error) {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName:     "CreateSnapshot",
+		ZoneName:     zone,
+		VolumeName:   volumeName,
+		SnapshotName: snapshotName,
+		Description:  description,
+	})
+	return fc.GoogleSnapshot, fc.err()
+}
+
+func (fc *fakeConn) Snapshots() ([]*google.Snapshot, error) {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName: "Snapshots",
+	})
+	return fc.GoogleSnapshots, fc.err()
+}
+
+func (fc *fakeConn) Snapshot(name string) (*google.Snapshot, error) {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName:     "Snapshot",
+		SnapshotName: name,
+	})
+	return fc.GoogleSnapshot, fc.err()
+}
+
+func (fc *fakeConn) RemoveSnapshot(name string) error {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName:     "RemoveSnapshot",
+		SnapshotName: name,
+	})
+	return fc.err()
+}
+
 func (fc *fakeConn) WasCalled(funcName string) (bool, []fakeConnCall) {
 	var calls []fakeConnCall
 	called := false
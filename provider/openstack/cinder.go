@@ -12,6 +12,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/utils"
 	"gopkg.in/goose.v1/cinder"
+	gooseerrors "gopkg.in/goose.v1/errors"
 	"gopkg.in/goose.v1/identity"
 	"gopkg.in/goose.v1/nova"
 
@@ -30,6 +31,10 @@ const (
 	volumeStatusDeleting  = "deleting"
 	volumeStatusError     = "error"
 	volumeStatusInUse     = "in-use"
+
+	// cinderTimeFormat is the format of timestamps returned
+	// by the Cinder API, which are in UTC.
+	cinderTimeFormat = "2006-01-02T15:04:05.999999"
 )
 
 // StorageProviderTypes implements storage.ProviderRegistry.
@@ -159,6 +164,7 @@ type cinderVolumeSource struct {
 }
 
 var _ storage.VolumeSource = (*cinderVolumeSource)(nil)
+var _ storage.VolumeSnapshotter = (*cinderVolumeSource)(nil)
 
 // CreateVolumes implements storage.VolumeSource.
 func (s *cinderVolumeSource) CreateVolumes(args []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
@@ -187,6 +193,7 @@ func (s *cinderVolumeSource) createVolume(arg storage.VolumeParams) (*storage.Vo
 		// TODO(axw) use the AZ of the initially attached machine.
 		AvailabilityZone: "",
 		Metadata:         metadata,
+		SnapshotId:       arg.SnapshotId,
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -415,6 +422,77 @@ func waitVolume(
 	return nil, errors.New("timed out")
 }
 
+// CreateSnapshots implements storage.VolumeSnapshotter.
+func (s *cinderVolumeSource) CreateSnapshots(args []storage.SnapshotParams) ([]storage.CreateSnapshotsResult, error) {
+	results := make([]storage.CreateSnapshotsResult, len(args))
+	for i, arg := range args {
+		snapshot, err := s.createSnapshot(arg)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "creating snapshot of volume %q", arg.VolumeId)
+			continue
+		}
+		results[i].Snapshot = snapshot
+	}
+	return results, nil
+}
+
+func (s *cinderVolumeSource) createSnapshot(arg storage.SnapshotParams) (*storage.Snapshot, error) {
+	// Force is required to snapshot volumes that are in use.
+	cinderSnapshot, err := s.storageAdapter.CreateSnapshot(cinder.CreateSnapshotSnapshotParams{
+		VolumeId: arg.VolumeId,
+		Name:     resourceName(s.namespace, s.envName, arg.Volume.String()),
+		Force:    true,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	snapshot := cinderToJujuSnapshot(cinderSnapshot)
+	return &snapshot, nil
+}
+
+// ListSnapshots implements storage.VolumeSnapshotter.
+func (s *cinderVolumeSource) ListSnapshots(volumeIds []string) ([]storage.ListSnapshotsResult, error) {
+	cinderSnapshots, err := s.storageAdapter.GetSnapshotsDetail()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	byVolumeId := make(map[string][]storage.Snapshot)
+	for i := range cinderSnapshots {
+		snapshot := cinderToJujuSnapshot(&cinderSnapshots[i])
+		byVolumeId[snapshot.VolumeId] = append(byVolumeId[snapshot.VolumeId], snapshot)
+	}
+	results := make([]storage.ListSnapshotsResult, len(volumeIds))
+	for i, volumeId := range volumeIds {
+		results[i].Snapshots = byVolumeId[volumeId]
+	}
+	return results, nil
+}
+
+// DeleteSnapshots implements storage.VolumeSnapshotter.
+func (s *cinderVolumeSource) DeleteSnapshots(snapshotIds []string) ([]error, error) {
+	results := make([]error, len(snapshotIds))
+	for i, snapshotId := range snapshotIds {
+		err := s.storageAdapter.DeleteSnapshot(snapshotId)
+		if err != nil && !gooseerrors.IsNotFound(err) {
+			results[i] = errors.Annotatef(err, "deleting snapshot %q", snapshotId)
+		}
+	}
+	return results, nil
+}
+
+func cinderToJujuSnapshot(snapshot *cinder.Snapshot) storage.Snapshot {
+	created, err := time.Parse(cinderTimeFormat, snapshot.CreatedAt)
+	if err != nil {
+		logger.Debugf("parsing creation time of snapshot %q: %v", snapshot.ID, err)
+	}
+	return storage.Snapshot{
+		SnapshotId: snapshot.ID,
+		VolumeId:   snapshot.VolumeID,
+		Size:       uint64(snapshot.Size * 1024),
+		Created:    created.UTC(),
+	}
+}
+
 // DetachVolumes implements storage.VolumeSource.
 func (s *cinderVolumeSource) DetachVolumes(args []storage.VolumeAttachmentParams) ([]error, error) {
 	return detachVolumes(s.storageAdapter, args)
@@ -482,6 +560,9 @@ type OpenstackStorage interface {
 	DetachVolume(serverId, attachmentId string) error
 	ListVolumeAttachments(serverId string) ([]nova.VolumeAttachment, error)
 	SetVolumeMetadata(volumeId string, metadata map[string]string) (map[string]string, error)
+	CreateSnapshot(cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error)
+	GetSnapshotsDetail() ([]cinder.Snapshot, error)
+	DeleteSnapshot(snapshotId string) error
 }
 
 type endpointResolver interface {
@@ -553,3 +634,21 @@ func (ga *openstackStorageAdapter) GetVolume(volumeId string) (*cinder.Volume, e
 func (ga *openstackStorageAdapter) SetVolumeMetadata(volumeId string, metadata map[string]string) (map[string]string, error) {
 	return ga.cinderClient.SetVolumeMetadata(volumeId, metadata)
 }
+
+// CreateSnapshot is part of the OpenstackStorage interface.
+func (ga *openstackStorageAdapter) CreateSnapshot(args cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error) {
+	resp, err := ga.cinderClient.CreateSnapshot(args)
+	if err != nil {
+		return nil, err
+	}
+	return &resp.Snapshot, nil
+}
+
+// GetSnapshotsDetail is part of the OpenstackStorage interface.
+func (ga *openstackStorageAdapter) GetSnapshotsDetail() ([]cinder.Snapshot, error) {
+	resp, err := ga.cinderClient.GetSnapshotsDetail()
+	if err != nil {
+		return nil, err
+	}
+	return resp.Snapshots, nil
+}
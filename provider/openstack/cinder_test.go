@@ -463,6 +463,87 @@ func (s *cinderVolumeSourceSuite) TestCreateVolumeCleanupDestroys(c *gc.C) {
 	c.Assert(numDestroyCalls, gc.Equals, 1)
 }
 
+func (s *cinderVolumeSourceSuite) TestCreateSnapshots(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		createSnapshot: func(args cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error) {
+			if args.VolumeId == "bad" {
+				return nil, errors.New("no snapshot for you")
+			}
+			return &cinder.Snapshot{
+				ID:        "snap-0",
+				VolumeID:  args.VolumeId,
+				Size:      2,
+				CreatedAt: "2017-05-16T04:35:29.000000",
+			}, nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	snapshotter := volSource.(storage.VolumeSnapshotter)
+	results, err := snapshotter.CreateSnapshots([]storage.SnapshotParams{{
+		Volume:   mockVolumeTag,
+		VolumeId: mockVolId,
+	}, {
+		Volume:   mockVolumeTag,
+		VolumeId: "bad",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Snapshot, jc.DeepEquals, &storage.Snapshot{
+		SnapshotId: "snap-0",
+		VolumeId:   mockVolId,
+		Size:       2048,
+		Created:    time.Date(2017, 5, 16, 4, 35, 29, 0, time.UTC),
+	})
+	c.Assert(results[1].Error, gc.ErrorMatches, `creating snapshot of volume "bad": no snapshot for you`)
+	mockAdapter.CheckCall(c, 0, "CreateSnapshot", cinder.CreateSnapshotSnapshotParams{
+		VolumeId: mockVolId,
+		Name:     "juju-testenv-volume-123",
+		Force:    true,
+	})
+}
+
+func (s *cinderVolumeSourceSuite) TestListSnapshots(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		getSnapshotsDetail: func() ([]cinder.Snapshot, error) {
+			return []cinder.Snapshot{
+				{ID: "snap-0", VolumeID: "vol-0", Size: 1},
+				{ID: "snap-1", VolumeID: "vol-1", Size: 1},
+				{ID: "snap-2", VolumeID: "vol-0", Size: 1},
+			}, nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	results, err := volSource.(storage.VolumeSnapshotter).ListSnapshots([]string{"vol-0", "vol-2"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0].Snapshots, gc.HasLen, 2)
+	c.Assert(results[0].Snapshots[0].SnapshotId, gc.Equals, "snap-0")
+	c.Assert(results[0].Snapshots[1].SnapshotId, gc.Equals, "snap-2")
+	c.Assert(results[1].Snapshots, gc.HasLen, 0)
+}
+
+func (s *cinderVolumeSourceSuite) TestDeleteSnapshots(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		deleteSnapshot: func(snapshotId string) error {
+			if snapshotId == "snap-1" {
+				return errors.New("snapshot in use")
+			}
+			return nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	errs, err := volSource.(storage.VolumeSnapshotter).DeleteSnapshots([]string{"snap-0", "snap-1"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, gc.HasLen, 2)
+	c.Assert(errs[0], jc.ErrorIsNil)
+	c.Assert(errs[1], gc.ErrorMatches, `deleting snapshot "snap-1": snapshot in use`)
+	mockAdapter.CheckCalls(c, []gitjujutesting.StubCall{
+		{"DeleteSnapshot", []interface{}{"snap-0"}},
+		{"DeleteSnapshot", []interface{}{"snap-1"}},
+	})
+}
+
 type mockAdapter struct {
 	gitjujutesting.Stub
 	getVolume             func(string) (*cinder.Volume, error)
@@ -474,6 +555,9 @@ type mockAdapter struct {
 	detachVolume          func(string, string) error
 	listVolumeAttachments func(string) ([]nova.VolumeAttachment, error)
 	setVolumeMetadata     func(string, map[string]string) (map[string]string, error)
+	createSnapshot        func(cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error)
+	getSnapshotsDetail    func() ([]cinder.Snapshot, error)
+	deleteSnapshot        func(string) error
 }
 
 func (ma *mockAdapter) GetVolume(volumeId string) (*cinder.Volume, error) {
@@ -543,6 +627,30 @@ func (ma *mockAdapter) SetVolumeMetadata(volumeId string, metadata map[string]st
 	return nil, nil
 }
 
+func (ma *mockAdapter) CreateSnapshot(args cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error) {
+	ma.MethodCall(ma, "CreateSnapshot", args)
+	if ma.createSnapshot != nil {
+		return ma.createSnapshot(args)
+	}
+	return nil, errors.NotImplementedf("CreateSnapshot")
+}
+
+func (ma *mockAdapter) GetSnapshotsDetail() ([]cinder.Snapshot, error) {
+	ma.MethodCall(ma, "GetSnapshotsDetail")
+	if ma.getSnapshotsDetail != nil {
+		return ma.getSnapshotsDetail()
+	}
+	return nil, nil
+}
+
+func (ma *mockAdapter) DeleteSnapshot(snapshotId string) error {
+	ma.MethodCall(ma, "DeleteSnapshot", snapshotId)
+	if ma.deleteSnapshot != nil {
+		return ma.deleteSnapshot(snapshotId)
+	}
+	return nil
+}
+
 type testEndpointResolver struct {
 	authenticated   bool
 	regionEndpoints map[string]identity.ServiceURLs
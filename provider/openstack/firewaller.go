@@ -820,11 +820,12 @@ func (c *neutronFirewaller) closePortsInGroup(nameRegExp string, rules []network
 			if !secGroupMatchesIngressRule(p, rule) {
 				continue
 			}
+			// A rule with several source ranges maps to one group
+			// rule per range, so keep looking for more matches.
 			err := neutronClient.DeleteSecurityGroupRuleV2(p.Id)
 			if err != nil {
 				return errors.Trace(err)
 			}
-			break
 		}
 	}
 	return nil
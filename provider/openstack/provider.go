@@ -7,6 +7,7 @@ package openstack
 
 import (
 	"fmt"
+	"net"
 	"net/url"
 	"strings"
 	"sync"
@@ -1481,6 +1482,11 @@ func rulesToRuleInfo(groupId string, rules []network.IngressRule) []neutron.Rule
 		}
 		for _, sr := range sourceCIDRs {
 			ruleInfo.RemoteIPPrefix = sr
+			// Neutron assumes IPv4 unless told otherwise.
+			ruleInfo.EthernetType = ""
+			if ip, _, err := net.ParseCIDR(sr); err == nil && ip.To4() == nil {
+				ruleInfo.EthernetType = "IPv6"
+			}
 			result = append(result, ruleInfo)
 		}
 	}
@@ -261,6 +261,26 @@ func (*localTests) TestPortsToRuleInfo(c *gc.C) {
 			RemoteIPPrefix: "0.0.0.0/0",
 			ParentGroupId:  groupId,
 		}},
+	}, {
+		about: "IPv6 source range",
+		rules: []network.IngressRule{network.MustNewIngressRule(
+			"tcp", 80, 80, "0.0.0.0/0", "::/0")},
+		expected: []neutron.RuleInfoV2{{
+			Direction:      "ingress",
+			IPProtocol:     "tcp",
+			PortRangeMin:   80,
+			PortRangeMax:   80,
+			RemoteIPPrefix: "0.0.0.0/0",
+			ParentGroupId:  groupId,
+		}, {
+			Direction:      "ingress",
+			IPProtocol:     "tcp",
+			PortRangeMin:   80,
+			PortRangeMax:   80,
+			RemoteIPPrefix: "::/0",
+			EthernetType:   "IPv6",
+			ParentGroupId:  groupId,
+		}},
 	}}
 
 	for i, t := range testCases {
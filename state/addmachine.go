@@ -10,6 +10,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/replicaset"
 	jujutxn "github.com/juju/txn"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
@@ -955,11 +956,19 @@ func (st *State) enableHAIntentions(info *ControllerInfo, placement []string) (*
 		return nil, errors.Errorf("unsupported placement directive %q", s)
 	}
 
+	nonVoting := set.NewStrings(info.NonVotingMachineIds...)
 	for _, mid := range info.MachineIds {
 		m, err := st.Machine(mid)
 		if err != nil {
 			return nil, err
 		}
+		if nonVoting.Contains(mid) {
+			// An operator has chosen that this machine never
+			// votes, so it's neither promoted nor removed, and
+			// doesn't count towards the controllers wanted.
+			intent.maintain = append(intent.maintain, m)
+			continue
+		}
 		available, err := controllerAvailable(m)
 		if err != nil {
 			return nil, err
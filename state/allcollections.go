@@ -56,6 +56,13 @@ func allCollections() collectionSchema {
 			global:         true,
 			rawAccess:      true,
 			explicitCreate: &mgo.CollectionInfo{},
+			// The state index backs PendingTransactionCount. The
+			// collection can be large on existing controllers, so
+			// the index is built without blocking it.
+			indexes: []mgo.Index{{
+				Key:        []string{"s"},
+				Background: true,
+			}},
 		},
 		txnLogC: {
 			// This collection is used by mgo/txn to record the set of documents
@@ -199,6 +206,13 @@ func allCollections() collectionSchema {
 			rawAccess: true,
 		},
 
+		// This collection holds the last health report made by each
+		// controller machine's API server.
+		controllerHealthC: {
+			global:    true,
+			rawAccess: true,
+		},
+
 		// This collection holds the last time the model user connected
 		// to the model.
 		modelUserLastConnectionC: {
@@ -453,6 +467,7 @@ const (
 	cloudCredentialsC        = "cloudCredentials"
 	constraintsC             = "constraints"
 	containerRefsC           = "containerRefs"
+	controllerHealthC        = "controllerHealth"
 	controllersC             = "controllers"
 	controllerUsersC         = "controllerusers"
 	filesystemAttachmentsC   = "filesystemAttachments"
@@ -6,6 +6,7 @@ package state
 import (
 	stderrors "errors"
 	"fmt"
+	"net"
 	"sort"
 	"strconv"
 	"strings"
@@ -14,6 +15,7 @@ import (
 	jujutxn "github.com/juju/txn"
 	"github.com/juju/utils/featureflag"
 	"github.com/juju/utils/series"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/charm.v6-unstable"
 	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
 	"gopkg.in/juju/names.v2"
@@ -24,6 +26,7 @@ import (
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/feature"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/status"
 )
 
@@ -52,6 +55,26 @@ type applicationDoc struct {
 	MinUnits             int        `bson:"minunits"`
 	TxnRevno             int64      `bson:"txn-revno"`
 	MetricCredentials    []byte     `bson:"metric-credentials"`
+
+	// HookTimeouts holds the maximum durations the application's
+	// hooks may run for. It is nil if no timeouts have been set.
+	HookTimeouts *HookTimeouts `bson:"hook-timeouts,omitempty"`
+
+	// ExposedCIDRs and ExposedSpaces restrict the sources from which
+	// the application's open ports may be accessed when it is exposed.
+	// If both are empty, an exposed application may be accessed from
+	// anywhere.
+	ExposedCIDRs  []string `bson:"exposed-cidrs,omitempty"`
+	ExposedSpaces []string `bson:"exposed-spaces,omitempty"`
+
+	// EgressRules holds the destinations, in the string form accepted
+	// by network.ParseEgressRule, to which the application's units
+	// are allowed to connect. If empty, egress is not restricted.
+	EgressRules []string `bson:"egress-rules,omitempty"`
+
+	// RollingUpgrade describes the rolling charm upgrade in progress.
+	// It is nil if no rolling upgrade is in progress.
+	RollingUpgrade *RollingUpgrade `bson:"rollingupgrade,omitempty"`
 }
 
 func newApplication(st *State, doc *applicationDoc) *Application {
@@ -132,6 +155,18 @@ var errRefresh = stderrors.New("state seems inconsistent, refresh and try again"
 // some point; if the application has no units, and no relation involving the
 // application has any units in scope, they are all removed immediately.
 func (a *Application) Destroy() (err error) {
+	return a.destroy(false)
+}
+
+// DestroyReleasingStorage behaves like Destroy, except that the cloud
+// volumes of the application's storage are released rather than
+// destroyed: they are detached and left intact in the cloud, so that
+// they may later be imported into another model.
+func (a *Application) DestroyReleasingStorage() (err error) {
+	return a.destroy(true)
+}
+
+func (a *Application) destroy(releaseStorage bool) (err error) {
 	defer errors.DeferredAnnotatef(&err, "cannot destroy application %q", a)
 	defer func() {
 		if err == nil {
@@ -153,6 +188,15 @@ func (a *Application) Destroy() (err error) {
 		case errAlreadyDying:
 			return nil, jujutxn.ErrNoOperations
 		case nil:
+			if releaseStorage {
+				releaseOps, err := releaseApplicationVolumesOps(a.st, app)
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				// The volumes are marked before they may be
+				// destroyed by the remaining operations.
+				ops = append(releaseOps, ops...)
+			}
 			return ops, nil
 		default:
 			return nil, err
@@ -291,6 +335,15 @@ func (a *Application) removeOps(asserts bson.D) ([]txn.Op, error) {
 	}
 	ops = append(ops, charmOps...)
 	ops = append(ops, finalAppCharmRemoveOps(name, curl)...)
+	if r := a.doc.RollingUpgrade; r != nil {
+		// The rolling upgrade holds a reference to the previous charm.
+		prevCharmOps, err := appCharmDecRefOps(a.st, name, r.PreviousCharmURL)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, prevCharmOps...)
+		ops = append(ops, finalAppCharmRemoveOps(name, r.PreviousCharmURL)...)
+	}
 
 	globalKey := a.globalKey()
 	ops = append(ops,
@@ -311,32 +364,136 @@ func (a *Application) IsExposed() bool {
 	return a.doc.Exposed
 }
 
-// SetExposed marks the application as exposed.
-// See ClearExposed and IsExposed.
+// ExposedCIDRs returns the CIDRs that the application's ingress is
+// restricted to when it is exposed. See SetExposedTo.
+func (a *Application) ExposedCIDRs() []string {
+	return a.doc.ExposedCIDRs
+}
+
+// ExposedSpaces returns the names of the spaces that the application's
+// ingress is restricted to when it is exposed. See SetExposedTo.
+func (a *Application) ExposedSpaces() []string {
+	return a.doc.ExposedSpaces
+}
+
+// SetExposed marks the application as exposed, allowing access from
+// anywhere. See ClearExposed and IsExposed.
 func (a *Application) SetExposed() error {
-	return a.setExposed(true)
+	return a.setExposed(true, nil, nil)
 }
 
-// ClearExposed removes the exposed flag from the application.
-// See SetExposed and IsExposed.
+// SetExposedTo marks the application as exposed, allowing access only
+// from the specified CIDRs and the subnets of the specified spaces. If
+// both are empty, access is allowed from anywhere.
+// See ClearExposed and IsExposed.
+func (a *Application) SetExposedTo(cidrs, spaces []string) error {
+	for _, cidr := range cidrs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return errors.NotValidf("CIDR %q", cidr)
+		}
+	}
+	for _, space := range spaces {
+		if !names.IsValidSpace(space) {
+			return errors.NotValidf("space name %q", space)
+		}
+	}
+	return a.setExposed(true, cidrs, spaces)
+}
+
+// ClearExposed removes the exposed flag, and any ingress restrictions,
+// from the application. See SetExposed and IsExposed.
 func (a *Application) ClearExposed() error {
-	return a.setExposed(false)
+	return a.setExposed(false, nil, nil)
 }
 
-func (a *Application) setExposed(exposed bool) (err error) {
+func (a *Application) setExposed(exposed bool, cidrs, spaces []string) (err error) {
+	var update bson.D
+	if len(cidrs) > 0 || len(spaces) > 0 {
+		update = bson.D{{"$set", bson.D{
+			{"exposed", exposed},
+			{"exposed-cidrs", cidrs},
+			{"exposed-spaces", spaces},
+		}}}
+	} else {
+		update = bson.D{
+			{"$set", bson.D{{"exposed", exposed}}},
+			{"$unset", bson.D{{"exposed-cidrs", nil}, {"exposed-spaces", nil}}},
+		}
+	}
 	ops := []txn.Op{{
 		C:      applicationsC,
 		Id:     a.doc.DocID,
 		Assert: isAliveDoc,
-		Update: bson.D{{"$set", bson.D{{"exposed", exposed}}}},
+		Update: update,
 	}}
+	for _, space := range spaces {
+		ops = append(ops, txn.Op{
+			C:      spacesC,
+			Id:     space,
+			Assert: txn.DocExists,
+		})
+	}
 	if err := a.st.runTransaction(ops); err != nil {
+		if err == txn.ErrAborted {
+			if err := a.checkSpacesExist(spaces); err != nil {
+				return errors.Annotatef(err, "cannot set exposed flag for application %q", a)
+			}
+		}
 		return errors.Errorf("cannot set exposed flag for application %q to %v: %v", a, exposed, onAbort(err, errNotAlive))
 	}
 	a.doc.Exposed = exposed
+	a.doc.ExposedCIDRs = cidrs
+	a.doc.ExposedSpaces = spaces
 	return nil
 }
 
+func (a *Application) checkSpacesExist(spaces []string) error {
+	for _, space := range spaces {
+		if _, err := a.st.Space(space); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// ExposedIngressCIDRs returns the source CIDRs from which the open ports
+// of the application may be accessed, or nil if it is not exposed. The
+// CIDRs of the subnets in the application's exposed spaces are resolved
+// when this method is called. An application exposed without
+// restriction may be accessed from all IPv4 addresses, and from all
+// IPv6 addresses too if the model prefers IPv6 addresses.
+func (a *Application) ExposedIngressCIDRs() ([]string, error) {
+	if !a.doc.Exposed {
+		return nil, nil
+	}
+	if len(a.doc.ExposedCIDRs) == 0 && len(a.doc.ExposedSpaces) == 0 {
+		cfg, err := a.st.ModelConfig()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		cidrs := network.DefaultIngressCIDRs()
+		if cfg.AddressPreference() == network.PreferIPv6 {
+			cidrs = append(cidrs, network.DefaultIPv6IngressCIDR)
+		}
+		return cidrs, nil
+	}
+	cidrs := set.NewStrings(a.doc.ExposedCIDRs...)
+	for _, name := range a.doc.ExposedSpaces {
+		space, err := a.st.Space(name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		subnets, err := space.Subnets()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, subnet := range subnets {
+			cidrs.Add(subnet.CIDR())
+		}
+	}
+	return cidrs.SortedValues(), nil
+}
+
 // Charm returns the application's charm and whether units should upgrade to that
 // charm even if they are in an error state.
 func (a *Application) Charm() (ch *Charm, force bool, err error) {
@@ -549,7 +706,8 @@ func (a *Application) checkStorageUpgrade(newMeta, oldMeta *charm.Meta, units []
 }
 
 // changeCharmOps returns the operations necessary to set a application's
-// charm URL to a new value.
+// charm URL to a new value. If retainCurrentRef is true, the reference
+// to the current charm is kept, to be held by a rolling upgrade.
 func (a *Application) changeCharmOps(
 	ch *Charm,
 	channel string,
@@ -557,6 +715,7 @@ func (a *Application) changeCharmOps(
 	forceUnits bool,
 	resourceIDs map[string]string,
 	updatedStorageConstraints map[string]StorageConstraints,
+	retainCurrentRef bool,
 ) ([]txn.Op, error) {
 	// Build the new application config from what can be used of the old one.
 	var newSettings charm.Settings
@@ -679,7 +838,7 @@ func (a *Application) changeCharmOps(
 	var decOps []txn.Op
 	// Drop the references to the old settings, storage constraints,
 	// and charm docs (if the refs actually exist yet).
-	if oldSettings != nil {
+	if oldSettings != nil && !retainCurrentRef {
 		decOps, err = appCharmDecRefOps(a.st, a.doc.Name, a.doc.CharmURL) // current charm
 		if err != nil {
 			return nil, errors.Trace(err)
@@ -855,6 +1014,12 @@ type SetCharmConfig struct {
 	// unaffected; the storage constraints will only be used for
 	// provisioning new storage instances.
 	StorageConstraints map[string]StorageConstraints
+
+	// RollingUpgrade, if not nil, causes the charm to be upgraded
+	// as a rolling upgrade: units continue to run the current charm
+	// until they are released to the upgrade with
+	// ReleaseRollingUpgradeUnits.
+	RollingUpgrade *RollingUpgradeParams
 }
 
 // SetCharm changes the charm for the application.
@@ -918,6 +1083,11 @@ func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
 	if err != nil {
 		return errors.Annotate(err, "validating config settings")
 	}
+	if cfg.RollingUpgrade != nil {
+		if err := cfg.RollingUpgrade.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+	}
 
 	var newCharmModifiedVersion int
 	channel := string(cfg.Channel)
@@ -945,15 +1115,24 @@ func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
 		// charm URL.
 		newCharmModifiedVersion = a.doc.CharmModifiedVersion
 
+		// The charm cannot be changed while a rolling upgrade is in
+		// progress; it must be finished first.
+		if a.doc.RollingUpgrade != nil {
+			return nil, errors.Errorf("rolling upgrade to %q in progress", a.doc.CharmURL)
+		}
 		ops := []txn.Op{{
 			C:  applicationsC,
 			Id: a.doc.DocID,
-			Assert: append(notDeadDoc, bson.DocElem{
-				"charmmodifiedversion", a.doc.CharmModifiedVersion,
-			}),
+			Assert: append(notDeadDoc,
+				bson.DocElem{"charmmodifiedversion", a.doc.CharmModifiedVersion},
+				bson.DocElem{"rollingupgrade", bson.D{{"$exists", false}}},
+			),
 		}}
 
 		if a.doc.CharmURL.String() == cfg.Charm.URL().String() {
+			if cfg.RollingUpgrade != nil {
+				return nil, errors.Errorf("already running charm %q", a.doc.CharmURL)
+			}
 			// Charm URL already set; just update the force flag and channel.
 			ops = append(ops, txn.Op{
 				C:  applicationsC,
@@ -971,11 +1150,15 @@ func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
 				cfg.ForceUnits,
 				cfg.ResourceIDs,
 				cfg.StorageConstraints,
+				cfg.RollingUpgrade != nil,
 			)
 			if err != nil {
 				return nil, errors.Trace(err)
 			}
 			ops = append(ops, chng...)
+			if cfg.RollingUpgrade != nil {
+				ops = append(ops, a.startRollingUpgradeOp(*cfg.RollingUpgrade))
+			}
 			newCharmModifiedVersion++
 		}
 
@@ -984,6 +1167,13 @@ func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
 	if err := a.st.run(buildTxn); err != nil {
 		return err
 	}
+	if cfg.RollingUpgrade != nil {
+		a.doc.RollingUpgrade = &RollingUpgrade{
+			RollingUpgradeParams:         *cfg.RollingUpgrade,
+			PreviousCharmURL:             acopy.doc.CharmURL,
+			PreviousCharmModifiedVersion: acopy.doc.CharmModifiedVersion,
+		}
+	}
 	a.doc.CharmURL = cfg.Charm.URL()
 	a.doc.Channel = channel
 	a.doc.ForceCharm = cfg.ForceUnits
@@ -1588,6 +1778,193 @@ func (a *Application) SetMetricCredentials(b []byte) error {
 	return nil
 }
 
+// HookTimeouts returns the maximum durations that the application's
+// hooks may run for.
+func (a *Application) HookTimeouts() HookTimeouts {
+	if a.doc.HookTimeouts == nil {
+		return HookTimeouts{}
+	}
+	return *a.doc.HookTimeouts
+}
+
+// SetHookTimeouts replaces the maximum durations that the application's
+// hooks may run for. Hooks that are already running are not affected.
+func (a *Application) SetHookTimeouts(timeouts HookTimeouts) error {
+	if err := timeouts.Validate(); err != nil {
+		return errors.Annotate(err, "cannot set hook timeouts")
+	}
+	var update bson.D
+	if timeouts.IsZero() {
+		update = bson.D{{"$unset", bson.D{{"hook-timeouts", nil}}}}
+	} else {
+		update = bson.D{{"$set", bson.D{{"hook-timeouts", timeouts}}}}
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			alive, err := isAlive(a.st, applicationsC, a.doc.DocID)
+			if err != nil {
+				return nil, errors.Trace(err)
+			} else if !alive {
+				return nil, errNotAlive
+			}
+		}
+		ops := []txn.Op{{
+			C:      applicationsC,
+			Id:     a.doc.DocID,
+			Assert: isAliveDoc,
+			Update: update,
+		}}
+		return ops, nil
+	}
+	if err := a.st.run(buildTxn); err != nil {
+		if err == errNotAlive {
+			return errors.New("cannot set hook timeouts: application " + err.Error())
+		}
+		return errors.Annotatef(err, "cannot set hook timeouts")
+	}
+	if timeouts.IsZero() {
+		a.doc.HookTimeouts = nil
+	} else {
+		a.doc.HookTimeouts = &timeouts
+	}
+	return nil
+}
+
+// EgressRules returns the destinations to which the application's
+// units are allowed to connect. See SetEgressRules.
+func (a *Application) EgressRules() ([]network.EgressRule, error) {
+	rules := make([]network.EgressRule, len(a.doc.EgressRules))
+	for i, in := range a.doc.EgressRules {
+		rule, err := network.ParseEgressRule(in)
+		if err != nil {
+			return nil, errors.Annotatef(err, "application %q egress rule %d", a, i)
+		}
+		rules[i] = rule
+	}
+	return rules, nil
+}
+
+// SetEgressRules replaces the destinations to which the application's
+// units are allowed to connect. If no rules are given, egress is no
+// longer restricted.
+func (a *Application) SetEgressRules(rules []network.EgressRule) error {
+	var in []string
+	for _, rule := range rules {
+		if err := rule.Validate(); err != nil {
+			return errors.Annotatef(err, "cannot set egress rules: rule %v", rule)
+		}
+		in = append(in, rule.String())
+	}
+	sort.Strings(in)
+	var update bson.D
+	if len(in) == 0 {
+		update = bson.D{{"$unset", bson.D{{"egress-rules", nil}}}}
+	} else {
+		update = bson.D{{"$set", bson.D{{"egress-rules", in}}}}
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			alive, err := isAlive(a.st, applicationsC, a.doc.DocID)
+			if err != nil {
+				return nil, errors.Trace(err)
+			} else if !alive {
+				return nil, errNotAlive
+			}
+		}
+		ops := []txn.Op{{
+			C:      applicationsC,
+			Id:     a.doc.DocID,
+			Assert: isAliveDoc,
+			Update: update,
+		}}
+		return ops, nil
+	}
+	if err := a.st.run(buildTxn); err != nil {
+		if err == errNotAlive {
+			return errors.New("cannot set egress rules: application " + err.Error())
+		}
+		return errors.Annotatef(err, "cannot set egress rules")
+	}
+	a.doc.EgressRules = in
+	return nil
+}
+
+// RemoteEgressRules returns the egress rules required for the
+// application's units to reach the units of remote applications to
+// which it is related, as advertised by the private-address settings
+// of those units. The rules are sorted and allow all TCP and UDP
+// ports.
+func (a *Application) RemoteEgressRules() ([]network.EgressRule, error) {
+	relations, err := a.Relations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	relationScopes, closer := a.st.getCollection(relationScopesC)
+	defer closer()
+
+	seen := make(map[string]bool)
+	var rules []network.EgressRule
+	for _, relation := range relations {
+		for _, ep := range relation.Endpoints() {
+			if ep.ApplicationName == a.doc.Name {
+				continue
+			}
+			if _, err := a.st.RemoteApplication(ep.ApplicationName); errors.IsNotFound(err) {
+				continue
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+			prefix := strings.Join([]string{relation.globalScope(), string(ep.Role), ""}, "#")
+			var docs []relationScopeDoc
+			sel := bson.D{
+				{"key", bson.D{{"$regex", "^" + prefix}}},
+				{"departing", bson.D{{"$ne", true}}},
+			}
+			if err := relationScopes.Find(sel).All(&docs); err != nil {
+				return nil, errors.Annotatef(err, "cannot read scope of relation %q", relation)
+			}
+			for _, doc := range docs {
+				cidr, err := remoteUnitCIDR(a.st, doc.Key)
+				if err != nil {
+					return nil, errors.Annotatef(err, "remote unit %q", doc.unitName())
+				}
+				if cidr == "" || seen[cidr] {
+					continue
+				}
+				seen[cidr] = true
+				for _, protocol := range []string{"tcp", "udp"} {
+					rules = append(rules, network.MustNewEgressRule(protocol, 1, 65535, cidr))
+				}
+			}
+		}
+	}
+	network.SortEgressRules(rules)
+	return rules, nil
+}
+
+// remoteUnitCIDR returns a CIDR that matches only the private address
+// in the relation settings with the given key, or "" if the settings
+// do not hold a private IP address.
+func remoteUnitCIDR(st *State, key string) (string, error) {
+	settings, err := readSettings(st, settingsC, key)
+	if errors.IsNotFound(err) {
+		return "", nil
+	} else if err != nil {
+		return "", errors.Trace(err)
+	}
+	value, _ := settings.Get("private-address")
+	address, _ := value.(string)
+	ip := net.ParseIP(address)
+	switch {
+	case ip == nil:
+		return "", nil
+	case ip.To4() != nil:
+		return ip.String() + "/32", nil
+	default:
+		return ip.String() + "/128", nil
+	}
+}
+
 // StorageConstraints returns the storage constraints for the application.
 func (a *Application) StorageConstraints() (map[string]StorageConstraints, error) {
 	cons, err := readStorageConstraints(a.st, a.storageConstraintsKey())
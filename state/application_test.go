@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -19,6 +20,7 @@ import (
 	"gopkg.in/mgo.v2/txn"
 
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/resource/resourcetesting"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/testing"
@@ -1380,6 +1382,73 @@ func (s *ApplicationSuite) TestServiceExposed(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, notAliveErr)
 }
 
+func (s *ApplicationSuite) TestSetExposedTo(c *gc.C) {
+	_, err := s.State.AddSubnet(state.SubnetInfo{CIDR: "10.1.0.0/16"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSpace("mgmt", "", []string{"10.1.0.0/16"}, false)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.mysql.SetExposedTo([]string{"192.168.0.0/24"}, []string{"mgmt"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsTrue)
+	c.Assert(s.mysql.ExposedCIDRs(), jc.DeepEquals, []string{"192.168.0.0/24"})
+	c.Assert(s.mysql.ExposedSpaces(), jc.DeepEquals, []string{"mgmt"})
+
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	cidrs, err := s.mysql.ExposedIngressCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidrs, jc.DeepEquals, []string{"10.1.0.0/16", "192.168.0.0/24"})
+
+	// Clearing the exposed flag removes the restrictions.
+	err = s.mysql.ClearExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.ExposedCIDRs(), gc.HasLen, 0)
+	c.Assert(s.mysql.ExposedSpaces(), gc.HasLen, 0)
+	cidrs, err = s.mysql.ExposedIngressCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidrs, gc.HasLen, 0)
+}
+
+func (s *ApplicationSuite) TestSetExposedUnrestricted(c *gc.C) {
+	err := s.mysql.SetExposedTo([]string{"192.168.0.0/24"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.ExposedCIDRs(), gc.HasLen, 0)
+	cidrs, err := s.mysql.ExposedIngressCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidrs, jc.DeepEquals, []string{"0.0.0.0/0"})
+}
+
+func (s *ApplicationSuite) TestSetExposedUnrestrictedPreferIPv6(c *gc.C) {
+	err := s.State.UpdateModelConfig(map[string]interface{}{
+		"address-preference": "ipv6",
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	cidrs, err := s.mysql.ExposedIngressCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidrs, jc.DeepEquals, []string{"0.0.0.0/0", "::/0"})
+}
+
+func (s *ApplicationSuite) TestSetExposedToInvalidCIDR(c *gc.C) {
+	err := s.mysql.SetExposedTo([]string{"10.0.0.0"}, nil)
+	c.Assert(err, gc.ErrorMatches, `CIDR "10.0.0.0" not valid`)
+	c.Assert(s.mysql.IsExposed(), jc.IsFalse)
+}
+
+func (s *ApplicationSuite) TestSetExposedToUnknownSpace(c *gc.C) {
+	err := s.mysql.SetExposedTo(nil, []string{"mgmt"})
+	c.Assert(err, gc.ErrorMatches, `cannot set exposed flag for application "mysql": space "mgmt" not found`)
+	c.Assert(s.mysql.IsExposed(), jc.IsFalse)
+}
+
 func (s *ApplicationSuite) TestAddUnit(c *gc.C) {
 	// Check that principal units can be added on their own.
 	unitZero, err := s.mysql.AddUnit()
@@ -2153,6 +2222,240 @@ func (s *ApplicationSuite) TestMetricCredentialsOnDying(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "cannot update metric credentials: application not found or not alive")
 }
 
+func (s *ApplicationSuite) TestHookTimeouts(c *gc.C) {
+	c.Assert(s.mysql.HookTimeouts(), jc.DeepEquals, state.HookTimeouts{})
+	timeouts := state.HookTimeouts{
+		Default: 10 * time.Minute,
+		Hooks: map[string]time.Duration{
+			"install":          time.Hour,
+			"relation-changed": 30 * time.Second,
+		},
+	}
+	err := s.mysql.SetHookTimeouts(timeouts)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.HookTimeouts(), jc.DeepEquals, timeouts)
+
+	application, err := s.State.Application(s.mysql.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(application.HookTimeouts(), jc.DeepEquals, timeouts)
+
+	err = s.mysql.SetHookTimeouts(state.HookTimeouts{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = application.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(application.HookTimeouts(), jc.DeepEquals, state.HookTimeouts{})
+}
+
+func (s *ApplicationSuite) TestSetHookTimeoutsInvalid(c *gc.C) {
+	err := s.mysql.SetHookTimeouts(state.HookTimeouts{Default: -time.Second})
+	c.Assert(err, gc.ErrorMatches, `cannot set hook timeouts: negative default hook timeout -1s not valid`)
+	err = s.mysql.SetHookTimeouts(state.HookTimeouts{
+		Hooks: map[string]time.Duration{"install": -time.Second},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot set hook timeouts: negative "install" hook timeout -1s not valid`)
+}
+
+func (s *ApplicationSuite) TestSetHookTimeoutsOnDying(c *gc.C) {
+	_, err := s.mysql.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	assertLife(c, s.mysql, state.Dying)
+	err = s.mysql.SetHookTimeouts(state.HookTimeouts{Default: time.Minute})
+	c.Assert(err, gc.ErrorMatches, "cannot set hook timeouts: application not found or not alive")
+}
+
+func (s *ApplicationSuite) TestEgressRules(c *gc.C) {
+	rules, err := s.mysql.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, gc.HasLen, 0)
+
+	err = s.mysql.SetEgressRules([]network.EgressRule{
+		network.MustNewEgressRule("udp", 53, 53, "10.0.0.0/8"),
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	expected := []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+		network.MustNewEgressRule("udp", 53, 53, "10.0.0.0/8"),
+	}
+	application, err := s.State.Application(s.mysql.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err = application.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, expected)
+
+	err = s.mysql.SetEgressRules(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = application.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err = application.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, gc.HasLen, 0)
+}
+
+func (s *ApplicationSuite) TestSetEgressRulesInvalid(c *gc.C) {
+	err := s.mysql.SetEgressRules([]network.EgressRule{{
+		PortRange:       network.PortRange{Protocol: "tcp", FromPort: 443, ToPort: 443},
+		DestinationCIDR: "10.0.0.0",
+	}})
+	c.Assert(err, gc.ErrorMatches, `cannot set egress rules: rule 10.0.0.0:443/tcp: invalid CIDR address: 10.0.0.0`)
+}
+
+func (s *ApplicationSuite) TestSetEgressRulesOnDying(c *gc.C) {
+	_, err := s.mysql.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	assertLife(c, s.mysql, state.Dying)
+	err = s.mysql.SetEgressRules([]network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+	})
+	c.Assert(err, gc.ErrorMatches, "cannot set egress rules: application not found or not alive")
+}
+
+func (s *ApplicationSuite) startRollingUpgrade(c *gc.C) *state.Charm {
+	sch := s.AddMetaCharm(c, "mysql", metaBase, 2)
+	err := s.mysql.SetCharm(state.SetCharmConfig{
+		Charm: sch,
+		RollingUpgrade: &state.RollingUpgradeParams{
+			BatchSize:   2,
+			LeaderFirst: true,
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return sch
+}
+
+func (s *ApplicationSuite) TestSetCharmRollingUpgrade(c *gc.C) {
+	sch := s.startRollingUpgrade(c)
+	url, _ := s.mysql.CharmURL()
+	c.Assert(url, gc.DeepEquals, sch.URL())
+
+	expected := state.RollingUpgrade{
+		RollingUpgradeParams: state.RollingUpgradeParams{
+			BatchSize:   2,
+			LeaderFirst: true,
+		},
+		PreviousCharmURL:             s.charm.URL(),
+		PreviousCharmModifiedVersion: 0,
+	}
+	upgrade, ok := s.mysql.RollingUpgrade()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(upgrade, jc.DeepEquals, expected)
+
+	application, err := s.State.Application(s.mysql.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	upgrade, ok = application.RollingUpgrade()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(upgrade, jc.DeepEquals, expected)
+
+	// The rolling upgrade holds a reference to the previous charm.
+	err = s.charm.Destroy()
+	c.Assert(err, gc.ErrorMatches, "charm in use")
+}
+
+func (s *ApplicationSuite) TestSetCharmRollingUpgradeInvalid(c *gc.C) {
+	sch := s.AddMetaCharm(c, "mysql", metaBase, 2)
+	err := s.mysql.SetCharm(state.SetCharmConfig{
+		Charm:          sch,
+		RollingUpgrade: &state.RollingUpgradeParams{},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot upgrade application "mysql" to charm "local:quantal/quantal-mysql-2": rolling upgrade batch size 0 not valid`)
+
+	err = s.mysql.SetCharm(state.SetCharmConfig{
+		Charm:          s.charm,
+		RollingUpgrade: &state.RollingUpgradeParams{BatchSize: 1},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot upgrade application "mysql" to charm "local:quantal/quantal-mysql-1": already running charm "local:quantal/quantal-mysql-1"`)
+}
+
+func (s *ApplicationSuite) TestSetCharmDuringRollingUpgrade(c *gc.C) {
+	s.startRollingUpgrade(c)
+	sch := s.AddMetaCharm(c, "mysql", metaBase, 3)
+	err := s.mysql.SetCharm(state.SetCharmConfig{Charm: sch})
+	c.Assert(err, gc.ErrorMatches, `cannot upgrade application "mysql" to charm "local:quantal/quantal-mysql-3": rolling upgrade to "local:quantal/quantal-mysql-2" in progress`)
+}
+
+func (s *ApplicationSuite) TestUnitCharmURLRollingUpgrade(c *gc.C) {
+	url, _ := s.mysql.UnitCharmURL("mysql/0")
+	c.Assert(url, gc.DeepEquals, s.charm.URL())
+
+	sch := s.startRollingUpgrade(c)
+	url, _ = s.mysql.UnitCharmURL("mysql/0")
+	c.Assert(url, gc.DeepEquals, s.charm.URL())
+	c.Assert(s.mysql.UnitCharmModifiedVersion("mysql/0"), gc.Equals, 0)
+
+	err := s.mysql.ReleaseRollingUpgradeUnits("mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	url, _ = s.mysql.UnitCharmURL("mysql/0")
+	c.Assert(url, gc.DeepEquals, sch.URL())
+	c.Assert(s.mysql.UnitCharmModifiedVersion("mysql/0"), gc.Equals, 1)
+	url, _ = s.mysql.UnitCharmURL("mysql/1")
+	c.Assert(url, gc.DeepEquals, s.charm.URL())
+}
+
+func (s *ApplicationSuite) TestReleaseAndPauseRollingUpgrade(c *gc.C) {
+	err := s.mysql.ReleaseRollingUpgradeUnits("mysql/0")
+	c.Assert(err, gc.ErrorMatches, `cannot release units for rolling upgrade of application "mysql": rolling upgrade not found`)
+
+	s.startRollingUpgrade(c)
+	err = s.mysql.ReleaseRollingUpgradeUnits("wordpress/0")
+	c.Assert(err, gc.ErrorMatches, `cannot release units: "wordpress/0" is not a unit of "mysql"`)
+
+	err = s.mysql.ReleaseRollingUpgradeUnits("mysql/1", "mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.PauseRollingUpgrade("unit mysql/1 is in error")
+	c.Assert(err, jc.ErrorIsNil)
+
+	application, err := s.State.Application(s.mysql.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	upgrade, _ := application.RollingUpgrade()
+	c.Assert(upgrade.Released, jc.SameContents, []string{"mysql/0", "mysql/1"})
+	c.Assert(upgrade.Paused, jc.IsTrue)
+	c.Assert(upgrade.PauseReason, gc.Equals, "unit mysql/1 is in error")
+
+	// Releasing more units resumes the upgrade.
+	err = application.ReleaseRollingUpgradeUnits("mysql/1", "mysql/2")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	upgrade, _ = s.mysql.RollingUpgrade()
+	c.Assert(upgrade.Released, jc.SameContents, []string{"mysql/0", "mysql/1", "mysql/2"})
+	c.Assert(upgrade.Paused, jc.IsFalse)
+	c.Assert(upgrade.PauseReason, gc.Equals, "")
+}
+
+func (s *ApplicationSuite) TestFinishRollingUpgrade(c *gc.C) {
+	err := s.mysql.FinishRollingUpgrade()
+	c.Assert(err, gc.ErrorMatches, `cannot finish rolling upgrade of application "mysql": rolling upgrade not found`)
+
+	sch := s.startRollingUpgrade(c)
+	err = s.mysql.FinishRollingUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok := s.mysql.RollingUpgrade()
+	c.Assert(ok, jc.IsFalse)
+	url, _ := s.mysql.UnitCharmURL("mysql/0")
+	c.Assert(url, gc.DeepEquals, sch.URL())
+
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok = s.mysql.RollingUpgrade()
+	c.Assert(ok, jc.IsFalse)
+
+	// The reference to the previous charm is released.
+	err = s.charm.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ApplicationSuite) TestDestroyDuringRollingUpgrade(c *gc.C) {
+	s.startRollingUpgrade(c)
+	err := s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func (s *ApplicationSuite) testStatus(c *gc.C, status1, status2, expected status.Status) {
 	u1, err := s.mysql.AddUnit()
 	c.Assert(err, jc.ErrorIsNil)
@@ -103,6 +103,11 @@ type Backups interface {
 	// it returns the tag string for the machine where the backup originated
 	// or error if the process fails.
 	Restore(backupId string, dbInfo *DBInfo, args RestoreArgs) (names.Tag, error)
+
+	// ExtractModel writes an archive of a single model, as it was when
+	// the backup was made, to w. The archive is in the format read by
+	// migration.ReadModelArchive, so the model can be imported again.
+	ExtractModel(backupId string, args ExtractModelArgs, w io.Writer) error
 }
 
 type backups struct {
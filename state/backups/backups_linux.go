@@ -6,7 +6,9 @@
 package backups
 
 import (
+	"io/ioutil"
 	"net"
+	"os"
 	"strconv"
 
 	"github.com/juju/errors"
@@ -64,6 +66,7 @@ func ensureMongoService(agentConfig agent.Config) error {
 // Restore handles either returning or creating a controller to a backed up status:
 // * extracts the content of the given backup file and:
 // * runs mongorestore with the backed up mongo dump
+// * replays the archived oplog up to args.ToTime, if set
 // * updates and writes configuration files
 // * updates existing db entries to make sure they hold no references to
 // old instances
@@ -94,6 +97,26 @@ func (b *backups) Restore(backupId string, dbInfo *DBInfo, args RestoreArgs) (na
 	}
 	backupMachine := names.NewMachineTag(meta.Origin.Machine)
 
+	// The archived oplog lives in the database about to be replaced,
+	// so extract what's needed before touching anything.
+	var oplogDir string
+	if !args.ToTime.IsZero() {
+		if args.Oplog == nil {
+			return nil, errors.New("cannot restore to a point in time without an archived oplog")
+		}
+		if args.ToTime.Before(meta.Started) {
+			return nil, errors.Errorf("cannot restore backup %q, started at %v, to the earlier time %v", backupId, meta.Started, args.ToTime)
+		}
+		oplogDir, err = ioutil.TempDir("", "juju-restore-oplog")
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		defer os.RemoveAll(oplogDir)
+		if err := args.Oplog.WriteOplog(oplogDir, meta.Started, args.ToTime); err != nil {
+			return nil, errors.Annotate(err, "cannot extract archived oplog")
+		}
+	}
+
 	// The path for the config file might change if the tag changed
 	// and also the rest of the path, so we assume as little as possible.
 	oldDatadir, err := paths.DataDir(args.NewInstSeries)
@@ -210,6 +233,12 @@ func (b *backups) Restore(backupId string, dbInfo *DBInfo, args RestoreArgs) (na
 	if err := restorer.Restore(workspace.DBDumpDir, oldDialInfo); err != nil {
 		return nil, errors.Annotate(err, "error restoring state from backup")
 	}
+	if oplogDir != "" {
+		logger.Infof("replaying archived oplog up to %v", args.ToTime)
+		if err := restorer.ReplayOplog(oplogDir); err != nil {
+			return nil, errors.Annotate(err, "error replaying archived oplog")
+		}
+	}
 
 	// Re-start replicaset with the new value for server address
 	logger.Infof("restarting replicaset")
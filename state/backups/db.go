@@ -250,6 +250,10 @@ var getMongorestorePath = func() (string, error) {
 type DBRestorer interface {
 	// Dump something to dumpDir.
 	Restore(dumpDir string, dialInfo *mgo.DialInfo) error
+
+	// ReplayOplog applies the operations in oplogDir/oplog.bson to
+	// the restored database.
+	ReplayOplog(oplogDir string) error
 }
 
 type mongoRestorer struct {
@@ -301,6 +305,28 @@ func (md *mongoRestorer24) Restore(dumpDir string, _ *mgo.DialInfo) error {
 	return nil
 }
 
+func (md *mongoRestorer24) ReplayOplog(oplogDir string) error {
+	logger.Debugf("stopping mongo service for oplog replay")
+	if err := md.stopMongo(); err != nil {
+		return errors.Annotate(err, "cannot stop mongo to replay oplog")
+	}
+	dbDir := filepath.Join(agent.DefaultPaths.DataDir, "db")
+	options := []string{
+		"--journal",
+		"--oplogReplay",
+		"--dbpath", dbDir,
+		oplogDir,
+	}
+	logger.Infof("replaying oplog with params %v", options)
+	if err := md.runCommandFn(md.binPath, options...); err != nil {
+		return errors.Annotate(err, "error replaying oplog")
+	}
+	if err := md.startMongo(); err != nil {
+		return errors.Annotate(err, "cannot start mongo after oplog replay")
+	}
+	return nil
+}
+
 // GetDB wraps mgo.Session.DB to ease testing.
 func GetDB(s string, session MongoSession) MongoDB {
 	return session.DB(s)
@@ -371,6 +397,18 @@ func NewDBRestorer(args RestorerArgs) (DBRestorer, error) {
 	return restorer, nil
 }
 
+// connectionOptions returns the mongorestore options needed to
+// connect to the database being restored.
+func (md *mongoRestorer32) connectionOptions() []string {
+	return []string{
+		"--ssl",
+		"--authenticationDatabase", "admin",
+		"--host", md.Addrs[0],
+		"--username", md.Username,
+		"--password", md.Password,
+	}
+}
+
 func (md *mongoRestorer32) options(dumpDir string) []string {
 	// note the batchSize, which is known to mitigate EOF errors
 	// seen when using mongorestore; as seen and reported in
@@ -379,17 +417,12 @@ func (md *mongoRestorer32) options(dumpDir string) []string {
 	//
 	// The value of 10 was chosen because it's more pessimistic
 	// than the "1000" that many report success using in the bug.
-	options := []string{
-		"--ssl",
-		"--authenticationDatabase", "admin",
-		"--host", md.Addrs[0],
-		"--username", md.Username,
-		"--password", md.Password,
+	options := append(md.connectionOptions(),
 		"--drop",
 		"--oplogReplay",
 		"--batchSize", "10",
 		dumpDir,
-	}
+	)
 	return options
 }
 
@@ -513,3 +546,18 @@ func (md *mongoRestorer32) Restore(dumpDir string, dialInfo *mgo.DialInfo) error
 	}
 	return nil
 }
+
+// ReplayOplog applies the operations in oplogDir/oplog.bson to the
+// database restored by Restore, whose credentials it relies on.
+func (md *mongoRestorer32) ReplayOplog(oplogDir string) error {
+	options := append(md.connectionOptions(),
+		"--oplogReplay",
+		"--batchSize", "10",
+		oplogDir,
+	)
+	logger.Infof("replaying oplog from %s", oplogDir)
+	if err := md.runCommandFn(md.binPath, options...); err != nil {
+		return errors.Annotate(err, "error replaying oplog")
+	}
+	return nil
+}
@@ -30,6 +30,7 @@ var (
 	RunCommand            = &runCommandFn
 	ReplaceableFolders    = &replaceableFolders
 	MongoInstalledVersion = &mongoInstalledVersion
+	WriteModelArchive     = writeModelArchive
 )
 
 var _ filestorage.DocStorage = (*backupsDocStorage)(nil)
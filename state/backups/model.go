@@ -0,0 +1,392 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"io"
+	"io/ioutil"
+	"net"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/migration"
+	"github.com/juju/juju/mongo"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/storage"
+)
+
+// A single model is extracted from a backup by restoring the backed up
+// database into a scratch mongod, private to the extraction, and
+// exporting the model from there the same way a migration would.
+
+// scratchDBTimeout is how long to wait for the scratch mongod to
+// accept connections.
+const scratchDBTimeout = time.Minute
+
+// ExtractModel writes an archive of the model identified by args.Model,
+// as it was when the backup was made, to w.
+func (b *backups) ExtractModel(backupId string, args ExtractModelArgs, w io.Writer) error {
+	meta, backupReader, err := b.Get(backupId)
+	if err != nil {
+		return errors.Annotatef(err, "could not fetch backup %q", backupId)
+	}
+	defer backupReader.Close()
+
+	workspace, err := NewArchiveWorkspaceReader(backupReader)
+	if err != nil {
+		return errors.Annotate(err, "cannot unpack backup file")
+	}
+	defer workspace.Close()
+
+	db, err := startScratchDB(args)
+	if err != nil {
+		return errors.Annotate(err, "cannot start database for backup")
+	}
+	defer db.Close()
+	if err := db.restore(workspace.DBDumpDir); err != nil {
+		return errors.Annotate(err, "cannot restore backed up database")
+	}
+	st, err := db.openState(names.NewModelTag(meta.Origin.Model))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer st.Close()
+	return errors.Trace(writeModelArchive(st, args.Model, w))
+}
+
+// writeModelArchive writes an archive of the identified model, one of
+// those managed by the controller st connects to, to w.
+func writeModelArchive(st *state.State, model string, w io.Writer) error {
+	modelSt, err := modelState(st, model)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer modelSt.Close()
+
+	logger.Infof("exporting model %s", modelSt.ModelUUID())
+	modelBytes, err := migration.ExportModel(modelSt)
+	if err != nil {
+		return errors.Annotate(err, "cannot export model")
+	}
+	binaries := modelBinaries{modelSt}
+	err = migration.WriteModelArchive(w, migration.WriteModelArchiveConfig{
+		Model:              modelBytes,
+		CharmDownloader:    binaries,
+		ToolsDownloader:    binaries,
+		ResourceDownloader: binaries,
+	})
+	return errors.Annotate(err, "cannot write model archive")
+}
+
+// modelState returns a State for the model identified by its UUID,
+// its name, or its owner and name as "owner/name".
+func modelState(st *state.State, model string) (*state.State, error) {
+	models, err := st.AllModels()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// Without a name, the model is identified by its UUID.
+	var owner, name string
+	if !names.IsValidModel(model) {
+		name = model
+		if i := strings.Index(model, "/"); i >= 0 {
+			owner, name = model[:i], model[i+1:]
+		}
+	}
+	var found []*state.Model
+	for _, m := range models {
+		switch {
+		case name == "" && m.UUID() == model:
+		case name != "" && m.Name() == name && (owner == "" || m.Owner().Id() == owner):
+		default:
+			continue
+		}
+		found = append(found, m)
+	}
+	switch len(found) {
+	case 0:
+		return nil, errors.NotFoundf("model %q in backup", model)
+	case 1:
+		return st.ForModel(found[0].ModelTag())
+	}
+	return nil, errors.Errorf("more than one model named %q in backup, specify it as owner/name", model)
+}
+
+// modelBinaries serves the charms, tools and resources of a model
+// straight from its State, for WriteModelArchive.
+type modelBinaries struct {
+	st *state.State
+}
+
+// OpenCharm is part of migration.CharmDownloader.
+func (b modelBinaries) OpenCharm(curl *charm.URL) (io.ReadCloser, error) {
+	ch, err := b.st.Charm(curl)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	stor := storage.NewStorage(b.st.ModelUUID(), b.st.MongoSession())
+	reader, _, err := stor.Get(ch.StoragePath())
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot read charm %s", curl)
+	}
+	return reader, nil
+}
+
+// OpenURI is part of migration.ToolsDownloader. It accepts the tools
+// URIs listed by migration.NewSerializedModel.
+func (b modelBinaries) OpenURI(uri string, _ url.Values) (io.ReadCloser, error) {
+	if !strings.HasPrefix(uri, "/tools/") {
+		return nil, errors.NotSupportedf("URI %q", uri)
+	}
+	toolsStorage, err := b.st.ToolsStorage()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	_, reader, err := toolsStorage.Open(strings.TrimPrefix(uri, "/tools/"))
+	if err != nil {
+		toolsStorage.Close()
+		return nil, errors.Annotatef(err, "cannot read tools at %q", uri)
+	}
+	return &storageReader{reader, toolsStorage}, nil
+}
+
+// OpenResource is part of migration.ResourceDownloader.
+func (b modelBinaries) OpenResource(application, name string) (io.ReadCloser, error) {
+	resources, err := b.st.Resources()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	_, reader, err := resources.OpenResource(application, name)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot read resource %s/%s", application, name)
+	}
+	return reader, nil
+}
+
+// storageReader reads from a storage that is closed along with it.
+type storageReader struct {
+	io.ReadCloser
+	storage io.Closer
+}
+
+// Close is part of io.Closer.
+func (r *storageReader) Close() error {
+	err := r.ReadCloser.Close()
+	r.storage.Close()
+	return errors.Trace(err)
+}
+
+// scratchDB is a mongod, listening only on the loopback interface and
+// keeping its data in a temporary directory, into which a backed up
+// database can be restored. It requires authentication, as the admin
+// user with a password generated for it alone, since other users of
+// the machine can reach the loopback interface.
+type scratchDB struct {
+	dir      string
+	cmd      *exec.Cmd
+	info     mongo.Info
+	password string
+}
+
+// scratchDBOplogRole is the role which lets the scratch mongod's admin
+// user replay an oplog, as mongorestore --oplogReplay requires.
+const scratchDBOplogRole = "oploger"
+
+// startScratchDB starts a new scratch mongod, serving with the
+// certificate in args.ServingInfo.
+var startScratchDB = func(args ExtractModelArgs) (_ *scratchDB, err error) {
+	mongodPath, err := getMongodPath()
+	if err != nil {
+		return nil, errors.Annotate(err, "failed to get mongod path")
+	}
+	password, err := utils.RandomPassword()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	dir, err := ioutil.TempDir("", "juju-backup-model")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer func() {
+		if err != nil {
+			os.RemoveAll(dir)
+		}
+	}()
+	if err := mongo.UpdateSSLKey(dir, args.ServingInfo.Cert, args.ServingInfo.PrivateKey); err != nil {
+		return nil, errors.Trace(err)
+	}
+	dbDir := filepath.Join(dir, "db")
+	if err := os.Mkdir(dbDir, 0700); err != nil {
+		return nil, errors.Trace(err)
+	}
+	port, err := freePort()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	options := []string{
+		"--dbpath", dbDir,
+		"--logpath", filepath.Join(dir, "mongod.log"),
+		"--bind_ip", "127.0.0.1",
+		"--port", strconv.Itoa(port),
+		"--sslOnNormalPorts",
+		"--sslPEMKeyFile", filepath.Join(dir, "server.pem"),
+		"--sslPEMKeyPassword=ignored",
+		"--auth",
+		"--quiet",
+	}
+	if mongoInstalledVersion().StorageEngine != mongo.WiredTiger {
+		options = append(options, "--noprealloc", "--smallfiles")
+	}
+	logger.Debugf("starting scratch mongod with params %v", options)
+	cmd := exec.Command(mongodPath, options...)
+	if err := cmd.Start(); err != nil {
+		return nil, errors.Annotate(err, "cannot start mongod")
+	}
+	return &scratchDB{
+		dir: dir,
+		cmd: cmd,
+		info: mongo.Info{
+			Addrs:  []string{net.JoinHostPort("127.0.0.1", strconv.Itoa(port))},
+			CACert: args.CACert,
+		},
+		password: password,
+	}, nil
+}
+
+// freePort returns a local TCP port that is not in use.
+func freePort() (int, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
+
+// Close stops the mongod and removes its data.
+func (db *scratchDB) Close() error {
+	if err := db.cmd.Process.Kill(); err != nil {
+		logger.Warningf("cannot stop scratch mongod: %v", err)
+	}
+	db.cmd.Wait()
+	return errors.Trace(os.RemoveAll(db.dir))
+}
+
+func (db *scratchDB) dialOpts() mongo.DialOpts {
+	opts := mongo.DefaultDialOpts()
+	opts.Timeout = scratchDBTimeout
+	opts.Direct = true
+	return opts
+}
+
+// addAdminUser adds the admin user to the new mongod, waiting for it
+// to accept connections first. The mongod lets the first user be added
+// without authenticating, since no users exist yet.
+func (db *scratchDB) addAdminUser() error {
+	session, err := mongo.DialWithInfo(db.info, db.dialOpts())
+	if err != nil {
+		return errors.Annotate(err, "cannot connect to scratch mongod")
+	}
+	defer session.Close()
+
+	admin := session.DB("admin")
+	if err := admin.UpsertUser(&mgo.User{
+		Username: mongo.AdminUser,
+		Password: db.password,
+		Roles:    []mgo.Role{mgo.RoleRoot},
+	}); err != nil {
+		return errors.Annotate(err, "cannot add admin user")
+	}
+	if err := admin.Login(mongo.AdminUser, db.password); err != nil {
+		return errors.Annotate(err, "cannot log in as admin user")
+	}
+	err = admin.Run(bson.D{
+		{"createRole", scratchDBOplogRole},
+		{"privileges", []bson.D{{
+			{"resource", bson.M{"anyResource": true}},
+			{"actions", []string{"anyAction"}},
+		}}},
+		{"roles", []string{}},
+	}, nil)
+	if err != nil {
+		return errors.Annotate(err, "cannot create oplog replay role")
+	}
+	err = admin.Run(bson.D{
+		{"grantRolesToUser", mongo.AdminUser},
+		{"roles", []string{scratchDBOplogRole}},
+	}, nil)
+	return errors.Annotate(err, "cannot grant oplog replay role")
+}
+
+// restore loads the database dumped to dumpDir.
+func (db *scratchDB) restore(dumpDir string) error {
+	if err := db.addAdminUser(); err != nil {
+		return errors.Trace(err)
+	}
+	mongorestorePath, err := getMongorestorePath()
+	if err != nil {
+		return errors.Annotate(err, "mongorestore not available")
+	}
+	options := []string{
+		"--ssl",
+		"--authenticationDatabase", "admin",
+		"--host", db.info.Addrs[0],
+		"--username", mongo.AdminUser,
+		"--password", db.password,
+		"--oplogReplay",
+		dumpDir,
+	}
+	logger.Infof("restoring backed up database to %s", db.info.Addrs[0])
+	return errors.Trace(runCommandFn(mongorestorePath, options...))
+}
+
+// openState returns a State for the restored controller, whose
+// controller model is given.
+func (db *scratchDB) openState(controllerModelTag names.ModelTag) (*state.State, error) {
+	session, err := mongo.DialWithInfo(db.info, db.dialOpts())
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot connect to scratch mongod")
+	}
+	defer session.Close()
+	if err := session.DB("admin").Login(mongo.AdminUser, db.password); err != nil {
+		return nil, errors.Annotate(err, "cannot log in to scratch mongod")
+	}
+	// The backup may have been made by another controller, so its
+	// UUID is read from the backed up settings.
+	var doc struct {
+		Settings map[string]interface{} `bson:"settings"`
+	}
+	err = session.DB("juju").C("controllers").FindId("controllerSettings").One(&doc)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot read backed up controller settings")
+	}
+	controllerUUID, _ := doc.Settings[controller.ControllerUUIDKey].(string)
+	if !names.IsValidController(controllerUUID) {
+		return nil, errors.Errorf("invalid controller UUID %q in backup", controllerUUID)
+	}
+
+	st, err := state.Open(state.OpenParams{
+		Clock:              clock.WallClock,
+		ControllerTag:      names.NewControllerTag(controllerUUID),
+		ControllerModelTag: controllerModelTag,
+		MongoInfo:          &mongo.MongoInfo{Info: db.info, Password: db.password},
+		MongoDialOpts:      db.dialOpts(),
+	})
+	return st, errors.Annotate(err, "cannot open backed up state")
+}
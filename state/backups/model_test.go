@@ -0,0 +1,77 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"bytes"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/migration"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/testing/factory"
+)
+
+type modelSuite struct {
+	statetesting.StateSuite
+}
+
+var _ = gc.Suite(&modelSuite{})
+
+func (s *modelSuite) makeModel(c *gc.C, owner, name string) *state.State {
+	params := &factory.ModelParams{Name: name}
+	if owner != "" {
+		params.Owner = s.Factory.MakeUser(c, &factory.UserParams{Name: owner}).UserTag()
+	}
+	st := s.Factory.MakeModel(c, params)
+	s.AddCleanup(func(*gc.C) { st.Close() })
+	return st
+}
+
+func (s *modelSuite) checkArchive(c *gc.C, model string, expected *state.State) {
+	var buf bytes.Buffer
+	err := backups.WriteModelArchive(s.State, model, &buf)
+	c.Assert(err, jc.ErrorIsNil)
+
+	archive, err := migration.ReadModelArchive(&buf)
+	c.Assert(err, jc.ErrorIsNil)
+	defer archive.Close()
+	exported, err := description.Deserialize(archive.Model.Bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(exported.Tag(), gc.Equals, expected.ModelTag())
+}
+
+func (s *modelSuite) TestWriteModelArchiveByName(c *gc.C) {
+	st := s.makeModel(c, "", "mine")
+	s.checkArchive(c, "mine", st)
+}
+
+func (s *modelSuite) TestWriteModelArchiveByOwnerAndName(c *gc.C) {
+	s.makeModel(c, "", "shared")
+	st := s.makeModel(c, "bob", "shared")
+	s.checkArchive(c, "bob/shared", st)
+}
+
+func (s *modelSuite) TestWriteModelArchiveByUUID(c *gc.C) {
+	st := s.makeModel(c, "", "mine")
+	s.checkArchive(c, st.ModelUUID(), st)
+}
+
+func (s *modelSuite) TestWriteModelArchiveNotFound(c *gc.C) {
+	var buf bytes.Buffer
+	err := backups.WriteModelArchive(s.State, "missing", &buf)
+	c.Assert(err, gc.ErrorMatches, `model "missing" in backup not found`)
+}
+
+func (s *modelSuite) TestWriteModelArchiveAmbiguous(c *gc.C) {
+	s.makeModel(c, "", "shared")
+	s.makeModel(c, "bob", "shared")
+	var buf bytes.Buffer
+	err := backups.WriteModelArchive(s.State, "shared", &buf)
+	c.Assert(err, gc.ErrorMatches, `more than one model named "shared" in backup, specify it as owner/name`)
+}
@@ -0,0 +1,373 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/blobstore.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/mongo"
+)
+
+// The oplog archive keeps the operations recorded in the controller's
+// oplog in segments stored next to the backups, so that a restore can
+// replay what happened after the backup it restores was made.
+
+const (
+	oplogSegmentsName = "oplogsegments"
+	oplogStorageRoot  = "oplog"
+
+	// OplogFilename is the name of the file, in the directory given
+	// to OplogArchive.WriteOplog, holding the extracted operations. It
+	// is the name mongorestore expects when replaying an oplog.
+	OplogFilename = "oplog.bson"
+)
+
+// ignoredOplogNamespaces matches the namespaces of operations that
+// are never archived: those on databases that aren't restored, and
+// those on the backups database, which the archive itself writes to.
+var ignoredOplogNamespaces = bson.RegEx{
+	Pattern: `^(admin|local|` + storageDBName + `)\.`,
+}
+
+// archivedOplogSelector matches the oplog entries which are archived:
+// every operation other than a no-op, on a namespace which isn't
+// ignored.
+func archivedOplogSelector() bson.D {
+	return bson.D{
+		{"op", bson.D{{"$ne", "n"}}},
+		{"ns", bson.D{{"$not", ignoredOplogNamespaces}}},
+	}
+}
+
+// oplogSegmentDoc describes a run of archived oplog entries. A
+// segment holds every archived entry with a timestamp after From, up
+// to and including To, and the segments are contiguous unless the
+// oplog rolled over between two runs of the archiver.
+type oplogSegmentDoc struct {
+	ID    string              `bson:"_id"`
+	From  bson.MongoTimestamp `bson:"from"`
+	To    bson.MongoTimestamp `bson:"to"`
+	Count int                 `bson:"count"`
+	Size  int64               `bson:"size,minsize"`
+}
+
+// mongoTimestamp returns the earliest oplog timestamp in the second
+// holding t.
+func mongoTimestamp(t time.Time) bson.MongoTimestamp {
+	return bson.MongoTimestamp(t.Unix() << 32)
+}
+
+// timestampTime returns the time, to the second, of an oplog
+// timestamp.
+func timestampTime(ts bson.MongoTimestamp) time.Time {
+	return time.Unix(int64(ts>>32), 0).UTC()
+}
+
+// oplogEntry holds the part of an oplog entry the archive needs.
+type oplogEntry struct {
+	Timestamp bson.MongoTimestamp `bson:"ts"`
+}
+
+// OplogArchive stores segments of the controller's oplog next to its
+// backups.
+type OplogArchive struct {
+	dbWrap    *storageDBWrapper
+	blobs     blobstore.ManagedStorage
+	modelUUID string
+}
+
+// NewOplogArchive returns an OplogArchive using the backups database
+// of the given controller.
+func NewOplogArchive(st DB) *OplogArchive {
+	modelUUID := st.ModelTag().Id()
+	db := st.MongoSession().DB(storageDBName)
+	dbWrap := newStorageDBWrapper(db, oplogSegmentsName, modelUUID)
+	return &OplogArchive{
+		dbWrap:    dbWrap,
+		blobs:     dbWrap.blobStorage(dbWrap.db.Name),
+		modelUUID: modelUUID,
+	}
+}
+
+// Close releases the DB resources.
+func (a *OplogArchive) Close() error {
+	return a.dbWrap.Close()
+}
+
+func (a *OplogArchive) path(id string) string {
+	return path.Join(oplogStorageRoot, id)
+}
+
+// segments returns all the archived segments, oldest first.
+func (a *OplogArchive) segments() ([]oplogSegmentDoc, error) {
+	var docs []oplogSegmentDoc
+	if err := a.dbWrap.metaColl.Find(nil).Sort("from").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot read archived oplog segments")
+	}
+	return docs, nil
+}
+
+// Archive stores, as a new segment, the entries in the given oplog
+// collection made since the last segment was archived, and returns
+// the number of entries stored.
+func (a *OplogArchive) Archive(oplog *mgo.Collection) (int, error) {
+	segments, err := a.segments()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	var oldest, latest oplogEntry
+	if err := oplog.Find(nil).Sort("$natural").One(&oldest); err == mgo.ErrNotFound {
+		return 0, nil
+	} else if err != nil {
+		return 0, errors.Annotate(err, "cannot read oplog")
+	}
+	if err := oplog.Find(nil).Sort("-$natural").One(&latest); err != nil {
+		return 0, errors.Annotate(err, "cannot read oplog")
+	}
+
+	from := oldest.Timestamp - 1
+	var last *oplogSegmentDoc
+	if len(segments) > 0 {
+		last = &segments[len(segments)-1]
+		if latest.Timestamp <= last.To {
+			return 0, nil
+		}
+		if oldest.Timestamp > last.To {
+			logger.Warningf(
+				"oplog entries between %v and %v were lost before they were archived",
+				timestampTime(last.To), timestampTime(oldest.Timestamp),
+			)
+		} else {
+			from = last.To
+		}
+	}
+
+	file, err := ioutil.TempFile("", "juju-oplog-")
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	sel := append(bson.D{
+		{"ts", bson.D{{"$gt", from}, {"$lte", latest.Timestamp}}},
+	}, archivedOplogSelector()...)
+	query := oplog.Find(sel).Sort("$natural")
+	iter := query.Iter()
+	var raw bson.Raw
+	var count int
+	var size int64
+	for iter.Next(&raw) {
+		if _, err := file.Write(raw.Data); err != nil {
+			iter.Close()
+			return 0, errors.Trace(err)
+		}
+		count++
+		size += int64(len(raw.Data))
+	}
+	if err := iter.Close(); err != nil {
+		return 0, errors.Annotate(err, "cannot read oplog")
+	}
+
+	if count == 0 && last != nil && from == last.To {
+		// Nothing worth archiving happened; just record that the
+		// last segment now reaches further.
+		op := a.dbWrap.txnOpUpdate(last.ID, bson.DocElem{"to", latest.Timestamp})
+		if err := a.dbWrap.runTransaction([]txn.Op{op}); err != nil {
+			return 0, errors.Annotate(err, "cannot update archived oplog segment")
+		}
+		return 0, nil
+	}
+
+	doc := oplogSegmentDoc{
+		ID:    fmt.Sprintf("%016x", uint64(from)),
+		From:  from,
+		To:    latest.Timestamp,
+		Count: count,
+		Size:  size,
+	}
+	if count > 0 {
+		if _, err := file.Seek(0, 0); err != nil {
+			return 0, errors.Trace(err)
+		}
+		if err := a.blobs.PutForBucket(a.modelUUID, a.path(doc.ID), file, size); err != nil {
+			return 0, errors.Annotate(err, "cannot store archived oplog segment")
+		}
+	}
+	op := a.dbWrap.txnOpInsert(doc.ID, &doc)
+	if err := a.dbWrap.runTransaction([]txn.Op{op}); err != nil {
+		return 0, errors.Annotate(err, "cannot add archived oplog segment")
+	}
+	return count, nil
+}
+
+// Prune removes the segments holding only entries made before the
+// given time. The latest segment is always kept, since the next
+// segment continues from it.
+func (a *OplogArchive) Prune(before time.Time) error {
+	segments, err := a.segments()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	limit := mongoTimestamp(before)
+	for i, doc := range segments {
+		if i == len(segments)-1 || doc.To >= limit {
+			break
+		}
+		if doc.Count > 0 {
+			err := a.blobs.RemoveForBucket(a.modelUUID, a.path(doc.ID))
+			if err != nil && !errors.IsNotFound(err) {
+				return errors.Annotatef(err, "cannot remove archived oplog segment %q", doc.ID)
+			}
+		}
+		op := a.dbWrap.txnOpBase(doc.ID)
+		op.Remove = true
+		if err := a.dbWrap.runTransaction([]txn.Op{op}); err != nil {
+			return errors.Annotatef(err, "cannot remove archived oplog segment %q", doc.ID)
+		}
+	}
+	return nil
+}
+
+// WriteOplog writes the archived entries made from since until just
+// before until to OplogFilename in dir. It fails if the archive
+// doesn't hold every entry made in that time.
+func (a *OplogArchive) WriteOplog(dir string, since, until time.Time) error {
+	if !until.After(since) {
+		return errors.Errorf("cannot replay oplog from %v to %v", since, until)
+	}
+	sinceTs, untilTs := mongoTimestamp(since), mongoTimestamp(until)
+
+	segments, err := a.segments()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var needed []oplogSegmentDoc
+	for _, doc := range segments {
+		if doc.To >= sinceTs && doc.From < untilTs {
+			needed = append(needed, doc)
+		}
+	}
+	if len(needed) == 0 {
+		return errors.Errorf("no archived oplog covers %v to %v", since, until)
+	}
+	if first := needed[0]; first.From > sinceTs {
+		return errors.Errorf("archived oplog starts at %v, after %v", timestampTime(first.From), since)
+	}
+	for i := 1; i < len(needed); i++ {
+		if needed[i].From != needed[i-1].To {
+			return errors.Errorf(
+				"archived oplog is missing entries between %v and %v",
+				timestampTime(needed[i-1].To), timestampTime(needed[i].From),
+			)
+		}
+	}
+	// Oplog timestamps only grow, so every entry made before until
+	// has been archived once an entry made after it has been.
+	if last := needed[len(needed)-1]; last.To < untilTs {
+		return errors.Errorf("archived oplog only reaches %v", timestampTime(last.To))
+	}
+
+	file, err := os.Create(filepath.Join(dir, OplogFilename))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer file.Close()
+	for _, doc := range needed {
+		if doc.Count == 0 {
+			continue
+		}
+		if err := a.copySegment(file, doc.ID, sinceTs, untilTs); err != nil {
+			return errors.Annotatef(err, "cannot read archived oplog segment %q", doc.ID)
+		}
+	}
+	return errors.Trace(file.Close())
+}
+
+// copySegment writes the entries of the identified segment with
+// timestamps from sinceTs until just before untilTs to w.
+func (a *OplogArchive) copySegment(w io.Writer, id string, sinceTs, untilTs bson.MongoTimestamp) error {
+	r, _, err := a.blobs.GetForBucket(a.modelUUID, a.path(id))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer r.Close()
+
+	var header [4]byte
+	for {
+		if _, err := io.ReadFull(r, header[:]); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		size := int(binary.LittleEndian.Uint32(header[:]))
+		if size < len(header) {
+			return errors.Errorf("invalid oplog entry size %d", size)
+		}
+		data := make([]byte, size)
+		copy(data, header[:])
+		if _, err := io.ReadFull(r, data[len(header):]); err != nil {
+			return errors.Trace(err)
+		}
+		var entry oplogEntry
+		if err := bson.Unmarshal(data, &entry); err != nil {
+			return errors.Trace(err)
+		}
+		if entry.Timestamp < sinceTs || entry.Timestamp >= untilTs {
+			continue
+		}
+		if _, err := w.Write(data); err != nil {
+			return errors.Trace(err)
+		}
+	}
+}
+
+// TailOplog returns an OplogTailer which reports, as they're made, the
+// entries in the controller's oplog which need archiving, starting
+// from the given time.
+func TailOplog(st DB, since time.Time) *mongo.OplogTailer {
+	oplog := mongo.GetOplog(st.MongoSession())
+	session := mongo.NewOplogSession(oplog, archivedOplogSelector())
+	return mongo.NewOplogTailer(session, since)
+}
+
+// ArchiveOplog archives the entries made in the controller's oplog
+// since it was last called, and prunes the archived entries made
+// before the oldest stored backup, which no restore can use. Without
+// any backups, it prunes the entries made before now.
+func ArchiveOplog(st DB, now time.Time) error {
+	archive := NewOplogArchive(st)
+	defer archive.Close()
+
+	oplog := archive.dbWrap.session.DB("local").C("oplog.rs")
+	count, err := archive.Archive(oplog)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	logger.Debugf("archived %d oplog entries", count)
+
+	var oldest storageMetaDoc
+	backups := archive.dbWrap.db.C(storageMetaName)
+	err = backups.Find(nil).Sort("started").One(&oldest)
+	if err == mgo.ErrNotFound {
+		// Without backups there's nothing to replay the oplog onto.
+		return errors.Trace(archive.Prune(now))
+	} else if err != nil {
+		return errors.Annotate(err, "cannot read backup metadata")
+	}
+	return errors.Trace(archive.Prune(metadocUnixToTime(oldest.Started)))
+}
@@ -0,0 +1,206 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"encoding/binary"
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/testing"
+)
+
+type oplogSuite struct {
+	gitjujutesting.MgoSuite
+	testing.BaseSuite
+	State   *state.State
+	oplog   *mgo.Collection
+	archive *backups.OplogArchive
+}
+
+var _ = gc.Suite(&oplogSuite{})
+
+func (s *oplogSuite) SetUpSuite(c *gc.C) {
+	s.BaseSuite.SetUpSuite(c)
+	s.MgoSuite.SetUpSuite(c)
+}
+
+func (s *oplogSuite) TearDownSuite(c *gc.C) {
+	s.MgoSuite.TearDownSuite(c)
+	s.BaseSuite.TearDownSuite(c)
+}
+
+func (s *oplogSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.MgoSuite.SetUpTest(c)
+	s.State = statetesting.NewState(c)
+	// The test mongo isn't a replica set, so stand in for its oplog.
+	s.oplog = s.State.MongoSession().DB("juju").C("fakeoplog")
+	s.archive = backups.NewOplogArchive(s.State)
+}
+
+func (s *oplogSuite) TearDownTest(c *gc.C) {
+	if s.archive != nil {
+		s.archive.Close()
+	}
+	if s.State != nil {
+		s.State.Close()
+	}
+	s.MgoSuite.TearDownTest(c)
+	s.BaseSuite.TearDownTest(c)
+}
+
+func timestamp(seconds int64, ordinal int) bson.MongoTimestamp {
+	return bson.MongoTimestamp(seconds<<32 | int64(ordinal))
+}
+
+func (s *oplogSuite) addEntry(c *gc.C, ts bson.MongoTimestamp, op, ns string) {
+	err := s.oplog.Insert(bson.D{
+		{"ts", ts},
+		{"op", op},
+		{"ns", ns},
+		{"o", bson.D{{"_id", "foo"}}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *oplogSuite) archiveEntries(c *gc.C, expected int) {
+	count, err := s.archive.Archive(s.oplog)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, expected)
+}
+
+func (s *oplogSuite) writeOplog(c *gc.C, since, until int64) ([]bson.MongoTimestamp, error) {
+	dir := c.MkDir()
+	err := s.archive.WriteOplog(dir, time.Unix(since, 0), time.Unix(until, 0))
+	if err != nil {
+		return nil, err
+	}
+	data, err := ioutil.ReadFile(filepath.Join(dir, backups.OplogFilename))
+	c.Assert(err, jc.ErrorIsNil)
+	var result []bson.MongoTimestamp
+	for len(data) > 0 {
+		size := binary.LittleEndian.Uint32(data)
+		var entry struct {
+			Timestamp bson.MongoTimestamp `bson:"ts"`
+		}
+		err := bson.Unmarshal(data[:size], &entry)
+		c.Assert(err, jc.ErrorIsNil)
+		result = append(result, entry.Timestamp)
+		data = data[size:]
+	}
+	return result, nil
+}
+
+func (s *oplogSuite) TestArchiveAndWriteOplog(c *gc.C) {
+	s.addEntry(c, timestamp(100, 1), "i", "juju.machines")
+	s.addEntry(c, timestamp(200, 1), "u", "juju.machines")
+	s.addEntry(c, timestamp(200, 2), "i", "backups.metadata")
+	s.addEntry(c, timestamp(250, 1), "n", "")
+	s.addEntry(c, timestamp(300, 1), "d", "juju.applications")
+	s.archiveEntries(c, 3)
+
+	// Nothing new, nothing archived.
+	s.archiveEntries(c, 0)
+
+	s.addEntry(c, timestamp(400, 1), "i", "juju.models")
+	s.addEntry(c, timestamp(400, 2), "i", "admin.system.users")
+	s.archiveEntries(c, 1)
+
+	entries, err := s.writeOplog(c, 150, 350)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(entries, jc.DeepEquals, []bson.MongoTimestamp{timestamp(200, 1), timestamp(300, 1)})
+
+	entries, err = s.writeOplog(c, 100, 400)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(entries, jc.DeepEquals, []bson.MongoTimestamp{
+		timestamp(100, 1), timestamp(200, 1), timestamp(300, 1),
+	})
+}
+
+func (s *oplogSuite) TestArchiveExtendsSegmentWithoutEntries(c *gc.C) {
+	s.addEntry(c, timestamp(100, 1), "i", "juju.machines")
+	s.archiveEntries(c, 1)
+	s.addEntry(c, timestamp(200, 1), "n", "")
+	s.archiveEntries(c, 0)
+
+	// The archive now covers the time of the noop.
+	entries, err := s.writeOplog(c, 150, 200)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(entries, gc.HasLen, 0)
+}
+
+func (s *oplogSuite) TestWriteOplogBeyondArchive(c *gc.C) {
+	s.addEntry(c, timestamp(100, 1), "i", "juju.machines")
+	s.addEntry(c, timestamp(200, 1), "i", "juju.machines")
+	s.archiveEntries(c, 2)
+
+	_, err := s.writeOplog(c, 150, 300)
+	c.Assert(err, gc.ErrorMatches, `archived oplog only reaches 1970-01-01 00:03:20 \+0000 UTC`)
+}
+
+func (s *oplogSuite) TestWriteOplogBeforeArchive(c *gc.C) {
+	s.addEntry(c, timestamp(100, 1), "i", "juju.machines")
+	s.addEntry(c, timestamp(200, 1), "i", "juju.machines")
+	s.archiveEntries(c, 2)
+
+	_, err := s.writeOplog(c, 50, 150)
+	c.Assert(err, gc.ErrorMatches, `archived oplog starts at .*, after .*`)
+}
+
+func (s *oplogSuite) TestWriteOplogGap(c *gc.C) {
+	s.addEntry(c, timestamp(100, 1), "i", "juju.machines")
+	s.addEntry(c, timestamp(200, 1), "i", "juju.machines")
+	s.archiveEntries(c, 2)
+
+	// The oplog rolls over before the next run of the archiver.
+	_, err := s.oplog.RemoveAll(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.addEntry(c, timestamp(400, 1), "i", "juju.machines")
+	s.addEntry(c, timestamp(500, 1), "i", "juju.machines")
+	s.addEntry(c, timestamp(600, 1), "i", "juju.machines")
+	s.archiveEntries(c, 3)
+
+	_, err = s.writeOplog(c, 150, 450)
+	c.Assert(err, gc.ErrorMatches, `archived oplog is missing entries between 1970-01-01 00:03:20 \+0000 UTC and .*`)
+
+	// Times after the gap can still be replayed.
+	entries, err := s.writeOplog(c, 450, 600)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(entries, jc.DeepEquals, []bson.MongoTimestamp{timestamp(500, 1)})
+}
+
+func (s *oplogSuite) TestPrune(c *gc.C) {
+	for _, seconds := range []int64{100, 200, 300, 400} {
+		s.addEntry(c, timestamp(seconds, 1), "i", "juju.machines")
+		s.archiveEntries(c, 1)
+	}
+
+	err := s.archive.Prune(time.Unix(250, 0))
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.writeOplog(c, 150, 400)
+	c.Assert(err, gc.ErrorMatches, `archived oplog starts at .*, after .*`)
+	entries, err := s.writeOplog(c, 250, 400)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(entries, jc.DeepEquals, []bson.MongoTimestamp{timestamp(300, 1)})
+
+	// The latest segment is always kept.
+	err = s.archive.Prune(time.Unix(1000, 0))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.writeOplog(c, 250, 400)
+	c.Assert(err, gc.ErrorMatches, `archived oplog starts at .*, after .*`)
+	_, err = s.writeOplog(c, 350, 400)
+	c.Assert(err, jc.ErrorIsNil)
+}
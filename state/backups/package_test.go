@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/utils/os"
 
+	"github.com/juju/juju/component/all"
 	"github.com/juju/juju/testing"
 )
 
@@ -16,3 +17,10 @@ func Test(t *stdtesting.T) {
 		testing.MgoTestPackage(t)
 	}
 }
+
+func init() {
+	// Required for resources.
+	if err := all.RegisterForServer(); err != nil {
+		panic(err)
+	}
+}
@@ -4,9 +4,12 @@
 package backups
 
 import (
+	"time"
+
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state"
 )
 
 // RestoreArgs holds the args to be used to call state/backups.Restore
@@ -16,4 +19,36 @@ type RestoreArgs struct {
 	NewInstId      instance.Id
 	NewInstTag     names.Tag
 	NewInstSeries  string
+
+	// ToTime, if set, is the moment up to which the operations
+	// archived from the oplog since the backup was made are replayed
+	// once the backup is restored.
+	ToTime time.Time
+
+	// Oplog supplies the archived operations to replay. It must be
+	// set if ToTime is.
+	Oplog OplogSource
+}
+
+// OplogSource supplies archived oplog entries for replay.
+type OplogSource interface {
+	// WriteOplog writes the entries made from since until just before
+	// until to OplogFilename in dir.
+	WriteOplog(dir string, since, until time.Time) error
+}
+
+// ExtractModelArgs holds the args to be used to call
+// state/backups.ExtractModel.
+type ExtractModelArgs struct {
+	// Model identifies the model to extract: its UUID, its name, or
+	// its owner and name as "owner/name".
+	Model string
+
+	// ServingInfo holds the certificate and key with which the
+	// backed up database is served while the model is extracted.
+	ServingInfo state.StateServingInfo
+
+	// CACert is the certificate of the authority that signed the
+	// ServingInfo certificate.
+	CACert string
 }
@@ -44,6 +44,10 @@ type FakeBackups struct {
 	InstanceId instance.Id
 	// ArchiveArg holds the backup archive that was passed in.
 	ArchiveArg io.Reader
+	// ExtractArg holds the ExtractModelArgs that was passed in.
+	ExtractArg backups.ExtractModelArgs
+	// ModelArchive holds the model archive to write.
+	ModelArchive []byte
 }
 
 var _ backups.Backups = (*FakeBackups)(nil)
@@ -104,6 +108,18 @@ func (b *FakeBackups) Restore(bkpId string, dbInfo *backups.DBInfo, args backups
 	return nil, errors.Trace(b.Error)
 }
 
+// ExtractModel writes the model archive of a juju backup.
+func (b *FakeBackups) ExtractModel(id string, args backups.ExtractModelArgs, w io.Writer) error {
+	b.Calls = append(b.Calls, "ExtractModel")
+	b.IDArg = id
+	b.ExtractArg = args
+	if b.Error != nil {
+		return errors.Trace(b.Error)
+	}
+	_, err := w.Write(b.ModelArchive)
+	return errors.Trace(err)
+}
+
 // TODO(ericsnow) FakeStorage should probably move over to the utils repo.
 
 // FakeStorage is a FileStorage implementation to use when testing
@@ -30,6 +30,8 @@ func (s *ControllerConfigSuite) TestControllerAndModelConfigInitialisation(c *gc
 		controller.AutocertDNSNameKey:  true,
 		controller.AllowModelAccessKey: true,
 		controller.MongoMemoryProfile:  true,
+
+		controller.MaxConcurrentMigrations: true,
 	}
 	for _, controllerAttr := range controller.ControllerOnlyConfigAttributes {
 		v, ok := controllerSettings.Get(controllerAttr)
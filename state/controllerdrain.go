@@ -0,0 +1,211 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"github.com/juju/utils/set"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// controllerDrainDoc records the progress of draining a controller
+// machine. It is created when the drain starts and removed when the
+// machine is undrained.
+type controllerDrainDoc struct {
+	Id        string `bson:"_id"`
+	MachineId string `bson:"machine-id"`
+
+	// AgentConnections holds the number of remote agents still
+	// connected to the machine's API server, as last reported by
+	// that server, or -1 if it hasn't reported yet.
+	AgentConnections int `bson:"agent-connections"`
+}
+
+func controllerDrainKey(machineId string) string {
+	return "controllerDrain#" + machineId
+}
+
+// ControllerDrainStatus describes how far the draining of a controller
+// machine has got.
+type ControllerDrainStatus struct {
+	// Draining is true if the machine is being drained.
+	Draining bool
+
+	// AgentConnections holds the number of remote agents still
+	// connected to the machine's API server, or -1 if the server
+	// hasn't yet reported since the drain started.
+	AgentConnections int
+}
+
+// DrainController marks the controller machine with the given id as
+// draining. Its API server stops accepting logins from remote agents
+// and closes their connections, and its address is no longer
+// published to agents. At least one other controller must remain
+// available.
+func (st *State) DrainController(machineId string) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		info, err := st.ControllerInfo()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		draining := set.NewStrings(info.DrainingMachineIds...)
+		if !set.NewStrings(info.MachineIds...).Contains(machineId) {
+			return nil, errors.Errorf("machine %s is not a controller", machineId)
+		}
+		if draining.Contains(machineId) {
+			return nil, jujutxn.ErrNoOperations
+		}
+		available := set.NewStrings(info.MachineIds...).Difference(draining).SortedValues()
+		if len(available) < 2 {
+			return nil, errors.New("cannot drain the only available controller")
+		}
+		ops := []txn.Op{{
+			C:  controllersC,
+			Id: modelGlobalKey,
+			Assert: bson.D{
+				{"machineids", machineId},
+				// None of the available controllers may have
+				// started draining in the meantime.
+				{"draining-machine-ids", bson.D{{"$nin", available}}},
+			},
+			Update: bson.D{{"$addToSet", bson.D{{"draining-machine-ids", machineId}}}},
+		}}
+		resetOps, err := st.resetControllerDrainOps(machineId)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(ops, resetOps...), nil
+	}
+	if err := st.run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot drain controller %s", machineId)
+	}
+	return nil
+}
+
+// UndrainController reverses DrainController, returning the controller
+// machine with the given id to normal service.
+func (st *State) UndrainController(machineId string) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		info, err := st.ControllerInfo()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if !set.NewStrings(info.DrainingMachineIds...).Contains(machineId) {
+			return nil, jujutxn.ErrNoOperations
+		}
+		ops := []txn.Op{{
+			C:      controllersC,
+			Id:     modelGlobalKey,
+			Assert: bson.D{{"draining-machine-ids", machineId}},
+			Update: bson.D{{"$pull", bson.D{{"draining-machine-ids", machineId}}}},
+		}}
+		exists, err := st.controllerDrainDocExists(machineId)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if exists {
+			ops = append(ops, txn.Op{
+				C:      controllersC,
+				Id:     controllerDrainKey(machineId),
+				Assert: txn.DocExists,
+				Remove: true,
+			})
+		}
+		return ops, nil
+	}
+	if err := st.run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot undrain controller %s", machineId)
+	}
+	return nil
+}
+
+// ControllerDrainStatus reports whether the controller machine with
+// the given id is being drained, and how many remote agents are still
+// connected to it.
+func (st *State) ControllerDrainStatus(machineId string) (ControllerDrainStatus, error) {
+	info, err := st.ControllerInfo()
+	if err != nil {
+		return ControllerDrainStatus{}, errors.Trace(err)
+	}
+	if !set.NewStrings(info.MachineIds...).Contains(machineId) {
+		return ControllerDrainStatus{}, errors.NotFoundf("controller %s", machineId)
+	}
+	if !set.NewStrings(info.DrainingMachineIds...).Contains(machineId) {
+		return ControllerDrainStatus{}, nil
+	}
+
+	controllers, closer := st.getCollection(controllersC)
+	defer closer()
+	var doc controllerDrainDoc
+	err = controllers.FindId(controllerDrainKey(machineId)).One(&doc)
+	if err == mgo.ErrNotFound {
+		return ControllerDrainStatus{Draining: true, AgentConnections: -1}, nil
+	} else if err != nil {
+		return ControllerDrainStatus{}, errors.Trace(err)
+	}
+	return ControllerDrainStatus{
+		Draining:         true,
+		AgentConnections: doc.AgentConnections,
+	}, nil
+}
+
+// SetControllerDrainConnections records the number of remote agents
+// still connected to the API server of a draining controller machine.
+// It returns a NotFound error if the machine isn't being drained.
+func (st *State) SetControllerDrainConnections(machineId string, count int) error {
+	ops := []txn.Op{{
+		C:      controllersC,
+		Id:     controllerDrainKey(machineId),
+		Assert: txn.DocExists,
+		Update: bson.D{{"$set", bson.D{{"agent-connections", count}}}},
+	}}
+	err := st.runTransaction(ops)
+	if err == txn.ErrAborted {
+		return errors.NotFoundf("drain of controller %s", machineId)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot record drain progress of controller %s", machineId)
+	}
+	return nil
+}
+
+// resetControllerDrainOps returns the operations needed to start
+// tracking the drain of a controller machine afresh.
+func (st *State) resetControllerDrainOps(machineId string) ([]txn.Op, error) {
+	key := controllerDrainKey(machineId)
+	exists, err := st.controllerDrainDocExists(machineId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if exists {
+		return []txn.Op{{
+			C:      controllersC,
+			Id:     key,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{{"agent-connections", -1}}}},
+		}}, nil
+	}
+	return []txn.Op{{
+		C:      controllersC,
+		Id:     key,
+		Assert: txn.DocMissing,
+		Insert: &controllerDrainDoc{
+			Id:               key,
+			MachineId:        machineId,
+			AgentConnections: -1,
+		},
+	}}, nil
+}
+
+func (st *State) controllerDrainDocExists(machineId string) (bool, error) {
+	controllers, closer := st.getCollection(controllersC)
+	defer closer()
+	n, err := controllers.FindId(controllerDrainKey(machineId)).Count()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return n > 0, nil
+}
@@ -0,0 +1,131 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type ControllerDrainSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&ControllerDrainSuite{})
+
+func (s *ControllerDrainSuite) addControllers(c *gc.C, n int) {
+	for i := 0; i < n; i++ {
+		_, err := s.State.AddMachine("quantal", state.JobManageModel)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
+func (s *ControllerDrainSuite) TestDrainController(c *gc.C) {
+	s.addControllers(c, 2)
+
+	err := s.State.DrainController("1")
+	c.Assert(err, jc.ErrorIsNil)
+
+	info, err := s.State.ControllerInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(info.DrainingMachineIds, jc.DeepEquals, []string{"1"})
+
+	status, err := s.State.ControllerDrainStatus("1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(status, jc.DeepEquals, state.ControllerDrainStatus{
+		Draining:         true,
+		AgentConnections: -1,
+	})
+	status, err = s.State.ControllerDrainStatus("0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(status, jc.DeepEquals, state.ControllerDrainStatus{})
+}
+
+func (s *ControllerDrainSuite) TestDrainControllerIdempotent(c *gc.C) {
+	s.addControllers(c, 2)
+
+	err := s.State.DrainController("1")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.DrainController("1")
+	c.Assert(err, jc.ErrorIsNil)
+
+	info, err := s.State.ControllerInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(info.DrainingMachineIds, jc.DeepEquals, []string{"1"})
+}
+
+func (s *ControllerDrainSuite) TestDrainOnlyAvailableController(c *gc.C) {
+	s.addControllers(c, 2)
+
+	err := s.State.DrainController("0")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.DrainController("1")
+	c.Assert(err, gc.ErrorMatches, "cannot drain controller 1: cannot drain the only available controller")
+}
+
+func (s *ControllerDrainSuite) TestDrainNonController(c *gc.C) {
+	s.addControllers(c, 1)
+	_, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.DrainController("1")
+	c.Assert(err, gc.ErrorMatches, "cannot drain controller 1: machine 1 is not a controller")
+}
+
+func (s *ControllerDrainSuite) TestUndrainController(c *gc.C) {
+	s.addControllers(c, 2)
+	err := s.State.DrainController("1")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetControllerDrainConnections("1", 3)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.UndrainController("1")
+	c.Assert(err, jc.ErrorIsNil)
+
+	info, err := s.State.ControllerInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(info.DrainingMachineIds, gc.HasLen, 0)
+	status, err := s.State.ControllerDrainStatus("1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(status, jc.DeepEquals, state.ControllerDrainStatus{})
+
+	// Undraining a controller which isn't draining does nothing.
+	err = s.State.UndrainController("1")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ControllerDrainSuite) TestSetControllerDrainConnections(c *gc.C) {
+	s.addControllers(c, 2)
+	err := s.State.DrainController("1")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.SetControllerDrainConnections("1", 5)
+	c.Assert(err, jc.ErrorIsNil)
+	status, err := s.State.ControllerDrainStatus("1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(status.AgentConnections, gc.Equals, 5)
+
+	// Draining again starts the count afresh.
+	err = s.State.UndrainController("1")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.DrainController("1")
+	c.Assert(err, jc.ErrorIsNil)
+	status, err = s.State.ControllerDrainStatus("1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(status.AgentConnections, gc.Equals, -1)
+}
+
+func (s *ControllerDrainSuite) TestSetControllerDrainConnectionsNotDraining(c *gc.C) {
+	s.addControllers(c, 2)
+	err := s.State.SetControllerDrainConnections("1", 5)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ControllerDrainSuite) TestControllerDrainStatusNotController(c *gc.C) {
+	_, err := s.State.ControllerDrainStatus("42")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
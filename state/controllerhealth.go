@@ -0,0 +1,172 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// controllerHealthDoc holds the last health report made by the API
+// server of a controller machine.
+type controllerHealthDoc struct {
+	MachineId        string            `bson:"_id"`
+	Updated          int64             `bson:"updated"`
+	APIConnections   int               `bson:"api-connections"`
+	AgentConnections int               `bson:"agent-connections"`
+	WorkerCount      int               `bson:"worker-count"`
+	UnhealthyWorkers []workerHealthDoc `bson:"unhealthy-workers,omitempty"`
+}
+
+type workerHealthDoc struct {
+	Name  string `bson:"name"`
+	State string `bson:"state"`
+	Error string `bson:"error,omitempty"`
+}
+
+// ControllerHealthReport describes the health of a controller machine's
+// agent, as last reported by its API server.
+type ControllerHealthReport struct {
+	// MachineId holds the id of the controller machine.
+	MachineId string
+
+	// Updated holds the time at which the report was made.
+	Updated time.Time
+
+	// APIConnections holds the number of connections to the
+	// machine's API server.
+	APIConnections int
+
+	// AgentConnections holds the number of remote agents logged
+	// in to the machine's API server.
+	AgentConnections int
+
+	// WorkerCount holds the number of workers run by the
+	// machine agent's dependency engine.
+	WorkerCount int
+
+	// UnhealthyWorkers holds the workers which weren't running
+	// when the report was made.
+	UnhealthyWorkers []WorkerHealth
+}
+
+// WorkerHealth describes a worker run by an agent's dependency engine.
+type WorkerHealth struct {
+	Name  string
+	State string
+	Error string
+}
+
+// SetControllerHealthReport records the health of a controller machine,
+// replacing any report previously made for it. Reports are made often
+// and are only informational, so they're written directly rather than
+// in a transaction, as last login times are.
+func (st *State) SetControllerHealthReport(report ControllerHealthReport) error {
+	doc := controllerHealthDoc{
+		MachineId:        report.MachineId,
+		Updated:          report.Updated.UnixNano(),
+		APIConnections:   report.APIConnections,
+		AgentConnections: report.AgentConnections,
+		WorkerCount:      report.WorkerCount,
+	}
+	for _, worker := range report.UnhealthyWorkers {
+		doc.UnhealthyWorkers = append(doc.UnhealthyWorkers, workerHealthDoc{
+			Name:  worker.Name,
+			State: worker.State,
+			Error: worker.Error,
+		})
+	}
+	health, closer := st.getCollection(controllerHealthC)
+	defer closer()
+
+	healthW := health.Writeable()
+
+	// Update the safe mode of the underlying session to not require
+	// write majority, nor sync to disk.
+	session := healthW.Underlying().Database.Session
+	session.SetSafe(&mgo.Safe{})
+
+	if _, err := healthW.UpsertId(doc.MachineId, doc); err != nil {
+		return errors.Annotatef(err, "cannot record health of controller %s", report.MachineId)
+	}
+	return nil
+}
+
+// ControllerHealthReports returns the last health report made for each
+// controller machine, ordered by machine id. Machines which haven't
+// reported are omitted.
+func (st *State) ControllerHealthReports() ([]ControllerHealthReport, error) {
+	info, err := st.ControllerInfo()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	health, closer := st.getCollection(controllerHealthC)
+	defer closer()
+
+	var docs []controllerHealthDoc
+	err = health.Find(bson.D{{"_id", bson.D{{"$in", info.MachineIds}}}}).All(&docs)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot read controller health reports")
+	}
+	var reports []ControllerHealthReport
+	for _, doc := range docs {
+		report := ControllerHealthReport{
+			MachineId:        doc.MachineId,
+			Updated:          time.Unix(0, doc.Updated).UTC(),
+			APIConnections:   doc.APIConnections,
+			AgentConnections: doc.AgentConnections,
+			WorkerCount:      doc.WorkerCount,
+		}
+		for _, worker := range doc.UnhealthyWorkers {
+			report.UnhealthyWorkers = append(report.UnhealthyWorkers, WorkerHealth{
+				Name:  worker.Name,
+				State: worker.State,
+				Error: worker.Error,
+			})
+		}
+		reports = append(reports, report)
+	}
+	sort.Sort(healthReportsByMachineId(reports))
+	return reports, nil
+}
+
+type healthReportsByMachineId []ControllerHealthReport
+
+func (r healthReportsByMachineId) Len() int      { return len(r) }
+func (r healthReportsByMachineId) Swap(i, j int) { r[i], r[j] = r[j], r[i] }
+func (r healthReportsByMachineId) Less(i, j int) bool {
+	return machineIdLessThan(r[i].MachineId, r[j].MachineId)
+}
+
+// PendingTransactionCount returns the number of transactions, across
+// all models, which have not yet been applied or aborted. The query is
+// served by the index on the transaction state, so it only touches
+// the pending transactions.
+func (st *State) PendingTransactionCount() (int, error) {
+	txns, closer := st.getRawCollection(txnsC)
+	defer closer()
+	// These are the mgo/txn states preparing, prepared, aborting
+	// and applying.
+	n, err := txns.Find(bson.D{{"s", bson.D{{"$in", []int{1, 2, 3, 4}}}}}).Count()
+	if err != nil {
+		return 0, errors.Annotate(err, "cannot count pending transactions")
+	}
+	return n, nil
+}
+
+// CleanupBacklog returns the number of cleanups, across all models,
+// which have yet to be run.
+func (st *State) CleanupBacklog() (int, error) {
+	cleanups, closer := st.getRawCollection(cleanupsC)
+	defer closer()
+	n, err := cleanups.Count()
+	if err != nil {
+		return 0, errors.Annotate(err, "cannot count pending cleanups")
+	}
+	return n, nil
+}
@@ -0,0 +1,150 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type ControllerHealthSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&ControllerHealthSuite{})
+
+func (s *ControllerHealthSuite) TestControllerHealthReports(c *gc.C) {
+	for i := 0; i < 2; i++ {
+		_, err := s.State.AddMachine("quantal", state.JobManageModel)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	_, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	reports, err := s.State.ControllerHealthReports()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(reports, gc.HasLen, 0)
+
+	updated := time.Date(2017, 5, 1, 12, 0, 0, 0, time.UTC)
+	report1 := state.ControllerHealthReport{
+		MachineId:        "1",
+		Updated:          updated,
+		APIConnections:   7,
+		AgentConnections: 5,
+		WorkerCount:      30,
+		UnhealthyWorkers: []state.WorkerHealth{{
+			Name:  "peer-grouper",
+			State: "stopped",
+			Error: "boom",
+		}},
+	}
+	report0 := state.ControllerHealthReport{
+		MachineId:      "0",
+		Updated:        updated,
+		APIConnections: 2,
+		WorkerCount:    31,
+	}
+	// Machine 2 isn't a controller, so its report is ignored.
+	report2 := state.ControllerHealthReport{MachineId: "2", Updated: updated}
+	for _, report := range []state.ControllerHealthReport{report1, report0, report2} {
+		err := s.State.SetControllerHealthReport(report)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	reports, err = s.State.ControllerHealthReports()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(reports, jc.DeepEquals, []state.ControllerHealthReport{report0, report1})
+}
+
+func (s *ControllerHealthSuite) TestSetControllerHealthReportReplaces(c *gc.C) {
+	_, err := s.State.AddMachine("quantal", state.JobManageModel)
+	c.Assert(err, jc.ErrorIsNil)
+
+	updated := time.Date(2017, 5, 1, 12, 0, 0, 0, time.UTC)
+	err = s.State.SetControllerHealthReport(state.ControllerHealthReport{
+		MachineId:      "0",
+		Updated:        updated,
+		APIConnections: 3,
+		UnhealthyWorkers: []state.WorkerHealth{{
+			Name:  "certupdater",
+			State: "starting",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	latest := state.ControllerHealthReport{
+		MachineId:      "0",
+		Updated:        updated.Add(time.Minute),
+		APIConnections: 4,
+		WorkerCount:    12,
+	}
+	err = s.State.SetControllerHealthReport(latest)
+	c.Assert(err, jc.ErrorIsNil)
+
+	reports, err := s.State.ControllerHealthReports()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(reports, jc.DeepEquals, []state.ControllerHealthReport{latest})
+}
+
+func (s *ControllerHealthSuite) TestSetControllerHealthReportRunsNoTransaction(c *gc.C) {
+	txns := s.State.MongoSession().DB("juju").C("txns")
+	before, err := txns.Count()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.SetControllerHealthReport(state.ControllerHealthReport{
+		MachineId: "0",
+		Updated:   time.Date(2017, 5, 1, 12, 0, 0, 0, time.UTC),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	after, err := txns.Count()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(after, gc.Equals, before)
+}
+
+func (s *ControllerHealthSuite) TestPendingTransactionCount(c *gc.C) {
+	_, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Every transaction run through state completes.
+	count, err := s.State.PendingTransactionCount()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(count, gc.Equals, 0)
+}
+
+func (s *ControllerHealthSuite) TestPendingTransactionCountIndexed(c *gc.C) {
+	txns := s.State.MongoSession().DB("juju").C("txns")
+	indexes, err := txns.Indexes()
+	c.Assert(err, jc.ErrorIsNil)
+	var keys [][]string
+	for _, index := range indexes {
+		keys = append(keys, index.Key)
+	}
+	c.Check(keys, jc.DeepContains, [][]string{{"s"}})
+}
+
+func (s *ControllerHealthSuite) TestCleanupBacklog(c *gc.C) {
+	count, err := s.State.CleanupBacklog()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(count, gc.Equals, 0)
+
+	// Destroying a hosted model with a machine schedules cleanups in
+	// that model, which are counted by the controller.
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	factory.NewFactory(st).MakeMachine(c, nil)
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	err = model.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	count, err = s.State.CleanupBacklog()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(count, gc.Equals, 2)
+}
@@ -0,0 +1,130 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"github.com/juju/utils/set"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+const (
+	// DefaultMemberPriority is the replica set election priority of
+	// a voting controller machine whose priority hasn't been chosen
+	// by an operator.
+	DefaultMemberPriority = 1.0
+
+	// MaxMemberPriority is the highest replica set election priority
+	// mongo allows.
+	MaxMemberPriority = 1000.0
+)
+
+// SetControllerMemberVoting records whether an operator wants the
+// controller machine with the given id to have a vote in peer
+// election. A machine marked as non-voting, such as a member kept in
+// a remote region for disaster recovery, still holds a copy of the
+// database but is never given a vote or elected primary, and
+// EnableHA neither promotes nor removes it.
+//
+// The peergrouper moves votes only when the number of voting members
+// remains odd, so marking a machine as non-voting may not take effect
+// until another voting controller is available.
+func (st *State) SetControllerMemberVoting(machineId string, voting bool) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		info, err := st.ControllerInfo()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if !set.NewStrings(info.MachineIds...).Contains(machineId) {
+			return nil, errors.Errorf("machine %s is not a controller", machineId)
+		}
+		nonVoting := set.NewStrings(info.NonVotingMachineIds...)
+		if nonVoting.Contains(machineId) != voting {
+			return nil, jujutxn.ErrNoOperations
+		}
+		m, err := st.Machine(machineId)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if m.Life() != Alive {
+			return nil, errors.Errorf("machine %s is not alive", machineId)
+		}
+		if voting {
+			return []txn.Op{{
+				C:      machinesC,
+				Id:     m.doc.DocID,
+				Assert: isAliveDoc,
+				Update: bson.D{{"$set", bson.D{{"novote", false}}}},
+			}, {
+				C:      controllersC,
+				Id:     modelGlobalKey,
+				Assert: bson.D{{"non-voting-machine-ids", machineId}},
+				Update: bson.D{
+					{"$pull", bson.D{{"non-voting-machine-ids", machineId}}},
+					{"$addToSet", bson.D{{"votingmachineids", machineId}}},
+				},
+			}}, nil
+		}
+
+		otherVoters := set.NewStrings(info.VotingMachineIds...)
+		otherVoters.Remove(machineId)
+		if otherVoters.IsEmpty() {
+			return nil, errors.New("cannot remove the vote from the last voting controller")
+		}
+		return []txn.Op{{
+			C:      machinesC,
+			Id:     m.doc.DocID,
+			Assert: isAliveDoc,
+			Update: bson.D{{"$set", bson.D{{"novote", true}}}},
+		}, {
+			C:  controllersC,
+			Id: modelGlobalKey,
+			Assert: bson.D{
+				{"machineids", machineId},
+				{"votingmachineids", bson.D{{"$size", len(info.VotingMachineIds)}}},
+				{"non-voting-machine-ids", bson.D{{"$ne", machineId}}},
+			},
+			Update: bson.D{
+				{"$addToSet", bson.D{{"non-voting-machine-ids", machineId}}},
+				{"$pull", bson.D{{"votingmachineids", machineId}}},
+			},
+		}}, nil
+	}
+	if err := st.run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot set voting of controller %s", machineId)
+	}
+	return nil
+}
+
+// SetControllerMemberPriority records the replica set election
+// priority an operator wants the controller machine with the given id
+// to have while it is voting. The voting member with the highest
+// priority is preferred as primary; a priority of 0 means the machine
+// is never elected primary. Setting DefaultMemberPriority removes any
+// previous choice.
+func (st *State) SetControllerMemberPriority(machineId string, priority float64) error {
+	if priority < 0 || priority > MaxMemberPriority {
+		return errors.NotValidf("priority %v (must be between 0 and %v)", priority, MaxMemberPriority)
+	}
+	field := "member-priorities." + machineId
+	update := bson.D{{"$set", bson.D{{field, priority}}}}
+	if priority == DefaultMemberPriority {
+		update = bson.D{{"$unset", bson.D{{field, nil}}}}
+	}
+	ops := []txn.Op{{
+		C:      controllersC,
+		Id:     modelGlobalKey,
+		Assert: bson.D{{"machineids", machineId}},
+		Update: update,
+	}}
+	err := st.runTransaction(ops)
+	if err == txn.ErrAborted {
+		return errors.Errorf("cannot set priority of controller %s: machine %s is not a controller", machineId, machineId)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot set priority of controller %s", machineId)
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/state"
+)
+
+type ControllerMembersSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&ControllerMembersSuite{})
+
+func (s *ControllerMembersSuite) addControllers(c *gc.C, n int) {
+	for i := 0; i < n; i++ {
+		_, err := s.State.AddMachine("quantal", state.JobManageModel)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
+func (s *ControllerMembersSuite) TestSetControllerMemberVoting(c *gc.C) {
+	s.addControllers(c, 3)
+
+	err := s.State.SetControllerMemberVoting("2", false)
+	c.Assert(err, jc.ErrorIsNil)
+
+	info, err := s.State.ControllerInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(info.NonVotingMachineIds, jc.DeepEquals, []string{"2"})
+	c.Check(info.VotingMachineIds, jc.SameContents, []string{"0", "1"})
+	m, err := s.State.Machine("2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(m.WantsVote(), jc.IsFalse)
+
+	// Doing it again changes nothing.
+	err = s.State.SetControllerMemberVoting("2", false)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.SetControllerMemberVoting("2", true)
+	c.Assert(err, jc.ErrorIsNil)
+
+	info, err = s.State.ControllerInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(info.NonVotingMachineIds, gc.HasLen, 0)
+	c.Check(info.VotingMachineIds, jc.SameContents, []string{"0", "1", "2"})
+	err = m.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(m.WantsVote(), jc.IsTrue)
+}
+
+func (s *ControllerMembersSuite) TestSetControllerMemberVotingLastVoter(c *gc.C) {
+	s.addControllers(c, 2)
+
+	err := s.State.SetControllerMemberVoting("0", false)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetControllerMemberVoting("1", false)
+	c.Assert(err, gc.ErrorMatches, "cannot set voting of controller 1: cannot remove the vote from the last voting controller")
+}
+
+func (s *ControllerMembersSuite) TestSetControllerMemberVotingNonController(c *gc.C) {
+	s.addControllers(c, 1)
+	_, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.SetControllerMemberVoting("1", false)
+	c.Assert(err, gc.ErrorMatches, "cannot set voting of controller 1: machine 1 is not a controller")
+}
+
+func (s *ControllerMembersSuite) TestEnableHAKeepsNonVotingMachines(c *gc.C) {
+	s.PatchValue(state.ControllerAvailable, func(m *state.Machine) (bool, error) {
+		return true, nil
+	})
+	_, err := s.State.EnableHA(3, constraints.Value{}, "quantal", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.SetControllerMemberVoting("2", false)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The non-voting machine isn't promoted; a new machine takes its
+	// place among the voters.
+	changes, err := s.State.EnableHA(3, constraints.Value{}, "quantal", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(changes.Added, jc.DeepEquals, []string{"3"})
+	c.Check(changes.Promoted, gc.HasLen, 0)
+	c.Check(changes.Maintained, jc.SameContents, []string{"0", "1", "2"})
+
+	info, err := s.State.ControllerInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(info.MachineIds, jc.SameContents, []string{"0", "1", "2", "3"})
+	c.Check(info.VotingMachineIds, jc.SameContents, []string{"0", "1", "3"})
+
+	// It isn't removed when it's unavailable either.
+	s.PatchValue(state.ControllerAvailable, func(m *state.Machine) (bool, error) {
+		return m.Id() != "2", nil
+	})
+	changes, err = s.State.EnableHA(3, constraints.Value{}, "quantal", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(changes.Removed, gc.HasLen, 0)
+}
+
+func (s *ControllerMembersSuite) TestSetControllerMemberPriority(c *gc.C) {
+	s.addControllers(c, 2)
+
+	err := s.State.SetControllerMemberPriority("0", 10)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetControllerMemberPriority("1", 0)
+	c.Assert(err, jc.ErrorIsNil)
+
+	info, err := s.State.ControllerInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(info.MemberPriorities, jc.DeepEquals, map[string]float64{"0": 10, "1": 0})
+
+	// The default priority clears the operator's choice.
+	err = s.State.SetControllerMemberPriority("0", state.DefaultMemberPriority)
+	c.Assert(err, jc.ErrorIsNil)
+
+	info, err = s.State.ControllerInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(info.MemberPriorities, jc.DeepEquals, map[string]float64{"1": 0})
+}
+
+func (s *ControllerMembersSuite) TestSetControllerMemberPriorityInvalid(c *gc.C) {
+	s.addControllers(c, 1)
+	_, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.SetControllerMemberPriority("0", -1)
+	c.Assert(err, gc.ErrorMatches, `priority -1 \(must be between 0 and 1000\) not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	err = s.State.SetControllerMemberPriority("0", 1001)
+	c.Assert(err, gc.ErrorMatches, `priority 1001 \(must be between 0 and 1000\) not valid`)
+	err = s.State.SetControllerMemberPriority("1", 2)
+	c.Assert(err, gc.ErrorMatches, "cannot set priority of controller 1: machine 1 is not a controller")
+}
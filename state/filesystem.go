@@ -60,6 +60,12 @@ type Filesystem interface {
 	// if it needs to be provisioned. Params returns true if the returned
 	// parameters are usable for provisioning, otherwise false.
 	Params() (FilesystemParams, bool)
+
+	// RequestedSize returns the size in MiB that the filesystem has
+	// been requested to be grown to, if a resize is pending.
+	// RequestedSize returns true if a resize is pending, otherwise
+	// false.
+	RequestedSize() (uint64, bool)
 }
 
 // FilesystemAttachment describes an attachment of a filesystem to a machine.
@@ -111,6 +117,7 @@ type filesystemDoc struct {
 	Binding         string            `bson:"binding,omitempty"`
 	Info            *FilesystemInfo   `bson:"info,omitempty"`
 	Params          *FilesystemParams `bson:"params,omitempty"`
+	RequestedSize   uint64            `bson:"requestedsize,omitempty"`
 }
 
 // filesystemAttachmentDoc records information about a filesystem attachment.
@@ -269,6 +276,11 @@ func (f *filesystem) Params() (FilesystemParams, bool) {
 	return *f.doc.Params, true
 }
 
+// RequestedSize is required to implement Filesystem.
+func (f *filesystem) RequestedSize() (uint64, bool) {
+	return f.doc.RequestedSize, f.doc.RequestedSize > 0
+}
+
 // Status is required to implement StatusGetter.
 func (f *filesystem) Status() (status.StatusInfo, error) {
 	return f.st.FilesystemStatus(f.FilesystemTag())
@@ -895,6 +907,9 @@ func (st *State) SetFilesystemInfo(tag names.FilesystemTag, info FilesystemInfo)
 			}
 		}
 		ops := setFilesystemInfoOps(tag, info, unsetParams)
+		if requestedSize, ok := fs.RequestedSize(); ok && info.Size >= requestedSize {
+			ops = append(ops, filesystemResizedOps(tag, requestedSize)...)
+		}
 		return ops, nil
 	}
 	return st.run(buildTxn)
@@ -0,0 +1,44 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// HookTimeouts describes how long the hooks of an application may run
+// before the unit agent kills them and puts the unit into an error
+// state. A zero timeout means that hooks may run indefinitely.
+type HookTimeouts struct {
+	// Default is the timeout applied to any hook kind that
+	// does not have a specific timeout in Hooks.
+	Default time.Duration `bson:"default,omitempty"`
+
+	// Hooks holds timeouts keyed on hook kind, such as "install"
+	// or "relation-changed".
+	Hooks map[string]time.Duration `bson:"hooks,omitempty"`
+}
+
+// Validate returns an error if the timeouts are not valid.
+func (t HookTimeouts) Validate() error {
+	if t.Default < 0 {
+		return errors.NotValidf("negative default hook timeout %v", t.Default)
+	}
+	for kind, timeout := range t.Hooks {
+		if kind == "" {
+			return errors.NotValidf("hook timeout with empty hook kind")
+		}
+		if timeout < 0 {
+			return errors.NotValidf("negative %q hook timeout %v", kind, timeout)
+		}
+	}
+	return nil
+}
+
+// IsZero returns whether no timeouts are set at all.
+func (t HookTimeouts) IsZero() bool {
+	return t.Default == 0 && len(t.Hooks) == 0
+}
@@ -1065,6 +1065,63 @@ func (m *Machine) Units() (units []*Unit, err error) {
 	return units, nil
 }
 
+// EgressRules returns the destinations to which the units assigned to
+// the machine are allowed to connect. Egress can only be restricted
+// per machine, as the units share the machine's network, so it is
+// only restricted if all of the units' applications have egress rules.
+// The rules of all of them are then combined, along with the rules
+// required to reach the units of related remote applications. If any
+// of the applications has no egress rules, no rules are returned and
+// egress is not restricted, so that its units are not cut off.
+func (m *Machine) EgressRules() ([]network.EgressRule, error) {
+	units, err := m.Units()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var (
+		rules    []network.EgressRule
+		seen     = make(map[string]bool)
+		appended = make(map[network.EgressRule]bool)
+	)
+	add := func(in []network.EgressRule) {
+		for _, rule := range in {
+			if !appended[rule] {
+				appended[rule] = true
+				rules = append(rules, rule)
+			}
+		}
+	}
+	for _, unit := range units {
+		appName := unit.ApplicationName()
+		if seen[appName] {
+			continue
+		}
+		seen[appName] = true
+		app, err := m.st.Application(appName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		appRules, err := app.EgressRules()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if len(appRules) == 0 {
+			return nil, nil
+		}
+		add(appRules)
+		remoteRules, err := app.RemoteEgressRules()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		add(remoteRules)
+	}
+	if len(seen) == 0 {
+		return nil, nil
+	}
+	network.SortEgressRules(rules)
+	return rules, nil
+}
+
 // XXX(jam): 2016-12-09 These are just copied from
 // provider/maas/constraints.go, but they should be tied to machine
 // constraints, *not* tied to provider/maas constraints.
@@ -1344,7 +1401,7 @@ func (m *Machine) setPreferredAddressOps(addr address, isPublic bool) []txn.Op {
 	return ops
 }
 
-func (m *Machine) setPublicAddressOps(providerAddresses []address, machineAddresses []address) ([]txn.Op, address, bool) {
+func (m *Machine) setPublicAddressOps(providerAddresses []address, machineAddresses []address, pref network.AddressPreference) ([]txn.Op, address, bool) {
 	publicAddress := m.doc.PreferredPublicAddress
 	logger.Tracef("machine %v: current public address: %#v \nprovider addresses: %#v \nmachine addresses: %#v", m.Id(), publicAddress, providerAddresses, machineAddresses)
 	// Always prefer an exact match if available.
@@ -1353,7 +1410,7 @@ func (m *Machine) setPublicAddressOps(providerAddresses []address, machineAddres
 	}
 	// Without an exact match, prefer a fallback match.
 	getAddr := func(addresses []address) network.Address {
-		addr, _ := network.SelectPublicAddress(networkAddresses(addresses))
+		addr, _ := pref.SelectPublicAddress(networkAddresses(addresses))
 		return addr
 	}
 
@@ -1367,7 +1424,7 @@ func (m *Machine) setPublicAddressOps(providerAddresses []address, machineAddres
 	return ops, newAddr, true
 }
 
-func (m *Machine) setPrivateAddressOps(providerAddresses []address, machineAddresses []address) ([]txn.Op, address, bool) {
+func (m *Machine) setPrivateAddressOps(providerAddresses []address, machineAddresses []address, pref network.AddressPreference) ([]txn.Op, address, bool) {
 	privateAddress := m.doc.PreferredPrivateAddress
 	// Always prefer an exact match if available.
 	checkScope := func(addr address) bool {
@@ -1375,7 +1432,7 @@ func (m *Machine) setPrivateAddressOps(providerAddresses []address, machineAddre
 	}
 	// Without an exact match, prefer a fallback match.
 	getAddr := func(addresses []address) network.Address {
-		addr, _ := network.SelectInternalAddress(networkAddresses(addresses), false)
+		addr, _ := pref.SelectInternalAddress(networkAddresses(addresses), false)
 		return addr
 	}
 
@@ -1442,8 +1499,14 @@ func (m *Machine) setAddresses(addresses []network.Address, field *[]address, fi
 	addressesToSet := make([]network.Address, len(addresses))
 	copy(addressesToSet, addresses)
 
+	cfg, err := m.st.ModelConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	pref := cfg.AddressPreference()
+
 	// Update addresses now.
-	network.SortAddresses(addressesToSet)
+	pref.SortAddresses(addressesToSet)
 	origin := OriginProvider
 	if fieldName == "machineaddresses" {
 		origin = OriginMachine
@@ -1453,7 +1516,6 @@ func (m *Machine) setAddresses(addresses []network.Address, field *[]address, fi
 	var (
 		newPrivate, newPublic         address
 		changedPrivate, changedPublic bool
-		err                           error
 	)
 	machine := m
 	buildTxn := func(attempt int) ([]txn.Op, error) {
@@ -1483,8 +1545,8 @@ func (m *Machine) setAddresses(addresses []network.Address, field *[]address, fi
 		}
 
 		var setPrivateAddressOps, setPublicAddressOps []txn.Op
-		setPrivateAddressOps, newPrivate, changedPrivate = machine.setPrivateAddressOps(providerAddresses, machineAddresses)
-		setPublicAddressOps, newPublic, changedPublic = machine.setPublicAddressOps(providerAddresses, machineAddresses)
+		setPrivateAddressOps, newPrivate, changedPrivate = machine.setPrivateAddressOps(providerAddresses, machineAddresses, pref)
+		setPublicAddressOps, newPublic, changedPublic = machine.setPublicAddressOps(providerAddresses, machineAddresses, pref)
 		ops = append(ops, setPrivateAddressOps...)
 		ops = append(ops, setPublicAddressOps...)
 		return ops, nil
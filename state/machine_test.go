@@ -1213,6 +1213,26 @@ func (s *MachineSuite) TestWatchMachine(c *gc.C) {
 	testing.NewNotifyWatcherC(c, s.State, w).AssertOneChange()
 }
 
+func (s *MachineSuite) TestWatchModelMachineAddresses(c *gc.C) {
+	w := s.State.WatchModelMachineAddresses()
+	defer testing.AssertStop(c, w)
+	wc := testing.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	// Change the addresses of a machine, check one event.
+	err := s.machine.SetProviderAddresses(network.NewAddress("10.0.0.1"))
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Add a container, check one event.
+	_, err = s.State.AddMachineInsideMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}, s.machine.Id(), instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
 func (s *MachineSuite) TestWatchDiesOnStateClose(c *gc.C) {
 	// This test is testing logic in watcher.entityWatcher, which
 	// is also used by:
@@ -1337,6 +1357,88 @@ func (s *MachineSuite) TestWatchPrincipalUnits(c *gc.C) {
 	wc.AssertNoChange()
 }
 
+func (s *MachineSuite) TestEgressRules(c *gc.C) {
+	mysql := s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	wordpress := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	for _, app := range []*state.Application{mysql, wordpress} {
+		unit, err := app.AddUnit()
+		c.Assert(err, jc.ErrorIsNil)
+		err = unit.AssignToMachine(s.machine)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	err := wordpress.SetEgressRules([]network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Egress is not restricted for the machine while any of its
+	// applications has no egress rules.
+	rules, err := s.machine.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, gc.IsNil)
+
+	// Once all of them have egress rules, their rules are
+	// combined.
+	err = mysql.SetEgressRules([]network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+		network.MustNewEgressRule("udp", 53, 53, "10.0.0.0/8"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err = s.machine.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+		network.MustNewEgressRule("udp", 53, 53, "10.0.0.0/8"),
+	})
+
+	// Rules on machines without units are not affected.
+	rules, err = s.machine0.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, gc.HasLen, 0)
+}
+
+func (s *MachineSuite) TestEgressRulesUnrestricted(c *gc.C) {
+	mysql := s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	unit, err := mysql.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(s.machine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	rules, err := s.machine.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, gc.IsNil)
+}
+
+func (s *MachineSuite) TestWatchEgressRules(c *gc.C) {
+	w := s.machine.WatchEgressRules()
+	defer testing.AssertStop(c, w)
+	wc := testing.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	// Adding an application is detected, as are units assigned to
+	// the machine.
+	mysql := s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	wc.AssertOneChange()
+	unit, err := mysql.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+	err = unit.AssignToMachine(s.machine)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Changing the application's egress rules is detected.
+	err = mysql.SetEgressRules([]network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Changes to other machines are not.
+	err = s.machine0.SetProvisioned("cheese", "fake_nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+}
+
 func (s *MachineSuite) TestWatchPrincipalUnitsDiesOnStateClose(c *gc.C) {
 	// This test is testing logic in watcher.unitsWatcher, which
 	// is also used by Unit.WatchSubordinateUnits.
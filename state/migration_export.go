@@ -607,13 +607,20 @@ func (e *exporter) addApplication(ctx addApplicationContext) error {
 		CharmModifiedVersion: application.doc.CharmModifiedVersion,
 		ForceCharm:           application.doc.ForceCharm,
 		Exposed:              application.doc.Exposed,
+		ExposedCIDRs:         application.doc.ExposedCIDRs,
+		ExposedSpaces:        application.doc.ExposedSpaces,
 		MinUnits:             application.doc.MinUnits,
+		EgressRules:          application.doc.EgressRules,
 		EndpointBindings:     map[string]string(ctx.endpoingBindings[globalKey]),
 		Settings:             applicationSettingsDoc.Settings,
 		Leader:               ctx.leader,
 		LeadershipSettings:   leadershipSettingsDoc.Settings,
 		MetricsCredentials:   application.doc.MetricCredentials,
 	}
+	if timeouts := application.doc.HookTimeouts; timeouts != nil {
+		args.DefaultHookTimeout = timeouts.Default
+		args.HookTimeouts = timeouts.Hooks
+	}
 	if constraints, found := e.modelStorageConstraints[storageConstraintsKey]; found {
 		args.StorageConstraints = e.storageConstraints(constraints)
 	}
@@ -1588,10 +1595,13 @@ func (e *exporter) addStorage(instance *storageInstance, attachments []names.Uni
 	args := description.StorageArgs{
 		Tag:         instance.StorageTag(),
 		Kind:        instance.Kind().String(),
-		Owner:       instance.Owner(),
 		Name:        instance.StorageName(),
 		Attachments: attachments,
 	}
+	// Detached storage has no owner.
+	if owner, ok := instance.Owner(); ok {
+		args.Owner = owner
+	}
 	e.model.AddStorage(args)
 	return nil
 }
@@ -346,6 +346,11 @@ func (s *MigrationExportSuite) assertMigrateApplications(c *gc.C, cons constrain
 	c.Assert(err, jc.ErrorIsNil)
 	err = application.SetMetricCredentials([]byte("sekrit"))
 	c.Assert(err, jc.ErrorIsNil)
+	err = application.SetHookTimeouts(state.HookTimeouts{
+		Default: 5 * time.Minute,
+		Hooks:   map[string]time.Duration{"install": time.Hour},
+	})
+	c.Assert(err, jc.ErrorIsNil)
 	err = s.State.SetAnnotations(application, testAnnotations)
 	c.Assert(err, jc.ErrorIsNil)
 	s.primeStatusHistory(c, application, status.Active, addedHistoryCount)
@@ -369,6 +374,10 @@ func (s *MigrationExportSuite) assertMigrateApplications(c *gc.C, cons constrain
 		"leader": "true",
 	})
 	c.Assert(exported.MetricsCredentials(), jc.DeepEquals, []byte("sekrit"))
+	c.Assert(exported.DefaultHookTimeout(), gc.Equals, 5*time.Minute)
+	c.Assert(exported.HookTimeouts(), jc.DeepEquals, map[string]time.Duration{
+		"install": time.Hour,
+	})
 
 	constraints := exported.Constraints()
 	c.Assert(constraints, gc.NotNil)
@@ -960,6 +969,30 @@ func (s *MigrationExportSuite) TestStorage(c *gc.C) {
 	})
 }
 
+func (s *MigrationExportSuite) TestStorageDetached(c *gc.C) {
+	storageTag, err := s.State.AddExistingVolume(state.VolumeInfo{
+		VolumeId: "vol-123",
+		Pool:     "loop",
+		Size:     1024,
+	}, "data")
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	storages := model.Storages()
+	c.Assert(storages, gc.HasLen, 1)
+
+	storage := storages[0]
+	c.Check(storage.Tag(), gc.Equals, storageTag)
+	c.Check(storage.Kind(), gc.Equals, "block")
+	owner, err := storage.Owner()
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(owner, gc.IsNil)
+	c.Check(storage.Name(), gc.Equals, "data")
+	c.Check(storage.Attachments(), gc.HasLen, 0)
+}
+
 func (s *MigrationExportSuite) TestStoragePools(c *gc.C) {
 	pm := poolmanager.New(state.NewStateSettings(s.State), provider.CommonStorageProviders())
 	_, err := pm.Create("test-pool", provider.LoopProviderType, map[string]interface{}{
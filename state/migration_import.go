@@ -84,7 +84,19 @@ func (st *State) Import(model description.Model) (_ *Model, _ *State, err error)
 
 		existingCreds, err := st.CloudCredential(credTag)
 
-		if errors.IsNotFound(err) {
+		if creds.Reference() {
+			// The credential refers to one already on this
+			// controller, as written when a migration remaps the
+			// model's credential.
+			if errors.IsNotFound(err) {
+				return nil, nil, errors.Errorf("credential %q not found", credID)
+			} else if err != nil {
+				return nil, nil, errors.Trace(err)
+			}
+			if existingCreds.Revoked {
+				return nil, nil, errors.Errorf("credential %q is revoked", credID)
+			}
+		} else if errors.IsNotFound(err) {
 			credential := cloud.NewCredential(
 				cloud.AuthType(creds.AuthType()),
 				creds.Attributes())
@@ -877,7 +889,7 @@ func (i *importer) makeApplicationDoc(s description.Application) (*applicationDo
 		return nil, errors.Trace(err)
 	}
 
-	return &applicationDoc{
+	doc := &applicationDoc{
 		Name:                 s.Name(),
 		Series:               s.Series(),
 		Subordinate:          s.Subordinate(),
@@ -889,9 +901,26 @@ func (i *importer) makeApplicationDoc(s description.Application) (*applicationDo
 		UnitCount:            len(s.Units()),
 		RelationCount:        i.relationCount(s.Name()),
 		Exposed:              s.Exposed(),
+		ExposedCIDRs:         s.ExposedCIDRs(),
+		ExposedSpaces:        s.ExposedSpaces(),
 		MinUnits:             s.MinUnits(),
 		MetricCredentials:    s.MetricsCredentials(),
-	}, nil
+	}
+	for _, in := range s.EgressRules() {
+		rule, err := network.ParseEgressRule(in)
+		if err != nil {
+			return nil, errors.Annotatef(err, "application %q egress rule", s.Name())
+		}
+		doc.EgressRules = append(doc.EgressRules, rule.String())
+	}
+	timeouts := HookTimeouts{
+		Default: s.DefaultHookTimeout(),
+		Hooks:   s.HookTimeouts(),
+	}
+	if !timeouts.IsZero() {
+		doc.HookTimeouts = &timeouts
+	}
+	return doc, nil
 }
 
 func (i *importer) relationCount(application string) int {
@@ -1431,10 +1460,12 @@ func (i *importer) addStorageInstance(storage description.Storage) error {
 	doc := &storageInstanceDoc{
 		Id:              storage.Tag().Id(),
 		Kind:            kind,
-		Owner:           owner.String(),
 		StorageName:     storage.Name(),
 		AttachmentCount: len(attachments),
 	}
+	if owner != nil {
+		doc.Owner = owner.String()
+	}
 	ops = append(ops, txn.Op{
 		C:      storageInstancesC,
 		Id:     tag.Id(),
@@ -1442,14 +1473,18 @@ func (i *importer) addStorageInstance(storage description.Storage) error {
 		Insert: doc,
 	})
 
-	refcounts, closer := i.st.getCollection(refcountsC)
-	defer closer()
-	storageRefcountKey := entityStorageRefcountKey(owner, storage.Name())
-	incRefOp, err := nsRefcounts.CreateOrIncRefOp(refcounts, storageRefcountKey, 1)
-	if err != nil {
-		return errors.Trace(err)
+	// Detached storage has no owner, and so is not counted against
+	// any entity's storage.
+	if owner != nil {
+		refcounts, closer := i.st.getCollection(refcountsC)
+		defer closer()
+		storageRefcountKey := entityStorageRefcountKey(owner, storage.Name())
+		incRefOp, err := nsRefcounts.CreateOrIncRefOp(refcounts, storageRefcountKey, 1)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		ops = append(ops, incRefOp)
 	}
-	ops = append(ops, incRefOp)
 
 	if err := i.st.runTransaction(ops); err != nil {
 		return errors.Trace(err)
@@ -5,7 +5,7 @@ package state_test
 
 import (
 	"fmt"
-	"time" // only uses time.Time values
+	"time" // only uses time.Time and time.Duration values
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -15,6 +15,7 @@ import (
 	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/core/description"
 	"github.com/juju/juju/network"
@@ -183,6 +184,52 @@ func (s *MigrationImportSuite) AssertUserEqual(c *gc.C, newUser, oldUser permiss
 	}
 }
 
+func (s *MigrationImportSuite) importWithCredentialReference(c *gc.C, credTag names.CloudCredentialTag) (*state.Model, *state.State, error) {
+	out, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	in := newModel(out, utils.MustNewUUID().String(), "new")
+	in.SetCloudCredential(description.CloudCredentialArgs{
+		Owner:     credTag.Owner(),
+		Cloud:     credTag.Cloud(),
+		Name:      credTag.Name(),
+		Reference: true,
+	})
+	return s.State.Import(in)
+}
+
+func (s *MigrationImportSuite) TestCredentialReference(c *gc.C) {
+	credTag := names.NewCloudCredentialTag(fmt.Sprintf("dummy/%s/other", s.Owner.Id()))
+	cred := cloud.NewCredential(cloud.EmptyAuthType, nil)
+	err := s.State.UpdateCloudCredential(credTag, cred)
+	c.Assert(err, jc.ErrorIsNil)
+
+	newModel, newSt, err := s.importWithCredentialReference(c, credTag)
+	c.Assert(err, jc.ErrorIsNil)
+	defer newSt.Close()
+
+	modelCredTag, ok := newModel.CloudCredential()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(modelCredTag, gc.Equals, credTag)
+}
+
+func (s *MigrationImportSuite) TestCredentialReferenceNotFound(c *gc.C) {
+	credTag := names.NewCloudCredentialTag(fmt.Sprintf("dummy/%s/other", s.Owner.Id()))
+	_, _, err := s.importWithCredentialReference(c, credTag)
+	c.Assert(err, gc.ErrorMatches, `credential "dummy/.*/other" not found`)
+}
+
+func (s *MigrationImportSuite) TestCredentialReferenceRevoked(c *gc.C) {
+	credTag := names.NewCloudCredentialTag(fmt.Sprintf("dummy/%s/other", s.Owner.Id()))
+	cred := cloud.NewCredential(cloud.EmptyAuthType, nil)
+	cred.Revoked = true
+	err := s.State.UpdateCloudCredential(credTag, cred)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, _, err = s.importWithCredentialReference(c, credTag)
+	c.Assert(err, gc.ErrorMatches, `credential "dummy/.*/other" is revoked`)
+}
+
 func (s *MigrationImportSuite) TestModelUsers(c *gc.C) {
 	// To be sure with this test, we create three env users, and remove
 	// the owner.
@@ -326,6 +373,15 @@ func (s *MigrationImportSuite) TestApplications(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	err = application.SetMetricCredentials([]byte("sekrit"))
 	c.Assert(err, jc.ErrorIsNil)
+	err = application.SetHookTimeouts(state.HookTimeouts{
+		Default: 5 * time.Minute,
+		Hooks:   map[string]time.Duration{"install": time.Hour},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = application.SetEgressRules([]network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
 	// Expose the application.
 	c.Assert(application.SetExposed(), jc.ErrorIsNil)
 	err = s.State.SetAnnotations(application, testAnnotations)
@@ -349,6 +405,12 @@ func (s *MigrationImportSuite) TestApplications(c *gc.C) {
 	c.Assert(imported.Series(), gc.Equals, exported.Series())
 	c.Assert(imported.IsExposed(), gc.Equals, exported.IsExposed())
 	c.Assert(imported.MetricCredentials(), jc.DeepEquals, exported.MetricCredentials())
+	c.Assert(imported.HookTimeouts(), jc.DeepEquals, exported.HookTimeouts())
+	importedEgress, err := imported.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	exportedEgress, err := exported.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(importedEgress, jc.DeepEquals, exportedEgress)
 
 	exportedConfig, err := exported.ConfigSettings()
 	c.Assert(err, jc.ErrorIsNil)
@@ -1053,6 +1115,31 @@ func (s *MigrationImportSuite) TestStorage(c *gc.C) {
 	c.Assert(attachments[0].Unit(), gc.Equals, u.UnitTag())
 }
 
+func (s *MigrationImportSuite) TestStorageDetached(c *gc.C) {
+	storageTag, err := s.State.AddExistingVolume(state.VolumeInfo{
+		VolumeId: "vol-123",
+		Pool:     "loop",
+		Size:     1024,
+	}, "data")
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importModel(c)
+
+	instance, err := newSt.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(instance.Kind(), gc.Equals, state.StorageKindBlock)
+	c.Check(instance.StorageName(), gc.Equals, "data")
+	_, ok := instance.Owner()
+	c.Check(ok, jc.IsFalse)
+	c.Check(state.StorageAttachmentCount(instance), gc.Equals, 0)
+
+	volume, err := newSt.StorageInstanceVolume(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	info, err := volume.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(info.VolumeId, gc.Equals, "vol-123")
+}
+
 func (s *MigrationImportSuite) TestStoragePools(c *gc.C) {
 	pm := poolmanager.New(state.NewStateSettings(s.State), provider.CommonStorageProviders())
 	_, err := pm.Create("test-pool", provider.LoopProviderType, map[string]interface{}{
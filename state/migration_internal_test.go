@@ -84,6 +84,8 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		autocertCacheC,
 		// We don't export the controller model at this stage.
 		controllersC,
+		// Controller health reports are controller global.
+		controllerHealthC,
 		// Clouds aren't migrated. They must exist in the
 		// target controller already.
 		cloudsC,
@@ -350,6 +352,9 @@ func (s *MigrationSuite) TestApplicationDocFields(c *gc.C) {
 		// RelationCount is handled by the number of times the application name
 		// appears in relation endpoints.
 		"RelationCount",
+		// Migrations are refused while a rolling charm upgrade is
+		// in progress.
+		"RollingUpgrade",
 	)
 	migrated := set.NewStrings(
 		"Name",
@@ -360,8 +365,12 @@ func (s *MigrationSuite) TestApplicationDocFields(c *gc.C) {
 		"CharmModifiedVersion",
 		"ForceCharm",
 		"Exposed",
+		"ExposedCIDRs",
+		"ExposedSpaces",
 		"MinUnits",
+		"EgressRules",
 		"MetricCredentials",
+		"HookTimeouts",
 	)
 	s.AssertExportedFields(c, applicationDoc{}, migrated.Union(ignored))
 }
@@ -677,6 +686,16 @@ func (s *MigrationSuite) TestVolumeDocFields(c *gc.C) {
 		"ModelUUID",
 		"DocID",
 		"Life",
+		// A pending resize is not migrated; it is
+		// requested again after migration.
+		"RequestedSize",
+		// Only volumes in dying models or applications
+		// are released, and those are not migrated.
+		"Releasing",
+		// Snapshots of machine-scoped volumes are kept
+		// on the machine, and are not migrated.
+		"SnapshotRequested",
+		"Snapshots",
 	)
 	migrated := set.NewStrings(
 		"Name",
@@ -691,7 +710,7 @@ func (s *MigrationSuite) TestVolumeDocFields(c *gc.C) {
 	s.AssertExportedFields(c, VolumeInfo{}, set.NewStrings(
 		"HardwareId", "Size", "Pool", "VolumeId", "Persistent"))
 	s.AssertExportedFields(c, VolumeParams{}, set.NewStrings(
-		"Size", "Pool"))
+		"Size", "Pool", "SnapshotId"))
 }
 
 func (s *MigrationSuite) TestVolumeAttachmentDocFields(c *gc.C) {
@@ -719,6 +738,7 @@ func (s *MigrationSuite) TestFilesystemDocFields(c *gc.C) {
 		"ModelUUID",
 		"DocID",
 		"Life",
+		"RequestedSize",
 	)
 	migrated := set.NewStrings(
 		"FilesystemId",
@@ -672,7 +672,19 @@ func (m *Model) Destroy() error {
 	if m.isControllerModel() {
 		ensureNoHostedModels = true
 	}
-	return m.destroy(ensureNoHostedModels)
+	return m.destroy(ensureNoHostedModels, false)
+}
+
+// DestroyReleasingStorage behaves like Destroy, except that the
+// model's cloud volumes are released rather than destroyed: they are
+// detached and left intact in the cloud, so that they may later be
+// imported into another model.
+func (m *Model) DestroyReleasingStorage() error {
+	ensureNoHostedModels := false
+	if m.isControllerModel() {
+		ensureNoHostedModels = true
+	}
+	return m.destroy(ensureNoHostedModels, true)
 }
 
 // DestroyIncludingHosted sets the model's lifecycle to Dying, preventing
@@ -680,10 +692,10 @@ func (m *Model) Destroy() error {
 // hosting other models, they will also be destroyed.
 func (m *Model) DestroyIncludingHosted() error {
 	ensureNoHostedModels := false
-	return m.destroy(ensureNoHostedModels)
+	return m.destroy(ensureNoHostedModels, false)
 }
 
-func (m *Model) destroy(ensureNoHostedModels bool) (err error) {
+func (m *Model) destroy(ensureNoHostedModels, releaseStorage bool) (err error) {
 	defer errors.DeferredAnnotatef(&err, "failed to destroy model")
 
 	st, closeState, err := m.getState()
@@ -710,6 +722,13 @@ func (m *Model) destroy(ensureNoHostedModels bool) (err error) {
 		} else if err != nil {
 			return nil, errors.Trace(err)
 		}
+		if releaseStorage {
+			releaseOps, err := releaseModelVolumesOps(st)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(releaseOps, ops...)
+		}
 
 		return ops, nil
 	}
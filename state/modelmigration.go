@@ -64,6 +64,14 @@ type ModelMigration interface {
 	// progress of the migration.
 	StatusMessage() string
 
+	// Progress returns structured details about how far the
+	// migration has got through its current phase.
+	Progress() migration.Progress
+
+	// PhaseTimes returns when the migration entered each of the
+	// phases it has been through, in order.
+	PhaseTimes() []migration.PhaseTime
+
 	// InitiatedBy returns username the initiated the migration.
 	InitiatedBy() string
 
@@ -71,6 +79,15 @@ type ModelMigration interface {
 	// migration's target controller.
 	TargetInfo() (*migration.TargetInfo, error)
 
+	// CloudRemap returns the changes to make to the model's cloud
+	// details when it is imported into the target controller.
+	CloudRemap() migration.CloudRemap
+
+	// QueuePosition reports how many active migrations in the
+	// controller were started before this one, along with the
+	// controller's limit on concurrent migrations.
+	QueuePosition() (migration.QueuePosition, error)
+
 	// SetPhase sets the phase of the migration. An error will be
 	// returned if the new phase does not follow the current phase or
 	// if the migration is no longer active.
@@ -80,6 +97,11 @@ type ModelMigration interface {
 	// current progress of the migration.
 	SetStatusMessage(text string) error
 
+	// SetProgress records structured details about how far the
+	// migration has got through its current phase. Progress is
+	// cleared whenever the phase changes.
+	SetProgress(progress migration.Progress) error
+
 	// SubmitMinionReport records a report from a migration minion
 	// worker about the success or failure to complete its actions for
 	// a given migration phase.
@@ -160,6 +182,17 @@ type modelMigDoc struct {
 	// TargetMacaroons holds the macaroons to use with TargetAuthTag
 	// when authenticating.
 	TargetMacaroons string `bson:"target-macaroons,omitempty"`
+
+	// TargetCloud and TargetCloudRegion hold the names of the cloud
+	// and region to use for the model on the target controller, if
+	// they differ from those on the source controller.
+	TargetCloud       string `bson:"target-cloud,omitempty"`
+	TargetCloudRegion string `bson:"target-cloud-region,omitempty"`
+
+	// TargetCredential holds the id of the cloud credential to use
+	// for the model on the target controller, if it differs from the
+	// model's credential.
+	TargetCredential string `bson:"target-credential,omitempty"`
 }
 
 // modelMigStatusDoc tracks the progress of a migration attempt for a
@@ -198,6 +231,26 @@ type modelMigStatusDoc struct {
 	// StatusMessage holds a human readable message about the
 	// migration's progress.
 	StatusMessage string `bson:"status-message"`
+
+	// Progress holds structured details about how far the migration
+	// has got through its current phase.
+	Progress *modelMigProgressDoc `bson:"progress,omitempty"`
+
+	// PhaseTimes records when each phase was entered, in order.
+	PhaseTimes []modelMigPhaseTimeDoc `bson:"phase-times,omitempty"`
+}
+
+type modelMigProgressDoc struct {
+	BinariesUploaded      int64 `bson:"binaries-uploaded"`
+	BinariesTotal         int64 `bson:"binaries-total"`
+	MinionReportsReceived int   `bson:"minion-reports-received"`
+	MinionReportsExpected int   `bson:"minion-reports-expected"`
+}
+
+type modelMigPhaseTimeDoc struct {
+	Phase string `bson:"phase"`
+	// Time is stored as per UnixNano.
+	Time int64 `bson:"time"`
 }
 
 type modelMigMinionSyncDoc struct {
@@ -263,6 +316,38 @@ func (mig *modelMigration) StatusMessage() string {
 	return mig.statusDoc.StatusMessage
 }
 
+// Progress implements ModelMigration.
+func (mig *modelMigration) Progress() migration.Progress {
+	doc := mig.statusDoc.Progress
+	if doc == nil {
+		return migration.Progress{}
+	}
+	return migration.Progress{
+		BinariesUploaded:      doc.BinariesUploaded,
+		BinariesTotal:         doc.BinariesTotal,
+		MinionReportsReceived: doc.MinionReportsReceived,
+		MinionReportsExpected: doc.MinionReportsExpected,
+	}
+}
+
+// PhaseTimes implements ModelMigration.
+func (mig *modelMigration) PhaseTimes() []migration.PhaseTime {
+	var out []migration.PhaseTime
+	for _, doc := range mig.statusDoc.PhaseTimes {
+		phase, ok := migration.ParsePhase(doc.Phase)
+		if !ok {
+			// Phases are validated when set, so this should
+			// never happen.
+			continue
+		}
+		out = append(out, migration.PhaseTime{
+			Phase: phase,
+			Start: unixNanoToTime0(doc.Time),
+		})
+	}
+	return out
+}
+
 // InitiatedBy implements ModelMigration.
 func (mig *modelMigration) InitiatedBy() string {
 	return mig.doc.InitiatedBy
@@ -288,6 +373,56 @@ func (mig *modelMigration) TargetInfo() (*migration.TargetInfo, error) {
 	}, nil
 }
 
+// CloudRemap implements ModelMigration.
+func (mig *modelMigration) CloudRemap() migration.CloudRemap {
+	remap := migration.CloudRemap{
+		Cloud:       mig.doc.TargetCloud,
+		CloudRegion: mig.doc.TargetCloudRegion,
+	}
+	if mig.doc.TargetCredential != "" {
+		remap.Credential = names.NewCloudCredentialTag(mig.doc.TargetCredential)
+	}
+	return remap
+}
+
+// QueuePosition implements ModelMigration.
+func (mig *modelMigration) QueuePosition() (migration.QueuePosition, error) {
+	var position migration.QueuePosition
+	config, err := mig.st.ControllerConfig()
+	if err != nil {
+		return position, errors.Trace(err)
+	}
+	position.Limit = config.MaxConcurrentMigrations()
+
+	activeColl, closer := mig.st.getCollection(migrationsActiveC)
+	defer closer()
+	var activeDocs []struct {
+		Id string `bson:"id"`
+	}
+	if err := activeColl.Find(nil).All(&activeDocs); err != nil {
+		return position, errors.Annotate(err, "active migrations lookup failed")
+	}
+	ids := make([]string, len(activeDocs))
+	for i, doc := range activeDocs {
+		ids[i] = doc.Id
+	}
+
+	statusColl, closer := mig.st.getCollection(migrationsStatusC)
+	defer closer()
+	startTime := mig.statusDoc.StartTime
+	position.Ahead, err = statusColl.Find(bson.D{
+		{"_id", bson.D{{"$in", ids}}},
+		{"$or", []bson.D{
+			{{"start-time", bson.D{{"$lt", startTime}}}},
+			{{"start-time", startTime}, {"_id", bson.D{{"$lt", mig.doc.Id}}}},
+		}},
+	}).Count()
+	if err != nil {
+		return position, errors.Annotate(err, "migration status lookup failed")
+	}
+	return position, nil
+}
+
 // SetPhase implements ModelMigration.
 func (mig *modelMigration) SetPhase(nextPhase migration.Phase) error {
 	now := mig.st.clock.Now().UnixNano()
@@ -307,6 +442,9 @@ func (mig *modelMigration) SetPhase(nextPhase migration.Phase) error {
 	nextDoc := mig.statusDoc
 	nextDoc.Phase = nextPhase.String()
 	nextDoc.PhaseChangedTime = now
+	nextDoc.Progress = nil
+	phaseTime := modelMigPhaseTimeDoc{Phase: nextDoc.Phase, Time: now}
+	nextDoc.PhaseTimes = append(nextDoc.PhaseTimes, phaseTime)
 	update := bson.M{
 		"phase":              nextDoc.Phase,
 		"phase-changed-time": now,
@@ -321,6 +459,12 @@ func (mig *modelMigration) SetPhase(nextPhase migration.Phase) error {
 		return errors.Trace(err)
 	}
 
+	// A queued migration only starts exporting the model once it
+	// leaves the QUEUED phase.
+	if phase == migration.QUEUED && nextPhase == migration.QUIESCE {
+		ops = append(ops, setExportingOp(mig.doc.ModelUUID))
+	}
+
 	// If the migration aborted, make the model active again.
 	if nextPhase == migration.ABORTDONE {
 		ops = append(ops, txn.Op{
@@ -347,9 +491,13 @@ func (mig *modelMigration) SetPhase(nextPhase migration.Phase) error {
 	}
 
 	ops = append(ops, txn.Op{
-		C:      migrationsStatusC,
-		Id:     mig.statusDoc.Id,
-		Update: bson.M{"$set": update},
+		C:  migrationsStatusC,
+		Id: mig.statusDoc.Id,
+		Update: bson.M{
+			"$set":   update,
+			"$unset": bson.M{"progress": nil},
+			"$push":  bson.M{"phase-times": phaseTime},
+		},
 		// Ensure phase hasn't changed underneath us
 		Assert: bson.M{"phase": mig.statusDoc.Phase},
 	})
@@ -424,6 +572,30 @@ func (mig *modelMigration) SetStatusMessage(text string) error {
 	return nil
 }
 
+// SetProgress implements ModelMigration.
+func (mig *modelMigration) SetProgress(progress migration.Progress) error {
+	doc := &modelMigProgressDoc{
+		BinariesUploaded:      progress.BinariesUploaded,
+		BinariesTotal:         progress.BinariesTotal,
+		MinionReportsReceived: progress.MinionReportsReceived,
+		MinionReportsExpected: progress.MinionReportsExpected,
+	}
+	ops := []txn.Op{{
+		C:      migrationsStatusC,
+		Id:     mig.statusDoc.Id,
+		Update: bson.M{"$set": bson.M{"progress": doc}},
+		// Progress only applies to the phase it was reported in.
+		Assert: bson.M{"phase": mig.statusDoc.Phase},
+	}}
+	if err := mig.st.runTransaction(ops); err == txn.ErrAborted {
+		return errors.New("phase changed")
+	} else if err != nil {
+		return errors.Annotate(err, "failed to set migration progress")
+	}
+	mig.statusDoc.Progress = doc
+	return nil
+}
+
 // SubmitMinionReport implements ModelMigration.
 func (mig *modelMigration) SubmitMinionReport(tag names.Tag, phase migration.Phase, success bool) error {
 	globalKey, err := agentTagToGlobalKey(tag)
@@ -610,6 +782,12 @@ type MigrationSpec struct {
 	InitiatedBy     names.UserTag
 	TargetInfo      migration.TargetInfo
 	ExternalControl bool
+	CloudRemap      migration.CloudRemap
+
+	// Queue indicates that the migration should wait in the QUEUED
+	// phase until the controller's limit on concurrent migrations
+	// allows it to start.
+	Queue bool
 }
 
 // Validate returns an error if the MigrationSpec contains bad
@@ -618,6 +796,12 @@ func (spec *MigrationSpec) Validate() error {
 	if !names.IsValidUser(spec.InitiatedBy.Id()) {
 		return errors.NotValidf("InitiatedBy")
 	}
+	if err := spec.CloudRemap.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if spec.Queue && spec.ExternalControl {
+		return errors.New("externally controlled migrations can't be queued")
+	}
 	return spec.TargetInfo.Validate()
 }
 
@@ -640,8 +824,11 @@ func (st *State) CreateMigration(spec MigrationSpec) (ModelMigration, error) {
 	var doc modelMigDoc
 	var statusDoc modelMigStatusDoc
 
-	msg := "starting"
-	ops, err := migStatusHistoryAndOps(st, migration.QUIESCE, now, msg)
+	phase, msg := migration.QUIESCE, "starting"
+	if spec.Queue {
+		phase, msg = migration.QUEUED, "queued"
+	}
+	ops, err := migStatusHistoryAndOps(st, phase, now, msg)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -684,14 +871,22 @@ func (st *State) CreateMigration(spec MigrationSpec) (ModelMigration, error) {
 			TargetAuthTag:    spec.TargetInfo.AuthTag.String(),
 			TargetPassword:   spec.TargetInfo.Password,
 			TargetMacaroons:  macsJSON,
+
+			TargetCloud:       spec.CloudRemap.Cloud,
+			TargetCloudRegion: spec.CloudRemap.CloudRegion,
+			TargetCredential:  spec.CloudRemap.Credential.Id(),
 		}
 
 		statusDoc = modelMigStatusDoc{
 			Id:               id,
 			StartTime:        now,
-			Phase:            migration.QUIESCE.String(),
+			Phase:            phase.String(),
 			PhaseChangedTime: now,
 			StatusMessage:    msg,
+			PhaseTimes: []modelMigPhaseTimeDoc{{
+				Phase: phase.String(),
+				Time:  now,
+			}},
 		}
 
 		ops := append(ops, []txn.Op{{
@@ -709,15 +904,13 @@ func (st *State) CreateMigration(spec MigrationSpec) (ModelMigration, error) {
 			Id:     modelUUID,
 			Assert: txn.DocMissing,
 			Insert: bson.M{"id": doc.Id},
-		}, {
-			C:      modelsC,
-			Id:     modelUUID,
-			Assert: txn.DocExists,
-			Update: bson.M{"$set": bson.M{
-				"migration-mode": MigrationModeExporting,
-			}},
 		}, model.assertActiveOp(),
 		}...)
+		// A queued migration doesn't affect the model until it
+		// leaves the QUEUED phase.
+		if !spec.Queue {
+			ops = append(ops, setExportingOp(modelUUID))
+		}
 		return ops, nil
 	}
 	if err := st.run(buildTxn); err != nil {
@@ -731,6 +924,17 @@ func (st *State) CreateMigration(spec MigrationSpec) (ModelMigration, error) {
 	}, nil
 }
 
+func setExportingOp(modelUUID string) txn.Op {
+	return txn.Op{
+		C:      modelsC,
+		Id:     modelUUID,
+		Assert: txn.DocExists,
+		Update: bson.M{"$set": bson.M{
+			"migration-mode": MigrationModeExporting,
+		}},
+	}
+}
+
 func macaroonsToJSON(m []macaroon.Slice) (string, error) {
 	if len(m) == 0 {
 		return "", nil
@@ -15,6 +15,7 @@ import (
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/macaroon.v1"
 
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/migration"
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
@@ -80,6 +81,7 @@ func (s *MigrationSuite) TestCreate(c *gc.C) {
 	info, err := mig.TargetInfo()
 	c.Assert(err, jc.ErrorIsNil)
 	c.Check(*info, jc.DeepEquals, s.stdSpec.TargetInfo)
+	c.Check(mig.CloudRemap().IsEmpty(), jc.IsTrue)
 
 	assertPhase(c, mig, migration.QUIESCE)
 	c.Check(mig.PhaseChangedTime(), gc.Equals, mig.StartTime())
@@ -99,6 +101,94 @@ func (s *MigrationSuite) TestCreateExternalControl(c *gc.C) {
 	c.Check(mig.ExternalControl(), jc.IsTrue)
 }
 
+func (s *MigrationSuite) TestCreateQueued(c *gc.C) {
+	spec := s.stdSpec
+	spec.Queue = true
+	mig, err := s.State2.CreateMigration(spec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	assertPhase(c, mig, migration.QUEUED)
+	c.Check(mig.StatusMessage(), gc.Equals, "queued")
+	c.Check(mig.PhaseTimes(), jc.DeepEquals, []migration.PhaseTime{
+		{Phase: migration.QUEUED, Start: s.clock.Now()},
+	})
+	assertMigrationActive(c, s.State2)
+
+	// The model isn't locked for export while the migration is queued.
+	model, err := s.State2.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(model.MigrationMode(), gc.Equals, state.MigrationModeNone)
+
+	c.Assert(mig.SetPhase(migration.QUIESCE), jc.ErrorIsNil)
+	assertPhase(c, mig, migration.QUIESCE)
+	c.Assert(model.Refresh(), jc.ErrorIsNil)
+	c.Check(model.MigrationMode(), gc.Equals, state.MigrationModeExporting)
+}
+
+func (s *MigrationSuite) TestCreateQueuedExternalControl(c *gc.C) {
+	spec := s.stdSpec
+	spec.Queue = true
+	spec.ExternalControl = true
+	_, err := s.State2.CreateMigration(spec)
+	c.Assert(err, gc.ErrorMatches, "externally controlled migrations can't be queued")
+}
+
+func (s *MigrationSuite) TestQueuePosition(c *gc.C) {
+	spec := s.stdSpec
+	spec.Queue = true
+	var migs []state.ModelMigration
+	for i := 0; i < 3; i++ {
+		st := s.State2
+		if i > 0 {
+			st = s.Factory.MakeModel(c, nil)
+			s.AddCleanup(func(*gc.C) { st.Close() })
+		}
+		mig, err := st.CreateMigration(spec)
+		c.Assert(err, jc.ErrorIsNil)
+		migs = append(migs, mig)
+		s.clock.Advance(time.Second)
+	}
+
+	for i, mig := range migs {
+		position, err := mig.QueuePosition()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(position, gc.Equals, migration.QueuePosition{
+			Ahead: i,
+			Limit: controller.DefaultMaxConcurrentMigrations,
+		})
+	}
+
+	// Once the first migration finishes, the others move up.
+	c.Assert(migs[0].SetPhase(migration.ABORT), jc.ErrorIsNil)
+	c.Assert(migs[0].SetPhase(migration.ABORTDONE), jc.ErrorIsNil)
+	position, err := migs[2].QueuePosition()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(position.Ahead, gc.Equals, 1)
+}
+
+func (s *MigrationSuite) TestCreateCloudRemap(c *gc.C) {
+	spec := s.stdSpec
+	spec.CloudRemap = migration.CloudRemap{
+		Cloud:       "openstack",
+		CloudRegion: "RegionOne",
+		Credential:  names.NewCloudCredentialTag("openstack/bob/default"),
+	}
+	mig, err := s.State2.CreateMigration(spec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mig.CloudRemap(), jc.DeepEquals, spec.CloudRemap)
+
+	mig2, err := s.State2.LatestMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mig2.CloudRemap(), jc.DeepEquals, spec.CloudRemap)
+}
+
+func (s *MigrationSuite) TestCreateInvalidCloudRemap(c *gc.C) {
+	spec := s.stdSpec
+	spec.CloudRemap = migration.CloudRemap{CloudRegion: "RegionOne"}
+	_, err := s.State2.CreateMigration(spec)
+	c.Check(err, gc.ErrorMatches, "cloud region without cloud not valid")
+}
+
 func (s *MigrationSuite) TestIsMigrationActive(c *gc.C) {
 	check := func(expected bool) {
 		isActive, err := s.State2.IsMigrationActive()
@@ -506,6 +596,75 @@ func (s *MigrationSuite) TestStatusMessage(c *gc.C) {
 	c.Check(mig2.StatusMessage(), gc.Equals, "foo bar")
 }
 
+func (s *MigrationSuite) TestPhaseTimes(c *gc.C) {
+	start := s.clock.Now()
+	mig, err := s.State2.CreateMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mig.PhaseTimes(), jc.DeepEquals, []migration.PhaseTime{
+		{Phase: migration.QUIESCE, Start: start},
+	})
+
+	s.clock.Advance(time.Minute)
+	c.Assert(mig.SetPhase(migration.IMPORT), jc.ErrorIsNil)
+	s.clock.Advance(time.Minute)
+	c.Assert(mig.SetPhase(migration.VALIDATION), jc.ErrorIsNil)
+
+	expected := []migration.PhaseTime{
+		{Phase: migration.QUIESCE, Start: start},
+		{Phase: migration.IMPORT, Start: start.Add(time.Minute)},
+		{Phase: migration.VALIDATION, Start: start.Add(2 * time.Minute)},
+	}
+	c.Check(mig.PhaseTimes(), jc.DeepEquals, expected)
+
+	mig2, err := s.State2.LatestMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mig2.PhaseTimes(), jc.DeepEquals, expected)
+}
+
+func (s *MigrationSuite) TestProgress(c *gc.C) {
+	mig, err := s.State2.CreateMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mig.Progress().IsZero(), jc.IsTrue)
+
+	progress := migration.Progress{
+		BinariesUploaded:      100,
+		BinariesTotal:         1000,
+		MinionReportsReceived: 2,
+		MinionReportsExpected: 5,
+	}
+	c.Assert(mig.SetProgress(progress), jc.ErrorIsNil)
+	c.Check(mig.Progress(), jc.DeepEquals, progress)
+
+	mig2, err := s.State2.LatestMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mig2.Progress(), jc.DeepEquals, progress)
+}
+
+func (s *MigrationSuite) TestProgressClearedOnPhaseChange(c *gc.C) {
+	mig, err := s.State2.CreateMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mig.SetProgress(migration.Progress{MinionReportsExpected: 5}), jc.ErrorIsNil)
+
+	c.Assert(mig.SetPhase(migration.IMPORT), jc.ErrorIsNil)
+	c.Check(mig.Progress().IsZero(), jc.IsTrue)
+
+	mig2, err := s.State2.LatestMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mig2.Progress().IsZero(), jc.IsTrue)
+}
+
+func (s *MigrationSuite) TestProgressPhaseChanged(c *gc.C) {
+	mig, err := s.State2.CreateMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	mig2, err := s.State2.LatestMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mig2.SetPhase(migration.IMPORT), jc.ErrorIsNil)
+
+	err = mig.SetProgress(migration.Progress{MinionReportsExpected: 5})
+	c.Assert(err, gc.ErrorMatches, "phase changed")
+}
+
 func (s *MigrationSuite) TestWatchForMigration(c *gc.C) {
 	// Start watching for migration.
 	w, wc := s.createMigrationWatcher(c, s.State2)
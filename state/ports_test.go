@@ -443,6 +443,44 @@ func (s *PortsDocSuite) TestWatchPorts(c *gc.C) {
 	wc.AssertNoChange()
 }
 
+func (s *PortsDocSuite) TestWatchMachineOpenedPorts(c *gc.C) {
+	w := s.machine.WatchOpenedPorts()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	// Open a port range on the machine, detect a change.
+	portRange := state.PortRange{
+		FromPort: 100,
+		ToPort:   200,
+		UnitName: s.unit1.Name(),
+		Protocol: "tcp",
+	}
+	err := s.portsOnSubnet.OpenPorts(portRange)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Open a port range on another machine, no change.
+	f := factory.NewFactory(s.State)
+	machine := f.MakeMachine(c, &factory.MachineParams{Series: "quantal"})
+	unit := f.MakeUnit(c, &factory.UnitParams{Application: s.service, Machine: machine})
+	ports, err := state.GetOrCreatePorts(s.State, machine.Id(), "")
+	c.Assert(err, jc.ErrorIsNil)
+	err = ports.OpenPorts(state.PortRange{
+		FromPort: 100,
+		ToPort:   200,
+		UnitName: unit.Name(),
+		Protocol: "tcp",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	// Close the port range on the machine, detect a change.
+	err = s.portsOnSubnet.ClosePorts(portRange)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
 type PortRangeSuite struct{}
 
 var _ = gc.Suite(&PortRangeSuite{})
@@ -12,6 +12,7 @@ import (
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable"
 
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/testing"
 	"github.com/juju/juju/status"
@@ -436,6 +437,39 @@ func (s *remoteApplicationSuite) assertDestroyWithReferencedRelation(c *gc.C, re
 	c.Assert(err, jc.Satisfies, errors.IsNotFound)
 }
 
+func (s *remoteApplicationSuite) TestRemoteEgressRules(c *gc.C) {
+	ch := s.AddTestingCharm(c, "wordpress")
+	wordpress := s.AddTestingService(c, "wordpress", ch)
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps[0], eps[1])
+	c.Assert(err, jc.ErrorIsNil)
+
+	rules, err := wordpress.RemoteEgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, gc.HasLen, 0)
+
+	for unitName, address := range map[string]string{
+		"mysql/0": "10.0.0.1",
+		"mysql/1": "2001:db8::1",
+		"mysql/2": "mysql.example.com",
+	} {
+		ru, err := rel.RemoteUnit(unitName)
+		c.Assert(err, jc.ErrorIsNil)
+		err = ru.EnterScope(map[string]interface{}{"private-address": address})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	rules, err = wordpress.RemoteEgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.EgressRule{
+		network.MustNewEgressRule("tcp", 1, 65535, "10.0.0.1/32"),
+		network.MustNewEgressRule("udp", 1, 65535, "10.0.0.1/32"),
+		network.MustNewEgressRule("tcp", 1, 65535, "2001:db8::1/128"),
+		network.MustNewEgressRule("udp", 1, 65535, "2001:db8::1/128"),
+	})
+}
+
 func (s *remoteApplicationSuite) TestAllRemoteApplicationsNone(c *gc.C) {
 	err := s.application.Destroy()
 	c.Assert(err, jc.ErrorIsNil)
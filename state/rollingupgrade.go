@@ -0,0 +1,224 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// RollingUpgradeParams holds the parameters of a rolling charm upgrade,
+// which are recorded so that an interrupted upgrade can be resumed.
+type RollingUpgradeParams struct {
+	// BatchSize is the number of units to upgrade at a time.
+	BatchSize int `bson:"batch-size"`
+
+	// LeaderFirst is true if the leader unit is to be upgraded
+	// before the other units, rather than after them.
+	LeaderFirst bool `bson:"leader-first,omitempty"`
+}
+
+// Validate returns an error if the parameters are not valid.
+func (p RollingUpgradeParams) Validate() error {
+	if p.BatchSize < 1 {
+		return errors.NotValidf("rolling upgrade batch size %d", p.BatchSize)
+	}
+	return nil
+}
+
+// RollingUpgrade describes a charm upgrade of an application that is
+// applied to its units in batches, rather than to all of them at once.
+// Until a unit is released to the upgrade, the unit agent continues
+// to see the application's previous charm.
+type RollingUpgrade struct {
+	RollingUpgradeParams `bson:",inline"`
+
+	// PreviousCharmURL and PreviousCharmModifiedVersion record the
+	// application's charm before the upgrade.
+	PreviousCharmURL             *charm.URL `bson:"previous-charmurl"`
+	PreviousCharmModifiedVersion int        `bson:"previous-charmmodifiedversion"`
+
+	// Released holds the names of the units released to the upgrade.
+	Released []string `bson:"released,omitempty"`
+
+	// Paused is true if the upgrade was paused because of a problem
+	// with a unit, described by PauseReason. Releasing further units
+	// resumes the upgrade.
+	Paused      bool   `bson:"paused,omitempty"`
+	PauseReason string `bson:"pause-reason,omitempty"`
+}
+
+// IsReleased returns whether the named unit has been released to the
+// upgrade.
+func (r RollingUpgrade) IsReleased(unitName string) bool {
+	for _, released := range r.Released {
+		if released == unitName {
+			return true
+		}
+	}
+	return false
+}
+
+// RollingUpgrade returns the application's rolling charm upgrade, and
+// whether one is in progress.
+func (a *Application) RollingUpgrade() (RollingUpgrade, bool) {
+	if a.doc.RollingUpgrade == nil {
+		return RollingUpgrade{}, false
+	}
+	return *a.doc.RollingUpgrade, true
+}
+
+// UnitCharmURL returns the charm URL that the named unit should be
+// running, and whether it should upgrade to that charm even if it is
+// in an error state. This is the application's charm unless a rolling
+// upgrade is in progress and the unit has not yet been released to it.
+func (a *Application) UnitCharmURL(unitName string) (*charm.URL, bool) {
+	if r := a.doc.RollingUpgrade; r != nil && !r.IsReleased(unitName) {
+		return r.PreviousCharmURL, false
+	}
+	return a.doc.CharmURL, a.doc.ForceCharm
+}
+
+// UnitCharmModifiedVersion returns the charm modified version that the
+// named unit should see, in the same way as UnitCharmURL.
+func (a *Application) UnitCharmModifiedVersion(unitName string) int {
+	if r := a.doc.RollingUpgrade; r != nil && !r.IsReleased(unitName) {
+		return r.PreviousCharmModifiedVersion
+	}
+	return a.doc.CharmModifiedVersion
+}
+
+// ReleaseRollingUpgradeUnits releases the named units to the rolling
+// upgrade in progress, so that they upgrade to the application's charm.
+// Releasing units resumes a paused upgrade.
+func (a *Application) ReleaseRollingUpgradeUnits(unitNames ...string) error {
+	for _, name := range unitNames {
+		if unitAppName(name) != a.doc.Name {
+			return errors.Errorf("cannot release units: %q is not a unit of %q", name, a.doc.Name)
+		}
+	}
+	return a.updateRollingUpgrade("release units", func(r *RollingUpgrade) bson.D {
+		released := set.NewStrings(r.Released...).Union(set.NewStrings(unitNames...))
+		r.Released = released.SortedValues()
+		r.Paused = false
+		r.PauseReason = ""
+		return bson.D{
+			{"$addToSet", bson.D{{"rollingupgrade.released", bson.D{{"$each", unitNames}}}}},
+			{"$unset", bson.D{
+				{"rollingupgrade.paused", nil},
+				{"rollingupgrade.pause-reason", nil},
+			}},
+		}
+	})
+}
+
+// PauseRollingUpgrade records that the rolling upgrade in progress was
+// paused for the given reason.
+func (a *Application) PauseRollingUpgrade(reason string) error {
+	return a.updateRollingUpgrade("pause", func(r *RollingUpgrade) bson.D {
+		r.Paused = true
+		r.PauseReason = reason
+		return bson.D{{"$set", bson.D{
+			{"rollingupgrade.paused", true},
+			{"rollingupgrade.pause-reason", reason},
+		}}}
+	})
+}
+
+// FinishRollingUpgrade ends the rolling upgrade in progress, releasing
+// any remaining units to it.
+func (a *Application) FinishRollingUpgrade() (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot finish rolling upgrade of application %q", a)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := a.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		r := a.doc.RollingUpgrade
+		if r == nil {
+			return nil, errors.NotFoundf("rolling upgrade")
+		}
+		ops := []txn.Op{{
+			C:  applicationsC,
+			Id: a.doc.DocID,
+			Assert: bson.D{
+				{"rollingupgrade.previous-charmurl", r.PreviousCharmURL},
+			},
+			Update: bson.D{{"$unset", bson.D{{"rollingupgrade", nil}}}},
+		}}
+		decRefOps, err := appCharmDecRefOps(a.st, a.doc.Name, r.PreviousCharmURL)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(ops, decRefOps...), nil
+	}
+	if err := a.st.run(buildTxn); err != nil {
+		return err
+	}
+	a.doc.RollingUpgrade = nil
+	return nil
+}
+
+// updateRollingUpgrade runs a transaction that applies the update
+// returned by change to the rolling upgrade in progress. The change
+// function also applies the update to the in-memory rolling upgrade.
+func (a *Application) updateRollingUpgrade(what string, change func(*RollingUpgrade) bson.D) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot %s for rolling upgrade of application %q", what, a)
+	var updated RollingUpgrade
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := a.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if a.doc.RollingUpgrade == nil {
+			return nil, errors.NotFoundf("rolling upgrade")
+		}
+		updated = *a.doc.RollingUpgrade
+		updated.Released = append([]string(nil), updated.Released...)
+		return []txn.Op{{
+			C:  applicationsC,
+			Id: a.doc.DocID,
+			Assert: bson.D{
+				{"rollingupgrade.previous-charmurl", updated.PreviousCharmURL},
+			},
+			Update: change(&updated),
+		}}, nil
+	}
+	if err := a.st.run(buildTxn); err != nil {
+		return err
+	}
+	a.doc.RollingUpgrade = &updated
+	return nil
+}
+
+// startRollingUpgradeOp returns the operation necessary to start a
+// rolling upgrade from the application's current charm. The rolling
+// upgrade takes over the application's reference to that charm, which
+// it holds until the upgrade is finished.
+func (a *Application) startRollingUpgradeOp(params RollingUpgradeParams) txn.Op {
+	return txn.Op{
+		C:  applicationsC,
+		Id: a.doc.DocID,
+		Update: bson.D{{"$set", bson.D{{"rollingupgrade", RollingUpgrade{
+			RollingUpgradeParams:         params,
+			PreviousCharmURL:             a.doc.CharmURL,
+			PreviousCharmModifiedVersion: a.doc.CharmModifiedVersion,
+		}}}}},
+	}
+}
+
+// unitAppName returns the name of the application of the named unit.
+func unitAppName(unitName string) string {
+	for i := len(unitName) - 1; i >= 0; i-- {
+		if unitName[i] == '/' {
+			return unitName[:i]
+		}
+	}
+	return ""
+}
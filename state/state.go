@@ -1901,6 +1901,11 @@ type controllersDoc struct {
 	VotingMachineIds []string
 	MongoSpaceName   string `bson:"mongo-space-name"`
 	MongoSpaceState  string `bson:"mongo-space-state"`
+
+	DrainingMachineIds []string `bson:"draining-machine-ids,omitempty"`
+
+	NonVotingMachineIds []string           `bson:"non-voting-machine-ids,omitempty"`
+	MemberPriorities    map[string]float64 `bson:"member-priorities,omitempty"`
 }
 
 // ControllerInfo holds information about currently
@@ -1933,6 +1938,22 @@ type ControllerInfo struct {
 	// * We have looked for and found a Mongo space (MongoSpaceValid)
 	// * We didn't try to find a Mongo space because the provider doesn't support spaces (MongoSpaceUnsupported)
 	MongoSpaceState MongoSpaceStates
+
+	// DrainingMachineIds holds the ids of the controller machines
+	// which are being drained of API connections before
+	// maintenance.
+	DrainingMachineIds []string
+
+	// NonVotingMachineIds holds the ids of the controller machines
+	// which an operator has chosen never to give a vote in peer
+	// election.
+	NonVotingMachineIds []string
+
+	// MemberPriorities holds the replica set election priority
+	// chosen by an operator for controller machines, keyed by
+	// machine id. Machines without an entry have the default
+	// priority.
+	MemberPriorities map[string]float64
 }
 
 type MongoSpaceStates string
@@ -1974,6 +1995,10 @@ func readRawControllerInfo(session *mgo.Session) (*ControllerInfo, error) {
 		VotingMachineIds: doc.VotingMachineIds,
 		MongoSpaceName:   doc.MongoSpaceName,
 		MongoSpaceState:  MongoSpaceStates(doc.MongoSpaceState),
+
+		DrainingMachineIds:  doc.DrainingMachineIds,
+		NonVotingMachineIds: doc.NonVotingMachineIds,
+		MemberPriorities:    doc.MemberPriorities,
 	}, nil
 }
 
@@ -17,6 +17,7 @@ import (
 	"gopkg.in/mgo.v2/txn"
 
 	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/status"
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/storage/poolmanager"
 	"github.com/juju/juju/storage/provider"
@@ -34,8 +35,12 @@ type StorageInstance interface {
 	Kind() StorageKind
 
 	// Owner returns the tag of the application or unit that owns this storage
-	// instance.
-	Owner() names.Tag
+	// instance, and a boolean indicating whether or not there is an owner.
+	//
+	// When a non-shared storage instance is detached from the unit, the
+	// storage instance's owner will be cleared, allowing it to be attached
+	// to another unit.
+	Owner() (names.Tag, bool)
 
 	// StorageName returns the name of the storage, as defined in the charm
 	// storage metadata. This does not uniquely identify storage instances,
@@ -114,14 +119,18 @@ func (s *storageInstance) Kind() StorageKind {
 	return s.doc.Kind
 }
 
-func (s *storageInstance) Owner() names.Tag {
+func (s *storageInstance) Owner() (names.Tag, bool) {
+	if s.doc.Owner == "" {
+		return nil, false
+	}
 	tag, err := names.ParseTag(s.doc.Owner)
 	if err != nil {
 		// This should be impossible; we do not expose
-		// a means of modifying the owner tag.
+		// a means of setting the owner tag to anything
+		// other than a valid tag.
 		panic(err)
 	}
-	return tag
+	return tag, true
 }
 
 func (s *storageInstance) StorageName() string {
@@ -264,7 +273,8 @@ func (st *State) destroyStorageInstanceOps(s *storageInstance) ([]txn.Op, error)
 		// remove the storage instance immediately.
 		hasNoAttachments := bson.D{{"attachmentcount", 0}}
 		assert := append(hasNoAttachments, isAliveDoc...)
-		return removeStorageInstanceOps(st, s.Owner(), s.StorageTag(), assert)
+		owner, _ := s.Owner()
+		return removeStorageInstanceOps(st, owner, s.StorageTag(), assert)
 	}
 	// There are still attachments: the storage instance will be removed
 	// when the last attachment is removed. We schedule a cleanup to destroy
@@ -287,7 +297,8 @@ func (st *State) destroyStorageInstanceOps(s *storageInstance) ([]txn.Op, error)
 }
 
 // removeStorageInstanceOps removes the storage instance with the given
-// tag from state, if the specified assertions hold true.
+// tag from state, if the specified assertions hold true. The owner may
+// be nil, if the storage instance has been detached.
 func removeStorageInstanceOps(
 	st *State,
 	owner names.Tag,
@@ -337,6 +348,12 @@ func removeStorageInstanceOps(
 		return nil, errors.Trace(err)
 	}
 
+	if owner == nil {
+		// The storage instance is detached, so there is no
+		// charm storage reference count to decrement.
+		return ops, nil
+	}
+
 	// Decrement the charm storage reference count.
 	refcounts, closer := st.getCollection(refcountsC)
 	defer closer()
@@ -691,6 +708,513 @@ func destroyStorageAttachmentOps(storage names.StorageTag, unit names.UnitTag) [
 	return ops
 }
 
+// DetachStorage ensures that the storage instance will be detached from
+// the unit that owns it, without destroying the storage instance. Once
+// the unit has run its storage-detaching hook and the storage attachment
+// has been removed, the storage's volume or filesystem is detached from
+// the unit's machine. The storage instance can then be attached to another
+// unit with AttachStorage.
+func (st *State) DetachStorage(storage names.StorageTag, unit names.UnitTag) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot detach storage %s from unit %s", storage.Id(), unit.Id())
+	u, err := st.Unit(unit.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := u.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		si, err := st.storageInstance(storage)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		s, err := st.storageAttachment(storage, unit)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if s.doc.Life != Alive {
+			if si.doc.Owner == "" {
+				// The storage is already being detached.
+				return nil, jujutxn.ErrNoOperations
+			}
+			return nil, errors.New("storage attachment is not alive")
+		}
+		if si.doc.Life != Alive {
+			return nil, errors.New("storage is not alive")
+		}
+		if si.doc.Owner != unit.String() {
+			return nil, errors.NotSupportedf("detaching shared storage")
+		}
+		machineBound, err := isStorageInstanceInherentlyMachineBound(st, si)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if machineBound {
+			return nil, errors.Errorf(
+				"storage is bound to the lifetime of the unit's machine, and cannot be detached",
+			)
+		}
+
+		charmMeta, ops, err := st.unitCharmMetaOps(u)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		charmStorage, ok := charmMeta.Storage[si.doc.StorageName]
+		if !ok {
+			return nil, errors.NotFoundf("charm storage %q", si.doc.StorageName)
+		}
+		currentCountOp, currentCount, err := st.countEntityStorageInstances(unit, si.doc.StorageName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if currentCount-1 < charmStorage.CountMin {
+			return nil, errors.Errorf(
+				"charm %q store %q: %d instances required",
+				charmMeta.Name, si.doc.StorageName, charmStorage.CountMin,
+			)
+		}
+
+		refcounts, closer := st.getCollection(refcountsC)
+		defer closer()
+		storageRefcountKey := entityStorageRefcountKey(unit, si.doc.StorageName)
+		decRefOp, _, err := nsRefcounts.DyingDecRefOp(refcounts, storageRefcountKey)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+
+		// Clearing the owner marks the storage instance as detached,
+		// so it will not be removed along with the storage attachment.
+		ops = append(ops, currentCountOp, decRefOp, txn.Op{
+			C:  storageInstancesC,
+			Id: si.doc.Id,
+			Assert: bson.D{
+				{"life", Alive},
+				{"owner", unit.String()},
+			},
+			Update: bson.D{{"$set", bson.D{{"owner", ""}}}},
+		})
+		ops = append(ops, destroyStorageAttachmentOps(storage, unit)...)
+		return ops, nil
+	}
+	return st.run(buildTxn)
+}
+
+// AttachStorage attaches the detached storage instance to the specified
+// unit, which must be assigned to a machine. The unit's charm must have
+// a store with the same name and kind as the storage instance, with room
+// for another instance. The storage's existing volume or filesystem is
+// attached to the unit's machine.
+func (st *State) AttachStorage(storage names.StorageTag, unit names.UnitTag) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot attach storage %s to unit %s", storage.Id(), unit.Id())
+	u, err := st.Unit(unit.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := u.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if u.Life() != Alive {
+			return nil, unitNotAliveErr
+		}
+		si, err := st.storageInstance(storage)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if si.doc.Life != Alive {
+			return nil, errors.New("storage is not alive")
+		}
+		if si.doc.Owner == unit.String() {
+			if attempt > 0 {
+				return nil, jujutxn.ErrNoOperations
+			}
+			return nil, errors.AlreadyExistsf("storage attachment %s:%s", storage.Id(), unit.Id())
+		}
+		if si.doc.Owner != "" {
+			owner, _ := si.Owner()
+			return nil, errors.Errorf("storage is attached to %s", names.ReadableString(owner))
+		}
+		if si.doc.AttachmentCount != 0 {
+			return nil, errors.New("storage is still being detached")
+		}
+		m, err := u.machine()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+
+		charmMeta, ops, err := st.unitCharmMetaOps(u)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		charmStorage, ok := charmMeta.Storage[si.doc.StorageName]
+		if !ok {
+			return nil, errors.Errorf("charm %q has no store called %q", charmMeta.Name, si.doc.StorageName)
+		}
+		if charmStorage.Shared {
+			return nil, errors.NotSupportedf("attaching shared storage")
+		}
+		if kind := storageKind(charmStorage.Type); kind.String() != si.doc.Kind.String() {
+			return nil, errors.Errorf(
+				"charm %q store %q: cannot attach %s storage to %s store",
+				charmMeta.Name, si.doc.StorageName, si.doc.Kind, kind,
+			)
+		}
+		currentCountOp, currentCount, err := st.countEntityStorageInstances(unit, si.doc.StorageName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if charmStorage.CountMax >= 0 && currentCount+1 > charmStorage.CountMax {
+			return nil, errors.Errorf(
+				"charm %q store %q: at most %d instances supported",
+				charmMeta.Name, si.doc.StorageName, charmStorage.CountMax,
+			)
+		}
+
+		refcounts, closer := st.getCollection(refcountsC)
+		defer closer()
+		storageRefcountKey := entityStorageRefcountKey(unit, si.doc.StorageName)
+		incRefOp, err := nsRefcounts.CreateOrIncRefOp(refcounts, storageRefcountKey, 1)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, currentCountOp, incRefOp, txn.Op{
+			C:  storageInstancesC,
+			Id: si.doc.Id,
+			Assert: bson.D{
+				{"life", Alive},
+				{"owner", ""},
+				{"attachmentcount", 0},
+			},
+			Update: bson.D{{"$set", bson.D{
+				{"owner", unit.String()},
+				{"attachmentcount", 1},
+			}}},
+		}, createStorageAttachmentOp(storage, unit), txn.Op{
+			C:      unitsC,
+			Id:     u.doc.DocID,
+			Assert: isAliveDoc,
+			Update: bson.D{{"$inc", bson.D{{"storageattachmentcount", 1}}}},
+		})
+
+		attached := *si
+		attached.doc.Owner = unit.String()
+		machineOps, err := st.attachStorageMachineOps(&attached, charmMeta, charmStorage, u, m)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(ops, machineOps...), nil
+	}
+	return st.run(buildTxn)
+}
+
+// attachStorageMachineOps returns txn.Ops for attaching the volume or
+// filesystem of the storage instance to the unit's machine. If the storage
+// instance has no volume or filesystem, then one will be created.
+func (st *State) attachStorageMachineOps(
+	si *storageInstance,
+	charmMeta *charm.Meta,
+	charmStorage charm.Storage,
+	u *Unit,
+	m *Machine,
+) ([]txn.Op, error) {
+	var volumeAttachments []volumeAttachmentTemplate
+	var filesystemAttachments []filesystemAttachmentTemplate
+	var ops []txn.Op
+	switch si.doc.Kind {
+	case StorageKindBlock:
+		v, err := st.storageInstanceVolume(si.StorageTag())
+		if errors.IsNotFound(err) {
+			return st.createStorageMachineOps(si, charmMeta, u)
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		volumeOps, err := attachDetachedVolumeOps(v, nil)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, volumeOps...)
+		volumeAttachments = append(volumeAttachments, volumeAttachmentTemplate{
+			v.VolumeTag(), VolumeAttachmentParams{charmStorage.ReadOnly},
+		})
+
+	case StorageKindFilesystem:
+		f, err := st.storageInstanceFilesystem(si.StorageTag())
+		if errors.IsNotFound(err) {
+			return st.createStorageMachineOps(si, charmMeta, u)
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if f.doc.Life != Alive {
+			return nil, errors.Errorf("filesystem %s is not alive", f.doc.FilesystemId)
+		}
+		if f.doc.AttachmentCount != 0 {
+			return nil, errors.New("storage is still being detached")
+		}
+		location, err := filesystemMountPoint(charmStorage, si.StorageTag(), u.Series())
+		if err != nil {
+			return nil, errors.Annotatef(
+				err, "getting filesystem mount point for storage %s",
+				si.doc.StorageName,
+			)
+		}
+		filesystemTag := f.FilesystemTag()
+		scope, scoped := names.FilesystemMachine(filesystemTag)
+		if scoped && scope != m.MachineTag() || !scoped && f.doc.VolumeId != "" {
+			// The filesystem is managed by the machine it was
+			// created on, or by no machine if it was imported,
+			// so we replace it with one managed by the unit's
+			// machine, on the same volume.
+			replaceOps, tag, err := st.replaceMachineFilesystemOps(f, m.Id())
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, replaceOps...)
+			filesystemTag = tag
+		} else {
+			ops = append(ops, txn.Op{
+				C:      filesystemsC,
+				Id:     f.doc.FilesystemId,
+				Assert: bson.D{{"life", Alive}, {"attachmentcount", 0}},
+				Update: bson.D{{"$inc", bson.D{{"attachmentcount", 1}}}},
+			})
+		}
+		if f.doc.VolumeId != "" {
+			v, err := st.volumeByTag(names.NewVolumeTag(f.doc.VolumeId))
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			var binding names.Tag
+			if filesystemTag != f.FilesystemTag() {
+				binding = filesystemTag
+			}
+			volumeOps, err := attachDetachedVolumeOps(v, binding)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, volumeOps...)
+			volumeAttachments = append(volumeAttachments, volumeAttachmentTemplate{
+				v.VolumeTag(), VolumeAttachmentParams{},
+			})
+		}
+		filesystemAttachments = append(filesystemAttachments, filesystemAttachmentTemplate{
+			filesystemTag, si.StorageTag(), FilesystemAttachmentParams{
+				charmStorage.Location == "", // auto-generated location
+				location,
+				charmStorage.ReadOnly,
+			},
+		})
+
+	default:
+		return nil, errors.Errorf("invalid storage kind %v", si.doc.Kind)
+	}
+
+	ops = append(ops, createMachineVolumeAttachmentsOps(m.Id(), volumeAttachments)...)
+	ops = append(ops, createMachineFilesystemAttachmentsOps(m.Id(), filesystemAttachments)...)
+	attachmentOps, err := addMachineStorageAttachmentsOps(m, volumeAttachments, filesystemAttachments)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return append(ops, attachmentOps...), nil
+}
+
+// createStorageMachineOps returns txn.Ops for creating the volume or
+// filesystem for a storage instance that has none, using the storage
+// constraints of the unit's application.
+func (st *State) createStorageMachineOps(si *storageInstance, charmMeta *charm.Meta, u *Unit) ([]txn.Op, error) {
+	app, err := u.Application()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cons, err := app.StorageConstraints()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return unitAssignedMachineStorageOps(
+		st, u.UnitTag(), charmMeta, cons, u.Series(), si, u,
+	)
+}
+
+// attachDetachedVolumeOps returns txn.Ops for adding an attachment to a
+// volume with no attachments. If binding is non-nil, the volume's lifecycle
+// will be bound to it.
+func attachDetachedVolumeOps(v *volume, binding names.Tag) ([]txn.Op, error) {
+	if v.doc.Life != Alive {
+		return nil, errors.Errorf("volume %s is not alive", v.doc.Name)
+	}
+	if v.doc.AttachmentCount != 0 {
+		return nil, errors.New("storage is still being detached")
+	}
+	update := bson.D{{"$inc", bson.D{{"attachmentcount", 1}}}}
+	if binding != nil {
+		update = append(update, bson.DocElem{"$set", bson.D{{"binding", binding.String()}}})
+	}
+	return []txn.Op{{
+		C:      volumesC,
+		Id:     v.doc.Name,
+		Assert: bson.D{{"life", Alive}, {"attachmentcount", 0}},
+		Update: update,
+	}}, nil
+}
+
+// replaceMachineFilesystemOps returns txn.Ops for replacing the detached,
+// machine-scoped filesystem with a new filesystem scoped to the specified
+// machine. The new filesystem takes over the storage assignment and info of
+// the existing one, so that a provisioned filesystem is not recreated. The
+// existing filesystem is unassigned from its storage, and marked Dead so
+// that it is removed by its machine's storage provisioner.
+func (st *State) replaceMachineFilesystemOps(f *filesystem, machineId string) ([]txn.Op, names.FilesystemTag, error) {
+	filesystemId, err := newFilesystemId(st, machineId)
+	if err != nil {
+		return nil, names.FilesystemTag{}, errors.Annotate(err, "cannot generate filesystem name")
+	}
+	doc := filesystemDoc{
+		FilesystemId:    filesystemId,
+		VolumeId:        f.doc.VolumeId,
+		StorageId:       f.doc.StorageId,
+		Binding:         f.doc.Binding,
+		Info:            f.doc.Info,
+		Params:          f.doc.Params,
+		AttachmentCount: 1,
+	}
+	filesystemStatus := status.Pending
+	if doc.Info != nil {
+		doc.Params = nil
+		filesystemStatus = status.Attaching
+	}
+	ops := st.newFilesystemOps(doc, statusDoc{
+		Status:  filesystemStatus,
+		Updated: st.clock.Now().UnixNano(),
+	})
+	ops = append(ops, txn.Op{
+		C:      filesystemsC,
+		Id:     f.doc.FilesystemId,
+		Assert: bson.D{{"life", Alive}, {"attachmentcount", 0}},
+		Update: bson.D{{"$set", bson.D{
+			{"life", Dead},
+			{"storageid", ""},
+			{"binding", ""},
+		}}},
+	})
+	return ops, names.NewFilesystemTag(filesystemId), nil
+}
+
+// detachStorageMachineOps returns txn.Ops for detaching the volume or
+// filesystem of a detached storage instance from the machine of the unit
+// it was attached to. A volume-backed filesystem's volume is detached once
+// the filesystem attachment has been removed.
+func detachStorageMachineOps(st *State, si *storageInstance, unitName string) ([]txn.Op, error) {
+	u, err := st.Unit(unitName)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	machineId, err := u.AssignedMachineId()
+	if errors.IsNotAssigned(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	machineTag := names.NewMachineTag(machineId)
+	switch si.doc.Kind {
+	case StorageKindBlock:
+		v, err := st.storageInstanceVolume(si.StorageTag())
+		if errors.IsNotFound(err) {
+			return nil, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		va, err := st.VolumeAttachment(machineTag, v.VolumeTag())
+		if errors.IsNotFound(err) {
+			return nil, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if va.Life() == Alive {
+			return detachVolumeOps(machineTag, v.VolumeTag()), nil
+		}
+	case StorageKindFilesystem:
+		f, err := st.storageInstanceFilesystem(si.StorageTag())
+		if errors.IsNotFound(err) {
+			return nil, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		fa, err := st.FilesystemAttachment(machineTag, f.FilesystemTag())
+		if errors.IsNotFound(err) {
+			return nil, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if fa.Life() == Alive {
+			return detachFilesystemOps(machineTag, f.FilesystemTag()), nil
+		}
+	}
+	return nil, nil
+}
+
+// isStorageInstanceInherentlyMachineBound reports whether or not the
+// storage instance's volume or filesystem is inherently bound to the
+// lifetime of the machine it is attached to, and so cannot be detached.
+func isStorageInstanceInherentlyMachineBound(st *State, si *storageInstance) (bool, error) {
+	switch si.doc.Kind {
+	case StorageKindBlock:
+		v, err := st.storageInstanceVolume(si.StorageTag())
+		if errors.IsNotFound(err) {
+			return false, nil
+		} else if err != nil {
+			return false, errors.Trace(err)
+		}
+		return isVolumeInherentlyMachineBound(st, v.VolumeTag())
+	case StorageKindFilesystem:
+		f, err := st.storageInstanceFilesystem(si.StorageTag())
+		if errors.IsNotFound(err) {
+			return false, nil
+		} else if err != nil {
+			return false, errors.Trace(err)
+		}
+		if f.doc.VolumeId != "" {
+			return isVolumeInherentlyMachineBound(st, names.NewVolumeTag(f.doc.VolumeId))
+		}
+		return isFilesystemInherentlyMachineBound(st, f.FilesystemTag())
+	}
+	return false, errors.Errorf("invalid storage kind %v", si.doc.Kind)
+}
+
+// unitCharmMetaOps returns the metadata of the charm that the unit is
+// running, or that of its application's charm if the unit has no charm
+// URL set yet, along with txn.Ops asserting that the charm URL does not
+// change.
+func (st *State) unitCharmMetaOps(u *Unit) (*charm.Meta, []txn.Op, error) {
+	ops := []txn.Op{{
+		C:      unitsC,
+		Id:     u.doc.Name,
+		Assert: bson.D{{"charmurl", u.doc.CharmURL}},
+	}}
+	curl, ok := u.CharmURL()
+	if !ok {
+		a, err := u.Application()
+		if err != nil {
+			return nil, nil, errors.Annotatef(err, "getting application for unit %v", u.doc.Name)
+		}
+		curl = a.doc.CharmURL
+		ops = append(ops, txn.Op{
+			C:      applicationsC,
+			Id:     a.doc.Name,
+			Assert: bson.D{{"charmurl", curl}},
+		})
+	}
+	ch, err := st.Charm(curl)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return ch.Meta(), ops, nil
+}
+
 // Remove removes the storage attachment from state, and may remove its storage
 // instance as well, if the storage instance is Dying and no other references to
 // it exist. It will fail if the storage attachment is not Dying.
@@ -750,8 +1274,9 @@ func removeStorageAttachmentOps(
 			// Either the storage instance is dying, or its owner
 			// is a unit; in either case, no more attachments can
 			// be added to the instance, so it can be removed.
+			owner, _ := si.Owner()
 			siOps, err := removeStorageInstanceOps(
-				st, si.Owner(), si.StorageTag(), hasLastRef,
+				st, owner, si.StorageTag(), hasLastRef,
 			)
 			if err != nil {
 				return nil, errors.Trace(err)
@@ -760,6 +1285,16 @@ func removeStorageAttachmentOps(
 			return ops, nil
 		}
 	}
+	if si.doc.Life == Alive && si.doc.Owner == "" {
+		// The storage instance has been detached from the unit,
+		// so detach its volume or filesystem from the unit's
+		// machine too, leaving it free to be attached elsewhere.
+		detachOps, err := detachStorageMachineOps(st, si, s.doc.Unit)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, detachOps...)
+	}
 	decrefOp := txn.Op{
 		C:      storageInstancesC,
 		Id:     si.doc.Id,
@@ -829,6 +1364,11 @@ type StorageConstraints struct {
 
 	// Count is the required number of storage instances.
 	Count uint64 `bson:"count"`
+
+	// SnapshotId, if non-empty, is the provider ID of the volume
+	// snapshot from which to create the storage instances. It may
+	// only be specified when adding block storage to a unit.
+	SnapshotId string `bson:"snapshotid,omitempty"`
 }
 
 func createStorageConstraintsOp(key string, cons map[string]StorageConstraints) txn.Op {
@@ -890,6 +1430,13 @@ func validateStorageConstraints(st *State, allCons map[string]StorageConstraints
 	if err != nil {
 		return errors.Trace(err)
 	}
+	// Snapshots may only be specified when adding storage to a unit;
+	// they make no sense for all units of an application.
+	for name, cons := range allCons {
+		if cons.SnapshotId != "" {
+			return errors.NotValidf("snapshot in storage constraints for store %q", name)
+		}
+	}
 	// Ensure all stores have constraints specified. Defaults should have
 	// been set by this point, if the user didn't specify constraints.
 	for name, charmStorage := range charmMeta.Storage {
@@ -1171,35 +1718,20 @@ func (st *State) addStorageForUnitOps(
 
 	// Storage addition is based on the charm metadata, so make sure that
 	// the charm URL for the unit or application does not change during
-	// the transaction. If the unit does not have a charm URL set yet,
-	// then we use the application's charm URL.
-	ops := []txn.Op{{
-		C:      unitsC,
-		Id:     u.doc.Name,
-		Assert: bson.D{{"charmurl", u.doc.CharmURL}},
-	}}
-	curl, ok := u.CharmURL()
-	if !ok {
-		a, err := u.Application()
-		if err != nil {
-			return nil, errors.Annotatef(err, "getting application for unit %v", u.doc.Name)
-		}
-		curl = a.doc.CharmURL
-		ops = append(ops, txn.Op{
-			C:      applicationsC,
-			Id:     a.doc.Name,
-			Assert: bson.D{{"charmurl", curl}},
-		})
-	}
-	ch, err := st.Charm(curl)
+	// the transaction.
+	charmMeta, ops, err := st.unitCharmMetaOps(u)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	charmMeta := ch.Meta()
 	charmStorageMeta, ok := charmMeta.Storage[storageName]
 	if !ok {
 		return nil, errors.NotFoundf("charm storage %q", storageName)
 	}
+	if cons.SnapshotId != "" && charmStorageMeta.Type != charm.StorageBlock {
+		// Filesystems are created by formatting a new volume, so
+		// they cannot be restored from a volume snapshot.
+		return nil, errors.NotSupportedf("adding %s storage from a snapshot", charmStorageMeta.Type)
+	}
 
 	// Populate missing configuration parameters with default values.
 	modelConfig, err := st.ModelConfig()
@@ -344,3 +344,48 @@ func (s *storageAddSuite) TestAddStorageStatic(c *gc.C) {
 	s.assertFileSystemCount(c, 1) // no change
 	assertMachineStorageRefs(c, s.State, s.machineTag)
 }
+
+func (s *storageAddSuite) TestAddStorageFromSnapshot(c *gc.C) {
+	u := s.setupMultipleStoragesForAdd(c)
+	s.assignUnit(c, u)
+
+	cons := makeStorageCons("loop-pool", 1024, 1)
+	cons.SnapshotId = "volume-0@1"
+	err := s.State.AddStorageForUnit(s.unitTag, "multi1to10", cons)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertVolumeCount(c, s.originalVolumeCount+1)
+
+	volumes, err := s.State.AllVolumes()
+	c.Assert(err, jc.ErrorIsNil)
+	var snapshotIds []string
+	for _, v := range volumes {
+		params, ok := v.Params()
+		c.Assert(ok, jc.IsTrue)
+		if params.SnapshotId != "" {
+			snapshotIds = append(snapshotIds, params.SnapshotId)
+		}
+	}
+	c.Assert(snapshotIds, jc.DeepEquals, []string{"volume-0@1"})
+}
+
+func (s *storageAddSuite) TestAddStorageFilesystemFromSnapshot(c *gc.C) {
+	_, u, _ := s.setupSingleStorage(c, "filesystem", "loop-pool")
+	cons := makeStorageCons("loop-pool", 1024, 1)
+	cons.SnapshotId = "volume-0@1"
+	err := s.State.AddStorageForUnit(u.UnitTag(), "data", cons)
+	c.Assert(err, gc.ErrorMatches, `adding storage to unit storage-filesystem/0: adding filesystem storage from a snapshot not supported`)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsNotSupported)
+	s.assertStorageCount(c, 1)
+}
+
+func (s *storageAddSuite) TestAddApplicationStorageSnapshotInvalid(c *gc.C) {
+	cons := makeStorageCons("loop", 0, 3)
+	cons.SnapshotId = "volume-0@1"
+	charm := s.AddTestingCharm(c, "storage-block2")
+	_, err := s.State.AddApplication(state.AddApplicationArgs{
+		Name:    "storage-block2",
+		Charm:   charm,
+		Storage: map[string]state.StorageConstraints{"multi1to10": cons},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot add application "storage-block2": snapshot in storage constraints for store "multi1to10" not valid`)
+}
@@ -0,0 +1,189 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/status"
+	"github.com/juju/juju/storage"
+)
+
+// AddExistingVolume imports an existing, already-provisioned volume into
+// the model. The volume is assigned to a new storage instance with the
+// specified storage name, which is not attached to any unit. The tag of
+// the new storage instance is returned; it may then be attached to a unit
+// of an application whose charm has block storage of that name.
+func (st *State) AddExistingVolume(info VolumeInfo, storageName string) (_ names.StorageTag, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot add existing volume %q", info.VolumeId)
+	if err := st.validateExistingVolume(info, storageName, storage.StorageKindBlock); err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	storageTag, ops, err := st.addDetachedStorageInstanceOps(StorageKindBlock, storageName)
+	if err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	volumeOps, _, err := st.addExistingVolumeOps(info, storageTag, storageTag)
+	if err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	if err := st.runTransaction(append(ops, volumeOps...)); err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	return storageTag, nil
+}
+
+// AddExistingFilesystem imports an existing, already-provisioned volume
+// containing a filesystem into the model. The filesystem, managed by Juju
+// on the backing volume, is assigned to a new storage instance with the
+// specified storage name, which is not attached to any unit. The tag of
+// the new storage instance is returned; it may then be attached to a unit
+// of an application whose charm has filesystem storage of that name.
+//
+// The filesystem is not reformatted when it is first attached.
+func (st *State) AddExistingFilesystem(info FilesystemInfo, backingVolume VolumeInfo, storageName string) (_ names.StorageTag, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot add existing filesystem on volume %q", backingVolume.VolumeId)
+	if err := st.validateExistingVolume(backingVolume, storageName, storage.StorageKindFilesystem); err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	if info.Pool == "" {
+		info.Pool = backingVolume.Pool
+	}
+	if info.Size == 0 {
+		info.Size = backingVolume.Size
+	}
+	info.FilesystemId = ""
+
+	storageTag, ops, err := st.addDetachedStorageInstanceOps(StorageKindFilesystem, storageName)
+	if err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	// The filesystem is not scoped to any machine; it will be replaced
+	// with a filesystem scoped to the machine it is first attached to.
+	filesystemId, err := newFilesystemId(st, "")
+	if err != nil {
+		return names.StorageTag{}, errors.Annotate(err, "cannot generate filesystem name")
+	}
+	filesystemTag := names.NewFilesystemTag(filesystemId)
+	volumeOps, volumeTag, err := st.addExistingVolumeOps(backingVolume, storageTag, filesystemTag)
+	if err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	ops = append(ops, volumeOps...)
+	ops = append(ops, st.newFilesystemOps(filesystemDoc{
+		FilesystemId: filesystemId,
+		VolumeId:     volumeTag.Id(),
+		StorageId:    storageTag.Id(),
+		Binding:      storageTag.String(),
+		Info:         &info,
+	}, statusDoc{
+		Status:  status.Detached,
+		Updated: st.clock.Now().UnixNano(),
+	})...)
+	if err := st.runTransaction(ops); err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	return storageTag, nil
+}
+
+// validateExistingVolume returns an error if the existing volume cannot
+// be imported into the model for the specified kind of storage.
+func (st *State) validateExistingVolume(info VolumeInfo, storageName string, kind storage.StorageKind) error {
+	if !names.IsValidStorageName(storageName) {
+		return errors.NotValidf("storage name %q", storageName)
+	}
+	if info.VolumeId == "" {
+		return errors.NotValidf("empty volume ID")
+	}
+	if err := validateStoragePool(st, info.Pool, kind, nil); err != nil {
+		return errors.Trace(err)
+	}
+	_, provider, err := poolStorageProvider(st, info.Pool)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !provider.Supports(storage.StorageKindBlock) {
+		return errors.NotSupportedf("importing %s storage from pool %q", kind, info.Pool)
+	}
+	return errors.Trace(st.CheckExistingVolume(info.VolumeId, info.Pool))
+}
+
+// CheckExistingVolume returns an error satisfying errors.IsAlreadyExists
+// if the volume with the specified provider ID, from the storage provider
+// of the specified pool, is already in the model. Volumes are compared by
+// provider rather than pool, so the same volume cannot be imported twice
+// through different pools. It should be called before an existing volume
+// is prepared for import, so the volume is left untouched if it may not
+// be imported.
+func (st *State) CheckExistingVolume(volumeId, pool string) error {
+	volumes, err := st.volumes(bson.D{{"info.volumeid", volumeId}})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(volumes) == 0 {
+		return nil
+	}
+	providerType, _, err := poolStorageProvider(st, pool)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, v := range volumes {
+		volumeProviderType, _, err := poolStorageProvider(st, v.doc.Info.Pool)
+		if errors.IsNotFound(err) {
+			// The volume's pool has since been removed, so
+			// all that can be compared is the pool name.
+			if v.doc.Info.Pool != pool {
+				continue
+			}
+		} else if err != nil {
+			return errors.Trace(err)
+		} else if volumeProviderType != providerType {
+			continue
+		}
+		return errors.AlreadyExistsf("volume %q in model as %s", volumeId, names.ReadableString(v.Tag()))
+	}
+	return nil
+}
+
+// addDetachedStorageInstanceOps returns txn.Ops for creating a new storage
+// instance that has no owner, and is not attached to any unit.
+func (st *State) addDetachedStorageInstanceOps(kind StorageKind, storageName string) (names.StorageTag, []txn.Op, error) {
+	id, err := newStorageInstanceId(st, storageName)
+	if err != nil {
+		return names.StorageTag{}, nil, errors.Annotate(err, "cannot generate storage instance name")
+	}
+	return names.NewStorageTag(id), []txn.Op{{
+		C:      storageInstancesC,
+		Id:     id,
+		Assert: txn.DocMissing,
+		Insert: &storageInstanceDoc{
+			Id:          id,
+			Kind:        kind,
+			StorageName: storageName,
+		},
+	}}, nil
+}
+
+// addExistingVolumeOps returns txn.Ops for creating a new, unattached
+// volume with the specified info, assigned to the specified storage
+// instance, and with its lifecycle bound to the specified entity.
+func (st *State) addExistingVolumeOps(info VolumeInfo, storageTag names.StorageTag, binding names.Tag) ([]txn.Op, names.VolumeTag, error) {
+	name, err := newVolumeName(st, "")
+	if err != nil {
+		return nil, names.VolumeTag{}, errors.Annotate(err, "cannot generate volume name")
+	}
+	ops := st.newVolumeOps(volumeDoc{
+		Name:      name,
+		StorageId: storageTag.Id(),
+		Binding:   binding.String(),
+		Info:      &info,
+	}, statusDoc{
+		Status:  status.Detached,
+		Updated: st.clock.Now().UnixNano(),
+	})
+	return ops, names.NewVolumeTag(name), nil
+}
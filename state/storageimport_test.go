@@ -0,0 +1,164 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+)
+
+type StorageImportSuite struct {
+	StorageStateSuiteBase
+}
+
+var _ = gc.Suite(&StorageImportSuite{})
+
+func (s *StorageImportSuite) assignedUnit(c *gc.C, kind string) (*state.Unit, *state.Machine) {
+	_, u, _ := s.setupSingleStorage(c, kind, "persistent-block")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	machineId, err := u.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	return u, s.machine(c, machineId)
+}
+
+func (s *StorageImportSuite) TestAddExistingVolume(c *gc.C) {
+	info := state.VolumeInfo{
+		VolumeId:   "vol-123",
+		Pool:       "persistent-block",
+		Size:       1024,
+		Persistent: true,
+	}
+	storageTag, err := s.State.AddExistingVolume(info, "allecto")
+	c.Assert(err, jc.ErrorIsNil)
+
+	si, err := s.State.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(si.Kind(), gc.Equals, state.StorageKindBlock)
+	c.Assert(si.StorageName(), gc.Equals, "allecto")
+	_, ok := si.Owner()
+	c.Assert(ok, jc.IsFalse)
+
+	v := s.storageInstanceVolume(c, storageTag)
+	s.assertVolumeInfo(c, v.VolumeTag(), info)
+	volumeStatus, err := v.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volumeStatus.Status, gc.Equals, status.Detached)
+	attachments, err := s.State.VolumeAttachments(v.VolumeTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachments, gc.HasLen, 0)
+}
+
+func (s *StorageImportSuite) TestAttachExistingVolume(c *gc.C) {
+	u, m := s.assignedUnit(c, "block")
+	storageTag, err := s.State.AddExistingVolume(state.VolumeInfo{
+		VolumeId: "vol-123",
+		Pool:     "persistent-block",
+		Size:     1024,
+	}, "allecto")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.AttachStorage(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	si, err := s.State.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	owner, ok := si.Owner()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(owner, gc.Equals, u.Tag())
+
+	v := s.storageInstanceVolume(c, storageTag)
+	s.volumeAttachment(c, m.MachineTag(), v.VolumeTag())
+}
+
+func (s *StorageImportSuite) TestAttachExistingFilesystem(c *gc.C) {
+	u, m := s.assignedUnit(c, "filesystem")
+	storageTag, err := s.State.AddExistingFilesystem(state.FilesystemInfo{}, state.VolumeInfo{
+		VolumeId: "vol-123",
+		Pool:     "persistent-block",
+		Size:     1024,
+	}, "data")
+	c.Assert(err, jc.ErrorIsNil)
+
+	imported := s.storageInstanceFilesystem(c, storageTag)
+	_, scoped := names.FilesystemMachine(imported.FilesystemTag())
+	c.Assert(scoped, jc.IsFalse)
+	s.assertFilesystemInfo(c, imported.FilesystemTag(), state.FilesystemInfo{
+		Pool: "persistent-block",
+		Size: 1024,
+	})
+
+	err = s.State.AttachStorage(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The imported filesystem is replaced with one managed by the
+	// unit's machine, which takes over the existing filesystem info
+	// and backing volume.
+	f := s.storageInstanceFilesystem(c, storageTag)
+	scope, scoped := names.FilesystemMachine(f.FilesystemTag())
+	c.Assert(scoped, jc.IsTrue)
+	c.Assert(scope, gc.Equals, m.MachineTag())
+	s.assertFilesystemInfo(c, f.FilesystemTag(), state.FilesystemInfo{
+		Pool: "persistent-block",
+		Size: 1024,
+	})
+	s.filesystemAttachment(c, m.MachineTag(), f.FilesystemTag())
+
+	v := s.filesystemVolume(c, f.FilesystemTag())
+	c.Assert(v.LifeBinding(), gc.Equals, f.FilesystemTag())
+	s.volumeAttachment(c, m.MachineTag(), v.VolumeTag())
+	c.Assert(s.filesystem(c, imported.FilesystemTag()).Life(), gc.Equals, state.Dead)
+}
+
+func (s *StorageImportSuite) TestAddExistingVolumeErrors(c *gc.C) {
+	_, err := s.State.AddExistingVolume(state.VolumeInfo{
+		VolumeId: "vol-123", Pool: "persistent-block",
+	}, "0data")
+	c.Assert(err, gc.ErrorMatches, `cannot add existing volume "vol-123": storage name "0data" not valid`)
+
+	_, err = s.State.AddExistingVolume(state.VolumeInfo{
+		VolumeId: "vol-123",
+	}, "data")
+	c.Assert(err, gc.ErrorMatches, `cannot add existing volume "vol-123": pool name is required`)
+
+	_, err = s.State.AddExistingVolume(state.VolumeInfo{
+		Pool: "persistent-block",
+	}, "data")
+	c.Assert(err, gc.ErrorMatches, `cannot add existing volume "": empty volume ID not valid`)
+}
+
+func (s *StorageImportSuite) TestAddExistingVolumeAlreadyInModel(c *gc.C) {
+	info := state.VolumeInfo{VolumeId: "vol-123", Pool: "persistent-block"}
+	_, err := s.State.AddExistingVolume(info, "data")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddExistingFilesystem(state.FilesystemInfo{}, info, "data")
+	c.Assert(err, gc.ErrorMatches, `cannot add existing filesystem on volume "vol-123": volume "vol-123" in model as volume 0 already exists`)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *StorageImportSuite) TestCheckExistingVolume(c *gc.C) {
+	err := s.State.CheckExistingVolume("vol-123", "persistent-block")
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.AddExistingVolume(state.VolumeInfo{VolumeId: "vol-123", Pool: "persistent-block"}, "data")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.CheckExistingVolume("vol-123", "persistent-block")
+	c.Assert(err, gc.ErrorMatches, `volume "vol-123" in model as volume 0 already exists`)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+
+	// The same volume may not be imported again through another
+	// pool of the same provider.
+	err = s.State.CheckExistingVolume("vol-123", "environscoped-block")
+	c.Assert(err, gc.ErrorMatches, `volume "vol-123" in model as volume 0 already exists`)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+
+	// The same ID may identify a different volume of another provider.
+	err = s.State.CheckExistingVolume("vol-123", "loop-pool")
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -0,0 +1,187 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/poolmanager"
+)
+
+// releaseModelVolumesOps returns txn.Ops to mark all of the alive,
+// model-scoped volumes in the model as releasing. Releasing volumes
+// are detached and left intact in the cloud when they are removed,
+// rather than being destroyed.
+//
+// Machine-scoped volumes are not released; they exist only as long
+// as the machine they are scoped to.
+func releaseModelVolumesOps(st *State) ([]txn.Op, error) {
+	volumes, err := st.volumes(bson.D{{"life", Alive}})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return releaseVolumesOps(st, volumes)
+}
+
+// releaseApplicationVolumesOps returns txn.Ops to mark the alive,
+// model-scoped volumes assigned to storage owned by the specified
+// application or its units as releasing. This includes the volumes
+// backing the application's filesystem storage.
+func releaseApplicationVolumesOps(st *State, a *Application) ([]txn.Op, error) {
+	units, err := a.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	owners := []string{a.Tag().String()}
+	for _, u := range units {
+		owners = append(owners, u.Tag().String())
+	}
+
+	coll, closer := st.getCollection(storageInstancesC)
+	defer closer()
+	var docs []storageInstanceDoc
+	err = coll.Find(bson.D{{"owner", bson.D{{"$in", owners}}}}).Select(bson.D{{"id", true}}).All(&docs)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get storage instances for %s", a)
+	}
+	if len(docs) == 0 {
+		return nil, nil
+	}
+	storageIds := make([]string, len(docs))
+	for i, doc := range docs {
+		storageIds[i] = doc.Id
+	}
+
+	filesystems, err := st.filesystems(bson.D{
+		{"storageid", bson.D{{"$in", storageIds}}},
+		{"volumeid", bson.D{{"$exists", true}}},
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	backingVolumeIds := make([]string, len(filesystems))
+	for i, f := range filesystems {
+		backingVolumeIds[i] = f.doc.VolumeId
+	}
+
+	volumes, err := st.volumes(bson.D{
+		{"life", Alive},
+		{"storageid", bson.D{{"$in", storageIds}}},
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(backingVolumeIds) > 0 {
+		backingVolumes, err := st.volumes(bson.D{
+			{"_id", bson.D{{"$in", backingVolumeIds}}},
+			{"life", Alive},
+		})
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		volumes = append(volumes, backingVolumes...)
+	}
+	return releaseVolumesOps(st, volumes)
+}
+
+// releaseVolumesOps returns txn.Ops to mark the specified volumes as
+// releasing. Machine-scoped volumes, and volumes already releasing,
+// are skipped. An error satisfying errors.IsNotSupported is returned
+// if any of the volumes is managed by a storage provider that cannot
+// release volumes.
+func releaseVolumesOps(st *State, volumes []*volume) ([]txn.Op, error) {
+	var release []*volume
+	for _, v := range volumes {
+		if v.doc.Releasing {
+			continue
+		}
+		if _, ok := names.VolumeMachine(v.VolumeTag()); ok {
+			continue
+		}
+		release = append(release, v)
+	}
+	if err := checkVolumesReleasable(st, release); err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops := make([]txn.Op, len(release))
+	for i, v := range release {
+		ops[i] = txn.Op{
+			C:      volumesC,
+			Id:     v.doc.Name,
+			Assert: isAliveDoc,
+			Update: bson.D{{"$set", bson.D{{"releasing", true}}}},
+		}
+	}
+	return ops, nil
+}
+
+// checkVolumesReleasable returns an error satisfying errors.IsNotSupported
+// if any of the specified volumes is managed by a storage provider whose
+// volume source does not support releasing volumes. Such volumes could
+// never be released by the storage provisioner, so they must be rejected
+// before they are marked as releasing.
+func checkVolumesReleasable(st *State, volumes []*volume) error {
+	if len(volumes) == 0 {
+		return nil
+	}
+	registry, err := st.storageProviderRegistry()
+	if err != nil {
+		return errors.Annotate(err, "getting storage provider registry")
+	}
+	poolManager := poolmanager.New(NewStateSettings(st), registry)
+	checked := make(map[string]bool)
+	for _, v := range volumes {
+		var poolName string
+		if v.doc.Info != nil {
+			poolName = v.doc.Info.Pool
+		} else if v.doc.Params != nil {
+			poolName = v.doc.Params.Pool
+		}
+		if checked[poolName] {
+			continue
+		}
+		cfg, err := poolManager.Get(poolName)
+		if errors.IsNotFound(err) {
+			// If there's no pool called poolName, the
+			// volume was created with a provider type.
+			cfg, err = storage.NewConfig(poolName, storage.ProviderType(poolName), nil)
+		}
+		if err != nil {
+			return errors.Trace(err)
+		}
+		provider, err := registry.StorageProvider(cfg.Provider())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		source, err := provider.VolumeSource(cfg)
+		if err != nil && !errors.IsNotSupported(err) {
+			return errors.Trace(err)
+		}
+		if _, ok := source.(storage.VolumeReleaser); !ok {
+			return errors.NotSupportedf("releasing volumes with storage provider %q", cfg.Provider())
+		}
+		checked[poolName] = true
+	}
+	return nil
+}
+
+// checkNoReleasingVolumes returns an error if any volumes in the model
+// are yet to be released. The model's cloud resources must not be torn
+// down until its releasing volumes have been dissociated from it.
+func checkNoReleasingVolumes(st *State) error {
+	coll, closer := st.getCollection(volumesC)
+	defer closer()
+	n, err := coll.Find(bson.D{{"releasing", true}}).Count()
+	if err != nil {
+		return errors.Annotate(err, "counting releasing volumes")
+	}
+	if n > 0 {
+		return errors.Errorf("model not empty, found %d volume(s) being released", n)
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+)
+
+type StorageReleaseSuite struct {
+	StorageStateSuiteBase
+}
+
+var _ = gc.Suite(&StorageReleaseSuite{})
+
+func (s *StorageReleaseSuite) assignedStorageVolume(c *gc.C, kind, pool string) (*state.Application, names.VolumeTag) {
+	app, u, _ := s.setupSingleStorage(c, kind, pool)
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	storageAttachments, err := s.State.UnitStorageAttachments(u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(storageAttachments, gc.HasLen, 1)
+	storageTag := storageAttachments[0].StorageInstance()
+	if kind == "block" {
+		return app, s.storageInstanceVolume(c, storageTag).VolumeTag()
+	}
+	f := s.storageInstanceFilesystem(c, storageTag)
+	return app, s.filesystemVolume(c, f.FilesystemTag()).VolumeTag()
+}
+
+func (s *StorageReleaseSuite) TestDestroyModelReleasingStorage(c *gc.C) {
+	_, volumeTag := s.assignedStorageVolume(c, "block", "persistent-block")
+	c.Assert(s.volume(c, volumeTag).Releasing(), jc.IsFalse)
+
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	err = model.DestroyReleasingStorage()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.volume(c, volumeTag).Releasing(), jc.IsTrue)
+}
+
+func (s *StorageReleaseSuite) TestDestroyModelReleasingStorageNotSupported(c *gc.C) {
+	// The "environscoped" provider cannot release volumes, so the
+	// model is not destroyed, rather than leaving the volume to be
+	// released forever.
+	_, volumeTag := s.assignedStorageVolume(c, "block", "environscoped")
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	err = model.DestroyReleasingStorage()
+	c.Assert(err, gc.ErrorMatches, `failed to destroy model: releasing volumes with storage provider "environscoped" not supported`)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(s.volume(c, volumeTag).Releasing(), jc.IsFalse)
+	err = model.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Life(), gc.Equals, state.Alive)
+}
+
+func (s *StorageReleaseSuite) TestDestroyModelDestroyingStorage(c *gc.C) {
+	_, volumeTag := s.assignedStorageVolume(c, "block", "persistent-block")
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	err = model.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.volume(c, volumeTag).Releasing(), jc.IsFalse)
+}
+
+func (s *StorageReleaseSuite) TestDestroyModelReleasingStorageMachineScoped(c *gc.C) {
+	// Machine-scoped volumes live and die with their machines,
+	// so they are never released.
+	_, volumeTag := s.assignedStorageVolume(c, "block", "loop-pool")
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	err = model.DestroyReleasingStorage()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.volume(c, volumeTag).Releasing(), jc.IsFalse)
+}
+
+func (s *StorageReleaseSuite) TestDestroyApplicationReleasingStorage(c *gc.C) {
+	app, volumeTag := s.assignedStorageVolume(c, "block", "persistent-block")
+	err := app.DestroyReleasingStorage()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.volume(c, volumeTag).Releasing(), jc.IsTrue)
+}
+
+func (s *StorageReleaseSuite) TestDestroyApplicationReleasingStorageNotSupported(c *gc.C) {
+	app, volumeTag := s.assignedStorageVolume(c, "block", "environscoped")
+	err := app.DestroyReleasingStorage()
+	c.Assert(err, gc.ErrorMatches, `cannot destroy application "storage-block": releasing volumes with storage provider "environscoped" not supported`)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(s.volume(c, volumeTag).Releasing(), jc.IsFalse)
+	err = app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.Life(), gc.Equals, state.Alive)
+}
+
+func (s *StorageReleaseSuite) TestDestroyApplicationReleasingFilesystemStorage(c *gc.C) {
+	// The volume backing a filesystem is released.
+	app, volumeTag := s.assignedStorageVolume(c, "filesystem", "persistent-block")
+	err := app.DestroyReleasingStorage()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.volume(c, volumeTag).Releasing(), jc.IsTrue)
+}
+
+func (s *StorageReleaseSuite) TestDestroyApplicationReleasingStorageOtherApplication(c *gc.C) {
+	app, _ := s.assignedStorageVolume(c, "block", "persistent-block")
+	_, otherVolumeTag := s.assignedStorageVolume(c, "filesystem", "persistent-block")
+
+	err := app.DestroyReleasingStorage()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.volume(c, otherVolumeTag).Releasing(), jc.IsFalse)
+}
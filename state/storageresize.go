@@ -0,0 +1,184 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// ResizeStorage requests that the volume backing the specified storage
+// instance be grown to the specified size, in MiB. Once the volume has
+// been resized, the filesystem on it, if any, is grown to fill it.
+//
+// Storage can only be grown; the requested size must be larger than
+// the current size of the volume.
+func (st *State) ResizeStorage(tag names.StorageTag, size uint64) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot resize storage %s", tag.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		s, err := st.storageInstance(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if s.Life() != Alive {
+			return nil, errors.New("storage is not alive")
+		}
+		v, err := st.storageInstanceBackingVolume(s)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if v.Life() != Alive {
+			return nil, errors.New("volume is not alive")
+		}
+		info, err := v.Info()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if size <= info.Size {
+			return nil, errors.Errorf(
+				"new size %dM must be larger than current size %dM",
+				size, info.Size,
+			)
+		}
+		if requestedSize, ok := v.RequestedSize(); ok && requestedSize >= size {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:  volumesC,
+			Id: v.doc.Name,
+			Assert: append(bson.D{
+				{"info", bson.D{{"$exists", true}}},
+			}, isAliveDoc...),
+			Update: bson.D{{"$set", bson.D{{"requestedsize", size}}}},
+		}}, nil
+	}
+	return st.run(buildTxn)
+}
+
+// storageInstanceBackingVolume returns the volume that backs the
+// specified storage instance. Filesystem storage that is not backed
+// by a volume cannot be resized, and an error satisfying
+// errors.IsNotSupported is returned.
+func (st *State) storageInstanceBackingVolume(s *storageInstance) (*volume, error) {
+	if s.Kind() == StorageKindBlock {
+		return st.storageInstanceVolume(s.StorageTag())
+	}
+	f, err := st.storageInstanceFilesystem(s.StorageTag())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if f.doc.VolumeId == "" {
+		return nil, errors.NotSupportedf("resizing filesystem not backed by a volume")
+	}
+	return st.volumeByTag(names.NewVolumeTag(f.doc.VolumeId))
+}
+
+// volumeResizedOps returns txn.Ops for recording that a pending resize
+// of the specified volume has been completed. If there is a filesystem
+// on the volume, the filesystem is requested to be grown to fill it.
+func (st *State) volumeResizedOps(tag names.VolumeTag, requestedSize, newSize uint64) ([]txn.Op, error) {
+	ops := []txn.Op{{
+		C:      volumesC,
+		Id:     tag.Id(),
+		Assert: bson.D{{"requestedsize", requestedSize}},
+		Update: bson.D{{"$unset", bson.D{{"requestedsize", nil}}}},
+	}}
+	f, err := st.volumeFilesystem(tag)
+	if errors.IsNotFound(err) {
+		return ops, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if f.Life() != Alive {
+		return ops, nil
+	}
+	return append(ops, txn.Op{
+		C:      filesystemsC,
+		Id:     f.FilesystemTag().Id(),
+		Assert: isAliveDoc,
+		Update: bson.D{{"$set", bson.D{{"requestedsize", newSize}}}},
+	}), nil
+}
+
+// filesystemResizedOps returns txn.Ops for recording that a pending
+// resize of the specified filesystem has been completed.
+func filesystemResizedOps(tag names.FilesystemTag, requestedSize uint64) []txn.Op {
+	return []txn.Op{{
+		C:      filesystemsC,
+		Id:     tag.Id(),
+		Assert: bson.D{{"requestedsize", requestedSize}},
+		Update: bson.D{{"$unset", bson.D{{"requestedsize", nil}}}},
+	}}
+}
+
+// WatchModelVolumeResizes returns a StringsWatcher that notifies of
+// changes to all model-scoped volumes, so that requests to resize them
+// may be observed.
+func (st *State) WatchModelVolumeResizes() StringsWatcher {
+	return st.watchModelStorageResizes(volumesC)
+}
+
+// WatchModelFilesystemResizes returns a StringsWatcher that notifies of
+// changes to all model-scoped filesystems, so that requests to resize
+// them may be observed.
+func (st *State) WatchModelFilesystemResizes() StringsWatcher {
+	return st.watchModelStorageResizes(filesystemsC)
+}
+
+// WatchMachineVolumeResizes returns a StringsWatcher that notifies of
+// changes to all volumes scoped to the specified machine, so that
+// requests to resize them may be observed.
+func (st *State) WatchMachineVolumeResizes(m names.MachineTag) StringsWatcher {
+	return st.watchMachineStorageResizes(m, volumesC)
+}
+
+// WatchMachineFilesystemResizes returns a StringsWatcher that notifies of
+// changes to all filesystems scoped to the specified machine, so that
+// requests to resize them may be observed.
+func (st *State) WatchMachineFilesystemResizes(m names.MachineTag) StringsWatcher {
+	return st.watchMachineStorageResizes(m, filesystemsC)
+}
+
+func (st *State) watchModelStorageResizes(collection string) StringsWatcher {
+	return newcollectionWatcher(st, colWCfg{
+		col: collection,
+		filter: func(id interface{}) bool {
+			k, err := st.strictLocalID(id.(string))
+			if err != nil {
+				return false
+			}
+			return !strings.Contains(k, "/")
+		},
+	})
+}
+
+func (st *State) watchMachineStorageResizes(m names.MachineTag, collection string) StringsWatcher {
+	prefix := m.Id() + "/"
+	return newcollectionWatcher(st, colWCfg{
+		col: collection,
+		filter: func(id interface{}) bool {
+			k, err := st.strictLocalID(id.(string))
+			if err != nil {
+				return false
+			}
+			return strings.HasPrefix(k, prefix)
+		},
+	})
+}
+
+// WatchVolume returns a watcher for observing changes to a volume.
+func (st *State) WatchVolume(tag names.VolumeTag) NotifyWatcher {
+	return newEntityWatcher(st, volumesC, st.docID(tag.Id()))
+}
+
+// WatchFilesystem returns a watcher for observing changes to a
+// filesystem.
+func (st *State) WatchFilesystem(tag names.FilesystemTag) NotifyWatcher {
+	return newEntityWatcher(st, filesystemsC, st.docID(tag.Id()))
+}
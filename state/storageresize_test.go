@@ -0,0 +1,134 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/testing"
+)
+
+type StorageResizeSuite struct {
+	StorageStateSuiteBase
+}
+
+var _ = gc.Suite(&StorageResizeSuite{})
+
+func (s *StorageResizeSuite) provisionedBlockStorage(c *gc.C) (names.StorageTag, names.VolumeTag) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "persistent-block")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	volumeTag := s.storageInstanceVolume(c, storageTag).VolumeTag()
+	err = s.State.SetVolumeInfo(volumeTag, state.VolumeInfo{VolumeId: "vol-123", Size: 1024})
+	c.Assert(err, jc.ErrorIsNil)
+	return storageTag, volumeTag
+}
+
+func (s *StorageResizeSuite) TestResizeStorageBlock(c *gc.C) {
+	storageTag, volumeTag := s.provisionedBlockStorage(c)
+
+	err := s.State.ResizeStorage(storageTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	requestedSize, ok := s.volume(c, volumeTag).RequestedSize()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(requestedSize, gc.Equals, uint64(2048))
+
+	// Once the volume has been resized, the request is cleared.
+	err = s.State.SetVolumeInfo(volumeTag, state.VolumeInfo{
+		VolumeId: "vol-123",
+		Pool:     "persistent-block",
+		Size:     2048,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok = s.volume(c, volumeTag).RequestedSize()
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *StorageResizeSuite) TestResizeStorageIncomplete(c *gc.C) {
+	storageTag, volumeTag := s.provisionedBlockStorage(c)
+	err := s.State.ResizeStorage(storageTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The request remains pending until the volume is at least
+	// as large as requested.
+	err = s.State.SetVolumeInfo(volumeTag, state.VolumeInfo{
+		VolumeId: "vol-123",
+		Pool:     "persistent-block",
+		Size:     1536,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	requestedSize, ok := s.volume(c, volumeTag).RequestedSize()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(requestedSize, gc.Equals, uint64(2048))
+}
+
+func (s *StorageResizeSuite) TestResizeStorageFilesystem(c *gc.C) {
+	storageTag, err := s.State.AddExistingFilesystem(state.FilesystemInfo{}, state.VolumeInfo{
+		VolumeId: "vol-123",
+		Pool:     "persistent-block",
+		Size:     1024,
+	}, "data")
+	c.Assert(err, jc.ErrorIsNil)
+	f := s.storageInstanceFilesystem(c, storageTag)
+	v := s.filesystemVolume(c, f.FilesystemTag())
+
+	err = s.State.ResizeStorage(storageTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok := s.filesystem(c, f.FilesystemTag()).RequestedSize()
+	c.Assert(ok, jc.IsFalse)
+
+	// Once the volume has been resized, the filesystem on
+	// it is requested to be grown to fill it.
+	err = s.State.SetVolumeInfo(v.VolumeTag(), state.VolumeInfo{
+		VolumeId: "vol-123",
+		Pool:     "persistent-block",
+		Size:     2050,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok = s.volume(c, v.VolumeTag()).RequestedSize()
+	c.Assert(ok, jc.IsFalse)
+	requestedSize, ok := s.filesystem(c, f.FilesystemTag()).RequestedSize()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(requestedSize, gc.Equals, uint64(2050))
+}
+
+func (s *StorageResizeSuite) TestResizeStorageNotLarger(c *gc.C) {
+	storageTag, _ := s.provisionedBlockStorage(c)
+	err := s.State.ResizeStorage(storageTag, 1024)
+	c.Assert(err, gc.ErrorMatches, `cannot resize storage data/0: new size 1024M must be larger than current size 1024M`)
+}
+
+func (s *StorageResizeSuite) TestResizeStorageUnprovisioned(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "persistent-block")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.ResizeStorage(storageTag, 2048)
+	c.Assert(err, gc.ErrorMatches, `cannot resize storage data/0: volume "0" not provisioned`)
+}
+
+func (s *StorageResizeSuite) TestResizeStorageNotVolumeBacked(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "filesystem", "rootfs")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.ResizeStorage(storageTag, 2048)
+	c.Assert(err, gc.ErrorMatches, `cannot resize storage data/0: resizing filesystem not backed by a volume not supported`)
+}
+
+func (s *StorageResizeSuite) TestWatchModelVolumeResizes(c *gc.C) {
+	storageTag, volumeTag := s.provisionedBlockStorage(c)
+
+	w := s.State.WatchModelVolumeResizes()
+	defer testing.AssertStop(c, w)
+	wc := testing.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChangeInSingleEvent(volumeTag.Id()) // initial
+	wc.AssertNoChange()
+
+	err := s.State.ResizeStorage(storageTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent(volumeTag.Id())
+	wc.AssertNoChange()
+}
@@ -0,0 +1,307 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+	"gopkg.in/tomb.v1"
+
+	"github.com/juju/juju/state/watcher"
+)
+
+// VolumeSnapshot describes a snapshot of a machine-scoped volume,
+// taken by the storage provisioner of the machine that manages the
+// volume.
+type VolumeSnapshot struct {
+	// SnapshotId is the storage provider's ID for the snapshot.
+	SnapshotId string `bson:"snapshotid"`
+
+	// Size is the size of the snapshotted volume, in MiB.
+	Size uint64 `bson:"size"`
+
+	// Created is the time at which the snapshot was taken.
+	Created time.Time `bson:"created"`
+
+	// Dying is true if the snapshot has been requested to be
+	// deleted, and is to be deleted by the storage provisioner.
+	Dying bool `bson:"dying,omitempty"`
+}
+
+// RequestVolumeSnapshot requests that a snapshot be taken of the
+// specified machine-scoped volume, by the storage provisioner of the
+// machine that manages it. Snapshots of model-scoped volumes are taken
+// directly with the storage provider, and are not requested in state.
+//
+// If a snapshot has already been requested and not yet taken, no
+// further snapshot is requested.
+func (st *State) RequestVolumeSnapshot(tag names.VolumeTag) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot request snapshot of volume %s", tag.Id())
+	if _, ok := names.VolumeMachine(tag); !ok {
+		return errors.NotSupportedf("requesting snapshot of model-scoped volume")
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		v, err := st.volumeByTag(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if v.Life() != Alive {
+			return nil, errors.New("volume is not alive")
+		}
+		if _, err := v.Info(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if v.SnapshotRequested() {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:  volumesC,
+			Id: v.doc.Name,
+			Assert: append(bson.D{
+				{"info", bson.D{{"$exists", true}}},
+			}, isAliveDoc...),
+			Update: bson.D{{"$set", bson.D{{"snapshotrequested", true}}}},
+		}}, nil
+	}
+	return st.run(buildTxn)
+}
+
+// VolumeSnapshotTaken records that the requested snapshot of the
+// specified volume has been attempted. If the snapshot was taken,
+// its details are recorded with the volume; snapshot is nil if the
+// attempt failed.
+func (st *State) VolumeSnapshotTaken(tag names.VolumeTag, snapshot *VolumeSnapshot) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot record snapshot of volume %s", tag.Id())
+	update := bson.D{{"$unset", bson.D{{"snapshotrequested", nil}}}}
+	if snapshot != nil {
+		if snapshot.SnapshotId == "" {
+			return errors.NotValidf("empty snapshot ID")
+		}
+		doc := *snapshot
+		doc.Dying = false
+		update = append(update, bson.DocElem{"$push", bson.D{{"snapshots", doc}}})
+	}
+	ops := []txn.Op{{
+		C:      volumesC,
+		Id:     tag.Id(),
+		Assert: bson.D{{"snapshotrequested", true}},
+		Update: update,
+	}}
+	if err := st.runTransaction(ops); err == txn.ErrAborted {
+		return errors.New("no snapshot requested")
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// DestroyVolumeSnapshot requests that the specified snapshot of a
+// machine-scoped volume be deleted by the storage provisioner of the
+// machine that manages the volume.
+func (st *State) DestroyVolumeSnapshot(tag names.VolumeTag, snapshotId string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot destroy snapshot %q of volume %s", snapshotId, tag.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		v, err := st.volumeByTag(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		var found *VolumeSnapshot
+		for i, snapshot := range v.doc.Snapshots {
+			if snapshot.SnapshotId == snapshotId {
+				found = &v.doc.Snapshots[i]
+				break
+			}
+		}
+		if found == nil {
+			return nil, errors.NotFoundf("snapshot %q", snapshotId)
+		}
+		if found.Dying {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:  volumesC,
+			Id: v.doc.Name,
+			Assert: bson.D{{"snapshots", bson.D{{"$elemMatch", bson.D{
+				{"snapshotid", snapshotId},
+				{"dying", bson.D{{"$ne", true}}},
+			}}}}},
+			Update: bson.D{{"$set", bson.D{{"snapshots.$.dying", true}}}},
+		}}, nil
+	}
+	return st.run(buildTxn)
+}
+
+// RemoveVolumeSnapshots removes the records of the specified snapshots
+// of a machine-scoped volume, once the storage provisioner has deleted
+// them. Only snapshots that have been destroyed may be removed.
+func (st *State) RemoveVolumeSnapshots(tag names.VolumeTag, snapshotIds []string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot remove snapshots of volume %s", tag.Id())
+	if len(snapshotIds) == 0 {
+		return nil
+	}
+	ops := []txn.Op{{
+		C:      volumesC,
+		Id:     tag.Id(),
+		Assert: txn.DocExists,
+		Update: bson.D{{"$pull", bson.D{{"snapshots", bson.D{
+			{"snapshotid", bson.D{{"$in", snapshotIds}}},
+			{"dying", true},
+		}}}}},
+	}}
+	if err := st.runTransaction(ops); err == txn.ErrAborted {
+		return errors.NotFoundf("volume %s", tag.Id())
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// WatchMachineVolumeSnapshots returns a StringsWatcher that notifies of
+// volumes scoped to the specified machine which have snapshot operations
+// pending, either because a snapshot has been requested or because
+// snapshots have been destroyed. Other changes to the volumes are not
+// reported. The initial event contains the volumes with operations
+// already pending.
+func (st *State) WatchMachineVolumeSnapshots(m names.MachineTag) StringsWatcher {
+	return newVolumeSnapshotsWatcher(st, m)
+}
+
+// volumeSnapshotsWatcher notifies of changes to the snapshot operations
+// pending for the volumes scoped to a machine.
+type volumeSnapshotsWatcher struct {
+	commonWatcher
+	prefix string
+	known  map[string]string
+	out    chan []string
+}
+
+var _ Watcher = (*volumeSnapshotsWatcher)(nil)
+
+func newVolumeSnapshotsWatcher(st *State, m names.MachineTag) StringsWatcher {
+	w := &volumeSnapshotsWatcher{
+		commonWatcher: newCommonWatcher(st),
+		prefix:        m.Id() + "/",
+		known:         make(map[string]string),
+		out:           make(chan []string),
+	}
+	go func() {
+		defer w.tomb.Done()
+		defer close(w.out)
+		w.tomb.Kill(w.loop())
+	}()
+	return w
+}
+
+// pendingSnapshotOps returns a summary of the snapshot operations
+// pending for the volume, which is empty if there are none.
+func pendingSnapshotOps(doc *volumeDoc) string {
+	var ops []string
+	if doc.SnapshotRequested {
+		ops = append(ops, "requested")
+	}
+	for _, snapshot := range doc.Snapshots {
+		if snapshot.Dying {
+			ops = append(ops, snapshot.SnapshotId)
+		}
+	}
+	return strings.Join(ops, " ")
+}
+
+func (w *volumeSnapshotsWatcher) filter(id interface{}) bool {
+	k, err := w.st.strictLocalID(id.(string))
+	if err != nil {
+		return false
+	}
+	return strings.HasPrefix(k, w.prefix)
+}
+
+func (w *volumeSnapshotsWatcher) initial() (set.Strings, error) {
+	ids := make(set.Strings)
+	volumes, closer := w.st.getCollection(volumesC)
+	defer closer()
+
+	var doc volumeDoc
+	iter := volumes.Find(nil).Iter()
+	for iter.Next(&doc) {
+		if !strings.HasPrefix(doc.Name, w.prefix) {
+			continue
+		}
+		if ops := pendingSnapshotOps(&doc); ops != "" {
+			w.known[doc.Name] = ops
+			ids.Add(doc.Name)
+		}
+	}
+	return ids, iter.Close()
+}
+
+func (w *volumeSnapshotsWatcher) merge(ids set.Strings, change watcher.Change) error {
+	name := w.st.localID(change.Id.(string))
+	if change.Revno == -1 {
+		delete(w.known, name)
+		ids.Remove(name)
+		return nil
+	}
+	volumes, closer := w.st.getCollection(volumesC)
+	defer closer()
+	var doc volumeDoc
+	if err := volumes.FindId(change.Id).One(&doc); err == mgo.ErrNotFound {
+		delete(w.known, name)
+		ids.Remove(name)
+		return nil
+	} else if err != nil {
+		return err
+	}
+	ops := pendingSnapshotOps(&doc)
+	if ops == "" {
+		delete(w.known, name)
+		return nil
+	}
+	if ops != w.known[name] {
+		w.known[name] = ops
+		ids.Add(name)
+	}
+	return nil
+}
+
+func (w *volumeSnapshotsWatcher) loop() error {
+	ch := make(chan watcher.Change)
+	w.watcher.WatchCollectionWithFilter(volumesC, ch, w.filter)
+	defer w.watcher.UnwatchCollection(volumesC, ch)
+	ids, err := w.initial()
+	if err != nil {
+		return err
+	}
+	out := w.out
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-w.watcher.Dead():
+			return stateWatcherDeadError(w.watcher.Err())
+		case change := <-ch:
+			if err := w.merge(ids, change); err != nil {
+				return err
+			}
+			if !ids.IsEmpty() {
+				out = w.out
+			}
+		case out <- ids.Values():
+			out = nil
+			ids = make(set.Strings)
+		}
+	}
+}
+
+// Changes returns the event channel for the watcher.
+func (w *volumeSnapshotsWatcher) Changes() <-chan []string {
+	return w.out
+}
@@ -0,0 +1,171 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type StorageSnapshotSuite struct {
+	StorageStateSuiteBase
+}
+
+var _ = gc.Suite(&StorageSnapshotSuite{})
+
+func (s *StorageSnapshotSuite) provisionedLoopVolume(c *gc.C) names.VolumeTag {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	volumeTag := s.storageInstanceVolume(c, storageTag).VolumeTag()
+	_, scoped := names.VolumeMachine(volumeTag)
+	c.Assert(scoped, jc.IsTrue)
+	err = s.State.SetVolumeInfo(volumeTag, state.VolumeInfo{VolumeId: "loop0", Size: 1024})
+	c.Assert(err, jc.ErrorIsNil)
+	return volumeTag
+}
+
+func (s *StorageSnapshotSuite) TestRequestVolumeSnapshot(c *gc.C) {
+	volumeTag := s.provisionedLoopVolume(c)
+	c.Assert(s.volume(c, volumeTag).SnapshotRequested(), jc.IsFalse)
+
+	err := s.State.RequestVolumeSnapshot(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.volume(c, volumeTag).SnapshotRequested(), jc.IsTrue)
+
+	// A pending request is not repeated.
+	err = s.State.RequestVolumeSnapshot(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	created := time.Date(2017, 6, 1, 10, 15, 0, 0, time.UTC)
+	snapshot := state.VolumeSnapshot{SnapshotId: "volume-0-0@1", Size: 1024, Created: created}
+	err = s.State.VolumeSnapshotTaken(volumeTag, &snapshot)
+	c.Assert(err, jc.ErrorIsNil)
+	v := s.volume(c, volumeTag)
+	c.Assert(v.SnapshotRequested(), jc.IsFalse)
+	c.Assert(v.Snapshots(), gc.HasLen, 1)
+	c.Assert(v.Snapshots()[0].SnapshotId, gc.Equals, "volume-0-0@1")
+	c.Assert(v.Snapshots()[0].Created.Equal(created), jc.IsTrue)
+
+	err = s.State.VolumeSnapshotTaken(volumeTag, &snapshot)
+	c.Assert(err, gc.ErrorMatches, "cannot record snapshot of volume .*: no snapshot requested")
+}
+
+func (s *StorageSnapshotSuite) TestVolumeSnapshotFailed(c *gc.C) {
+	volumeTag := s.provisionedLoopVolume(c)
+	err := s.State.RequestVolumeSnapshot(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.VolumeSnapshotTaken(volumeTag, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	v := s.volume(c, volumeTag)
+	c.Assert(v.SnapshotRequested(), jc.IsFalse)
+	c.Assert(v.Snapshots(), gc.HasLen, 0)
+}
+
+func (s *StorageSnapshotSuite) TestRequestVolumeSnapshotModelScoped(c *gc.C) {
+	err := s.State.RequestVolumeSnapshot(names.NewVolumeTag("0"))
+	c.Assert(err, gc.ErrorMatches, "cannot request snapshot of volume 0: requesting snapshot of model-scoped volume not supported")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *StorageSnapshotSuite) TestRequestVolumeSnapshotNotProvisioned(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	volumeTag := s.storageInstanceVolume(c, storageTag).VolumeTag()
+
+	err = s.State.RequestVolumeSnapshot(volumeTag)
+	c.Assert(err, gc.ErrorMatches, `cannot request snapshot of volume .*: volume ".*" not provisioned`)
+}
+
+func (s *StorageSnapshotSuite) TestDestroyAndRemoveVolumeSnapshot(c *gc.C) {
+	volumeTag := s.provisionedLoopVolume(c)
+	for _, id := range []string{"volume-0-0@1", "volume-0-0@2"} {
+		err := s.State.RequestVolumeSnapshot(volumeTag)
+		c.Assert(err, jc.ErrorIsNil)
+		err = s.State.VolumeSnapshotTaken(volumeTag, &state.VolumeSnapshot{SnapshotId: id, Size: 1024})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	err := s.State.DestroyVolumeSnapshot(volumeTag, "volume-0-0@3")
+	c.Assert(err, gc.ErrorMatches, `cannot destroy snapshot "volume-0-0@3" of volume .*: snapshot "volume-0-0@3" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	err = s.State.DestroyVolumeSnapshot(volumeTag, "volume-0-0@1")
+	c.Assert(err, jc.ErrorIsNil)
+	snapshots := s.volume(c, volumeTag).Snapshots()
+	c.Assert(snapshots, gc.HasLen, 2)
+	c.Assert(snapshots[0].Dying, jc.IsTrue)
+	c.Assert(snapshots[1].Dying, jc.IsFalse)
+
+	// Only destroyed snapshots are removed.
+	err = s.State.RemoveVolumeSnapshots(volumeTag, []string{"volume-0-0@1", "volume-0-0@2"})
+	c.Assert(err, jc.ErrorIsNil)
+	snapshots = s.volume(c, volumeTag).Snapshots()
+	c.Assert(snapshots, gc.HasLen, 1)
+	c.Assert(snapshots[0].SnapshotId, gc.Equals, "volume-0-0@2")
+}
+
+func (s *StorageSnapshotSuite) TestWatchMachineVolumeSnapshots(c *gc.C) {
+	volumeTag := s.provisionedLoopVolume(c)
+	machineTag, _ := names.VolumeMachine(volumeTag)
+	w := s.State.WatchMachineVolumeSnapshots(machineTag)
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChangeInSingleEvent() // initial
+	wc.AssertNoChange()
+
+	// Changes to other fields of the volume are not reported.
+	err := s.State.SetVolumeInfo(volumeTag, state.VolumeInfo{VolumeId: "loop0", Pool: "loop-pool", Size: 2048})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	err = s.State.RequestVolumeSnapshot(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent(volumeTag.Id())
+	wc.AssertNoChange()
+
+	// Recording the outcome leaves nothing pending.
+	snapshot := state.VolumeSnapshot{SnapshotId: "volume-0-0@1", Size: 2048}
+	err = s.State.VolumeSnapshotTaken(volumeTag, &snapshot)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	err = s.State.DestroyVolumeSnapshot(volumeTag, "volume-0-0@1")
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent(volumeTag.Id())
+	wc.AssertNoChange()
+
+	err = s.State.RemoveVolumeSnapshots(volumeTag, []string{"volume-0-0@1"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+}
+
+func (s *StorageSnapshotSuite) TestWatchMachineVolumeSnapshotsInitial(c *gc.C) {
+	volumeTag := s.provisionedLoopVolume(c)
+	err := s.State.RequestVolumeSnapshot(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	machineTag, _ := names.VolumeMachine(volumeTag)
+	w := s.State.WatchMachineVolumeSnapshots(machineTag)
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChangeInSingleEvent(volumeTag.Id()) // initial
+	wc.AssertNoChange()
+
+	// Volumes scoped to other machines are not reported.
+	w = s.State.WatchMachineVolumeSnapshots(names.NewMachineTag("42"))
+	defer statetesting.AssertStop(c, w)
+	wc = statetesting.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChangeInSingleEvent() // initial
+	wc.AssertNoChange()
+}
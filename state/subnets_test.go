@@ -11,6 +11,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
 )
 
 type SubnetSuite struct {
@@ -272,3 +273,21 @@ func (s *SubnetSuite) TestAllSubnets(c *gc.C) {
 		c.Assert(subnet.AvailabilityZone(), gc.Equals, subnetInfos[i].AvailabilityZone)
 	}
 }
+
+func (s *SubnetSuite) TestWatchSubnets(c *gc.C) {
+	w := s.State.WatchSubnets()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChangeInSingleEvent()
+	wc.AssertNoChange()
+
+	subnet, err := s.State.AddSubnet(state.SubnetInfo{CIDR: "10.0.0.0/24", SpaceName: "mgmt"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange("10.0.0.0/24")
+	wc.AssertNoChange()
+
+	err = subnet.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange("10.0.0.0/24")
+	wc.AssertNoChange()
+}
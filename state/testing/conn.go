@@ -117,6 +117,9 @@ func StorageProviders() storage.ProviderRegistry {
 					SupportsFunc: func(k storage.StorageKind) bool {
 						return k == storage.StorageKindBlock
 					},
+					VolumeSourceFunc: func(*storage.Config) (storage.VolumeSource, error) {
+						return &dummystorage.VolumeSource{}, nil
+					},
 				},
 				"machinescoped": &dummystorage.StorageProvider{
 					StorageScope: storage.ScopeMachine,
@@ -39,6 +39,9 @@ func (st *State) ProcessDyingModel() (err error) {
 		if err := model.checkEmpty(); err != nil {
 			return nil, errors.Trace(err)
 		}
+		if err := checkNoReleasingVolumes(st); err != nil {
+			return nil, errors.Trace(err)
+		}
 
 		ops := []txn.Op{{
 			C:      modelsC,
@@ -1829,6 +1829,7 @@ func machineStorageParamsForStorageInstance(
 ) (*machineStorageParams, error) {
 
 	charmStorage := charmMeta.Storage[storage.StorageName()]
+	owner, _ := storage.Owner()
 
 	var volumes []MachineVolumeParams
 	var filesystems []MachineFilesystemParams
@@ -1840,15 +1841,16 @@ func machineStorageParamsForStorageInstance(
 		volumeAttachmentParams := VolumeAttachmentParams{
 			charmStorage.ReadOnly,
 		}
-		if unit == storage.Owner() {
+		if unit == owner {
 			// The storage instance is owned by the unit, so we'll need
 			// to create a volume.
 			cons := allCons[storage.StorageName()]
 			volumeParams := VolumeParams{
-				storage: storage.StorageTag(),
-				binding: storage.StorageTag(),
-				Pool:    cons.Pool,
-				Size:    cons.Size,
+				storage:    storage.StorageTag(),
+				binding:    storage.StorageTag(),
+				Pool:       cons.Pool,
+				Size:       cons.Size,
+				SnapshotId: cons.SnapshotId,
 			}
 			volumes = append(volumes, MachineVolumeParams{
 				volumeParams, volumeAttachmentParams,
@@ -1876,7 +1878,7 @@ func machineStorageParamsForStorageInstance(
 			location,
 			charmStorage.ReadOnly,
 		}
-		if unit == storage.Owner() {
+		if unit == owner {
 			// The storage instance is owned by the unit, so we'll need
 			// to create a filesystem.
 			cons := allCons[storage.StorageName()]
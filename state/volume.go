@@ -46,6 +46,27 @@ type Volume interface {
 	// if it has not already been provisioned. Params returns true if the
 	// returned parameters are usable for provisioning, otherwise false.
 	Params() (VolumeParams, bool)
+
+	// RequestedSize returns the size in MiB that the volume has been
+	// requested to be resized to, if a resize is pending. RequestedSize
+	// returns true if a resize is pending, otherwise false.
+	RequestedSize() (uint64, bool)
+
+	// Releasing reports whether the volume is to be released when it
+	// is removed, rather than destroyed. A released volume is left
+	// intact in the cloud, so that it may later be imported.
+	Releasing() bool
+
+	// SnapshotRequested reports whether a snapshot of the volume has
+	// been requested, and is to be taken by the storage provisioner
+	// of the machine that manages the volume.
+	SnapshotRequested() bool
+
+	// Snapshots returns the snapshots of a machine-scoped volume that
+	// have been taken by the storage provisioner of its machine.
+	// Snapshots of model-scoped volumes are known only to the storage
+	// provider, and are not recorded in state.
+	Snapshots() []VolumeSnapshot
 }
 
 // VolumeAttachment describes an attachment of a volume to a machine.
@@ -91,6 +112,13 @@ type volumeDoc struct {
 	Binding         string        `bson:"binding,omitempty"`
 	Info            *VolumeInfo   `bson:"info,omitempty"`
 	Params          *VolumeParams `bson:"params,omitempty"`
+	RequestedSize   uint64        `bson:"requestedsize,omitempty"`
+	Releasing       bool          `bson:"releasing,omitempty"`
+
+	// SnapshotRequested and Snapshots are only
+	// used for machine-scoped volumes.
+	SnapshotRequested bool             `bson:"snapshotrequested,omitempty"`
+	Snapshots         []VolumeSnapshot `bson:"snapshots,omitempty"`
 }
 
 // volumeAttachmentDoc records information about a volume attachment.
@@ -117,6 +145,10 @@ type VolumeParams struct {
 
 	Pool string `bson:"pool"`
 	Size uint64 `bson:"size"`
+
+	// SnapshotId, if non-empty, is the provider ID of the snapshot
+	// from which the volume is to be created.
+	SnapshotId string `bson:"snapshotid,omitempty"`
 }
 
 // VolumeInfo describes information about a volume.
@@ -236,6 +268,26 @@ func (v *volume) Params() (VolumeParams, bool) {
 	return *v.doc.Params, true
 }
 
+// RequestedSize is required to implement Volume.
+func (v *volume) RequestedSize() (uint64, bool) {
+	return v.doc.RequestedSize, v.doc.RequestedSize > 0
+}
+
+// Releasing is required to implement Volume.
+func (v *volume) Releasing() bool {
+	return v.doc.Releasing
+}
+
+// SnapshotRequested is required to implement Volume.
+func (v *volume) SnapshotRequested() bool {
+	return v.doc.SnapshotRequested
+}
+
+// Snapshots is required to implement Volume.
+func (v *volume) Snapshots() []VolumeSnapshot {
+	return v.doc.Snapshots
+}
+
 // Status is required to implement StatusGetter.
 func (v *volume) Status() (status.StatusInfo, error) {
 	return v.st.VolumeStatus(v.VolumeTag())
@@ -979,6 +1031,15 @@ func (st *State) SetVolumeInfo(tag names.VolumeTag, info VolumeInfo) (err error)
 			}
 		}
 		ops = append(ops, setVolumeInfoOps(tag, info, unsetParams)...)
+		if requestedSize, ok := v.RequestedSize(); ok && info.Size >= requestedSize {
+			// The volume has been resized, so the filesystem on
+			// it, if any, must now be grown to fill it.
+			resizeOps, err := st.volumeResizedOps(tag, requestedSize, info.Size)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, resizeOps...)
+		}
 		return ops, nil
 	}
 	return st.run(buildTxn)
@@ -1375,6 +1375,12 @@ func (st *State) WatchForUnitAssignment() StringsWatcher {
 	return newcollectionWatcher(st, colWCfg{col: assignUnitC})
 }
 
+// WatchSubnets returns a StringsWatcher that notifies of changes to
+// the subnets in the model. Reported changes are subnet CIDRs.
+func (st *State) WatchSubnets() StringsWatcher {
+	return newcollectionWatcher(st, colWCfg{col: subnetsC})
+}
+
 // WatchAPIHostPorts returns a NotifyWatcher that notifies
 // when the set of API addresses changes.
 func (st *State) WatchAPIHostPorts() NotifyWatcher {
@@ -2419,6 +2425,42 @@ func (m *Machine) WatchForRebootEvent() NotifyWatcher {
 	return newNotifyCollWatcher(m.st, rebootC, filter)
 }
 
+// WatchOpenedPorts returns a NotifyWatcher that notifies of changes
+// to the ports opened on the machine, in any subnet.
+func (m *Machine) WatchOpenedPorts() NotifyWatcher {
+	prefix := portsGlobalKey(m.doc.Id, "")
+	filter := func(key interface{}) bool {
+		if id, ok := key.(string); ok {
+			if id, err := m.st.strictLocalID(id); err == nil {
+				return strings.HasPrefix(id, prefix)
+			}
+		}
+		return false
+	}
+	return newNotifyCollWatcher(m.st, openedPortsC, filter)
+}
+
+// WatchEgressRules returns a NotifyWatcher that notifies of changes
+// that may affect the egress rules of the machine: to the units
+// assigned to it, to applications, and to relation scopes.
+func (m *Machine) WatchEgressRules() NotifyWatcher {
+	machineDocID := m.doc.DocID
+	return newNotifyCollsWatcher(m.st, map[string]func(interface{}) bool{
+		machinesC: func(key interface{}) bool {
+			return key == machineDocID
+		},
+		applicationsC:   isLocalID(m.st),
+		relationScopesC: isLocalID(m.st),
+	})
+}
+
+// WatchModelMachineAddresses returns a NotifyWatcher that notifies of
+// changes to the machines in the model, including containers, and so
+// of any changes to their addresses.
+func (st *State) WatchModelMachineAddresses() NotifyWatcher {
+	return newNotifyCollWatcher(st, machinesC, isLocalID(st))
+}
+
 // blockDevicesWatcher notifies about changes to all block devices
 // associated with a machine.
 type blockDevicesWatcher struct {
@@ -2631,6 +2673,60 @@ func (w *notifyCollWatcher) loop() error {
 	}
 }
 
+// notifyCollsWatcher implements NotifyWatcher, triggering when a
+// change is seen in any of a number of collections matching the
+// filter function provided for that collection.
+type notifyCollsWatcher struct {
+	commonWatcher
+	filters map[string]func(interface{}) bool
+	sink    chan struct{}
+}
+
+func newNotifyCollsWatcher(st *State, filters map[string]func(interface{}) bool) NotifyWatcher {
+	w := &notifyCollsWatcher{
+		commonWatcher: newCommonWatcher(st),
+		filters:       filters,
+		sink:          make(chan struct{}),
+	}
+	go func() {
+		defer w.tomb.Done()
+		defer close(w.sink)
+		w.tomb.Kill(w.loop())
+	}()
+	return w
+}
+
+// Changes returns the event channel for this watcher.
+func (w *notifyCollsWatcher) Changes() <-chan struct{} {
+	return w.sink
+}
+
+func (w *notifyCollsWatcher) loop() error {
+	in := make(chan watcher.Change)
+
+	for collName, filter := range w.filters {
+		w.watcher.WatchCollectionWithFilter(collName, in, filter)
+		defer w.watcher.UnwatchCollection(collName, in)
+	}
+
+	out := w.sink // out set so that initial event is sent.
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-w.watcher.Dead():
+			return stateWatcherDeadError(w.watcher.Err())
+		case change := <-in:
+			if _, ok := collect(change, in, w.tomb.Dying()); !ok {
+				return tomb.ErrDying
+			}
+			out = w.sink
+		case out <- struct{}{}:
+			out = nil
+		}
+	}
+}
+
 // OfferedApplicationWatcher notifies about values in the collection
 // of offered applications. The first event returned by the watcher
 // is a slice of all current offer urls.
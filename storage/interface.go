@@ -127,6 +127,60 @@ type VolumeSource interface {
 	DetachVolumes(params []VolumeAttachmentParams) ([]error, error)
 }
 
+// VolumeImporter provides an interface for importing volumes created
+// outside of Juju into the model. A VolumeSource may optionally
+// implement VolumeImporter, if the provider supports it.
+type VolumeImporter interface {
+	// ImportVolume updates the volume with the specified provider
+	// volume ID with the given resource tags, so that it is seen as
+	// being managed by the model, and returns the volume's properties.
+	// ImportVolume must return an error if the volume is in use.
+	ImportVolume(volumeId string, resourceTags map[string]string) (VolumeInfo, error)
+}
+
+// VolumeSnapshotter provides an interface for taking point-in-time
+// snapshots of volumes, and for managing those snapshots. A VolumeSource
+// may optionally implement VolumeSnapshotter, if the provider supports
+// it. A VolumeSource that implements VolumeSnapshotter must support the
+// creation of volumes from snapshots, as specified by the SnapshotId
+// field of VolumeParams.
+type VolumeSnapshotter interface {
+	// CreateSnapshots creates snapshots of the volumes with the
+	// specified parameters.
+	CreateSnapshots(params []SnapshotParams) ([]CreateSnapshotsResult, error)
+
+	// ListSnapshots lists the snapshots of each of the volumes with
+	// the specified provider volume IDs.
+	ListSnapshots(volIds []string) ([]ListSnapshotsResult, error)
+
+	// DeleteSnapshots deletes the snapshots with the specified
+	// provider snapshot IDs.
+	DeleteSnapshots(snapshotIds []string) ([]error, error)
+}
+
+// VolumeResizer provides an interface for growing provisioned volumes.
+// A VolumeSource may optionally implement VolumeResizer, if the provider
+// supports it.
+type VolumeResizer interface {
+	// ResizeVolumes grows the volumes with the specified parameters
+	// to at least the requested size, in MiB. Volumes may be resized
+	// while they are attached to machines; it is the responsibility
+	// of the caller to extend any filesystem on the volume.
+	ResizeVolumes(params []VolumeResizeParams) ([]ResizeVolumesResult, error)
+}
+
+// VolumeReleaser provides an interface for releasing volumes from the
+// model, rather than destroying them. A VolumeSource may optionally
+// implement VolumeReleaser, if the provider supports it.
+type VolumeReleaser interface {
+	// ReleaseVolumes dissociates the volumes with the specified provider
+	// volume IDs from the model and controller, leaving them intact so
+	// that they may later be imported with VolumeImporter. Released
+	// volumes must no longer be reported by ListVolumes, nor destroyed
+	// when the model or controller is destroyed.
+	ReleaseVolumes(volumeIds []string) ([]error, error)
+}
+
 // FilesystemSource provides an interface for creating, destroying and
 // describing filesystems in the environment. A FilesystemSource is
 // configured in a particular way, and corresponds to a storage "pool".
@@ -160,6 +214,16 @@ type FilesystemSource interface {
 	DetachFilesystems(params []FilesystemAttachmentParams) ([]error, error)
 }
 
+// FilesystemResizer provides an interface for growing provisioned
+// filesystems. A FilesystemSource may optionally implement
+// FilesystemResizer, if the provider supports it.
+type FilesystemResizer interface {
+	// ResizeFilesystems grows the filesystems with the specified
+	// parameters to at least the requested size, in MiB. Filesystems
+	// are resized while they are mounted.
+	ResizeFilesystems(params []FilesystemResizeParams) ([]ResizeFilesystemsResult, error)
+}
+
 // VolumeParams is a fully specified set of parameters for volume creation,
 // derived from one or more of user-specified storage constraints, a
 // storage pool definition, and charm storage metadata.
@@ -183,6 +247,12 @@ type VolumeParams struct {
 	// storage provider supports tags.
 	ResourceTags map[string]string
 
+	// SnapshotId is the provider-supplied ID of the snapshot from which
+	// the volume is to be created, or empty if the volume is to be
+	// created empty. SnapshotId may only be specified if the volume
+	// source implements VolumeSnapshotter.
+	SnapshotId string
+
 	// Attachment identifies the machine that the volume should be attached
 	// to initially, or nil if the volume should not be attached to any
 	// machine. Some providers, such as MAAS, do not support dynamic
@@ -233,6 +303,36 @@ type AttachmentParams struct {
 	ReadOnly bool
 }
 
+// SnapshotParams is a set of parameters for creating a snapshot of a volume.
+type SnapshotParams struct {
+	// Volume is the unique tag assigned by Juju for the volume that
+	// is to be snapshotted.
+	Volume names.VolumeTag
+
+	// VolumeId is the unique provider-supplied ID for the volume that
+	// is to be snapshotted.
+	VolumeId string
+
+	// ResourceTags is a set of tags to set on the created snapshot, if
+	// the storage provider supports tags.
+	ResourceTags map[string]string
+}
+
+// VolumeResizeParams is a set of parameters for growing a volume.
+type VolumeResizeParams struct {
+	// Tag is the unique tag assigned by Juju for the volume that
+	// is to be resized.
+	Tag names.VolumeTag
+
+	// VolumeId is the unique provider-supplied ID for the volume that
+	// is to be resized.
+	VolumeId string
+
+	// Size is the minimum size, in MiB, that the volume is to be
+	// grown to.
+	Size uint64
+}
+
 // FilesystemParams is a fully specified set of parameters for filesystem creation,
 // derived from one or more of user-specified storage constraints, a
 // storage pool definition, and charm storage metadata.
@@ -276,6 +376,28 @@ type FilesystemAttachmentParams struct {
 	Path string
 }
 
+// FilesystemResizeParams is a set of parameters for growing a filesystem.
+type FilesystemResizeParams struct {
+	// Tag is the unique tag assigned by Juju for the filesystem that
+	// is to be resized.
+	Tag names.FilesystemTag
+
+	// Volume is the tag of the volume that backs the filesystem, if any.
+	Volume names.VolumeTag
+
+	// FilesystemId is the unique provider-supplied ID for the filesystem
+	// that is to be resized.
+	FilesystemId string
+
+	// Size is the minimum size, in MiB, that the filesystem is to be
+	// grown to.
+	Size uint64
+
+	// Path is the path at which the filesystem is mounted on the
+	// machine, or empty if the filesystem is not mounted.
+	Path string
+}
+
 // CreateVolumesResult contains the result of a VolumeSource.CreateVolumes call
 // for one volume. Volume and VolumeAttachment should only be used if Error is
 // nil.
@@ -319,3 +441,34 @@ type AttachFilesystemsResult struct {
 	FilesystemAttachment *FilesystemAttachment
 	Error                error
 }
+
+// CreateSnapshotsResult contains the result of a
+// VolumeSnapshotter.CreateSnapshots call for one volume. Snapshot
+// should only be used if Error is nil.
+type CreateSnapshotsResult struct {
+	Snapshot *Snapshot
+	Error    error
+}
+
+// ListSnapshotsResult contains the result of a
+// VolumeSnapshotter.ListSnapshots call for one volume. Snapshots
+// should only be used if Error is nil.
+type ListSnapshotsResult struct {
+	Snapshots []Snapshot
+	Error     error
+}
+
+// ResizeVolumesResult contains the result of a VolumeResizer.ResizeVolumes
+// call for one volume. Volume should only be used if Error is nil.
+type ResizeVolumesResult struct {
+	Volume *Volume
+	Error  error
+}
+
+// ResizeFilesystemsResult contains the result of a
+// FilesystemResizer.ResizeFilesystems call for one filesystem.
+// Filesystem should only be used if Error is nil.
+type ResizeFilesystemsResult struct {
+	Filesystem *Filesystem
+	Error      error
+}
@@ -13,9 +13,10 @@ var (
 	errNoMountPoint = errors.New("filesystem mount point not specified")
 
 	commonStorageProviders = map[storage.ProviderType]storage.Provider{
-		LoopProviderType:   &loopProvider{logAndExec},
-		RootfsProviderType: &rootfsProvider{logAndExec},
-		TmpfsProviderType:  &tmpfsProvider{logAndExec},
+		LoopProviderType:    &loopProvider{logAndExec},
+		LXDBindProviderType: &lxdBindProvider{logAndExec},
+		RootfsProviderType:  &rootfsProvider{logAndExec},
+		TmpfsProviderType:   &tmpfsProvider{logAndExec},
 	}
 )
 
@@ -23,6 +23,12 @@ type VolumeSource struct {
 	ValidateVolumeParamsFunc func(storage.VolumeParams) error
 	AttachVolumesFunc        func([]storage.VolumeAttachmentParams) ([]storage.AttachVolumesResult, error)
 	DetachVolumesFunc        func([]storage.VolumeAttachmentParams) ([]error, error)
+	ImportVolumeFunc         func(string, map[string]string) (storage.VolumeInfo, error)
+	CreateSnapshotsFunc      func([]storage.SnapshotParams) ([]storage.CreateSnapshotsResult, error)
+	ListSnapshotsFunc        func([]string) ([]storage.ListSnapshotsResult, error)
+	DeleteSnapshotsFunc      func([]string) ([]error, error)
+	ResizeVolumesFunc        func([]storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error)
+	ReleaseVolumesFunc       func([]string) ([]error, error)
 }
 
 // CreateVolumes is defined on storage.VolumeSource.
@@ -87,3 +93,57 @@ func (s *VolumeSource) DetachVolumes(params []storage.VolumeAttachmentParams) ([
 	}
 	return nil, errors.NotImplementedf("DetachVolumes")
 }
+
+// ImportVolume is defined on storage.VolumeImporter.
+func (s *VolumeSource) ImportVolume(volumeId string, resourceTags map[string]string) (storage.VolumeInfo, error) {
+	s.MethodCall(s, "ImportVolume", volumeId, resourceTags)
+	if s.ImportVolumeFunc != nil {
+		return s.ImportVolumeFunc(volumeId, resourceTags)
+	}
+	return storage.VolumeInfo{}, errors.NotImplementedf("ImportVolume")
+}
+
+// CreateSnapshots is defined on storage.VolumeSnapshotter.
+func (s *VolumeSource) CreateSnapshots(params []storage.SnapshotParams) ([]storage.CreateSnapshotsResult, error) {
+	s.MethodCall(s, "CreateSnapshots", params)
+	if s.CreateSnapshotsFunc != nil {
+		return s.CreateSnapshotsFunc(params)
+	}
+	return nil, errors.NotImplementedf("CreateSnapshots")
+}
+
+// ListSnapshots is defined on storage.VolumeSnapshotter.
+func (s *VolumeSource) ListSnapshots(volIds []string) ([]storage.ListSnapshotsResult, error) {
+	s.MethodCall(s, "ListSnapshots", volIds)
+	if s.ListSnapshotsFunc != nil {
+		return s.ListSnapshotsFunc(volIds)
+	}
+	return nil, errors.NotImplementedf("ListSnapshots")
+}
+
+// DeleteSnapshots is defined on storage.VolumeSnapshotter.
+func (s *VolumeSource) DeleteSnapshots(snapshotIds []string) ([]error, error) {
+	s.MethodCall(s, "DeleteSnapshots", snapshotIds)
+	if s.DeleteSnapshotsFunc != nil {
+		return s.DeleteSnapshotsFunc(snapshotIds)
+	}
+	return nil, errors.NotImplementedf("DeleteSnapshots")
+}
+
+// ResizeVolumes is defined on storage.VolumeResizer.
+func (s *VolumeSource) ResizeVolumes(params []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	s.MethodCall(s, "ResizeVolumes", params)
+	if s.ResizeVolumesFunc != nil {
+		return s.ResizeVolumesFunc(params)
+	}
+	return nil, errors.NotImplementedf("ResizeVolumes")
+}
+
+// ReleaseVolumes is defined on storage.VolumeReleaser.
+func (s *VolumeSource) ReleaseVolumes(volIds []string) ([]error, error) {
+	s.MethodCall(s, "ReleaseVolumes", volIds)
+	if s.ReleaseVolumesFunc != nil {
+		return s.ReleaseVolumesFunc(volIds)
+	}
+	return nil, errors.NotImplementedf("ReleaseVolumes")
+}
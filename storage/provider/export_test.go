@@ -5,6 +5,7 @@ package provider
 
 import (
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -114,6 +115,20 @@ func RootfsProvider(run func(string, ...string) (string, error)) storage.Provide
 	return &rootfsProvider{run}
 }
 
+func LXDBindFilesystemSource(storageDir string, run func(string, ...string) (string, error)) (storage.FilesystemSource, *MockDirFuncs) {
+	d := &MockDirFuncs{
+		osDirFuncs{run},
+		set.NewStrings(),
+	}
+	return &lxdBindFilesystemSource{
+		rootfsFilesystemSource{d, run, filepath.Join(storageDir, lxdBindDir)},
+	}, d
+}
+
+func LXDBindProvider(run func(string, ...string) (string, error)) storage.Provider {
+	return &lxdBindProvider{run}
+}
+
 func TmpfsFilesystemSource(storageDir string, run func(string, ...string) (string, error)) storage.FilesystemSource {
 	return &tmpfsFilesystemSource{
 		&MockDirFuncs{
@@ -5,10 +5,14 @@ package provider
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
@@ -98,6 +102,8 @@ type loopVolumeSource struct {
 }
 
 var _ storage.VolumeSource = (*loopVolumeSource)(nil)
+var _ storage.VolumeSnapshotter = (*loopVolumeSource)(nil)
+var _ storage.VolumeResizer = (*loopVolumeSource)(nil)
 
 // CreateVolumes is defined on the VolumeSource interface.
 func (lvs *loopVolumeSource) CreateVolumes(args []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
@@ -118,18 +124,50 @@ func (lvs *loopVolumeSource) createVolume(params storage.VolumeParams) (storage.
 	if err := ensureDir(lvs.dirFuncs, filepath.Dir(loopFilePath)); err != nil {
 		return storage.Volume{}, errors.Trace(err)
 	}
-	if err := createBlockFile(lvs.run, loopFilePath, params.Size); err != nil {
+	size := params.Size
+	if params.SnapshotId != "" {
+		snapshotSize, err := lvs.copySnapshot(params.SnapshotId, loopFilePath)
+		if err != nil {
+			return storage.Volume{}, errors.Annotatef(err, "could not restore snapshot %q", params.SnapshotId)
+		}
+		if snapshotSize > size {
+			size = snapshotSize
+		}
+	}
+	// If the volume was restored from a snapshot, fallocate will
+	// extend the copied file to the requested size if necessary.
+	if err := createBlockFile(lvs.run, loopFilePath, size); err != nil {
 		return storage.Volume{}, errors.Annotate(err, "could not create block file")
 	}
 	return storage.Volume{
 		params.Tag,
 		storage.VolumeInfo{
 			VolumeId: volumeId,
-			Size:     params.Size,
+			Size:     size,
 		},
 	}, nil
 }
 
+// copySnapshot copies the backing file of the snapshot with the
+// specified ID to the given path, returning the size of the snapshot
+// in MiB.
+func (lvs *loopVolumeSource) copySnapshot(snapshotId, filePath string) (uint64, error) {
+	snapshotFilePath, err := lvs.snapshotFilePath(snapshotId)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	fi, err := os.Stat(snapshotFilePath)
+	if os.IsNotExist(err) {
+		return 0, errors.NotFoundf("snapshot %q", snapshotId)
+	} else if err != nil {
+		return 0, errors.Trace(err)
+	}
+	if err := copySparseFile(lvs.run, snapshotFilePath, filePath); err != nil {
+		return 0, errors.Trace(err)
+	}
+	return sizeInMiB(fi.Size()), nil
+}
+
 func (lvs *loopVolumeSource) volumeFilePath(tag names.VolumeTag) string {
 	return filepath.Join(lvs.storageDir, tag.String())
 }
@@ -237,6 +275,231 @@ func (lvs *loopVolumeSource) detachVolume(tag names.VolumeTag) error {
 	return nil
 }
 
+// ResizeVolumes is defined on the VolumeResizer interface.
+func (lvs *loopVolumeSource) ResizeVolumes(args []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	results := make([]storage.ResizeVolumesResult, len(args))
+	for i, arg := range args {
+		volume, err := lvs.resizeVolume(arg)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "resizing volume %s", arg.Tag.Id())
+			continue
+		}
+		results[i].Volume = volume
+	}
+	return results, nil
+}
+
+func (lvs *loopVolumeSource) resizeVolume(arg storage.VolumeResizeParams) (*storage.Volume, error) {
+	loopFilePath := lvs.volumeFilePath(arg.Tag)
+	fi, err := os.Stat(loopFilePath)
+	if err != nil {
+		return nil, errors.Annotate(err, "reading loop backing file")
+	}
+	size := sizeInMiB(fi.Size())
+	if arg.Size > size {
+		// fallocate extends the file, preserving its contents.
+		if err := createBlockFile(lvs.run, loopFilePath, arg.Size); err != nil {
+			return nil, errors.Trace(err)
+		}
+		size = arg.Size
+	}
+	// Any loop devices attached to the file must be told to
+	// reread its size, so the new capacity is visible.
+	deviceNames, err := associatedLoopDevices(lvs.run, loopFilePath)
+	if err != nil {
+		return nil, errors.Annotate(err, "locating loop device")
+	}
+	for _, deviceName := range deviceNames {
+		if err := refreshLoopDeviceCapacity(lvs.run, deviceName); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return &storage.Volume{
+		arg.Tag,
+		storage.VolumeInfo{
+			VolumeId: arg.VolumeId,
+			Size:     size,
+		},
+	}, nil
+}
+
+// CreateSnapshots is defined on the VolumeSnapshotter interface.
+func (lvs *loopVolumeSource) CreateSnapshots(args []storage.SnapshotParams) ([]storage.CreateSnapshotsResult, error) {
+	results := make([]storage.CreateSnapshotsResult, len(args))
+	for i, arg := range args {
+		snapshot, err := lvs.createSnapshot(arg)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "creating snapshot of volume %s", arg.Volume.Id())
+			continue
+		}
+		results[i].Snapshot = snapshot
+	}
+	return results, nil
+}
+
+func (lvs *loopVolumeSource) createSnapshot(arg storage.SnapshotParams) (*storage.Snapshot, error) {
+	loopFilePath := lvs.volumeFilePath(arg.Volume)
+	fi, err := os.Stat(loopFilePath)
+	if err != nil {
+		return nil, errors.Annotate(err, "reading loop backing file")
+	}
+	existing, err := lvs.listSnapshots(arg.VolumeId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// Snapshots are numbered sequentially per volume.
+	var index int
+	for _, snapshot := range existing {
+		_, n, err := parseLoopSnapshotId(snapshot.SnapshotId)
+		if err == nil && n > index {
+			index = n
+		}
+	}
+	snapshotId := fmt.Sprintf("%s@%d", arg.VolumeId, index+1)
+	snapshotFilePath, err := lvs.snapshotFilePath(snapshotId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := ensureDir(lvs.dirFuncs, filepath.Dir(snapshotFilePath)); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := copySparseFile(lvs.run, loopFilePath, snapshotFilePath); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &storage.Snapshot{
+		SnapshotId: snapshotId,
+		VolumeId:   arg.VolumeId,
+		Size:       sizeInMiB(fi.Size()),
+		Created:    time.Now().UTC(),
+	}, nil
+}
+
+// ListSnapshots is defined on the VolumeSnapshotter interface.
+func (lvs *loopVolumeSource) ListSnapshots(volumeIds []string) ([]storage.ListSnapshotsResult, error) {
+	results := make([]storage.ListSnapshotsResult, len(volumeIds))
+	for i, volumeId := range volumeIds {
+		snapshots, err := lvs.listSnapshots(volumeId)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "listing snapshots of %q", volumeId)
+			continue
+		}
+		results[i].Snapshots = snapshots
+	}
+	return results, nil
+}
+
+func (lvs *loopVolumeSource) listSnapshots(volumeId string) ([]storage.Snapshot, error) {
+	if _, err := names.ParseVolumeTag(volumeId); err != nil {
+		return nil, errors.Errorf("invalid loop volume ID %q", volumeId)
+	}
+	infos, err := ioutil.ReadDir(lvs.snapshotsDir())
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Annotate(err, "reading snapshots directory")
+	}
+	var snapshots []storage.Snapshot
+	for _, info := range infos {
+		snapshotVolumeId, _, err := parseLoopSnapshotId(info.Name())
+		if err != nil || snapshotVolumeId != volumeId {
+			continue
+		}
+		snapshots = append(snapshots, storage.Snapshot{
+			SnapshotId: info.Name(),
+			VolumeId:   volumeId,
+			Size:       sizeInMiB(info.Size()),
+			Created:    info.ModTime().UTC(),
+		})
+	}
+	sort.Sort(loopSnapshotsByIndex(snapshots))
+	return snapshots, nil
+}
+
+// DeleteSnapshots is defined on the VolumeSnapshotter interface.
+func (lvs *loopVolumeSource) DeleteSnapshots(snapshotIds []string) ([]error, error) {
+	results := make([]error, len(snapshotIds))
+	for i, snapshotId := range snapshotIds {
+		if err := lvs.deleteSnapshot(snapshotId); err != nil {
+			results[i] = errors.Annotatef(err, "deleting snapshot %q", snapshotId)
+		}
+	}
+	return results, nil
+}
+
+func (lvs *loopVolumeSource) deleteSnapshot(snapshotId string) error {
+	snapshotFilePath, err := lvs.snapshotFilePath(snapshotId)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = os.Remove(snapshotFilePath)
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Annotate(err, "removing snapshot file")
+	}
+	return nil
+}
+
+func (lvs *loopVolumeSource) snapshotsDir() string {
+	return filepath.Join(lvs.storageDir, "snapshots")
+}
+
+func (lvs *loopVolumeSource) snapshotFilePath(snapshotId string) (string, error) {
+	if _, _, err := parseLoopSnapshotId(snapshotId); err != nil {
+		return "", errors.Trace(err)
+	}
+	return filepath.Join(lvs.snapshotsDir(), snapshotId), nil
+}
+
+// parseLoopSnapshotId parses a loop snapshot ID, which has the format
+// <volume-id>@<n>, returning the volume ID and the snapshot number.
+func parseLoopSnapshotId(snapshotId string) (string, int, error) {
+	pos := strings.LastIndex(snapshotId, "@")
+	if pos == -1 {
+		return "", 0, errors.Errorf("invalid loop snapshot ID %q", snapshotId)
+	}
+	volumeId := snapshotId[:pos]
+	if _, err := names.ParseVolumeTag(volumeId); err != nil {
+		return "", 0, errors.Errorf("invalid loop snapshot ID %q", snapshotId)
+	}
+	n, err := strconv.Atoi(snapshotId[pos+1:])
+	if err != nil || n <= 0 {
+		return "", 0, errors.Errorf("invalid loop snapshot ID %q", snapshotId)
+	}
+	return volumeId, n, nil
+}
+
+type loopSnapshotsByIndex []storage.Snapshot
+
+func (s loopSnapshotsByIndex) Len() int {
+	return len(s)
+}
+
+func (s loopSnapshotsByIndex) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+
+func (s loopSnapshotsByIndex) Less(i, j int) bool {
+	_, ni, _ := parseLoopSnapshotId(s[i].SnapshotId)
+	_, nj, _ := parseLoopSnapshotId(s[j].SnapshotId)
+	return ni < nj
+}
+
+// copySparseFile copies the file at the source path to the destination
+// path, preserving holes in the file so that no more space is used than
+// necessary.
+func copySparseFile(run runCommandFunc, source, dest string) error {
+	if _, err := run("cp", "--sparse=always", source, dest); err != nil {
+		return errors.Annotatef(err, "copying %q to %q", source, dest)
+	}
+	return nil
+}
+
+// sizeInMiB returns the specified size in bytes as mebibytes,
+// rounding up.
+func sizeInMiB(size int64) uint64 {
+	const mib = 1024 * 1024
+	return uint64((size + mib - 1) / mib)
+}
+
 // createBlockFile creates a file at the specified path, with the
 // given size in mebibytes.
 func createBlockFile(run runCommandFunc, filePath string, sizeInMiB uint64) error {
@@ -287,6 +550,16 @@ func detachLoopDevice(run runCommandFunc, deviceName string) error {
 	return err
 }
 
+// refreshLoopDeviceCapacity causes the loop device with the specified
+// name to reread the size of its backing file.
+func refreshLoopDeviceCapacity(run runCommandFunc, deviceName string) error {
+	_, err := run("losetup", "-c", path.Join("/dev", deviceName))
+	if err != nil {
+		return errors.Annotatef(err, "refreshing capacity of loop device %q", deviceName)
+	}
+	return nil
+}
+
 // associatedLoopDevices returns the device names of the loop devices
 // associated with the specified file path.
 func associatedLoopDevices(run runCommandFunc, filePath string) ([]string, error) {
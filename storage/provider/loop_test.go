@@ -272,3 +272,165 @@ func (s *loopSuite) TestDetachVolumesDetachFails(c *gc.C) {
 	_, err = os.Stat(fileName)
 	c.Assert(err, jc.ErrorIsNil)
 }
+
+func (s *loopSuite) TestCreateSnapshots(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	snapshotsDir := filepath.Join(s.storageDir, "snapshots")
+	err := os.MkdirAll(snapshotsDir, 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(filepath.Join(s.storageDir, "volume-0"), make([]byte, 1024*1024), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(filepath.Join(snapshotsDir, "volume-0@1"), nil, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	s.commands.expect(
+		"cp", "--sparse=always",
+		filepath.Join(s.storageDir, "volume-0"),
+		filepath.Join(snapshotsDir, "volume-0@2"),
+	)
+
+	snapshotter := source.(storage.VolumeSnapshotter)
+	results, err := snapshotter.CreateSnapshots([]storage.SnapshotParams{{
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: "volume-0",
+	}, {
+		Volume:   names.NewVolumeTag("1"),
+		VolumeId: "volume-1",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Snapshot.SnapshotId, gc.Equals, "volume-0@2")
+	c.Assert(results[0].Snapshot.VolumeId, gc.Equals, "volume-0")
+	c.Assert(results[0].Snapshot.Size, gc.Equals, uint64(1))
+	c.Assert(results[1].Error, gc.ErrorMatches, "creating snapshot of volume 1: reading loop backing file: .*")
+}
+
+func (s *loopSuite) TestListSnapshots(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	snapshotsDir := filepath.Join(s.storageDir, "snapshots")
+	err := os.MkdirAll(snapshotsDir, 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	for _, name := range []string{"volume-0@10", "volume-0@2", "volume-1@1", "junk"} {
+		err := ioutil.WriteFile(filepath.Join(snapshotsDir, name), nil, 0644)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	snapshotter := source.(storage.VolumeSnapshotter)
+	results, err := snapshotter.ListSnapshots([]string{"volume-0", "volume-2", "../foo"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 3)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Snapshots, gc.HasLen, 2)
+	c.Assert(results[0].Snapshots[0].SnapshotId, gc.Equals, "volume-0@2")
+	c.Assert(results[0].Snapshots[1].SnapshotId, gc.Equals, "volume-0@10")
+	c.Assert(results[1].Error, jc.ErrorIsNil)
+	c.Assert(results[1].Snapshots, gc.HasLen, 0)
+	c.Assert(results[2].Error, gc.ErrorMatches, `.* invalid loop volume ID "\.\./foo"`)
+}
+
+func (s *loopSuite) TestDeleteSnapshots(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	snapshotsDir := filepath.Join(s.storageDir, "snapshots")
+	err := os.MkdirAll(snapshotsDir, 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	fileName := filepath.Join(snapshotsDir, "volume-0@1")
+	err = ioutil.WriteFile(fileName, nil, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	snapshotter := source.(storage.VolumeSnapshotter)
+	errs, err := snapshotter.DeleteSnapshots([]string{"volume-0@1", "../../volume-0@1"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, gc.HasLen, 2)
+	c.Assert(errs[0], jc.ErrorIsNil)
+	c.Assert(errs[1], gc.ErrorMatches, `.* invalid loop snapshot ID "\.\./\.\./volume-0@1"`)
+
+	_, err = os.Stat(fileName)
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+}
+
+func (s *loopSuite) TestCreateVolumesFromSnapshot(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	snapshotsDir := filepath.Join(s.storageDir, "snapshots")
+	err := os.MkdirAll(snapshotsDir, 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(filepath.Join(snapshotsDir, "volume-0@1"), make([]byte, 3*1024*1024), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	s.commands.expect(
+		"cp", "--sparse=always",
+		filepath.Join(snapshotsDir, "volume-0@1"),
+		filepath.Join(s.storageDir, "volume-1"),
+	)
+	s.commands.expect("fallocate", "-l", "3MiB", filepath.Join(s.storageDir, "volume-1"))
+
+	results, err := source.CreateVolumes([]storage.VolumeParams{{
+		Tag:        names.NewVolumeTag("1"),
+		Size:       2,
+		SnapshotId: "volume-0@1",
+	}, {
+		Tag:        names.NewVolumeTag("2"),
+		Size:       2,
+		SnapshotId: "volume-0@2",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Volume, jc.DeepEquals, &storage.Volume{
+		names.NewVolumeTag("1"),
+		storage.VolumeInfo{
+			VolumeId: "volume-1",
+			Size:     3,
+		},
+	})
+	c.Assert(results[1].Error, gc.ErrorMatches, `creating volume: could not restore snapshot "volume-0@2": snapshot "volume-0@2" not found`)
+}
+
+func (s *loopSuite) TestResizeVolumes(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	fileName := filepath.Join(s.storageDir, "volume-0")
+	err := ioutil.WriteFile(fileName, make([]byte, 2*1024*1024), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	s.commands.expect("fallocate", "-l", "4MiB", fileName)
+	cmd := s.commands.expect("losetup", "-j", fileName)
+	cmd.respond("/dev/loop0: foo\n", nil)
+	s.commands.expect("losetup", "-c", "/dev/loop0")
+
+	resizer := source.(storage.VolumeResizer)
+	results, err := resizer.ResizeVolumes([]storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		VolumeId: "volume-0",
+		Size:     4,
+	}, {
+		Tag:      names.NewVolumeTag("1"),
+		VolumeId: "volume-1",
+		Size:     4,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Volume, jc.DeepEquals, &storage.Volume{
+		names.NewVolumeTag("0"),
+		storage.VolumeInfo{
+			VolumeId: "volume-0",
+			Size:     4,
+		},
+	})
+	c.Assert(results[1].Error, gc.ErrorMatches, `resizing volume 1: reading loop backing file: .*`)
+}
+
+func (s *loopSuite) TestResizeVolumesAlreadyLargeEnough(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	fileName := filepath.Join(s.storageDir, "volume-0")
+	err := ioutil.WriteFile(fileName, make([]byte, 4*1024*1024), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	s.commands.expect("losetup", "-j", fileName)
+
+	resizer := source.(storage.VolumeResizer)
+	results, err := resizer.ResizeVolumes([]storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		VolumeId: "volume-0",
+		Size:     3,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Volume.Size, gc.Equals, uint64(4))
+}
@@ -0,0 +1,237 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"path/filepath"
+
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+	"github.com/juju/utils"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/storage"
+)
+
+const (
+	LXDBindProviderType = storage.ProviderType("lxd-bind")
+
+	// LXDBindHostPath is the pool attribute that specifies the
+	// directory on the host machine under which the directories
+	// bound into containers are created.
+	LXDBindHostPath = "host-path"
+
+	// LXDBindQuota is the pool attribute that specifies the maximum
+	// size of each bound directory, e.g. "10G". Quotas are only
+	// supported when the host path is on ZFS or btrfs.
+	LXDBindQuota = "quota"
+
+	// DefaultLXDBindHostPath is the host path used when the pool
+	// does not specify one.
+	DefaultLXDBindHostPath = "/var/lib/juju/lxd-bind"
+
+	// lxdBindDir is the name of the directory, within the storage
+	// directory of a container, under which the host directories
+	// are bound.
+	lxdBindDir = "lxd-bind"
+)
+
+var lxdBindConfigFields = schema.Fields{
+	LXDBindHostPath: schema.String(),
+	LXDBindQuota:    schema.String(),
+}
+
+var lxdBindConfigChecker = schema.FieldMap(
+	lxdBindConfigFields,
+	schema.Defaults{
+		LXDBindHostPath: DefaultLXDBindHostPath,
+		LXDBindQuota:    schema.Omit,
+	},
+)
+
+// LXDBindConfig holds the parsed configuration of an lxd-bind
+// storage pool.
+type LXDBindConfig struct {
+	// HostPath is the absolute path of the directory on the host
+	// under which the bound directories are created.
+	HostPath string
+
+	// Quota is the maximum size of each bound directory in MiB,
+	// or zero if the size is unlimited.
+	Quota uint64
+}
+
+// ParseLXDBindConfig parses and validates the attributes of an
+// lxd-bind storage pool.
+func ParseLXDBindConfig(attrs map[string]interface{}) (*LXDBindConfig, error) {
+	out, err := lxdBindConfigChecker.Coerce(attrs, nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "validating lxd-bind storage config")
+	}
+	coerced := out.(map[string]interface{})
+	hostPath := coerced[LXDBindHostPath].(string)
+	if !filepath.IsAbs(hostPath) {
+		return nil, errors.NotValidf("%s %q (must be an absolute path)", LXDBindHostPath, hostPath)
+	}
+	cfg := &LXDBindConfig{HostPath: filepath.Clean(hostPath)}
+	if quota, ok := coerced[LXDBindQuota].(string); ok {
+		cfg.Quota, err = utils.ParseSize(quota)
+		if err != nil {
+			return nil, errors.Annotatef(err, "parsing %s", LXDBindQuota)
+		}
+	}
+	return cfg, nil
+}
+
+// LXDBindFilesystemPath returns the path, inside a container whose
+// agent uses the specified storage directory, at which the host
+// directory for the specified filesystem is bound.
+func LXDBindFilesystemPath(storageDir string, tag names.FilesystemTag) string {
+	return filepath.Join(storageDir, lxdBindDir, tag.Id())
+}
+
+// lxdBindProvider creates storage sources which provide access to
+// directories bound into an LXD container by its host.
+//
+// The host directories are created, and added to the container as
+// disk devices, by the LXD broker when the container is created; the
+// filesystem source runs inside the container, and is responsible only
+// for making the bound directories available at the attachment paths.
+type lxdBindProvider struct {
+	// run is a function type used for running commands on the local machine.
+	run runCommandFunc
+}
+
+var (
+	_ storage.Provider = (*lxdBindProvider)(nil)
+)
+
+// ValidateConfig is defined on the Provider interface.
+func (p *lxdBindProvider) ValidateConfig(cfg *storage.Config) error {
+	_, err := ParseLXDBindConfig(cfg.Attrs())
+	return errors.Trace(err)
+}
+
+// validateFullConfig validates a fully-constructed storage config,
+// combining the user-specified config and any internally specified
+// config.
+func (p *lxdBindProvider) validateFullConfig(cfg *storage.Config) error {
+	if err := p.ValidateConfig(cfg); err != nil {
+		return err
+	}
+	storageDir, ok := cfg.ValueString(storage.ConfigStorageDir)
+	if !ok || storageDir == "" {
+		return errors.New("storage directory not specified")
+	}
+	return nil
+}
+
+// VolumeSource is defined on the Provider interface.
+func (p *lxdBindProvider) VolumeSource(providerConfig *storage.Config) (storage.VolumeSource, error) {
+	return nil, errors.NotSupportedf("volumes")
+}
+
+// FilesystemSource is defined on the Provider interface.
+func (p *lxdBindProvider) FilesystemSource(sourceConfig *storage.Config) (storage.FilesystemSource, error) {
+	if err := p.validateFullConfig(sourceConfig); err != nil {
+		return nil, err
+	}
+	// storageDir is validated by validateFullConfig.
+	storageDir, _ := sourceConfig.ValueString(storage.ConfigStorageDir)
+	return &lxdBindFilesystemSource{
+		rootfsFilesystemSource{
+			&osDirFuncs{p.run},
+			p.run,
+			filepath.Join(storageDir, lxdBindDir),
+		},
+	}, nil
+}
+
+// Supports is defined on the Provider interface.
+func (*lxdBindProvider) Supports(k storage.StorageKind) bool {
+	return k == storage.StorageKindFilesystem
+}
+
+// Scope is defined on the Provider interface.
+func (*lxdBindProvider) Scope() storage.Scope {
+	return storage.ScopeMachine
+}
+
+// Dynamic is defined on the Provider interface.
+//
+// The host directories can only be bound into a container when it is
+// created, so lxd-bind storage cannot be added to an existing machine.
+func (*lxdBindProvider) Dynamic() bool {
+	return false
+}
+
+// DefaultPools is defined on the Provider interface.
+func (*lxdBindProvider) DefaultPools() []*storage.Config {
+	return nil
+}
+
+// lxdBindFilesystemSource is a filesystem source for directories bound
+// into the container at <storage-dir>/lxd-bind/<filesystem-id>. Once
+// bound, the directories are attached in the same way as rootfs
+// filesystems.
+type lxdBindFilesystemSource struct {
+	rootfsFilesystemSource
+}
+
+var _ storage.FilesystemSource = (*lxdBindFilesystemSource)(nil)
+
+// CreateFilesystems is defined on the FilesystemSource interface.
+func (s *lxdBindFilesystemSource) CreateFilesystems(args []storage.FilesystemParams) ([]storage.CreateFilesystemsResult, error) {
+	results := make([]storage.CreateFilesystemsResult, len(args))
+	for i, arg := range args {
+		filesystem, err := s.createFilesystem(arg)
+		if err != nil {
+			results[i].Error = err
+			continue
+		}
+		results[i].Filesystem = filesystem
+	}
+	return results, nil
+}
+
+func (s *lxdBindFilesystemSource) createFilesystem(params storage.FilesystemParams) (*storage.Filesystem, error) {
+	path := filepath.Join(s.storageDir, params.Tag.Id())
+	fi, err := s.dirFuncs.lstat(path)
+	if err != nil {
+		// The host binds the directories when the container is
+		// created; filesystems added to the container afterwards
+		// cannot be bound.
+		return nil, errors.Annotatef(err,
+			"filesystem %s was not bound into the container by the host",
+			params.Tag.Id(),
+		)
+	}
+	if !fi.IsDir() {
+		return nil, errors.Errorf("path %q must be a directory", path)
+	}
+	// If the host applied a quota, it is reflected in
+	// the size of the filesystem containing the path.
+	sizeInMiB, err := s.dirFuncs.calculateSize(path)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if sizeInMiB < params.Size {
+		return nil, errors.Errorf("filesystem is not big enough (%dM < %dM)", sizeInMiB, params.Size)
+	}
+	return &storage.Filesystem{
+		params.Tag,
+		names.VolumeTag{},
+		storage.FilesystemInfo{
+			FilesystemId: params.Tag.Id(),
+			Size:         sizeInMiB,
+		},
+	}, nil
+}
+
+// DestroyFilesystems is defined on the FilesystemSource interface.
+func (s *lxdBindFilesystemSource) DestroyFilesystems(filesystemIds []string) ([]error, error) {
+	// DestroyFilesystems is a no-op; the directories belong to
+	// the host, and are left intact when the container is gone.
+	return make([]error, len(filesystemIds)), nil
+}
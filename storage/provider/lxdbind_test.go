@@ -0,0 +1,220 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"path/filepath"
+	"runtime"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider"
+	"github.com/juju/juju/testing"
+)
+
+var _ = gc.Suite(&lxdBindSuite{})
+
+type lxdBindSuite struct {
+	testing.BaseSuite
+	storageDir   string
+	commands     *mockRunCommand
+	mockDirFuncs *provider.MockDirFuncs
+}
+
+func (s *lxdBindSuite) SetUpTest(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("Tests relevant only on *nix systems")
+	}
+	s.BaseSuite.SetUpTest(c)
+	s.storageDir = c.MkDir()
+}
+
+func (s *lxdBindSuite) TearDownTest(c *gc.C) {
+	if s.commands != nil {
+		s.commands.assertDrained()
+	}
+	s.BaseSuite.TearDownTest(c)
+}
+
+func (s *lxdBindSuite) lxdBindProvider(c *gc.C) storage.Provider {
+	s.commands = &mockRunCommand{c: c}
+	return provider.LXDBindProvider(s.commands.run)
+}
+
+func (s *lxdBindSuite) TestFilesystemSource(c *gc.C) {
+	p := s.lxdBindProvider(c)
+	cfg, err := storage.NewConfig("name", provider.LXDBindProviderType, map[string]interface{}{})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = p.FilesystemSource(cfg)
+	c.Assert(err, gc.ErrorMatches, "storage directory not specified")
+	cfg, err = storage.NewConfig("name", provider.LXDBindProviderType, map[string]interface{}{
+		"storage-dir": c.MkDir(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = p.FilesystemSource(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *lxdBindSuite) TestValidateConfig(c *gc.C) {
+	p := s.lxdBindProvider(c)
+	cfg, err := storage.NewConfig("name", provider.LXDBindProviderType, map[string]interface{}{
+		"host-path": "/srv/juju",
+		"quota":     "10G",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = p.ValidateConfig(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *lxdBindSuite) TestValidateConfigRelativeHostPath(c *gc.C) {
+	p := s.lxdBindProvider(c)
+	cfg, err := storage.NewConfig("name", provider.LXDBindProviderType, map[string]interface{}{
+		"host-path": "srv/juju",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = p.ValidateConfig(cfg)
+	c.Assert(err, gc.ErrorMatches, `host-path "srv/juju" \(must be an absolute path\) not valid`)
+}
+
+func (s *lxdBindSuite) TestValidateConfigInvalidQuota(c *gc.C) {
+	p := s.lxdBindProvider(c)
+	cfg, err := storage.NewConfig("name", provider.LXDBindProviderType, map[string]interface{}{
+		"quota": "lots",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = p.ValidateConfig(cfg)
+	c.Assert(err, gc.ErrorMatches, `parsing quota: .*`)
+}
+
+func (s *lxdBindSuite) TestParseLXDBindConfig(c *gc.C) {
+	cfg, err := provider.ParseLXDBindConfig(map[string]interface{}{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg, jc.DeepEquals, &provider.LXDBindConfig{
+		HostPath: provider.DefaultLXDBindHostPath,
+	})
+
+	cfg, err = provider.ParseLXDBindConfig(map[string]interface{}{
+		"host-path": "/srv/juju/",
+		"quota":     "2G",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg, jc.DeepEquals, &provider.LXDBindConfig{
+		HostPath: "/srv/juju",
+		Quota:    2048,
+	})
+}
+
+func (s *lxdBindSuite) TestSupports(c *gc.C) {
+	p := s.lxdBindProvider(c)
+	c.Assert(p.Supports(storage.StorageKindBlock), jc.IsFalse)
+	c.Assert(p.Supports(storage.StorageKindFilesystem), jc.IsTrue)
+}
+
+func (s *lxdBindSuite) TestScope(c *gc.C) {
+	p := s.lxdBindProvider(c)
+	c.Assert(p.Scope(), gc.Equals, storage.ScopeMachine)
+}
+
+func (s *lxdBindSuite) TestDynamic(c *gc.C) {
+	p := s.lxdBindProvider(c)
+	c.Assert(p.Dynamic(), jc.IsFalse)
+}
+
+func (s *lxdBindSuite) lxdBindFilesystemSource(c *gc.C) storage.FilesystemSource {
+	s.commands = &mockRunCommand{c: c}
+	source, d := provider.LXDBindFilesystemSource(s.storageDir, s.commands.run)
+	s.mockDirFuncs = d
+	return source
+}
+
+func (s *lxdBindSuite) TestCreateFilesystems(c *gc.C) {
+	source := s.lxdBindFilesystemSource(c)
+	tag := names.NewFilesystemTag("0/lxd/0/6")
+	path := provider.LXDBindFilesystemPath(s.storageDir, tag)
+	s.mockDirFuncs.Dirs.Add(path)
+	cmd := s.commands.expect("df", "--output=size", path)
+	cmd.respond("1K-blocks\n2048", nil)
+
+	results, err := source.CreateFilesystems([]storage.FilesystemParams{{
+		Tag:  tag,
+		Size: 2,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.CreateFilesystemsResult{{
+		Filesystem: &storage.Filesystem{
+			Tag: tag,
+			FilesystemInfo: storage.FilesystemInfo{
+				FilesystemId: "0/lxd/0/6",
+				Size:         2,
+			},
+		},
+	}})
+}
+
+func (s *lxdBindSuite) TestCreateFilesystemsNotBound(c *gc.C) {
+	source := s.lxdBindFilesystemSource(c)
+	results, err := source.CreateFilesystems([]storage.FilesystemParams{{
+		Tag:  names.NewFilesystemTag("0/lxd/0/6"),
+		Size: 2,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, gc.ErrorMatches, "filesystem 0/lxd/0/6 was not bound into the container by the host: .*")
+}
+
+func (s *lxdBindSuite) TestCreateFilesystemsNotEnoughSpace(c *gc.C) {
+	source := s.lxdBindFilesystemSource(c)
+	tag := names.NewFilesystemTag("0/lxd/0/6")
+	path := provider.LXDBindFilesystemPath(s.storageDir, tag)
+	s.mockDirFuncs.Dirs.Add(path)
+	cmd := s.commands.expect("df", "--output=size", path)
+	cmd.respond("1K-blocks\n2048", nil)
+
+	results, err := source.CreateFilesystems([]storage.FilesystemParams{{
+		Tag:  tag,
+		Size: 4,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, gc.ErrorMatches, "filesystem is not big enough \\(2M < 4M\\)")
+}
+
+func (s *lxdBindSuite) TestAttachFilesystemsBind(c *gc.C) {
+	source := s.lxdBindFilesystemSource(c)
+	tag := names.NewFilesystemTag("0/lxd/0/6")
+
+	cmd := s.commands.expect("df", "--output=source", "/srv")
+	cmd.respond("headers\n/src/of/root", nil)
+
+	cmd = s.commands.expect("mount", "--bind", filepath.Join(s.storageDir, "lxd-bind", "0/lxd/0/6"), "/srv")
+	cmd.respond("", nil)
+
+	results, err := source.AttachFilesystems([]storage.FilesystemAttachmentParams{{
+		Filesystem:   tag,
+		FilesystemId: "0/lxd/0/6",
+		Path:         "/srv",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.AttachFilesystemsResult{{
+		FilesystemAttachment: &storage.FilesystemAttachment{
+			Filesystem: tag,
+			FilesystemAttachmentInfo: storage.FilesystemAttachmentInfo{
+				Path: "/srv",
+			},
+		},
+	}})
+}
+
+func (s *lxdBindSuite) TestDestroyFilesystems(c *gc.C) {
+	source := s.lxdBindFilesystemSource(c)
+	errs, err := source.DestroyFilesystems([]string{"0/lxd/0/6"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, jc.DeepEquals, []error{nil})
+}
+
+func (s *lxdBindSuite) TestDetachFilesystems(c *gc.C) {
+	source := s.lxdBindFilesystemSource(c)
+	testDetachFilesystems(c, s.commands, source, true)
+}
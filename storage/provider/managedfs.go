@@ -6,6 +6,7 @@ package provider
 import (
 	"path"
 	"path/filepath"
+	"strings"
 	"unicode"
 
 	"github.com/juju/errors"
@@ -31,6 +32,8 @@ type managedFilesystemSource struct {
 	filesystems        map[names.FilesystemTag]storage.Filesystem
 }
 
+var _ storage.FilesystemResizer = (*managedFilesystemSource)(nil)
+
 // NewManagedFilesystemSource returns a storage.FilesystemSource that manages
 // filesystems on block devices on the host machine.
 //
@@ -167,6 +170,49 @@ func (s *managedFilesystemSource) DetachFilesystems(args []storage.FilesystemAtt
 	return results, nil
 }
 
+// ResizeFilesystems is defined on storage.FilesystemResizer.
+func (s *managedFilesystemSource) ResizeFilesystems(args []storage.FilesystemResizeParams) ([]storage.ResizeFilesystemsResult, error) {
+	results := make([]storage.ResizeFilesystemsResult, len(args))
+	for i, arg := range args {
+		filesystem, err := s.resizeFilesystem(arg)
+		if err != nil {
+			results[i].Error = err
+			continue
+		}
+		results[i].Filesystem = filesystem
+	}
+	return results, nil
+}
+
+func (s *managedFilesystemSource) resizeFilesystem(arg storage.FilesystemResizeParams) (*storage.Filesystem, error) {
+	blockDevice, err := s.backingVolumeBlockDevice(arg.Volume)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	devicePath := devicePath(blockDevice)
+	if isDiskDevice(devicePath) {
+		if err := growPartition(s.run, devicePath); err != nil {
+			return nil, errors.Trace(err)
+		}
+		devicePath = partitionDevicePath(devicePath)
+	}
+	if err := growFilesystem(s.run, blockDevice.FilesystemType, devicePath, arg.Path); err != nil {
+		return nil, errors.Trace(err)
+	}
+	size := arg.Size
+	if blockDevice.Size > size {
+		size = blockDevice.Size
+	}
+	return &storage.Filesystem{
+		arg.Tag,
+		arg.Volume,
+		storage.FilesystemInfo{
+			arg.FilesystemId,
+			size,
+		},
+	}, nil
+}
+
 func destroyPartitions(run runCommandFunc, devicePath string) error {
 	logger.Debugf("destroying partitions on %q", devicePath)
 	if _, err := run("sgdisk", "--zap-all", devicePath); err != nil {
@@ -196,6 +242,49 @@ func createFilesystem(run runCommandFunc, devicePath string) error {
 	return nil
 }
 
+// growPartition grows the single partition (1) on the disk with the
+// specified device path to fill the disk.
+func growPartition(run runCommandFunc, devicePath string) error {
+	logger.Debugf("growing partition on %q", devicePath)
+	if _, err := run("growpart", devicePath, "1"); err != nil {
+		// growpart exits with an error, reporting NOCHANGE,
+		// if the partition already fills the disk.
+		if strings.Contains(err.Error(), "NOCHANGE") {
+			return nil
+		}
+		return errors.Annotate(err, "growpart failed")
+	}
+	return nil
+}
+
+// growFilesystem grows the filesystem on the device with the specified
+// path to fill the device. Some filesystems can only be grown while they
+// are mounted, so the filesystem's mount point must also be specified.
+func growFilesystem(run runCommandFunc, filesystemType, devicePath, mountPoint string) error {
+	if filesystemType == "" {
+		filesystemType = defaultFilesystemType
+	}
+	logger.Debugf("attempting to grow %s filesystem on %q", filesystemType, devicePath)
+	var cmd string
+	var args []string
+	switch filesystemType {
+	case "ext2", "ext3", "ext4":
+		cmd, args = "resize2fs", []string{devicePath}
+	case "xfs":
+		if mountPoint == "" {
+			return errors.New("xfs filesystem must be mounted to be resized")
+		}
+		cmd, args = "xfs_growfs", []string{mountPoint}
+	default:
+		return errors.NotSupportedf("resizing %s filesystem", filesystemType)
+	}
+	if _, err := run(cmd, args...); err != nil {
+		return errors.Annotatef(err, "%s failed", cmd)
+	}
+	logger.Infof("resized filesystem on %q", devicePath)
+	return nil
+}
+
 func mountFilesystem(run runCommandFunc, dirFuncs dirFuncs, devicePath, mountPoint string, readOnly bool) error {
 	logger.Debugf("attempting to mount filesystem on %q at %q", devicePath, mountPoint)
 	if err := dirFuncs.mkDirAll(mountPoint, 0755); err != nil {
@@ -6,6 +6,7 @@ package provider_test
 import (
 	"path/filepath"
 
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
@@ -185,3 +186,86 @@ func (s *managedfsSuite) TestDetachFilesystemsUnattached(c *gc.C) {
 	source := s.initSource(c)
 	testDetachFilesystems(c, s.commands, source, false)
 }
+
+func (s *managedfsSuite) TestResizeFilesystems(c *gc.C) {
+	source := s.initSource(c)
+	// The partition on sda is grown before the filesystem.
+	s.commands.expect("growpart", "/dev/sda", "1")
+	s.commands.expect("resize2fs", "/dev/sda1")
+	// xfs filesystems are grown through their mount point.
+	s.commands.expect("xfs_growfs", "/srv/data")
+
+	s.blockDevices[names.NewVolumeTag("0")] = storage.BlockDevice{
+		DeviceName: "sda",
+		Size:       2,
+	}
+	s.blockDevices[names.NewVolumeTag("1")] = storage.BlockDevice{
+		DeviceName:     "xvdf1",
+		FilesystemType: "xfs",
+		Size:           3,
+	}
+	results, err := source.(storage.FilesystemResizer).ResizeFilesystems([]storage.FilesystemResizeParams{{
+		Tag:          names.NewFilesystemTag("0/0"),
+		Volume:       names.NewVolumeTag("0"),
+		FilesystemId: "filesystem-0-0",
+		Size:         4,
+		Path:         "/srv/pgdata",
+	}, {
+		Tag:          names.NewFilesystemTag("0/1"),
+		Volume:       names.NewVolumeTag("1"),
+		FilesystemId: "filesystem-0-1",
+		Size:         2,
+		Path:         "/srv/data",
+	}, {
+		Tag:          names.NewFilesystemTag("0/2"),
+		Volume:       names.NewVolumeTag("2"),
+		FilesystemId: "filesystem-0-2",
+		Size:         2,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 3)
+	c.Assert(results[0], jc.DeepEquals, storage.ResizeFilesystemsResult{
+		Filesystem: &storage.Filesystem{
+			names.NewFilesystemTag("0/0"),
+			names.NewVolumeTag("0"),
+			storage.FilesystemInfo{
+				FilesystemId: "filesystem-0-0",
+				Size:         4,
+			},
+		},
+	})
+	c.Assert(results[1].Error, jc.ErrorIsNil)
+	c.Assert(results[1].Filesystem.Size, gc.Equals, uint64(3))
+	c.Assert(results[2].Error, gc.ErrorMatches, "backing-volume 2 is not yet attached")
+}
+
+func (s *managedfsSuite) TestResizeFilesystemsPartitionUnchanged(c *gc.C) {
+	source := s.initSource(c)
+	cmd := s.commands.expect("growpart", "/dev/sda", "1")
+	cmd.respond("NOCHANGE: partition 1 could only be grown by 0", errors.New("exit status 1: NOCHANGE"))
+	s.commands.expect("resize2fs", "/dev/sda1")
+
+	s.blockDevices[names.NewVolumeTag("0")] = storage.BlockDevice{DeviceName: "sda", Size: 2}
+	results, err := source.(storage.FilesystemResizer).ResizeFilesystems([]storage.FilesystemResizeParams{{
+		Tag:    names.NewFilesystemTag("0/0"),
+		Volume: names.NewVolumeTag("0"),
+		Size:   2,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+}
+
+func (s *managedfsSuite) TestResizeFilesystemsUnsupportedType(c *gc.C) {
+	source := s.initSource(c)
+	s.blockDevices[names.NewVolumeTag("0")] = storage.BlockDevice{
+		DeviceName:     "xvdf1",
+		FilesystemType: "btrfs",
+	}
+	results, err := source.(storage.FilesystemResizer).ResizeFilesystems([]storage.FilesystemResizeParams{{
+		Tag:    names.NewFilesystemTag("0/0"),
+		Volume: names.NewVolumeTag("0"),
+		Size:   2,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, gc.ErrorMatches, "resizing btrfs filesystem not supported")
+}
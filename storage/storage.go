@@ -47,4 +47,8 @@ type StorageAttachmentInfo struct {
 	// for a filesystem-kind storage attachment, and the device path
 	// for a block-kind.
 	Location string
+
+	// Size is the size of the storage attachment's volume or
+	// filesystem in MiB, or zero if it is not known.
+	Size uint64
 }
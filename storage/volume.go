@@ -3,7 +3,11 @@
 
 package storage
 
-import "gopkg.in/juju/names.v2"
+import (
+	"time"
+
+	"gopkg.in/juju/names.v2"
+)
 
 // Volume identifies and describes a volume (disk, logical volume, etc.)
 type Volume struct {
@@ -73,3 +77,20 @@ type VolumeAttachmentInfo struct {
 	// ReadOnly signifies whether the volume is read only or writable.
 	ReadOnly bool
 }
+
+// Snapshot describes a point-in-time snapshot of a volume.
+type Snapshot struct {
+	// SnapshotId is a unique provider-supplied ID for the snapshot.
+	SnapshotId string
+
+	// VolumeId is the provider-supplied ID of the volume that the
+	// snapshot was taken of.
+	VolumeId string
+
+	// Size is the size of the snapshotted volume, in MiB. A volume
+	// created from the snapshot must be at least this size.
+	Size uint64
+
+	// Created is the time at which the snapshot was taken.
+	Created time.Time
+}
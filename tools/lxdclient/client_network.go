@@ -7,6 +7,7 @@ package lxdclient
 
 import (
 	"fmt"
+	"net"
 
 	"github.com/juju/errors"
 	"github.com/lxc/lxd"
@@ -50,20 +51,32 @@ type creator interface {
 }
 
 func checkBridgeConfig(client rawNetworkClient, bridge string) error {
-	n, err := client.NetworkGet(bridge)
-	if err != nil {
-		return err
-	}
-	ipv6AddressConfig := n.Config["ipv6.address"]
-	if n.Managed && ipv6AddressConfig != "none" && ipv6AddressConfig != "" {
-		return errors.Errorf(`juju doesn't support ipv6. Please disable LXD's IPV6:
+	_, err := client.NetworkGet(bridge)
+	return err
+}
 
-	$ lxc network set %s ipv6.address none
+var interfaceAddrs = net.InterfaceAddrs
 
-and rebootstrap`, bridge)
+// defaultBridgeConfig returns the config used to create the default
+// bridge. The bridge is IPv4-only, unless the host has no IPv4
+// connectivity, in which case it is IPv6-only.
+func defaultBridgeConfig() (map[string]string, error) {
+	addrs, err := interfaceAddrs()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get network interface addresses")
 	}
-
-	return nil
+	if network.IPv6Only(addrs) {
+		return map[string]string{
+			"ipv4.address": "none",
+			"ipv4.nat":     "false",
+			"ipv6.address": "auto",
+			"ipv6.nat":     "true",
+		}, nil
+	}
+	return map[string]string{
+		"ipv6.address": "none",
+		"ipv6.nat":     "false",
+	}, nil
 }
 
 // CreateDefaultBridgeInDefaultProfile creates a default bridge if it doesn't
@@ -72,10 +85,11 @@ func CreateDefaultBridgeInDefaultProfile(client creator) error {
 	/* create the default bridge if it doesn't exist */
 	n, err := client.NetworkGet(network.DefaultLXDBridge)
 	if err != nil {
-		err := client.NetworkCreate(network.DefaultLXDBridge, map[string]string{
-			"ipv6.address": "none",
-			"ipv6.nat":     "false",
-		})
+		config, err := defaultBridgeConfig()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		err = client.NetworkCreate(network.DefaultLXDBridge, config)
 		if err != nil {
 			return err
 		}
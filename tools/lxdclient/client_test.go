@@ -7,6 +7,7 @@ package lxdclient
 
 import (
 	"io/ioutil"
+	"net"
 	"os"
 
 	"github.com/juju/errors"
@@ -181,15 +182,43 @@ LXD_IPV6_ADDR="2001:470:b368:4242::1"
 `
 
 	err = checkLXDBridgeConfiguration(ipv6)
-	c.Assert(err.Error(), gc.Equals, LXDBridgeFile+` has IPv6 enabled.
-Juju doesn't currently support IPv6.
+	c.Assert(err, jc.ErrorIsNil)
 
-IPv6 can be disabled by running:
+}
 
-       sudo dpkg-reconfigure -p medium lxd
+func (cs *ConnectSuite) patchInterfaceAddrs(c *gc.C, cidrs ...string) {
+	var addrs []net.Addr
+	for _, cidr := range cidrs {
+		ip, ipNet, err := net.ParseCIDR(cidr)
+		c.Assert(err, jc.ErrorIsNil)
+		ipNet.IP = ip
+		addrs = append(addrs, ipNet)
+	}
+	cs.PatchValue(&interfaceAddrs, func() ([]net.Addr, error) {
+		return addrs, nil
+	})
+}
 
-and then bootstrap again.`)
+func (cs *ConnectSuite) TestDefaultBridgeConfigIPv4(c *gc.C) {
+	cs.patchInterfaceAddrs(c, "127.0.0.1/8", "10.0.0.1/24", "2001:db8::1/64")
+	config, err := defaultBridgeConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(config, jc.DeepEquals, map[string]string{
+		"ipv6.address": "none",
+		"ipv6.nat":     "false",
+	})
+}
 
+func (cs *ConnectSuite) TestDefaultBridgeConfigIPv6Only(c *gc.C) {
+	cs.patchInterfaceAddrs(c, "127.0.0.1/8", "::1/128", "2001:db8::1/64")
+	config, err := defaultBridgeConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(config, jc.DeepEquals, map[string]string{
+		"ipv4.address": "none",
+		"ipv4.nat":     "false",
+		"ipv6.address": "auto",
+		"ipv6.nat":     "true",
+	})
 }
 
 func (cs *ConnectSuite) TestRemoteConnectError(c *gc.C) {
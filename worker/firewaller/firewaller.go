@@ -32,6 +32,7 @@ type Firewaller struct {
 	environ              environs.Environ
 	machinesWatcher      watcher.StringsWatcher
 	portsWatcher         watcher.StringsWatcher
+	subnetsWatcher       watcher.StringsWatcher
 	machineds            map[names.MachineTag]*machineData
 	unitsChange          chan *unitsChange
 	unitds               map[names.UnitTag]*unitData
@@ -98,6 +99,20 @@ func (fw *Firewaller) setUp() error {
 	}
 
 	logger.Debugf("started watching opened port ranges for the environment")
+
+	// Exposed applications may be restricted to the subnets of
+	// spaces, so their ingress CIDRs must be recomputed when the
+	// subnets change.
+	fw.subnetsWatcher, err = fw.st.WatchSubnets()
+	if errors.IsNotSupported(err) {
+		logger.Debugf("subnet watching not supported: %v", err)
+		return nil
+	} else if err != nil {
+		return errors.Annotatef(err, "failed to start subnets watcher")
+	}
+	if err := fw.catacomb.Add(fw.subnetsWatcher); err != nil {
+		return errors.Trace(err)
+	}
 	return nil
 }
 
@@ -107,6 +122,10 @@ func (fw *Firewaller) loop() error {
 	}
 	var reconciled bool
 	portsChange := fw.portsWatcher.Changes()
+	var subnetsChange watcher.StringsChannel
+	if fw.subnetsWatcher != nil {
+		subnetsChange = fw.subnetsWatcher.Changes()
+	}
 	for {
 		select {
 		case <-fw.catacomb.Dying():
@@ -145,12 +164,22 @@ func (fw *Firewaller) loop() error {
 					return errors.Trace(err)
 				}
 			}
+		case _, ok := <-subnetsChange:
+			if !ok {
+				return errors.New("subnets watcher closed")
+			}
+			for _, serviced := range fw.applicationids {
+				if serviced.exposed {
+					serviced.subnetsChanged()
+				}
+			}
 		case change := <-fw.unitsChange:
 			if err := fw.unitsChanged(change); err != nil {
 				return errors.Trace(err)
 			}
 		case change := <-fw.exposedChange:
 			change.serviced.exposed = change.exposed
+			change.serviced.ingressCIDRs = change.ingressCIDRs
 			unitds := []*unitData{}
 			for _, unitd := range change.serviced.unitds {
 				unitds = append(unitds, unitd)
@@ -278,20 +307,22 @@ func (fw *Firewaller) startUnit(unit *firewaller.Unit, machineTag names.MachineT
 // startService creates a new data value for tracking details of the
 // service and starts watching the service for exposure changes.
 func (fw *Firewaller) startService(service *firewaller.Application) error {
-	exposed, err := service.IsExposed()
+	exposed, ingressCIDRs, err := service.IngressCIDRs()
 	if err != nil {
 		return err
 	}
 	serviced := &serviceData{
-		fw:          fw,
-		application: service,
-		exposed:     exposed,
-		unitds:      make(map[names.UnitTag]*unitData),
+		fw:            fw,
+		application:   service,
+		exposed:       exposed,
+		ingressCIDRs:  ingressCIDRs,
+		unitds:        make(map[names.UnitTag]*unitData),
+		subnetsChange: make(chan struct{}, 1),
 	}
 	err = catacomb.Invoke(catacomb.Plan{
 		Site: &serviced.catacomb,
 		Work: func() error {
-			return serviced.watchLoop(exposed)
+			return serviced.watchLoop(exposed, ingressCIDRs)
 		},
 	})
 	if err != nil {
@@ -554,9 +585,12 @@ func (fw *Firewaller) gatherIngressRules(machines ...*machineData) ([]network.In
 			}
 
 			cidrs := set.NewStrings()
-			// If the unit is exposed, allow access from everywhere.
+			// If the unit is exposed, allow access from the
+			// application's ingress CIDRs.
 			if unitd.serviced.exposed {
-				cidrs.Add("0.0.0.0/0")
+				for _, cidr := range unitd.serviced.ingressCIDRs {
+					cidrs.Add(cidr)
+				}
 			}
 
 			// Add any ingress rules required by remote relations.
@@ -585,6 +619,11 @@ func (fw *Firewaller) updateForRemoteRelationIngress(tag names.ApplicationTag, c
 // It keeps a reference count for ports so that only 0-to-1 and 1-to-0 events
 // modify the environment.
 func (fw *Firewaller) flushGlobalPorts(rawOpen, rawClose []network.IngressRule) error {
+	// Machines may open the same port range to different sources,
+	// so the references are counted per port range and source CIDR.
+	rawOpen = splitRuleSources(rawOpen)
+	rawClose = splitRuleSources(rawClose)
+
 	// Filter which ports are really to open or close.
 	var toOpen, toClose []network.IngressRule
 	for _, rule := range rawOpen {
@@ -622,6 +661,25 @@ func (fw *Firewaller) flushGlobalPorts(rawOpen, rawClose []network.IngressRule)
 	return nil
 }
 
+// splitRuleSources returns the given rules split into rules
+// with a single source CIDR each.
+func splitRuleSources(rules []network.IngressRule) []network.IngressRule {
+	var result []network.IngressRule
+	for _, rule := range rules {
+		cidrs := rule.SourceCIDRs
+		if len(cidrs) == 0 {
+			cidrs = network.DefaultIngressCIDRs()
+		}
+		for _, cidr := range cidrs {
+			result = append(result, network.IngressRule{
+				PortRange:   rule.PortRange,
+				SourceCIDRs: []string{cidr},
+			})
+		}
+	}
+	return result
+}
+
 // flushInstancePorts opens and closes ports global on the machine.
 func (fw *Firewaller) flushInstancePorts(machined *machineData, toOpen, toClose []network.IngressRule) error {
 	// If there's nothing to do, do nothing.
@@ -811,10 +869,12 @@ type unitData struct {
 	machined *machineData
 }
 
-// exposedChange contains the changed exposed flag for one specific service.
+// exposedChange contains the changed exposed flag and ingress CIDRs
+// for one specific service.
 type exposedChange struct {
-	serviced *serviceData
-	exposed  bool
+	serviced     *serviceData
+	exposed      bool
+	ingressCIDRs []string
 }
 
 // serviceData holds service details and watches exposure changes.
@@ -824,10 +884,29 @@ type serviceData struct {
 	application *firewaller.Application
 	exposed     bool
 	unitds      map[names.UnitTag]*unitData
+
+	// ingressCIDRs holds the source CIDRs from which the
+	// service's open ports may be accessed when it is exposed.
+	ingressCIDRs []string
+
+	// subnetsChange is signalled when the model's subnets change,
+	// which may change the ingress CIDRs of an exposed service.
+	subnetsChange chan struct{}
+}
+
+// subnetsChanged signals the service's watch loop to recompute its
+// ingress CIDRs, without blocking if a signal is already pending.
+func (sd *serviceData) subnetsChanged() {
+	select {
+	case sd.subnetsChange <- struct{}{}:
+	default:
+	}
 }
 
-// watchLoop watches the service's exposed flag for changes.
-func (sd *serviceData) watchLoop(exposed bool) error {
+// watchLoop watches the service's exposed flag and ingress CIDRs
+// for changes, including those caused by changes to the subnets of
+// the spaces the service is exposed to.
+func (sd *serviceData) watchLoop(exposed bool, ingressCIDRs []string) error {
 	serviceWatcher, err := sd.application.Watch()
 	if err != nil {
 		return errors.Trace(err)
@@ -849,24 +928,34 @@ func (sd *serviceData) watchLoop(exposed bool) error {
 				}
 				return nil
 			}
-			change, err := sd.application.IsExposed()
-			if err != nil {
-				return errors.Trace(err)
-			}
-			if change == exposed {
-				continue
-			}
+		case <-sd.subnetsChange:
+		}
+		change, changeCIDRs, err := sd.application.IngressCIDRs()
+		if params.IsCodeNotFound(err) {
+			return nil
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if change == exposed && cidrsEqual(changeCIDRs, ingressCIDRs) {
+			continue
+		}
 
-			exposed = change
-			select {
-			case sd.fw.exposedChange <- &exposedChange{sd, change}:
-			case <-sd.catacomb.Dying():
-				return sd.catacomb.ErrDying()
-			}
+		exposed = change
+		ingressCIDRs = changeCIDRs
+		select {
+		case sd.fw.exposedChange <- &exposedChange{sd, change, changeCIDRs}:
+		case <-sd.catacomb.Dying():
+			return sd.catacomb.ErrDying()
 		}
 	}
 }
 
+// cidrsEqual reports whether the two lists hold the same CIDRs.
+func cidrsEqual(a, b []string) bool {
+	as, bs := set.NewStrings(a...), set.NewStrings(b...)
+	return as.Size() == bs.Size() && as.Difference(bs).IsEmpty()
+}
+
 // Kill is part of the worker.Worker interface.
 func (sd *serviceData) Kill() {
 	sd.catacomb.Kill(nil)
@@ -907,7 +996,7 @@ func diffRanges(currentRules, wantedRules []network.IngressRule) (toOpen, toClos
 			}
 			ruleCidrs := rule.SourceCIDRs
 			if len(ruleCidrs) == 0 {
-				ruleCidrs = []string{"0.0.0.0/0"}
+				ruleCidrs = network.DefaultIngressCIDRs()
 			}
 			for _, cidr := range ruleCidrs {
 				cidrs.Add(cidr)
@@ -428,6 +428,69 @@ func (s *InstanceModeSuite) TestSetClearExposedService(c *gc.C) {
 	s.assertPorts(c, inst, m.Id(), nil)
 }
 
+func (s *InstanceModeSuite) TestExposedServiceToCIDRs(c *gc.C) {
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app := s.AddTestingService(c, "wordpress", s.charm)
+
+	u, m := s.addUnit(c, app)
+	inst := s.startInstance(c, m)
+	err := u.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = app.SetExposedTo([]string{"10.0.0.0/8", "192.168.0.0/24"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/8", "192.168.0.0/24"),
+	})
+
+	// Changing the restrictions closes access from the
+	// sources that are no longer allowed.
+	err = app.SetExposedTo([]string{"10.0.0.0/8"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/8"),
+	})
+
+	// Removing the restrictions allows access from anywhere.
+	err = app.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0"),
+	})
+}
+
+func (s *InstanceModeSuite) TestExposedServiceToSpaces(c *gc.C) {
+	_, err := s.State.AddSubnet(state.SubnetInfo{CIDR: "10.1.0.0/16"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSpace("mgmt", "", []string{"10.1.0.0/16"}, false)
+	c.Assert(err, jc.ErrorIsNil)
+
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app := s.AddTestingService(c, "wordpress", s.charm)
+	err = app.SetExposedTo(nil, []string{"mgmt"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	u, m := s.addUnit(c, app)
+	inst := s.startInstance(c, m)
+	err = u.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "10.1.0.0/16"),
+	})
+
+	// Adding a subnet to the space opens the ports to it too.
+	_, err = s.State.AddSubnet(state.SubnetInfo{CIDR: "10.2.0.0/16", SpaceName: "mgmt"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "10.1.0.0/16", "10.2.0.0/16"),
+	})
+}
+
 func (s *InstanceModeSuite) TestRemoveUnit(c *gc.C) {
 	fw := s.newFirewaller(c)
 	defer statetesting.AssertKillAndWait(c, fw)
@@ -717,6 +780,39 @@ func (s *GlobalModeSuite) TestGlobalMode(c *gc.C) {
 	s.assertEnvironPorts(c, nil)
 }
 
+func (s *GlobalModeSuite) TestGlobalModeExposedToCIDRs(c *gc.C) {
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app1 := s.AddTestingService(c, "wordpress", s.charm)
+	err := app1.SetExposedTo([]string{"10.0.0.0/8"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	u1, m1 := s.addUnit(c, app1)
+	s.startInstance(c, m1)
+	err = u1.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+
+	app2 := s.AddTestingService(c, "moinmoin", s.charm)
+	err = app2.SetExposedTo([]string{"10.0.0.0/8", "192.168.0.0/24"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	u2, m2 := s.addUnit(c, app2)
+	s.startInstance(c, m2)
+	err = u2.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertEnvironPorts(c, []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/8", "192.168.0.0/24"),
+	})
+
+	// Unexposing the second application closes only the
+	// source that the first application does not use.
+	err = app2.ClearExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertEnvironPorts(c, []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/8"),
+	})
+}
+
 func (s *GlobalModeSuite) TestStartWithUnexposedService(c *gc.C) {
 	m, err := s.State.AddMachine("quantal", state.JobHostUnits)
 	c.Assert(err, jc.ErrorIsNil)
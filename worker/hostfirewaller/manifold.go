@@ -0,0 +1,172 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hostfirewaller
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/dependency"
+)
+
+// ManifoldConfig defines the names of the manifolds on which the
+// hostfirewaller worker depends.
+type ManifoldConfig struct {
+	AgentName     string
+	APICallerName string
+
+	NewFacade  func(base.APICaller) (Facade, error)
+	NewWorker  func(Config) (worker.Worker, error)
+	RunCommand RunCommandFunc
+}
+
+// validate is called by start to check for bad configuration.
+func (config ManifoldConfig) validate() error {
+	if config.AgentName == "" {
+		return errors.NotValidf("empty AgentName")
+	}
+	if config.APICallerName == "" {
+		return errors.NotValidf("empty APICallerName")
+	}
+	if config.NewFacade == nil {
+		return errors.NotValidf("nil NewFacade")
+	}
+	if config.NewWorker == nil {
+		return errors.NotValidf("nil NewWorker")
+	}
+	if config.RunCommand == nil {
+		return errors.NotValidf("nil RunCommand")
+	}
+	return nil
+}
+
+// start is a StartFunc for a Worker manifold.
+func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, error) {
+	if runtime.GOOS == "windows" {
+		logger.Debugf("host firewalls are not managed on Windows machines")
+		return nil, dependency.ErrUninstall
+	}
+
+	if err := config.validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var agent agent.Agent
+	if err := context.Get(config.AgentName, &agent); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var apiCaller base.APICaller
+	if err := context.Get(config.APICallerName, &apiCaller); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	agentConfig := agent.CurrentConfig()
+	tag, ok := agentConfig.Tag().(names.MachineTag)
+	if !ok {
+		return nil, errors.New("hostfirewaller may only be used with a machine agent")
+	}
+
+	// SSH must remain reachable, as must the API server
+	// and database of controller machines.
+	alwaysOpen := []network.PortRange{{FromPort: 22, ToPort: 22, Protocol: "tcp"}}
+	if info, ok := agentConfig.StateServingInfo(); ok {
+		alwaysOpen = append(alwaysOpen,
+			network.PortRange{FromPort: info.APIPort, ToPort: info.APIPort, Protocol: "tcp"},
+			network.PortRange{FromPort: info.StatePort, ToPort: info.StatePort, Protocol: "tcp"},
+		)
+	}
+
+	// When egress is restricted, DNS must keep working,
+	// and the controllers must remain reachable.
+	alwaysAllowedEgress := []network.EgressRule{
+		network.MustNewEgressRule("tcp", 53, 53, "0.0.0.0/0"),
+		network.MustNewEgressRule("udp", 53, 53, "0.0.0.0/0"),
+		network.MustNewEgressRule("tcp", 53, 53, "::/0"),
+		network.MustNewEgressRule("udp", 53, 53, "::/0"),
+	}
+	apiAddresses, err := agentConfig.APIAddresses()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	alwaysAllowedEgress = append(alwaysAllowedEgress, apiEgressRules(apiAddresses)...)
+
+	rulesDir := filepath.Join(agentConfig.DataDir(), "host-firewall")
+	if err := os.MkdirAll(rulesDir, 0700); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	facade, err := config.NewFacade(apiCaller)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	worker, err := config.NewWorker(Config{
+		Facade:     facade,
+		MachineTag: tag,
+		AlwaysOpen: alwaysOpen,
+		RulesDir:   rulesDir,
+		RunCommand: config.RunCommand,
+
+		AlwaysAllowedEgress: alwaysAllowedEgress,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return worker, nil
+}
+
+// apiEgressRules returns egress rules allowing connections to the given
+// API server addresses. Addresses that are not IP addresses are
+// skipped, as they cannot be expressed as a CIDR.
+func apiEgressRules(addresses []string) []network.EgressRule {
+	var rules []network.EgressRule
+	for _, address := range addresses {
+		host, portString, err := net.SplitHostPort(address)
+		if err != nil {
+			logger.Warningf("ignoring API address %q: %v", address, err)
+			continue
+		}
+		port, err := strconv.Atoi(portString)
+		if err != nil {
+			logger.Warningf("ignoring API address %q: %v", address, err)
+			continue
+		}
+		ip := net.ParseIP(host)
+		if ip == nil {
+			logger.Debugf("ignoring API address %q: not an IP address", address)
+			continue
+		}
+		cidr := ip.String() + "/32"
+		if ip.To4() == nil {
+			cidr = ip.String() + "/128"
+		}
+		rule, err := network.NewEgressRule("tcp", port, port, cidr)
+		if err != nil {
+			logger.Warningf("ignoring API address %q: %v", address, err)
+			continue
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// Manifold returns a dependency manifold that runs the hostfirewaller
+// worker.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{
+			config.AgentName,
+			config.APICallerName,
+		},
+		Start: config.start,
+	}
+}
@@ -0,0 +1,340 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hostfirewaller
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/network"
+)
+
+const (
+	// iptablesChain is the chain, jumped to from INPUT, that holds
+	// the rules managed by the worker.
+	iptablesChain = "juju-host-firewall"
+
+	// iptablesEgressChain is the chain, jumped to from OUTPUT, that
+	// holds the egress rules managed by the worker.
+	iptablesEgressChain = "juju-host-egress"
+
+	// nftablesTable is the inet table that holds the rules managed
+	// by the worker.
+	nftablesTable = "juju"
+)
+
+// containerBridges holds the bridges from which all traffic is
+// accepted, as containers hosted on the machine rely on it for DHCP
+// and DNS.
+var containerBridges = []string{
+	network.DefaultLXDBridge,
+	network.DefaultKVMBridge,
+}
+
+// firewallBackend applies rules to the machine's firewall using a
+// particular tool.
+type firewallBackend interface {
+	// apply replaces any rules previously applied with rules that
+	// drop incoming traffic other than to the given port ranges or
+	// from the given source addresses. If any egress rules are
+	// given, outgoing traffic other than to their destinations is
+	// also dropped.
+	apply(portRanges []network.PortRange, sources []string, egressRules []network.EgressRule) error
+
+	// removeEgress removes any egress rules previously applied.
+	removeEgress() error
+
+	// remove removes any ingress rules previously applied.
+	remove() error
+}
+
+// iptablesBackend is a firewallBackend that uses iptables and
+// ip6tables. Its rules live in a dedicated chain, so that rules
+// managed by others are left untouched.
+type iptablesBackend struct {
+	run RunCommandFunc
+	dir string
+}
+
+var iptablesCommands = []struct {
+	command string
+	icmp    string
+	ipv6    bool
+}{
+	{"iptables", "icmp", false},
+	{"ip6tables", "ipv6-icmp", true},
+}
+
+func (b *iptablesBackend) apply(portRanges []network.PortRange, sources []string, egressRules []network.EgressRule) error {
+	for _, cmd := range iptablesCommands {
+		path := filepath.Join(b.dir, cmd.command+".rules")
+		rules := renderIPTablesRules(cmd.icmp, cmd.ipv6, portRanges, sources, egressRules)
+		if err := ioutil.WriteFile(path, []byte(rules), 0600); err != nil {
+			return errors.Trace(err)
+		}
+		// With --noflush, only our chains are flushed and
+		// replaced; the rest of the table is left alone.
+		if _, err := b.run(cmd.command+"-restore", "--noflush", path); err != nil {
+			return errors.Annotatef(err, "loading %s", path)
+		}
+		if err := b.ensureJump(cmd.command, "INPUT", iptablesChain); err != nil {
+			return errors.Trace(err)
+		}
+		if len(egressRules) == 0 {
+			continue
+		}
+		if err := b.ensureJump(cmd.command, "OUTPUT", iptablesEgressChain); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// ensureJump adds a rule jumping from the builtin chain to the named
+// chain, unless one already exists.
+func (b *iptablesBackend) ensureJump(command, builtin, chain string) error {
+	if _, err := b.run(command, "-C", builtin, "-j", chain); err == nil {
+		return nil
+	}
+	if _, err := b.run(command, "-I", builtin, "-j", chain); err != nil {
+		return errors.Annotatef(err, "adding %s chain to %s", chain, builtin)
+	}
+	return nil
+}
+
+func (b *iptablesBackend) removeEgress() error {
+	for _, cmd := range iptablesCommands {
+		if err := b.removeChain(cmd.command, "OUTPUT", iptablesEgressChain); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+func (b *iptablesBackend) remove() error {
+	for _, cmd := range iptablesCommands {
+		if err := b.removeChain(cmd.command, "INPUT", iptablesChain); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// removeChain removes the rule jumping from the builtin chain to the
+// named chain, and then deletes the named chain.
+func (b *iptablesBackend) removeChain(command, builtin, chain string) error {
+	if _, err := b.run(command, "-C", builtin, "-j", chain); err == nil {
+		if _, err := b.run(command, "-D", builtin, "-j", chain); err != nil {
+			return errors.Annotatef(err, "removing %s chain from %s", chain, builtin)
+		}
+	}
+	// The chain will not exist if the machine has
+	// rebooted since the rules were applied.
+	if _, err := b.run(command, "-F", chain); err != nil {
+		return nil
+	}
+	if _, err := b.run(command, "-X", chain); err != nil {
+		return errors.Annotatef(err, "deleting %s chain", chain)
+	}
+	return nil
+}
+
+// renderIPTablesRules returns an iptables-restore rule set that
+// replaces the contents of the worker's chain and, if there are
+// egress rules, its egress chain. Only the source addresses and the
+// egress rules for destinations of the given address family are
+// included.
+func renderIPTablesRules(icmp string, ipv6 bool, portRanges []network.PortRange, sources []string, egressRules []network.EgressRule) string {
+	var buf bytes.Buffer
+	rule := func(chain, spec string) {
+		fmt.Fprintf(&buf, "-A %s %s\n", chain, spec)
+	}
+	fmt.Fprintf(&buf, "*filter\n:%s - [0:0]\n", iptablesChain)
+	if len(egressRules) > 0 {
+		fmt.Fprintf(&buf, ":%s - [0:0]\n", iptablesEgressChain)
+	}
+	rule(iptablesChain, "-i lo -j ACCEPT")
+	rule(iptablesChain, "-m conntrack --ctstate RELATED,ESTABLISHED -j ACCEPT")
+	rule(iptablesChain, fmt.Sprintf("-p %s -j ACCEPT", icmp))
+	for _, iface := range containerBridges {
+		rule(iptablesChain, fmt.Sprintf("-i %s -j ACCEPT", iface))
+	}
+	for _, source := range sources {
+		if isIPv6Address(source) != ipv6 {
+			continue
+		}
+		rule(iptablesChain, fmt.Sprintf("-s %s -j ACCEPT", source))
+	}
+	for _, portRange := range portRanges {
+		protocol, ok := filteredProtocol(portRange)
+		if !ok {
+			continue
+		}
+		rule(iptablesChain, fmt.Sprintf("-p %s -m %s --dport %s -j ACCEPT", protocol, protocol, iptablesPorts(portRange)))
+	}
+	rule(iptablesChain, "-j DROP")
+
+	if len(egressRules) > 0 {
+		rule(iptablesEgressChain, "-o lo -j ACCEPT")
+		rule(iptablesEgressChain, "-m conntrack --ctstate RELATED,ESTABLISHED -j ACCEPT")
+		rule(iptablesEgressChain, fmt.Sprintf("-p %s -j ACCEPT", icmp))
+		for _, iface := range containerBridges {
+			rule(iptablesEgressChain, fmt.Sprintf("-o %s -j ACCEPT", iface))
+		}
+		for _, egressRule := range egressRules {
+			protocol, ok := filteredProtocol(egressRule.PortRange)
+			if !ok || isIPv6CIDR(egressRule.DestinationCIDR) != ipv6 {
+				continue
+			}
+			rule(iptablesEgressChain, fmt.Sprintf("-d %s -p %s -m %s --dport %s -j ACCEPT",
+				egressRule.DestinationCIDR, protocol, protocol, iptablesPorts(egressRule.PortRange),
+			))
+		}
+		rule(iptablesEgressChain, "-j DROP")
+	}
+	buf.WriteString("COMMIT\n")
+	return buf.String()
+}
+
+func iptablesPorts(portRange network.PortRange) string {
+	if portRange.ToPort != portRange.FromPort {
+		return fmt.Sprintf("%d:%d", portRange.FromPort, portRange.ToPort)
+	}
+	return fmt.Sprint(portRange.FromPort)
+}
+
+// nftablesBackend is a firewallBackend that uses nftables. Its rules
+// live in a dedicated table, which is replaced atomically.
+type nftablesBackend struct {
+	run RunCommandFunc
+	dir string
+}
+
+func (b *nftablesBackend) apply(portRanges []network.PortRange, sources []string, egressRules []network.EgressRule) error {
+	return b.load(renderNFTablesRules(portRanges, sources, egressRules))
+}
+
+// removeEgress is a no-op, as the egress rules are replaced along
+// with the rest of the table whenever rules are applied.
+func (b *nftablesBackend) removeEgress() error {
+	return nil
+}
+
+func (b *nftablesBackend) remove() error {
+	return b.load(nftablesReset)
+}
+
+func (b *nftablesBackend) load(rules string) error {
+	path := filepath.Join(b.dir, "nftables.rules")
+	if err := ioutil.WriteFile(path, []byte(rules), 0600); err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := b.run("nft", "-f", path); err != nil {
+		return errors.Annotatef(err, "loading %s", path)
+	}
+	return nil
+}
+
+// nftablesReset deletes the worker's table. The table is declared
+// first so that the deletion succeeds whether or not it exists.
+var nftablesReset = fmt.Sprintf("table inet %s\ndelete table inet %s\n", nftablesTable, nftablesTable)
+
+// renderNFTablesRules returns an nft script that replaces the
+// worker's table. The output chain is only included if there are
+// egress rules.
+func renderNFTablesRules(portRanges []network.PortRange, sources []string, egressRules []network.EgressRule) string {
+	var buf bytes.Buffer
+	rule := func(spec string) {
+		fmt.Fprintf(&buf, "\t\t%s\n", spec)
+	}
+	buf.WriteString(nftablesReset)
+	fmt.Fprintf(&buf, "table inet %s {\n\tchain input {\n", nftablesTable)
+	rule("type filter hook input priority 0; policy drop;")
+	rule("iif lo accept")
+	rule("ct state established,related accept")
+	rule("ip protocol icmp accept")
+	rule("ip6 nexthdr ipv6-icmp accept")
+	for _, iface := range containerBridges {
+		rule(fmt.Sprintf("iifname %q accept", iface))
+	}
+	for _, source := range sources {
+		family := "ip"
+		if isIPv6Address(source) {
+			family = "ip6"
+		}
+		rule(fmt.Sprintf("%s saddr %s accept", family, source))
+	}
+	for _, portRange := range portRanges {
+		protocol, ok := filteredProtocol(portRange)
+		if !ok {
+			continue
+		}
+		rule(fmt.Sprintf("%s dport %s accept", protocol, nftablesPorts(portRange)))
+	}
+	buf.WriteString("\t}\n")
+	if len(egressRules) > 0 {
+		buf.WriteString("\tchain output {\n")
+		rule("type filter hook output priority 0; policy drop;")
+		rule("oif lo accept")
+		rule("ct state established,related accept")
+		rule("ip protocol icmp accept")
+		rule("ip6 nexthdr ipv6-icmp accept")
+		for _, iface := range containerBridges {
+			rule(fmt.Sprintf("oifname %q accept", iface))
+		}
+		for _, egressRule := range egressRules {
+			protocol, ok := filteredProtocol(egressRule.PortRange)
+			if !ok {
+				continue
+			}
+			family := "ip"
+			if isIPv6CIDR(egressRule.DestinationCIDR) {
+				family = "ip6"
+			}
+			rule(fmt.Sprintf("%s daddr %s %s dport %s accept",
+				family, egressRule.DestinationCIDR, protocol, nftablesPorts(egressRule.PortRange),
+			))
+		}
+		buf.WriteString("\t}\n")
+	}
+	buf.WriteString("}\n")
+	return buf.String()
+}
+
+func nftablesPorts(portRange network.PortRange) string {
+	if portRange.ToPort != portRange.FromPort {
+		return fmt.Sprintf("%d-%d", portRange.FromPort, portRange.ToPort)
+	}
+	return fmt.Sprint(portRange.FromPort)
+}
+
+// isIPv6CIDR returns whether the given valid CIDR is an IPv6 one.
+func isIPv6CIDR(cidr string) bool {
+	ip, _, err := net.ParseCIDR(cidr)
+	return err == nil && ip.To4() == nil
+}
+
+// isIPv6Address returns whether the given IP address is an IPv6 one.
+func isIPv6Address(address string) bool {
+	ip := net.ParseIP(address)
+	return ip != nil && ip.To4() == nil
+}
+
+// filteredProtocol returns the protocol of the port range, and
+// whether rules can be rendered for it.
+func filteredProtocol(portRange network.PortRange) (string, bool) {
+	protocol := strings.ToLower(portRange.Protocol)
+	switch protocol {
+	case "tcp", "udp":
+		return protocol, true
+	}
+	logger.Warningf("ignoring port range %v: protocol not supported", portRange)
+	return "", false
+}
@@ -0,0 +1,26 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hostfirewaller
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	apihostfirewaller "github.com/juju/juju/api/hostfirewaller"
+	"github.com/juju/juju/worker"
+)
+
+// NewFacade returns a Facade backed by the HostFirewaller API facade.
+func NewFacade(apiCaller base.APICaller) (Facade, error) {
+	return apihostfirewaller.NewFacade(apiCaller), nil
+}
+
+// NewWorker returns a Worker backed by config, or an error.
+func NewWorker(config Config) (worker.Worker, error) {
+	worker, err := New(config)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return worker, nil
+}
@@ -0,0 +1,343 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package hostfirewaller implements a machine agent worker that
+// renders the ports opened by units on the machine into host firewall
+// rules, dropping incoming traffic to other ports unless it comes from
+// the model's machines. When all of the applications on the machine
+// have egress rules, outgoing traffic to destinations they do not allow
+// is dropped too. It complements the firewaller, which only manages the cloud's
+// security groups, and is enabled per model with the
+// host-firewall-mode setting.
+package hostfirewaller
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.hostfirewaller")
+
+// Facade exposes controller functionality to a Worker.
+type Facade interface {
+	ModelConfig() (*config.Config, error)
+	WatchForModelConfigChanges() (watcher.NotifyWatcher, error)
+	OpenedPorts(names.MachineTag) ([]network.PortRange, error)
+	WatchOpenedPorts(names.MachineTag) (watcher.NotifyWatcher, error)
+	EgressRules(names.MachineTag) ([]network.EgressRule, error)
+	WatchEgressRules(names.MachineTag) (watcher.NotifyWatcher, error)
+	ModelMachineAddresses() ([]string, error)
+	WatchModelMachineAddresses() (watcher.NotifyWatcher, error)
+}
+
+// RunCommandFunc runs a command on the machine, returning its
+// combined output.
+type RunCommandFunc func(cmd string, args ...string) (string, error)
+
+// Config defines the parameters of the hostfirewaller worker.
+type Config struct {
+	Facade     Facade
+	MachineTag names.MachineTag
+
+	// AlwaysOpen holds the port ranges that are allowed in addition
+	// to those opened by units, so that the machine remains
+	// reachable by its operators and agents.
+	AlwaysOpen []network.PortRange
+
+	// AlwaysAllowedEgress holds the destinations that are allowed
+	// in addition to those of the applications' egress rules, when
+	// egress is restricted, so that the machine's agents keep
+	// working.
+	AlwaysAllowedEgress []network.EgressRule
+
+	// RulesDir is the directory in which rule sets are written
+	// before they are loaded.
+	RulesDir string
+
+	// RunCommand is used to run the firewall tools.
+	RunCommand RunCommandFunc
+}
+
+// Validate returns an error if Config cannot drive a hostfirewaller.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.MachineTag.Id() == "" {
+		return errors.NotValidf("empty MachineTag")
+	}
+	if config.RulesDir == "" {
+		return errors.NotValidf("empty RulesDir")
+	}
+	if config.RunCommand == nil {
+		return errors.NotValidf("nil RunCommand")
+	}
+	return nil
+}
+
+// New returns a Worker backed by config, or an error.
+func New(config Config) (*Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Worker keeps the machine's firewall in line with the ports opened
+// on the machine and the model's host-firewall-mode.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+
+	// mode, rules, sources and egress record the last firewall
+	// configuration that was applied, so that unchanged
+	// configurations are not reapplied, and so that rules are
+	// removed when the mode changes.
+	mode    string
+	rules   []network.PortRange
+	sources []string
+	egress  []network.EgressRule
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	configWatcher, err := w.config.Facade.WatchForModelConfigChanges()
+	if err != nil {
+		return errors.Annotate(err, "cannot watch model config")
+	}
+	if err := w.catacomb.Add(configWatcher); err != nil {
+		return errors.Trace(err)
+	}
+	portsWatcher, err := w.config.Facade.WatchOpenedPorts(w.config.MachineTag)
+	if err != nil {
+		return errors.Annotate(err, "cannot watch opened ports")
+	}
+	if err := w.catacomb.Add(portsWatcher); err != nil {
+		return errors.Trace(err)
+	}
+	egressWatcher, err := w.config.Facade.WatchEgressRules(w.config.MachineTag)
+	if err != nil {
+		return errors.Annotate(err, "cannot watch egress rules")
+	}
+	if err := w.catacomb.Add(egressWatcher); err != nil {
+		return errors.Trace(err)
+	}
+	addressesWatcher, err := w.config.Facade.WatchModelMachineAddresses()
+	if err != nil {
+		return errors.Annotate(err, "cannot watch model machine addresses")
+	}
+	if err := w.catacomb.Add(addressesWatcher); err != nil {
+		return errors.Trace(err)
+	}
+
+	w.mode = config.HostFwNone
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-configWatcher.Changes():
+			if !ok {
+				return errors.New("model config watch closed")
+			}
+		case _, ok := <-portsWatcher.Changes():
+			if !ok {
+				return errors.New("opened ports watch closed")
+			}
+		case _, ok := <-egressWatcher.Changes():
+			if !ok {
+				return errors.New("egress rules watch closed")
+			}
+		case _, ok := <-addressesWatcher.Changes():
+			if !ok {
+				return errors.New("model machine addresses watch closed")
+			}
+		}
+		if err := w.update(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+}
+
+// update applies the firewall rules required by the current model
+// config, opened ports, machine addresses and egress rules, if they
+// differ from those last applied.
+func (w *Worker) update() error {
+	modelConfig, err := w.config.Facade.ModelConfig()
+	if err != nil {
+		return errors.Annotate(err, "cannot read model config")
+	}
+	mode := modelConfig.HostFirewallMode()
+	var rules []network.PortRange
+	var sources []string
+	var egress []network.EgressRule
+	if mode != config.HostFwNone {
+		opened, err := w.config.Facade.OpenedPorts(w.config.MachineTag)
+		if err != nil {
+			return errors.Annotate(err, "cannot get opened ports")
+		}
+		rules = allowedPortRanges(w.config.AlwaysOpen, opened)
+		// Units on the model's other machines must be able to
+		// reach the machine, whether or not they have opened
+		// ports, for relations to work.
+		addresses, err := w.config.Facade.ModelMachineAddresses()
+		if err != nil {
+			return errors.Annotate(err, "cannot get model machine addresses")
+		}
+		sources = set.NewStrings(addresses...).SortedValues()
+		egressRules, err := w.config.Facade.EgressRules(w.config.MachineTag)
+		if err != nil {
+			return errors.Annotate(err, "cannot get egress rules")
+		}
+		if len(egressRules) > 0 {
+			egress = allowedEgressRules(w.config.AlwaysAllowedEgress, egressRules)
+		}
+	}
+	if mode == w.mode && portRangesEqual(rules, w.rules) &&
+		stringsEqual(sources, w.sources) && egressRulesEqual(egress, w.egress) {
+		return nil
+	}
+
+	if mode != w.mode && w.mode != config.HostFwNone {
+		if err := w.removeEgress(); err != nil {
+			return errors.Trace(err)
+		}
+		logger.Infof("removing %s rules", w.mode)
+		if err := w.backend(w.mode).remove(); err != nil {
+			return errors.Annotatef(err, "removing %s rules", w.mode)
+		}
+		w.mode, w.rules, w.sources = config.HostFwNone, nil, nil
+	}
+	if mode == config.HostFwNone {
+		return nil
+	}
+	logger.Infof("allowing incoming traffic to %v, and from %v, with %s", rules, sources, mode)
+	if len(egress) > 0 {
+		logger.Infof("allowing outgoing traffic to %v with %s", egress, mode)
+	}
+	if err := w.backend(mode).apply(rules, sources, egress); err != nil {
+		return errors.Annotatef(err, "applying %s rules", mode)
+	}
+	w.mode, w.rules, w.sources = mode, rules, sources
+	if len(egress) == 0 {
+		if err := w.removeEgress(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	w.egress = egress
+	return nil
+}
+
+// removeEgress removes the egress rules last applied, if any.
+func (w *Worker) removeEgress() error {
+	if len(w.egress) == 0 {
+		return nil
+	}
+	logger.Infof("removing %s egress rules", w.mode)
+	if err := w.backend(w.mode).removeEgress(); err != nil {
+		return errors.Annotatef(err, "removing %s egress rules", w.mode)
+	}
+	w.egress = nil
+	return nil
+}
+
+// backend returns the firewallBackend for the given mode.
+func (w *Worker) backend(mode string) firewallBackend {
+	switch mode {
+	case config.HostFwNFTables:
+		return &nftablesBackend{w.config.RunCommand, w.config.RulesDir}
+	default:
+		return &iptablesBackend{w.config.RunCommand, w.config.RulesDir}
+	}
+}
+
+// allowedPortRanges returns the sorted, de-duplicated union of the
+// given port ranges.
+func allowedPortRanges(alwaysOpen, opened []network.PortRange) []network.PortRange {
+	seen := make(map[network.PortRange]bool)
+	var result []network.PortRange
+	for _, portRange := range append(append([]network.PortRange{}, alwaysOpen...), opened...) {
+		if seen[portRange] {
+			continue
+		}
+		seen[portRange] = true
+		result = append(result, portRange)
+	}
+	network.SortPortRanges(result)
+	return result
+}
+
+// allowedEgressRules returns the sorted, de-duplicated union of the
+// given egress rules.
+func allowedEgressRules(alwaysAllowed, egressRules []network.EgressRule) []network.EgressRule {
+	seen := make(map[network.EgressRule]bool)
+	var result []network.EgressRule
+	for _, rule := range append(append([]network.EgressRule{}, alwaysAllowed...), egressRules...) {
+		if seen[rule] {
+			continue
+		}
+		seen[rule] = true
+		result = append(result, rule)
+	}
+	network.SortEgressRules(result)
+	return result
+}
+
+func egressRulesEqual(a, b []network.EgressRule) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func stringsEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func portRangesEqual(a, b []network.PortRange) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
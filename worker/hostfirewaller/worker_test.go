@@ -0,0 +1,628 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hostfirewaller_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/tomb.v1"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/network"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker/hostfirewaller"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type WorkerSuite struct {
+	jujutesting.IsolationSuite
+
+	dir    string
+	facade *stubFacade
+	runner *stubRunner
+	config hostfirewaller.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.dir = c.MkDir()
+	s.facade = newStubFacade(c)
+	s.runner = newStubRunner()
+	s.config = hostfirewaller.Config{
+		Facade:     s.facade,
+		MachineTag: names.NewMachineTag("0"),
+		AlwaysOpen: []network.PortRange{{FromPort: 22, ToPort: 22, Protocol: "tcp"}},
+		RulesDir:   s.dir,
+		RunCommand: s.runner.run,
+
+		AlwaysAllowedEgress: []network.EgressRule{
+			network.MustNewEgressRule("udp", 53, 53, "0.0.0.0/0"),
+			network.MustNewEgressRule("udp", 53, 53, "::/0"),
+		},
+	}
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) *hostfirewaller.Worker {
+	w, err := hostfirewaller.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.CleanKill(c, w) })
+	return w
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	s.config.RunCommand = nil
+	_, err := hostfirewaller.New(s.config)
+	c.Assert(err, gc.ErrorMatches, "nil RunCommand not valid")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *WorkerSuite) TestModeNone(c *gc.C) {
+	s.startWorker(c)
+	s.facade.configChanged()
+	s.facade.portsChanged()
+	s.runner.assertNoCommands(c)
+}
+
+func (s *WorkerSuite) TestIPTables(c *gc.C) {
+	s.facade.setMode(config.HostFwIPTables)
+	s.facade.setPorts(
+		network.PortRange{FromPort: 8000, ToPort: 8080, Protocol: "udp"},
+		network.PortRange{FromPort: 80, ToPort: 80, Protocol: "tcp"},
+	)
+	s.startWorker(c)
+	s.facade.configChanged()
+
+	s.runner.assertCommands(c,
+		"iptables-restore --noflush "+filepath.Join(s.dir, "iptables.rules"),
+		"iptables -C INPUT -j juju-host-firewall",
+		"iptables -I INPUT -j juju-host-firewall",
+		"ip6tables-restore --noflush "+filepath.Join(s.dir, "ip6tables.rules"),
+		"ip6tables -C INPUT -j juju-host-firewall",
+		"ip6tables -I INPUT -j juju-host-firewall",
+	)
+	s.assertRules(c, "iptables.rules", `
+*filter
+:juju-host-firewall - [0:0]
+-A juju-host-firewall -i lo -j ACCEPT
+-A juju-host-firewall -m conntrack --ctstate RELATED,ESTABLISHED -j ACCEPT
+-A juju-host-firewall -p icmp -j ACCEPT
+-A juju-host-firewall -i lxdbr0 -j ACCEPT
+-A juju-host-firewall -i virbr0 -j ACCEPT
+-A juju-host-firewall -p tcp -m tcp --dport 22 -j ACCEPT
+-A juju-host-firewall -p tcp -m tcp --dport 80 -j ACCEPT
+-A juju-host-firewall -p udp -m udp --dport 8000:8080 -j ACCEPT
+-A juju-host-firewall -j DROP
+COMMIT
+`[1:])
+	c.Assert(s.readRules(c, "ip6tables.rules"), jc.Contains, "-A juju-host-firewall -p ipv6-icmp -j ACCEPT\n")
+
+	// Once the chain is jumped to from INPUT, only the
+	// chain's contents are replaced.
+	s.runner.setError("iptables -C INPUT -j juju-host-firewall", nil)
+	s.runner.setError("ip6tables -C INPUT -j juju-host-firewall", nil)
+	s.facade.setPorts()
+	s.facade.portsChanged()
+	s.runner.assertCommands(c,
+		"iptables-restore --noflush "+filepath.Join(s.dir, "iptables.rules"),
+		"iptables -C INPUT -j juju-host-firewall",
+		"ip6tables-restore --noflush "+filepath.Join(s.dir, "ip6tables.rules"),
+		"ip6tables -C INPUT -j juju-host-firewall",
+	)
+	c.Assert(s.readRules(c, "iptables.rules"), gc.Not(jc.Contains), "--dport 80 ")
+}
+
+func (s *WorkerSuite) TestNFTables(c *gc.C) {
+	s.facade.setMode(config.HostFwNFTables)
+	s.facade.setPorts(
+		network.PortRange{FromPort: 8000, ToPort: 8080, Protocol: "udp"},
+		network.PortRange{FromPort: 80, ToPort: 80, Protocol: "tcp"},
+	)
+	s.startWorker(c)
+	s.facade.configChanged()
+
+	s.runner.assertCommands(c, "nft -f "+filepath.Join(s.dir, "nftables.rules"))
+	s.assertRules(c, "nftables.rules", `
+table inet juju
+delete table inet juju
+table inet juju {
+	chain input {
+		type filter hook input priority 0; policy drop;
+		iif lo accept
+		ct state established,related accept
+		ip protocol icmp accept
+		ip6 nexthdr ipv6-icmp accept
+		iifname "lxdbr0" accept
+		iifname "virbr0" accept
+		tcp dport 22 accept
+		tcp dport 80 accept
+		udp dport 8000-8080 accept
+	}
+}
+`[1:])
+}
+
+func (s *WorkerSuite) TestIPTablesModelMachineAddresses(c *gc.C) {
+	s.facade.setMode(config.HostFwIPTables)
+	s.facade.setAddresses("10.0.0.2", "2001:db8::1", "10.0.0.1")
+	s.startWorker(c)
+	s.facade.configChanged()
+
+	s.runner.assertCommands(c,
+		"iptables-restore --noflush "+filepath.Join(s.dir, "iptables.rules"),
+		"iptables -C INPUT -j juju-host-firewall",
+		"iptables -I INPUT -j juju-host-firewall",
+		"ip6tables-restore --noflush "+filepath.Join(s.dir, "ip6tables.rules"),
+		"ip6tables -C INPUT -j juju-host-firewall",
+		"ip6tables -I INPUT -j juju-host-firewall",
+	)
+	s.assertRules(c, "iptables.rules", `
+*filter
+:juju-host-firewall - [0:0]
+-A juju-host-firewall -i lo -j ACCEPT
+-A juju-host-firewall -m conntrack --ctstate RELATED,ESTABLISHED -j ACCEPT
+-A juju-host-firewall -p icmp -j ACCEPT
+-A juju-host-firewall -i lxdbr0 -j ACCEPT
+-A juju-host-firewall -i virbr0 -j ACCEPT
+-A juju-host-firewall -s 10.0.0.1 -j ACCEPT
+-A juju-host-firewall -s 10.0.0.2 -j ACCEPT
+-A juju-host-firewall -p tcp -m tcp --dport 22 -j ACCEPT
+-A juju-host-firewall -j DROP
+COMMIT
+`[1:])
+	ip6rules := s.readRules(c, "ip6tables.rules")
+	c.Assert(ip6rules, jc.Contains, "-A juju-host-firewall -s 2001:db8::1 -j ACCEPT\n")
+	c.Assert(ip6rules, gc.Not(jc.Contains), "10.0.0.1")
+
+	// When a machine's addresses change, the rules are
+	// reapplied.
+	s.runner.setError("iptables -C INPUT -j juju-host-firewall", nil)
+	s.runner.setError("ip6tables -C INPUT -j juju-host-firewall", nil)
+	s.facade.setAddresses("10.0.0.1")
+	s.facade.addressesChanged()
+	s.runner.assertCommands(c,
+		"iptables-restore --noflush "+filepath.Join(s.dir, "iptables.rules"),
+		"iptables -C INPUT -j juju-host-firewall",
+		"ip6tables-restore --noflush "+filepath.Join(s.dir, "ip6tables.rules"),
+		"ip6tables -C INPUT -j juju-host-firewall",
+	)
+	c.Assert(s.readRules(c, "iptables.rules"), gc.Not(jc.Contains), "10.0.0.2")
+}
+
+func (s *WorkerSuite) TestNFTablesModelMachineAddresses(c *gc.C) {
+	s.facade.setMode(config.HostFwNFTables)
+	s.facade.setAddresses("10.0.0.1", "2001:db8::1")
+	s.startWorker(c)
+	s.facade.configChanged()
+
+	s.runner.assertCommands(c, "nft -f "+filepath.Join(s.dir, "nftables.rules"))
+	s.assertRules(c, "nftables.rules", `
+table inet juju
+delete table inet juju
+table inet juju {
+	chain input {
+		type filter hook input priority 0; policy drop;
+		iif lo accept
+		ct state established,related accept
+		ip protocol icmp accept
+		ip6 nexthdr ipv6-icmp accept
+		iifname "lxdbr0" accept
+		iifname "virbr0" accept
+		ip saddr 10.0.0.1 accept
+		ip6 saddr 2001:db8::1 accept
+		tcp dport 22 accept
+	}
+}
+`[1:])
+
+	// Unchanged addresses are not reapplied.
+	s.facade.addressesChanged()
+	s.runner.assertNoCommands(c)
+}
+
+func (s *WorkerSuite) TestIPTablesEgress(c *gc.C) {
+	s.facade.setMode(config.HostFwIPTables)
+	s.facade.setEgress(
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+		network.MustNewEgressRule("tcp", 5432, 5432, "2001:db8::/32"),
+	)
+	s.startWorker(c)
+	s.facade.configChanged()
+
+	s.runner.assertCommands(c,
+		"iptables-restore --noflush "+filepath.Join(s.dir, "iptables.rules"),
+		"iptables -C INPUT -j juju-host-firewall",
+		"iptables -I INPUT -j juju-host-firewall",
+		"iptables -C OUTPUT -j juju-host-egress",
+		"iptables -I OUTPUT -j juju-host-egress",
+		"ip6tables-restore --noflush "+filepath.Join(s.dir, "ip6tables.rules"),
+		"ip6tables -C INPUT -j juju-host-firewall",
+		"ip6tables -I INPUT -j juju-host-firewall",
+		"ip6tables -C OUTPUT -j juju-host-egress",
+		"ip6tables -I OUTPUT -j juju-host-egress",
+	)
+	s.assertRules(c, "iptables.rules", `
+*filter
+:juju-host-firewall - [0:0]
+:juju-host-egress - [0:0]
+-A juju-host-firewall -i lo -j ACCEPT
+-A juju-host-firewall -m conntrack --ctstate RELATED,ESTABLISHED -j ACCEPT
+-A juju-host-firewall -p icmp -j ACCEPT
+-A juju-host-firewall -i lxdbr0 -j ACCEPT
+-A juju-host-firewall -i virbr0 -j ACCEPT
+-A juju-host-firewall -p tcp -m tcp --dport 22 -j ACCEPT
+-A juju-host-firewall -j DROP
+-A juju-host-egress -o lo -j ACCEPT
+-A juju-host-egress -m conntrack --ctstate RELATED,ESTABLISHED -j ACCEPT
+-A juju-host-egress -p icmp -j ACCEPT
+-A juju-host-egress -o lxdbr0 -j ACCEPT
+-A juju-host-egress -o virbr0 -j ACCEPT
+-A juju-host-egress -d 0.0.0.0/0 -p udp -m udp --dport 53 -j ACCEPT
+-A juju-host-egress -d 10.0.0.0/8 -p tcp -m tcp --dport 443 -j ACCEPT
+-A juju-host-egress -j DROP
+COMMIT
+`[1:])
+	ip6rules := s.readRules(c, "ip6tables.rules")
+	c.Assert(ip6rules, jc.Contains, "-A juju-host-egress -d ::/0 -p udp -m udp --dport 53 -j ACCEPT\n")
+	c.Assert(ip6rules, jc.Contains, "-A juju-host-egress -d 2001:db8::/32 -p tcp -m tcp --dport 5432 -j ACCEPT\n")
+	c.Assert(ip6rules, gc.Not(jc.Contains), "10.0.0.0/8")
+
+	// When egress is no longer restricted, the egress
+	// chain is removed.
+	s.runner.setError("iptables -C INPUT -j juju-host-firewall", nil)
+	s.runner.setError("ip6tables -C INPUT -j juju-host-firewall", nil)
+	s.runner.setError("iptables -C OUTPUT -j juju-host-egress", nil)
+	s.runner.setError("ip6tables -C OUTPUT -j juju-host-egress", nil)
+	s.facade.setEgress()
+	s.facade.egressChanged()
+	s.runner.assertCommands(c,
+		"iptables-restore --noflush "+filepath.Join(s.dir, "iptables.rules"),
+		"iptables -C INPUT -j juju-host-firewall",
+		"ip6tables-restore --noflush "+filepath.Join(s.dir, "ip6tables.rules"),
+		"ip6tables -C INPUT -j juju-host-firewall",
+		"iptables -C OUTPUT -j juju-host-egress",
+		"iptables -D OUTPUT -j juju-host-egress",
+		"iptables -F juju-host-egress",
+		"iptables -X juju-host-egress",
+		"ip6tables -C OUTPUT -j juju-host-egress",
+		"ip6tables -D OUTPUT -j juju-host-egress",
+		"ip6tables -F juju-host-egress",
+		"ip6tables -X juju-host-egress",
+	)
+	c.Assert(s.readRules(c, "iptables.rules"), gc.Not(jc.Contains), "juju-host-egress")
+}
+
+func (s *WorkerSuite) TestNFTablesEgress(c *gc.C) {
+	s.facade.setMode(config.HostFwNFTables)
+	s.facade.setEgress(
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+		network.MustNewEgressRule("udp", 8000, 8080, "2001:db8::/32"),
+	)
+	s.startWorker(c)
+	s.facade.configChanged()
+
+	s.runner.assertCommands(c, "nft -f "+filepath.Join(s.dir, "nftables.rules"))
+	s.assertRules(c, "nftables.rules", `
+table inet juju
+delete table inet juju
+table inet juju {
+	chain input {
+		type filter hook input priority 0; policy drop;
+		iif lo accept
+		ct state established,related accept
+		ip protocol icmp accept
+		ip6 nexthdr ipv6-icmp accept
+		iifname "lxdbr0" accept
+		iifname "virbr0" accept
+		tcp dport 22 accept
+	}
+	chain output {
+		type filter hook output priority 0; policy drop;
+		oif lo accept
+		ct state established,related accept
+		ip protocol icmp accept
+		ip6 nexthdr ipv6-icmp accept
+		oifname "lxdbr0" accept
+		oifname "virbr0" accept
+		ip daddr 0.0.0.0/0 udp dport 53 accept
+		ip daddr 10.0.0.0/8 tcp dport 443 accept
+		ip6 daddr 2001:db8::/32 udp dport 8000-8080 accept
+		ip6 daddr ::/0 udp dport 53 accept
+	}
+}
+`[1:])
+
+	// When egress is no longer restricted, the table is
+	// replaced without the output chain.
+	s.facade.setEgress()
+	s.facade.egressChanged()
+	s.runner.assertCommands(c, "nft -f "+filepath.Join(s.dir, "nftables.rules"))
+	c.Assert(s.readRules(c, "nftables.rules"), gc.Not(jc.Contains), "chain output")
+}
+
+func (s *WorkerSuite) TestEgressNotRestrictedInModeNone(c *gc.C) {
+	s.facade.setEgress(network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"))
+	s.startWorker(c)
+	s.facade.egressChanged()
+	s.runner.assertNoCommands(c)
+}
+
+func (s *WorkerSuite) TestUnchangedRulesNotReapplied(c *gc.C) {
+	s.facade.setMode(config.HostFwNFTables)
+	s.startWorker(c)
+	s.facade.configChanged()
+	s.runner.assertCommands(c, "nft -f "+filepath.Join(s.dir, "nftables.rules"))
+
+	s.facade.portsChanged()
+	s.facade.configChanged()
+	s.runner.assertNoCommands(c)
+}
+
+func (s *WorkerSuite) TestModeChangeRemovesRules(c *gc.C) {
+	s.facade.setMode(config.HostFwIPTables)
+	s.startWorker(c)
+	s.facade.configChanged()
+	s.runner.assertCommands(c,
+		"iptables-restore --noflush "+filepath.Join(s.dir, "iptables.rules"),
+		"iptables -C INPUT -j juju-host-firewall",
+		"iptables -I INPUT -j juju-host-firewall",
+		"ip6tables-restore --noflush "+filepath.Join(s.dir, "ip6tables.rules"),
+		"ip6tables -C INPUT -j juju-host-firewall",
+		"ip6tables -I INPUT -j juju-host-firewall",
+	)
+
+	s.runner.setError("iptables -C INPUT -j juju-host-firewall", nil)
+	s.facade.setMode(config.HostFwNFTables)
+	s.facade.configChanged()
+	s.runner.assertCommands(c,
+		"iptables -C INPUT -j juju-host-firewall",
+		"iptables -D INPUT -j juju-host-firewall",
+		"iptables -F juju-host-firewall",
+		"iptables -X juju-host-firewall",
+		"ip6tables -C INPUT -j juju-host-firewall",
+		"ip6tables -F juju-host-firewall",
+		"ip6tables -X juju-host-firewall",
+		"nft -f "+filepath.Join(s.dir, "nftables.rules"),
+	)
+
+	s.facade.setMode(config.HostFwNone)
+	s.facade.configChanged()
+	s.runner.assertCommands(c, "nft -f "+filepath.Join(s.dir, "nftables.rules"))
+	s.assertRules(c, "nftables.rules", "table inet juju\ndelete table inet juju\n")
+}
+
+func (s *WorkerSuite) TestApplyError(c *gc.C) {
+	s.facade.setMode(config.HostFwNFTables)
+	s.runner.setError("nft -f "+filepath.Join(s.dir, "nftables.rules"), errors.New("nft: not found"))
+	w, err := hostfirewaller.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, w)
+	s.facade.configChanged()
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "applying nftables rules: loading .*: nft: not found")
+}
+
+func (s *WorkerSuite) readRules(c *gc.C, name string) string {
+	data, err := ioutil.ReadFile(filepath.Join(s.dir, name))
+	c.Assert(err, jc.ErrorIsNil)
+	return string(data)
+}
+
+func (s *WorkerSuite) assertRules(c *gc.C, name, expect string) {
+	c.Assert(s.readRules(c, name), gc.Equals, expect)
+}
+
+type stubFacade struct {
+	c *gc.C
+
+	mu          sync.Mutex
+	mode        string
+	portRanges  []network.PortRange
+	addresses   []string
+	egressRules []network.EgressRule
+
+	configWatcher    *mockNotifyWatcher
+	portsWatcher     *mockNotifyWatcher
+	egressWatcher    *mockNotifyWatcher
+	addressesWatcher *mockNotifyWatcher
+}
+
+func newStubFacade(c *gc.C) *stubFacade {
+	return &stubFacade{
+		c:                c,
+		mode:             config.HostFwNone,
+		configWatcher:    newMockNotifyWatcher(),
+		portsWatcher:     newMockNotifyWatcher(),
+		egressWatcher:    newMockNotifyWatcher(),
+		addressesWatcher: newMockNotifyWatcher(),
+	}
+}
+
+func (f *stubFacade) setMode(mode string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.mode = mode
+}
+
+func (f *stubFacade) setPorts(portRanges ...network.PortRange) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.portRanges = portRanges
+}
+
+func (f *stubFacade) setAddresses(addresses ...string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.addresses = addresses
+}
+
+func (f *stubFacade) setEgress(rules ...network.EgressRule) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.egressRules = rules
+}
+
+func (f *stubFacade) configChanged() {
+	f.configWatcher.change(f.c)
+}
+
+func (f *stubFacade) portsChanged() {
+	f.portsWatcher.change(f.c)
+}
+
+func (f *stubFacade) egressChanged() {
+	f.egressWatcher.change(f.c)
+}
+
+func (f *stubFacade) addressesChanged() {
+	f.addressesWatcher.change(f.c)
+}
+
+func (f *stubFacade) ModelConfig() (*config.Config, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return coretesting.CustomModelConfig(f.c, coretesting.Attrs{
+		"host-firewall-mode": f.mode,
+	}), nil
+}
+
+func (f *stubFacade) WatchForModelConfigChanges() (watcher.NotifyWatcher, error) {
+	return f.configWatcher, nil
+}
+
+func (f *stubFacade) OpenedPorts(tag names.MachineTag) ([]network.PortRange, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.c.Check(tag, gc.Equals, names.NewMachineTag("0"))
+	return f.portRanges, nil
+}
+
+func (f *stubFacade) WatchOpenedPorts(tag names.MachineTag) (watcher.NotifyWatcher, error) {
+	f.c.Check(tag, gc.Equals, names.NewMachineTag("0"))
+	return f.portsWatcher, nil
+}
+
+func (f *stubFacade) EgressRules(tag names.MachineTag) ([]network.EgressRule, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.c.Check(tag, gc.Equals, names.NewMachineTag("0"))
+	return f.egressRules, nil
+}
+
+func (f *stubFacade) WatchEgressRules(tag names.MachineTag) (watcher.NotifyWatcher, error) {
+	f.c.Check(tag, gc.Equals, names.NewMachineTag("0"))
+	return f.egressWatcher, nil
+}
+
+func (f *stubFacade) ModelMachineAddresses() ([]string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.addresses, nil
+}
+
+func (f *stubFacade) WatchModelMachineAddresses() (watcher.NotifyWatcher, error) {
+	return f.addressesWatcher, nil
+}
+
+type mockNotifyWatcher struct {
+	tomb    tomb.Tomb
+	changes chan struct{}
+}
+
+func newMockNotifyWatcher() *mockNotifyWatcher {
+	w := &mockNotifyWatcher{changes: make(chan struct{})}
+	go func() {
+		defer w.tomb.Done()
+		<-w.tomb.Dying()
+	}()
+	return w
+}
+
+func (w *mockNotifyWatcher) Kill() {
+	w.tomb.Kill(nil)
+}
+
+func (w *mockNotifyWatcher) Wait() error {
+	return w.tomb.Wait()
+}
+
+func (w *mockNotifyWatcher) Changes() watcher.NotifyChannel {
+	return w.changes
+}
+
+func (w *mockNotifyWatcher) change(c *gc.C) {
+	select {
+	case w.changes <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out sending change")
+	}
+}
+
+// stubRunner records the commands it is asked to run. The iptables
+// -C commands fail unless told otherwise, as they do when the chains
+// are not jumped to from INPUT and OUTPUT.
+type stubRunner struct {
+	mu       sync.Mutex
+	errors   map[string]error
+	commands chan string
+}
+
+func newStubRunner() *stubRunner {
+	return &stubRunner{
+		errors: map[string]error{
+			"iptables -C INPUT -j juju-host-firewall":  errors.New("Bad rule"),
+			"ip6tables -C INPUT -j juju-host-firewall": errors.New("Bad rule"),
+			"iptables -C OUTPUT -j juju-host-egress":   errors.New("Bad rule"),
+			"ip6tables -C OUTPUT -j juju-host-egress":  errors.New("Bad rule"),
+		},
+		commands: make(chan string, 100),
+	}
+}
+
+func (r *stubRunner) setError(command string, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.errors[command] = err
+}
+
+func (r *stubRunner) run(cmd string, args ...string) (string, error) {
+	command := strings.Join(append([]string{cmd}, args...), " ")
+	r.commands <- command
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return "", r.errors[command]
+}
+
+func (r *stubRunner) assertCommands(c *gc.C, expect ...string) {
+	var commands []string
+	for range expect {
+		select {
+		case command := <-r.commands:
+			commands = append(commands, command)
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for commands; got %q", commands)
+		}
+	}
+	c.Assert(commands, jc.DeepEquals, expect)
+	r.assertNoCommands(c)
+}
+
+func (r *stubRunner) assertNoCommands(c *gc.C) {
+	select {
+	case command := <-r.commands:
+		c.Fatalf("unexpected command %q", command)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
@@ -83,6 +83,12 @@ func (ctx *limitedContext) ResetExecutionSetUnitStatus() {}
 // Id implements runner.Context.
 func (ctx *limitedContext) Id() string { return ctx.id }
 
+// HookTimeout implements runner.Context.
+func (ctx *limitedContext) HookTimeout() time.Duration { return 0 }
+
+// RecordFailedHooks implements runner.Context.
+func (ctx *limitedContext) RecordFailedHooks() bool { return false }
+
 // Prepare implements runner.Context.
 func (ctx *limitedContext) Prepare() error {
 	return jujuc.ErrRestrictedContext
@@ -85,6 +85,12 @@ func (ctx *hookContext) ResetExecutionSetUnitStatus() {}
 // Id implements runner.Context.
 func (ctx *hookContext) Id() string { return ctx.id }
 
+// HookTimeout implements runner.Context.
+func (ctx *hookContext) HookTimeout() time.Duration { return 0 }
+
+// RecordFailedHooks implements runner.Context.
+func (ctx *hookContext) RecordFailedHooks() bool { return false }
+
 // Prepare implements runner.Context.
 func (ctx *hookContext) Prepare() error {
 	return jujuc.ErrRestrictedContext
@@ -53,6 +53,7 @@ func (*dummyPaths) GetToolsDir() string             { return "/dummy/tools" }
 func (*dummyPaths) GetCharmDir() string             { return "/dummy/charm" }
 func (*dummyPaths) GetJujucSocket() string          { return "/dummy/jujuc.sock" }
 func (*dummyPaths) GetMetricsSpoolDir() string      { return "/dummy/spool" }
+func (*dummyPaths) GetHookRecordingsDir() string    { return "/dummy/hook-recordings" }
 func (*dummyPaths) ComponentDir(name string) string { return "/dummy/" + name }
 
 func (s *ContextSuite) TestHookContextEnv(c *gc.C) {
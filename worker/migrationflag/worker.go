@@ -31,6 +31,13 @@ func IsTerminal(phase migration.Phase) bool {
 	return phase.IsTerminal()
 }
 
+// IsTerminalOrQueued returns true when the given phase means a
+// migration has finished, or is still waiting for its turn to start
+// and so doesn't yet affect the model.
+func IsTerminalOrQueued(phase migration.Phase) bool {
+	return phase.IsTerminal() || phase == migration.QUEUED
+}
+
 // Config holds the dependencies and configuration for a Worker.
 type Config struct {
 	Facade Facade
@@ -131,6 +131,23 @@ func (*WorkerSuite) TestNoRelevantPhaseChange(c *gc.C) {
 	checkCalls(c, stub, "Phase", "Watch", "Phase", "Phase", "Phase")
 }
 
+func (*WorkerSuite) TestIsTerminalOrQueued(c *gc.C) {
+	tests := []struct {
+		phase    migration.Phase
+		expected bool
+	}{
+		{migration.QUEUED, true},
+		{migration.QUIESCE, false},
+		{migration.ABORT, false},
+		{migration.NONE, true},
+		{migration.DONE, true},
+	}
+	for _, t := range tests {
+		c.Check(migrationflag.IsTerminalOrQueued(t.phase), gc.Equals, t.expected,
+			gc.Commentf("for %s", t.phase))
+	}
+}
+
 func (*WorkerSuite) TestIsTerminal(c *gc.C) {
 	tests := []struct {
 		phase    migration.Phase
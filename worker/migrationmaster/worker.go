@@ -58,6 +58,15 @@ const (
 	// reports from minions and while it's transferring log messages
 	// to the newly-migrated model.
 	progressUpdateInterval = 30 * time.Second
+
+	// binariesProgressInterval is the minimum time between reports
+	// of how many bytes of binaries have been sent to the target
+	// controller.
+	binariesProgressInterval = 5 * time.Second
+
+	// queuePollInterval is the time between checks of a queued
+	// migration's position in the controller's queue of migrations.
+	queuePollInterval = 15 * time.Second
 )
 
 // Facade exposes controller functionality to a Worker.
@@ -78,6 +87,14 @@ type Facade interface {
 	// progress of a migration.
 	SetStatusMessage(string) error
 
+	// SetProgress records structured details about how far the
+	// migration has got through its current phase.
+	SetProgress(coremigration.Progress) error
+
+	// QueuePosition reports where the migration is in the
+	// controller's queue of migrations.
+	QueuePosition() (coremigration.QueuePosition, error)
+
 	// Prechecks performs pre-migration checks on the model and
 	// (source) controller.
 	Prechecks() error
@@ -203,6 +220,19 @@ func (w *Worker) run() error {
 		return errors.Trace(err)
 	}
 
+	// A queued migration leaves the model alone until it's allowed
+	// to start, so wait before locking the model down.
+	if status.Phase == coremigration.QUEUED {
+		if err := w.waitForQueue(); err != nil {
+			return errors.Trace(err)
+		}
+		w.logger.Infof("setting migration phase to %s", coremigration.QUIESCE)
+		if err := w.config.Facade.SetPhase(coremigration.QUIESCE); err != nil {
+			return errors.Annotate(err, "failed to set phase")
+		}
+		status.Phase = coremigration.QUIESCE
+	}
+
 	err = w.config.Guard.Lockdown(w.catacomb.Dying())
 	if errors.Cause(err) == fortress.ErrAborted {
 		return w.catacomb.ErrDying()
@@ -223,7 +253,7 @@ func (w *Worker) run() error {
 		case coremigration.QUIESCE:
 			phase, err = w.doQUIESCE(status)
 		case coremigration.IMPORT:
-			phase, err = w.doIMPORT(status.TargetInfo, status.ModelUUID)
+			phase, err = w.doIMPORT(status.TargetInfo, status.ModelUUID, status.CloudRemap)
 		case coremigration.VALIDATION:
 			phase, err = w.doVALIDATION(status)
 		case coremigration.SUCCESS:
@@ -295,6 +325,34 @@ func (w *Worker) setStatusAndLog(log func(string, ...interface{}), s string, a .
 	}
 }
 
+// reportProgress records structured progress for the migration.
+// Progress is only informational so failures are logged rather than
+// stopping the migration.
+func (w *Worker) reportProgress(progress coremigration.Progress) {
+	if err := w.config.Facade.SetProgress(progress); err != nil {
+		w.logger.Debugf("failed to report migration progress: %v", err)
+	}
+}
+
+// binariesProgressReporter returns a function for
+// UploadBinariesConfig.Progress which reports progress at most once
+// every binariesProgressInterval, as well as when the upload starts
+// and completes.
+func (w *Worker) binariesProgressReporter() func(uploaded, total int64) {
+	var lastReport time.Time
+	return func(uploaded, total int64) {
+		now := w.config.Clock.Now()
+		if uploaded > 0 && uploaded < total && now.Sub(lastReport) < binariesProgressInterval {
+			return
+		}
+		lastReport = now
+		w.reportProgress(coremigration.Progress{
+			BinariesUploaded: uploaded,
+			BinariesTotal:    total,
+		})
+	}
+}
+
 func (w *Worker) setStatus(message string) error {
 	err := w.config.Facade.SetStatusMessage(message)
 	return errors.Annotate(err, "failed to set status message")
@@ -349,13 +407,55 @@ func (w *Worker) prechecks(status coremigration.MigrationStatus) error {
 			conn.ControllerTag(), status.TargetInfo.ControllerTag)
 	}
 
+	cloudRemap := status.CloudRemap
+	if !cloudRemap.IsEmpty() {
+		if conn.BestFacadeVersion("MigrationTarget") < 3 {
+			return errors.New("target controller does not support cloud remapping")
+		}
+		if err := cloudRemap.CheckCredential(model.CloudCredential); err != nil {
+			return errors.Annotate(err, "target prechecks failed")
+		}
+	}
+
 	targetClient := migrationtarget.NewClient(conn)
-	err = targetClient.Prechecks(model)
-	return errors.Annotate(err, "target prechecks failed")
+	err = targetClient.Prechecks(cloudRemap.Apply(model))
+	if err != nil {
+		return errors.Annotate(err, "target prechecks failed")
+	}
+	if cloudRemap.IsEmpty() {
+		return nil
+	}
+	return errors.Trace(w.checkRemappedMachines(targetClient, cloudRemap))
+}
+
+// checkRemappedMachines checks that the target controller can find the
+// model's machines using the remapped cloud details, so that a model
+// that would lose track of its instances is never imported.
+func (w *Worker) checkRemappedMachines(targetClient *migrationtarget.Client, cloudRemap coremigration.CloudRemap) error {
+	w.setInfoStatus("checking model machines on target controller")
+	serialized, err := w.config.Facade.Export()
+	if err != nil {
+		return errors.Annotate(err, "model export failed")
+	}
+	modelBytes, err := migration.RemapCloud(serialized.Bytes, cloudRemap)
+	if err != nil {
+		return errors.Annotate(err, "remapping model cloud")
+	}
+	problems, err := targetClient.CheckMachines(modelBytes)
+	if err != nil {
+		return errors.Annotate(err, "failed to check machines")
+	}
+	for _, problem := range problems {
+		w.logger.Errorf("machine check failed: %v", problem)
+	}
+	if len(problems) > 0 {
+		return errors.Errorf("%d machine(s) not found with remapped cloud details", len(problems))
+	}
+	return nil
 }
 
-func (w *Worker) doIMPORT(targetInfo coremigration.TargetInfo, modelUUID string) (coremigration.Phase, error) {
-	err := w.transferModel(targetInfo, modelUUID)
+func (w *Worker) doIMPORT(targetInfo coremigration.TargetInfo, modelUUID string, cloudRemap coremigration.CloudRemap) (coremigration.Phase, error) {
+	err := w.transferModel(targetInfo, modelUUID, cloudRemap)
 	if err != nil {
 		w.setErrorStatus("model data transfer failed, %v", err)
 		return coremigration.ABORT, nil
@@ -393,12 +493,18 @@ func (w *uploadWrapper) SetUnitResource(unitName string, res resource.Resource)
 	return w.client.SetUnitResource(w.modelUUID, unitName, res)
 }
 
-func (w *Worker) transferModel(targetInfo coremigration.TargetInfo, modelUUID string) error {
+func (w *Worker) transferModel(targetInfo coremigration.TargetInfo, modelUUID string, cloudRemap coremigration.CloudRemap) error {
 	w.setInfoStatus("exporting model")
 	serialized, err := w.config.Facade.Export()
 	if err != nil {
 		return errors.Annotate(err, "model export failed")
 	}
+	if !cloudRemap.IsEmpty() {
+		serialized.Bytes, err = migration.RemapCloud(serialized.Bytes, cloudRemap)
+		if err != nil {
+			return errors.Annotate(err, "remapping model cloud")
+		}
+	}
 
 	w.setInfoStatus("importing model into target controller")
 	conn, err := w.openAPIConn(targetInfo)
@@ -420,12 +526,15 @@ func (w *Worker) transferModel(targetInfo coremigration.TargetInfo, modelUUID st
 		CharmUploader:   wrapper,
 
 		Tools:           serialized.Tools,
+		ToolsSizes:      serialized.ToolsSizes,
 		ToolsDownloader: w.config.ToolsDownloader,
 		ToolsUploader:   wrapper,
 
 		Resources:          serialized.Resources,
 		ResourceDownloader: w.config.Facade,
 		ResourceUploader:   wrapper,
+
+		Progress: w.binariesProgressReporter(),
 	})
 	return errors.Annotate(err, "failed to migrate binaries")
 }
@@ -648,6 +757,30 @@ func (w *Worker) waitForActiveMigration() (coremigration.MigrationStatus, error)
 	}
 }
 
+// waitForQueue waits until the controller's limit on concurrent
+// migrations allows a queued migration to start.
+func (w *Worker) waitForQueue() error {
+	lastWaiting := -1
+	for {
+		position, err := w.config.Facade.QueuePosition()
+		if err != nil {
+			return errors.Annotate(err, "retrieving queue position")
+		}
+		if position.CanStart() {
+			return nil
+		}
+		if waiting := position.Ahead - position.Limit + 1; waiting != lastWaiting {
+			w.setInfoStatus("queued, waiting for %d earlier migration(s) to finish", waiting)
+			lastWaiting = waiting
+		}
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-w.config.Clock.After(queuePollInterval):
+		}
+	}
+}
+
 func (w *Worker) waitForMigrationEnd() error {
 	w.logger.Infof("migration is externally managed. waiting for completion")
 	watcher, err := w.config.Facade.Watch()
@@ -730,6 +863,11 @@ func (w *Worker) waitForMinions(
 				return false, errors.Trace(err)
 			}
 			failures := len(reports.FailedMachines) + len(reports.FailedUnits)
+			received := reports.SuccessCount + failures
+			w.reportProgress(coremigration.Progress{
+				MinionReportsReceived: received,
+				MinionReportsExpected: received + reports.UnknownCount,
+			})
 			if failures > 0 {
 				w.logger.Errorf(formatMinionFailure(reports, infoPrefix))
 				w.setErrorStatus("%s, some agents reported failure", infoPrefix)
@@ -24,6 +24,7 @@ import (
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/description"
 	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/migration"
 	"github.com/juju/juju/resource/resourcetesting"
@@ -384,6 +385,68 @@ func (s *Suite) TestLockdownError(c *gc.C) {
 	s.stub.CheckCalls(c, watchStatusLockdownCalls)
 }
 
+func (s *Suite) TestQUEUED(c *gc.C) {
+	s.facade.queueStatus(s.makeStatus(coremigration.QUEUED))
+	s.facade.queuePositions = []coremigration.QueuePosition{{Ahead: 0, Limit: 4}}
+	s.facade.prechecksErr = errors.New("boom")
+
+	s.checkWorkerReturns(c, migrationmaster.ErrInactive)
+	s.stub.CheckCalls(c, joinCalls(
+		[]jujutesting.StubCall{
+			{"facade.Watch", nil},
+			{"facade.MigrationStatus", nil},
+			{"facade.QueuePosition", nil},
+			{"facade.SetPhase", []interface{}{coremigration.QUIESCE}},
+			{"guard.Lockdown", nil},
+			{"facade.Prechecks", nil},
+		},
+		abortCalls,
+	))
+}
+
+func (s *Suite) TestQUEUEDWaitsForSlot(c *gc.C) {
+	s.facade.queueStatus(s.makeStatus(coremigration.QUEUED))
+	s.facade.queuePositions = []coremigration.QueuePosition{
+		{Ahead: 5, Limit: 4},
+		{Ahead: 3, Limit: 4},
+	}
+	s.facade.prechecksErr = errors.New("boom")
+
+	worker, err := migrationmaster.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, worker)
+
+	select {
+	case <-s.clock.Alarms():
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for clock.After call")
+	}
+	s.stub.CheckCallNames(c, "facade.Watch", "facade.MigrationStatus", "facade.QueuePosition")
+
+	s.clock.Advance(15 * time.Second)
+	err = workertest.CheckKilled(c, worker)
+	c.Assert(err, gc.Equals, migrationmaster.ErrInactive)
+	s.stub.CheckCalls(c, joinCalls(
+		[]jujutesting.StubCall{
+			{"facade.Watch", nil},
+			{"facade.MigrationStatus", nil},
+			{"facade.QueuePosition", nil},
+			{"facade.QueuePosition", nil},
+			{"facade.SetPhase", []interface{}{coremigration.QUIESCE}},
+			{"guard.Lockdown", nil},
+			{"facade.Prechecks", nil},
+		},
+		abortCalls,
+	))
+}
+
+func (s *Suite) TestQUEUEDPositionError(c *gc.C) {
+	s.facade.queueStatus(s.makeStatus(coremigration.QUEUED))
+	s.facade.queuePositionErr = errors.New("boom")
+
+	s.checkWorkerErr(c, "retrieving queue position: boom")
+}
+
 func (s *Suite) TestQUIESCEMinionWaitWatchError(c *gc.C) {
 	s.checkMinionWaitWatchError(c, coremigration.QUIESCE)
 }
@@ -468,6 +531,110 @@ func (s *Suite) TestQUIESCETargetChecksFail(c *gc.C) {
 	))
 }
 
+func (s *Suite) TestQUIESCECloudRemapNotSupported(c *gc.C) {
+	status := s.makeStatus(coremigration.QUIESCE)
+	status.CloudRemap = coremigration.CloudRemap{Cloud: "cumulus"}
+	s.facade.queueStatus(status)
+
+	s.checkWorkerReturns(c, migrationmaster.ErrInactive)
+	s.stub.CheckCalls(c, joinCalls(
+		watchStatusLockdownCalls,
+		[]jujutesting.StubCall{
+			{"facade.Prechecks", nil},
+			{"facade.ModelInfo", nil},
+			apiOpenControllerCall,
+			apiCloseCall,
+		},
+		abortCalls,
+	))
+}
+
+func (s *Suite) TestQUIESCECloudRemapCredentialRequired(c *gc.C) {
+	status := s.makeStatus(coremigration.QUIESCE)
+	status.CloudRemap = coremigration.CloudRemap{Cloud: "cumulus"}
+	s.facade.queueStatus(status)
+	s.facade.modelCredential = names.NewCloudCredentialTag("stratus/bob/creds")
+	s.connection.facadeVersion = 3
+
+	s.checkWorkerReturns(c, migrationmaster.ErrInactive)
+	s.stub.CheckCalls(c, joinCalls(
+		watchStatusLockdownCalls,
+		[]jujutesting.StubCall{
+			{"facade.Prechecks", nil},
+			{"facade.ModelInfo", nil},
+			apiOpenControllerCall,
+			apiCloseCall,
+		},
+		abortCalls,
+	))
+}
+
+func (s *Suite) TestQUIESCECloudRemapMachinesMissing(c *gc.C) {
+	modelBytes := s.setExportedModelCloud(c, "stratus")
+	remap := coremigration.CloudRemap{Cloud: "cumulus"}
+	remappedBytes, err := migration.RemapCloud(modelBytes, remap)
+	c.Assert(err, jc.ErrorIsNil)
+
+	status := s.makeStatus(coremigration.QUIESCE)
+	status.CloudRemap = remap
+	s.facade.queueStatus(status)
+	s.connection.facadeVersion = 3
+	s.connection.machineErrors = []params.ErrorResult{
+		{Error: &params.Error{Message: `machine 0 instance "inst-0" not found`}},
+	}
+
+	s.checkWorkerReturns(c, migrationmaster.ErrInactive)
+	s.stub.CheckCalls(c, joinCalls(
+		watchStatusLockdownCalls,
+		[]jujutesting.StubCall{
+			{"facade.Prechecks", nil},
+			{"facade.ModelInfo", nil},
+			apiOpenControllerCall,
+			{"MigrationTarget.Prechecks", []interface{}{params.MigrationModelInfo{
+				UUID:         modelUUID,
+				Name:         modelName,
+				OwnerTag:     ownerTag.String(),
+				AgentVersion: modelVersion,
+				Cloud:        "cumulus",
+			}}},
+			{"facade.Export", nil},
+			{"MigrationTarget.CheckMachines", []interface{}{
+				params.SerializedModel{Bytes: remappedBytes},
+			}},
+			apiCloseCall,
+		},
+		abortCalls,
+	))
+}
+
+func (s *Suite) TestIMPORTCloudRemap(c *gc.C) {
+	modelBytes := s.setExportedModelCloud(c, "stratus")
+	remap := coremigration.CloudRemap{Cloud: "cumulus"}
+	remappedBytes, err := migration.RemapCloud(modelBytes, remap)
+	c.Assert(err, jc.ErrorIsNil)
+
+	status := s.makeStatus(coremigration.IMPORT)
+	status.CloudRemap = remap
+	s.facade.queueStatus(status)
+	s.connection.importErr = errors.New("boom")
+
+	// The machines were checked during prechecks, so the import only
+	// sends the remapped model.
+	s.checkWorkerReturns(c, migrationmaster.ErrInactive)
+	s.stub.CheckCalls(c, joinCalls(
+		watchStatusLockdownCalls,
+		[]jujutesting.StubCall{
+			{"facade.Export", nil},
+			apiOpenControllerCall,
+			{"MigrationTarget.Import", []interface{}{
+				params.SerializedModel{Bytes: remappedBytes},
+			}},
+			apiCloseCall,
+		},
+		abortCalls,
+	))
+}
+
 func (s *Suite) TestExportFailure(c *gc.C) {
 	s.facade.queueStatus(s.makeStatus(coremigration.IMPORT))
 	s.facade.exportErr = errors.New("boom")
@@ -516,6 +683,42 @@ func (s *Suite) TestImportFailure(c *gc.C) {
 	))
 }
 
+func (s *Suite) TestIMPORTBinariesProgress(c *gc.C) {
+	s.facade.queueStatus(s.makeStatus(coremigration.IMPORT))
+	s.config.UploadBinaries = func(config migration.UploadBinariesConfig) error {
+		config.Progress(0, 100)
+		config.Progress(50, 100)
+		s.clock.Advance(5 * time.Second)
+		config.Progress(60, 100)
+		config.Progress(70, 100)
+		config.Progress(100, 100)
+		return errors.New("boom")
+	}
+
+	s.checkWorkerReturns(c, migrationmaster.ErrInactive)
+	c.Check(s.facade.progress, jc.DeepEquals, []coremigration.Progress{
+		{BinariesUploaded: 0, BinariesTotal: 100},
+		{BinariesUploaded: 60, BinariesTotal: 100},
+		{BinariesUploaded: 100, BinariesTotal: 100},
+	})
+}
+
+func (s *Suite) TestVALIDATIONMinionProgress(c *gc.C) {
+	s.facade.queueStatus(s.makeStatus(coremigration.VALIDATION))
+	s.facade.queueMinionReports(coremigration.MinionReports{
+		MigrationId:    "model-uuid:2",
+		Phase:          coremigration.VALIDATION,
+		SuccessCount:   3,
+		UnknownCount:   2,
+		FailedMachines: []string{"1"},
+	})
+
+	s.checkWorkerReturns(c, migrationmaster.ErrInactive)
+	c.Check(s.facade.progress, jc.DeepEquals, []coremigration.Progress{
+		{MinionReportsReceived: 4, MinionReportsExpected: 6},
+	})
+}
+
 func (s *Suite) TestVALIDATIONMinionWaitWatchError(c *gc.C) {
 	s.checkMinionWaitWatchError(c, coremigration.VALIDATION)
 }
@@ -947,6 +1150,20 @@ func safeSend(c *gc.C, d chan<- common.LogMessage, message common.LogMessage) {
 	}
 }
 
+// setExportedModelCloud makes the stub facade export a minimal model
+// hosted on the given cloud, returning its serialized bytes.
+func (s *Suite) setExportedModelCloud(c *gc.C, cloud string) []byte {
+	model := description.NewModel(description.ModelArgs{
+		Owner:  ownerTag,
+		Cloud:  cloud,
+		Config: map[string]interface{}{"uuid": modelUUID},
+	})
+	modelBytes, err := description.Serialize(model)
+	c.Assert(err, jc.ErrorIsNil)
+	s.facade.exportedBytes = modelBytes
+	return modelBytes
+}
+
 func (s *Suite) checkWorkerReturns(c *gc.C, expected error) {
 	err := s.runWorker(c)
 	c.Check(errors.Cause(err), gc.Equals, expected)
@@ -1041,9 +1258,10 @@ type stubMasterFacade struct {
 	status         []coremigration.MigrationStatus
 	statusErr      error
 
-	prechecksErr error
-	modelInfoErr error
-	exportErr    error
+	prechecksErr    error
+	modelInfoErr    error
+	exportErr       error
+	modelCredential names.CloudCredentialTag
 
 	logMessages func(chan<- common.LogMessage)
 	streamErr   error
@@ -1054,6 +1272,14 @@ type stubMasterFacade struct {
 	minionReportsErr      error
 
 	exportedResources []coremigration.SerializedModelResource
+	exportedBytes     []byte
+
+	// progress records calls to SetProgress. They are kept out of
+	// the stub so that call sequence checks aren't affected.
+	progress []coremigration.Progress
+
+	queuePositions   []coremigration.QueuePosition
+	queuePositionErr error
 }
 
 func (f *stubMasterFacade) triggerWatcher() {
@@ -1136,10 +1362,11 @@ func (f *stubMasterFacade) ModelInfo() (coremigration.ModelInfo, error) {
 		return coremigration.ModelInfo{}, f.modelInfoErr
 	}
 	return coremigration.ModelInfo{
-		UUID:         modelUUID,
-		Name:         modelName,
-		Owner:        ownerTag,
-		AgentVersion: modelVersion,
+		UUID:            modelUUID,
+		Name:            modelName,
+		Owner:           ownerTag,
+		AgentVersion:    modelVersion,
+		CloudCredential: f.modelCredential,
 	}, nil
 }
 
@@ -1148,8 +1375,12 @@ func (f *stubMasterFacade) Export() (coremigration.SerializedModel, error) {
 	if f.exportErr != nil {
 		return coremigration.SerializedModel{}, f.exportErr
 	}
+	bytes := fakeModelBytes
+	if f.exportedBytes != nil {
+		bytes = f.exportedBytes
+	}
 	return coremigration.SerializedModel{
-		Bytes:  fakeModelBytes,
+		Bytes:  bytes,
 		Charms: []string{"charm0", "charm1"},
 		Tools: map[version.Binary]string{
 			version.MustParseBinary("2.1.0-trusty-amd64"): "/tools/0",
@@ -1163,6 +1394,24 @@ func (f *stubMasterFacade) SetPhase(phase coremigration.Phase) error {
 	return nil
 }
 
+func (f *stubMasterFacade) SetProgress(progress coremigration.Progress) error {
+	f.progress = append(f.progress, progress)
+	return nil
+}
+
+func (f *stubMasterFacade) QueuePosition() (coremigration.QueuePosition, error) {
+	f.stub.AddCall("facade.QueuePosition")
+	if f.queuePositionErr != nil {
+		return coremigration.QueuePosition{}, f.queuePositionErr
+	}
+	if len(f.queuePositions) == 0 {
+		panic("no queue position queued to report")
+	}
+	position := f.queuePositions[0]
+	f.queuePositions = f.queuePositions[1:]
+	return position, nil
+}
+
 func (f *stubMasterFacade) SetStatusMessage(message string) error {
 	return nil
 }
@@ -1217,9 +1466,15 @@ type stubConnection struct {
 
 	latestLogErr  error
 	latestLogTime time.Time
+
+	facadeVersion int
+	machineErrors []params.ErrorResult
 }
 
 func (c *stubConnection) BestFacadeVersion(string) int {
+	if c.facadeVersion > 0 {
+		return c.facadeVersion
+	}
 	return 1
 }
 
@@ -1234,6 +1489,9 @@ func (c *stubConnection) APICall(objType string, version int, id, request string
 			return c.importErr
 		case "Activate", "AdoptResources":
 			return nil
+		case "CheckMachines":
+			response.(*params.ErrorResults).Results = c.machineErrors
+			return nil
 		case "LatestLogTime":
 			responseTime := response.(*time.Time)
 			// This is needed because even if a zero time comes back
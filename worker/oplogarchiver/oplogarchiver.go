@@ -0,0 +1,73 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package oplogarchiver
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/mongo"
+	"github.com/juju/juju/worker"
+)
+
+// OplogArchiver defines the interface for types capable of archiving
+// the controller's oplog.
+type OplogArchiver interface {
+	// TailOplog returns an OplogTailer reporting the entries made
+	// in the oplog from the given time which need archiving.
+	TailOplog(since time.Time) OplogTailer
+
+	// ArchiveOplog archives the entries made in the oplog since it
+	// was last called.
+	ArchiveOplog(now time.Time) error
+}
+
+// OplogTailer reports entries as they're made in the oplog.
+// *mongo.OplogTailer implements it.
+type OplogTailer interface {
+	Out() <-chan *mongo.OplogDoc
+	Err() error
+	Stop() error
+}
+
+// New returns a worker which archives the operations recorded in the
+// controller's oplog next to its backups, so that a backup can be
+// restored to any later point in time. It archives the oplog when it
+// starts, then tails it and archives new entries within delay of them
+// being made. It is intended to run just once, on the MongoDB master.
+func New(archiver OplogArchiver, delay time.Duration, clock clock.Clock) worker.Worker {
+	return worker.NewSimpleWorker(func(stopCh <-chan struct{}) error {
+		tailer := archiver.TailOplog(clock.Now())
+		defer tailer.Stop()
+
+		// Entries made while the worker wasn't running are only
+		// found by archiving.
+		if err := archiver.ArchiveOplog(clock.Now()); err != nil {
+			return errors.Annotate(err, "archiving failed, oplogarchiver stopping")
+		}
+		var archive <-chan time.Time
+		for {
+			select {
+			case _, ok := <-tailer.Out():
+				if !ok {
+					return errors.Annotate(tailer.Err(), "oplog tailer stopped")
+				}
+				// Entries made in quick succession are
+				// archived together.
+				if archive == nil {
+					archive = clock.After(delay)
+				}
+			case <-archive:
+				archive = nil
+				if err := archiver.ArchiveOplog(clock.Now()); err != nil {
+					return errors.Annotate(err, "archiving failed, oplogarchiver stopping")
+				}
+			case <-stopCh:
+				return nil
+			}
+		}
+	})
+}
@@ -0,0 +1,159 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package oplogarchiver_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/mongo"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/oplogarchiver"
+)
+
+type OplogArchiverSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&OplogArchiverSuite{})
+
+func (s *OplogArchiverSuite) waitForAlarm(c *gc.C, clock *testing.Clock) {
+	select {
+	case <-clock.Alarms():
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for worker to wait")
+	}
+}
+
+func (s *OplogArchiverSuite) waitForArchive(c *gc.C, archiver *fakeOplogArchiver) time.Time {
+	select {
+	case now := <-archiver.archiveCh:
+		return now
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for archiving to happen")
+	}
+	return time.Time{}
+}
+
+func (s *OplogArchiverSuite) sendEntry(c *gc.C, tailer *fakeOplogTailer) {
+	select {
+	case tailer.out <- &mongo.OplogDoc{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending oplog entry")
+	}
+}
+
+func (s *OplogArchiverSuite) TestArchivesOnStart(c *gc.C) {
+	archiver := newFakeOplogArchiver(nil)
+	testClock := testing.NewClock(time.Now())
+	w := oplogarchiver.New(archiver, time.Second, testClock)
+	defer w.Kill()
+
+	c.Check(s.waitForArchive(c, archiver), gc.Equals, testClock.Now())
+	c.Check(archiver.since, gc.Equals, testClock.Now())
+}
+
+func (s *OplogArchiverSuite) TestArchivesNewEntries(c *gc.C) {
+	archiver := newFakeOplogArchiver(nil)
+	testClock := testing.NewClock(time.Now())
+	delay := 10 * time.Second
+	w := oplogarchiver.New(archiver, delay, testClock)
+	defer w.Kill()
+	s.waitForArchive(c, archiver)
+
+	for i := 0; i < 3; i++ {
+		// Several entries made together are archived once.
+		s.sendEntry(c, archiver.tailer)
+		s.waitForAlarm(c, testClock)
+		s.sendEntry(c, archiver.tailer)
+		testClock.Advance(delay)
+		c.Check(s.waitForArchive(c, archiver), gc.Equals, testClock.Now())
+	}
+	select {
+	case <-archiver.archiveCh:
+		c.Fatal("unexpected archiving")
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *OplogArchiverSuite) TestFailureStopsWorker(c *gc.C) {
+	archiver := newFakeOplogArchiver(errors.New("boom"))
+	w := oplogarchiver.New(archiver, time.Second, testing.NewClock(time.Now()))
+
+	s.waitForArchive(c, archiver)
+	c.Assert(w.Wait(), gc.ErrorMatches, "archiving failed, oplogarchiver stopping: boom")
+	c.Check(archiver.tailer.stopped, jc.IsTrue)
+}
+
+func (s *OplogArchiverSuite) TestTailerFailureStopsWorker(c *gc.C) {
+	archiver := newFakeOplogArchiver(nil)
+	w := oplogarchiver.New(archiver, time.Second, testing.NewClock(time.Now()))
+	s.waitForArchive(c, archiver)
+
+	archiver.tailer.err = errors.New("boom")
+	close(archiver.tailer.out)
+	c.Assert(w.Wait(), gc.ErrorMatches, "oplog tailer stopped: boom")
+}
+
+func (s *OplogArchiverSuite) TestStops(c *gc.C) {
+	archiver := newFakeOplogArchiver(nil)
+	w := oplogarchiver.New(archiver, time.Second, testing.NewClock(time.Now()))
+	s.waitForArchive(c, archiver)
+	w.Kill()
+	c.Check(w.Wait(), jc.ErrorIsNil)
+	c.Check(archiver.tailer.stopped, jc.IsTrue)
+}
+
+func newFakeOplogArchiver(err error) *fakeOplogArchiver {
+	return &fakeOplogArchiver{
+		archiveCh: make(chan time.Time),
+		err:       err,
+		tailer:    &fakeOplogTailer{out: make(chan *mongo.OplogDoc)},
+	}
+}
+
+type fakeOplogArchiver struct {
+	archiveCh chan time.Time
+	err       error
+	tailer    *fakeOplogTailer
+	since     time.Time
+}
+
+// TailOplog implements oplogarchiver.OplogArchiver.
+func (a *fakeOplogArchiver) TailOplog(since time.Time) oplogarchiver.OplogTailer {
+	a.since = since
+	return a.tailer
+}
+
+// ArchiveOplog implements oplogarchiver.OplogArchiver.
+func (a *fakeOplogArchiver) ArchiveOplog(now time.Time) error {
+	a.archiveCh <- now
+	return a.err
+}
+
+type fakeOplogTailer struct {
+	out     chan *mongo.OplogDoc
+	err     error
+	stopped bool
+}
+
+// Out implements oplogarchiver.OplogTailer.
+func (t *fakeOplogTailer) Out() <-chan *mongo.OplogDoc {
+	return t.out
+}
+
+// Err implements oplogarchiver.OplogTailer.
+func (t *fakeOplogTailer) Err() error {
+	return t.err
+}
+
+// Stop implements oplogarchiver.OplogTailer.
+func (t *fakeOplogTailer) Stop() error {
+	t.stopped = true
+	return nil
+}
@@ -8,8 +8,10 @@ import (
 	"sort"
 
 	"github.com/juju/replicaset"
+	"github.com/juju/utils/set"
 
 	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
 )
 
 // jujuMachineKey is the key for the tag where we save the member's juju machine id.
@@ -22,6 +24,19 @@ type peerGroupInfo struct {
 	statuses        []replicaset.MemberStatus
 	members         []replicaset.Member
 	mongoSpace      network.SpaceName
+
+	// nonVoting holds the ids of the machines which an operator
+	// has chosen never to give a vote.
+	nonVoting set.Strings
+
+	// priorities holds the election priorities chosen by an
+	// operator for voting members, keyed by machine id.
+	priorities map[string]float64
+
+	// draining holds the ids of the machines being drained before
+	// maintenance. They give up their votes when other machines can
+	// take them, and are never elected primary.
+	draining set.Strings
 }
 
 // desiredPeerGroup returns the mongo peer group according to the given
@@ -83,6 +98,9 @@ func desiredPeerGroup(info *peerGroupInfo) ([]replicaset.Member, map[*machineTra
 	adjustVotes(toRemoveVote, toAddVote, setVoting)
 
 	addNewMembers(members, toKeep, maxId, setVoting, info.mongoSpace)
+	if updatePriorities(members, info.priorities, info.draining) {
+		changed = true
+	}
 	if updateAddresses(members, info.machineTrackers, info.mongoSpace) {
 		changed = true
 	}
@@ -117,7 +135,7 @@ func possiblePeerGroupChanges(
 	logger.Debugf("assessing possible peer group changes:")
 	for _, m := range info.machineTrackers {
 		member := members[m]
-		wantsVote := m.WantsVote()
+		wantsVote := m.WantsVote() && !info.nonVoting.Contains(m.Id()) && !info.draining.Contains(m.Id())
 		isVoting := member != nil && isVotingMember(member)
 		switch {
 		case wantsVote && isVoting:
@@ -174,6 +192,67 @@ func updateAddresses(
 	return changed
 }
 
+// updatePriorities sets the election priority of each voting member
+// to the one chosen by an operator for its machine, or the default
+// priority if none was chosen. Members on draining machines are given
+// priority 0 so they can't become primary. Priorities that would leave
+// no voting member able to become primary are ignored. It reports
+// whether any changes have been made.
+func updatePriorities(
+	members map[*machineTracker]*replicaset.Member,
+	priorities map[string]float64,
+	draining set.Strings,
+) bool {
+	desired := make(map[*machineTracker]float64)
+	electable := false
+	for m, member := range members {
+		if !isVotingMember(member) {
+			continue
+		}
+		priority, ok := priorities[m.Id()]
+		if !ok {
+			priority = state.DefaultMemberPriority
+		}
+		if draining.Contains(m.Id()) {
+			priority = 0
+		}
+		if priority > 0 {
+			electable = true
+		}
+		desired[m] = priority
+	}
+	if len(desired) > 0 && !electable {
+		logger.Warningf("ignoring member priorities which leave no voting member able to become primary")
+		for m := range desired {
+			desired[m] = state.DefaultMemberPriority
+		}
+	}
+
+	changed := false
+	for m, priority := range desired {
+		member := members[m]
+		if memberPriority(member) == priority {
+			continue
+		}
+		if priority == state.DefaultMemberPriority {
+			member.Priority = nil
+		} else {
+			p := priority
+			member.Priority = &p
+		}
+		changed = true
+	}
+	return changed
+}
+
+// memberPriority returns the election priority of the given member.
+func memberPriority(member *replicaset.Member) float64 {
+	if member.Priority == nil {
+		return state.DefaultMemberPriority
+	}
+	return *member.Priority
+}
+
 // adjustVotes adjusts the votes of the given machines, taking
 // care not to let the total number of votes become even at
 // any time. It calls setVoting to change the voting status
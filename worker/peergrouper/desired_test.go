@@ -11,6 +11,7 @@ import (
 
 	"github.com/juju/replicaset"
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/set"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/network"
@@ -34,6 +35,10 @@ type desiredPeerGroupTest struct {
 	statuses []replicaset.MemberStatus
 	members  []replicaset.Member
 
+	nonVoting  []string
+	priorities map[string]float64
+	draining   []string
+
 	expectMembers []replicaset.Member
 	expectVoting  []bool
 	expectErr     string
@@ -183,6 +188,82 @@ func desiredPeerGroupTests(ipVersion TestIPVersion) []desiredPeerGroupTest {
 			members:       mkMembers("1v 2v 3v", ipVersion),
 			expectVoting:  []bool{true, true, true},
 			expectMembers: nil,
+		}, {
+			about:         "a machine chosen not to vote gives its vote to a ready machine",
+			machines:      mkMachines("11v 12v 13v 14v", ipVersion),
+			statuses:      mkStatuses("1p 2s 3s 4s", ipVersion),
+			members:       mkMembers("1v 2v 3v 4", ipVersion),
+			nonVoting:     []string{"13"},
+			expectVoting:  []bool{true, true, false, true},
+			expectMembers: mkMembers("1v 2v 3 4v", ipVersion),
+		}, {
+			about:         "a machine chosen not to vote keeps its vote until another can take it",
+			machines:      mkMachines("11v 12v 13v", ipVersion),
+			statuses:      mkStatuses("1p 2s 3s", ipVersion),
+			members:       mkMembers("1v 2v 3v", ipVersion),
+			nonVoting:     []string{"13"},
+			expectVoting:  []bool{true, true, true},
+			expectMembers: nil,
+		}, {
+			about:        "voting members are given the chosen priorities",
+			machines:     mkMachines("11v 12v 13v 14", ipVersion),
+			statuses:     mkStatuses("1p 2s 3s 4s", ipVersion),
+			members:      mkMembers("1v 2v 3v 4", ipVersion),
+			priorities:   map[string]float64{"11": 10, "13": 0, "14": 5},
+			expectVoting: []bool{true, true, true, false},
+			expectMembers: func() []replicaset.Member {
+				members := mkMembers("1v 2v 3v 4", ipVersion)
+				members[0].Priority = newFloat64(10)
+				members[2].Priority = newFloat64(0)
+				return members
+			}(),
+		}, {
+			about:    "members with the default priority are unchanged",
+			machines: mkMachines("11v", ipVersion),
+			statuses: mkStatuses("1p", ipVersion),
+			members: func() []replicaset.Member {
+				members := mkMembers("1v", ipVersion)
+				members[0].Priority = newFloat64(1)
+				return members
+			}(),
+			expectVoting:  []bool{true},
+			expectMembers: nil,
+		}, {
+			about:         "priorities leaving no member able to become primary are ignored",
+			machines:      mkMachines("11v", ipVersion),
+			statuses:      mkStatuses("1p", ipVersion),
+			members:       mkMembers("1v", ipVersion),
+			priorities:    map[string]float64{"11": 0},
+			expectVoting:  []bool{true},
+			expectMembers: nil,
+		}, {
+			about:         "a draining machine gives its vote to a ready machine",
+			machines:      mkMachines("11v 12v 13v 14v", ipVersion),
+			statuses:      mkStatuses("1p 2s 3s 4s", ipVersion),
+			members:       mkMembers("1v 2v 3v 4", ipVersion),
+			draining:      []string{"13"},
+			expectVoting:  []bool{true, true, false, true},
+			expectMembers: mkMembers("1v 2v 3 4v", ipVersion),
+		}, {
+			about:        "a draining machine which keeps its vote can't become primary",
+			machines:     mkMachines("11v 12v 13v", ipVersion),
+			statuses:     mkStatuses("1s 2s 3p", ipVersion),
+			members:      mkMembers("1v 2v 3v", ipVersion),
+			draining:     []string{"13"},
+			expectVoting: []bool{true, true, true},
+			expectMembers: func() []replicaset.Member {
+				members := mkMembers("1v 2v 3v", ipVersion)
+				members[2].Priority = newFloat64(0)
+				return members
+			}(),
+		}, {
+			about:         "draining every voting machine leaves their priorities unchanged",
+			machines:      mkMachines("11v", ipVersion),
+			statuses:      mkStatuses("1p", ipVersion),
+			members:       mkMembers("1v", ipVersion),
+			draining:      []string{"11"},
+			expectVoting:  []bool{true},
+			expectMembers: nil,
 		}}
 }
 
@@ -199,6 +280,9 @@ func (*desiredPeerGroupSuite) TestDesiredPeerGroup(c *gc.C) {
 				machineTrackers: trackerMap,
 				statuses:        test.statuses,
 				members:         test.members,
+				nonVoting:       set.NewStrings(test.nonVoting...),
+				priorities:      test.priorities,
+				draining:        set.NewStrings(test.draining...),
 			}
 			members, voting, err := desiredPeerGroup(info)
 			if test.expectErr != "" {
@@ -222,6 +222,19 @@ func (st *fakeState) setControllers(ids ...string) {
 	})
 }
 
+func (st *fakeState) setDraining(ids ...string) {
+	info := deepCopy(st.controllers.Get()).(*state.ControllerInfo)
+	info.DrainingMachineIds = ids
+	st.controllers.Set(info)
+}
+
+func (st *fakeState) setMemberSettings(nonVoting []string, priorities map[string]float64) {
+	info := deepCopy(st.controllers.Get()).(*state.ControllerInfo)
+	info.NonVotingMachineIds = nonVoting
+	info.MemberPriorities = priorities
+	st.controllers.Set(info)
+}
+
 func (st *fakeState) ControllerInfo() (*state.ControllerInfo, error) {
 	if err := st.errors.errorFor("State.ControllerInfo"); err != nil {
 		return nil, err
@@ -503,6 +516,22 @@ func (session *fakeMongoSession) Set(members []replicaset.Member) error {
 	return nil
 }
 
+// StepDownPrimary implements mongoSession.StepDownPrimary.
+func (session *fakeMongoSession) StepDownPrimary() error {
+	if err := session.errors.errorFor("Session.StepDownPrimary"); err != nil {
+		return err
+	}
+	status := deepCopy(session.status.Get()).(*replicaset.Status)
+	for i, member := range status.Members {
+		if member.State == replicaset.PrimaryState {
+			status.Members[i].State = replicaset.SecondaryState
+		}
+	}
+	logger.Infof("stepping down primary")
+	session.status.Set(status)
+	return nil
+}
+
 // deepCopy makes a deep copy of any type by marshalling
 // it as JSON, then unmarshalling it.
 func deepCopy(x interface{}) interface{} {
@@ -4,8 +4,11 @@
 package peergrouper
 
 import (
+	"io"
+
 	"github.com/juju/replicaset"
 	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
 
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
@@ -74,3 +77,19 @@ func (s mongoSessionShim) CurrentMembers() ([]replicaset.Member, error) {
 func (s mongoSessionShim) Set(members []replicaset.Member) error {
 	return replicaset.Set(s.session, members)
 }
+
+// stepDownSeconds holds how long a primary which has been asked to
+// step down is ineligible for re-election.
+const stepDownSeconds = 60
+
+func (s mongoSessionShim) StepDownPrimary() error {
+	session := s.session.Copy()
+	defer session.Close()
+	err := session.Run(bson.D{{"replSetStepDown", stepDownSeconds}}, nil)
+	if err == io.EOF {
+		// The primary closes all client connections when it
+		// steps down, so this is expected.
+		return nil
+	}
+	return err
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/juju/pubsub"
 	"github.com/juju/replicaset"
 	"github.com/juju/utils/clock"
+	"github.com/juju/utils/set"
 
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
@@ -53,6 +54,7 @@ type mongoSession interface {
 	CurrentStatus() (*replicaset.Status, error)
 	CurrentMembers() ([]replicaset.Member, error)
 	Set([]replicaset.Member) error
+	StepDownPrimary() error
 }
 
 type publisherInterface interface {
@@ -107,6 +109,17 @@ type pgWorker struct {
 	// are currently watching (all the controller machines).
 	machineTrackers map[string]*machineTracker
 
+	// draining holds the ids of the controller machines which are
+	// being drained before maintenance. Their API addresses aren't
+	// published, and they are not left as the mongo primary.
+	draining set.Strings
+
+	// nonVoting and priorities hold the replica set settings chosen
+	// by an operator for controller machines: the machines which
+	// are never to vote, and the election priorities of the others.
+	nonVoting  set.Strings
+	priorities map[string]float64
+
 	// publisher holds the implementation of the API
 	// address publisher.
 	publisher publisherInterface
@@ -212,6 +225,10 @@ func (w *pgWorker) loop() error {
 				logger.Errorf("cannot set replicaset: %v", err)
 				ok = false
 			}
+			if err := w.stepDownDrainingPrimary(); err != nil {
+				logger.Errorf("cannot step down mongo primary: %v", err)
+				ok = false
+			}
 			if ok {
 				// Update the replica set members occasionally
 				// to keep them up to date with the current
@@ -276,6 +293,28 @@ func (w *pgWorker) updateControllerMachines() (bool, error) {
 	logger.Debugf("controller machines in state: %#v", info.MachineIds)
 	changed := false
 
+	// A change to the controllers being drained changes the API
+	// addresses to publish.
+	draining := set.NewStrings(info.DrainingMachineIds...)
+	if draining.Size() != w.draining.Size() || !draining.Difference(w.draining).IsEmpty() {
+		logger.Debugf("controller machines being drained: %v", draining.SortedValues())
+		w.draining = draining
+		changed = true
+	}
+
+	// So does a change to the operator's replica set settings.
+	nonVoting := set.NewStrings(info.NonVotingMachineIds...)
+	if nonVoting.Size() != w.nonVoting.Size() || !nonVoting.Difference(w.nonVoting).IsEmpty() {
+		logger.Debugf("controller machines not to vote: %v", nonVoting.SortedValues())
+		w.nonVoting = nonVoting
+		changed = true
+	}
+	if !prioritiesEqual(info.MemberPriorities, w.priorities) {
+		logger.Debugf("controller member priorities: %v", info.MemberPriorities)
+		w.priorities = info.MemberPriorities
+		changed = true
+	}
+
 	// Stop machine goroutines that no longer correspond to controller
 	// machines.
 	for _, m := range w.machineTrackers {
@@ -329,6 +368,18 @@ func (w *pgWorker) updateControllerMachines() (bool, error) {
 	return changed, nil
 }
 
+func prioritiesEqual(p1, p2 map[string]float64) bool {
+	if len(p1) != len(p2) {
+		return false
+	}
+	for id, priority := range p1 {
+		if other, ok := p2[id]; !ok || other != priority {
+			return false
+		}
+	}
+	return true
+}
+
 func inStrings(t string, ss []string) bool {
 	for _, s := range ss {
 		if s == t {
@@ -345,6 +396,13 @@ func (w *pgWorker) apiPublishInfo() ([][]network.HostPort, []instance.Id, error)
 	}
 	servers := make([][]network.HostPort, 0, len(w.machineTrackers))
 	instanceIds := make([]instance.Id, 0, len(w.machineTrackers))
+
+	// Controllers being drained aren't published to agents, unless
+	// that would leave no controllers for them to connect to.
+	exclude := w.draining
+	if !w.haveUndrainedAPIServer() {
+		exclude = nil
+	}
 	for _, m := range w.machineTrackers {
 		hostPorts := m.APIHostPorts()
 		server := apiserver.APIServer{ID: m.Id()}
@@ -354,6 +412,10 @@ func (w *pgWorker) apiPublishInfo() ([][]network.HostPort, []instance.Id, error)
 		for _, hp := range network.FilterUnusableHostPorts(hostPorts) {
 			server.Addresses = append(server.Addresses, hp.String())
 		}
+		details.Servers[server.ID] = server
+		if exclude.Contains(m.Id()) {
+			continue
+		}
 
 		instanceId, err := m.stm.InstanceId()
 		if err != nil {
@@ -361,12 +423,56 @@ func (w *pgWorker) apiPublishInfo() ([][]network.HostPort, []instance.Id, error)
 		}
 		instanceIds = append(instanceIds, instanceId)
 		servers = append(servers, m.APIHostPorts())
-		details.Servers[server.ID] = server
 	}
 	w.hub.Publish(apiserver.DetailsTopic, details)
 	return servers, instanceIds, nil
 }
 
+// haveUndrainedAPIServer returns true if there is a controller which
+// isn't being drained and has API addresses to publish.
+func (w *pgWorker) haveUndrainedAPIServer() bool {
+	for id, m := range w.machineTrackers {
+		if !w.draining.Contains(id) && len(m.APIHostPorts()) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// stepDownDrainingPrimary asks the mongo primary to step down if it
+// is on a controller machine which is being drained, so that one of
+// the other members is elected in its place.
+func (w *pgWorker) stepDownDrainingPrimary() error {
+	if w.draining.IsEmpty() {
+		return nil
+	}
+	session := w.st.MongoSession()
+	status, err := session.CurrentStatus()
+	if err != nil {
+		return errors.Annotate(err, "cannot get replica set status")
+	}
+	members, err := session.CurrentMembers()
+	if err != nil {
+		return errors.Annotate(err, "cannot get replica set members")
+	}
+	machineIds := make(map[int]string)
+	for _, member := range members {
+		machineIds[member.Id] = member.Tags[jujuMachineKey]
+	}
+	for _, memberStatus := range status.Members {
+		if memberStatus.State != replicaset.PrimaryState {
+			continue
+		}
+		id := machineIds[memberStatus.Id]
+		if !w.draining.Contains(id) {
+			return nil
+		}
+		logger.Infof("stepping down mongo primary on draining controller %q", id)
+		return errors.Trace(session.StepDownPrimary())
+	}
+	return nil
+}
+
 // peerGroupInfo collates current session information about the
 // mongo peer group with information from state machines.
 func (w *pgWorker) peerGroupInfo() (*peerGroupInfo, error) {
@@ -383,6 +489,9 @@ func (w *pgWorker) peerGroupInfo() (*peerGroupInfo, error) {
 		return nil, fmt.Errorf("cannot get replica set members: %v", err)
 	}
 	info.machineTrackers = w.machineTrackers
+	info.nonVoting = w.nonVoting
+	info.priorities = w.priorities
+	info.draining = w.draining
 
 	spaceName, err := w.getMongoSpace(mongoAddresses(info.machineTrackers))
 	if err != nil {
@@ -490,6 +490,120 @@ func (s *workerSuite) TestControllersArePublishedOverHub(c *gc.C) {
 
 }
 
+func (s *workerSuite) TestDrainingControllersAreNotPublished(c *gc.C) {
+	DoTestForIPv4AndIPv6(func(ipVersion TestIPVersion) {
+		publishCh := make(chan [][]network.HostPort)
+		publish := func(apiServers [][]network.HostPort, instanceIds []instance.Id) error {
+			publishCh <- apiServers
+			return nil
+		}
+
+		st := NewFakeState()
+		InitState(c, st, 3, ipVersion)
+		st.setDraining("11")
+		s.newPublishWorker(c, st, PublisherFunc(publish))
+
+		expected := ExpectedAPIHostPorts(3, ipVersion)
+		select {
+		case servers := <-publishCh:
+			AssertAPIHostPorts(c, servers, [][]network.HostPort{expected[0], expected[2]})
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for publish")
+		}
+
+		// Undraining the controller publishes it again.
+		st.setDraining()
+		select {
+		case servers := <-publishCh:
+			AssertAPIHostPorts(c, servers, expected)
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for publish")
+		}
+	})
+}
+
+func (s *workerSuite) TestAllControllersDrainingArePublished(c *gc.C) {
+	publishCh := make(chan [][]network.HostPort)
+	publish := func(apiServers [][]network.HostPort, instanceIds []instance.Id) error {
+		publishCh <- apiServers
+		return nil
+	}
+
+	st := NewFakeState()
+	InitState(c, st, 2, testIPv4)
+	st.setDraining("10", "11")
+	s.newPublishWorker(c, st, PublisherFunc(publish))
+
+	select {
+	case servers := <-publishCh:
+		AssertAPIHostPorts(c, servers, ExpectedAPIHostPorts(2, testIPv4))
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for publish")
+	}
+}
+
+func (s *workerSuite) TestDrainingPrimaryStepsDown(c *gc.C) {
+	st := NewFakeState()
+	InitState(c, st, 3, testIPv4)
+	st.setDraining("10")
+	statusWatcher := st.session.status.Watch()
+	c.Assert(statusWatcher.Next(), jc.IsTrue)
+
+	s.newNoPublishWorker(c, st)
+
+	done := make(chan bool)
+	go func() {
+		done <- statusWatcher.Next()
+	}()
+	select {
+	case ok := <-done:
+		c.Assert(ok, jc.IsTrue)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for primary to step down")
+	}
+	status := statusWatcher.Value().(*replicaset.Status)
+	c.Assert(status.Members, gc.HasLen, 1)
+	c.Check(status.Members[0].State, gc.Equals, replicaset.SecondaryState)
+}
+
+func (s *workerSuite) TestMemberSettingsAreApplied(c *gc.C) {
+	st := NewFakeState()
+	InitState(c, st, 4, testIPv4)
+	st.setMemberSettings([]string{"13"}, map[string]float64{"11": 5})
+	memberWatcher := st.session.members.Watch()
+	mustNext(c, memberWatcher)
+
+	s.newNoPublishWorker(c, st)
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			select {
+			case <-time.After(5 * time.Millisecond):
+				s.clock.Advance(pollInterval)
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	mustNext(c, memberWatcher)
+	assertMembers(c, memberWatcher.Value(), mkMembers("0v 1 2 3", testIPv4))
+
+	// Machine 13 isn't given a vote even though it wants one, and
+	// machine 11 votes with the chosen priority.
+	st.session.setStatus(mkStatuses("0p 1s 2s 3s", testIPv4))
+	mustNext(c, memberWatcher)
+	expected := mkMembers("0v 1v 2v 3", testIPv4)
+	expected[1].Priority = newFloat64(5)
+	assertMembers(c, memberWatcher.Value(), expected)
+
+	// Clearing the settings restores the default priority.
+	st.setMemberSettings([]string{"13"}, nil)
+	mustNext(c, memberWatcher)
+	assertMembers(c, memberWatcher.Value(), mkMembers("0v 1v 2v 3", testIPv4))
+}
+
 func hostPortInSpace(address, spaceName string) network.HostPort {
 	netAddress := network.Address{
 		Value:     address,
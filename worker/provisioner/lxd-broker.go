@@ -135,7 +135,9 @@ func (broker *lxdBroker) StartInstance(args environs.StartInstanceParams) (*envi
 		return nil, err
 	}
 
-	storageConfig := &container.StorageConfig{}
+	storageConfig := &container.StorageConfig{
+		Filesystems: args.Filesystems,
+	}
 	inst, hardware, err := broker.manager.CreateContainer(
 		args.InstanceConfig, args.Constraints,
 		series, network, storageConfig, args.StatusCallback,
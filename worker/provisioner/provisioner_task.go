@@ -582,6 +582,7 @@ func constructStartInstanceParams(
 			storage.ProviderType(v.Provider),
 			v.Attributes,
 			v.Tags,
+			v.SnapshotId,
 			&storage.VolumeAttachmentParams{
 				AttachmentParams: storage.AttachmentParams{
 					Machine:  machineTag,
@@ -592,6 +593,29 @@ func constructStartInstanceParams(
 		}
 	}
 
+	filesystems := make([]storage.FilesystemParams, len(provisioningInfo.Filesystems))
+	for i, f := range provisioningInfo.Filesystems {
+		filesystemTag, err := names.ParseFilesystemTag(f.FilesystemTag)
+		if err != nil {
+			return environs.StartInstanceParams{}, errors.Trace(err)
+		}
+		var volumeTag names.VolumeTag
+		if f.VolumeTag != "" {
+			volumeTag, err = names.ParseVolumeTag(f.VolumeTag)
+			if err != nil {
+				return environs.StartInstanceParams{}, errors.Trace(err)
+			}
+		}
+		filesystems[i] = storage.FilesystemParams{
+			Tag:          filesystemTag,
+			Volume:       volumeTag,
+			Size:         f.Size,
+			Provider:     storage.ProviderType(f.Provider),
+			Attributes:   f.Attributes,
+			ResourceTags: f.Tags,
+		}
+	}
+
 	var subnetsToZones map[network.Id][]string
 	if provisioningInfo.SubnetsToZones != nil {
 		// Convert subnet provider ids from string to network.Id.
@@ -630,6 +654,7 @@ func constructStartInstanceParams(
 		Placement:         provisioningInfo.Placement,
 		DistributionGroup: machine.DistributionGroup,
 		Volumes:           volumes,
+		Filesystems:       filesystems,
 		SubnetsToZones:    subnetsToZones,
 		EndpointBindings:  endpointBindings,
 		ImageMetadata:     possibleImageMetadata,
@@ -808,8 +833,8 @@ func volumesToAPIserver(volumes []storage.Volume) []params.Volume {
 	result := make([]params.Volume, len(volumes))
 	for i, v := range volumes {
 		result[i] = params.Volume{
-			v.Tag.String(),
-			params.VolumeInfo{
+			VolumeTag: v.Tag.String(),
+			Info: params.VolumeInfo{
 				v.VolumeId,
 				v.HardwareId,
 				v.Size,
@@ -92,13 +92,18 @@ type mockVolumeAccessor struct {
 	volumesWatcher         *mockStringsWatcher
 	attachmentsWatcher     *mockAttachmentsWatcher
 	blockDevicesWatcher    *mockNotifyWatcher
+	resizesWatcher         *mockStringsWatcher
+	snapshotsWatcher       *mockStringsWatcher
 	provisionedMachines    map[string]instance.Id
 	provisionedVolumes     map[string]params.Volume
 	provisionedAttachments map[params.MachineStorageId]params.VolumeAttachment
 	blockDevices           map[params.MachineStorageId]storage.BlockDevice
+	requestedSizes         map[string]uint64
+	snapshotParams         map[string]params.VolumeSnapshotParams
 
-	setVolumeInfo           func([]params.Volume) ([]params.ErrorResult, error)
-	setVolumeAttachmentInfo func([]params.VolumeAttachment) ([]params.ErrorResult, error)
+	setVolumeInfo             func([]params.Volume) ([]params.ErrorResult, error)
+	setVolumeAttachmentInfo   func([]params.VolumeAttachment) ([]params.ErrorResult, error)
+	setVolumeSnapshotOutcomes func([]params.VolumeSnapshotOutcome) ([]params.ErrorResult, error)
 }
 
 func (m *mockVolumeAccessor) provisionVolume(tag names.VolumeTag) params.Volume {
@@ -124,6 +129,14 @@ func (w *mockVolumeAccessor) WatchBlockDevices(tag names.MachineTag) (watcher.No
 	return w.blockDevicesWatcher, nil
 }
 
+func (w *mockVolumeAccessor) WatchVolumeResizes() (watcher.StringsWatcher, error) {
+	return w.resizesWatcher, nil
+}
+
+func (w *mockVolumeAccessor) WatchVolumeSnapshots() (watcher.StringsWatcher, error) {
+	return w.snapshotsWatcher, nil
+}
+
 func (v *mockVolumeAccessor) Volumes(volumes []names.VolumeTag) ([]params.VolumeResult, error) {
 	var result []params.VolumeResult
 	for _, tag := range volumes {
@@ -211,6 +224,48 @@ func (v *mockVolumeAccessor) VolumeAttachmentParams(ids []params.MachineStorageI
 	return result, nil
 }
 
+func (v *mockVolumeAccessor) VolumeResizeParams(volumes []names.VolumeTag) ([]params.VolumeResizeParamsResult, error) {
+	var result []params.VolumeResizeParamsResult
+	for _, tag := range volumes {
+		size, ok := v.requestedSizes[tag.String()]
+		if !ok {
+			result = append(result, params.VolumeResizeParamsResult{
+				Error: common.ServerError(errors.NotFoundf("pending resize of volume %q", tag.Id())),
+			})
+			continue
+		}
+		result = append(result, params.VolumeResizeParamsResult{Result: params.VolumeResizeParams{
+			VolumeTag: tag.String(),
+			VolumeId:  "vol-" + tag.Id(),
+			Provider:  "dummy",
+			Size:      size,
+		}})
+	}
+	return result, nil
+}
+
+func (v *mockVolumeAccessor) VolumeSnapshotParams(volumes []names.VolumeTag) ([]params.VolumeSnapshotParamsResult, error) {
+	var result []params.VolumeSnapshotParamsResult
+	for _, tag := range volumes {
+		snapshotParams, ok := v.snapshotParams[tag.String()]
+		if !ok {
+			result = append(result, params.VolumeSnapshotParamsResult{
+				Error: common.ServerError(errors.NotFoundf("pending snapshot operations on volume %q", tag.Id())),
+			})
+			continue
+		}
+		result = append(result, params.VolumeSnapshotParamsResult{Result: snapshotParams})
+	}
+	return result, nil
+}
+
+func (v *mockVolumeAccessor) SetVolumeSnapshotOutcomes(outcomes []params.VolumeSnapshotOutcome) ([]params.ErrorResult, error) {
+	if v.setVolumeSnapshotOutcomes != nil {
+		return v.setVolumeSnapshotOutcomes(outcomes)
+	}
+	return make([]params.ErrorResult, len(outcomes)), nil
+}
+
 func (v *mockVolumeAccessor) SetVolumeInfo(volumes []params.Volume) ([]params.ErrorResult, error) {
 	if v.setVolumeInfo != nil {
 		return v.setVolumeInfo(volumes)
@@ -230,19 +285,25 @@ func newMockVolumeAccessor() *mockVolumeAccessor {
 		volumesWatcher:         newMockStringsWatcher(),
 		attachmentsWatcher:     newMockAttachmentsWatcher(),
 		blockDevicesWatcher:    newMockNotifyWatcher(),
+		resizesWatcher:         newMockStringsWatcher(),
+		snapshotsWatcher:       newMockStringsWatcher(),
 		provisionedMachines:    make(map[string]instance.Id),
 		provisionedVolumes:     make(map[string]params.Volume),
 		provisionedAttachments: make(map[params.MachineStorageId]params.VolumeAttachment),
 		blockDevices:           make(map[params.MachineStorageId]storage.BlockDevice),
+		requestedSizes:         make(map[string]uint64),
+		snapshotParams:         make(map[string]params.VolumeSnapshotParams),
 	}
 }
 
 type mockFilesystemAccessor struct {
 	filesystemsWatcher     *mockStringsWatcher
 	attachmentsWatcher     *mockAttachmentsWatcher
+	resizesWatcher         *mockStringsWatcher
 	provisionedMachines    map[string]instance.Id
 	provisionedFilesystems map[string]params.Filesystem
 	provisionedAttachments map[params.MachineStorageId]params.FilesystemAttachment
+	requestedSizes         map[string]uint64
 
 	setFilesystemInfo           func([]params.Filesystem) ([]params.ErrorResult, error)
 	setFilesystemAttachmentInfo func([]params.FilesystemAttachment) ([]params.ErrorResult, error)
@@ -267,6 +328,10 @@ func (w *mockFilesystemAccessor) WatchFilesystemAttachments() (watcher.MachineSt
 	return w.attachmentsWatcher, nil
 }
 
+func (w *mockFilesystemAccessor) WatchFilesystemResizes() (watcher.StringsWatcher, error) {
+	return w.resizesWatcher, nil
+}
+
 func (v *mockFilesystemAccessor) Filesystems(filesystems []names.FilesystemTag) ([]params.FilesystemResult, error) {
 	var result []params.FilesystemResult
 	for _, tag := range filesystems {
@@ -335,6 +400,26 @@ func (f *mockFilesystemAccessor) FilesystemAttachmentParams(ids []params.Machine
 	return result, nil
 }
 
+func (f *mockFilesystemAccessor) FilesystemResizeParams(filesystems []names.FilesystemTag) ([]params.FilesystemResizeParamsResult, error) {
+	var result []params.FilesystemResizeParamsResult
+	for _, tag := range filesystems {
+		size, ok := f.requestedSizes[tag.String()]
+		if !ok {
+			result = append(result, params.FilesystemResizeParamsResult{
+				Error: common.ServerError(errors.NotFoundf("pending resize of filesystem %q", tag.Id())),
+			})
+			continue
+		}
+		result = append(result, params.FilesystemResizeParamsResult{Result: params.FilesystemResizeParams{
+			FilesystemTag: tag.String(),
+			FilesystemId:  "fs-" + tag.Id(),
+			Provider:      "dummy",
+			Size:          size,
+		}})
+	}
+	return result, nil
+}
+
 func (f *mockFilesystemAccessor) SetFilesystemInfo(filesystems []params.Filesystem) ([]params.ErrorResult, error) {
 	if f.setFilesystemInfo != nil {
 		return f.setFilesystemInfo(filesystems)
@@ -353,9 +438,11 @@ func newMockFilesystemAccessor() *mockFilesystemAccessor {
 	return &mockFilesystemAccessor{
 		filesystemsWatcher:     newMockStringsWatcher(),
 		attachmentsWatcher:     newMockAttachmentsWatcher(),
+		resizesWatcher:         newMockStringsWatcher(),
 		provisionedMachines:    make(map[string]instance.Id),
 		provisionedFilesystems: make(map[string]params.Filesystem),
 		provisionedAttachments: make(map[params.MachineStorageId]params.FilesystemAttachment),
+		requestedSizes:         make(map[string]uint64),
 	}
 }
 
@@ -431,6 +518,10 @@ type dummyProvider struct {
 	detachFilesystemsFunc        func([]storage.FilesystemAttachmentParams) ([]error, error)
 	destroyVolumesFunc           func([]string) ([]error, error)
 	destroyFilesystemsFunc       func([]string) ([]error, error)
+	resizeVolumesFunc            func([]storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error)
+	releaseVolumesFunc           func([]string) ([]error, error)
+	createSnapshotsFunc          func([]storage.SnapshotParams) ([]storage.CreateSnapshotsResult, error)
+	deleteSnapshotsFunc          func([]string) ([]error, error)
 	validateVolumeParamsFunc     func(storage.VolumeParams) error
 	validateFilesystemParamsFunc func(storage.FilesystemParams) error
 }
@@ -540,6 +631,61 @@ func (s *dummyVolumeSource) DetachVolumes(params []storage.VolumeAttachmentParam
 	return make([]error, len(params)), nil
 }
 
+// ResizeVolumes grows volumes to the requested sizes.
+func (s *dummyVolumeSource) ResizeVolumes(params []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	if s.provider.resizeVolumesFunc != nil {
+		return s.provider.resizeVolumesFunc(params)
+	}
+	results := make([]storage.ResizeVolumesResult, len(params))
+	for i, p := range params {
+		results[i].Volume = &storage.Volume{
+			p.Tag,
+			storage.VolumeInfo{
+				Size:     p.Size,
+				VolumeId: p.VolumeId,
+			},
+		}
+	}
+	return results, nil
+}
+
+// ReleaseVolumes releases volumes from the model.
+func (s *dummyVolumeSource) ReleaseVolumes(volumeIds []string) ([]error, error) {
+	if s.provider.releaseVolumesFunc != nil {
+		return s.provider.releaseVolumesFunc(volumeIds)
+	}
+	return make([]error, len(volumeIds)), nil
+}
+
+// CreateSnapshots takes snapshots of volumes.
+func (s *dummyVolumeSource) CreateSnapshots(params []storage.SnapshotParams) ([]storage.CreateSnapshotsResult, error) {
+	if s.provider.createSnapshotsFunc != nil {
+		return s.provider.createSnapshotsFunc(params)
+	}
+	results := make([]storage.CreateSnapshotsResult, len(params))
+	for i, p := range params {
+		results[i].Snapshot = &storage.Snapshot{
+			SnapshotId: p.VolumeId + "@1",
+			VolumeId:   p.VolumeId,
+			Size:       1024,
+		}
+	}
+	return results, nil
+}
+
+// ListSnapshots lists the snapshots of volumes.
+func (s *dummyVolumeSource) ListSnapshots(volumeIds []string) ([]storage.ListSnapshotsResult, error) {
+	return make([]storage.ListSnapshotsResult, len(volumeIds)), nil
+}
+
+// DeleteSnapshots deletes snapshots of volumes.
+func (s *dummyVolumeSource) DeleteSnapshots(snapshotIds []string) ([]error, error) {
+	if s.provider.deleteSnapshotsFunc != nil {
+		return s.provider.deleteSnapshotsFunc(snapshotIds)
+	}
+	return make([]error, len(snapshotIds)), nil
+}
+
 func (s *dummyFilesystemSource) ValidateFilesystemParams(params storage.FilesystemParams) error {
 	if s.provider != nil && s.provider.validateFilesystemParamsFunc != nil {
 		return s.provider.validateFilesystemParamsFunc(params)
@@ -0,0 +1,252 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storageprovisioner
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/status"
+	"github.com/juju/juju/storage"
+)
+
+// volumeResizesChanged is called when the volumes watched for resize
+// requests change. Volumes with a pending resize are grown, and their
+// new sizes recorded in state.
+func volumeResizesChanged(ctx *context, changes []string) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	tags := make([]names.VolumeTag, len(changes))
+	for i, id := range changes {
+		tags[i] = names.NewVolumeTag(id)
+	}
+	paramsResults, err := ctx.config.Volumes.VolumeResizeParams(tags)
+	if err != nil {
+		return errors.Annotate(err, "getting volume resize params")
+	}
+	paramsBySource := make(map[string][]storage.VolumeResizeParams)
+	for i, result := range paramsResults {
+		if params.IsCodeNotFound(result.Error) {
+			// There is no resize pending for the volume.
+			continue
+		} else if result.Error != nil {
+			return errors.Annotatef(
+				result.Error, "getting resize params for %s",
+				names.ReadableString(tags[i]),
+			)
+		}
+		sourceName := result.Result.Provider
+		paramsBySource[sourceName] = append(paramsBySource[sourceName], storage.VolumeResizeParams{
+			Tag:      tags[i],
+			VolumeId: result.Result.VolumeId,
+			Size:     result.Result.Size,
+		})
+	}
+	var volumes []storage.Volume
+	var statuses []params.EntityStatusArgs
+	for sourceName, resizeParams := range paramsBySource {
+		logger.Debugf("resizing volumes from %q: %v", sourceName, resizeParams)
+		results, err := resizeVolumes(ctx, sourceName, resizeParams)
+		if err != nil {
+			return errors.Annotatef(err, "resizing volumes from source %q", sourceName)
+		}
+		for i, result := range results {
+			tag := resizeParams[i].Tag
+			if result.Error != nil {
+				statuses = append(statuses, params.EntityStatusArgs{
+					Tag:    tag.String(),
+					Status: status.Error.String(),
+					Info:   errors.Annotate(result.Error, "resizing volume").Error(),
+				})
+				logger.Debugf("failed to resize %s: %v", names.ReadableString(tag), result.Error)
+				continue
+			}
+			// Only the size of the volume changes;
+			// the remaining details are unaffected.
+			volume, ok := ctx.volumes[tag]
+			if !ok {
+				volume = *result.Volume
+			}
+			volume.Size = result.Volume.Size
+			volumes = append(volumes, volume)
+		}
+	}
+	setStatus(ctx, statuses)
+	if len(volumes) == 0 {
+		return nil
+	}
+	errorResults, err := ctx.config.Volumes.SetVolumeInfo(volumesFromStorage(volumes))
+	if err != nil {
+		return errors.Annotate(err, "publishing resized volumes to state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			logger.Errorf(
+				"publishing resized volume %s to state: %v",
+				volumes[i].Tag.Id(),
+				result.Error,
+			)
+			continue
+		}
+		ctx.volumes[volumes[i].Tag] = volumes[i]
+	}
+	return nil
+}
+
+// resizeVolumes resizes volumes using the named volume source, if it
+// supports resizing.
+func resizeVolumes(ctx *context, sourceName string, args []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	source, err := volumeSource(
+		ctx.config.StorageDir, sourceName, storage.ProviderType(sourceName), ctx.config.Registry,
+	)
+	if err != nil && errors.Cause(err) != errNonDynamic {
+		return nil, errors.Annotate(err, "getting volume source")
+	}
+	if resizer, ok := source.(storage.VolumeResizer); ok {
+		return resizer.ResizeVolumes(args)
+	}
+	results := make([]storage.ResizeVolumesResult, len(args))
+	for i := range results {
+		results[i].Error = errors.NotSupportedf("resizing volumes with storage provider %q", sourceName)
+	}
+	return results, nil
+}
+
+// filesystemResizesChanged is called when the filesystems watched for
+// resize requests change. Filesystems with a pending resize are grown,
+// and their new sizes recorded in state.
+func filesystemResizesChanged(ctx *context, changes []string) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	tags := make([]names.FilesystemTag, len(changes))
+	for i, id := range changes {
+		tags[i] = names.NewFilesystemTag(id)
+	}
+	paramsResults, err := ctx.config.Filesystems.FilesystemResizeParams(tags)
+	if err != nil {
+		return errors.Annotate(err, "getting filesystem resize params")
+	}
+	paramsBySource := make(map[string][]storage.FilesystemResizeParams)
+	for i, result := range paramsResults {
+		if params.IsCodeNotFound(result.Error) {
+			// There is no resize pending for the filesystem.
+			continue
+		} else if result.Error != nil {
+			return errors.Annotatef(
+				result.Error, "getting resize params for %s",
+				names.ReadableString(tags[i]),
+			)
+		}
+		arg := storage.FilesystemResizeParams{
+			Tag:          tags[i],
+			FilesystemId: result.Result.FilesystemId,
+			Size:         result.Result.Size,
+			Path:         filesystemMountPoint(ctx, tags[i]),
+		}
+		sourceName := result.Result.Provider
+		if result.Result.VolumeTag != "" {
+			if _, ok := ctx.config.Scope.(names.MachineTag); !ok {
+				// Volume-backed filesystems can only be grown by
+				// the machine that the volume is attached to.
+				continue
+			}
+			volumeTag, err := names.ParseVolumeTag(result.Result.VolumeTag)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			arg.Volume = volumeTag
+			// Volume-backed filesystems are managed by
+			// the storage provisioner's machine.
+			sourceName = managedFilesystemSourceName
+		}
+		paramsBySource[sourceName] = append(paramsBySource[sourceName], arg)
+	}
+	var filesystems []storage.Filesystem
+	var statuses []params.EntityStatusArgs
+	for sourceName, resizeParams := range paramsBySource {
+		logger.Debugf("resizing filesystems from %q: %v", sourceName, resizeParams)
+		results, err := resizeFilesystems(ctx, sourceName, resizeParams)
+		if err != nil {
+			return errors.Annotatef(err, "resizing filesystems from source %q", sourceName)
+		}
+		for i, result := range results {
+			tag := resizeParams[i].Tag
+			if result.Error != nil {
+				statuses = append(statuses, params.EntityStatusArgs{
+					Tag:    tag.String(),
+					Status: status.Error.String(),
+					Info:   errors.Annotate(result.Error, "resizing filesystem").Error(),
+				})
+				logger.Debugf("failed to resize %s: %v", names.ReadableString(tag), result.Error)
+				continue
+			}
+			filesystems = append(filesystems, *result.Filesystem)
+		}
+	}
+	setStatus(ctx, statuses)
+	if len(filesystems) == 0 {
+		return nil
+	}
+	errorResults, err := ctx.config.Filesystems.SetFilesystemInfo(filesystemsFromStorage(filesystems))
+	if err != nil {
+		return errors.Annotate(err, "publishing resized filesystems to state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			logger.Errorf(
+				"publishing resized filesystem %s to state: %v",
+				filesystems[i].Tag.Id(),
+				result.Error,
+			)
+			continue
+		}
+		ctx.filesystems[filesystems[i].Tag] = filesystems[i]
+	}
+	return nil
+}
+
+// managedFilesystemSourceName is the name used to group the resizing
+// of volume-backed filesystems, which are managed by the machine's
+// managed filesystem source.
+const managedFilesystemSourceName = ""
+
+// resizeFilesystems resizes filesystems using the named filesystem
+// source, if it supports resizing.
+func resizeFilesystems(ctx *context, sourceName string, args []storage.FilesystemResizeParams) ([]storage.ResizeFilesystemsResult, error) {
+	var source storage.FilesystemSource
+	if sourceName == managedFilesystemSourceName {
+		source = ctx.managedFilesystemSource
+	} else {
+		var err error
+		source, err = filesystemSource(
+			ctx.config.StorageDir, sourceName, storage.ProviderType(sourceName), ctx.config.Registry,
+		)
+		if err != nil {
+			return nil, errors.Annotate(err, "getting filesystem source")
+		}
+	}
+	if resizer, ok := source.(storage.FilesystemResizer); ok {
+		return resizer.ResizeFilesystems(args)
+	}
+	results := make([]storage.ResizeFilesystemsResult, len(args))
+	for i := range results {
+		results[i].Error = errors.NotSupportedf("resizing filesystems with storage provider %q", sourceName)
+	}
+	return results, nil
+}
+
+// filesystemMountPoint returns the path at which the specified
+// filesystem is mounted on a machine, or the empty string if it
+// is not known to be mounted.
+func filesystemMountPoint(ctx *context, tag names.FilesystemTag) string {
+	for _, attachment := range ctx.filesystemAttachments {
+		if attachment.Filesystem == tag {
+			return attachment.Path
+		}
+	}
+	return ""
+}
@@ -0,0 +1,155 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storageprovisioner
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/status"
+	"github.com/juju/juju/storage"
+)
+
+// volumeSnapshotsChanged is called when the volumes watched for
+// snapshot requests change. Requested snapshots are taken, destroyed
+// snapshots are deleted, and the outcomes recorded in state.
+func volumeSnapshotsChanged(ctx *context, changes []string) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	tags := make([]names.VolumeTag, len(changes))
+	for i, id := range changes {
+		tags[i] = names.NewVolumeTag(id)
+	}
+	paramsResults, err := ctx.config.Volumes.VolumeSnapshotParams(tags)
+	if err != nil {
+		return errors.Annotate(err, "getting volume snapshot params")
+	}
+	paramsBySource := make(map[string][]params.VolumeSnapshotParams)
+	for i, result := range paramsResults {
+		if params.IsCodeNotFound(result.Error) {
+			// There are no snapshot operations pending for the volume.
+			continue
+		} else if result.Error != nil {
+			return errors.Annotatef(
+				result.Error, "getting snapshot params for %s",
+				names.ReadableString(tags[i]),
+			)
+		}
+		sourceName := result.Result.Provider
+		paramsBySource[sourceName] = append(paramsBySource[sourceName], result.Result)
+	}
+	var outcomes []params.VolumeSnapshotOutcome
+	var statuses []params.EntityStatusArgs
+	for sourceName, snapshotParams := range paramsBySource {
+		logger.Debugf("snapshotting volumes from %q: %v", sourceName, snapshotParams)
+		sourceOutcomes, sourceStatuses, err := snapshotVolumes(ctx, sourceName, snapshotParams)
+		if err != nil {
+			return errors.Annotatef(err, "snapshotting volumes from source %q", sourceName)
+		}
+		outcomes = append(outcomes, sourceOutcomes...)
+		statuses = append(statuses, sourceStatuses...)
+	}
+	setStatus(ctx, statuses)
+	if len(outcomes) == 0 {
+		return nil
+	}
+	errorResults, err := ctx.config.Volumes.SetVolumeSnapshotOutcomes(outcomes)
+	if err != nil {
+		return errors.Annotate(err, "publishing volume snapshots to state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			logger.Errorf(
+				"publishing snapshots of volume %s to state: %v",
+				outcomes[i].VolumeTag,
+				result.Error,
+			)
+		}
+	}
+	return nil
+}
+
+// snapshotVolumes takes and deletes snapshots of volumes using the named
+// volume source, if it supports snapshots. The outcomes are returned,
+// along with error statuses for the volumes whose operations failed.
+func snapshotVolumes(
+	ctx *context, sourceName string, args []params.VolumeSnapshotParams,
+) ([]params.VolumeSnapshotOutcome, []params.EntityStatusArgs, error) {
+	source, err := volumeSource(
+		ctx.config.StorageDir, sourceName, storage.ProviderType(sourceName), ctx.config.Registry,
+	)
+	if err != nil && errors.Cause(err) != errNonDynamic {
+		return nil, nil, errors.Annotate(err, "getting volume source")
+	}
+	snapshotter, _ := source.(storage.VolumeSnapshotter)
+
+	var statuses []params.EntityStatusArgs
+	failed := func(tag string, err error) {
+		statuses = append(statuses, params.EntityStatusArgs{
+			Tag:    tag,
+			Status: status.Error.String(),
+			Info:   err.Error(),
+		})
+		logger.Debugf("failed to snapshot %s: %v", tag, err)
+	}
+
+	outcomes := make([]params.VolumeSnapshotOutcome, len(args))
+	for i, arg := range args {
+		outcome := &outcomes[i]
+		outcome.VolumeTag = arg.VolumeTag
+		volumeTag, err := names.ParseVolumeTag(arg.VolumeTag)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		if arg.Create {
+			outcome.CreateAttempted = true
+			if snapshotter == nil {
+				failed(arg.VolumeTag, errors.NotSupportedf(
+					"snapshotting volumes with storage provider %q", sourceName,
+				))
+			} else {
+				results, err := snapshotter.CreateSnapshots([]storage.SnapshotParams{{
+					Volume:   volumeTag,
+					VolumeId: arg.VolumeId,
+				}})
+				if err != nil {
+					return nil, nil, errors.Trace(err)
+				}
+				if err := results[0].Error; err != nil {
+					failed(arg.VolumeTag, errors.Annotate(err, "creating snapshot"))
+				} else {
+					snapshot := results[0].Snapshot
+					outcome.Created = &params.VolumeSnapshot{
+						SnapshotId: snapshot.SnapshotId,
+						Size:       snapshot.Size,
+						Created:    snapshot.Created,
+					}
+				}
+			}
+		}
+		if len(arg.Delete) == 0 {
+			continue
+		}
+		if snapshotter == nil {
+			failed(arg.VolumeTag, errors.NotSupportedf(
+				"deleting snapshots with storage provider %q", sourceName,
+			))
+			continue
+		}
+		deleteErrors, err := snapshotter.DeleteSnapshots(arg.Delete)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		for j, err := range deleteErrors {
+			if err != nil {
+				failed(arg.VolumeTag, errors.Annotate(err, "deleting snapshot"))
+				continue
+			}
+			outcome.Deleted = append(outcome.Deleted, arg.Delete[j])
+		}
+	}
+	return outcomes, statuses, nil
+}
@@ -60,6 +60,16 @@ type VolumeAccessor interface {
 	// that this storage provisioner is responsible for.
 	WatchVolumeAttachments() (watcher.MachineStorageIdsWatcher, error)
 
+	// WatchVolumeResizes watches for changes to volumes that this
+	// storage provisioner is responsible for, so that requests to
+	// resize them may be observed.
+	WatchVolumeResizes() (watcher.StringsWatcher, error)
+
+	// WatchVolumeSnapshots watches for changes to volumes that this
+	// storage provisioner is responsible for, so that requests to
+	// take and delete their snapshots may be observed.
+	WatchVolumeSnapshots() (watcher.StringsWatcher, error)
+
 	// Volumes returns details of volumes with the specified tags.
 	Volumes([]names.VolumeTag) ([]params.VolumeResult, error)
 
@@ -79,6 +89,18 @@ type VolumeAccessor interface {
 	// volume attachments with the specified tags.
 	VolumeAttachmentParams([]params.MachineStorageId) ([]params.VolumeAttachmentParamsResult, error)
 
+	// VolumeResizeParams returns the parameters for resizing the
+	// volumes with the specified tags.
+	VolumeResizeParams([]names.VolumeTag) ([]params.VolumeResizeParamsResult, error)
+
+	// VolumeSnapshotParams returns the parameters for the pending
+	// snapshot operations on the volumes with the specified tags.
+	VolumeSnapshotParams([]names.VolumeTag) ([]params.VolumeSnapshotParamsResult, error)
+
+	// SetVolumeSnapshotOutcomes records the outcomes of the pending
+	// snapshot operations on volumes.
+	SetVolumeSnapshotOutcomes([]params.VolumeSnapshotOutcome) ([]params.ErrorResult, error)
+
 	// SetVolumeInfo records the details of newly provisioned volumes.
 	SetVolumeInfo([]params.Volume) ([]params.ErrorResult, error)
 
@@ -98,6 +120,11 @@ type FilesystemAccessor interface {
 	// that this storage provisioner is responsible for.
 	WatchFilesystemAttachments() (watcher.MachineStorageIdsWatcher, error)
 
+	// WatchFilesystemResizes watches for changes to filesystems that
+	// this storage provisioner is responsible for, so that requests to
+	// resize them may be observed.
+	WatchFilesystemResizes() (watcher.StringsWatcher, error)
+
 	// Filesystems returns details of filesystems with the specified tags.
 	Filesystems([]names.FilesystemTag) ([]params.FilesystemResult, error)
 
@@ -113,6 +140,10 @@ type FilesystemAccessor interface {
 	// filesystem attachments with the specified tags.
 	FilesystemAttachmentParams([]params.MachineStorageId) ([]params.FilesystemAttachmentParamsResult, error)
 
+	// FilesystemResizeParams returns the parameters for resizing the
+	// filesystems with the specified tags.
+	FilesystemResizeParams([]names.FilesystemTag) ([]params.FilesystemResizeParamsResult, error)
+
 	// SetFilesystemInfo records the details of newly provisioned filesystems.
 	SetFilesystemInfo([]params.Filesystem) ([]params.ErrorResult, error)
 
@@ -201,6 +232,9 @@ func (w *storageProvisioner) loop() error {
 		filesystemsChanges           watcher.StringsChannel
 		volumeAttachmentsChanges     watcher.MachineStorageIdsChannel
 		filesystemAttachmentsChanges watcher.MachineStorageIdsChannel
+		volumeResizesChanges         watcher.StringsChannel
+		filesystemResizesChanges     watcher.StringsChannel
+		volumeSnapshotsChanges       watcher.StringsChannel
 		machineBlockDevicesChanges   <-chan struct{}
 	)
 	machineChanges := make(chan names.MachineTag)
@@ -254,6 +288,49 @@ func (w *storageProvisioner) loop() error {
 	}
 	filesystemAttachmentsChanges = filesystemAttachmentsWatcher.Changes()
 
+	// Resizing is not supported by older controllers; if so,
+	// resize requests are never observed.
+	volumeResizesWatcher, err := w.config.Volumes.WatchVolumeResizes()
+	if errors.IsNotSupported(err) {
+		logger.Debugf("not watching volume resizes: %v", err)
+	} else if err != nil {
+		return errors.Annotate(err, "watching volume resizes")
+	} else {
+		if err := w.catacomb.Add(volumeResizesWatcher); err != nil {
+			return errors.Trace(err)
+		}
+		volumeResizesChanges = volumeResizesWatcher.Changes()
+	}
+
+	filesystemResizesWatcher, err := w.config.Filesystems.WatchFilesystemResizes()
+	if errors.IsNotSupported(err) {
+		logger.Debugf("not watching filesystem resizes: %v", err)
+	} else if err != nil {
+		return errors.Annotate(err, "watching filesystem resizes")
+	} else {
+		if err := w.catacomb.Add(filesystemResizesWatcher); err != nil {
+			return errors.Trace(err)
+		}
+		filesystemResizesChanges = filesystemResizesWatcher.Changes()
+	}
+
+	// Snapshots of model-scoped volumes are taken directly with the
+	// storage provider, so only machine-scoped provisioners watch for
+	// snapshot requests. Older controllers do not support them.
+	if _, ok := w.config.Scope.(names.MachineTag); ok {
+		volumeSnapshotsWatcher, err := w.config.Volumes.WatchVolumeSnapshots()
+		if errors.IsNotSupported(err) {
+			logger.Debugf("not watching volume snapshots: %v", err)
+		} else if err != nil {
+			return errors.Annotate(err, "watching volume snapshots")
+		} else {
+			if err := w.catacomb.Add(volumeSnapshotsWatcher); err != nil {
+				return errors.Trace(err)
+			}
+			volumeSnapshotsChanges = volumeSnapshotsWatcher.Changes()
+		}
+	}
+
 	ctx := context{
 		kill:                                 w.catacomb.Kill,
 		addWorker:                            w.catacomb.Add,
@@ -313,6 +390,27 @@ func (w *storageProvisioner) loop() error {
 			if err := filesystemAttachmentsChanged(&ctx, changes); err != nil {
 				return errors.Trace(err)
 			}
+		case changes, ok := <-volumeResizesChanges:
+			if !ok {
+				return errors.New("volume resizes watcher closed")
+			}
+			if err := volumeResizesChanged(&ctx, changes); err != nil {
+				return errors.Trace(err)
+			}
+		case changes, ok := <-filesystemResizesChanges:
+			if !ok {
+				return errors.New("filesystem resizes watcher closed")
+			}
+			if err := filesystemResizesChanged(&ctx, changes); err != nil {
+				return errors.Trace(err)
+			}
+		case changes, ok := <-volumeSnapshotsChanges:
+			if !ok {
+				return errors.New("volume snapshots watcher closed")
+			}
+			if err := volumeSnapshotsChanged(&ctx, changes); err != nil {
+				return errors.Trace(err)
+			}
 		case _, ok := <-machineBlockDevicesChanges:
 			if !ok {
 				return errors.New("machine block devices watcher closed")
@@ -1167,6 +1167,147 @@ func (s *storageProvisionerSuite) TestSetVolumeInfoErrorResultDoesNotStopWorker(
 	assertNoEvent(c, done, "worker exited")
 }
 
+func (s *storageProvisionerSuite) TestVolumeResized(c *gc.C) {
+	volumeInfoSet := make(chan interface{})
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.requestedSizes["volume-1"] = 2048
+	volumeAccessor.setVolumeInfo = func(volumes []params.Volume) ([]params.ErrorResult, error) {
+		defer close(volumeInfoSet)
+		c.Assert(volumes, jc.DeepEquals, []params.Volume{{
+			VolumeTag: "volume-1",
+			Info: params.VolumeInfo{
+				VolumeId: "vol-1",
+				Size:     2048,
+			},
+		}})
+		return nil, nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	// Volume "2" has no pending resize, and is ignored.
+	volumeAccessor.resizesWatcher.changes <- []string{"1", "2"}
+	waitChannel(c, volumeInfoSet, "waiting for volume info to be set")
+}
+
+func (s *storageProvisionerSuite) TestFilesystemResizeNotSupported(c *gc.C) {
+	filesystemAccessor := newMockFilesystemAccessor()
+	filesystemAccessor.requestedSizes["filesystem-1"] = 2048
+	filesystemAccessor.setFilesystemInfo = func([]params.Filesystem) ([]params.ErrorResult, error) {
+		c.Fatalf("unexpected call to SetFilesystemInfo")
+		return nil, nil
+	}
+
+	statusSet := make(chan interface{})
+	args := &workerArgs{filesystems: filesystemAccessor, registry: s.registry}
+	args.statusSetter = &mockStatusSetter{
+		setStatus: func(args []params.EntityStatusArgs) error {
+			defer close(statusSet)
+			c.Assert(args, jc.DeepEquals, []params.EntityStatusArgs{{
+				Tag:    "filesystem-1",
+				Status: "error",
+				Info:   `resizing filesystem: resizing filesystems with storage provider "dummy" not supported`,
+			}})
+			return nil
+		},
+	}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	filesystemAccessor.resizesWatcher.changes <- []string{"1"}
+	waitChannel(c, statusSet, "waiting for status to be set")
+}
+
+func (s *storageProvisionerSuite) TestVolumeSnapshots(c *gc.C) {
+	outcomesSet := make(chan interface{})
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.snapshotParams["volume-0-1"] = params.VolumeSnapshotParams{
+		VolumeTag: "volume-0-1",
+		VolumeId:  "vol-0-1",
+		Provider:  "dummy",
+		Create:    true,
+		Delete:    []string{"vol-0-1@0"},
+	}
+	volumeAccessor.setVolumeSnapshotOutcomes = func(outcomes []params.VolumeSnapshotOutcome) ([]params.ErrorResult, error) {
+		defer close(outcomesSet)
+		c.Assert(outcomes, jc.DeepEquals, []params.VolumeSnapshotOutcome{{
+			VolumeTag:       "volume-0-1",
+			CreateAttempted: true,
+			Created: &params.VolumeSnapshot{
+				SnapshotId: "vol-0-1@1",
+				Size:       1024,
+			},
+			Deleted: []string{"vol-0-1@0"},
+		}})
+		return make([]params.ErrorResult, len(outcomes)), nil
+	}
+
+	args := &workerArgs{
+		scope:    names.NewMachineTag("0"),
+		volumes:  volumeAccessor,
+		registry: s.registry,
+	}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	// Volume "0/2" has no pending snapshot operations, and is ignored.
+	volumeAccessor.snapshotsWatcher.changes <- []string{"0/1", "0/2"}
+	waitChannel(c, outcomesSet, "waiting for snapshot outcomes to be set")
+}
+
+func (s *storageProvisionerSuite) TestVolumeSnapshotFailed(c *gc.C) {
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.snapshotParams["volume-0-1"] = params.VolumeSnapshotParams{
+		VolumeTag: "volume-0-1",
+		VolumeId:  "vol-0-1",
+		Provider:  "dummy",
+		Create:    true,
+	}
+	s.provider.createSnapshotsFunc = func(args []storage.SnapshotParams) ([]storage.CreateSnapshotsResult, error) {
+		return []storage.CreateSnapshotsResult{{Error: errors.New("volume is busy")}}, nil
+	}
+	outcomesSet := make(chan interface{})
+	volumeAccessor.setVolumeSnapshotOutcomes = func(outcomes []params.VolumeSnapshotOutcome) ([]params.ErrorResult, error) {
+		defer close(outcomesSet)
+		// The failed attempt is recorded, so that it is not retried.
+		c.Assert(outcomes, jc.DeepEquals, []params.VolumeSnapshotOutcome{{
+			VolumeTag:       "volume-0-1",
+			CreateAttempted: true,
+		}})
+		return make([]params.ErrorResult, len(outcomes)), nil
+	}
+
+	statusSet := make(chan interface{})
+	args := &workerArgs{
+		scope:    names.NewMachineTag("0"),
+		volumes:  volumeAccessor,
+		registry: s.registry,
+	}
+	args.statusSetter = &mockStatusSetter{
+		setStatus: func(args []params.EntityStatusArgs) error {
+			defer close(statusSet)
+			c.Assert(args, jc.DeepEquals, []params.EntityStatusArgs{{
+				Tag:    "volume-0-1",
+				Status: "error",
+				Info:   "creating snapshot: volume is busy",
+			}})
+			return nil
+		},
+	}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.snapshotsWatcher.changes <- []string{"0/1"}
+	waitChannel(c, statusSet, "waiting for status to be set")
+	waitChannel(c, outcomesSet, "waiting for snapshot outcomes to be set")
+}
+
 func (s *storageProvisionerSuite) TestDetachVolumesUnattached(c *gc.C) {
 	removed := make(chan interface{})
 	removeAttachments := func(ids []params.MachineStorageId) ([]params.ErrorResult, error) {
@@ -1532,6 +1673,73 @@ func (s *storageProvisionerSuite) TestDestroyVolumes(c *gc.C) {
 	assertNoEvent(c, removedChan, "volumes removed")
 }
 
+func (s *storageProvisionerSuite) TestReleaseVolumes(c *gc.C) {
+	releasedVolume := names.NewVolumeTag("1")
+	destroyedVolume := names.NewVolumeTag("2")
+
+	volumeAccessor := newMockVolumeAccessor()
+	v := volumeAccessor.provisionVolume(releasedVolume)
+	v.Releasing = true
+	volumeAccessor.provisionedVolumes[releasedVolume.String()] = v
+	volumeAccessor.provisionVolume(destroyedVolume)
+
+	life := func(tags []names.Tag) ([]params.LifeResult, error) {
+		results := make([]params.LifeResult, len(tags))
+		for i := range results {
+			results[i].Life = params.Dead
+		}
+		return results, nil
+	}
+
+	destroyedChan := make(chan interface{}, 1)
+	s.provider.destroyVolumesFunc = func(volumeIds []string) ([]error, error) {
+		destroyedChan <- volumeIds
+		return make([]error, len(volumeIds)), nil
+	}
+	releasedChan := make(chan interface{}, 1)
+	s.provider.releaseVolumesFunc = func(volumeIds []string) ([]error, error) {
+		releasedChan <- volumeIds
+		return make([]error, len(volumeIds)), nil
+	}
+
+	removedChan := make(chan interface{}, 2)
+	remove := func(tags []names.Tag) ([]params.ErrorResult, error) {
+		removedChan <- tags
+		return make([]params.ErrorResult, len(tags)), nil
+	}
+
+	args := &workerArgs{
+		volumes: volumeAccessor,
+		life: &mockLifecycleManager{
+			life:   life,
+			remove: remove,
+		},
+		registry: s.registry,
+	}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.volumesWatcher.changes <- []string{
+		releasedVolume.Id(),
+		destroyedVolume.Id(),
+	}
+
+	// The releasing volume is released rather than destroyed,
+	// and both volumes are then removed from state.
+	released := waitChannel(c, releasedChan, "waiting for volume to be released")
+	c.Assert(released, jc.DeepEquals, []string{"vol-1"})
+	destroyed := waitChannel(c, destroyedChan, "waiting for volume to be destroyed")
+	c.Assert(destroyed, jc.DeepEquals, []string{"vol-2"})
+
+	var removed []names.Tag
+	for len(removed) < 2 {
+		tags := waitChannel(c, removedChan, "waiting for volumes to be removed").([]names.Tag)
+		removed = append(removed, tags...)
+	}
+	c.Assert(removed, jc.SameContents, []names.Tag{releasedVolume, destroyedVolume})
+}
+
 func (s *storageProvisionerSuite) TestDestroyVolumesRetry(c *gc.C) {
 	volume := names.NewVolumeTag("1")
 	volumeAccessor := newMockVolumeAccessor()
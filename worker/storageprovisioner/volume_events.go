@@ -169,7 +169,7 @@ func processDeadVolumes(ctx *context, tags []names.VolumeTag, volumeResults []pa
 	for _, tag := range tags {
 		removePendingVolume(ctx, tag)
 	}
-	var destroy []names.VolumeTag
+	var destroy []scheduleOp
 	var remove []names.Tag
 	for i, result := range volumeResults {
 		tag := tags[i]
@@ -180,7 +180,10 @@ func processDeadVolumes(ctx *context, tags []names.VolumeTag, volumeResults []pa
 				return errors.Annotate(err, "getting volume info")
 			}
 			updateVolume(ctx, volume)
-			destroy = append(destroy, tag)
+			destroy = append(destroy, &destroyVolumeOp{
+				tag:     tag,
+				release: result.Result.Releasing,
+			})
 			continue
 		}
 		if params.IsCodeNotProvisioned(result.Error) {
@@ -191,11 +194,7 @@ func processDeadVolumes(ctx *context, tags []names.VolumeTag, volumeResults []pa
 		return errors.Annotatef(result.Error, "getting volume information for volume %s", tag.Id())
 	}
 	if len(destroy) > 0 {
-		ops := make([]scheduleOp, len(destroy))
-		for i, tag := range destroy {
-			ops[i] = &destroyVolumeOp{tag: tag}
-		}
-		scheduleOperations(ctx, ops...)
+		scheduleOperations(ctx, destroy...)
 	}
 	if err := removeEntities(ctx, remove); err != nil {
 		return errors.Annotate(err, "removing volumes from state")
@@ -386,8 +385,8 @@ func volumesFromStorage(in []storage.Volume) []params.Volume {
 	out := make([]params.Volume, len(in))
 	for i, v := range in {
 		out[i] = params.Volume{
-			v.Tag.String(),
-			params.VolumeInfo{
+			VolumeTag: v.Tag.String(),
+			Info: params.VolumeInfo{
 				v.VolumeId,
 				v.HardwareId,
 				v.Size,
@@ -474,6 +473,7 @@ func volumeParamsFromParams(in params.VolumeParams) (storage.VolumeParams, error
 		providerType,
 		in.Attributes,
 		in.Tags,
+		in.SnapshotId,
 		attachment,
 	}, nil
 }
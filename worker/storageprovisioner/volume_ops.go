@@ -224,20 +224,39 @@ func destroyVolumes(ctx *context, ops map[names.VolumeTag]*destroyVolumeOp) erro
 		if len(volumeParams) == 0 {
 			continue
 		}
-		volumeIds := make([]string, len(volumeParams))
-		for i, volumeParams := range volumeParams {
+		var resultTags []names.VolumeTag
+		var destroyIds, releaseIds []string
+		var releaseTags []names.VolumeTag
+		for _, volumeParams := range volumeParams {
 			volume, ok := ctx.volumes[volumeParams.Tag]
 			if !ok {
 				return errors.NotFoundf("volume %s", volumeParams.Tag.Id())
 			}
-			volumeIds[i] = volume.VolumeId
+			if ops[volumeParams.Tag].release {
+				releaseIds = append(releaseIds, volume.VolumeId)
+				releaseTags = append(releaseTags, volumeParams.Tag)
+				continue
+			}
+			destroyIds = append(destroyIds, volume.VolumeId)
+			resultTags = append(resultTags, volumeParams.Tag)
 		}
-		errs, err := volumeSource.DestroyVolumes(volumeIds)
-		if err != nil {
-			return errors.Trace(err)
+		var errs []error
+		if len(destroyIds) > 0 {
+			errs, err = volumeSource.DestroyVolumes(destroyIds)
+			if err != nil {
+				return errors.Trace(err)
+			}
+		}
+		if len(releaseIds) > 0 {
+			releaseErrs, err := releaseVolumes(volumeSource, sourceName, releaseIds)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			resultTags = append(resultTags, releaseTags...)
+			errs = append(errs, releaseErrs...)
 		}
 		for i, err := range errs {
-			tag := volumeParams[i].Tag
+			tag := resultTags[i]
 			if err == nil {
 				remove = append(remove, tag)
 				continue
@@ -259,6 +278,20 @@ func destroyVolumes(ctx *context, ops map[names.VolumeTag]*destroyVolumeOp) erro
 	return nil
 }
 
+// releaseVolumes releases volumes from the model using the specified
+// volume source, if it supports releasing volumes. Released volumes are
+// left intact, rather than being destroyed.
+func releaseVolumes(source storage.VolumeSource, sourceName string, volumeIds []string) ([]error, error) {
+	if releaser, ok := source.(storage.VolumeReleaser); ok {
+		return releaser.ReleaseVolumes(volumeIds)
+	}
+	errs := make([]error, len(volumeIds))
+	for i := range errs {
+		errs[i] = errors.NotSupportedf("releasing volumes with storage provider %q", sourceName)
+	}
+	return errs, nil
+}
+
 // detachVolumes destroys volume attachments with the specified parameters.
 func detachVolumes(ctx *context, ops map[params.MachineStorageId]*detachVolumeOp) error {
 	volumeAttachmentParams := make([]storage.VolumeAttachmentParams, 0, len(ops))
@@ -451,7 +484,8 @@ func (op *createVolumeOp) key() interface{} {
 
 type destroyVolumeOp struct {
 	exponentialBackoff
-	tag names.VolumeTag
+	tag     names.VolumeTag
+	release bool
 }
 
 func (op *destroyVolumeOp) key() interface{} {
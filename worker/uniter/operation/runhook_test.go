@@ -4,6 +4,8 @@
 package operation_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -272,6 +274,27 @@ func (s *RunHookSuite) TestExecuteOtherError(c *gc.C) {
 	c.Assert(callbacks.MockNotifyHookCompleted.gotName, gc.IsNil)
 }
 
+func (s *RunHookSuite) TestExecuteTimedOutError(c *gc.C) {
+	runErr := errors.Trace(context.NewHookTimedOutError("config-changed", time.Minute))
+	op, callbacks, runnerFactory := s.getExecuteRunnerTest(c, (operation.Factory).NewRunHook, hooks.ConfigChanged, runErr)
+	_, err := op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	newState, err := op.Execute(operation.State{Started: true})
+	c.Assert(err, gc.Equals, operation.ErrHookFailed)
+	c.Assert(newState, gc.DeepEquals, &operation.State{
+		Started:      true,
+		Kind:         operation.RunHook,
+		Step:         operation.Pending,
+		Hook:         &hook.Info{Kind: hooks.ConfigChanged},
+		HookTimedOut: true,
+	})
+	c.Assert(*runnerFactory.MockNewHookRunner.runner.MockRunHook.gotName, gc.Equals, "some-hook-name")
+	c.Assert(*callbacks.MockNotifyHookFailed.gotName, gc.Equals, "some-hook-name")
+	c.Assert(*callbacks.MockNotifyHookFailed.gotContext, gc.Equals, runnerFactory.MockNewHookRunner.runner.context)
+	c.Assert(callbacks.MockNotifyHookCompleted.gotName, gc.IsNil)
+}
+
 func (s *RunHookSuite) testExecuteSuccess(
 	c *gc.C, before, after operation.State, setStatusCalled bool,
 ) {
@@ -78,6 +78,10 @@ type State struct {
 	// Step indicates the current operation's progression.
 	Step Step `yaml:"opstep"`
 
+	// HookTimedOut indicates that the hook held in Hook failed because
+	// it ran for longer than its timeout.
+	HookTimedOut bool `yaml:"hook-timed-out,omitempty"`
+
 	// Hook holds hook information relevant to the current operation. If Kind
 	// is Continue, it holds the last hook that was executed; if Kind is RunHook,
 	// it holds the running hook; if Kind is Upgrade, a non-nil hook indicates
@@ -165,6 +169,7 @@ type stateChange struct {
 	ActionId        *string
 	CharmURL        *charm.URL
 	HasRunStatusSet bool
+	HookTimedOut    bool
 }
 
 func (change stateChange) apply(state State) *State {
@@ -174,6 +179,7 @@ func (change stateChange) apply(state State) *State {
 	state.ActionId = change.ActionId
 	state.CharmURL = change.CharmURL
 	state.StatusSet = state.StatusSet || change.HasRunStatusSet
+	state.HookTimedOut = change.HookTimedOut
 	return &state
 }
 
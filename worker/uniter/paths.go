@@ -53,6 +53,11 @@ func (paths Paths) GetMetricsSpoolDir() string {
 	return paths.State.MetricsSpoolDir
 }
 
+// GetHookRecordingsDir exists to satisfy the context.Paths interface.
+func (paths Paths) GetHookRecordingsDir() string {
+	return paths.State.HookRecordingsDir
+}
+
 // ComponentDir returns the filesystem path to the directory
 // containing all data files for a component.
 func (paths Paths) ComponentDir(name string) string {
@@ -103,6 +108,10 @@ type StatePaths struct {
 	// MetricsSpoolDir acts as temporary storage for metrics being sent from
 	// the uniter to state.
 	MetricsSpoolDir string
+
+	// HookRecordingsDir holds recordings of the context of failed
+	// hooks, for replaying them offline.
+	HookRecordingsDir string
 }
 
 // NewPaths returns the set of filesystem paths that the supplied unit should
@@ -145,14 +154,15 @@ func NewWorkerPaths(dataDir string, unitTag names.UnitTag, worker string) Paths
 			JujucServerSocket: socket("agent", true),
 		},
 		State: StatePaths{
-			BaseDir:         baseDir,
-			CharmDir:        join(baseDir, "charm"),
-			OperationsFile:  join(stateDir, "uniter"),
-			RelationsDir:    join(stateDir, "relations"),
-			BundlesDir:      join(stateDir, "bundles"),
-			DeployerDir:     join(stateDir, "deployer"),
-			StorageDir:      join(stateDir, "storage"),
-			MetricsSpoolDir: join(stateDir, "spool", "metrics"),
+			BaseDir:           baseDir,
+			CharmDir:          join(baseDir, "charm"),
+			OperationsFile:    join(stateDir, "uniter"),
+			RelationsDir:      join(stateDir, "relations"),
+			BundlesDir:        join(stateDir, "bundles"),
+			DeployerDir:       join(stateDir, "deployer"),
+			StorageDir:        join(stateDir, "storage"),
+			MetricsSpoolDir:   join(stateDir, "spool", "metrics"),
+			HookRecordingsDir: join(stateDir, "hook-recordings"),
 		},
 	}
 }
@@ -44,14 +44,15 @@ func (s *PathsSuite) TestWindows(c *gc.C) {
 			JujucServerSocket: `\\.\pipe\unit-some-application-323-agent`,
 		},
 		State: uniter.StatePaths{
-			BaseDir:         relAgent(),
-			CharmDir:        relAgent("charm"),
-			OperationsFile:  relAgent("state", "uniter"),
-			RelationsDir:    relAgent("state", "relations"),
-			BundlesDir:      relAgent("state", "bundles"),
-			DeployerDir:     relAgent("state", "deployer"),
-			StorageDir:      relAgent("state", "storage"),
-			MetricsSpoolDir: relAgent("state", "spool", "metrics"),
+			BaseDir:           relAgent(),
+			CharmDir:          relAgent("charm"),
+			OperationsFile:    relAgent("state", "uniter"),
+			RelationsDir:      relAgent("state", "relations"),
+			BundlesDir:        relAgent("state", "bundles"),
+			DeployerDir:       relAgent("state", "deployer"),
+			StorageDir:        relAgent("state", "storage"),
+			MetricsSpoolDir:   relAgent("state", "spool", "metrics"),
+			HookRecordingsDir: relAgent("state", "hook-recordings"),
 		},
 	})
 }
@@ -73,14 +74,15 @@ func (s *PathsSuite) TestWorkerPathsWindows(c *gc.C) {
 			JujucServerSocket: `\\.\pipe\unit-some-application-323-some-worker-agent`,
 		},
 		State: uniter.StatePaths{
-			BaseDir:         relAgent(),
-			CharmDir:        relAgent("charm"),
-			OperationsFile:  relAgent("state", "uniter"),
-			RelationsDir:    relAgent("state", "relations"),
-			BundlesDir:      relAgent("state", "bundles"),
-			DeployerDir:     relAgent("state", "deployer"),
-			StorageDir:      relAgent("state", "storage"),
-			MetricsSpoolDir: relAgent("state", "spool", "metrics"),
+			BaseDir:           relAgent(),
+			CharmDir:          relAgent("charm"),
+			OperationsFile:    relAgent("state", "uniter"),
+			RelationsDir:      relAgent("state", "relations"),
+			BundlesDir:        relAgent("state", "bundles"),
+			DeployerDir:       relAgent("state", "deployer"),
+			StorageDir:        relAgent("state", "storage"),
+			MetricsSpoolDir:   relAgent("state", "spool", "metrics"),
+			HookRecordingsDir: relAgent("state", "hook-recordings"),
 		},
 	})
 }
@@ -101,14 +103,15 @@ func (s *PathsSuite) TestOther(c *gc.C) {
 			JujucServerSocket: "@" + relAgent("agent.socket"),
 		},
 		State: uniter.StatePaths{
-			BaseDir:         relAgent(),
-			CharmDir:        relAgent("charm"),
-			OperationsFile:  relAgent("state", "uniter"),
-			RelationsDir:    relAgent("state", "relations"),
-			BundlesDir:      relAgent("state", "bundles"),
-			DeployerDir:     relAgent("state", "deployer"),
-			StorageDir:      relAgent("state", "storage"),
-			MetricsSpoolDir: relAgent("state", "spool", "metrics"),
+			BaseDir:           relAgent(),
+			CharmDir:          relAgent("charm"),
+			OperationsFile:    relAgent("state", "uniter"),
+			RelationsDir:      relAgent("state", "relations"),
+			BundlesDir:        relAgent("state", "bundles"),
+			DeployerDir:       relAgent("state", "deployer"),
+			StorageDir:        relAgent("state", "storage"),
+			MetricsSpoolDir:   relAgent("state", "spool", "metrics"),
+			HookRecordingsDir: relAgent("state", "hook-recordings"),
 		},
 	})
 }
@@ -130,14 +133,15 @@ func (s *PathsSuite) TestWorkerPaths(c *gc.C) {
 			JujucServerSocket: "@" + relAgent(worker+"-agent.socket"),
 		},
 		State: uniter.StatePaths{
-			BaseDir:         relAgent(),
-			CharmDir:        relAgent("charm"),
-			OperationsFile:  relAgent("state", "uniter"),
-			RelationsDir:    relAgent("state", "relations"),
-			BundlesDir:      relAgent("state", "bundles"),
-			DeployerDir:     relAgent("state", "deployer"),
-			StorageDir:      relAgent("state", "storage"),
-			MetricsSpoolDir: relAgent("state", "spool", "metrics"),
+			BaseDir:           relAgent(),
+			CharmDir:          relAgent("charm"),
+			OperationsFile:    relAgent("state", "uniter"),
+			RelationsDir:      relAgent("state", "relations"),
+			BundlesDir:        relAgent("state", "bundles"),
+			DeployerDir:       relAgent("state", "deployer"),
+			StorageDir:        relAgent("state", "storage"),
+			MetricsSpoolDir:   relAgent("state", "spool", "metrics"),
+			HookRecordingsDir: relAgent("state", "hook-recordings"),
 		},
 	})
 }
@@ -149,12 +153,14 @@ func (s *PathsSuite) TestContextInterface(c *gc.C) {
 			JujucServerSocket: "/path/to/socket",
 		},
 		State: uniter.StatePaths{
-			CharmDir:        "/path/to/charm",
-			MetricsSpoolDir: "/path/to/spool/metrics",
+			CharmDir:          "/path/to/charm",
+			MetricsSpoolDir:   "/path/to/spool/metrics",
+			HookRecordingsDir: "/path/to/hook-recordings",
 		},
 	}
 	c.Assert(paths.GetToolsDir(), gc.Equals, "/path/to/tools")
 	c.Assert(paths.GetCharmDir(), gc.Equals, "/path/to/charm")
 	c.Assert(paths.GetJujucSocket(), gc.Equals, "/path/to/socket")
 	c.Assert(paths.GetMetricsSpoolDir(), gc.Equals, "/path/to/spool/metrics")
+	c.Assert(paths.GetHookRecordingsDir(), gc.Equals, "/path/to/hook-recordings")
 }
@@ -46,6 +46,10 @@ type Paths interface {
 	// to store metrics recorded during a single hook run.
 	GetMetricsSpoolDir() string
 
+	// GetHookRecordingsDir returns the path to the directory in which
+	// recordings of failed hooks are stored.
+	GetHookRecordingsDir() string
+
 	// ComponentDir returns the filesystem path to the directory
 	// containing all data files for a component.
 	ComponentDir(name string) string
@@ -159,6 +163,10 @@ type HookContext struct {
 	// proxySettings are the current proxy settings that the uniter knows about.
 	proxySettings proxy.Settings
 
+	// recordFailedHooks indicates whether the context of a failed hook
+	// should be recorded so that the hook can be replayed offline.
+	recordFailedHooks bool
+
 	// meterStatus is the status of the unit's metering.
 	meterStatus *meterStatus
 
@@ -179,6 +187,10 @@ type HookContext struct {
 	// like a juju-run command or a hook
 	process HookProcess
 
+	// hookTimeout is the maximum duration the hook may run for
+	// before it is killed. Zero means the hook may run indefinitely.
+	hookTimeout time.Duration
+
 	// rebootPriority tells us when the hook wants to reboot. If rebootPriority is jujuc.RebootNow
 	// the hook will be killed and requeued
 	rebootPriority jujuc.RebootPriority
@@ -278,6 +290,18 @@ func (ctx *HookContext) Id() string {
 	return ctx.id
 }
 
+// HookTimeout returns the maximum duration the hook may run for. Zero
+// means that the hook may run indefinitely.
+func (ctx *HookContext) HookTimeout() time.Duration {
+	return ctx.hookTimeout
+}
+
+// RecordFailedHooks returns whether the context of a failed hook
+// should be recorded.
+func (ctx *HookContext) RecordFailedHooks() bool {
+	return ctx.recordFailedHooks
+}
+
 func (ctx *HookContext) UnitName() string {
 	return ctx.unitName
 }
@@ -202,7 +202,7 @@ func (f *contextFactory) HookContext(hookInfo hook.Info) (*HookContext, error) {
 		}
 		hookName = fmt.Sprintf("%s-%s", relation.Name(), hookInfo.Kind)
 	}
-	if hookInfo.Kind.IsStorage() {
+	if hook.IsStorage(hookInfo.Kind) {
 		ctx.storageTag = names.NewStorageTag(hookInfo.StorageId)
 		if _, err := ctx.storage.Storage(ctx.storageTag); err != nil {
 			return nil, errors.Annotatef(err, "could not retrieve storage for id: %v", hookInfo.StorageId)
@@ -213,10 +213,35 @@ func (f *contextFactory) HookContext(hookInfo hook.Info) (*HookContext, error) {
 		}
 		hookName = fmt.Sprintf("%s-%s", storageName, hookName)
 	}
+	ctx.hookTimeout, err = f.hookTimeout(hookInfo.Kind)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot determine hook timeout")
+	}
 	ctx.id = f.newId(hookName)
 	return ctx, nil
 }
 
+// hookTimeout returns the maximum duration that a hook of the supplied
+// kind may run for, as configured on the unit's application.
+func (f *contextFactory) hookTimeout(kind hooks.Kind) (time.Duration, error) {
+	application, err := f.unit.Application()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	timeouts, err := application.HookTimeouts()
+	if params.IsCodeNotImplemented(err) {
+		// The controller predates hook timeouts, so hooks
+		// may run indefinitely as they always have.
+		return 0, nil
+	} else if err != nil {
+		return 0, errors.Trace(err)
+	}
+	if timeout, ok := timeouts.Hooks[string(kind)]; ok {
+		return timeout, nil
+	}
+	return timeouts.Default, nil
+}
+
 // CommandContext is part of the ContextFactory interface.
 func (f *contextFactory) CommandContext(commandInfo CommandInfo) (*HookContext, error) {
 	ctx, err := f.coreContext()
@@ -290,6 +315,7 @@ func (f *contextFactory) updateContext(ctx *HookContext) (err error) {
 		return err
 	}
 	ctx.proxySettings = modelConfig.ProxySettings()
+	ctx.recordFailedHooks = modelConfig.RecordFailedHooks()
 
 	// Calling these last, because there's a potential race: they're not guaranteed
 	// to be set in time to be needed for a hook. If they're not, we just leave them
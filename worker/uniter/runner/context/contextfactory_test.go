@@ -161,6 +161,35 @@ func (s *ContextFactorySuite) TestRelationHookContext(c *gc.C) {
 	s.AssertNotStorageContext(c, ctx)
 }
 
+func (s *ContextFactorySuite) TestNewHookContextHookTimeout(c *gc.C) {
+	ctx, err := s.factory.HookContext(hook.Info{Kind: hooks.Install})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.HookTimeout(), gc.Equals, time.Duration(0))
+
+	err = s.service.SetHookTimeouts(state.HookTimeouts{
+		Default: time.Minute,
+		Hooks:   map[string]time.Duration{"install": time.Hour},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx, err = s.factory.HookContext(hook.Info{Kind: hooks.Install})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.HookTimeout(), gc.Equals, time.Hour)
+
+	ctx, err = s.factory.HookContext(hook.Info{Kind: hooks.ConfigChanged})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.HookTimeout(), gc.Equals, time.Minute)
+}
+
+func (s *ContextFactorySuite) TestNewCommandContextNoHookTimeout(c *gc.C) {
+	err := s.service.SetHookTimeouts(state.HookTimeouts{Default: time.Minute})
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx, err := s.factory.CommandContext(context.CommandInfo{RelationId: -1})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.HookTimeout(), gc.Equals, time.Duration(0))
+}
+
 func (s *ContextFactorySuite) TestNewHookContextWithStorage(c *gc.C) {
 	// We need to set up a unit that has storage metadata defined.
 	ch := s.AddTestingCharm(c, "storage-block")
@@ -4,6 +4,9 @@
 package context
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/juju/errors"
 )
 
@@ -27,3 +30,25 @@ func IsMissingHookError(err error) bool {
 func NewMissingHookError(hookName string) error {
 	return &missingHookError{hookName}
 }
+
+type hookTimedOutError struct {
+	hookName string
+	timeout  time.Duration
+}
+
+func (e *hookTimedOutError) Error() string {
+	return fmt.Sprintf("hook %q timed out after %v", e.hookName, e.timeout)
+}
+
+// IsHookTimedOutError returns true if the error indicates that a hook
+// was killed for running longer than its timeout.
+func IsHookTimedOutError(err error) bool {
+	_, ok := err.(*hookTimedOutError)
+	return ok
+}
+
+// NewHookTimedOutError returns an error indicating that the named hook
+// was killed after running for longer than the supplied timeout.
+func NewHookTimedOutError(hookName string, timeout time.Duration) error {
+	return &hookTimedOutError{hookName, timeout}
+}
@@ -394,6 +394,10 @@ func (MockEnvPaths) GetMetricsSpoolDir() string {
 	return "path-to-metrics-spool-dir"
 }
 
+func (MockEnvPaths) GetHookRecordingsDir() string {
+	return "path-to-hook-recordings-dir"
+}
+
 func (MockEnvPaths) ComponentDir(name string) string {
 	return filepath.Join("path-to-base-dir", name)
 }
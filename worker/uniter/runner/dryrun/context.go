@@ -0,0 +1,552 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package dryrun
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/charm.v6-unstable/hooks"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/status"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+// Context is an in-memory implementation of jujuc.Context, holding the
+// state of a simulated unit. Changes made by hook tools are applied to
+// the context immediately. It is safe to use concurrently.
+type Context struct {
+	mu sync.Mutex
+
+	unitName         string
+	config           charm.Settings
+	leader           bool
+	leaderSettings   map[string]string
+	availabilityZone string
+	publicAddress    string
+	privateAddress   string
+
+	unitStatus        jujuc.StatusInfo
+	applicationStatus jujuc.StatusInfo
+	workloadVersion   string
+	ports             []network.PortRange
+	rebootPriority    jujuc.RebootPriority
+
+	relations  map[int]*relation
+	relationId int
+	remoteUnit string
+}
+
+var _ jujuc.Context = (*Context)(nil)
+
+// NewContext returns a Context holding the state described by the
+// scenario, with the charm config defaults taken from config.
+func NewContext(s *Scenario, config *charm.Config) (*Context, error) {
+	settings := config.DefaultSettings()
+	if len(s.Config) > 0 {
+		overrides, err := config.ValidateSettings(s.Config)
+		if err != nil {
+			return nil, errors.Annotate(err, "invalid config")
+		}
+		for k, v := range overrides {
+			settings[k] = v
+		}
+	}
+	application, err := names.UnitApplication(s.Unit)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	privateAddress := s.PrivateAddress
+	if privateAddress == "" {
+		privateAddress = "10.0.0.1"
+	}
+	publicAddress := s.PublicAddress
+	if publicAddress == "" {
+		publicAddress = privateAddress
+	}
+	ctx := &Context{
+		unitName:         s.Unit,
+		config:           settings,
+		leader:           s.Leader,
+		leaderSettings:   copySettings(s.LeaderSettings),
+		availabilityZone: s.AvailabilityZone,
+		publicAddress:    publicAddress,
+		privateAddress:   privateAddress,
+		unitStatus: jujuc.StatusInfo{
+			Tag:    names.NewUnitTag(s.Unit).String(),
+			Status: string(status.Unknown),
+		},
+		applicationStatus: jujuc.StatusInfo{
+			Tag:    names.NewApplicationTag(application).String(),
+			Status: string(status.Unknown),
+		},
+		relations:  make(map[int]*relation),
+		relationId: -1,
+	}
+	for id, r := range s.Relations {
+		remote := make(map[string]params.Settings)
+		for unit, settings := range r.Units {
+			remote[unit] = copySettings(settings)
+		}
+		ctx.relations[id] = &relation{
+			ctx:      ctx,
+			id:       id,
+			name:     r.Endpoint,
+			settings: copySettings(r.Settings),
+			remote:   remote,
+		}
+	}
+	return ctx, nil
+}
+
+// setHook prepares the context for running the given hook, updating
+// the membership of the hook's relation as the hook kind requires.
+func (ctx *Context) setHook(step hookStep) {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	ctx.relationId = step.relationId
+	ctx.remoteUnit = step.remoteUnit
+	r, ok := ctx.relations[step.relationId]
+	if !ok || step.remoteUnit == "" {
+		return
+	}
+	switch {
+	case strings.HasSuffix(step.hook, "-"+string(hooks.RelationJoined)),
+		strings.HasSuffix(step.hook, "-"+string(hooks.RelationChanged)):
+		r.join(step.remoteUnit)
+	case strings.HasSuffix(step.hook, "-"+string(hooks.RelationDeparted)):
+		r.depart(step.remoteUnit)
+	}
+}
+
+// UnitName is part of the jujuc.ContextUnit interface.
+func (ctx *Context) UnitName() string {
+	return ctx.unitName
+}
+
+// ConfigSettings is part of the jujuc.ContextUnit interface.
+func (ctx *Context) ConfigSettings() (charm.Settings, error) {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	result := make(charm.Settings)
+	for k, v := range ctx.config {
+		result[k] = v
+	}
+	return result, nil
+}
+
+// UnitStatus is part of the jujuc.ContextStatus interface.
+func (ctx *Context) UnitStatus() (*jujuc.StatusInfo, error) {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	info := ctx.unitStatus
+	return &info, nil
+}
+
+// SetUnitStatus is part of the jujuc.ContextStatus interface.
+func (ctx *Context) SetUnitStatus(info jujuc.StatusInfo) error {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	info.Tag = ctx.unitStatus.Tag
+	ctx.unitStatus = info
+	return nil
+}
+
+// ApplicationStatus is part of the jujuc.ContextStatus interface.
+func (ctx *Context) ApplicationStatus() (jujuc.ApplicationStatusInfo, error) {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	if !ctx.leader {
+		return jujuc.ApplicationStatusInfo{}, errors.New("this unit is not the leader")
+	}
+	return jujuc.ApplicationStatusInfo{
+		Application: ctx.applicationStatus,
+		Units:       []jujuc.StatusInfo{ctx.unitStatus},
+	}, nil
+}
+
+// SetApplicationStatus is part of the jujuc.ContextStatus interface.
+func (ctx *Context) SetApplicationStatus(info jujuc.StatusInfo) error {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	if !ctx.leader {
+		return errors.New("this unit is not the leader")
+	}
+	info.Tag = ctx.applicationStatus.Tag
+	ctx.applicationStatus = info
+	return nil
+}
+
+// AvailabilityZone is part of the jujuc.ContextInstance interface.
+func (ctx *Context) AvailabilityZone() (string, error) {
+	if ctx.availabilityZone == "" {
+		return "", errors.NotFoundf("availability zone")
+	}
+	return ctx.availabilityZone, nil
+}
+
+// RequestReboot is part of the jujuc.ContextInstance interface.
+func (ctx *Context) RequestReboot(priority jujuc.RebootPriority) error {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	ctx.rebootPriority = priority
+	return nil
+}
+
+// PublicAddress is part of the jujuc.ContextNetworking interface.
+func (ctx *Context) PublicAddress() (string, error) {
+	return ctx.publicAddress, nil
+}
+
+// PrivateAddress is part of the jujuc.ContextNetworking interface.
+func (ctx *Context) PrivateAddress() (string, error) {
+	return ctx.privateAddress, nil
+}
+
+// OpenPorts is part of the jujuc.ContextNetworking interface.
+func (ctx *Context) OpenPorts(protocol string, fromPort, toPort int) error {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	ports := network.PortRange{Protocol: protocol, FromPort: fromPort, ToPort: toPort}
+	for _, existing := range ctx.ports {
+		if existing == ports {
+			return nil
+		}
+		if existing.ConflictsWith(ports) {
+			return errors.Errorf("cannot open %v (unit %q): conflicts with existing %v", ports, ctx.unitName, existing)
+		}
+	}
+	ctx.ports = append(ctx.ports, ports)
+	network.SortPortRanges(ctx.ports)
+	return nil
+}
+
+// ClosePorts is part of the jujuc.ContextNetworking interface.
+func (ctx *Context) ClosePorts(protocol string, fromPort, toPort int) error {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	ports := network.PortRange{Protocol: protocol, FromPort: fromPort, ToPort: toPort}
+	for i, existing := range ctx.ports {
+		if existing == ports {
+			ctx.ports = append(ctx.ports[:i], ctx.ports[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+// OpenedPorts is part of the jujuc.ContextNetworking interface.
+func (ctx *Context) OpenedPorts() []network.PortRange {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	return append([]network.PortRange(nil), ctx.ports...)
+}
+
+// NetworkConfig is part of the jujuc.ContextNetworking interface.
+func (ctx *Context) NetworkConfig(bindingName string) ([]params.NetworkConfig, error) {
+	return []params.NetworkConfig{{Address: ctx.privateAddress}}, nil
+}
+
+// IsLeader is part of the jujuc.ContextLeadership interface.
+func (ctx *Context) IsLeader() (bool, error) {
+	return ctx.leader, nil
+}
+
+// LeaderSettings is part of the jujuc.ContextLeadership interface.
+func (ctx *Context) LeaderSettings() (map[string]string, error) {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	return copySettings(ctx.leaderSettings), nil
+}
+
+// WriteLeaderSettings is part of the jujuc.ContextLeadership interface.
+func (ctx *Context) WriteLeaderSettings(settings map[string]string) error {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	if !ctx.leader {
+		return errors.New("cannot write settings: not the leader")
+	}
+	if ctx.leaderSettings == nil {
+		ctx.leaderSettings = make(map[string]string)
+	}
+	for k, v := range settings {
+		if v == "" {
+			delete(ctx.leaderSettings, k)
+		} else {
+			ctx.leaderSettings[k] = v
+		}
+	}
+	return nil
+}
+
+// AddMetric is part of the jujuc.ContextMetrics interface. Metrics are
+// discarded.
+func (ctx *Context) AddMetric(key, value string, created time.Time) error {
+	return nil
+}
+
+// StorageTags is part of the jujuc.ContextStorage interface.
+func (ctx *Context) StorageTags() ([]names.StorageTag, error) {
+	return nil, nil
+}
+
+// Storage is part of the jujuc.ContextStorage interface.
+func (ctx *Context) Storage(tag names.StorageTag) (jujuc.ContextStorageAttachment, error) {
+	return nil, errors.NotFoundf("storage %q", tag.Id())
+}
+
+// HookStorage is part of the jujuc.ContextStorage interface.
+func (ctx *Context) HookStorage() (jujuc.ContextStorageAttachment, error) {
+	return nil, errors.NotFoundf("hook storage")
+}
+
+// AddUnitStorage is part of the jujuc.ContextStorage interface.
+func (ctx *Context) AddUnitStorage(map[string]params.StorageConstraints) error {
+	return errors.NotSupportedf("adding storage in a dry run")
+}
+
+// Component is part of the jujuc.ContextComponents interface.
+func (ctx *Context) Component(name string) (jujuc.ContextComponent, error) {
+	return nil, errors.NotFoundf("context component %q", name)
+}
+
+// Relation is part of the jujuc.ContextRelations interface.
+func (ctx *Context) Relation(id int) (jujuc.ContextRelation, error) {
+	r, ok := ctx.relations[id]
+	if !ok {
+		return nil, errors.NotFoundf("relation %d", id)
+	}
+	return r, nil
+}
+
+// RelationIds is part of the jujuc.ContextRelations interface.
+func (ctx *Context) RelationIds() ([]int, error) {
+	var ids []int
+	for id := range ctx.relations {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids, nil
+}
+
+// UnitWorkloadVersion is part of the jujuc.ContextVersion interface.
+func (ctx *Context) UnitWorkloadVersion() (string, error) {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	return ctx.workloadVersion, nil
+}
+
+// SetUnitWorkloadVersion is part of the jujuc.ContextVersion interface.
+func (ctx *Context) SetUnitWorkloadVersion(version string) error {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	ctx.workloadVersion = version
+	return nil
+}
+
+// HookRelation is part of the jujuc.Context interface.
+func (ctx *Context) HookRelation() (jujuc.ContextRelation, error) {
+	ctx.mu.Lock()
+	id := ctx.relationId
+	ctx.mu.Unlock()
+	if id == -1 {
+		return nil, errors.NotFoundf("hook relation")
+	}
+	return ctx.Relation(id)
+}
+
+// RemoteUnitName is part of the jujuc.Context interface.
+func (ctx *Context) RemoteUnitName() (string, error) {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	if ctx.remoteUnit == "" {
+		return "", errors.NotFoundf("remote unit")
+	}
+	return ctx.remoteUnit, nil
+}
+
+// ActionParams is part of the jujuc.Context interface.
+func (ctx *Context) ActionParams() (map[string]interface{}, error) {
+	return nil, errors.New("not running an action")
+}
+
+// UpdateActionResults is part of the jujuc.Context interface.
+func (ctx *Context) UpdateActionResults(keys []string, value string) error {
+	return errors.New("not running an action")
+}
+
+// SetActionMessage is part of the jujuc.Context interface.
+func (ctx *Context) SetActionMessage(string) error {
+	return errors.New("not running an action")
+}
+
+// SetActionFailed is part of the jujuc.Context interface.
+func (ctx *Context) SetActionFailed() error {
+	return errors.New("not running an action")
+}
+
+// State holds the state of a simulated unit, as left by the hooks.
+type State struct {
+	UnitStatus        string            `yaml:"unit-status" json:"unit-status"`
+	UnitMessage       string            `yaml:"unit-message,omitempty" json:"unit-message,omitempty"`
+	ApplicationStatus string            `yaml:"application-status" json:"application-status"`
+	WorkloadVersion   string            `yaml:"workload-version,omitempty" json:"workload-version,omitempty"`
+	OpenedPorts       []string          `yaml:"opened-ports,omitempty" json:"opened-ports,omitempty"`
+	LeaderSettings    map[string]string `yaml:"leader-settings,omitempty" json:"leader-settings,omitempty"`
+	RebootRequested   bool              `yaml:"reboot-requested,omitempty" json:"reboot-requested,omitempty"`
+
+	// RelationSettings holds the unit's own settings for each
+	// relation, keyed on the relation's "endpoint:id" identifier.
+	RelationSettings map[string]map[string]string `yaml:"relation-settings,omitempty" json:"relation-settings,omitempty"`
+}
+
+// State returns the current state of the simulated unit.
+func (ctx *Context) State() State {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	state := State{
+		UnitStatus:        ctx.unitStatus.Status,
+		UnitMessage:       ctx.unitStatus.Info,
+		ApplicationStatus: ctx.applicationStatus.Status,
+		WorkloadVersion:   ctx.workloadVersion,
+		LeaderSettings:    copySettings(ctx.leaderSettings),
+		RebootRequested:   ctx.rebootPriority != jujuc.RebootSkip,
+	}
+	for _, ports := range ctx.ports {
+		state.OpenedPorts = append(state.OpenedPorts, ports.String())
+	}
+	for _, r := range ctx.relations {
+		if len(r.settings) == 0 {
+			continue
+		}
+		if state.RelationSettings == nil {
+			state.RelationSettings = make(map[string]map[string]string)
+		}
+		state.RelationSettings[r.FakeId()] = copySettings(r.settings)
+	}
+	return state
+}
+
+// relation is an in-memory implementation of jujuc.ContextRelation.
+type relation struct {
+	ctx      *Context
+	id       int
+	name     string
+	settings params.Settings
+	remote   map[string]params.Settings
+	members  []string
+}
+
+// join adds the remote unit to the relation's members. It must be
+// called with ctx.mu held.
+func (r *relation) join(unit string) {
+	for _, member := range r.members {
+		if member == unit {
+			return
+		}
+	}
+	r.members = append(r.members, unit)
+	sort.Strings(r.members)
+}
+
+// depart removes the remote unit from the relation's members. It must
+// be called with ctx.mu held.
+func (r *relation) depart(unit string) {
+	for i, member := range r.members {
+		if member == unit {
+			r.members = append(r.members[:i], r.members[i+1:]...)
+			return
+		}
+	}
+}
+
+// Id is part of the jujuc.ContextRelation interface.
+func (r *relation) Id() int {
+	return r.id
+}
+
+// Name is part of the jujuc.ContextRelation interface.
+func (r *relation) Name() string {
+	return r.name
+}
+
+// FakeId is part of the jujuc.ContextRelation interface.
+func (r *relation) FakeId() string {
+	return fmt.Sprintf("%s:%d", r.name, r.id)
+}
+
+// Settings is part of the jujuc.ContextRelation interface.
+func (r *relation) Settings() (jujuc.Settings, error) {
+	return relationSettings{r}, nil
+}
+
+// UnitNames is part of the jujuc.ContextRelation interface.
+func (r *relation) UnitNames() []string {
+	r.ctx.mu.Lock()
+	defer r.ctx.mu.Unlock()
+	return append([]string(nil), r.members...)
+}
+
+// ReadSettings is part of the jujuc.ContextRelation interface.
+func (r *relation) ReadSettings(unit string) (params.Settings, error) {
+	r.ctx.mu.Lock()
+	defer r.ctx.mu.Unlock()
+	if unit == r.ctx.unitName {
+		return copySettings(r.settings), nil
+	}
+	settings, ok := r.remote[unit]
+	if !ok {
+		return nil, errors.NotFoundf("unit %q in relation %q", unit, r.FakeId())
+	}
+	return copySettings(settings), nil
+}
+
+// relationSettings is an implementation of jujuc.Settings that writes
+// directly to the unit's settings for a relation.
+type relationSettings struct {
+	r *relation
+}
+
+// Map is part of the jujuc.Settings interface.
+func (s relationSettings) Map() params.Settings {
+	s.r.ctx.mu.Lock()
+	defer s.r.ctx.mu.Unlock()
+	return copySettings(s.r.settings)
+}
+
+// Set is part of the jujuc.Settings interface.
+func (s relationSettings) Set(key, value string) {
+	s.r.ctx.mu.Lock()
+	defer s.r.ctx.mu.Unlock()
+	if s.r.settings == nil {
+		s.r.settings = make(params.Settings)
+	}
+	s.r.settings[key] = value
+}
+
+// Delete is part of the jujuc.Settings interface.
+func (s relationSettings) Delete(key string) {
+	s.r.ctx.mu.Lock()
+	defer s.r.ctx.mu.Unlock()
+	delete(s.r.settings, key)
+}
+
+func copySettings(settings map[string]string) map[string]string {
+	if settings == nil {
+		return nil
+	}
+	result := make(map[string]string, len(settings))
+	for k, v := range settings {
+		result[k] = v
+	}
+	return result
+}
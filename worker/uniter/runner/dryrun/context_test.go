@@ -0,0 +1,113 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package dryrun_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/worker/uniter/runner/dryrun"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type ContextSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ContextSuite{})
+
+var testConfig = &charm.Config{
+	Options: map[string]charm.Option{
+		"title": {Type: "string", Default: "My Title"},
+		"port":  {Type: "int", Default: 80},
+	},
+}
+
+func (s *ContextSuite) TestConfigSettings(c *gc.C) {
+	ctx, err := dryrun.NewContext(&dryrun.Scenario{
+		Unit:   "wordpress/0",
+		Config: map[string]interface{}{"port": 8080},
+	}, testConfig)
+	c.Assert(err, jc.ErrorIsNil)
+	settings, err := ctx.ConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, jc.DeepEquals, charm.Settings{
+		"title": "My Title",
+		"port":  int64(8080),
+	})
+}
+
+func (s *ContextSuite) TestInvalidConfig(c *gc.C) {
+	_, err := dryrun.NewContext(&dryrun.Scenario{
+		Unit:   "wordpress/0",
+		Config: map[string]interface{}{"colour": "red"},
+	}, testConfig)
+	c.Assert(err, gc.ErrorMatches, `invalid config: unknown option "colour"`)
+}
+
+func (s *ContextSuite) TestLeadership(c *gc.C) {
+	ctx, err := dryrun.NewContext(&dryrun.Scenario{Unit: "wordpress/0"}, testConfig)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ctx.WriteLeaderSettings(map[string]string{"password": "sekrit"})
+	c.Assert(err, gc.ErrorMatches, "cannot write settings: not the leader")
+	err = ctx.SetApplicationStatus(jujuc.StatusInfo{Status: "active"})
+	c.Assert(err, gc.ErrorMatches, "this unit is not the leader")
+
+	ctx, err = dryrun.NewContext(&dryrun.Scenario{Unit: "wordpress/0", Leader: true}, testConfig)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ctx.WriteLeaderSettings(map[string]string{"password": "sekrit"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = ctx.SetApplicationStatus(jujuc.StatusInfo{Status: "active"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.State().LeaderSettings, jc.DeepEquals, map[string]string{"password": "sekrit"})
+	c.Assert(ctx.State().ApplicationStatus, gc.Equals, "active")
+}
+
+func (s *ContextSuite) TestPorts(c *gc.C) {
+	ctx, err := dryrun.NewContext(&dryrun.Scenario{Unit: "wordpress/0"}, testConfig)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.OpenPorts("tcp", 80, 80), jc.ErrorIsNil)
+	c.Assert(ctx.OpenPorts("tcp", 8000, 8100), jc.ErrorIsNil)
+	c.Assert(ctx.OpenPorts("tcp", 8080, 8080), gc.ErrorMatches, `cannot open 8080/tcp .*: conflicts with existing 8000-8100/tcp`)
+	c.Assert(ctx.ClosePorts("tcp", 80, 80), jc.ErrorIsNil)
+	c.Assert(ctx.State().OpenedPorts, jc.DeepEquals, []string{"8000-8100/tcp"})
+}
+
+func (s *ContextSuite) TestRelations(c *gc.C) {
+	ctx, err := dryrun.NewContext(&dryrun.Scenario{
+		Unit: "wordpress/0",
+		Relations: []dryrun.RelationScenario{{
+			Endpoint: "db",
+			Settings: map[string]string{"user": "admin"},
+			Units: map[string]map[string]string{
+				"mysql/0": {"host": "10.0.0.2"},
+			},
+		}},
+	}, testConfig)
+	c.Assert(err, jc.ErrorIsNil)
+	ids, err := ctx.RelationIds()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ids, jc.DeepEquals, []int{0})
+
+	relation, err := ctx.Relation(0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(relation.FakeId(), gc.Equals, "db:0")
+	remote, err := relation.ReadSettings("mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(remote, jc.DeepEquals, params.Settings{"host": "10.0.0.2"})
+
+	settings, err := relation.Settings()
+	c.Assert(err, jc.ErrorIsNil)
+	settings.Set("password", "sekrit")
+	settings.Delete("user")
+	c.Assert(ctx.State().RelationSettings, jc.DeepEquals, map[string]map[string]string{
+		"db:0": {"password": "sekrit"},
+	})
+
+	_, err = ctx.HookRelation()
+	c.Assert(err, gc.ErrorMatches, "hook relation not found")
+}
@@ -0,0 +1,83 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package dryrun
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"syscall"
+
+	"github.com/juju/errors"
+)
+
+// isolateScript is run, as root in the hook's new user namespace, to
+// remount the host's filesystems read-only in the hook's new mount
+// namespace, apart from a bind mount of the directory given as its
+// first argument. /proc is left writable, as the process IDs it shows
+// are the host's anyway and the nested user namespace's ID maps are
+// written there. The remaining arguments are then run in that nested
+// user namespace, without the privileges needed to undo the remounts.
+// The mounts are private to the namespace, so the host is untouched.
+const isolateScript = `
+set -e
+dir=$1
+shift
+mount --make-rprivate /
+mount --bind "$dir" "$dir"
+while read -r source target fstype options rest; do
+	target=$(printf '%b' "$target")
+	if [ "$fstype" != proc ] && [ "$target" != "$dir" ]; then
+		mount -o "remount,bind,$options,ro" "$target"
+	fi
+done < /proc/self/mounts
+exec unshare --user --map-root-user "$@"
+`
+
+// isolate arranges for cmd to run in new user, mount, network, PID,
+// IPC and UTS namespaces, without privileges on the host, unable to
+// reach the network, to see or signal the host's processes or to
+// write anywhere on the host's filesystems but within dir.
+func isolate(cmd *exec.Cmd, dir string) error {
+	uid, gid := os.Getuid(), os.Getgid()
+	if uid == 0 {
+		// A hook run as root keeps write access to everything root
+		// owns, user namespace or not.
+		return errors.New("hooks are not run as root")
+	}
+	cmd.Args = append([]string{"sh", "-c", isolateScript, "sh", dir, cmd.Path}, cmd.Args[1:]...)
+	cmd.Path = "/bin/sh"
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Cloneflags: syscall.CLONE_NEWUSER |
+			syscall.CLONE_NEWNS |
+			syscall.CLONE_NEWNET |
+			syscall.CLONE_NEWPID |
+			syscall.CLONE_NEWIPC |
+			syscall.CLONE_NEWUTS,
+		UidMappings: []syscall.SysProcIDMap{{ContainerID: 0, HostID: uid, Size: 1}},
+		GidMappings: []syscall.SysProcIDMap{{ContainerID: 0, HostID: gid, Size: 1}},
+	}
+	return nil
+}
+
+// checkIsolation returns an error if hooks cannot be isolated, because
+// the caller is root, the kernel does not allow unprivileged users to
+// create namespaces, or the host's filesystems cannot be made read-only
+// within them.
+var checkIsolation = func() error {
+	dir, err := ioutil.TempDir("", "juju-dry-run")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer os.RemoveAll(dir)
+	cmd := exec.Command("/bin/true")
+	if err := isolate(cmd, dir); err != nil {
+		return errors.Trace(err)
+	}
+	if output, err := cmd.CombinedOutput(); err != nil {
+		logger.Debugf("isolating hooks: %s", output)
+		return errors.Annotate(err, "cannot create namespaces for hooks")
+	}
+	return nil
+}
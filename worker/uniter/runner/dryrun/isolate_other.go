@@ -0,0 +1,24 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build !linux
+
+package dryrun
+
+import (
+	"os/exec"
+	"runtime"
+
+	"github.com/juju/errors"
+)
+
+// isolate is not supported on this platform.
+func isolate(cmd *exec.Cmd, dir string) error {
+	return errors.NotSupportedf("isolating hooks on %s", runtime.GOOS)
+}
+
+// checkIsolation returns an error, hooks cannot be isolated on this
+// platform.
+var checkIsolation = func() error {
+	return isolate(nil, "")
+}
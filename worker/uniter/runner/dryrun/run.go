@@ -0,0 +1,299 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package dryrun
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	utilexec "github.com/juju/utils/exec"
+	"github.com/juju/utils/fs"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+	"github.com/juju/juju/worker/uniter/runner/replay"
+)
+
+// Params holds the parameters for a dry run of a charm's hooks.
+type Params struct {
+	// Scenario describes the simulated model the hooks run in.
+	Scenario *Scenario
+
+	// CharmDir is the local directory holding the charm. The hooks
+	// are run from a copy of it, so it is left unchanged.
+	CharmDir string
+
+	// JujudPath is the path to the jujud executable, which acts as
+	// the hook tools when invoked via symlinks named for them.
+	JujudPath string
+
+	// Stdout and Stderr receive the output of the hooks.
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+// Validate returns an error if the params are not valid.
+func (p Params) Validate() error {
+	if p.Scenario == nil {
+		return errors.NotValidf("missing Scenario")
+	}
+	if p.CharmDir == "" {
+		return errors.NotValidf("empty CharmDir")
+	}
+	if p.JujudPath == "" {
+		return errors.NotValidf("empty JujudPath")
+	}
+	return nil
+}
+
+// HookResult holds the outcome of running a single hook.
+type HookResult struct {
+	// Hook is the name of the hook.
+	Hook string `yaml:"hook" json:"hook"`
+
+	// Relation and RemoteUnit identify the relation and remote unit
+	// a relation hook was run for.
+	Relation   string `yaml:"relation,omitempty" json:"relation,omitempty"`
+	RemoteUnit string `yaml:"remote-unit,omitempty" json:"remote-unit,omitempty"`
+
+	// Skipped is true if the charm does not implement the hook.
+	Skipped bool `yaml:"skipped,omitempty" json:"skipped,omitempty"`
+
+	// Error is the error with which the hook failed, if any.
+	Error string `yaml:"error,omitempty" json:"error,omitempty"`
+
+	// ToolCalls holds the hook tool invocations made by the hook.
+	ToolCalls []replay.ToolCall `yaml:"tool-calls,omitempty" json:"tool-calls,omitempty"`
+}
+
+// Report holds the outcome of a dry run.
+type Report struct {
+	// Hooks holds the result of each hook, in the order they ran.
+	Hooks []HookResult `yaml:"hooks" json:"hooks"`
+
+	// State holds the state of the simulated unit after the hooks ran.
+	State State `yaml:"state" json:"state"`
+
+	// NotRun holds the reason none of the hooks were run, if they
+	// could not be isolated from the host. Hooks then lists the hooks
+	// that would have run.
+	NotRun string `yaml:"not-run,omitempty" json:"not-run,omitempty"`
+}
+
+// Failed returns the names of the hooks that failed.
+func (r *Report) Failed() []string {
+	var failed []string
+	for _, result := range r.Hooks {
+		if result.Error != "" {
+			failed = append(failed, result.Hook)
+		}
+	}
+	return failed
+}
+
+// Run runs the charm's hooks, as described by the scenario, against a
+// simulated unit whose state is held in memory. Hooks run from a copy
+// of the charm directory, with the hook tools answered by the simulated
+// unit. A hook that fails does not stop the run; the returned report
+// records the tool calls made by each hook and any failure.
+//
+// Hooks are only run isolated from the host: in their own namespaces,
+// without network access, with the host's filesystems read-only apart
+// from the temporary directory holding the copy of the charm, and with
+// none of the caller's environment.
+// Where that is not possible, no hooks are run and the report only
+// lists those that would have been.
+func Run(p Params) (*Report, error) {
+	if err := p.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	ch, err := charm.ReadCharmDir(p.CharmDir)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot read charm")
+	}
+	if err := checkEndpoints(p.Scenario, ch.Meta()); err != nil {
+		return nil, errors.Trace(err)
+	}
+	ctx, err := NewContext(p.Scenario, ch.Config())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	if err := checkIsolation(); err != nil {
+		logger.Warningf("not running hooks: %v", err)
+		return plannedReport(p.Scenario, ctx, p.CharmDir, err), nil
+	}
+
+	tempDir, err := ioutil.TempDir("", "juju-dry-run")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer os.RemoveAll(tempDir)
+	charmDir := filepath.Join(tempDir, "charm")
+	if err := fs.Copy(p.CharmDir, charmDir); err != nil {
+		return nil, errors.Annotate(err, "cannot copy charm")
+	}
+	// Everything else is read-only to the hooks.
+	hookTempDir := filepath.Join(tempDir, "tmp")
+	if err := os.Mkdir(hookTempDir, 0700); err != nil {
+		return nil, errors.Trace(err)
+	}
+	toolsDir := filepath.Join(tempDir, "tools")
+	if err := replay.WriteToolSymlinks(toolsDir, p.JujudPath); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	// The server answers tool calls for the hook currently running,
+	// recording them with that hook's recorder.
+	var mu sync.Mutex
+	var contextId string
+	var recorder *replay.Recorder
+	getCmd := func(ctxId, cmdName string) (cmd.Command, error) {
+		mu.Lock()
+		expected := contextId
+		mu.Unlock()
+		if ctxId != expected {
+			return nil, errors.Errorf("expected context id %q, got %q", expected, ctxId)
+		}
+		return jujuc.NewCommand(ctx, cmdName)
+	}
+	record := func(req jujuc.Request, resp utilexec.ExecResponse) {
+		mu.Lock()
+		r := recorder
+		mu.Unlock()
+		if r != nil {
+			r.Record(req, resp)
+		}
+	}
+	socketPath := filepath.Join(tempDir, "agent.socket")
+	srv, err := jujuc.NewRecordingServer(getCmd, socketPath, record)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot start hook tool server")
+	}
+	go srv.Run()
+	defer srv.Close()
+
+	env := hookEnvironment(p.Scenario, map[string]string{
+		"JUJU_AGENT_SOCKET": socketPath,
+		"JUJU_CHARM_DIR":    charmDir,
+		"CHARM_DIR":         charmDir,
+		"HOME":              charmDir,
+		"TMPDIR":            hookTempDir,
+		"PATH":              toolsDir + string(os.PathListSeparator) + systemPath,
+	})
+	report := &Report{}
+	for i, step := range p.Scenario.steps() {
+		result, relationEnv := newHookResult(ctx, step, charmDir)
+		if result.Skipped {
+			logger.Debugf("skipping unimplemented %q hook", step.hook)
+			report.Hooks = append(report.Hooks, result)
+			continue
+		}
+
+		ctx.setHook(step)
+		hookRecorder := replay.NewRecorder()
+		hookContextId := fmt.Sprintf("%s-%s-%d", p.Scenario.Unit, step.hook, i)
+		mu.Lock()
+		contextId, recorder = hookContextId, hookRecorder
+		mu.Unlock()
+
+		ps := exec.Command(filepath.Join(charmDir, "hooks", step.hook))
+		if err := isolate(ps, tempDir); err != nil {
+			return nil, errors.Trace(err)
+		}
+		ps.Env = append([]string{"JUJU_CONTEXT_ID=" + hookContextId}, env...)
+		ps.Env = append(ps.Env, relationEnv...)
+		ps.Dir = charmDir
+		ps.Stdout = p.Stdout
+		ps.Stderr = p.Stderr
+		if err := ps.Run(); err != nil {
+			result.Error = err.Error()
+		}
+		result.ToolCalls = hookRecorder.ToolCalls()
+		report.Hooks = append(report.Hooks, result)
+	}
+	report.State = ctx.State()
+	return report, nil
+}
+
+// plannedReport returns a report listing the hooks that would be run
+// for the scenario, without running them, for the given reason.
+func plannedReport(s *Scenario, ctx *Context, charmDir string, reason error) *Report {
+	report := &Report{NotRun: reason.Error()}
+	for _, step := range s.steps() {
+		result, _ := newHookResult(ctx, step, charmDir)
+		report.Hooks = append(report.Hooks, result)
+	}
+	report.State = ctx.State()
+	return report
+}
+
+// newHookResult returns the initial result of running the hook for
+// step from charmDir, along with the relation variables to set in its
+// environment.
+func newHookResult(ctx *Context, step hookStep, charmDir string) (HookResult, []string) {
+	result := HookResult{
+		Hook:       step.hook,
+		RemoteUnit: step.remoteUnit,
+	}
+	var relationEnv []string
+	if relation, err := ctx.Relation(step.relationId); err == nil {
+		result.Relation = relation.FakeId()
+		relationEnv = []string{
+			"JUJU_RELATION=" + relation.Name(),
+			"JUJU_RELATION_ID=" + relation.FakeId(),
+			"JUJU_REMOTE_UNIT=" + step.remoteUnit,
+		}
+	}
+	hookPath := filepath.Join(charmDir, "hooks", step.hook)
+	if _, err := os.Stat(hookPath); os.IsNotExist(err) {
+		result.Skipped = true
+	}
+	return result, relationEnv
+}
+
+// checkEndpoints returns an error if any of the scenario's relations
+// are on endpoints the charm does not declare.
+func checkEndpoints(s *Scenario, meta *charm.Meta) error {
+	for _, relation := range s.Relations {
+		_, provides := meta.Provides[relation.Endpoint]
+		_, requires := meta.Requires[relation.Endpoint]
+		_, peers := meta.Peers[relation.Endpoint]
+		if !provides && !requires && !peers {
+			return errors.Errorf("charm %q has no %q relation endpoint", meta.Name, relation.Endpoint)
+		}
+	}
+	return nil
+}
+
+// systemPath is the PATH the hooks run with, after the hook tools.
+const systemPath = "/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin"
+
+// hookEnvironment returns the environment common to all of the hooks
+// run for the scenario. None of the current process's environment is
+// passed on; only the scenario's unit and model and the supplied values
+// are set.
+func hookEnvironment(s *Scenario, values map[string]string) []string {
+	var env []string
+	model := s.Model
+	if model == "" {
+		model = "dry-run"
+	}
+	env = append(env,
+		"JUJU_UNIT_NAME="+s.Unit,
+		"JUJU_MODEL_NAME="+model,
+		"JUJU_AVAILABILITY_ZONE="+s.AvailabilityZone,
+	)
+	for key, value := range values {
+		env = append(env, key+"="+value)
+	}
+	return env
+}
@@ -0,0 +1,163 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package dryrun_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/dryrun"
+)
+
+type RunSuite struct {
+	testing.IsolationSuite
+	charmDir string
+}
+
+var _ = gc.Suite(&RunSuite{})
+
+func (s *RunSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	if runtime.GOOS == "windows" {
+		c.Skip("dry runs of hooks are not supported on windows")
+	}
+	s.charmDir = c.MkDir()
+	writeFile(c, s.charmDir, "metadata.yaml", 0644, `
+name: wordpress
+summary: a blog
+description: a blog
+requires:
+  db:
+    interface: mysql
+`)
+	writeFile(c, s.charmDir, "config.yaml", 0644, `
+options:
+  title:
+    type: string
+    default: My Title
+`)
+	writeFile(c, s.charmDir, "hooks/install", 0755, `#!/bin/sh
+echo installing $JUJU_UNIT_NAME in $JUJU_MODEL_NAME with ${DRY_RUN_SECRET-no secret}
+touch $CHARM_DIR/installed
+`)
+	writeFile(c, s.charmDir, "hooks/config-changed", 0755, `#!/bin/sh
+exit 1
+`)
+	writeFile(c, s.charmDir, "hooks/db-relation-changed", 0755, `#!/bin/sh
+echo $JUJU_RELATION $JUJU_RELATION_ID $JUJU_REMOTE_UNIT
+`)
+}
+
+func (s *RunSuite) TestValidate(c *gc.C) {
+	for i, test := range []struct {
+		params dryrun.Params
+		err    string
+	}{{
+		params: dryrun.Params{CharmDir: "/charm", JujudPath: "/jujud"},
+		err:    "missing Scenario not valid",
+	}, {
+		params: dryrun.Params{Scenario: &dryrun.Scenario{}, JujudPath: "/jujud"},
+		err:    "empty CharmDir not valid",
+	}, {
+		params: dryrun.Params{Scenario: &dryrun.Scenario{}, CharmDir: "/charm"},
+		err:    "empty JujudPath not valid",
+	}} {
+		c.Logf("test %d", i)
+		c.Check(test.params.Validate(), gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *RunSuite) params(stdout, stderr *bytes.Buffer) dryrun.Params {
+	return dryrun.Params{
+		Scenario: &dryrun.Scenario{
+			Unit: "wordpress/0",
+			Relations: []dryrun.RelationScenario{{
+				Endpoint: "db",
+				Units: map[string]map[string]string{
+					"mysql/0": {"host": "10.0.0.2"},
+				},
+			}},
+		},
+		CharmDir:  s.charmDir,
+		JujudPath: "/bin/true",
+		Stdout:    stdout,
+		Stderr:    stderr,
+	}
+}
+
+func (s *RunSuite) TestRun(c *gc.C) {
+	if err := (*dryrun.CheckIsolation)(); err != nil {
+		c.Skip(err.Error())
+	}
+	s.PatchEnvironment("DRY_RUN_SECRET", "a secret")
+	var stdout, stderr bytes.Buffer
+	report, err := dryrun.Run(s.params(&stdout, &stderr))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(report.NotRun, gc.Equals, "")
+	c.Assert(report.Hooks, jc.DeepEquals, []dryrun.HookResult{
+		{Hook: "install"},
+		{Hook: "leader-settings-changed", Skipped: true},
+		{Hook: "config-changed", Error: "exit status 1"},
+		{Hook: "start", Skipped: true},
+		{Hook: "db-relation-joined", Relation: "db:0", RemoteUnit: "mysql/0", Skipped: true},
+		{Hook: "db-relation-changed", Relation: "db:0", RemoteUnit: "mysql/0"},
+	})
+	c.Assert(report.Failed(), jc.DeepEquals, []string{"config-changed"})
+	c.Assert(report.State.UnitStatus, gc.Equals, "unknown")
+	c.Assert(stdout.String(), gc.Equals, "installing wordpress/0 in dry-run with no secret\ndb db:0 mysql/0\n")
+
+	// The hooks ran against a copy of the charm.
+	_, err = os.Stat(filepath.Join(s.charmDir, "installed"))
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+}
+
+func (s *RunSuite) TestRunNotIsolated(c *gc.C) {
+	s.PatchValue(dryrun.CheckIsolation, func() error {
+		return errors.New("hooks are not run as root")
+	})
+	var stdout, stderr bytes.Buffer
+	report, err := dryrun.Run(s.params(&stdout, &stderr))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(report.NotRun, gc.Equals, "hooks are not run as root")
+	c.Assert(report.Hooks, jc.DeepEquals, []dryrun.HookResult{
+		{Hook: "install"},
+		{Hook: "leader-settings-changed", Skipped: true},
+		{Hook: "config-changed"},
+		{Hook: "start", Skipped: true},
+		{Hook: "db-relation-joined", Relation: "db:0", RemoteUnit: "mysql/0", Skipped: true},
+		{Hook: "db-relation-changed", Relation: "db:0", RemoteUnit: "mysql/0"},
+	})
+	c.Assert(report.Failed(), gc.HasLen, 0)
+	c.Assert(stdout.String(), gc.Equals, "")
+	_, err = os.Stat(filepath.Join(s.charmDir, "installed"))
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+}
+
+func (s *RunSuite) TestRunUnknownEndpoint(c *gc.C) {
+	_, err := dryrun.Run(dryrun.Params{
+		Scenario: &dryrun.Scenario{
+			Unit:      "wordpress/0",
+			Relations: []dryrun.RelationScenario{{Endpoint: "cache"}},
+		},
+		CharmDir:  s.charmDir,
+		JujudPath: "/bin/true",
+	})
+	c.Assert(err, gc.ErrorMatches, `charm "wordpress" has no "cache" relation endpoint`)
+}
+
+func writeFile(c *gc.C, dir, name string, mode os.FileMode, content string) {
+	path := filepath.Join(dir, name)
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(path, []byte(content), mode)
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -0,0 +1,182 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package dryrun supports running a local charm's hooks in a sandbox,
+// against a simulated model described by a scenario, without deploying
+// the charm.
+package dryrun
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils"
+	"gopkg.in/juju/charm.v6-unstable/hooks"
+	"gopkg.in/juju/names.v2"
+)
+
+var logger = loggo.GetLogger("juju.worker.uniter.runner.dryrun")
+
+// Scenario describes the simulated model in which a charm's hooks are
+// run.
+type Scenario struct {
+	// Unit is the name of the unit running the hooks.
+	Unit string `yaml:"unit"`
+
+	// Model is the name of the model. It defaults to "dry-run".
+	Model string `yaml:"model,omitempty"`
+
+	// Leader is true if the unit is its application's leader.
+	Leader bool `yaml:"leader,omitempty"`
+
+	// Config holds the charm config settings that differ from the
+	// charm's defaults.
+	Config map[string]interface{} `yaml:"config,omitempty"`
+
+	// LeaderSettings holds the application's leader settings.
+	LeaderSettings map[string]string `yaml:"leader-settings,omitempty"`
+
+	// AvailabilityZone, PublicAddress and PrivateAddress describe the
+	// machine the unit is running on.
+	AvailabilityZone string `yaml:"availability-zone,omitempty"`
+	PublicAddress    string `yaml:"public-address,omitempty"`
+	PrivateAddress   string `yaml:"private-address,omitempty"`
+
+	// Relations holds the relations the unit participates in. Each is
+	// given the id of its index in the list.
+	Relations []RelationScenario `yaml:"relations,omitempty"`
+
+	// Hooks holds the names of the hooks to run, in order. If empty,
+	// the hooks run when a unit is deployed and its relations are
+	// established are run. A relation hook is run for the first
+	// relation on its endpoint, with the first of its remote units.
+	Hooks []string `yaml:"hooks,omitempty"`
+}
+
+// RelationScenario describes a relation in a scenario.
+type RelationScenario struct {
+	// Endpoint is the name of the relation's endpoint in the charm.
+	Endpoint string `yaml:"endpoint"`
+
+	// Settings holds the unit's own settings for the relation.
+	Settings map[string]string `yaml:"settings,omitempty"`
+
+	// Units holds the settings of each remote unit, keyed on unit name.
+	Units map[string]map[string]string `yaml:"units,omitempty"`
+}
+
+// ReadScenario reads the scenario stored in the named YAML file.
+func ReadScenario(path string) (*Scenario, error) {
+	var s Scenario
+	if err := utils.ReadYaml(path, &s); err != nil {
+		return nil, errors.Annotate(err, "cannot read scenario")
+	}
+	if err := s.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &s, nil
+}
+
+// Validate returns an error if the scenario is not valid.
+func (s *Scenario) Validate() error {
+	if !names.IsValidUnit(s.Unit) {
+		return errors.NotValidf("unit name %q", s.Unit)
+	}
+	for i, relation := range s.Relations {
+		if relation.Endpoint == "" {
+			return errors.NotValidf("relation %d with no endpoint", i)
+		}
+		for unit := range relation.Units {
+			if !names.IsValidUnit(unit) {
+				return errors.NotValidf("relation %d unit name %q", i, unit)
+			}
+		}
+	}
+	for _, hook := range s.Hooks {
+		if _, err := s.step(hook); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// hookStep identifies a hook to run, and the relation and remote unit
+// it is run for, if any.
+type hookStep struct {
+	hook       string
+	relationId int
+	remoteUnit string
+}
+
+// steps returns the hooks to run for the scenario, in order.
+func (s *Scenario) steps() []hookStep {
+	if len(s.Hooks) > 0 {
+		result := make([]hookStep, len(s.Hooks))
+		for i, hook := range s.Hooks {
+			// The hooks were checked by Validate.
+			result[i], _ = s.step(hook)
+		}
+		return result
+	}
+
+	leaderHook := hooks.LeaderSettingsChanged
+	if s.Leader {
+		leaderHook = hooks.LeaderElected
+	}
+	result := []hookStep{
+		{hook: string(hooks.Install), relationId: -1},
+		{hook: string(leaderHook), relationId: -1},
+		{hook: string(hooks.ConfigChanged), relationId: -1},
+		{hook: string(hooks.Start), relationId: -1},
+	}
+	for id, relation := range s.Relations {
+		for _, unit := range remoteUnits(relation) {
+			for _, kind := range []hooks.Kind{hooks.RelationJoined, hooks.RelationChanged} {
+				result = append(result, hookStep{
+					hook:       relation.Endpoint + "-" + string(kind),
+					relationId: id,
+					remoteUnit: unit,
+				})
+			}
+		}
+	}
+	return result
+}
+
+// step returns the step that runs the named hook.
+func (s *Scenario) step(hook string) (hookStep, error) {
+	for _, kind := range []hooks.Kind{
+		hooks.RelationJoined, hooks.RelationChanged,
+		hooks.RelationDeparted, hooks.RelationBroken,
+	} {
+		suffix := "-" + string(kind)
+		if !strings.HasSuffix(hook, suffix) {
+			continue
+		}
+		endpoint := strings.TrimSuffix(hook, suffix)
+		for id, relation := range s.Relations {
+			if relation.Endpoint != endpoint {
+				continue
+			}
+			step := hookStep{hook: hook, relationId: id}
+			if units := remoteUnits(relation); kind != hooks.RelationBroken && len(units) > 0 {
+				step.remoteUnit = units[0]
+			}
+			return step, nil
+		}
+		return hookStep{}, errors.NotValidf("hook %q with no %q relation", hook, endpoint)
+	}
+	return hookStep{hook: hook, relationId: -1}, nil
+}
+
+// remoteUnits returns the sorted names of the relation's remote units.
+func remoteUnits(relation RelationScenario) []string {
+	var units []string
+	for unit := range relation.Units {
+		units = append(units, unit)
+	}
+	sort.Strings(units)
+	return units
+}
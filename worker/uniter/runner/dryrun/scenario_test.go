@@ -0,0 +1,89 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package dryrun_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/dryrun"
+)
+
+type ScenarioSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ScenarioSuite{})
+
+func (s *ScenarioSuite) TestReadScenario(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "scenario.yaml")
+	err := ioutil.WriteFile(path, []byte(`
+unit: mysql/0
+leader: true
+config:
+  port: 3306
+relations:
+- endpoint: db
+  units:
+    wordpress/0: {host: 10.0.0.2}
+hooks: [install, db-relation-joined]
+`), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	scenario, err := dryrun.ReadScenario(path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(scenario, jc.DeepEquals, &dryrun.Scenario{
+		Unit:   "mysql/0",
+		Leader: true,
+		Config: map[string]interface{}{"port": 3306},
+		Relations: []dryrun.RelationScenario{{
+			Endpoint: "db",
+			Units: map[string]map[string]string{
+				"wordpress/0": {"host": "10.0.0.2"},
+			},
+		}},
+		Hooks: []string{"install", "db-relation-joined"},
+	})
+}
+
+func (s *ScenarioSuite) TestValidate(c *gc.C) {
+	for i, test := range []struct {
+		scenario dryrun.Scenario
+		err      string
+	}{{
+		scenario: dryrun.Scenario{Unit: "mysql"},
+		err:      `unit name "mysql" not valid`,
+	}, {
+		scenario: dryrun.Scenario{
+			Unit:      "mysql/0",
+			Relations: []dryrun.RelationScenario{{}},
+		},
+		err: `relation 0 with no endpoint not valid`,
+	}, {
+		scenario: dryrun.Scenario{
+			Unit: "mysql/0",
+			Relations: []dryrun.RelationScenario{{
+				Endpoint: "db",
+				Units:    map[string]map[string]string{"wordpress": nil},
+			}},
+		},
+		err: `relation 0 unit name "wordpress" not valid`,
+	}, {
+		scenario: dryrun.Scenario{
+			Unit:  "mysql/0",
+			Hooks: []string{"db-relation-changed"},
+		},
+		err: `hook "db-relation-changed" with no "db" relation not valid`,
+	}} {
+		c.Logf("test %d", i)
+		err := test.scenario.Validate()
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
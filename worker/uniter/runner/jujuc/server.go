@@ -130,10 +130,15 @@ type Request struct {
 // CmdGetter looks up a Command implementation connected to a particular Context.
 type CmdGetter func(contextId, cmdName string) (cmd.Command, error)
 
+// RecordFunc is called with every hook tool request handled by a
+// Server, along with the response returned to the hook tool.
+type RecordFunc func(req Request, resp exec.ExecResponse)
+
 // Jujuc implements the jujuc command in the form required by net/rpc.
 type Jujuc struct {
 	mu     sync.Mutex
 	getCmd CmdGetter
+	record RecordFunc
 }
 
 // badReqErrorf returns an error indicating a bad Request.
@@ -183,6 +188,9 @@ func (j *Jujuc) Main(req Request, resp *exec.ExecResponse) error {
 	}
 	resp.Stdout = stdout.Bytes()
 	resp.Stderr = stderr.Bytes()
+	if j.record != nil {
+		j.record(req, *resp)
+	}
 	return nil
 }
 
@@ -201,8 +209,14 @@ type Server struct {
 // remote command invocations against an appropriate Context. It will not
 // actually do so until Run is called.
 func NewServer(getCmd CmdGetter, socketPath string) (*Server, error) {
+	return NewRecordingServer(getCmd, socketPath, nil)
+}
+
+// NewRecordingServer is like NewServer, but the supplied RecordFunc, if
+// not nil, is called with each command invocation and its response.
+func NewRecordingServer(getCmd CmdGetter, socketPath string, record RecordFunc) (*Server, error) {
 	server := rpc.NewServer()
-	if err := server.Register(&Jujuc{getCmd: getCmd}); err != nil {
+	if err := server.Register(&Jujuc{getCmd: getCmd, record: record}); err != nil {
 		return nil, err
 	}
 	listener, err := sockets.Listen(socketPath)
@@ -84,10 +84,16 @@ type ServerSuite struct {
 	server   *jujuc.Server
 	sockPath string
 	err      chan error
+	recorded []recordedCall
 }
 
 var _ = gc.Suite(&ServerSuite{})
 
+type recordedCall struct {
+	req  jujuc.Request
+	resp exec.ExecResponse
+}
+
 func (s *ServerSuite) osDependentSockPath(c *gc.C) string {
 	pipeRoot := c.MkDir()
 	var sock string
@@ -102,7 +108,11 @@ func (s *ServerSuite) osDependentSockPath(c *gc.C) string {
 func (s *ServerSuite) SetUpTest(c *gc.C) {
 	s.BaseSuite.SetUpTest(c)
 	s.sockPath = s.osDependentSockPath(c)
-	srv, err := jujuc.NewServer(factory, s.sockPath)
+	s.recorded = nil
+	record := func(req jujuc.Request, resp exec.ExecResponse) {
+		s.recorded = append(s.recorded, recordedCall{req, resp})
+	}
+	srv, err := jujuc.NewRecordingServer(factory, s.sockPath, record)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(srv, gc.NotNil)
 	s.server = srv
@@ -235,6 +245,25 @@ func (s *ServerSuite) TestParseError(c *gc.C) {
 	c.Assert(string(resp.Stderr), gc.Equals, "error: flag provided but not defined: --cheese\n")
 }
 
+func (s *ServerSuite) TestRecording(c *gc.C) {
+	dir := c.MkDir()
+	req := jujuc.Request{
+		ContextId:   "validCtx",
+		Dir:         dir,
+		CommandName: "remote",
+		Args:        []string{"--value", "something"},
+	}
+	resp, err := s.Call(c, req)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AssertBadCommand(c, []string{"remote", "--value", "error"}, 1)
+	c.Assert(s.recorded, gc.HasLen, 2)
+	c.Assert(s.recorded[0].req, jc.DeepEquals, req)
+	c.Assert(s.recorded[0].resp, jc.DeepEquals, resp)
+	c.Assert(s.recorded[1].req.Args, jc.DeepEquals, []string{"--value", "error"})
+	c.Assert(s.recorded[1].resp.Code, gc.Equals, 1)
+	c.Assert(string(s.recorded[1].resp.Stderr), gc.Equals, "error: blam\n")
+}
+
 func (s *ServerSuite) TestBrokenCommand(c *gc.C) {
 	resp := s.AssertBadCommand(c, []string{"remote", "--value", "error"}, 1)
 	c.Assert(string(resp.Stdout), gc.Equals, "")
@@ -0,0 +1,18 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package runner
+
+import (
+	"os"
+	"os/exec"
+)
+
+// setProcessGroup does nothing on Windows, where processes
+// are not grouped.
+func setProcessGroup(cmd *exec.Cmd) {}
+
+// killProcessGroup kills the supplied process.
+func killProcessGroup(p *os.Process) error {
+	return p.Kill()
+}
@@ -0,0 +1,266 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package replay supports recording the context in which a hook failed,
+// and replaying the hook later against a fake hook tool server that
+// answers from the recording.
+package replay
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils"
+	"github.com/juju/utils/exec"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+var logger = loggo.GetLogger("juju.worker.uniter.runner.replay")
+
+// maxRecordings is the number of recordings kept in a recordings
+// directory; older recordings are removed when new ones are written.
+const maxRecordings = 10
+
+// recordingSuffix is the filename suffix of recording files.
+const recordingSuffix = ".yaml"
+
+// Recording holds everything needed to replay a hook: the environment
+// it ran in, the state visible to it and the responses given to the hook
+// tools it invoked.
+type Recording struct {
+	// Unit is the name of the unit that ran the hook.
+	Unit string `yaml:"unit"`
+
+	// Hook is the name of the hook.
+	Hook string `yaml:"hook"`
+
+	// Recorded is the time at which the recording was made.
+	Recorded time.Time `yaml:"recorded"`
+
+	// Error is the error with which the hook failed.
+	Error string `yaml:"error,omitempty"`
+
+	// Environment holds the environment variables, in "key=value"
+	// form, that the hook was run with.
+	Environment []string `yaml:"environment"`
+
+	// Config holds the charm config settings.
+	Config map[string]interface{} `yaml:"config,omitempty"`
+
+	// LeaderSettings holds the application's leader settings.
+	LeaderSettings map[string]string `yaml:"leader-settings,omitempty"`
+
+	// Relations holds the settings of the relations the unit was
+	// participating in.
+	Relations []RelationRecording `yaml:"relations,omitempty"`
+
+	// ToolCalls holds the hook tool invocations made by the hook, in
+	// the order they were made.
+	ToolCalls []ToolCall `yaml:"tool-calls,omitempty"`
+}
+
+// RelationRecording holds the settings of a single relation.
+type RelationRecording struct {
+	// Id is the relation id.
+	Id int `yaml:"id"`
+
+	// Name is the name of the relation's endpoint in the local charm.
+	Name string `yaml:"name"`
+
+	// Settings holds the local unit's settings.
+	Settings map[string]string `yaml:"settings,omitempty"`
+
+	// Remote holds the settings of each remote unit, keyed on unit name.
+	Remote map[string]map[string]string `yaml:"remote,omitempty"`
+}
+
+// ToolCall holds a single hook tool invocation and its response.
+type ToolCall struct {
+	Command string   `yaml:"command" json:"command"`
+	Args    []string `yaml:"args,omitempty" json:"args,omitempty"`
+	Stdin   string   `yaml:"stdin,omitempty" json:"stdin,omitempty"`
+	Code    int      `yaml:"code" json:"code"`
+	Stdout  string   `yaml:"stdout,omitempty" json:"stdout,omitempty"`
+	Stderr  string   `yaml:"stderr,omitempty" json:"stderr,omitempty"`
+}
+
+// Recorder accumulates the hook tool invocations made while a hook
+// runs. It is safe to use concurrently.
+type Recorder struct {
+	mu    sync.Mutex
+	calls []ToolCall
+}
+
+// NewRecorder returns a new, empty Recorder.
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// Record records a hook tool request and its response. It has the
+// signature of a jujuc.RecordFunc.
+func (r *Recorder) Record(req jujuc.Request, resp exec.ExecResponse) {
+	call := ToolCall{
+		Command: strings.TrimSuffix(req.CommandName, jujuc.CmdSuffix),
+		Args:    append([]string(nil), req.Args...),
+		Code:    resp.Code,
+		Stdout:  string(resp.Stdout),
+		Stderr:  string(resp.Stderr),
+	}
+	if req.StdinSet {
+		call.Stdin = string(req.Stdin)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, call)
+}
+
+// ToolCalls returns the hook tool invocations recorded so far.
+func (r *Recorder) ToolCalls() []ToolCall {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]ToolCall(nil), r.calls...)
+}
+
+// Capture returns a Recording of the named hook, which failed with the
+// supplied error, holding the hook's environment, the state visible
+// through ctx and the hook tool invocations recorded so far. State that
+// cannot be read is logged and left out of the recording.
+func (r *Recorder) Capture(ctx jujuc.Context, hookName string, env []string, hookErr error) *Recording {
+	rec := &Recording{
+		Unit:        ctx.UnitName(),
+		Hook:        hookName,
+		Recorded:    time.Now().UTC(),
+		Environment: append([]string(nil), env...),
+		ToolCalls:   r.ToolCalls(),
+	}
+	if hookErr != nil {
+		rec.Error = hookErr.Error()
+	}
+	if config, err := ctx.ConfigSettings(); err != nil {
+		logger.Warningf("cannot record config settings: %v", err)
+	} else {
+		rec.Config = config
+	}
+	if settings, err := ctx.LeaderSettings(); err != nil {
+		logger.Warningf("cannot record leader settings: %v", err)
+	} else {
+		rec.LeaderSettings = settings
+	}
+	ids, err := ctx.RelationIds()
+	if err != nil {
+		logger.Warningf("cannot record relations: %v", err)
+		return rec
+	}
+	ids = append([]int(nil), ids...)
+	sort.Ints(ids)
+	for _, id := range ids {
+		relation, err := captureRelation(ctx, id)
+		if err != nil {
+			logger.Warningf("cannot record relation %d: %v", id, err)
+			continue
+		}
+		rec.Relations = append(rec.Relations, relation)
+	}
+	return rec
+}
+
+func captureRelation(ctx jujuc.Context, id int) (RelationRecording, error) {
+	relation, err := ctx.Relation(id)
+	if err != nil {
+		return RelationRecording{}, errors.Trace(err)
+	}
+	result := RelationRecording{
+		Id:   id,
+		Name: relation.Name(),
+	}
+	settings, err := relation.Settings()
+	if err != nil {
+		return RelationRecording{}, errors.Trace(err)
+	}
+	result.Settings = settings.Map()
+	for _, unit := range relation.UnitNames() {
+		remote, err := relation.ReadSettings(unit)
+		if err != nil {
+			return RelationRecording{}, errors.Annotatef(err, "reading settings of %q", unit)
+		}
+		if result.Remote == nil {
+			result.Remote = make(map[string]map[string]string)
+		}
+		result.Remote[unit] = remote
+	}
+	return result, nil
+}
+
+// WriteRecording writes the recording to a new file in dir, creating
+// the directory if necessary, and returns the path of the file. Only
+// the most recent recordings are kept in dir.
+func WriteRecording(dir string, rec *Recording) (string, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", errors.Trace(err)
+	}
+	name := fmt.Sprintf("%s-%s%s", rec.Hook, rec.Recorded.Format("20060102T150405.000000000"), recordingSuffix)
+	path := filepath.Join(dir, name)
+	if err := utils.WriteYaml(path, rec); err != nil {
+		return "", errors.Annotate(err, "cannot write hook recording")
+	}
+	if err := pruneRecordings(dir, maxRecordings); err != nil {
+		logger.Warningf("cannot remove old hook recordings: %v", err)
+	}
+	return path, nil
+}
+
+// ReadRecording reads the recording stored in the named file.
+func ReadRecording(path string) (*Recording, error) {
+	var rec Recording
+	if err := utils.ReadYaml(path, &rec); err != nil {
+		return nil, errors.Annotate(err, "cannot read hook recording")
+	}
+	if rec.Hook == "" {
+		return nil, errors.NotValidf("hook recording %q with no hook", path)
+	}
+	return &rec, nil
+}
+
+// pruneRecordings removes all but the newest keep recordings from dir.
+func pruneRecordings(dir string, keep int) error {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var recordings []os.FileInfo
+	for _, info := range infos {
+		if !info.IsDir() && strings.HasSuffix(info.Name(), recordingSuffix) {
+			recordings = append(recordings, info)
+		}
+	}
+	if len(recordings) <= keep {
+		return nil
+	}
+	sort.Sort(byModTime(recordings))
+	for _, info := range recordings[:len(recordings)-keep] {
+		if err := os.Remove(filepath.Join(dir, info.Name())); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+type byModTime []os.FileInfo
+
+func (b byModTime) Len() int      { return len(b) }
+func (b byModTime) Swap(i, j int) { b[i], b[j] = b[j], b[i] }
+func (b byModTime) Less(i, j int) bool {
+	if b[i].ModTime().Equal(b[j].ModTime()) {
+		return b[i].Name() < b[j].Name()
+	}
+	return b[i].ModTime().Before(b[j].ModTime())
+}
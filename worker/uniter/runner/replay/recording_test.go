@@ -0,0 +1,166 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package replay_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/exec"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+	jujuctesting "github.com/juju/juju/worker/uniter/runner/jujuc/testing"
+	"github.com/juju/juju/worker/uniter/runner/replay"
+)
+
+type RecordingSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&RecordingSuite{})
+
+func (s *RecordingSuite) TestRecord(c *gc.C) {
+	recorder := replay.NewRecorder()
+	recorder.Record(jujuc.Request{
+		CommandName: "config-get" + jujuc.CmdSuffix,
+		Args:        []string{"title"},
+	}, exec.ExecResponse{
+		Stdout: []byte("My Title\n"),
+	})
+	recorder.Record(jujuc.Request{
+		CommandName: "relation-set" + jujuc.CmdSuffix,
+		Args:        []string{"--file", "-"},
+		StdinSet:    true,
+		Stdin:       []byte("foo: bar\n"),
+	}, exec.ExecResponse{
+		Code:   1,
+		Stderr: []byte("error: boom\n"),
+	})
+	c.Assert(recorder.ToolCalls(), jc.DeepEquals, []replay.ToolCall{{
+		Command: "config-get",
+		Args:    []string{"title"},
+		Stdout:  "My Title\n",
+	}, {
+		Command: "relation-set",
+		Args:    []string{"--file", "-"},
+		Stdin:   "foo: bar\n",
+		Code:    1,
+		Stderr:  "error: boom\n",
+	}})
+}
+
+func (s *RecordingSuite) TestCapture(c *gc.C) {
+	stub := &testing.Stub{}
+	info := &jujuctesting.ContextInfo{}
+	info.Unit.Name = "wordpress/0"
+	info.Unit.ConfigSettings = charm.Settings{"title": "My Title"}
+	info.Leadership.LeaderSettings = map[string]string{"password": "sekrit"}
+	rel := info.Relations.SetNewRelation(1, "db", stub)
+	rel.UnitName = "wordpress/0"
+	info.Relations.SetRelated(1, "wordpress/0", jujuctesting.Settings{"host": "10.0.0.1"})
+	info.Relations.SetRelated(1, "mysql/0", jujuctesting.Settings{"user": "admin"})
+	ctx := info.Context(stub)
+
+	recorder := replay.NewRecorder()
+	recorder.Record(jujuc.Request{CommandName: "is-leader"}, exec.ExecResponse{Stdout: []byte("True\n")})
+	rec := recorder.Capture(ctx, "db-relation-changed", []string{"JUJU_UNIT_NAME=wordpress/0"}, errors.New("exit status 1"))
+
+	c.Assert(rec.Recorded.IsZero(), jc.IsFalse)
+	rec.Recorded = time.Time{}
+	c.Assert(rec, jc.DeepEquals, &replay.Recording{
+		Unit:           "wordpress/0",
+		Hook:           "db-relation-changed",
+		Error:          "exit status 1",
+		Environment:    []string{"JUJU_UNIT_NAME=wordpress/0"},
+		Config:         map[string]interface{}{"title": "My Title"},
+		LeaderSettings: map[string]string{"password": "sekrit"},
+		Relations: []replay.RelationRecording{{
+			Id:       1,
+			Name:     "db",
+			Settings: map[string]string{"host": "10.0.0.1"},
+			Remote: map[string]map[string]string{
+				"mysql/0":     {"user": "admin"},
+				"wordpress/0": {"host": "10.0.0.1"},
+			},
+		}},
+		ToolCalls: []replay.ToolCall{{
+			Command: "is-leader",
+			Stdout:  "True\n",
+		}},
+	})
+}
+
+func (s *RecordingSuite) TestCaptureIgnoresErrors(c *gc.C) {
+	stub := &testing.Stub{}
+	stub.SetErrors(nil, errors.New("no config"), errors.New("no leader settings"), errors.New("no relations"))
+	info := &jujuctesting.ContextInfo{}
+	info.Unit.Name = "wordpress/0"
+	ctx := info.Context(stub)
+
+	rec := replay.NewRecorder().Capture(ctx, "install", nil, nil)
+	c.Assert(rec.Hook, gc.Equals, "install")
+	c.Assert(rec.Error, gc.Equals, "")
+	c.Assert(rec.Config, gc.IsNil)
+	c.Assert(rec.LeaderSettings, gc.IsNil)
+	c.Assert(rec.Relations, gc.HasLen, 0)
+}
+
+func (s *RecordingSuite) TestWriteReadRecording(c *gc.C) {
+	dir := filepath.Join(c.MkDir(), "recordings")
+	rec := &replay.Recording{
+		Unit:           "wordpress/0",
+		Hook:           "install",
+		Recorded:       time.Date(2017, 3, 1, 12, 0, 0, 0, time.UTC),
+		Error:          "exit status 1",
+		Environment:    []string{"JUJU_UNIT_NAME=wordpress/0"},
+		Config:         map[string]interface{}{"title": "My Title"},
+		LeaderSettings: map[string]string{"password": "sekrit"},
+		ToolCalls: []replay.ToolCall{{
+			Command: "config-get",
+			Args:    []string{"title"},
+			Stdout:  "My Title\n",
+		}},
+	}
+	path, err := replay.WriteRecording(dir, rec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(path, gc.Equals, filepath.Join(dir, "install-20170301T120000.000000000.yaml"))
+
+	read, err := replay.ReadRecording(path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(read, jc.DeepEquals, rec)
+}
+
+func (s *RecordingSuite) TestReadRecordingInvalid(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "empty.yaml")
+	err := ioutil.WriteFile(path, []byte("unit: wordpress/0\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = replay.ReadRecording(path)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *RecordingSuite) TestWriteRecordingPrunes(c *gc.C) {
+	dir := c.MkDir()
+	t0 := time.Date(2017, 3, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 12; i++ {
+		recorded := t0.Add(time.Duration(i) * time.Second)
+		path, err := replay.WriteRecording(dir, &replay.Recording{
+			Hook:     fmt.Sprintf("hook-%02d", i),
+			Recorded: recorded,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(os.Chtimes(path, recorded, recorded), jc.ErrorIsNil)
+	}
+	paths, err := filepath.Glob(filepath.Join(dir, "*.yaml"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(paths, gc.HasLen, 10)
+	c.Assert(filepath.Base(paths[0]), gc.Matches, "hook-02-.*")
+}
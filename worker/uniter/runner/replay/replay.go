@@ -0,0 +1,143 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package replay
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+// Params holds the parameters for replaying a recorded hook.
+type Params struct {
+	// Recording is the recording of the hook to replay.
+	Recording *Recording
+
+	// CharmDir is the local directory holding the charm whose hook
+	// is replayed.
+	CharmDir string
+
+	// JujudPath is the path to the jujud executable, which acts as
+	// the hook tools when invoked via symlinks named for them.
+	JujudPath string
+
+	// Stdout and Stderr receive the output of the hook.
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+// Validate returns an error if the params are not valid.
+func (p Params) Validate() error {
+	if p.Recording == nil {
+		return errors.NotValidf("missing Recording")
+	}
+	if p.CharmDir == "" {
+		return errors.NotValidf("empty CharmDir")
+	}
+	if p.JujudPath == "" {
+		return errors.NotValidf("empty JujudPath")
+	}
+	return nil
+}
+
+// Run runs the recorded hook from the local charm directory, in the
+// recorded environment, with its hook tools answered from the recording.
+// It returns the error with which the hook failed, if any; a hook that
+// fails again has reproduced the recorded failure.
+func Run(p Params) error {
+	if err := p.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	rec := p.Recording
+	hookPath := filepath.Join(p.CharmDir, "hooks", rec.Hook)
+	if _, err := os.Stat(hookPath); err != nil {
+		return errors.Annotatef(err, "cannot find hook %q", rec.Hook)
+	}
+
+	tempDir, err := ioutil.TempDir("", "juju-replay-hook")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer os.RemoveAll(tempDir)
+	toolsDir := filepath.Join(tempDir, "tools")
+	if err := WriteToolSymlinks(toolsDir, p.JujudPath); err != nil {
+		return errors.Trace(err)
+	}
+
+	env := replayEnvironment(rec.Environment, map[string]string{
+		"JUJU_AGENT_SOCKET": filepath.Join(tempDir, "agent.socket"),
+		"JUJU_CHARM_DIR":    p.CharmDir,
+		"CHARM_DIR":         p.CharmDir,
+		"PATH":              toolsDir + string(os.PathListSeparator) + os.Getenv("PATH"),
+	})
+	contextId := environmentValue(rec.Environment, "JUJU_CONTEXT_ID")
+
+	replayer := NewReplayer(rec)
+	srv, err := NewServer(replayer, contextId, environmentValue(env, "JUJU_AGENT_SOCKET"))
+	if err != nil {
+		return errors.Annotate(err, "cannot start hook tool server")
+	}
+	go srv.Run()
+	defer srv.Close()
+
+	ps := exec.Command(hookPath)
+	ps.Env = env
+	ps.Dir = p.CharmDir
+	ps.Stdout = p.Stdout
+	ps.Stderr = p.Stderr
+	err = ps.Run()
+	for _, call := range replayer.Unreplayed() {
+		logger.Infof("recorded call to %s %q was not replayed", call.Command, call.Args)
+	}
+	return errors.Trace(err)
+}
+
+// WriteToolSymlinks creates dir, containing a symlink to jujud for each
+// of the hook tools.
+func WriteToolSymlinks(dir, jujudPath string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return errors.Trace(err)
+	}
+	for _, name := range jujuc.CommandNames() {
+		if err := os.Symlink(jujudPath, filepath.Join(dir, name)); err != nil {
+			return errors.Annotatef(err, "cannot create %q hook tool", name)
+		}
+	}
+	return nil
+}
+
+// replayEnvironment returns the recorded environment, with the values
+// of the supplied keys replaced.
+func replayEnvironment(recorded []string, replace map[string]string) []string {
+	var env []string
+	for _, kv := range recorded {
+		key := strings.SplitN(kv, "=", 2)[0]
+		if _, ok := replace[key]; ok {
+			continue
+		}
+		env = append(env, kv)
+	}
+	for key, value := range replace {
+		env = append(env, key+"="+value)
+	}
+	return env
+}
+
+// environmentValue returns the value of key in env, which holds
+// "key=value" pairs.
+func environmentValue(env []string, key string) string {
+	for _, kv := range env {
+		if strings.HasPrefix(kv, key+"=") {
+			return kv[len(key)+1:]
+		}
+	}
+	return ""
+}
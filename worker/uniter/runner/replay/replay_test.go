@@ -0,0 +1,98 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package replay_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/replay"
+)
+
+type ReplaySuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ReplaySuite{})
+
+func (s *ReplaySuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	if runtime.GOOS == "windows" {
+		c.Skip("replaying hooks is not supported on windows")
+	}
+}
+
+func (s *ReplaySuite) TestValidate(c *gc.C) {
+	for i, test := range []struct {
+		params replay.Params
+		err    string
+	}{{
+		params: replay.Params{CharmDir: "/charm", JujudPath: "/jujud"},
+		err:    "missing Recording not valid",
+	}, {
+		params: replay.Params{Recording: &replay.Recording{}, JujudPath: "/jujud"},
+		err:    "empty CharmDir not valid",
+	}, {
+		params: replay.Params{Recording: &replay.Recording{}, CharmDir: "/charm"},
+		err:    "empty JujudPath not valid",
+	}} {
+		c.Logf("test %d", i)
+		c.Check(test.params.Validate(), gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ReplaySuite) TestRun(c *gc.C) {
+	charmDir := c.MkDir()
+	writeHook(c, charmDir, "config-changed", `#!/bin/sh
+echo $JUJU_CONTEXT_ID $JUJU_UNIT_NAME
+echo $CHARM_DIR >&2
+for tool in config-get relation-get status-set; do
+	[ -x "$(command -v $tool)" ] || exit 2
+done
+exit 1
+`)
+	var stdout, stderr bytes.Buffer
+	err := replay.Run(replay.Params{
+		Recording: &replay.Recording{
+			Hook: "config-changed",
+			Environment: []string{
+				"JUJU_CONTEXT_ID=wordpress/0-config-changed-1",
+				"JUJU_UNIT_NAME=wordpress/0",
+				"JUJU_CHARM_DIR=/var/lib/juju/agents/unit-wordpress-0/charm",
+				"CHARM_DIR=/var/lib/juju/agents/unit-wordpress-0/charm",
+			},
+		},
+		CharmDir:  charmDir,
+		JujudPath: "/bin/true",
+		Stdout:    &stdout,
+		Stderr:    &stderr,
+	})
+	c.Assert(err, gc.ErrorMatches, "exit status 1")
+	c.Assert(stdout.String(), gc.Equals, "wordpress/0-config-changed-1 wordpress/0\n")
+	c.Assert(stderr.String(), gc.Equals, charmDir+"\n")
+}
+
+func (s *ReplaySuite) TestRunMissingHook(c *gc.C) {
+	err := replay.Run(replay.Params{
+		Recording: &replay.Recording{Hook: "install"},
+		CharmDir:  c.MkDir(),
+		JujudPath: "/bin/true",
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot find hook "install": .*`)
+}
+
+func writeHook(c *gc.C, charmDir, name, script string) {
+	hooksDir := filepath.Join(charmDir, "hooks")
+	err := os.MkdirAll(hooksDir, 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(filepath.Join(hooksDir, name), []byte(script), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -0,0 +1,180 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package replay
+
+import (
+	"fmt"
+	"net"
+	"net/rpc"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/exec"
+
+	"github.com/juju/juju/juju/sockets"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+// Replayer answers hook tool invocations from a Recording.
+type Replayer struct {
+	mu   sync.Mutex
+	rec  *Recording
+	used []bool
+}
+
+// NewReplayer returns a Replayer that answers from the supplied recording.
+func NewReplayer(rec *Recording) *Replayer {
+	return &Replayer{
+		rec:  rec,
+		used: make([]bool, len(rec.ToolCalls)),
+	}
+}
+
+// Response returns the recorded response to the named hook tool when
+// invoked with the supplied arguments. Identical invocations are given
+// the responses recorded for them in turn; once those are exhausted,
+// the last of them is repeated. An error satisfying errors.IsNotFound
+// is returned if the invocation was never recorded.
+func (r *Replayer) Response(command string, args []string) (exec.ExecResponse, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	last := -1
+	for i, call := range r.rec.ToolCalls {
+		if call.Command != command || !argsEqual(call.Args, args) {
+			continue
+		}
+		last = i
+		if !r.used[i] {
+			break
+		}
+	}
+	if last == -1 {
+		return exec.ExecResponse{}, errors.NotFoundf("recorded response to %s %q", command, args)
+	}
+	r.used[last] = true
+	call := r.rec.ToolCalls[last]
+	return exec.ExecResponse{
+		Code:   call.Code,
+		Stdout: []byte(call.Stdout),
+		Stderr: []byte(call.Stderr),
+	}, nil
+}
+
+// Unreplayed returns the recorded hook tool invocations that have not
+// been replayed.
+func (r *Replayer) Unreplayed() []ToolCall {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var result []ToolCall
+	for i, call := range r.rec.ToolCalls {
+		if !r.used[i] {
+			result = append(result, call)
+		}
+	}
+	return result
+}
+
+func argsEqual(a, b []string) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// Jujuc answers hook tool requests from a Replayer, in the form
+// required by net/rpc. It is registered under the same name as
+// jujuc.Jujuc, so that the usual hook tools can talk to it.
+type Jujuc struct {
+	replayer  *Replayer
+	contextId string
+}
+
+// Main fills in resp with the recorded response to req. A request that
+// was never recorded fails as the hook tool would, with exit code 1.
+func (j *Jujuc) Main(req jujuc.Request, resp *exec.ExecResponse) error {
+	if req.ContextId != j.contextId {
+		return errors.Errorf("bad request: expected context id %q, got %q", j.contextId, req.ContextId)
+	}
+	command := strings.TrimSuffix(req.CommandName, jujuc.CmdSuffix)
+	recorded, err := j.replayer.Response(command, req.Args)
+	if errors.IsNotFound(err) {
+		logger.Warningf("%v", err)
+		resp.Code = 1
+		resp.Stderr = []byte(fmt.Sprintf("error: %v\n", err))
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	*resp = recorded
+	return nil
+}
+
+// Server serves hook tool invocations from a Replayer via a unix
+// domain socket.
+type Server struct {
+	listener net.Listener
+	server   *rpc.Server
+	closing  chan struct{}
+	closed   chan struct{}
+	wg       sync.WaitGroup
+}
+
+// NewServer returns a server bound to socketPath, which answers hook
+// tool invocations made with the supplied context id from the replayer.
+// It will not actually do so until Run is called.
+func NewServer(replayer *Replayer, contextId, socketPath string) (*Server, error) {
+	server := rpc.NewServer()
+	handler := &Jujuc{replayer: replayer, contextId: contextId}
+	if err := server.RegisterName("Jujuc", handler); err != nil {
+		return nil, errors.Trace(err)
+	}
+	listener, err := sockets.Listen(socketPath)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &Server{
+		listener: listener,
+		server:   server,
+		closing:  make(chan struct{}),
+		closed:   make(chan struct{}),
+	}, nil
+}
+
+// Run accepts new connections until Close is called or an error is
+// encountered, and then blocks until all existing connections have
+// been closed.
+func (s *Server) Run() error {
+	var err error
+	for {
+		var conn net.Conn
+		conn, err = s.listener.Accept()
+		if err != nil {
+			break
+		}
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.server.ServeConn(conn)
+		}()
+	}
+	select {
+	case <-s.closing:
+		// The error is the result of the listener being closed.
+		err = nil
+	default:
+	}
+	s.wg.Wait()
+	close(s.closed)
+	return err
+}
+
+// Close stops accepting connections, and blocks until all existing
+// connections have been closed.
+func (s *Server) Close() {
+	close(s.closing)
+	s.listener.Close()
+	<-s.closed
+}
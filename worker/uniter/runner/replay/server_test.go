@@ -0,0 +1,116 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package replay_test
+
+import (
+	"path/filepath"
+	"runtime"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/exec"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/juju/sockets"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+	"github.com/juju/juju/worker/uniter/runner/replay"
+)
+
+type ServerSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ServerSuite{})
+
+var testRecording = &replay.Recording{
+	Hook: "config-changed",
+	ToolCalls: []replay.ToolCall{{
+		Command: "config-get",
+		Args:    []string{"title"},
+		Stdout:  "first\n",
+	}, {
+		Command: "status-set",
+		Args:    []string{"active"},
+	}, {
+		Command: "config-get",
+		Args:    []string{"title"},
+		Stdout:  "second\n",
+	}, {
+		Command: "is-leader",
+		Code:    1,
+		Stderr:  "error: cannot determine leadership\n",
+	}},
+}
+
+func (s *ServerSuite) TestResponse(c *gc.C) {
+	replayer := replay.NewReplayer(testRecording)
+	for i, expect := range []string{"first\n", "second\n", "second\n"} {
+		c.Logf("call %d", i)
+		resp, err := replayer.Response("config-get", []string{"title"})
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(resp.Code, gc.Equals, 0)
+		c.Assert(string(resp.Stdout), gc.Equals, expect)
+	}
+	resp, err := replayer.Response("is-leader", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resp.Code, gc.Equals, 1)
+	c.Assert(string(resp.Stderr), gc.Equals, "error: cannot determine leadership\n")
+
+	c.Assert(replayer.Unreplayed(), jc.DeepEquals, []replay.ToolCall{{
+		Command: "status-set",
+		Args:    []string{"active"},
+	}})
+}
+
+func (s *ServerSuite) TestResponseNotRecorded(c *gc.C) {
+	replayer := replay.NewReplayer(testRecording)
+	_, err := replayer.Response("config-get", []string{"colour"})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `recorded response to config-get \["colour"\] not found`)
+}
+
+func (s *ServerSuite) TestServer(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("replaying hooks is not supported on windows")
+	}
+	socketPath := filepath.Join(c.MkDir(), "agent.socket")
+	srv, err := replay.NewServer(replay.NewReplayer(testRecording), "ctx-id", socketPath)
+	c.Assert(err, jc.ErrorIsNil)
+	done := make(chan error, 1)
+	go func() {
+		done <- srv.Run()
+	}()
+	defer func() {
+		srv.Close()
+		c.Assert(<-done, jc.ErrorIsNil)
+	}()
+
+	call := func(contextId, command string, args ...string) (exec.ExecResponse, error) {
+		client, err := sockets.Dial(socketPath)
+		c.Assert(err, jc.ErrorIsNil)
+		defer client.Close()
+		var resp exec.ExecResponse
+		err = client.Call("Jujuc.Main", jujuc.Request{
+			ContextId:   contextId,
+			Dir:         "/",
+			CommandName: command + jujuc.CmdSuffix,
+			Args:        args,
+		}, &resp)
+		return resp, err
+	}
+
+	resp, err := call("ctx-id", "config-get", "title")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resp.Code, gc.Equals, 0)
+	c.Assert(string(resp.Stdout), gc.Equals, "first\n")
+
+	resp, err = call("ctx-id", "relation-get", "-r", "db:1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resp.Code, gc.Equals, 1)
+	c.Assert(string(resp.Stderr), gc.Equals, "error: recorded response to relation-get [\"-r\" \"db:1\"] not found\n")
+
+	_, err = call("other-id", "config-get", "title")
+	c.Assert(err, gc.ErrorMatches, `bad request: expected context id "ctx-id", got "other-id"`)
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/juju/juju/worker/uniter/runner/context"
 	"github.com/juju/juju/worker/uniter/runner/debug"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
+	"github.com/juju/juju/worker/uniter/runner/replay"
 	jujuos "github.com/juju/utils/os"
 )
 
@@ -47,6 +48,8 @@ type Runner interface {
 type Context interface {
 	jujuc.Context
 	Id() string
+	HookTimeout() time.Duration
+	RecordFailedHooks() bool
 	HookVars(paths context.Paths) ([]string, error)
 	ActionData() (*context.ActionData, error)
 	SetProcess(process context.HookProcess)
@@ -81,7 +84,7 @@ func (runner *runner) RunCommands(commands string) (*utilexec.ExecResponse, erro
 // runCommandsWithTimeout is a helper to abstract common code between run commands and
 // juju-run as an action
 func (runner *runner) runCommandsWithTimeout(commands string, timeout time.Duration, clock clock.Clock) (*utilexec.ExecResponse, error) {
-	srv, err := runner.startJujucServer()
+	srv, err := runner.startJujucServer(nil)
 	if err != nil {
 		return nil, err
 	}
@@ -204,7 +207,13 @@ func (runner *runner) RunHook(hookName string) error {
 }
 
 func (runner *runner) runCharmHookWithLocation(hookName, charmLocation string) error {
-	srv, err := runner.startJujucServer()
+	var recorder *replay.Recorder
+	var record jujuc.RecordFunc
+	if charmLocation == "hooks" && runner.context.RecordFailedHooks() {
+		recorder = replay.NewRecorder()
+		record = recorder.Record
+	}
+	srv, err := runner.startJujucServer(record)
 	if err != nil {
 		return err
 	}
@@ -228,9 +237,25 @@ func (runner *runner) runCharmHookWithLocation(hookName, charmLocation string) e
 	} else {
 		err = runner.runCharmHook(hookName, env, charmLocation)
 	}
+	if err != nil && recorder != nil && !context.IsMissingHookError(err) {
+		runner.saveRecording(recorder, hookName, env, err)
+	}
 	return runner.context.Flush(hookName, err)
 }
 
+// saveRecording records the context in which the named hook failed, so
+// that it can be replayed offline. Failures are logged rather than
+// returned, so as not to mask the hook's own error.
+func (runner *runner) saveRecording(recorder *replay.Recorder, hookName string, env []string, hookErr error) {
+	rec := recorder.Capture(runner.context, hookName, env, hookErr)
+	path, err := replay.WriteRecording(runner.paths.GetHookRecordingsDir(), rec)
+	if err != nil {
+		logger.Errorf("cannot record failed hook %q: %v", hookName, err)
+		return
+	}
+	logger.Infof("recorded failed hook %q in %s", hookName, path)
+}
+
 func (runner *runner) runCharmHook(hookName string, env []string, charmLocation string) error {
 	charmDir := runner.paths.GetCharmDir()
 	hook, err := searchHook(charmDir, filepath.Join(charmLocation, hookName))
@@ -241,6 +266,12 @@ func (runner *runner) runCharmHook(hookName string, env []string, charmLocation
 	ps := exec.Command(hookCmd[0], hookCmd[1:]...)
 	ps.Env = env
 	ps.Dir = charmDir
+	timeout := runner.context.HookTimeout()
+	if timeout > 0 {
+		// Run the hook in its own process group, so that any
+		// processes it spawns are killed with it on timeout.
+		setProcessGroup(ps)
+	}
 	outReader, outWriter, err := os.Pipe()
 	if err != nil {
 		return errors.Errorf("cannot make logging pipe: %v", err)
@@ -259,13 +290,37 @@ func (runner *runner) runCharmHook(hookName string, env []string, charmLocation
 		// Record the *os.Process of the hook
 		runner.context.SetProcess(hookProcess{ps.Process})
 		// Block until execution finishes
-		err = ps.Wait()
+		err = waitHookProcess(hookName, ps, timeout, clock.WallClock)
 	}
 	hookLogger.stop()
 	return errors.Trace(err)
 }
 
-func (runner *runner) startJujucServer() (*jujuc.Server, error) {
+// waitHookProcess blocks until the supplied hook process exits. If the
+// timeout is non-zero and the hook runs for longer than that, the hook's
+// process group is killed and a hook timed out error is returned.
+func waitHookProcess(hookName string, ps *exec.Cmd, timeout time.Duration, clock clock.Clock) error {
+	if timeout <= 0 {
+		return ps.Wait()
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- ps.Wait()
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-clock.After(timeout):
+	}
+	logger.Errorf("hook %q timed out after %v, killing it", hookName, timeout)
+	if err := killProcessGroup(ps.Process); err != nil {
+		logger.Errorf("cannot kill hook %q: %v", hookName, err)
+	}
+	<-done
+	return context.NewHookTimedOutError(hookName, timeout)
+}
+
+func (runner *runner) startJujucServer(record jujuc.RecordFunc) (*jujuc.Server, error) {
 	// Prepare server.
 	getCmd := func(ctxId, cmdName string) (cmd.Command, error) {
 		if ctxId != runner.context.Id() {
@@ -273,7 +328,7 @@ func (runner *runner) startJujucServer() (*jujuc.Server, error) {
 		}
 		return jujuc.NewCommand(runner.context, cmdName)
 	}
-	srv, err := jujuc.NewServer(getCmd, runner.paths.GetJujucSocket())
+	srv, err := jujuc.NewRecordingServer(getCmd, runner.paths.GetJujucSocket(), record)
 	if err != nil {
 		return nil, err
 	}
@@ -17,11 +17,13 @@ import (
 	"github.com/juju/utils/exec"
 	"github.com/juju/utils/proxy"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/charm.v6-unstable/hooks"
 
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/runner"
 	"github.com/juju/juju/worker/uniter/runner/context"
+	"github.com/juju/juju/worker/uniter/runner/replay"
 	runnertesting "github.com/juju/juju/worker/uniter/runner/testing"
 )
 
@@ -155,12 +157,34 @@ type MockContext struct {
 	flushBadge      string
 	flushFailure    error
 	flushResult     error
+	hookTimeout     time.Duration
+	recordHooks     bool
 }
 
 func (ctx *MockContext) UnitName() string {
 	return "some-unit/999"
 }
 
+func (ctx *MockContext) HookTimeout() time.Duration {
+	return ctx.hookTimeout
+}
+
+func (ctx *MockContext) RecordFailedHooks() bool {
+	return ctx.recordHooks
+}
+
+func (ctx *MockContext) ConfigSettings() (charm.Settings, error) {
+	return charm.Settings{"blog-title": "My Title"}, nil
+}
+
+func (ctx *MockContext) LeaderSettings() (map[string]string, error) {
+	return nil, errors.New("not the leader")
+}
+
+func (ctx *MockContext) RelationIds() ([]int, error) {
+	return nil, nil
+}
+
 func (ctx *MockContext) HookVars(paths context.Paths) ([]string, error) {
 	return []string{"VAR=value"}, nil
 }
@@ -252,6 +276,77 @@ func (s *RunMockContextSuite) TestRunHookFlushFailure(c *gc.C) {
 	s.assertRecordedPid(c, ctx.expectPid)
 }
 
+func (s *RunMockContextSuite) TestRunHookRecordsFailure(c *gc.C) {
+	ctx := &MockContext{
+		recordHooks: true,
+	}
+	makeCharm(c, hookSpec{
+		dir:  "hooks",
+		name: hookName,
+		perm: 0700,
+		code: 123,
+	}, s.paths.GetCharmDir())
+	err := runner.NewRunner(ctx, s.paths).RunHook("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushFailure, gc.ErrorMatches, "exit status 123")
+
+	paths, err := filepath.Glob(filepath.Join(s.paths.GetHookRecordingsDir(), "*.yaml"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(paths, gc.HasLen, 1)
+	rec, err := replay.ReadRecording(paths[0])
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(rec.Unit, gc.Equals, "some-unit/999")
+	c.Check(rec.Hook, gc.Equals, "something-happened")
+	c.Check(rec.Error, gc.Equals, "exit status 123")
+	c.Check(rec.Environment, jc.DeepEquals, []string{"VAR=value"})
+	c.Check(rec.Config, jc.DeepEquals, map[string]interface{}{"blog-title": "My Title"})
+	c.Check(rec.LeaderSettings, gc.HasLen, 0)
+}
+
+func (s *RunMockContextSuite) TestRunHookSuccessNotRecorded(c *gc.C) {
+	ctx := &MockContext{
+		recordHooks: true,
+	}
+	makeCharm(c, hookSpec{
+		dir:  "hooks",
+		name: hookName,
+		perm: 0700,
+	}, s.paths.GetCharmDir())
+	err := runner.NewRunner(ctx, s.paths).RunHook("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushFailure, gc.IsNil)
+	paths, err := filepath.Glob(filepath.Join(s.paths.GetHookRecordingsDir(), "*.yaml"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(paths, gc.HasLen, 0)
+}
+
+func (s *RunMockContextSuite) TestRunHookTimeout(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("hook process groups are not supported on windows")
+	}
+	ctx := &MockContext{
+		hookTimeout: 100 * time.Millisecond,
+	}
+	makeCharm(c, hookSpec{
+		dir:        "hooks",
+		name:       hookName,
+		perm:       0700,
+		background: "not printed",
+		sleep:      10,
+	}, s.paths.GetCharmDir())
+	t0 := time.Now()
+	err := runner.NewRunner(ctx, s.paths).RunHook("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	if time.Now().Sub(t0) > 5*time.Second {
+		c.Errorf("timed out hook was not killed")
+	}
+	c.Assert(ctx.flushBadge, gc.Equals, "something-happened")
+	c.Assert(context.IsHookTimedOutError(errors.Cause(ctx.flushFailure)), jc.IsTrue)
+	c.Assert(ctx.flushFailure, gc.ErrorMatches, `hook "something-happened" timed out after 100ms`)
+	s.assertRecordedPid(c, ctx.expectPid)
+	c.Assert(processExists(ctx.expectPid), jc.IsFalse)
+}
+
 func (s *RunMockContextSuite) TestRunActionFlushSuccess(c *gc.C) {
 	expectErr := errors.New("pew pew pew")
 	ctx := &MockContext{
@@ -28,6 +28,7 @@ type RealPaths struct {
 	charm         string
 	socket        string
 	metricsspool  string
+	recordings    string
 	componentDirs map[string]string
 	fops          fops
 }
@@ -46,6 +47,7 @@ func NewRealPaths(c *gc.C) RealPaths {
 		charm:         c.MkDir(),
 		socket:        osDependentSockPath(c),
 		metricsspool:  c.MkDir(),
+		recordings:    c.MkDir(),
 		componentDirs: make(map[string]string),
 		fops:          c,
 	}
@@ -55,6 +57,10 @@ func (p RealPaths) GetMetricsSpoolDir() string {
 	return p.metricsspool
 }
 
+func (p RealPaths) GetHookRecordingsDir() string {
+	return p.recordings
+}
+
 func (p RealPaths) GetToolsDir() string {
 	return p.tools
 }
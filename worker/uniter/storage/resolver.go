@@ -136,8 +136,22 @@ func (s *storageResolver) nextHookOp(
 		storageAttachment, ok := s.storage.storageAttachments[tag]
 		if ok && storageAttachment.attached {
 			// Once the storage is attached, we only care about
-			// lifecycle state changes.
-			return nil, resolver.ErrNoOperation
+			// lifecycle state changes, and the storage growing.
+			if snap.Size <= storageAttachment.size {
+				return nil, resolver.ErrNoOperation
+			}
+			if storageAttachment.size == 0 {
+				// The storage was attached before its size
+				// was recorded, so there is no way to tell
+				// whether it has grown; record it for next time.
+				if err := storageAttachment.recordSize(snap.Size); err != nil {
+					return nil, errors.Trace(err)
+				}
+				return nil, resolver.ErrNoOperation
+			}
+			hookInfo.Kind = hook.StorageResized
+			hookInfo.StorageSize = snap.Size
+			break
 		}
 		// The storage-attached hook has not been committed, so add the
 		// storage to the pending set.
@@ -151,6 +165,7 @@ func (s *storageResolver) nextHookOp(
 		// The storage is alive, but we haven't previously run the
 		// "storage-attached" hook. Do so now.
 		hookInfo.Kind = hooks.StorageAttached
+		hookInfo.StorageSize = snap.Size
 	case params.Dying:
 		storageAttachment, ok := s.storage.storageAttachments[tag]
 		if !ok || !storageAttachment.attached {
@@ -25,6 +25,10 @@ type state struct {
 	// attached records the uniter's knowledge of the
 	// storage attachment state.
 	attached bool
+
+	// size records the size of the storage, in MiB, last
+	// reported to the charm. It is zero if not known.
+	size uint64
 }
 
 // ValidateHook returns an error if the supplied hook.Info does not represent
@@ -41,7 +45,7 @@ func (s *state) ValidateHook(hi hook.Info) (err error) {
 		if s.attached {
 			return errors.New("storage already attached")
 		}
-	case hooks.StorageDetaching:
+	case hooks.StorageDetaching, hook.StorageResized:
 		if !s.attached {
 			return errors.New("storage not attached")
 		}
@@ -85,6 +89,7 @@ func readStateFile(dirPath string, tag names.StorageTag) (d *stateFile, err erro
 		return nil, errors.Errorf("invalid storage state file %q: missing 'attached'", d.path)
 	}
 	d.state.attached = *info.Attached
+	d.state.size = info.Size
 	return d, nil
 }
 
@@ -129,13 +134,25 @@ func (d *stateFile) CommitHook(hi hook.Info) (err error) {
 	if hi.Kind == hooks.StorageDetaching {
 		return d.Remove()
 	}
+	return d.write(hi.StorageSize)
+}
+
+// recordSize writes the size of the attached storage to disk, without
+// running a hook. It is used to record the size of storage attached
+// before sizes were recorded, so that later resizes can be observed.
+func (d *stateFile) recordSize(size uint64) error {
+	return errors.Annotatef(d.write(size), "failed to record size of storage %q", d.storage.Id())
+}
+
+func (d *stateFile) write(size uint64) error {
 	attached := true
-	di := diskInfo{&attached}
+	di := diskInfo{Attached: &attached, Size: size}
 	if err := utils.WriteYaml(d.path, &di); err != nil {
 		return err
 	}
 	// If write was successful, update own state.
 	d.state.attached = true
+	d.state.size = size
 	return nil
 }
 
@@ -151,5 +168,6 @@ func (d *stateFile) Remove() error {
 
 // diskInfo defines the storage attachment data serialization.
 type diskInfo struct {
-	Attached *bool `yaml:"attached,omitempty"`
+	Attached *bool  `yaml:"attached,omitempty"`
+	Size     uint64 `yaml:"size,omitempty"`
 }
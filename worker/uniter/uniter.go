@@ -576,5 +576,8 @@ func (u *Uniter) reportHookError(hookInfo hook.Info) error {
 	}
 	statusData["hook"] = hookName
 	statusMessage := fmt.Sprintf("hook failed: %q", hookName)
+	if u.operationExecutor.State().HookTimedOut {
+		statusMessage = fmt.Sprintf("hook timed out: %q", hookName)
+	}
 	return setAgentStatus(u, status.Error, statusMessage, statusData)
 }